@@ -259,35 +259,16 @@ func (c *client) do(req *http.Request) (*http.Response, error) {
 	return c.httpClient.Do(req)
 }
 
-// ProxyTCP connects to the given port on the remote buildlet.
-// The buildlet client must currently be a gomote client (RemoteName != "")
-// and the target type must be a VM type running on GCE. This was primarily
-// created for RDP to Windows machines, but it might get reused for other
-// purposes in the future.
+// ProxyTCP connects to the given TCP port on the buildlet's loopback
+// interface. This was primarily created for RDP to Windows machines,
+// and is now used by gomote to forward ports.
 func (c *client) ProxyTCP(port int) (io.ReadWriteCloser, error) {
-	if c.RemoteName() == "" {
-		return nil, errors.New("ProxyTCP currently only supports gomote-created buildlets")
-	}
 	req, err := http.NewRequest("POST", c.URL()+"/tcpproxy", nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Add("X-Target-Port", fmt.Sprint(port))
-	res, err := c.do(req)
-	if err != nil {
-		return nil, err
-	}
-	if res.StatusCode != http.StatusSwitchingProtocols {
-		slurp, _ := io.ReadAll(io.LimitReader(res.Body, 4<<10))
-		res.Body.Close()
-		return nil, fmt.Errorf("wanted 101 Switching Protocols; unexpected response: %v, %q", res.Status, slurp)
-	}
-	rwc, ok := res.Body.(io.ReadWriteCloser)
-	if !ok {
-		res.Body.Close()
-		return nil, fmt.Errorf("tcpproxy response was not a Writer")
-	}
-	return rwc, nil
+	return c.upgrade(req)
 }
 
 // ProxyRoundTripper returns a RoundTripper that sends HTTP requests directly
@@ -467,10 +448,14 @@ func (c *client) GetTar(ctx context.Context, dir string) (io.ReadCloser, error)
 
 // ExecOpts are options for a remote command invocation.
 type ExecOpts struct {
-	// Output is the output of stdout and stderr.
-	// If nil, the output is discarded.
+	// Output is the output of stdout, and of stderr unless Stderr
+	// is set. If nil, the output is discarded.
 	Output io.Writer
 
+	// Stderr, if non-nil, receives the output of stderr separately
+	// from Output.
+	Stderr io.Writer
+
 	// Dir is the directory from which to execute the command,
 	// as an absolute or relative path using the buildlet's native
 	// path separator, or a slash-separated relative path.
@@ -512,8 +497,74 @@ type ExecOpts struct {
 	// response from the buildlet, but before the output begins
 	// writing to Output.
 	OnStartExec func()
+
+	// OnExit is an optional hook that runs with how the command
+	// ended, once it has. It's not run if the buildlet is too old
+	// to report it.
+	OnExit func(*ExecResult)
+
+	// Limits, if non-nil, limits the resources the command may use.
+	// Buildlets older than version 33 ignore it. A command with a
+	// disk limit needs the buildlet to itself: while it runs, every
+	// other command fails to start with ErrExecBusy, as it does if
+	// other commands are running when it starts.
+	Limits *ExecLimits
 }
 
+// ErrExecBusy is returned by Exec and StartExec when the buildlet
+// refuses to start a command because it would run alongside another
+// and one of them has a disk limit. Retrying once the other command
+// is done succeeds.
+var ErrExecBusy = errors.New("buildlet is busy running a command with a disk limit")
+
+// ExecLimits are limits on the resources a command run on a buildlet
+// may use. Zero values mean no limit.
+//
+// CPU, Memory, Processes and NoNetwork are only supported by buildlets
+// on Linux with cgroup v2, which refuse to run commands that set them
+// if they can't enforce them, as do buildlets on other systems.
+//
+// When a command that has limits exits, the processes it left behind
+// are killed.
+type ExecLimits struct {
+	// CPU is how many CPUs the command may use, such as 1.5. The
+	// command is throttled rather than killed when it exceeds it.
+	CPU float64 `json:"cpu,omitempty"`
+
+	// Memory is the number of bytes of memory the command may use,
+	// including the page cache and without swapping.
+	Memory int64 `json:"memory,omitempty"`
+
+	// WallTime is how long the command may run.
+	WallTime time.Duration `json:"wallTime,omitempty"`
+
+	// Processes is how many processes and threads the command may
+	// have at once. Creating more fails.
+	Processes int `json:"processes,omitempty"`
+
+	// Disk is how many bytes the files in the buildlet's work
+	// directory may grow by while the command runs. It's checked
+	// every few seconds, so the command may exceed it briefly.
+	// The limit is best-effort: it applies to the whole work
+	// directory, so a command with a disk limit runs alone, and
+	// other files written to the buildlet while it runs count
+	// against it. Running it while another command runs, or running
+	// another command while it runs, fails.
+	Disk int64 `json:"disk,omitempty"`
+
+	// NoNetwork runs the command in a new network namespace, where
+	// only the loopback interface is available.
+	NoNetwork bool `json:"noNetwork,omitempty"`
+}
+
+// The limits of ExecLimits reported by ExecResult.LimitExceeded.
+const (
+	LimitMemory    = "memory"
+	LimitWallTime  = "wall time"
+	LimitProcesses = "processes"
+	LimitDisk      = "disk"
+)
+
 // ErrTimeout is a sentinel error that represents that waiting
 // for a command to complete has exceeded the given timeout.
 var ErrTimeout = errors.New("buildlet: timeout waiting for command to complete")
@@ -533,24 +584,9 @@ var ErrTimeout = errors.New("buildlet: timeout waiting for command to complete")
 // If the context's deadline is exceeded while waiting for the command
 // to complete, the returned execErr is ErrTimeout.
 func (c *client) Exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr, execErr error) {
-	var mode string
-	if opts.SystemLevel {
-		mode = "sys"
-	}
-	path := opts.Path
-	if len(path) == 0 && path != nil {
-		// url.Values doesn't distinguish between a nil slice and
-		// a non-nil zero-length slice, so use this sentinel value.
-		path = []string{"$EMPTY"}
-	}
-	form := url.Values{
-		"cmd":    {cmd},
-		"mode":   {mode},
-		"dir":    {opts.Dir},
-		"cmdArg": opts.Args,
-		"env":    opts.ExtraEnv,
-		"path":   path,
-		"debug":  {fmt.Sprint(opts.Debug)},
+	form := execForm(cmd, opts)
+	if opts.Stderr != nil {
+		form.Set("stderr", "separate")
 	}
 	req, err := http.NewRequest("POST", c.URL()+"/exec", strings.NewReader(form.Encode()))
 	if err != nil {
@@ -574,6 +610,9 @@ func (c *client) Exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr
 	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
 		slurp, _ := io.ReadAll(io.LimitReader(res.Body, 4<<10))
+		if res.StatusCode == http.StatusConflict {
+			return nil, fmt.Errorf("%w: %s", ErrExecBusy, strings.TrimSpace(string(slurp)))
+		}
 		return nil, fmt.Errorf("buildlet: HTTP status %v: %s", res.Status, slurp)
 	}
 	condRun(opts.OnStartExec)
@@ -588,7 +627,14 @@ func (c *client) Exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr
 		if out == nil {
 			out = io.Discard
 		}
-		if _, err := io.Copy(out, res.Body); err != nil {
+		var err error
+		if res.Header.Get("Content-Type") == ExecFramesContentType {
+			// The buildlet is keeping stdout and stderr apart.
+			err = copyExecFrames(out, opts.Stderr, res.Body)
+		} else {
+			_, err = io.Copy(out, res.Body)
+		}
+		if err != nil {
 			resc <- errs{execErr: fmt.Errorf("error copying response: %w", err)}
 			return
 		}
@@ -603,6 +649,14 @@ func (c *client) Exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr
 			resc <- errs{execErr: errors.New("missing Process-State trailer from HTTP response; buildlet built with old (<= 1.4) Go?")}
 			return
 		}
+		if v := res.Trailer.Get("Process-Result"); v != "" && opts.OnExit != nil {
+			result := new(ExecResult)
+			if err := json.Unmarshal([]byte(v), result); err != nil {
+				resc <- errs{execErr: fmt.Errorf("bad Process-Result trailer: %w", err)}
+				return
+			}
+			opts.OnExit(result)
+		}
 		if state != "ok" {
 			resc <- errs{remoteErr: errors.New(state)}
 		} else {
@@ -225,3 +225,25 @@ func (c deadlineOnDemandContext) Err() error {
 		return context.DeadlineExceeded
 	}
 }
+
+// Test that SyncManifest reports ErrSyncUnsupported for buildlets
+// that predate the sync protocol.
+func TestSyncManifestUnsupported(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(Status{})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("unable to parse http server url %s", err)
+	}
+	cl := NewClient(u.Host, NoKeyPair)
+	defer cl.Close()
+
+	err = cl.SyncManifest(context.Background(), "go", SyncManifestOpts{}, func(SyncFile) {})
+	if !errors.Is(err, ErrSyncUnsupported) {
+		t.Errorf("cl.SyncManifest error = %v; want %v", err, ErrSyncUnsupported)
+	}
+}
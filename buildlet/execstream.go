@@ -0,0 +1,402 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildlet
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The buildlet's /exec-stream handler runs a command like /exec, but
+// upgrades the HTTP connection to a bidirectional stream of frames, so
+// that the client can write to the command's standard input, resize its
+// terminal and send it signals while it runs.
+//
+// Each frame is a one-byte ExecFrameType, a four-byte big-endian payload
+// length and the payload. The client sends stdin, close-stdin, resize
+// and signal frames; the buildlet sends stdout and stderr frames,
+// followed by one exit frame once the command has exited.
+
+// An ExecFrameType is the type of a frame in the /exec-stream protocol.
+type ExecFrameType byte
+
+const (
+	ExecFrameStdin      ExecFrameType = 1 // payload is data for stdin
+	ExecFrameCloseStdin ExecFrameType = 2 // empty payload
+	ExecFrameResize     ExecFrameType = 3 // payload is rows and columns, as big-endian uint16s
+	ExecFrameSignal     ExecFrameType = 4 // payload is a signal name, such as "INT"
+	ExecFrameStdout     ExecFrameType = 5 // payload is data from stdout
+	ExecFrameStderr     ExecFrameType = 6 // payload is data from stderr
+	ExecFrameExit       ExecFrameType = 7 // payload is a JSON ExecResult
+)
+
+// ExecFramesContentType is the Content-Type of an /exec response
+// that keeps the command's stdout and stderr apart, as requested by
+// the form value stderr=separate. The body is a series of stdout and
+// stderr frames of the /exec-stream protocol.
+const ExecFramesContentType = "application/x-buildlet-exec-frames"
+
+// maxExecFramePayload is the largest payload ReadExecFrame accepts.
+const maxExecFramePayload = 1 << 20
+
+// WriteExecFrame writes a frame of the /exec-stream protocol to w,
+// in a single call to w.Write.
+func WriteExecFrame(w io.Writer, typ ExecFrameType, payload []byte) error {
+	if len(payload) > maxExecFramePayload {
+		return fmt.Errorf("buildlet: exec frame payload of %d bytes is too large", len(payload))
+	}
+	buf := make([]byte, 5+len(payload))
+	buf[0] = byte(typ)
+	binary.BigEndian.PutUint32(buf[1:5], uint32(len(payload)))
+	copy(buf[5:], payload)
+	_, err := w.Write(buf)
+	return err
+}
+
+// ReadExecFrame reads a frame of the /exec-stream protocol from r.
+func ReadExecFrame(r io.Reader) (typ ExecFrameType, payload []byte, err error) {
+	var hdr [5]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return 0, nil, err
+	}
+	n := binary.BigEndian.Uint32(hdr[1:])
+	if n > maxExecFramePayload {
+		return 0, nil, fmt.Errorf("buildlet: exec frame payload of %d bytes is too large", n)
+	}
+	payload = make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, err
+	}
+	return ExecFrameType(hdr[0]), payload, nil
+}
+
+// copyExecFrames copies the stdout and stderr frames read from r to
+// stdout and stderr, until r's EOF. If stderr is nil, stderr frames go
+// to stdout.
+func copyExecFrames(stdout, stderr io.Writer, r io.Reader) error {
+	if stderr == nil {
+		stderr = stdout
+	}
+	br := bufio.NewReader(r)
+	for {
+		typ, payload, err := ReadExecFrame(br)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var w io.Writer
+		switch typ {
+		case ExecFrameStdout:
+			w = stdout
+		case ExecFrameStderr:
+			w = stderr
+		default:
+			continue
+		}
+		if _, err := w.Write(payload); err != nil {
+			return err
+		}
+	}
+}
+
+// EncodeWindowSize returns the payload of an ExecFrameResize frame.
+func EncodeWindowSize(rows, cols uint16) []byte {
+	var b [4]byte
+	binary.BigEndian.PutUint16(b[0:2], rows)
+	binary.BigEndian.PutUint16(b[2:4], cols)
+	return b[:]
+}
+
+// DecodeWindowSize parses the payload of an ExecFrameResize frame.
+func DecodeWindowSize(p []byte) (rows, cols uint16, ok bool) {
+	if len(p) != 4 {
+		return 0, 0, false
+	}
+	return binary.BigEndian.Uint16(p[0:2]), binary.BigEndian.Uint16(p[2:4]), true
+}
+
+// ExecResult describes how a command run on a buildlet ended.
+type ExecResult struct {
+	// ExitCode is the command's exit code, or -1 if it was killed
+	// by a signal or couldn't be started.
+	ExitCode int `json:"exitCode"`
+
+	// Signal is the name of the signal that killed the command,
+	// such as "killed", if any.
+	Signal string `json:"signal,omitempty"`
+
+	// State is "ok" if the command succeeded. Otherwise it
+	// describes the failure, like the Process-State trailer
+	// of /exec, such as "exit status 1".
+	State string `json:"state"`
+
+	// WallTime is how long the command ran. UserTime and SystemTime
+	// are the CPU time it used, and MaxRSS is its peak resident set
+	// size in bytes, or zero if the buildlet's platform doesn't
+	// report it.
+	WallTime   time.Duration `json:"wallTime"`
+	UserTime   time.Duration `json:"userTime"`
+	SystemTime time.Duration `json:"systemTime"`
+	MaxRSS     int64         `json:"maxRSS,omitempty"`
+
+	// LimitExceeded is the limit of ExecOpts.Limits that the command
+	// exceeded, such as LimitMemory, if any. A command that exceeds
+	// its memory, wall time or disk limit is killed. If the command
+	// failed, State notes the limit it exceeded.
+	LimitExceeded string `json:"limitExceeded,omitempty"`
+}
+
+// Err returns nil if the command succeeded, or an error describing
+// how it failed. It's the remoteErr that Exec would have returned.
+func (r *ExecResult) Err() error {
+	if r.State == "ok" {
+		return nil
+	}
+	return errors.New(r.State)
+}
+
+// ExecSessionOpts are options for RemoteClient.StartExec.
+type ExecSessionOpts struct {
+	// ExecOpts are the options for running the command, as for Exec.
+	// OnStartExec runs once the buildlet has accepted the command.
+	ExecOpts
+
+	// TTY, if true, runs the command in a pseudo-terminal of the
+	// given size, with the TERM environment variable set to Term.
+	// A pseudo-terminal merges standard output and standard error.
+	TTY  bool
+	Term string
+	Rows uint16
+	Cols uint16
+}
+
+// An execTransport carries the frames of an ExecSession.
+type execTransport interface {
+	send(typ ExecFrameType, payload []byte) error
+	recv() (ExecFrameType, []byte, error)
+	close() error
+}
+
+// An ExecSession is a command started by RemoteClient.StartExec.
+//
+// Its methods for writing to the command may be called concurrently
+// with each other and with Wait.
+type ExecSession struct {
+	t       execTransport
+	sendMu  sync.Mutex
+	done    chan struct{} // closed when recvLoop is done
+	result  *ExecResult   // valid after done
+	err     error         // valid after done
+	closeMu sync.Mutex
+	closed  bool
+}
+
+func newExecSession(t execTransport, opts ExecSessionOpts) *ExecSession {
+	s := &ExecSession{t: t, done: make(chan struct{})}
+	go s.recvLoop(opts)
+	return s
+}
+
+func (s *ExecSession) recvLoop(opts ExecSessionOpts) {
+	defer close(s.done)
+	stdout := opts.Output
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	stderr := opts.Stderr
+	if stderr == nil {
+		stderr = stdout
+	}
+	for {
+		typ, payload, err := s.t.recv()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			s.err = fmt.Errorf("buildlet: exec stream ended before the command exited: %w", err)
+			return
+		}
+		switch typ {
+		case ExecFrameStdout:
+			stdout.Write(payload)
+		case ExecFrameStderr:
+			stderr.Write(payload)
+		case ExecFrameExit:
+			res := new(ExecResult)
+			if err := json.Unmarshal(payload, res); err != nil {
+				s.err = fmt.Errorf("buildlet: bad exit frame: %w", err)
+				return
+			}
+			s.result = res
+			if opts.OnExit != nil {
+				opts.OnExit(res)
+			}
+			return
+		}
+	}
+}
+
+func (s *ExecSession) send(typ ExecFrameType, payload []byte) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	select {
+	case <-s.done:
+		return errors.New("buildlet: command has exited")
+	default:
+	}
+	return s.t.send(typ, payload)
+}
+
+// Write writes p to the command's standard input.
+func (s *ExecSession) Write(p []byte) (n int, err error) {
+	for n < len(p) {
+		chunk := p[n:min(len(p), n+32<<10)]
+		if err := s.send(ExecFrameStdin, chunk); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+	}
+	return n, nil
+}
+
+// CloseStdin closes the command's standard input. In a pseudo-terminal,
+// it sends the end-of-file character instead.
+func (s *ExecSession) CloseStdin() error {
+	return s.send(ExecFrameCloseStdin, nil)
+}
+
+// Resize sets the size of the command's pseudo-terminal, if it has one.
+func (s *ExecSession) Resize(rows, cols uint16) error {
+	return s.send(ExecFrameResize, EncodeWindowSize(rows, cols))
+}
+
+// Signal sends a signal to the command. The name is that of a POSIX
+// signal without the "SIG" prefix, such as "INT", "TERM", "QUIT",
+// "HUP" or "KILL". Only "KILL" is supported on Windows.
+func (s *ExecSession) Signal(name string) error {
+	return s.send(ExecFrameSignal, []byte(name))
+}
+
+// Wait waits for the command to exit and returns how it ended. The
+// error is non-nil if the connection to the buildlet failed before
+// the command exited.
+func (s *ExecSession) Wait() (*ExecResult, error) {
+	<-s.done
+	s.Close()
+	return s.result, s.err
+}
+
+// Close closes the connection to the buildlet, killing the command if
+// it's still running.
+func (s *ExecSession) Close() error {
+	s.closeMu.Lock()
+	defer s.closeMu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.t.close()
+}
+
+// StartExec starts cmd on the buildlet, connected to the returned
+// ExecSession. See Exec for the meaning of cmd.
+func (c *client) StartExec(ctx context.Context, cmd string, opts ExecSessionOpts) (*ExecSession, error) {
+	form := execForm(cmd, opts.ExecOpts)
+	if opts.TTY {
+		form.Set("tty", "true")
+		form.Set("term", opts.Term)
+		form.Set("rows", fmt.Sprint(opts.Rows))
+		form.Set("cols", fmt.Sprint(opts.Cols))
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.URL()+"/exec-stream", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "buildlet-exec")
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		slurp, _ := io.ReadAll(io.LimitReader(res.Body, 4<<10))
+		res.Body.Close()
+		if res.StatusCode == http.StatusConflict {
+			return nil, fmt.Errorf("%w: %s", ErrExecBusy, strings.TrimSpace(string(slurp)))
+		}
+		return nil, fmt.Errorf("buildlet: HTTP status %v: %s", res.Status, slurp)
+	}
+	rwc, ok := res.Body.(io.ReadWriteCloser)
+	if !ok {
+		res.Body.Close()
+		return nil, errors.New("buildlet: exec-stream response was not a Writer")
+	}
+	condRun(opts.OnStartExec)
+	return newExecSession(&rwcExecTransport{rwc: rwc, br: bufio.NewReader(rwc)}, opts), nil
+}
+
+// execForm returns the form values of an /exec or /exec-stream request.
+func execForm(cmd string, opts ExecOpts) url.Values {
+	var mode string
+	if opts.SystemLevel {
+		mode = "sys"
+	}
+	path := opts.Path
+	if len(path) == 0 && path != nil {
+		// url.Values doesn't distinguish between a nil slice and
+		// a non-nil zero-length slice, so use this sentinel value.
+		path = []string{"$EMPTY"}
+	}
+	form := url.Values{
+		"cmd":    {cmd},
+		"mode":   {mode},
+		"dir":    {opts.Dir},
+		"cmdArg": opts.Args,
+		"env":    opts.ExtraEnv,
+		"path":   path,
+		"debug":  {fmt.Sprint(opts.Debug)},
+	}
+	if opts.Limits != nil {
+		limits, err := json.Marshal(opts.Limits)
+		if err != nil {
+			panic(err) // can't happen
+		}
+		form.Set("limits", string(limits))
+	}
+	return form
+}
+
+// rwcExecTransport carries exec frames over an upgraded HTTP connection.
+type rwcExecTransport struct {
+	rwc io.ReadWriteCloser
+	br  *bufio.Reader
+}
+
+func (t *rwcExecTransport) send(typ ExecFrameType, payload []byte) error {
+	return WriteExecFrame(t.rwc, typ, payload)
+}
+
+func (t *rwcExecTransport) recv() (ExecFrameType, []byte, error) {
+	return ReadExecFrame(t.br)
+}
+
+func (t *rwcExecTransport) close() error {
+	return t.rwc.Close()
+}
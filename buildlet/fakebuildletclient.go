@@ -5,7 +5,9 @@
 package buildlet
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -13,20 +15,26 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 )
 
 // RemoteClient is a subset of methods that can be used by a gomote client.
 type RemoteClient interface {
 	Close() error
 	Exec(ctx context.Context, cmd string, opts ExecOpts) (remoteErr, execErr error)
+	StartExec(ctx context.Context, cmd string, opts ExecSessionOpts) (*ExecSession, error)
 	GetTar(ctx context.Context, dir string) (io.ReadCloser, error)
 	ListDir(ctx context.Context, dir string, opts ListDirOpts, fn func(DirEntry)) error
 	Put(ctx context.Context, r io.Reader, path string, mode os.FileMode) error
 	PutTar(ctx context.Context, r io.Reader, dir string) error
 	PutTarFromURL(ctx context.Context, tarURL, dir string) error
+	PutSync(ctx context.Context, r io.Reader, dir string) error
+	PutSyncFromURL(ctx context.Context, syncURL, dir string) error
 	ProxyTCP(port int) (io.ReadWriteCloser, error)
+	ListenTCP(ctx context.Context, port int) (TCPListener, error)
 	RemoteName() string
 	RemoveAll(ctx context.Context, paths ...string) error
+	SyncManifest(ctx context.Context, dir string, opts SyncManifestOpts, fn func(SyncFile)) error
 	WorkDir(ctx context.Context) (string, error)
 }
 
@@ -84,6 +92,9 @@ func (fc *FakeClient) Exec(ctx context.Context, cmd string, opts ExecOpts) (remo
 	if cmd == "" {
 		return nil, errors.New("invalid command")
 	}
+	if opts.OnExit != nil {
+		defer opts.OnExit(&ExecResult{State: "ok"})
+	}
 	if opts.Output == nil {
 		return nil, nil
 	}
@@ -93,9 +104,69 @@ func (fc *FakeClient) Exec(ctx context.Context, cmd string, opts ExecOpts) (remo
 			return nil, fmt.Errorf("Output.Write(...) = %d, %q; want %d, no error", n, err, len(out))
 		}
 	}
+	if opts.Stderr != nil {
+		if _, err := opts.Stderr.Write([]byte("<it just goes on and on>")); err != nil {
+			return nil, fmt.Errorf("Stderr.Write(...) = %q; want no error", err)
+		}
+	}
 	return nil, nil
 }
 
+// StartExec fakes an interactive command which, like cat, echoes its
+// standard input to its standard output.
+func (fc *FakeClient) StartExec(ctx context.Context, cmd string, opts ExecSessionOpts) (*ExecSession, error) {
+	if cmd == "" {
+		return nil, errors.New("invalid command")
+	}
+	condRun(opts.OnStartExec)
+	return newExecSession(&fakeExecTransport{
+		frames: make(chan execFrame),
+		closed: make(chan struct{}),
+	}, opts), nil
+}
+
+// fakeExecTransport is the transport of a fake interactive command.
+type fakeExecTransport struct {
+	frames    chan execFrame // from the command
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (t *fakeExecTransport) send(typ ExecFrameType, payload []byte) error {
+	var f execFrame
+	switch typ {
+	case ExecFrameStdin:
+		f = execFrame{ExecFrameStdout, bytes.Clone(payload)}
+	case ExecFrameCloseStdin:
+		f = execFrame{ExecFrameExit, []byte(`{"exitCode":0,"state":"ok"}`)}
+	case ExecFrameSignal:
+		res, _ := json.Marshal(&ExecResult{ExitCode: -1, Signal: string(payload), State: "signal: " + string(payload)})
+		f = execFrame{ExecFrameExit, res}
+	default:
+		return nil
+	}
+	select {
+	case t.frames <- f:
+		return nil
+	case <-t.closed:
+		return errors.New("fake exec session closed")
+	}
+}
+
+func (t *fakeExecTransport) recv() (ExecFrameType, []byte, error) {
+	select {
+	case f := <-t.frames:
+		return f.typ, f.payload, nil
+	case <-t.closed:
+		return 0, nil, io.EOF
+	}
+}
+
+func (t *fakeExecTransport) close() error {
+	t.closeOnce.Do(func() { close(t.closed) })
+	return nil
+}
+
 // InstanceName gives the fake instance name.
 func (fc *FakeClient) InstanceName() string { return fc.instanceName }
 
@@ -134,8 +205,65 @@ func (fc *FakeClient) Name() string { return fc.name }
 // ProxyRoundTripper provides a fake proxy.
 func (fc *FakeClient) ProxyRoundTripper() http.RoundTripper { return nil }
 
-// ProxyTCP provides a fake proxy.
-func (fc *FakeClient) ProxyTCP(port int) (io.ReadWriteCloser, error) { return nil, errUnimplemented }
+// ProxyTCP connects to a fake TCP server which echoes what it reads.
+func (fc *FakeClient) ProxyTCP(port int) (io.ReadWriteCloser, error) {
+	if port <= 0 {
+		return nil, errors.New("invalid port")
+	}
+	c, s := net.Pipe()
+	go func() {
+		defer s.Close()
+		io.Copy(s, s)
+	}()
+	return c, nil
+}
+
+// ListenTCP listens on a fake port, where a single client connects and
+// sends a greeting.
+func (fc *FakeClient) ListenTCP(ctx context.Context, port int) (TCPListener, error) {
+	if port < 0 {
+		return nil, errors.New("invalid port")
+	}
+	if port == 0 {
+		port = 12345
+	}
+	l := &fakeTCPListener{
+		addr:   fmt.Sprintf("127.0.0.1:%d", port),
+		conns:  make(chan io.ReadWriteCloser, 1),
+		closed: make(chan struct{}),
+	}
+	c, s := net.Pipe()
+	go func() {
+		defer s.Close()
+		io.WriteString(s, "hello from the fake buildlet\n")
+		io.Copy(io.Discard, s)
+	}()
+	l.conns <- c
+	return l, nil
+}
+
+type fakeTCPListener struct {
+	addr      string
+	conns     chan io.ReadWriteCloser
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (l *fakeTCPListener) Accept() (io.ReadWriteCloser, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.closed:
+		return nil, errors.New("listener closed")
+	}
+}
+
+func (l *fakeTCPListener) Addr() string { return l.addr }
+
+func (l *fakeTCPListener) Close() error {
+	l.closeOnce.Do(func() { close(l.closed) })
+	return nil
+}
 
 // Put places a file on a fake buildlet.
 func (fc *FakeClient) Put(ctx context.Context, r io.Reader, path string, mode os.FileMode) error {
@@ -157,6 +285,16 @@ func (fc *FakeClient) PutTarFromURL(ctx context.Context, tarURL, dir string) err
 	return nil
 }
 
+// PutSync fakes applying sync ops on a buildlet.
+func (fc *FakeClient) PutSync(ctx context.Context, r io.Reader, dir string) error {
+	return errUnimplemented
+}
+
+// PutSyncFromURL fakes applying sync ops on a buildlet.
+func (fc *FakeClient) PutSyncFromURL(ctx context.Context, syncURL, dir string) error {
+	return nil
+}
+
 // RemoteName gives the remote name of the fake buildlet.
 func (fc *FakeClient) RemoteName() string { return "" }
 
@@ -185,6 +323,20 @@ func (fc *FakeClient) SetOnHeartbeatFailure(fn func()) {}
 // Status provides a status on the fake client.
 func (fc *FakeClient) Status(ctx context.Context) (Status, error) { return Status{}, errUnimplemented }
 
+// SyncManifest lists the files of a directory on a fake buildlet.
+func (fc *FakeClient) SyncManifest(ctx context.Context, dir string, opts SyncManifestOpts, fn func(SyncFile)) error {
+	if fn == nil {
+		return errors.New("invalid arguments")
+	}
+	fn(SyncFile{
+		Path:   "VERSION",
+		Mode:   0644,
+		Size:   int64(len("go1.99")),
+		Blocks: []string{HashBlock([]byte("go1.99"))},
+	})
+	return nil
+}
+
 // String provides a fake string representation of the client.
 func (fc *FakeClient) String() string { return "" }
 
@@ -7,6 +7,7 @@ package buildlet
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -19,6 +20,7 @@ import (
 	"golang.org/x/build/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type GRPCCoordinatorClient struct {
@@ -83,6 +85,8 @@ func (b *grpcBuildlet) Exec(ctx context.Context, cmd string, opts ExecOpts) (rem
 		Path:              opts.Path,
 		Directory:         opts.Dir,
 		Args:              opts.Args,
+		SeparateStderr:    opts.Stderr != nil,
+		Limits:            execLimitsToProto(opts.Limits),
 	})
 	if err != nil {
 		return nil, err
@@ -106,7 +110,139 @@ func (b *grpcBuildlet) Exec(ctx context.Context, cmd string, opts ExecOpts) (rem
 		if opts.Output != nil {
 			opts.Output.Write(update.Output)
 		}
+		if opts.Stderr != nil {
+			opts.Stderr.Write(update.Stderr)
+		}
+		if es := update.GetExitStatus(); es != nil && opts.OnExit != nil {
+			opts.OnExit(execResultFromProto(es))
+		}
+	}
+}
+
+// execResultFromProto converts the ExitStatus of a command to an ExecResult.
+func execResultFromProto(es *protos.ExitStatus) *ExecResult {
+	return &ExecResult{
+		ExitCode:      int(es.GetCode()),
+		Signal:        es.GetSignal(),
+		State:         es.GetState(),
+		WallTime:      es.GetWallTime().AsDuration(),
+		UserTime:      es.GetUserTime().AsDuration(),
+		SystemTime:    es.GetSystemTime().AsDuration(),
+		MaxRSS:        es.GetMaxRss(),
+		LimitExceeded: es.GetLimitExceeded(),
+	}
+}
+
+// execLimitsToProto converts limits on the resources of a command to
+// their protobuf form.
+func execLimitsToProto(lim *ExecLimits) *protos.ExecLimits {
+	if lim == nil {
+		return nil
+	}
+	return &protos.ExecLimits{
+		Cpus:        lim.CPU,
+		MemoryBytes: lim.Memory,
+		WallTime:    durationpb.New(lim.WallTime),
+		Processes:   int32(lim.Processes),
+		DiskBytes:   lim.Disk,
+		NoNetwork:   lim.NoNetwork,
+	}
+}
+
+func (b *grpcBuildlet) StartExec(ctx context.Context, cmd string, opts ExecSessionOpts) (*ExecSession, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := b.client.ExecuteCommandInteractive(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	start := &protos.ExecuteCommandInteractiveRequest{
+		Command: &protos.ExecuteCommandRequest{
+			GomoteId:          b.id,
+			Command:           cmd,
+			SystemLevel:       opts.SystemLevel,
+			Debug:             opts.Debug,
+			AppendEnvironment: opts.ExtraEnv,
+			Path:              opts.Path,
+			Directory:         opts.Dir,
+			Args:              opts.Args,
+			Limits:            execLimitsToProto(opts.Limits),
+		},
+	}
+	if opts.TTY {
+		start.Tty = true
+		start.Term = opts.Term
+		start.WindowSize = &protos.WindowSize{Rows: uint32(opts.Rows), Cols: uint32(opts.Cols)}
+	}
+	if err := stream.Send(start); err != nil {
+		cancel()
+		return nil, err
+	}
+	condRun(opts.OnStartExec)
+	return newExecSession(&grpcExecTransport{stream: stream, cancel: cancel}, opts), nil
+}
+
+// grpcExecTransport carries exec frames over an ExecuteCommandInteractive stream.
+type grpcExecTransport struct {
+	stream  protos.GomoteService_ExecuteCommandInteractiveClient
+	cancel  context.CancelFunc
+	pending []execFrame // received but not yet returned by recv
+}
+
+type execFrame struct {
+	typ     ExecFrameType
+	payload []byte
+}
+
+func (t *grpcExecTransport) send(typ ExecFrameType, payload []byte) error {
+	req := new(protos.ExecuteCommandInteractiveRequest)
+	switch typ {
+	case ExecFrameStdin:
+		req.Stdin = payload
+	case ExecFrameCloseStdin:
+		req.CloseStdin = true
+	case ExecFrameResize:
+		rows, cols, ok := DecodeWindowSize(payload)
+		if !ok {
+			return errors.New("invalid window size")
+		}
+		req.WindowSize = &protos.WindowSize{Rows: uint32(rows), Cols: uint32(cols)}
+	case ExecFrameSignal:
+		req.Signal = string(payload)
+	default:
+		return fmt.Errorf("unexpected exec frame type %d", typ)
+	}
+	return t.stream.Send(req)
+}
+
+func (t *grpcExecTransport) recv() (ExecFrameType, []byte, error) {
+	for len(t.pending) == 0 {
+		res, err := t.stream.Recv()
+		if err != nil {
+			return 0, nil, err
+		}
+		if len(res.GetStdout()) > 0 {
+			t.pending = append(t.pending, execFrame{ExecFrameStdout, res.GetStdout()})
+		}
+		if len(res.GetStderr()) > 0 {
+			t.pending = append(t.pending, execFrame{ExecFrameStderr, res.GetStderr()})
+		}
+		if es := res.GetExitStatus(); es != nil {
+			p, err := json.Marshal(execResultFromProto(es))
+			if err != nil {
+				return 0, nil, err
+			}
+			t.pending = append(t.pending, execFrame{ExecFrameExit, p})
+		}
 	}
+	f := t.pending[0]
+	t.pending = t.pending[1:]
+	return f.typ, f.payload, nil
+}
+
+func (t *grpcExecTransport) close() error {
+	t.cancel()
+	return nil
 }
 
 func (b *grpcBuildlet) GetTar(ctx context.Context, dir string) (io.ReadCloser, error) {
@@ -184,6 +320,54 @@ func (b *grpcBuildlet) PutTarFromURL(ctx context.Context, url string, dir string
 	return err
 }
 
+func (b *grpcBuildlet) PutSync(ctx context.Context, r io.Reader, dir string) error {
+	url, err := b.upload(ctx, r)
+	if err != nil {
+		return err
+	}
+	return b.PutSyncFromURL(ctx, url, dir)
+}
+
+func (b *grpcBuildlet) PutSyncFromURL(ctx context.Context, url string, dir string) error {
+	_, err := b.client.WriteSyncFromURL(ctx, &protos.WriteSyncFromURLRequest{
+		GomoteId:  b.id,
+		Url:       url,
+		Directory: dir,
+	})
+	return err
+}
+
+func (b *grpcBuildlet) SyncManifest(ctx context.Context, dir string, opts SyncManifestOpts, fn func(SyncFile)) error {
+	stream, err := b.client.SyncManifest(ctx, &protos.SyncManifestRequest{
+		GomoteId:  b.id,
+		Directory: dir,
+		SkipFiles: opts.Skip,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if status.Code(err) == codes.Unimplemented {
+			return fmt.Errorf("%w: %v", ErrSyncUnsupported, err)
+		}
+		if err != nil {
+			return err
+		}
+		for _, f := range resp.GetFiles() {
+			fn(SyncFile{
+				Path:   f.GetPath(),
+				Mode:   os.FileMode(f.GetMode()),
+				Size:   f.GetSize(),
+				Blocks: f.GetBlocks(),
+			})
+		}
+	}
+}
+
 func (b *grpcBuildlet) upload(ctx context.Context, r io.Reader) (string, error) {
 	resp, err := b.client.UploadFile(ctx, &protos.UploadFileRequest{})
 	if err != nil {
@@ -222,6 +406,10 @@ func (b *grpcBuildlet) ProxyTCP(port int) (io.ReadWriteCloser, error) {
 	return nil, fmt.Errorf("TCP proxying unimplemented in grpc")
 }
 
+func (b *grpcBuildlet) ListenTCP(ctx context.Context, port int) (TCPListener, error) {
+	return nil, fmt.Errorf("TCP listening unimplemented in grpc")
+}
+
 func (b *grpcBuildlet) RemoteName() string {
 	return b.id
 }
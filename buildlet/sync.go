@@ -0,0 +1,229 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildlet
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// The sync protocol updates a directory tree on a buildlet to match one
+// elsewhere, sending only what changed.
+//
+// Files are split into blocks of SyncBlockSize bytes, identified by
+// their SHA-256 hashes. The buildlet keeps a manifest of the blocks of
+// the files in its work directory, which clients fetch with
+// SyncManifest. A client then sends a stream of SyncOps with PutSync,
+// each followed by the data of the blocks the buildlet doesn't already
+// have somewhere in the directory.
+
+// SyncBlockSize is the size of the blocks of files in the sync protocol.
+// The last block of a file may be shorter.
+const SyncBlockSize = 64 << 10
+
+// A SyncFile describes a regular file in a buildlet's sync manifest.
+type SyncFile struct {
+	// Path is the file's path, slash-separated and relative to the
+	// directory of the manifest.
+	Path string `json:"path"`
+
+	Mode os.FileMode `json:"mode"`
+	Size int64       `json:"size"`
+
+	// Blocks are the hex SHA-256 hashes of the file's blocks, in order.
+	Blocks []string `json:"blocks"`
+}
+
+// A SyncOp is one change sent by PutSync.
+type SyncOp struct {
+	// Path is the path of the file to write or delete, slash-separated
+	// and relative to the directory being synced.
+	Path string `json:"path"`
+
+	// Delete says to remove Path, recursively if it's a directory.
+	Delete bool `json:"delete,omitempty"`
+
+	// Mode, Size and Blocks describe the new contents of Path when
+	// it's not being deleted. Size is the total size of the blocks.
+	Mode   os.FileMode `json:"mode,omitempty"`
+	Size   int64       `json:"size,omitempty"`
+	Blocks []string    `json:"blocks,omitempty"`
+
+	// Literal are the indexes into Blocks, in increasing order, of the
+	// blocks whose data follows the op.
+	Literal []int `json:"literal,omitempty"`
+}
+
+// BlockLen returns the length of the i'th block of the file.
+func (op *SyncOp) BlockLen(i int) int {
+	return int(min(SyncBlockSize, op.Size-int64(i)*SyncBlockSize))
+}
+
+// WriteSyncOp writes op to w, followed by data, which must be the
+// concatenated contents of op's literal blocks.
+func WriteSyncOp(w io.Writer, op *SyncOp, data []byte) error {
+	var n int
+	for _, i := range op.Literal {
+		n += op.BlockLen(i)
+	}
+	if n != len(data) {
+		return fmt.Errorf("sync op for %s has %d bytes of literal blocks; got %d", op.Path, n, len(data))
+	}
+	b, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	if _, err := w.Write(b); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// ReadSyncOp reads the next op written by WriteSyncOp. The caller must
+// then read the op's literal blocks from r, in order. At the end of the
+// stream it returns io.EOF.
+func ReadSyncOp(r *bufio.Reader) (*SyncOp, error) {
+	line, err := r.ReadBytes('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	op := new(SyncOp)
+	if err := json.Unmarshal(line, op); err != nil {
+		return nil, fmt.Errorf("bad sync op: %v", err)
+	}
+	if op.Path == "" {
+		return nil, errors.New("bad sync op: missing path")
+	}
+	if op.Delete {
+		return op, nil
+	}
+	if n := (op.Size + SyncBlockSize - 1) / SyncBlockSize; op.Size < 0 || int64(len(op.Blocks)) != n {
+		return nil, fmt.Errorf("bad sync op for %s: %d blocks for %d bytes", op.Path, len(op.Blocks), op.Size)
+	}
+	for j, i := range op.Literal {
+		if i < 0 || i >= len(op.Blocks) || j > 0 && i <= op.Literal[j-1] {
+			return nil, fmt.Errorf("bad sync op for %s: bad literal block index %d", op.Path, i)
+		}
+	}
+	return op, nil
+}
+
+// HashBlocks returns the hashes of the sync protocol blocks of the
+// contents of r, and their total size.
+func HashBlocks(r io.Reader) (blocks []string, size int64, err error) {
+	buf := make([]byte, SyncBlockSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			blocks = append(blocks, HashBlock(buf[:n]))
+			size += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return blocks, size, nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+}
+
+// HashBlock returns the hash identifying a block in the sync protocol.
+func HashBlock(p []byte) string {
+	sum := sha256.Sum256(p)
+	return hex.EncodeToString(sum[:])
+}
+
+// ErrSyncUnsupported is returned by Client.SyncManifest for buildlets
+// too old to support the sync protocol. Callers can fall back to
+// listing files with ListDir and writing them with PutTar.
+var ErrSyncUnsupported = errors.New("buildlet doesn't support syncing directories")
+
+// SyncManifestOpts are options for Client.SyncManifest.
+type SyncManifestOpts struct {
+	// Skip are the directories to skip, relative to the directory
+	// passed to SyncManifest. Each item should contain only forward
+	// slashes and not start or end in slashes.
+	Skip []string
+}
+
+// SyncManifest calls fn with each regular file in dir, relative to the
+// buildlet's work directory. The directory need not exist.
+func (c *client) SyncManifest(ctx context.Context, dir string, opts SyncManifestOpts, fn func(SyncFile)) error {
+	param := url.Values{
+		"dir":  {dir},
+		"skip": opts.Skip,
+	}
+	req, err := http.NewRequest("GET", c.URL()+"/syncmanifest?"+param.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		// Buildlets older than version 30 have no /syncmanifest.
+		return fmt.Errorf("%w: %s", ErrSyncUnsupported, resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK {
+		slurp, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("%s: %s", resp.Status, slurp)
+	}
+	return decodeSyncManifest(resp.Body, fn)
+}
+
+func decodeSyncManifest(r io.Reader, fn func(SyncFile)) error {
+	dec := json.NewDecoder(r)
+	for {
+		var f SyncFile
+		if err := dec.Decode(&f); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		fn(f)
+	}
+}
+
+// PutSync applies the changes in r, a gzip-compressed stream of
+// SyncOps, to dir, relative to the buildlet's work directory.
+// The dir is created if necessary.
+func (c *client) PutSync(ctx context.Context, r io.Reader, dir string) error {
+	req, err := http.NewRequest("PUT", c.URL()+"/writesync?dir="+url.QueryEscape(dir), r)
+	if err != nil {
+		return err
+	}
+	return c.doOK(req.WithContext(ctx))
+}
+
+// PutSyncFromURL is like PutSync, but tells the buildlet to download
+// the changes from syncURL.
+func (c *client) PutSyncFromURL(ctx context.Context, syncURL, dir string) error {
+	form := url.Values{
+		"url": {syncURL},
+	}
+	req, err := http.NewRequest("POST", c.URL()+"/writesync?dir="+url.QueryEscape(dir), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.doOK(req.WithContext(ctx))
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildlet
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// A TCPListener is a TCP port the buildlet listens on for connections,
+// as returned by ListenTCP.
+type TCPListener interface {
+	// Accept waits for and returns the next connection the buildlet
+	// accepted.
+	Accept() (io.ReadWriteCloser, error)
+
+	// Addr returns the address the buildlet listens on, such as
+	// "127.0.0.1:8080".
+	Addr() string
+
+	// Close stops the buildlet listening. Connections already
+	// accepted are unaffected.
+	Close() error
+}
+
+// ListenTCP asks the buildlet to listen on the given TCP port of its
+// loopback interface, or on any free port if port is 0.
+//
+// The buildlet sends a line "conn <id>" over the upgraded /tcplisten
+// connection for each connection it accepts, which the client then
+// claims with a /tcpproxy request.
+func (c *client) ListenTCP(ctx context.Context, port int) (TCPListener, error) {
+	req, err := http.NewRequest("POST", c.URL()+"/tcplisten", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Target-Port", fmt.Sprint(port))
+	rwc, err := c.upgrade(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(rwc)
+	line, err := br.ReadString('\n')
+	if err != nil {
+		rwc.Close()
+		return nil, fmt.Errorf("reading listen address: %w", err)
+	}
+	addr, ok := strings.CutPrefix(strings.TrimSpace(line), "listening ")
+	if !ok {
+		rwc.Close()
+		return nil, fmt.Errorf("unexpected response from /tcplisten: %q", line)
+	}
+	return &tcpListener{c: c, rwc: rwc, br: br, addr: addr}, nil
+}
+
+type tcpListener struct {
+	c    *client
+	rwc  io.ReadWriteCloser
+	br   *bufio.Reader
+	addr string
+
+	closeOnce sync.Once
+}
+
+func (l *tcpListener) Accept() (io.ReadWriteCloser, error) {
+	line, err := l.br.ReadString('\n')
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = errors.New("buildlet stopped listening")
+		}
+		return nil, err
+	}
+	id, ok := strings.CutPrefix(strings.TrimSpace(line), "conn ")
+	if !ok {
+		return nil, fmt.Errorf("unexpected message from /tcplisten: %q", line)
+	}
+	req, err := http.NewRequest("POST", l.c.URL()+"/tcpproxy", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Accept-Conn", id)
+	return l.c.upgrade(req)
+}
+
+func (l *tcpListener) Addr() string { return l.addr }
+
+func (l *tcpListener) Close() error {
+	var err error
+	l.closeOnce.Do(func() { err = l.rwc.Close() })
+	return err
+}
+
+// upgrade sends req, which must ask the buildlet to switch protocols,
+// and returns the resulting connection.
+func (c *client) upgrade(req *http.Request) (io.ReadWriteCloser, error) {
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		slurp, _ := io.ReadAll(io.LimitReader(res.Body, 4<<10))
+		res.Body.Close()
+		return nil, fmt.Errorf("wanted 101 Switching Protocols; unexpected response: %v, %q", res.Status, slurp)
+	}
+	rwc, ok := res.Body.(io.ReadWriteCloser)
+	if !ok {
+		res.Body.Close()
+		return nil, fmt.Errorf("%s response was not a Writer", req.URL.Path)
+	}
+	return rwc, nil
+}
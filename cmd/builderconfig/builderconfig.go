@@ -0,0 +1,134 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Builderconfig checks declarative builder configuration files, such as
+// dashboard/builders.yaml, and reports how changes to them affect which
+// repos and branches are built.
+//
+// Usage:
+//
+//	builderconfig check file.yaml...
+//	builderconfig diff [-repos=go,net,...] [-branches=master,...] old.yaml new.yaml
+//
+// The check command reports all the problems in the files. The diff
+// command lists the repo and branch combinations that the builders of
+// new.yaml build and those of old.yaml don't, prefixed with "+", and the
+// other way around, prefixed with "-". For example, to review a change
+// to dashboard/builders.yaml against the release branches being built:
+//
+//	builderconfig diff -branches=master,release-branch.go1.25,release-branch.go1.24 \
+//		<(git show HEAD:dashboard/builders.yaml) dashboard/builders.yaml
+//
+// Both files are checked as if they replaced the builders.yaml built into
+// builderconfig, so their builders can use the host types defined in Go.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/repos"
+)
+
+var (
+	flagRepos    = flag.String("repos", "", "comma-separated `list` of repos to diff; default all repos the coordinator can build")
+	flagBranches = flag.String("branches", "master", "comma-separated `list` of Go branches to diff; other repos are diffed on their master branch")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: builderconfig check file.yaml...\n")
+	fmt.Fprintf(os.Stderr, "       builderconfig diff [flags] old.yaml new.yaml\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("builderconfig: ")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+	}
+	cmd := flag.Arg(0)
+	flag.CommandLine.Parse(flag.Args()[1:]) // the flags after the command
+	switch {
+	case cmd == "check" && flag.NArg() > 0:
+		ok := true
+		for _, file := range flag.Args() {
+			if _, err := load(file); err != nil {
+				log.Print(err)
+				ok = false
+			}
+		}
+		if !ok {
+			os.Exit(1)
+		}
+	case cmd == "diff" && flag.NArg() == 2:
+		diff(flag.Arg(0), flag.Arg(1))
+	default:
+		usage()
+	}
+}
+
+// load reads and compiles the builders of the configuration file.
+func load(file string) (map[string]*dashboard.BuildConfig, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c, err := dashboard.ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	_, builders, err := c.Compile()
+	if err != nil {
+		return nil, fmt.Errorf("%s:\n%v", file, err)
+	}
+	return builders, nil
+}
+
+func diff(oldFile, newFile string) {
+	oldBuilders, err := load(oldFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	newBuilders, err := load(newFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var repoList []string
+	if *flagRepos != "" {
+		repoList = strings.Split(*flagRepos, ",")
+	} else {
+		for proj, r := range repos.ByGerritProject {
+			if r.CoordinatorCanBuild {
+				repoList = append(repoList, proj)
+			}
+		}
+		slices.Sort(repoList)
+	}
+	rbs := dashboard.RepoBranches(repoList, strings.Split(*flagBranches, ","))
+	added, removed := dashboard.DiffCoverage(oldBuilders, newBuilders, rbs)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	list := func(sign string, cov []dashboard.Coverage) {
+		for _, c := range cov {
+			kind := "post-submit"
+			if c.TryBot {
+				kind = "trybot"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\tgo@%s\t%s\n", sign, c.Builder, c.Repo, c.Branch, c.GoBranch, kind)
+		}
+	}
+	list("-", removed)
+	list("+", added)
+	tw.Flush()
+}
@@ -0,0 +1,278 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Buildercoverage reports which builders of golang.org/x/build/dashboard
+// build each repo with each branch of Go, post-submit and as trybots,
+// which cmd/dist tests they skip, and which ports no builder covers a
+// repo for.
+//
+// For example, to find what tests openbsd-arm64 on x/net against
+// release-branch.go1.23:
+//
+//	buildercoverage -builders=^openbsd-arm64 -repos=net -branches=release-branch.go1.23
+//
+// The -format flag selects the output: csv, the repo and branch
+// combinations each builder builds; gaps, the ports that no builder
+// builds a repo post-submit for although other ports' builders do; json,
+// all of the report; or html, a matrix of it.
+//
+// By default, builders stopped in the coordinator are reported with the
+// repos and branches they were declared to build; -live reports what the
+// coordinator actually builds.
+//
+// To see what a change to builders.go does, save the report of the tree
+// without it as JSON and compare against it with -diff:
+//
+//	git worktree add /tmp/base HEAD
+//	(cd /tmp/base && go run ./cmd/buildercoverage -format=json) > base.json
+//	go run ./cmd/buildercoverage -diff=base.json
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/gerrit"
+	"golang.org/x/build/maintner/maintnerd/maintapi/version"
+	"golang.org/x/build/repos"
+)
+
+var (
+	flagFormat    = flag.String("format", "csv", "output `format`: csv, gaps, json or html")
+	flagBuilders  = flag.String("builders", "", "only report builders whose names match this `regexp`")
+	flagRepos     = flag.String("repos", "", "comma-separated `list` of repos; default all in golang.org/x/build/repos")
+	flagBranches  = flag.String("branches", "master", "comma-separated `list` of Go branches, or \"supported\" for master and the supported release branches on Gerrit; other repos are reported on their master branch")
+	flagDistTests = flag.String("dist-tests", "api,reboot,race,moved_goroot,runtime:cpu124,test:0_5,go_test:cmd/go,go_test:net", "comma-separated `list` of cmd/dist tests, in the Go 1.20 format, to report skips of")
+	flagLive      = flag.Bool("live", false, "report builders stopped in the coordinator as building nothing")
+	flagDiff      = flag.String("diff", "", "JSON report `file` to print the changes from, instead of the report")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: buildercoverage [flags]\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("buildercoverage: ")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() != 0 {
+		usage()
+	}
+
+	builders := dashboard.Builders
+	if *flagBuilders != "" {
+		re, err := regexp.Compile(*flagBuilders)
+		if err != nil {
+			log.Fatalf("-builders: %v", err)
+		}
+		builders = make(map[string]*dashboard.BuildConfig)
+		for name, bc := range dashboard.Builders {
+			if re.MatchString(name) {
+				builders[name] = bc
+			}
+		}
+	}
+	repoList := split(*flagRepos)
+	if len(repoList) == 0 {
+		repoList = slices.Sorted(maps.Keys(repos.ByGerritProject))
+	}
+	goBranches := split(*flagBranches)
+	if *flagBranches == "supported" {
+		var err error
+		if goBranches, err = supportedBranches(); err != nil {
+			log.Fatalf("listing the supported branches of Go: %v", err)
+		}
+	}
+	r := newReport(builders, repoList, goBranches, split(*flagDistTests), !*flagLive)
+
+	if *flagDiff != "" {
+		data, err := os.ReadFile(*flagDiff)
+		if err != nil {
+			log.Fatal(err)
+		}
+		old := new(Report)
+		if err := json.Unmarshal(data, old); err != nil {
+			log.Fatalf("%s: %v", *flagDiff, err)
+		}
+		if err := r.writeDiff(os.Stdout, old); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	var err error
+	switch *flagFormat {
+	case "csv":
+		err = r.writeCSV(os.Stdout)
+	case "gaps":
+		err = r.writeGapsCSV(os.Stdout)
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "\t")
+		err = enc.Encode(r)
+	case "html":
+		err = r.writeHTML(os.Stdout)
+	default:
+		usage()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// split splits a comma-separated list, which may be empty.
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// supportedBranches returns master and the release branches of the two
+// newest major versions of Go on Gerrit.
+func supportedBranches() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	gc := gerrit.NewClient("https://go-review.googlesource.com", gerrit.NoAuth)
+	branches, err := gc.ListBranches(ctx, "go")
+	if err != nil {
+		return nil, err
+	}
+	var minors []int
+	for _, b := range branches {
+		if maj, min, ok := version.ParseReleaseBranch(strings.TrimPrefix(b.Ref, "refs/heads/")); ok && maj == 1 {
+			minors = append(minors, min)
+		}
+	}
+	if len(minors) < 2 {
+		return nil, errors.New("fewer than two release branches")
+	}
+	slices.Sort(minors)
+	slices.Reverse(minors)
+	return []string{
+		"master",
+		fmt.Sprintf("release-branch.go1.%d", minors[0]),
+		fmt.Sprintf("release-branch.go1.%d", minors[1]),
+	}, nil
+}
+
+// writeCSV writes the repo and branch combinations each builder builds
+// as CSV.
+func (r *Report) writeCSV(w io.Writer) error {
+	ports := make(map[string]string)
+	for _, b := range r.Builders {
+		ports[b.Name] = b.Port
+	}
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"builder", "port", "repo", "branch", "go_branch", "post_submit", "trybot"})
+	for _, c := range r.Cells {
+		cw.Write([]string{c.Builder, ports[c.Builder], c.Repo, c.Branch, c.GoBranch, strconv.FormatBool(c.PostSubmit), strconv.FormatBool(c.TryBot)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeGapsCSV writes the gaps in post-submit coverage as CSV.
+func (r *Report) writeGapsCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"port", "repo", "go_branch"})
+	for _, g := range r.Gaps {
+		cw.Write([]string{g.Port, g.Repo, g.GoBranch})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeHTML writes a matrix of the builders and repos for each branch of
+// Go, and the gaps and dist test skips, as an HTML page.
+func (r *Report) writeHTML(w io.Writer) error {
+	type key struct{ builder, repo, goBranch string }
+	cells := make(map[key]Cell)
+	for _, c := range r.Cells {
+		cells[key{c.Builder, c.Repo, c.GoBranch}] = c
+	}
+	type table struct {
+		GoBranch string
+		Rows     [][]string // builder, then a cell per repo
+	}
+	var tables []table
+	for _, goBranch := range r.GoBranches {
+		t := table{GoBranch: goBranch}
+		for _, b := range r.Builders {
+			row := []string{b.Name}
+			for _, repo := range r.Repos {
+				c := cells[key{b.Name, repo, goBranch}]
+				var s string
+				if c.PostSubmit {
+					s += "P"
+				}
+				if c.TryBot {
+					s += "T"
+				}
+				row = append(row, s)
+			}
+			t.Rows = append(t.Rows, row)
+		}
+		tables = append(tables, t)
+	}
+	return htmlTmpl.Execute(w, struct {
+		*Report
+		Tables []table
+	}{r, tables})
+}
+
+var htmlTmpl = template.Must(template.New("coverage").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>Builder coverage</title>
+<style>
+table { border-collapse: collapse; font-family: monospace; }
+th, td { border: 1px solid #ccc; padding: 0.1em 0.4em; }
+th.repo { writing-mode: vertical-rl; }
+td.cell { text-align: center; background: #dfd; }
+td.cell:empty { background: none; }
+</style>
+</head>
+<body>
+<h1>Builder coverage</h1>
+<p>P: built post-submit. T: built as a trybot. Repos other than go are built on their master branch.</p>
+{{range .Tables}}
+<h2>go@{{.GoBranch}}</h2>
+<table>
+<tr><th>builder</th>{{range $.Repos}}<th class="repo">{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr><td>{{index . 0}}</td>{{range slice . 1}}<td class="cell">{{.}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}
+<h2>Gaps</h2>
+<p>Ports that no builder builds a repo post-submit for, although other ports' builders do.</p>
+<table>
+<tr><th>port</th><th>repo</th><th>Go branch</th></tr>
+{{range .Gaps}}<tr><td>{{.Port}}</td><td>{{.Repo}}</td><td>{{.GoBranch}}</td></tr>
+{{end}}</table>
+<h2>Skipped dist tests</h2>
+<table>
+<tr><th>builder</th><th>port</th><th>post-submit</th><th>trybots</th></tr>
+{{range .Builders}}<tr><td>{{.Name}}{{if .Stopped}} (stopped){{end}}</td><td>{{.Port}}</td><td>{{range .SkippedDistTests}}{{.}} {{end}}</td><td>{{range .SkippedTryDistTests}}{{.}} {{end}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
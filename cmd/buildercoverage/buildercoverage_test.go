@@ -0,0 +1,146 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/build/dashboard"
+)
+
+func compile(t *testing.T, config string) map[string]*dashboard.BuildConfig {
+	t.Helper()
+	c, err := dashboard.ParseConfig([]byte(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, builders, err := c.Compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return builders
+}
+
+const testBuilders = `
+builders:
+  - name: linux-amd64-x
+    host: host-linux-amd64-bullseye
+    repos:
+      include: [go, net, sys]
+    trybot:
+      include: [go]
+      go_branches: [master]
+    skip_try_dist_tests: ["test:*"]
+  - name: openbsd-arm64-x
+    host: host-linux-amd64-bullseye
+    repos:
+      include: [go, sys]
+      go_branches: [master]
+    skip_dist_tests: [reboot]
+  - name: misc-compile-plan9-386
+    host: host-linux-amd64-bullseye
+    env: [GOOS=plan9, GOARCH=386]
+    try_only: true
+    trybot:
+      include: [go]
+`
+
+func TestReport(t *testing.T) {
+	r := newReport(compile(t, testBuilders), []string{"go", "net", "sys"}, []string{"master", "release-branch.go1.25"}, []string{"reboot", "test:0_5"}, true)
+
+	wantBuilders := []Builder{
+		{Name: "linux-amd64-x", Port: "linux-amd64", SkippedTryDistTests: []string{"test:0_5"}},
+		{Name: "misc-compile-plan9-386", Port: "plan9-386", TryOnly: true},
+		{Name: "openbsd-arm64-x", Port: "openbsd-arm64", SkippedDistTests: []string{"reboot"}, SkippedTryDistTests: []string{"reboot"}},
+	}
+	if !reflect.DeepEqual(r.Builders, wantBuilders) {
+		t.Errorf("Builders = %+v\nwant %+v", r.Builders, wantBuilders)
+	}
+
+	wantCells := []Cell{
+		{"linux-amd64-x", "go", "master", "master", true, true},
+		{"linux-amd64-x", "go", "release-branch.go1.25", "release-branch.go1.25", true, false},
+		{"linux-amd64-x", "net", "master", "master", true, false},
+		{"linux-amd64-x", "net", "master", "release-branch.go1.25", true, false},
+		{"linux-amd64-x", "sys", "master", "master", true, false},
+		{"linux-amd64-x", "sys", "master", "release-branch.go1.25", true, false},
+		{"misc-compile-plan9-386", "go", "master", "master", false, true},
+		{"misc-compile-plan9-386", "go", "release-branch.go1.25", "release-branch.go1.25", false, true},
+		{"openbsd-arm64-x", "go", "master", "master", true, false},
+		{"openbsd-arm64-x", "sys", "master", "master", true, false},
+	}
+	if !reflect.DeepEqual(r.Cells, wantCells) {
+		t.Errorf("Cells = %+v\nwant %+v", r.Cells, wantCells)
+	}
+
+	// The try-only plan9 builder isn't expected to build anything
+	// post-submit.
+	wantGaps := []Gap{
+		{"openbsd-arm64", "go", "release-branch.go1.25"},
+		{"openbsd-arm64", "net", "master"},
+		{"openbsd-arm64", "net", "release-branch.go1.25"},
+		{"openbsd-arm64", "sys", "release-branch.go1.25"},
+	}
+	if !reflect.DeepEqual(r.Gaps, wantGaps) {
+		t.Errorf("Gaps = %+v\nwant %+v", r.Gaps, wantGaps)
+	}
+
+	var buf bytes.Buffer
+	if err := r.writeHTML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `<td class="cell">PT</td>`) {
+		t.Errorf("HTML report doesn't show linux-amd64-x building go as a trybot and post-submit:\n%s", buf.String())
+	}
+}
+
+func TestReportLive(t *testing.T) {
+	// All the builders of the coordinator are stopped, so the live
+	// report of one builds nothing, while its declared report does.
+	bc, ok := dashboard.Builders["linux-amd64"]
+	if !ok || !bc.IsStopped() {
+		t.Skip("linux-amd64 isn't stopped in the coordinator")
+	}
+	builders := map[string]*dashboard.BuildConfig{"linux-amd64": bc}
+	if r := newReport(builders, []string{"go"}, []string{"master"}, nil, false); len(r.Cells) != 0 {
+		t.Errorf("live report of a stopped builder has cells %+v", r.Cells)
+	}
+	r := newReport(builders, []string{"go"}, []string{"master"}, nil, true)
+	if len(r.Cells) != 1 || !r.Cells[0].PostSubmit || !r.Builders[0].Stopped {
+		t.Errorf("declared report of a stopped builder = %+v; want it to build go", r)
+	}
+}
+
+func TestWriteDiff(t *testing.T) {
+	repos, branches, distTests := []string{"go", "net", "sys"}, []string{"master"}, []string{"reboot", "test:0_5"}
+	old := newReport(compile(t, testBuilders), repos, branches, distTests, true)
+	changed := strings.NewReplacer(
+		"include: [go, sys]", "include: [go, net]",
+		`skip_try_dist_tests: ["test:*"]`, "",
+	).Replace(testBuilders)
+	r := newReport(compile(t, changed), repos, branches, distTests, true)
+
+	var buf bytes.Buffer
+	if err := r.writeDiff(&buf, old); err != nil {
+		t.Fatal(err)
+	}
+	want := `- openbsd-arm64-x sys@master go@master post-submit
+- linux-amd64-x skips dist test test:0_5 in trybots
+- gap: no openbsd-arm64 builder builds net with go@master post-submit
++ openbsd-arm64-x net@master go@master post-submit
++ gap: no openbsd-arm64 builder builds sys with go@master post-submit
+`
+	if got := buf.String(); got != want {
+		t.Errorf("diff:\n%s\nwant:\n%s", got, want)
+	}
+
+	other := newReport(compile(t, testBuilders), repos, []string{"release-branch.go1.25"}, distTests, true)
+	if err := r.writeDiff(&buf, other); err == nil {
+		t.Errorf("diff of reports of different branches succeeded")
+	}
+}
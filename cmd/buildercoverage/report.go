@@ -0,0 +1,230 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/build/dashboard"
+)
+
+// A Report is the coverage matrix of a set of builders over repos and
+// branches of Go.
+type Report struct {
+	Repos      []string `json:"repos"`
+	GoBranches []string `json:"goBranches"`
+	DistTests  []string `json:"distTests"`
+
+	Builders []Builder `json:"builders"` // sorted by name
+	Cells    []Cell    `json:"cells"`    // sorted by builder, repo and branch
+	Gaps     []Gap     `json:"gaps"`     // sorted by port, repo and branch
+}
+
+// A Builder is a builder of a Report.
+type Builder struct {
+	Name    string `json:"name"`
+	Port    string `json:"port"` // GOOS-GOARCH
+	Stopped bool   `json:"stopped,omitempty"`
+	TryOnly bool   `json:"tryOnly,omitempty"`
+
+	// SkippedDistTests and SkippedTryDistTests are the dist tests of
+	// the Report that the builder doesn't run post-submit and in normal
+	// trybot runs respectively.
+	SkippedDistTests    []string `json:"skippedDistTests,omitempty"`
+	SkippedTryDistTests []string `json:"skippedTryDistTests,omitempty"`
+}
+
+// A Cell is a repo and branch combination that a builder builds.
+type Cell struct {
+	Builder    string `json:"builder"`
+	Repo       string `json:"repo"`
+	Branch     string `json:"branch"`
+	GoBranch   string `json:"goBranch"`
+	PostSubmit bool   `json:"postSubmit,omitempty"`
+	TryBot     bool   `json:"tryBot,omitempty"`
+}
+
+// A Gap is a repo that no builder of a port builds post-submit with a
+// branch of Go, although builders of other ports do.
+type Gap struct {
+	Port     string `json:"port"`
+	Repo     string `json:"repo"`
+	GoBranch string `json:"goBranch"`
+}
+
+// newReport returns the coverage Report of builders. If declared is set,
+// builders stopped in the coordinator are reported with what they were
+// declared to build, rather than nothing.
+func newReport(builders map[string]*dashboard.BuildConfig, repos, goBranches, distTests []string, declared bool) *Report {
+	r := &Report{Repos: repos, GoBranches: goBranches, DistTests: distTests}
+	confs := make(map[string]*dashboard.BuildConfig)
+	for _, name := range slices.Sorted(maps.Keys(builders)) {
+		bc := builders[name]
+		b := Builder{
+			Name:    name,
+			Port:    port(bc),
+			Stopped: bc.IsStopped(),
+			TryOnly: bc.IsTryOnly(),
+		}
+		if declared {
+			bc = bc.Declared()
+		}
+		confs[name] = bc
+		for _, t := range distTests {
+			if !bc.ShouldRunDistTest(t, false) {
+				b.SkippedDistTests = append(b.SkippedDistTests, t)
+			}
+			if !bc.ShouldRunDistTest(t, true) {
+				b.SkippedTryDistTests = append(b.SkippedTryDistTests, t)
+			}
+		}
+		r.Builders = append(r.Builders, b)
+	}
+
+	// CoverageOf sorts the post-submit coverage of a combination right
+	// before its trybot coverage, so merge them into one cell.
+	for _, c := range dashboard.CoverageOf(confs, dashboard.RepoBranches(repos, goBranches)) {
+		if n := len(r.Cells); n > 0 {
+			last := &r.Cells[n-1]
+			if last.Builder == c.Builder && last.Repo == c.Repo && last.Branch == c.Branch && last.GoBranch == c.GoBranch {
+				last.TryBot = true
+				continue
+			}
+		}
+		r.Cells = append(r.Cells, Cell{
+			Builder:    c.Builder,
+			Repo:       c.Repo,
+			Branch:     c.Branch,
+			GoBranch:   c.GoBranch,
+			PostSubmit: !c.TryBot,
+			TryBot:     c.TryBot,
+		})
+	}
+	r.Gaps = r.gaps()
+	return r
+}
+
+// port returns the GOOS-GOARCH port that bc tests, which is usually in
+// its name, but for cross-compiling builders such as misc-compile-*, in
+// its environment.
+func port(bc *dashboard.BuildConfig) string {
+	goos, goarch := bc.GOOS(), bc.GOARCH()
+	for _, kv := range bc.Env() {
+		if v, ok := strings.CutPrefix(kv, "GOOS="); ok {
+			goos = v
+		} else if v, ok := strings.CutPrefix(kv, "GOARCH="); ok {
+			goarch = v
+		}
+	}
+	return goos + "-" + goarch
+}
+
+// gaps returns the gaps in the post-submit coverage of r's cells.
+func (r *Report) gaps() []Gap {
+	type repoBranch struct{ repo, goBranch string }
+	portOf := make(map[string]string)
+	ports := make(map[string]bool)
+	for _, b := range r.Builders {
+		portOf[b.Name] = b.Port
+		if !b.TryOnly {
+			ports[b.Port] = true
+		}
+	}
+	covered := make(map[repoBranch]map[string]bool) // -> ports
+	for _, c := range r.Cells {
+		if !c.PostSubmit {
+			continue
+		}
+		rb := repoBranch{c.Repo, c.GoBranch}
+		if covered[rb] == nil {
+			covered[rb] = make(map[string]bool)
+		}
+		covered[rb][portOf[c.Builder]] = true
+	}
+	var gaps []Gap
+	for rb, cov := range covered {
+		for p := range ports {
+			if !cov[p] {
+				gaps = append(gaps, Gap{p, rb.repo, rb.goBranch})
+			}
+		}
+	}
+	slices.SortFunc(gaps, compareGaps)
+	return gaps
+}
+
+func compareGaps(a, b Gap) int {
+	return cmp.Or(
+		cmp.Compare(a.Port, b.Port),
+		cmp.Compare(a.Repo, b.Repo),
+		cmp.Compare(a.GoBranch, b.GoBranch),
+	)
+}
+
+// writeDiff writes the changes in coverage from old to r, one per line:
+// the repo and branch combinations built, the dist tests skipped and the
+// gaps in post-submit coverage, prefixed with "+" if they were added and
+// "-" if they were removed. It reports an error if old covers different
+// repos, branches or dist tests.
+func (r *Report) writeDiff(w io.Writer, old *Report) error {
+	if !slices.Equal(old.Repos, r.Repos) || !slices.Equal(old.GoBranches, r.GoBranches) || !slices.Equal(old.DistTests, r.DistTests) {
+		return fmt.Errorf("the reports cover different repos, branches or dist tests; use the same -repos, -branches and -dist-tests flags")
+	}
+	diff(w, old.lines(), r.lines())
+	return nil
+}
+
+// lines returns the lines of r that writeDiff compares.
+func (r *Report) lines() []string {
+	var lines []string
+	for _, c := range r.Cells {
+		if c.PostSubmit {
+			lines = append(lines, fmt.Sprintf("%s %s@%s go@%s post-submit", c.Builder, c.Repo, c.Branch, c.GoBranch))
+		}
+		if c.TryBot {
+			lines = append(lines, fmt.Sprintf("%s %s@%s go@%s trybot", c.Builder, c.Repo, c.Branch, c.GoBranch))
+		}
+	}
+	for _, b := range r.Builders {
+		for _, t := range b.SkippedDistTests {
+			lines = append(lines, fmt.Sprintf("%s skips dist test %s post-submit", b.Name, t))
+		}
+		for _, t := range b.SkippedTryDistTests {
+			lines = append(lines, fmt.Sprintf("%s skips dist test %s in trybots", b.Name, t))
+		}
+	}
+	for _, g := range r.Gaps {
+		lines = append(lines, fmt.Sprintf("gap: no %s builder builds %s with go@%s post-submit", g.Port, g.Repo, g.GoBranch))
+	}
+	return lines
+}
+
+// diff writes the lines of new missing from old prefixed with "+", and
+// those of old missing from new prefixed with "-".
+func diff(w io.Writer, old, new []string) {
+	inOld := make(map[string]bool)
+	for _, l := range old {
+		inOld[l] = true
+	}
+	inNew := make(map[string]bool)
+	for _, l := range new {
+		inNew[l] = true
+	}
+	for _, l := range old {
+		if !inNew[l] {
+			fmt.Fprintf(w, "- %s\n", l)
+		}
+	}
+	for _, l := range new {
+		if !inOld[l] {
+			fmt.Fprintf(w, "+ %s\n", l)
+		}
+	}
+}
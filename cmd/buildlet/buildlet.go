@@ -90,7 +90,11 @@ var (
 //	27: export GOPLSCACHE=$workdir/goplscache
 //	28: add support for gomote server
 //	29: fall back to /bin/sh when SHELL is unset
-const buildletVersion = 29
+//	30: add /syncmanifest and /writesync
+//	31: add /tcpproxy and /tcplisten
+//	32: multiplex reverse connections over a resumable muxconn session
+//	33: per-exec resource limits
+const buildletVersion = 33
 
 func defaultListenAddr() string {
 	if runtime.GOOS == "darwin" {
@@ -248,13 +252,18 @@ func main() {
 	http.Handle("/writetgz", requireAuth(handleWriteTGZ))
 	http.Handle("/write", requireAuth(handleWrite))
 	http.Handle("/exec", requireAuth(handleExec))
+	http.Handle("/exec-stream", requireAuth(handleExecStream))
 	http.Handle("/halt", requireAuth(handleHalt))
 	http.Handle("/tgz", requireAuth(handleGetTGZ))
 	http.Handle("/removeall", requireAuth(handleRemoveAll))
 	http.Handle("/workdir", requireAuth(handleWorkDir))
 	http.Handle("/status", requireAuth(handleStatus))
 	http.Handle("/ls", requireAuth(handleLs))
+	http.Handle("/syncmanifest", requireAuth(handleSyncManifest))
+	http.Handle("/writesync", requireAuth(handleWriteSync))
 	http.Handle("/connect-ssh", requireAuth(handleConnectSSH))
+	http.Handle("/tcpproxy", requireAuth(handleTCPProxy))
+	http.Handle("/tcplisten", requireAuth(handleTCPListen))
 	http.HandleFunc("/healthz", handleHealthz)
 
 	if !isReverse && !*swarmingBot {
@@ -308,6 +317,11 @@ func listenForCoordinator() {
 	if (tlsCert == "") != (tlsKey == "") {
 		log.Fatalf("tls-cert and tls-key must both be supplied, or neither.")
 	}
+	// The credentials may come from the environment, which the
+	// commands the buildlet runs inherit. They mustn't see them.
+	for _, key := range []string{metaKeyPassword, metaKeyTLSCert, metaKeyTLSkey} {
+		os.Unsetenv(metaEnvKey(key))
+	}
 
 	log.Printf("Listening on %s ...", *listenAddr)
 	ln, err := net.Listen("tcp", *listenAddr)
@@ -442,7 +456,7 @@ func metadataValue(key string) string {
 
 	// Else allow use of environment variables to fake
 	// metadata keys, for Kubernetes pods or local testing.
-	envKey := "META_" + strings.Replace(key, "-", "_", -1)
+	envKey := metaEnvKey(key)
 	v := os.Getenv(envKey)
 	// Respect curl-style '@' prefix to mean the rest is a filename.
 	if strings.HasPrefix(v, "@") {
@@ -458,6 +472,12 @@ func metadataValue(key string) string {
 	return v
 }
 
+// metaEnvKey returns the environment variable that fakes the metadata
+// key when not running on a cloud provider.
+func metaEnvKey(key string) string {
+	return "META_" + strings.Replace(key, "-", "_", -1)
+}
+
 // tcpKeepAliveListener is a net.Listener that sets TCP keep-alive
 // timeouts on accepted connections.
 type tcpKeepAliveListener struct {
@@ -881,6 +901,10 @@ func untar(r io.Reader, dir string) (err error) {
 // on success, or os.ProcessState.String() on failure.
 const hdrProcessState = "Process-State"
 
+// Process-Result is an HTTP Trailer set in the /exec handler to the
+// JSON buildlet.ExecResult of the command.
+const hdrProcessResult = "Process-Result"
+
 func handleExec(w http.ResponseWriter, r *http.Request) {
 	cn := w.(http.CloseNotifier)
 	clientGone := cn.CloseNotify()
@@ -896,43 +920,91 @@ func handleExec(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "HTTP/1.1 or higher required", http.StatusBadRequest)
 		return
 	}
-	// Create *workDir and any needed temporary subdirectories.
-	if !mkdirAllWorkdirOr500(w) {
+	w.Header().Set("Trailer", hdrProcessState+", "+hdrProcessResult) // declare them so we can set them
+
+	cmd, sb, err := newExecCmd(r)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
-	for _, dir := range []string{processTmpDirEnv, processGoCacheEnv, processGoplsCacheEnv} {
+	defer sb.release() // in case the command never runs
+	debug, _ := strconv.ParseBool(r.FormValue("debug"))
+
+	var cmdOutput io.Writer = flushWriter{w}
+	cmd.Stdout = cmdOutput
+	cmd.Stderr = cmdOutput
+	if r.FormValue("stderr") == "separate" {
+		w.Header().Set("Content-Type", buildlet.ExecFramesContentType)
+		s := &execStream{w: flushWriter{w}}
+		cmdOutput = execFrameWriter{s, buildlet.ExecFrameStdout}
+		cmd.Stdout = cmdOutput
+		cmd.Stderr = execFrameWriter{s, buildlet.ExecFrameStderr}
+	}
+
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	log.Printf("[%p] Running %s with args %q and env %q in dir %s",
+		cmd, cmd.Path, cmd.Args, cmd.Env, cmd.Dir)
+
+	if debug {
+		fmt.Fprintf(cmdOutput, ":: Running %s with args %q and env %q in dir %s\n\n",
+			cmd.Path, cmd.Args, cmd.Env, cmd.Dir)
+	}
+
+	t0 := time.Now()
+	err = sb.start(cmd.Start)
+	if err == nil {
+		go func() {
+			select {
+			case <-clientGone:
+				err := killProcessTree(cmd.Process)
+				if err != nil {
+					log.Printf("Kill failed: %v", err)
+				}
+			case <-handlerDone:
+				return
+			}
+		}()
+		err = cmd.Wait()
+	}
+	res := execResult(cmd, err, t0)
+	sb.finish(res)
+	w.Header().Set(hdrProcessState, res.State)
+	if j, err := json.Marshal(res); err == nil {
+		w.Header().Set(hdrProcessResult, string(j))
+	}
+	log.Printf("[%p] Run = %s, after %v", cmd, res.State, res.WallTime)
+}
+
+// newExecCmd prepares the buildlet for running the command of an /exec
+// or /exec-stream request, and returns it along with the sandbox that
+// enforces its limits.
+func newExecCmd(r *http.Request) (*exec.Cmd, *sandbox, error) {
+	// Create *workDir and any needed temporary subdirectories.
+	for _, dir := range []string{*workDir, processTmpDirEnv, processGoCacheEnv, processGoplsCacheEnv} {
 		if dir == "" {
 			continue
 		}
 		if err := os.MkdirAll(dir, 0755); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+			return nil, nil, err
 		}
 	}
 	if err := checkAndroidEmulator(); err != nil {
-		http.Error(w, "android emulator not running: "+err.Error(), http.StatusInternalServerError)
-		return
+		return nil, nil, fmt.Errorf("android emulator not running: %w", err)
 	}
 
-	w.Header().Set("Trailer", hdrProcessState) // declare it so we can set it
-
 	sysMode := r.FormValue("mode") == "sys"
-	debug, _ := strconv.ParseBool(r.FormValue("debug"))
 
 	absCmd, err := absExecCmd(r.FormValue("cmd"), sysMode) // required
 	if err != nil {
-		http.Error(w, "invalid 'cmd' parameter: "+err.Error(), httpStatus(err))
-		return
+		return nil, nil, fmt.Errorf("invalid 'cmd' parameter: %w", err)
 	}
 
 	absDir, err := absExecDir(r.FormValue("dir"), sysMode, filepath.Dir(absCmd)) // optional
 	if err != nil {
-		http.Error(w, "invalid 'dir' parameter: "+err.Error(), httpStatus(err))
-		return
-	}
-
-	if f, ok := w.(http.Flusher); ok {
-		f.Flush()
+		return nil, nil, fmt.Errorf("invalid 'dir' parameter: %w", err)
 	}
 
 	postEnv := r.PostForm["env"]
@@ -971,44 +1043,11 @@ func handleExec(w http.ResponseWriter, r *http.Request) {
 	cmd.Args = append(cmd.Args, r.PostForm["cmdArg"]...)
 	cmd.Env = env
 	envutil.SetDir(cmd, absDir)
-	cmdOutput := flushWriter{w}
-	cmd.Stdout = cmdOutput
-	cmd.Stderr = cmdOutput
-
-	log.Printf("[%p] Running %s with args %q and env %q in dir %s",
-		cmd, cmd.Path, cmd.Args, cmd.Env, cmd.Dir)
-
-	if debug {
-		fmt.Fprintf(cmdOutput, ":: Running %s with args %q and env %q in dir %s\n\n",
-			cmd.Path, cmd.Args, cmd.Env, cmd.Dir)
-	}
-
-	t0 := time.Now()
-	err = cmd.Start()
-	if err == nil {
-		go func() {
-			select {
-			case <-clientGone:
-				err := killProcessTree(cmd.Process)
-				if err != nil {
-					log.Printf("Kill failed: %v", err)
-				}
-			case <-handlerDone:
-				return
-			}
-		}()
-		err = cmd.Wait()
-	}
-	state := "ok"
+	sb, err := newSandbox(cmd, r.FormValue("limits"))
 	if err != nil {
-		if ps := cmd.ProcessState; ps != nil {
-			state = ps.String()
-		} else {
-			state = err.Error()
-		}
+		return nil, nil, err
 	}
-	w.Header().Set(hdrProcessState, state)
-	log.Printf("[%p] Run = %s, after %v", cmd, state, time.Since(t0))
+	return cmd, sb, nil
 }
 
 // absExecCmd returns the native, absolute path corresponding to the "cmd"
@@ -1318,6 +1357,7 @@ func handleRemoveAll(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Removing %s", p)
 		fullDir := filepath.Join(*workDir, filepath.FromSlash(p))
 		err := removeAllIncludingReadonly(fullDir)
+		forgetSyncHashes(fullDir)
 		if p == "." && err != nil {
 			// If workDir is a mountpoint and/or contains a binary
 			// using it, we can get a "Device or resource busy" error.
@@ -0,0 +1,225 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/internal/envutil"
+)
+
+// handleExecStream runs a command like handleExec, but upgrades the
+// connection to the bidirectional protocol described by
+// buildlet.ExecFrameType, so that the client can interact with it.
+func handleExecStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "requires POST method", http.StatusBadRequest)
+		return
+	}
+	cmd, sb, err := newExecCmd(r)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	defer sb.release() // in case the command never runs
+	tty, _ := strconv.ParseBool(r.FormValue("tty"))
+	if term := r.FormValue("term"); tty && term != "" {
+		envutil.SetEnv(cmd, "TERM="+term)
+	}
+	rows, _ := strconv.ParseUint(r.FormValue("rows"), 10, 16)
+	cols, _ := strconv.ParseUint(r.FormValue("cols"), 10, 16)
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "conn can't hijack", http.StatusInternalServerError)
+		return
+	}
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		log.Printf("exec-stream hijack error: %v", err)
+		http.Error(w, "exec-stream hijack error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer conn.Close()
+	fmt.Fprintf(conn, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: buildlet-exec\r\nConnection: Upgrade\r\n\r\n")
+
+	s := &execStream{w: conn}
+	log.Printf("[%p] Running %s interactively (tty=%v) with args %q and env %q in dir %s",
+		cmd, cmd.Path, tty, cmd.Args, cmd.Env, cmd.Dir)
+
+	// stdin is where the client's input goes: the pseudo-terminal if
+	// there is one, otherwise a pipe to the command.
+	var stdin io.WriteCloser
+	var ptmx *os.File
+	outputDone := make(chan struct{})
+	t0 := time.Now()
+	if tty {
+		err = sb.start(func() (err error) {
+			ptmx, err = startPTY(cmd, uint16(rows), uint16(cols))
+			return err
+		})
+		if err == nil {
+			stdin = ptmx
+			go func() {
+				defer close(outputDone)
+				io.Copy(execFrameWriter{s, buildlet.ExecFrameStdout}, ptmx)
+			}()
+		}
+	} else {
+		close(outputDone)
+		stdin, err = cmd.StdinPipe()
+		if err == nil {
+			cmd.Stdout = execFrameWriter{s, buildlet.ExecFrameStdout}
+			cmd.Stderr = execFrameWriter{s, buildlet.ExecFrameStderr}
+			err = sb.start(cmd.Start)
+		}
+	}
+	if err != nil {
+		res := execResult(cmd, err, t0)
+		sb.finish(res)
+		s.sendResult(res)
+		return
+	}
+
+	exited := make(chan struct{})
+	input := make(chan []byte, 64) // stdin data; nil means close stdin
+	go func() {
+		for p := range input {
+			if p == nil {
+				if tty {
+					stdin.Write([]byte{4}) // ^D
+				} else {
+					stdin.Close()
+				}
+				continue
+			}
+			stdin.Write(p)
+		}
+	}()
+	go func() {
+		defer close(input)
+		for {
+			typ, p, err := buildlet.ReadExecFrame(brw.Reader)
+			if err != nil {
+				select {
+				case <-exited:
+				default:
+					// The client is gone.
+					if err := killProcessTree(cmd.Process); err != nil {
+						log.Printf("Kill failed: %v", err)
+					}
+				}
+				return
+			}
+			switch typ {
+			case buildlet.ExecFrameStdin:
+				input <- p
+			case buildlet.ExecFrameCloseStdin:
+				input <- nil
+			case buildlet.ExecFrameResize:
+				if r, c, ok := buildlet.DecodeWindowSize(p); ok && ptmx != nil {
+					resizePTY(ptmx, r, c)
+				}
+			case buildlet.ExecFrameSignal:
+				var err error
+				if string(p) == "KILL" {
+					err = killProcessTree(cmd.Process)
+				} else {
+					err = signalProcess(cmd.Process, string(p), tty)
+				}
+				if err != nil {
+					s.send(buildlet.ExecFrameStderr, []byte(fmt.Sprintf("buildlet: sending signal %q: %v\n", p, err)))
+				}
+			}
+		}
+	}()
+
+	err = cmd.Wait()
+	close(exited)
+	if ptmx != nil {
+		// Give the output copier a moment to drain what the command
+		// wrote before it exited, but don't wait on any background
+		// processes still holding the terminal.
+		select {
+		case <-outputDone:
+		case <-time.After(2 * time.Second):
+		}
+		ptmx.Close()
+	}
+	res := execResult(cmd, err, t0)
+	sb.finish(res)
+	s.sendResult(res)
+	log.Printf("[%p] Run = %s, after %v", cmd, res.State, res.WallTime)
+}
+
+// execResult describes how cmd, started at t0, ended, given the error
+// from running it.
+func execResult(cmd *exec.Cmd, err error, t0 time.Time) *buildlet.ExecResult {
+	res := &buildlet.ExecResult{ExitCode: -1, State: "ok", WallTime: time.Since(t0)}
+	ps := cmd.ProcessState
+	if ps != nil {
+		res.ExitCode = ps.ExitCode()
+		res.Signal = exitSignal(ps)
+		res.UserTime = ps.UserTime()
+		res.SystemTime = ps.SystemTime()
+		res.MaxRSS = maxRSS(ps)
+	}
+	if err != nil {
+		if ps != nil {
+			res.State = ps.String()
+		} else {
+			res.State = err.Error()
+		}
+	}
+	return res
+}
+
+// An execStream writes frames of the /exec-stream protocol.
+type execStream struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *execStream) send(typ buildlet.ExecFrameType, p []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return buildlet.WriteExecFrame(s.w, typ, p)
+}
+
+func (s *execStream) sendResult(res *buildlet.ExecResult) {
+	p, err := json.Marshal(res)
+	if err != nil {
+		panic(err) // can't happen
+	}
+	s.send(buildlet.ExecFrameExit, p)
+}
+
+// execFrameWriter is an io.Writer that sends what's written to it as
+// frames of a type.
+type execFrameWriter struct {
+	s   *execStream
+	typ buildlet.ExecFrameType
+}
+
+func (w execFrameWriter) Write(p []byte) (n int, err error) {
+	for n < len(p) {
+		chunk := p[n:min(len(p), n+32<<10)]
+		if err := w.s.send(w.typ, chunk); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+	}
+	return n, nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build plan9 || windows
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+func startPTY(cmd *exec.Cmd, rows, cols uint16) (*os.File, error) {
+	return nil, fmt.Errorf("pseudo-terminals are not supported on %s", runtime.GOOS)
+}
+
+func resizePTY(f *os.File, rows, cols uint16) error {
+	return errors.New("pseudo-terminals are not supported")
+}
+
+func signalProcess(p *os.Process, name string, group bool) error {
+	return fmt.Errorf("signal %q is not supported on %s", name, runtime.GOOS)
+}
+
+func exitSignal(ps *os.ProcessState) string {
+	return ""
+}
+
+func maxRSS(ps *os.ProcessState) int64 {
+	return 0
+}
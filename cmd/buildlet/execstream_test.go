@@ -0,0 +1,168 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !plan9 && !windows
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"golang.org/x/build/buildlet"
+)
+
+// newExecTestClient returns a client of a buildlet serving /exec and
+// /exec-stream in a temporary work directory.
+func newExecTestClient(t *testing.T) buildlet.Client {
+	t.Helper()
+	old := *workDir
+	*workDir = t.TempDir()
+	t.Cleanup(func() { *workDir = old })
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/exec", handleExec)
+	mux.HandleFunc("/exec-stream", handleExecStream)
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := buildlet.NewClient(u.Host, buildlet.NoKeyPair)
+	t.Cleanup(func() { cl.Close() })
+	return cl
+}
+
+func TestExecSeparateStderr(t *testing.T) {
+	cl := newExecTestClient(t)
+	var stdout, stderr bytes.Buffer
+	var res *buildlet.ExecResult
+	remoteErr, execErr := cl.Exec(context.Background(), "/bin/sh", buildlet.ExecOpts{
+		SystemLevel: true,
+		Args:        []string{"-c", "echo '{\"Action\":\"pass\"}'; echo warning >&2; exit 2"},
+		Output:      &stdout,
+		Stderr:      &stderr,
+		OnExit:      func(r *buildlet.ExecResult) { res = r },
+	})
+	if execErr != nil {
+		t.Fatalf("Exec error = %v", execErr)
+	}
+	if remoteErr == nil || remoteErr.Error() != "exit status 2" {
+		t.Errorf("remote error = %v; want exit status 2", remoteErr)
+	}
+	if got, want := stdout.String(), "{\"Action\":\"pass\"}\n"; got != want {
+		t.Errorf("stdout = %q; want %q", got, want)
+	}
+	if got, want := stderr.String(), "warning\n"; got != want {
+		t.Errorf("stderr = %q; want %q", got, want)
+	}
+	if res == nil || res.ExitCode != 2 || res.WallTime <= 0 || res.State != "exit status 2" {
+		t.Errorf("result = %+v; want exit code 2 with a wall time", res)
+	}
+}
+
+func TestExecCombinedOutput(t *testing.T) {
+	cl := newExecTestClient(t)
+	var out bytes.Buffer
+	var res *buildlet.ExecResult
+	remoteErr, execErr := cl.Exec(context.Background(), "/bin/sh", buildlet.ExecOpts{
+		SystemLevel: true,
+		Args:        []string{"-c", "echo out; echo err >&2"},
+		Output:      &out,
+		OnExit:      func(r *buildlet.ExecResult) { res = r },
+	})
+	if remoteErr != nil || execErr != nil {
+		t.Fatalf("Exec = %v, %v; want success", remoteErr, execErr)
+	}
+	if got, want := out.String(), "out\nerr\n"; got != want {
+		t.Errorf("output = %q; want %q", got, want)
+	}
+	if res == nil || res.ExitCode != 0 || res.Err() != nil {
+		t.Errorf("result = %+v; want success", res)
+	}
+}
+
+func startExecStream(t *testing.T, cmd string, opts buildlet.ExecSessionOpts) *buildlet.ExecSession {
+	t.Helper()
+	cl := newExecTestClient(t)
+	opts.SystemLevel = true
+	s, err := cl.StartExec(context.Background(), cmd, opts)
+	if err != nil {
+		t.Fatalf("StartExec = %v", err)
+	}
+	return s
+}
+
+func TestExecStream(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	s := startExecStream(t, "/bin/sh", buildlet.ExecSessionOpts{
+		ExecOpts: buildlet.ExecOpts{
+			Args:   []string{"-c", "read x; echo got $x; echo oops >&2; exit 3"},
+			Output: &stdout,
+			Stderr: &stderr,
+		},
+	})
+	if _, err := s.Write([]byte("gopher\n")); err != nil {
+		t.Fatalf("Write = %v", err)
+	}
+	res, err := s.Wait()
+	if err != nil {
+		t.Fatalf("Wait = %v", err)
+	}
+	if res.ExitCode != 3 || res.Err() == nil {
+		t.Errorf("result = %+v; want exit code 3", res)
+	}
+	if got, want := stdout.String(), "got gopher\n"; got != want {
+		t.Errorf("stdout = %q; want %q", got, want)
+	}
+	if got, want := stderr.String(), "oops\n"; got != want {
+		t.Errorf("stderr = %q; want %q", got, want)
+	}
+}
+
+func TestExecStreamSignal(t *testing.T) {
+	s := startExecStream(t, "/bin/sh", buildlet.ExecSessionOpts{
+		ExecOpts: buildlet.ExecOpts{Args: []string{"-c", "echo ready; exec sleep 60"}},
+	})
+	if err := s.Signal("TERM"); err != nil {
+		t.Fatalf("Signal = %v", err)
+	}
+	res, err := s.Wait()
+	if err != nil {
+		t.Fatalf("Wait = %v", err)
+	}
+	if res.ExitCode != -1 || res.Signal != "terminated" {
+		t.Errorf("result = %+v; want killed by SIGTERM", res)
+	}
+}
+
+func TestExecStreamTTY(t *testing.T) {
+	var out bytes.Buffer
+	s := startExecStream(t, "/bin/sh", buildlet.ExecSessionOpts{
+		ExecOpts: buildlet.ExecOpts{
+			Args:   []string{"-c", "stty size; test -t 0 && echo tty"},
+			Output: &out,
+		},
+		TTY:  true,
+		Term: "xterm",
+		Rows: 24,
+		Cols: 80,
+	})
+	res, err := s.Wait()
+	if err != nil {
+		t.Fatalf("Wait = %v", err)
+	}
+	if res.Err() != nil {
+		t.Fatalf("result = %+v; want success (output %q)", res, out.String())
+	}
+	if got := strings.ReplaceAll(out.String(), "\r\n", "\n"); got != "24 80\ntty\n" {
+		t.Errorf("output = %q; want terminal size and confirmation", got)
+	}
+}
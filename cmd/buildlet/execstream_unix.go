@@ -0,0 +1,77 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !plan9 && !windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+
+	"github.com/creack/pty"
+)
+
+// startPTY starts cmd in a new session, with a pseudo-terminal of the
+// given size as its controlling terminal, and returns the terminal.
+func startPTY(cmd *exec.Cmd, rows, cols uint16) (*os.File, error) {
+	if cmd.SysProcAttr != nil {
+		// The session is a process group already.
+		cmd.SysProcAttr.Setpgid = false
+	}
+	return pty.StartWithSize(cmd, &pty.Winsize{Rows: rows, Cols: cols})
+}
+
+func resizePTY(f *os.File, rows, cols uint16) error {
+	return pty.Setsize(f, &pty.Winsize{Rows: rows, Cols: cols})
+}
+
+var execSignals = map[string]syscall.Signal{
+	"HUP":   syscall.SIGHUP,
+	"INT":   syscall.SIGINT,
+	"QUIT":  syscall.SIGQUIT,
+	"TERM":  syscall.SIGTERM,
+	"USR1":  syscall.SIGUSR1,
+	"USR2":  syscall.SIGUSR2,
+	"STOP":  syscall.SIGSTOP,
+	"CONT":  syscall.SIGCONT,
+	"WINCH": syscall.SIGWINCH,
+}
+
+// signalProcess sends the named signal to p, or to its process group
+// if group is set.
+func signalProcess(p *os.Process, name string, group bool) error {
+	sig, ok := execSignals[name]
+	if !ok {
+		return fmt.Errorf("unsupported signal %q", name)
+	}
+	if group {
+		return syscall.Kill(-p.Pid, sig)
+	}
+	return p.Signal(sig)
+}
+
+// exitSignal returns the name of the signal that killed the process,
+// if any.
+func exitSignal(ps *os.ProcessState) string {
+	if ws, ok := ps.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		return ws.Signal().String()
+	}
+	return ""
+}
+
+// maxRSS returns the peak resident set size of the process in bytes.
+func maxRSS(ps *os.ProcessState) int64 {
+	ru, ok := ps.SysUsage().(*syscall.Rusage)
+	if !ok {
+		return 0
+	}
+	if runtime.GOOS == "darwin" || runtime.GOOS == "ios" {
+		return int64(ru.Maxrss) // already in bytes
+	}
+	return int64(ru.Maxrss) * 1024
+}
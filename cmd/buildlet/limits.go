@@ -0,0 +1,252 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"golang.org/x/build/buildlet"
+)
+
+// An osSandbox enforces the limits of an exec request that need the
+// operating system's help: CPU, Memory, Processes and NoNetwork.
+type osSandbox interface {
+	// start calls start, which starts the command, in the sandbox.
+	start(start func() error) error
+	// exceeded returns the limit the operating system stopped the
+	// command from exceeding, if any.
+	exceeded() string
+	// kill kills all the processes in the sandbox.
+	kill()
+	// close kills all the processes in the sandbox and releases its
+	// resources.
+	close()
+}
+
+// newOSSandbox, if non-nil, returns an osSandbox for running cmd with
+// lim. It's set by the platforms that support it.
+var newOSSandbox func(cmd *exec.Cmd, lim *buildlet.ExecLimits) (osSandbox, error)
+
+// diskCheckInterval is how often the disk usage of a command with a
+// disk limit is checked.
+var diskCheckInterval = 5 * time.Second
+
+// runningExecs counts the commands with a sandbox that hasn't been
+// released. Disk limits are enforced on the growth of the whole work
+// directory, which other commands also change, so a command with a disk
+// limit only runs when no other command does.
+var runningExecs struct {
+	sync.Mutex
+	n           int
+	diskLimited bool // whether the one running command has a disk limit
+}
+
+// acquireExec records that a command, with a disk limit if diskLimited,
+// is about to run. It fails if that would run a command with a disk
+// limit alongside another command.
+func acquireExec(diskLimited bool) error {
+	runningExecs.Lock()
+	defer runningExecs.Unlock()
+	if runningExecs.diskLimited {
+		return httpError{http.StatusConflict, errors.New("a command with a disk limit is running")}
+	}
+	if diskLimited && runningExecs.n > 0 {
+		return httpError{http.StatusConflict, fmt.Errorf("disk limits need the buildlet to themselves, but %d other commands are running", runningExecs.n)}
+	}
+	runningExecs.n++
+	runningExecs.diskLimited = diskLimited
+	return nil
+}
+
+// releaseExec records that a command acquired with acquireExec is done.
+func releaseExec() {
+	runningExecs.Lock()
+	defer runningExecs.Unlock()
+	runningExecs.n--
+	runningExecs.diskLimited = false
+}
+
+// A sandbox enforces the limits of an exec request on its command.
+// A sandbox without limits does nothing. Commands with limits run in a
+// process group of their own, so that they can be killed along with
+// the processes they started.
+type sandbox struct {
+	cmd     *exec.Cmd
+	limited bool // whether there are limits
+	lim     buildlet.ExecLimits
+	os      osSandbox // nil if not needed
+	// diskBase is the size of the work directory before the command
+	// started, if it has a disk limit.
+	diskBase int64
+	// release releases the command's acquireExec slot, once.
+	release func()
+
+	timer *time.Timer
+	stop  chan struct{} // closed when the command is done
+	wg    sync.WaitGroup
+
+	mu  sync.Mutex
+	hit string // the limit the command exceeded, if any
+}
+
+// newSandbox returns a sandbox that enforces the JSON buildlet.ExecLimits
+// limits, if any, on cmd. The caller must call finish or release once
+// the command is done, or won't be run.
+func newSandbox(cmd *exec.Cmd, limits string) (_ *sandbox, err error) {
+	sb := &sandbox{cmd: cmd, stop: make(chan struct{})}
+	lim := &sb.lim
+	if limits != "" {
+		sb.limited = true
+		if err := json.Unmarshal([]byte(limits), lim); err != nil {
+			return nil, badRequestf("invalid 'limits' parameter: %w", err)
+		}
+		if lim.CPU < 0 || lim.Memory < 0 || lim.WallTime < 0 || lim.Processes < 0 || lim.Disk < 0 {
+			return nil, badRequestf("invalid 'limits' parameter: negative limit")
+		}
+	}
+	if err := acquireExec(lim.Disk > 0); err != nil {
+		return nil, err
+	}
+	sb.release = sync.OnceFunc(releaseExec)
+	defer func() {
+		if err != nil {
+			sb.release()
+		}
+	}()
+	if !sb.limited {
+		return sb, nil
+	}
+	if lim.CPU > 0 || lim.Memory > 0 || lim.Processes > 0 || lim.NoNetwork {
+		if newOSSandbox == nil {
+			return nil, httpError{http.StatusUnprocessableEntity, fmt.Errorf("CPU, memory, process and network limits are not supported on %s", runtime.GOOS)}
+		}
+		var err error
+		sb.os, err = newOSSandbox(cmd, lim)
+		if err != nil {
+			return nil, httpError{http.StatusUnprocessableEntity, err}
+		}
+	}
+	if lim.Disk > 0 {
+		sb.diskBase = dirSize(*workDir)
+	}
+	setProcessGroup(cmd)
+	return sb, nil
+}
+
+// start calls start, which starts the command, and begins enforcing the
+// limits. The caller must call finish once the command is done, even if
+// start fails.
+func (sb *sandbox) start(start func() error) error {
+	var err error
+	if sb.os != nil {
+		err = sb.os.start(start)
+	} else {
+		err = start()
+	}
+	if err != nil {
+		return err
+	}
+	if d := sb.lim.WallTime; d > 0 {
+		sb.timer = time.AfterFunc(d, func() { sb.exceed(buildlet.LimitWallTime) })
+	}
+	if sb.lim.Disk > 0 {
+		sb.wg.Go(sb.watchDisk)
+	}
+	return nil
+}
+
+// exceed records that the command exceeded limit, and kills it.
+func (sb *sandbox) exceed(limit string) {
+	sb.mu.Lock()
+	if sb.hit == "" {
+		sb.hit = limit
+	}
+	sb.mu.Unlock()
+	log.Printf("[%p] Killing command: %s limit exceeded", sb.cmd, limit)
+	sb.kill()
+}
+
+// kill kills the command and the processes it started.
+func (sb *sandbox) kill() {
+	if sb.os != nil {
+		sb.os.kill()
+	}
+	if err := killProcessGroup(sb.cmd.Process); err != nil {
+		log.Printf("Kill failed: %v", err)
+	}
+}
+
+// watchDisk kills the command if the work directory grows by more than
+// its disk limit.
+func (sb *sandbox) watchDisk() {
+	t := time.NewTicker(diskCheckInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-sb.stop:
+			return
+		case <-t.C:
+		}
+		if dirSize(*workDir)-sb.diskBase > sb.lim.Disk {
+			sb.exceed(buildlet.LimitDisk)
+			return
+		}
+	}
+}
+
+// finish stops enforcing the limits, kills what's left of the command
+// if it had limits, and records the limit it exceeded in res.
+func (sb *sandbox) finish(res *buildlet.ExecResult) {
+	if sb.timer != nil {
+		sb.timer.Stop()
+	}
+	close(sb.stop)
+	sb.wg.Wait()
+	sb.mu.Lock()
+	hit := sb.hit
+	sb.mu.Unlock()
+	if sb.os != nil && hit == "" {
+		hit = sb.os.exceeded()
+	}
+	if sb.limited && sb.cmd.Process != nil {
+		killProcessGroup(sb.cmd.Process)
+	}
+	if sb.os != nil {
+		sb.os.close()
+	}
+	sb.release()
+	if hit == "" {
+		return
+	}
+	res.LimitExceeded = hit
+	if res.State != "ok" {
+		res.State += fmt.Sprintf(" (%s limit exceeded)", hit)
+	}
+}
+
+// dirSize returns the total size of the regular files in dir.
+func dirSize(dir string) int64 {
+	var size int64
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil // files may disappear while walking
+		}
+		if fi, err := d.Info(); err == nil {
+			size += fi.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,318 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"golang.org/x/build/buildlet"
+	"golang.org/x/sys/unix"
+)
+
+func init() {
+	newOSSandbox = newLinuxSandbox
+}
+
+// A linuxSandbox runs a command in a cgroup of its own, and optionally
+// in a network namespace of its own.
+type linuxSandbox struct {
+	noNetwork bool
+	cgroup    string   // the cgroup's directory, if any
+	fd        *os.File // the open cgroup directory
+}
+
+// cgroupSeq numbers the cgroups of commands.
+var cgroupSeq atomic.Int64
+
+func newLinuxSandbox(cmd *exec.Cmd, lim *buildlet.ExecLimits) (osSandbox, error) {
+	if lim.NoNetwork {
+		if err := canUnshareNetwork(); err != nil {
+			return nil, err
+		}
+	}
+	sb := &linuxSandbox{noNetwork: lim.NoNetwork}
+	if lim.CPU == 0 && lim.Memory == 0 && lim.Processes == 0 {
+		return sb, nil
+	}
+	parent, err := cgroupParent()
+	if err != nil {
+		return nil, fmt.Errorf("resource limits need cgroup v2: %w", err)
+	}
+	enabled, err := os.ReadFile(filepath.Join(parent, "cgroup.subtree_control"))
+	if err != nil {
+		return nil, err
+	}
+	for c, needed := range map[string]bool{"cpu": lim.CPU > 0, "memory": lim.Memory > 0, "pids": lim.Processes > 0} {
+		if needed && !slices.Contains(strings.Fields(string(enabled)), c) {
+			return nil, fmt.Errorf("cgroup controller %s isn't available in %s", c, parent)
+		}
+	}
+	sb.cgroup = filepath.Join(parent, fmt.Sprintf("exec-%d-%d", os.Getpid(), cgroupSeq.Add(1)))
+	if err := os.Mkdir(sb.cgroup, 0755); err != nil {
+		return nil, err
+	}
+	var settings [][2]string
+	if lim.CPU > 0 {
+		const period = 100000 // µs
+		quota := max(int(lim.CPU*period), 1000)
+		settings = append(settings, [2]string{"cpu.max", fmt.Sprintf("%d %d", quota, period)})
+	}
+	if lim.Memory > 0 {
+		settings = append(settings,
+			[2]string{"memory.max", strconv.FormatInt(lim.Memory, 10)},
+			[2]string{"memory.swap.max", "0"},
+			[2]string{"memory.oom.group", "1"}) // a runaway test takes its children with it
+	}
+	if lim.Processes > 0 {
+		settings = append(settings, [2]string{"pids.max", strconv.Itoa(lim.Processes)})
+	}
+	for _, s := range settings {
+		err := os.WriteFile(filepath.Join(sb.cgroup, s[0]), []byte(s[1]), 0)
+		if err != nil && s[0] != "memory.swap.max" { // not there without swap accounting
+			sb.close()
+			return nil, fmt.Errorf("setting %s: %w", s[0], err)
+		}
+	}
+	sb.fd, err = os.Open(sb.cgroup)
+	if err != nil {
+		sb.close()
+		return nil, err
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = new(syscall.SysProcAttr)
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(sb.fd.Fd())
+	return sb, nil
+}
+
+// cgroupParent returns the directory of the cgroup under which the
+// buildlet creates the cgroups of commands. It's the buildlet's own
+// cgroup, whose processes, the buildlet's and any others such as those
+// of a wrapper that started it, move to a "buildlet" child cgroup so
+// that controllers can be enabled for the others: a cgroup other than
+// the root with processes of its own can't delegate them.
+var cgroupParent = sync.OnceValues(func() (string, error) {
+	mount, err := cgroup2Mount()
+	if err != nil {
+		return "", err
+	}
+	self, err := os.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return "", err
+	}
+	var path string
+	for line := range strings.Lines(string(self)) {
+		if p, ok := strings.CutPrefix(line, "0::"); ok {
+			path = strings.TrimSpace(p)
+		}
+	}
+	if path == "" {
+		return "", errors.New("the buildlet isn't in a cgroup v2 hierarchy")
+	}
+	dir := filepath.Join(mount, path)
+	// The root cgroup may have processes of its own, and has the
+	// whole system's.
+	if path != "/" {
+		leaf := filepath.Join(dir, "buildlet")
+		if err := os.Mkdir(leaf, 0755); err != nil && !os.IsExist(err) {
+			return "", err
+		}
+		if err := moveCgroupProcs(dir, leaf); err != nil {
+			return "", fmt.Errorf("moving the processes of %s to %s: %w", dir, leaf, err)
+		}
+	}
+	for _, c := range []string{"cpu", "memory", "pids"} {
+		if err := os.WriteFile(filepath.Join(dir, "cgroup.subtree_control"), []byte("+"+c), 0); err != nil {
+			log.Printf("enabling cgroup controller %s in %s: %v", c, dir, err)
+		}
+	}
+	return dir, nil
+})
+
+// moveCgroupProcs moves all the processes of the cgroup dir to the
+// cgroup to. Processes may start while it does, so it repeats until
+// there are none left.
+func moveCgroupProcs(dir, to string) error {
+	for range 10 {
+		procs, err := os.ReadFile(filepath.Join(dir, "cgroup.procs"))
+		if err != nil {
+			return err
+		}
+		pids := strings.Fields(string(procs))
+		if len(pids) == 0 {
+			return nil
+		}
+		for _, pid := range pids {
+			err := os.WriteFile(filepath.Join(to, "cgroup.procs"), []byte(pid), 0)
+			if err != nil && !errors.Is(err, syscall.ESRCH) { // the process exited
+				return fmt.Errorf("moving process %s: %w", pid, err)
+			}
+		}
+	}
+	return errors.New("processes keep starting")
+}
+
+// canUnshareNetwork reports why commands can't run in network
+// namespaces of their own, if they can't: creating one needs
+// CAP_SYS_ADMIN.
+var canUnshareNetwork = sync.OnceValue(func() error {
+	hdr := unix.CapUserHeader{Version: unix.LINUX_CAPABILITY_VERSION_3}
+	var data [2]unix.CapUserData
+	if err := unix.Capget(&hdr, &data[0]); err != nil {
+		return fmt.Errorf("network limits: reading capabilities: %w", err)
+	}
+	if data[unix.CAP_SYS_ADMIN/32].Effective&(1<<(unix.CAP_SYS_ADMIN%32)) == 0 {
+		return errors.New("network limits need CAP_SYS_ADMIN, which the buildlet doesn't have")
+	}
+	return nil
+})
+
+// cgroup2Mount returns where the cgroup v2 hierarchy is mounted.
+func cgroup2Mount() (string, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		// See proc(5): the fields after the separator are the
+		// filesystem type, the source and the super options.
+		pre, post, ok := strings.Cut(sc.Text(), " - ")
+		if fields := strings.Fields(pre); ok && len(fields) >= 5 && strings.HasPrefix(post, "cgroup2 ") {
+			return fields[4], nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return "", err
+	}
+	return "", errors.New("cgroup2 isn't mounted")
+}
+
+func (sb *linuxSandbox) start(start func() error) error {
+	if !sb.noNetwork {
+		return start()
+	}
+	// A new process starts in the network namespace of the thread that
+	// creates it, so start the command from a thread moved to a new
+	// network namespace. The thread stays locked, so that it exits with
+	// the goroutine rather than run other goroutines.
+	errc := make(chan error, 1)
+	go func() {
+		runtime.LockOSThread()
+		if err := unix.Unshare(unix.CLONE_NEWNET); err != nil {
+			errc <- fmt.Errorf("creating network namespace: %w", err)
+			return
+		}
+		if err := setLoopbackUp(); err != nil {
+			errc <- fmt.Errorf("setting up loopback interface: %w", err)
+			return
+		}
+		errc <- start()
+	}()
+	return <-errc
+}
+
+// setLoopbackUp brings up the loopback interface of the current
+// thread's network namespace.
+func setLoopbackUp() error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+	ifr, err := unix.NewIfreq("lo")
+	if err != nil {
+		return err
+	}
+	if err := unix.IoctlIfreq(fd, unix.SIOCGIFFLAGS, ifr); err != nil {
+		return err
+	}
+	ifr.SetUint16(ifr.Uint16() | unix.IFF_UP)
+	return unix.IoctlIfreq(fd, unix.SIOCSIFFLAGS, ifr)
+}
+
+func (sb *linuxSandbox) exceeded() string {
+	if sb.cgroup == "" {
+		return ""
+	}
+	if cgroupEvent(sb.cgroup, "memory.events", "oom_kill") > 0 {
+		return buildlet.LimitMemory
+	}
+	if cgroupEvent(sb.cgroup, "pids.events", "max") > 0 {
+		return buildlet.LimitProcesses
+	}
+	return ""
+}
+
+// cgroupEvent returns the count of an event in the events file of a
+// cgroup, or 0 if it can't be read.
+func cgroupEvent(cgroup, file, event string) int64 {
+	b, err := os.ReadFile(filepath.Join(cgroup, file))
+	if err != nil {
+		return 0
+	}
+	for line := range bytes.Lines(b) {
+		if k, v, ok := strings.Cut(strings.TrimSpace(string(line)), " "); ok && k == event {
+			n, _ := strconv.ParseInt(v, 10, 64)
+			return n
+		}
+	}
+	return 0
+}
+
+func (sb *linuxSandbox) kill() {
+	if sb.cgroup == "" {
+		return
+	}
+	if os.WriteFile(filepath.Join(sb.cgroup, "cgroup.kill"), []byte("1"), 0) == nil {
+		return
+	}
+	// cgroup.kill is new in Linux 5.14.
+	procs, _ := os.ReadFile(filepath.Join(sb.cgroup, "cgroup.procs"))
+	for _, f := range strings.Fields(string(procs)) {
+		if pid, err := strconv.Atoi(f); err == nil {
+			syscall.Kill(pid, syscall.SIGKILL)
+		}
+	}
+}
+
+func (sb *linuxSandbox) close() {
+	if sb.cgroup == "" {
+		return
+	}
+	sb.kill()
+	if sb.fd != nil {
+		sb.fd.Close()
+	}
+	// The cgroup can only be removed once its processes are gone.
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		err := syscall.Rmdir(sb.cgroup)
+		if err == nil || os.IsNotExist(err) {
+			return
+		}
+		if time.Now().After(deadline) {
+			log.Printf("removing cgroup %s: %v", sb.cgroup, err)
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
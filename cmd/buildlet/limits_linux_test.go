@@ -0,0 +1,51 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"golang.org/x/build/buildlet"
+)
+
+func TestExecNoNetwork(t *testing.T) {
+	if err := canUnshareNetwork(); err != nil {
+		t.Skip(err)
+	}
+	// /proc/net/dev lists the interfaces of the reader's network
+	// namespace.
+	out, res, remoteErr := execWithLimits(t, "cat /proc/net/dev", &buildlet.ExecLimits{NoNetwork: true})
+	if remoteErr != nil {
+		t.Fatalf("remote error = %v; output:\n%s", remoteErr, out)
+	}
+	if res.LimitExceeded != "" {
+		t.Errorf("LimitExceeded = %q; want none", res.LimitExceeded)
+	}
+	var ifaces []string
+	for _, line := range strings.Split(out, "\n") {
+		if name, _, ok := strings.Cut(line, ":"); ok {
+			ifaces = append(ifaces, strings.TrimSpace(name))
+		}
+	}
+	if len(ifaces) != 1 || ifaces[0] != "lo" {
+		t.Errorf("interfaces = %q; want only lo", ifaces)
+	}
+}
+
+func TestExecMemoryLimit(t *testing.T) {
+	if _, err := newLinuxSandbox(exec.Command("true"), &buildlet.ExecLimits{Memory: 1}); err != nil {
+		t.Skipf("memory limits aren't supported: %v", err)
+	}
+	// Hold 64 MiB in memory.
+	_, res, remoteErr := execWithLimits(t, "x=$(head -c 67108864 /dev/zero | tr '\\0' x); echo ${#x}", &buildlet.ExecLimits{Memory: 16 << 20})
+	if res.LimitExceeded != buildlet.LimitMemory {
+		t.Errorf("LimitExceeded = %q; want %q", res.LimitExceeded, buildlet.LimitMemory)
+	}
+	if remoteErr == nil {
+		t.Error("command exceeding its memory limit succeeded")
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !plan9 && !windows
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/build/buildlet"
+)
+
+// execWithLimits runs the shell script on a test buildlet with lim, and
+// returns its output, result and remote error.
+func execWithLimits(t *testing.T, script string, lim *buildlet.ExecLimits) (string, *buildlet.ExecResult, error) {
+	t.Helper()
+	cl := newExecTestClient(t)
+	var out strings.Builder
+	var res *buildlet.ExecResult
+	remoteErr, execErr := cl.Exec(context.Background(), "/bin/sh", buildlet.ExecOpts{
+		SystemLevel: true,
+		Args:        []string{"-c", script},
+		Output:      &out,
+		OnExit:      func(r *buildlet.ExecResult) { res = r },
+		Limits:      lim,
+	})
+	if execErr != nil {
+		t.Fatalf("Exec error = %v", execErr)
+	}
+	if res == nil {
+		t.Fatal("no result")
+	}
+	return out.String(), res, remoteErr
+}
+
+func TestExecWallTimeLimit(t *testing.T) {
+	t0 := time.Now()
+	_, res, remoteErr := execWithLimits(t, "sleep 60", &buildlet.ExecLimits{WallTime: 100 * time.Millisecond})
+	if d := time.Since(t0); d > 30*time.Second {
+		t.Errorf("command ran for %v", d)
+	}
+	if res.LimitExceeded != buildlet.LimitWallTime {
+		t.Errorf("LimitExceeded = %q; want %q", res.LimitExceeded, buildlet.LimitWallTime)
+	}
+	if remoteErr == nil || !strings.Contains(remoteErr.Error(), "wall time limit exceeded") {
+		t.Errorf("remote error = %v; want wall time limit exceeded", remoteErr)
+	}
+
+	// Commands that finish in time aren't affected.
+	out, res, remoteErr := execWithLimits(t, "echo ok", &buildlet.ExecLimits{WallTime: time.Minute})
+	if remoteErr != nil || res.LimitExceeded != "" || out != "ok\n" {
+		t.Errorf("Exec = %q, %+v, %v; want success", out, res, remoteErr)
+	}
+}
+
+func TestExecDiskLimit(t *testing.T) {
+	old := diskCheckInterval
+	diskCheckInterval = 10 * time.Millisecond
+	defer func() { diskCheckInterval = old }()
+
+	_, res, remoteErr := execWithLimits(t, "head -c 2000000 /dev/zero > big; sleep 60", &buildlet.ExecLimits{Disk: 1 << 20})
+	if res.LimitExceeded != buildlet.LimitDisk {
+		t.Errorf("LimitExceeded = %q; want %q", res.LimitExceeded, buildlet.LimitDisk)
+	}
+	if remoteErr == nil {
+		t.Error("command exceeding its disk limit succeeded")
+	}
+}
+
+func TestExecInvalidLimits(t *testing.T) {
+	cl := newExecTestClient(t)
+	_, execErr := cl.Exec(context.Background(), "/bin/true", buildlet.ExecOpts{
+		SystemLevel: true,
+		Limits:      &buildlet.ExecLimits{Memory: -1},
+	})
+	if execErr == nil || !strings.Contains(execErr.Error(), "negative limit") {
+		t.Errorf("Exec error = %v; want negative limit error", execErr)
+	}
+}
+
+func TestExecDiskLimitRunsAlone(t *testing.T) {
+	cl := newExecTestClient(t)
+	waitRunning := func(n int) {
+		t.Helper()
+		for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+			runningExecs.Lock()
+			got := runningExecs.n
+			runningExecs.Unlock()
+			if got == n {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("%d commands running; want %d", got, n)
+			}
+		}
+	}
+	// background runs a command with lim until the returned func is
+	// called.
+	background := func(lim *buildlet.ExecLimits) func() {
+		stopFile := filepath.Join(t.TempDir(), "stop")
+		script := fmt.Sprintf("while [ ! -e %s ]; do sleep 0.01; done", stopFile)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			cl.Exec(context.Background(), "/bin/sh", buildlet.ExecOpts{SystemLevel: true, Args: []string{"-c", script}, Limits: lim})
+		}()
+		waitRunning(1)
+		return func() {
+			if err := os.WriteFile(stopFile, nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+			<-done
+			waitRunning(0)
+		}
+	}
+	exec := func(lim *buildlet.ExecLimits) error {
+		_, err := cl.Exec(context.Background(), "/bin/true", buildlet.ExecOpts{SystemLevel: true, Limits: lim})
+		return err
+	}
+
+	stop := background(nil)
+	if err := exec(&buildlet.ExecLimits{Disk: 1 << 20}); !errors.Is(err, buildlet.ErrExecBusy) || !strings.Contains(err.Error(), "other commands are running") {
+		t.Errorf("Exec with a disk limit alongside another command: error = %v; want other commands are running", err)
+	}
+	stop()
+
+	stop = background(&buildlet.ExecLimits{Disk: 1 << 20})
+	if err := exec(nil); !errors.Is(err, buildlet.ErrExecBusy) || !strings.Contains(err.Error(), "disk limit is running") {
+		t.Errorf("Exec alongside a command with a disk limit: error = %v; want disk limit is running", err)
+	}
+	stop()
+
+	if err := exec(&buildlet.ExecLimits{Disk: 1 << 20}); err != nil {
+		t.Errorf("Exec with a disk limit on an idle buildlet: error = %v", err)
+	}
+	waitRunning(0)
+}
@@ -10,7 +10,6 @@ import (
 	"crypto/hmac"
 	"crypto/md5"
 	"crypto/tls"
-	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -25,6 +24,7 @@ import (
 	"time"
 
 	"go.chromium.org/luci/auth"
+	"golang.org/x/build/internal/muxconn"
 	"golang.org/x/build/internal/rendezvous"
 	"golang.org/x/build/revdial/v2"
 )
@@ -54,7 +54,7 @@ func isDevReverseMode() bool {
 	return !strings.HasPrefix(*coordinator, "farmer.golang.org")
 }
 
-// dialCoordinator dials the coordinator to establish a revdial connection
+// dialCoordinator dials the coordinator to establish a reverse connection
 // where the returned net.Listener can be used to accept connections from the
 // coordinator.
 func dialCoordinator() (net.Listener, error) {
@@ -103,54 +103,25 @@ func dialCoordinator() (net.Listener, error) {
 		tcpConn.SetDeadline(time.Time{})
 		return conn, nil
 	}
-	conn, err := dial(context.Background())
+	register := func(conn net.Conn, muxMode string) (bool, error) {
+		log.Printf("Registering reverse mode with coordinator...")
+		return registerReverse(conn, "coordinator", muxMode, func(h http.Header) {
+			h.Set("X-Go-Host-Type", *reverseType)
+			h.Set("X-Go-Builder-Key", key)
+			h.Set("X-Go-Builder-Hostname", *hostname)
+			h.Set("X-Go-Builder-Version", strconv.Itoa(buildletVersion))
+			h.Set("X-Revdial-Version", "2")
+		})
+	}
+	ln, err := reverseListener(dial, register)
 	if err != nil {
 		return nil, err
 	}
-
-	bufr := bufio.NewReader(conn)
-	bufw := bufio.NewWriter(conn)
-
-	log.Printf("Registering reverse mode with coordinator...")
-
-	success := false
-	location := "/reverse"
-	const maxRedirects = 2
-	for range maxRedirects {
-		req, err := http.NewRequest("GET", location, nil)
-		if err != nil {
-			log.Fatal(err)
-		}
-		req.Header.Set("X-Go-Host-Type", *reverseType)
-		req.Header.Set("X-Go-Builder-Key", key)
-		req.Header.Set("X-Go-Builder-Hostname", *hostname)
-		req.Header.Set("X-Go-Builder-Version", strconv.Itoa(buildletVersion))
-		req.Header.Set("X-Revdial-Version", "2")
-		if err := req.Write(bufw); err != nil {
-			return nil, fmt.Errorf("coordinator /reverse request failed: %v", err)
-		}
-		if err := bufw.Flush(); err != nil {
-			return nil, fmt.Errorf("coordinator /reverse request flush failed: %v", err)
-		}
-		location, err = revdial.ReadProtoSwitchOrRedirect(bufr, req)
-		if err != nil {
-			return nil, fmt.Errorf("coordinator registration failed: %v", err)
-		}
-		if location == "" {
-			success = true
-			break
-		}
-	}
-	if !success {
-		return nil, errors.New("coordinator /reverse: too many redirects")
-	}
-
 	log.Printf("Connected to coordinator; reverse dialing active")
-	ln := revdial.NewListener(conn, dial)
 	return ln, nil
 }
 
-// dialGomoteServer dials the gomote server to establish a revdial connection
+// dialGomoteServer dials the gomote server to establish a reverse connection
 // where the returned net.Listener can be used to accept connections from the
 // gomote server.
 func dialGomoteServer() (net.Listener, error) {
@@ -190,20 +161,65 @@ func dialGomoteServer() (net.Listener, error) {
 		tcpConn.SetDeadline(time.Time{})
 		return conn, nil
 	}
+	register := func(conn net.Conn, muxMode string) (bool, error) {
+		log.Printf("Registering reverse mode with the gomote server...")
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		return registerReverse(conn, "gomote server", muxMode, func(h http.Header) {
+			h.Set(rendezvous.HeaderID, os.Getenv("GOMOTEID"))
+			h.Set(rendezvous.HeaderToken, mustSwarmingAuthToken(ctx))
+			h.Set(rendezvous.HeaderHostname, *hostname)
+		})
+	}
+	ln, err := reverseListener(dial, register)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Connected to gomote server; reverse dialing active")
+	return ln, nil
+}
+
+// reverseListener dials and registers with a server, and returns the
+// listener of the connections the server makes to the buildlet.
+//
+// If the server supports it, they're streams of a muxconn session, which
+// is resumed over a new connection if the first one breaks. Otherwise,
+// they're made with revdial.
+func reverseListener(dial func(context.Context) (net.Conn, error), register func(conn net.Conn, muxMode string) (mux bool, err error)) (net.Listener, error) {
 	conn, err := dial(context.Background())
 	if err != nil {
 		return nil, err
 	}
+	mux, err := register(conn, "1")
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if !mux {
+		return revdial.NewListener(conn, dial), nil
+	}
+	redial := func(ctx context.Context) (net.Conn, error) {
+		conn, err := dial(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := register(conn, "resume"); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return conn, nil
+	}
+	return muxconn.Client(conn, redial, nil)
+}
 
+// registerReverse sends the /reverse request with which the buildlet
+// registers with the server over conn, following redirects. setHeader
+// sets the headers that identify the buildlet. It reports whether the
+// server agreed to a muxconn session, which muxMode asks for.
+func registerReverse(conn net.Conn, server, muxMode string, setHeader func(http.Header)) (mux bool, err error) {
 	bufr := bufio.NewReader(conn)
 	bufw := bufio.NewWriter(conn)
 
-	log.Printf("Registering reverse mode with the gomote server...")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-	defer cancel()
-
-	success := false
 	location := "/reverse"
 	const maxRedirects = 2
 	for range maxRedirects {
@@ -211,31 +227,24 @@ func dialGomoteServer() (net.Listener, error) {
 		if err != nil {
 			log.Fatal(err)
 		}
-		req.Header.Set(rendezvous.HeaderID, os.Getenv("GOMOTEID"))
-		req.Header.Set(rendezvous.HeaderToken, mustSwarmingAuthToken(ctx))
-		req.Header.Set(rendezvous.HeaderHostname, *hostname)
+		setHeader(req.Header)
+		req.Header.Set(muxconn.Header, muxMode)
 		if err := req.Write(bufw); err != nil {
-			return nil, fmt.Errorf("gomote server /reverse request failed: %v", err)
+			return false, fmt.Errorf("%s /reverse request failed: %v", server, err)
 		}
 		if err := bufw.Flush(); err != nil {
-			return nil, fmt.Errorf("gomote server /reverse request flush failed: %v", err)
+			return false, fmt.Errorf("%s /reverse request flush failed: %v", server, err)
 		}
-		location, err = revdial.ReadProtoSwitchOrRedirect(bufr, req)
+		var res *http.Response
+		res, location, err = revdial.ReadProtoSwitchResponse(bufr, req)
 		if err != nil {
-			return nil, fmt.Errorf("gomote server registration failed: %v", err)
+			return false, fmt.Errorf("%s registration failed: %v", server, err)
 		}
 		if location == "" {
-			success = true
-			break
+			return res.Header.Get(muxconn.Header) != "", nil
 		}
 	}
-	if !success {
-		return nil, errors.New("gomote server /reverse: too many redirects")
-	}
-
-	log.Printf("Connected to gomote server; reverse dialing active")
-	ln := revdial.NewListener(conn, dial)
-	return ln, nil
+	return false, fmt.Errorf("%s /reverse: too many redirects", server)
 }
 
 var coordDialer = &net.Dialer{
@@ -18,6 +18,7 @@ import (
 
 	"golang.org/x/build"
 	"golang.org/x/build/internal/coordinator/pool"
+	"golang.org/x/build/internal/muxconn"
 	"golang.org/x/build/revdial/v2"
 )
 
@@ -50,9 +51,11 @@ func coordinatorServer() (*http.Server, net.Listener, error) {
 	return srv, ln, nil
 }
 
-// testReverseDial verifies that a revdial connection can be established and
-// registered in the coordinator reverse pool at coordAddr.
-func testReverseDial(t *testing.T, coordAddr, hostType string) {
+// testReverseDial verifies that a reverse connection can be established and
+// registered in the coordinator reverse pool at coordAddr. If wantMux is
+// true, the connection must be a muxconn session; otherwise, it must use
+// revdial.
+func testReverseDial(t *testing.T, coordAddr, hostType string, wantMux bool) {
 	t.Helper()
 
 	oldCoordinator := *coordinator
@@ -75,6 +78,9 @@ func testReverseDial(t *testing.T, coordAddr, hostType string) {
 	if err != nil {
 		t.Fatalf("dialCoordinator got err %v want nil", err)
 	}
+	if _, isMux := ln.(*muxconn.Session); isMux != wantMux {
+		t.Errorf("dialCoordinator returned a %T; want muxconn session: %t", ln, wantMux)
+	}
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/status", handleStatus)
@@ -123,7 +129,31 @@ func TestReverseDial(t *testing.T) {
 	defer srv.Close()
 
 	const hostType = "test-reverse-dial"
-	testReverseDial(t, srv.Addr, hostType)
+	testReverseDial(t, srv.Addr, hostType, true)
+}
+
+// TestReverseDialRevdial verifies that a buildlet falls back to revdial
+// with a coordinator that doesn't support muxconn sessions.
+func TestReverseDialRevdial(t *testing.T) {
+	pool.SetBuilderMasterKey([]byte(devMasterKey))
+
+	srv, ln, err := coordinatorServer()
+	if err != nil {
+		t.Fatalf("serveCoordinator got err %v want nil", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/reverse", func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del(muxconn.Header)
+		pool.HandleReverse(w, r)
+	})
+	mux.Handle("/revdial", revdial.ConnHandler())
+	srv.Handler = mux
+
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	const hostType = "test-reverse-dial-revdial"
+	testReverseDial(t, srv.Addr, hostType, false)
 }
 
 // TestReverseDialRedirect verifies that a revdial connection works with a 307
@@ -153,5 +183,5 @@ func TestReverseDialRedirect(t *testing.T) {
 	defer srv.Close()
 
 	const hostType = "test-reverse-dial-redirect"
-	testReverseDial(t, srv.Addr, hostType)
+	testReverseDial(t, srv.Addr, hostType, true)
 }
@@ -0,0 +1,405 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/build/buildlet"
+)
+
+// syncHashes caches the block hashes of the files in the work directory,
+// keyed by absolute path, so that only new or changed files are hashed
+// when listing a sync manifest, and so that the blocks in a manifest a
+// client was sent can be found again when it refers to them.
+var syncHashes = struct {
+	sync.Mutex
+	m map[string]syncHashEntry
+}{m: make(map[string]syncHashEntry)}
+
+type syncHashEntry struct {
+	size    int64
+	modTime time.Time
+	hashed  time.Time // when the blocks were hashed or written
+	blocks  []string
+}
+
+// syncRacyWindow is how long after a file was hashed its modification
+// time must be for the cached hashes to be trusted. A file modified
+// again within the same timestamp tick, which is as long as 2s on some
+// file systems, would otherwise keep the same size and mtime.
+const syncRacyWindow = 3 * time.Second
+
+// fresh reports whether e still describes the file described by fi.
+func (e syncHashEntry) fresh(fi fs.FileInfo) bool {
+	return e.size == fi.Size() && e.modTime.Equal(fi.ModTime()) &&
+		fi.ModTime().Before(e.hashed.Add(-syncRacyWindow))
+}
+
+// fileBlocks returns the block hashes of the file at path, described by
+// fi, hashing it only if it changed since it was last hashed.
+func fileBlocks(path string, fi fs.FileInfo) ([]string, error) {
+	syncHashes.Lock()
+	e, ok := syncHashes.m[path]
+	syncHashes.Unlock()
+	if ok && e.fresh(fi) {
+		return e.blocks, nil
+	}
+	now := time.Now()
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	blocks, _, err := buildlet.HashBlocks(f)
+	if err != nil {
+		return nil, err
+	}
+	syncHashes.Lock()
+	syncHashes.m[path] = syncHashEntry{fi.Size(), fi.ModTime(), now, blocks}
+	syncHashes.Unlock()
+	return blocks, nil
+}
+
+// forgetSyncHashes drops the cached hashes of path and, if it's a
+// directory, of the files in it.
+func forgetSyncHashes(path string) {
+	prefix := path + string(filepath.Separator)
+	syncHashes.Lock()
+	defer syncHashes.Unlock()
+	for p := range syncHashes.m {
+		if p == path || strings.HasPrefix(p, prefix) {
+			delete(syncHashes.m, p)
+		}
+	}
+}
+
+// pruneSyncHashes drops the cached hashes of files in dir that aren't
+// in seen, the files a walk of dir found, unless they're in one of the
+// skipped directories.
+func pruneSyncHashes(dir string, seen map[string]bool, skip []string) {
+	prefix := dir + string(filepath.Separator)
+	syncHashes.Lock()
+	defer syncHashes.Unlock()
+	for p := range syncHashes.m {
+		if !strings.HasPrefix(p, prefix) || seen[p] {
+			continue
+		}
+		rel := filepath.ToSlash(p[len(prefix):])
+		if slices.ContainsFunc(skip, func(s string) bool { return strings.HasPrefix(rel, s+"/") }) {
+			continue
+		}
+		delete(syncHashes.m, p)
+	}
+}
+
+func handleSyncManifest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "requires GET method", http.StatusBadRequest)
+		return
+	}
+	dir := r.FormValue("dir")
+	if dir != "" {
+		var err error
+		dir, err = nativeRelPath(dir)
+		if err != nil {
+			http.Error(w, "invalid 'dir' parameter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	skip := r.Form["skip"] // '/'-separated relative dirs
+
+	if !mkdirAllWorkdirOr500(w) {
+		return
+	}
+
+	base := filepath.Join(*workDir, dir)
+	if _, err := os.Stat(base); errors.Is(err, fs.ErrNotExist) {
+		return // nothing to list
+	}
+	enc := json.NewEncoder(w)
+	anyOutput := false
+	seen := make(map[string]bool)
+	err := filepath.Walk(base, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel := strings.TrimPrefix(filepath.ToSlash(strings.TrimPrefix(path, base)), "/")
+		if fi.IsDir() {
+			if rel != "" && slices.Contains(skip, rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		blocks, err := fileBlocks(path, fi)
+		if err != nil {
+			return err
+		}
+		seen[path] = true
+		anyOutput = true
+		return enc.Encode(buildlet.SyncFile{
+			Path:   rel,
+			Mode:   fi.Mode(),
+			Size:   fi.Size(),
+			Blocks: blocks,
+		})
+	})
+	if err != nil {
+		log.Printf("syncmanifest: walk error: %v", err)
+		if anyOutput {
+			// Break the chunked response, as in handleLs.
+			panic(http.ErrAbortHandler)
+		}
+		http.Error(w, "Walk error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	pruneSyncHashes(base, seen, skip)
+}
+
+func handleWriteSync(w http.ResponseWriter, r *http.Request) {
+	if !mkdirAllWorkdirOr500(w) {
+		return
+	}
+	baseDir := *workDir
+	if dir := r.URL.Query().Get("dir"); dir != "" {
+		dir, err := nativeRelPath(dir)
+		if err != nil {
+			http.Error(w, "invalid 'dir' parameter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		baseDir = filepath.Join(baseDir, dir)
+	}
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		http.Error(w, "mkdir of base: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var body io.Reader
+	switch r.Method {
+	case "PUT":
+		body = r.Body
+	case "POST":
+		urlStr := r.FormValue("url")
+		if urlStr == "" {
+			http.Error(w, "missing url POST param", http.StatusBadRequest)
+			return
+		}
+		res, err := http.Get(urlStr)
+		if err != nil {
+			log.Printf("writesync: failed to fetch URL %s: %v", urlStr, err)
+			http.Error(w, fmt.Sprintf("fetching URL %s: %v", urlStr, err), http.StatusInternalServerError)
+			return
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			http.Error(w, fmt.Sprintf("writesync: fetching provided URL %q: %s", urlStr, res.Status), http.StatusInternalServerError)
+			return
+		}
+		body = res.Body
+	default:
+		http.Error(w, "requires PUT or POST method", http.StatusBadRequest)
+		return
+	}
+
+	t0 := time.Now()
+	st, err := applySync(body, baseDir)
+	if err != nil {
+		log.Printf("writesync: %v", err)
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	log.Printf("writesync: wrote %d files (%d of %d blocks sent) and deleted %d in %s in %v",
+		st.written, st.literal, st.blocks, st.deleted, baseDir, time.Since(t0))
+	io.WriteString(w, "OK")
+}
+
+type syncStats struct {
+	written, deleted int
+	blocks, literal  int
+}
+
+// applySync applies the sync ops in the gzip-compressed stream r to
+// baseDir.
+//
+// New file contents are all written to temporary files before any file
+// is replaced, so that blocks can be copied from files that the stream
+// goes on to change or delete.
+func applySync(r io.Reader, baseDir string) (st syncStats, err error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return st, badRequestf("requires gzip-compressed body: %v", err)
+	}
+	br := bufio.NewReader(zr)
+	index := newSyncBlockIndex(baseDir)
+
+	type pendingWrite struct {
+		tmp, path string
+		mode      os.FileMode
+		blocks    []string
+	}
+	var writes []pendingWrite
+	var deletes []string
+	defer func() {
+		for _, pw := range writes {
+			os.Remove(pw.tmp) // a no-op once renamed
+		}
+	}()
+	buf := make([]byte, buildlet.SyncBlockSize)
+	for {
+		op, err := buildlet.ReadSyncOp(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return st, badRequestf("%v", err)
+		}
+		rel, err := nativeRelPath(op.Path)
+		if err != nil {
+			return st, badRequestf("invalid path %q: %v", op.Path, err)
+		}
+		if filepath.Clean(rel) == "." {
+			// Deleting "." would remove baseDir itself.
+			return st, badRequestf("invalid path %q: refers to the sync directory itself", op.Path)
+		}
+		path := filepath.Join(baseDir, rel)
+		if op.Delete {
+			deletes = append(deletes, path)
+			continue
+		}
+		if !op.Mode.IsRegular() {
+			return st, badRequestf("%s: bad mode %v", op.Path, op.Mode)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return st, err
+		}
+		f, err := os.CreateTemp(filepath.Dir(path), ".buildlet-sync-*")
+		if err != nil {
+			return st, err
+		}
+		writes = append(writes, pendingWrite{f.Name(), path, op.Mode, op.Blocks})
+		lit := op.Literal
+		for i, h := range op.Blocks {
+			p := buf[:op.BlockLen(i)]
+			if len(lit) > 0 && lit[0] == i {
+				lit = lit[1:]
+				if _, err := io.ReadFull(br, p); err != nil {
+					f.Close()
+					return st, badRequestf("reading block %d of %s: %v", i, op.Path, err)
+				}
+				if buildlet.HashBlock(p) != h {
+					f.Close()
+					return st, badRequestf("block %d of %s doesn't match its hash", i, op.Path)
+				}
+				st.literal++
+			} else if err := index.read(h, p); err != nil {
+				f.Close()
+				return st, badRequestf("block %d of %s: %v", i, op.Path, err)
+			}
+			st.blocks++
+			if _, err := f.Write(p); err != nil {
+				f.Close()
+				return st, err
+			}
+		}
+		if err := f.Close(); err != nil {
+			return st, err
+		}
+	}
+
+	for _, pw := range writes {
+		if err := os.Chmod(pw.tmp, pw.mode.Perm()); err != nil {
+			return st, err
+		}
+		if err := os.Rename(pw.tmp, pw.path); err != nil {
+			return st, err
+		}
+		if fi, err := os.Stat(pw.path); err == nil {
+			syncHashes.Lock()
+			syncHashes.m[pw.path] = syncHashEntry{fi.Size(), fi.ModTime(), time.Now(), pw.blocks}
+			syncHashes.Unlock()
+		}
+		st.written++
+	}
+	for _, path := range deletes {
+		if err := os.RemoveAll(path); err != nil {
+			return st, err
+		}
+		forgetSyncHashes(path)
+		st.deleted++
+	}
+	return st, nil
+}
+
+// A syncBlockIndex locates the blocks in the cached hashes of the files
+// in a directory.
+type syncBlockIndex struct {
+	once sync.Once
+	dir  string
+	locs map[string][]syncBlockLoc
+}
+
+type syncBlockLoc struct {
+	path string
+	off  int64
+}
+
+func newSyncBlockIndex(dir string) *syncBlockIndex {
+	return &syncBlockIndex{dir: dir}
+}
+
+func (x *syncBlockIndex) init() {
+	x.locs = make(map[string][]syncBlockLoc)
+	prefix := x.dir + string(filepath.Separator)
+	syncHashes.Lock()
+	defer syncHashes.Unlock()
+	for path, e := range syncHashes.m {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		for i, h := range e.blocks {
+			x.locs[h] = append(x.locs[h], syncBlockLoc{path, int64(i) * buildlet.SyncBlockSize})
+		}
+	}
+}
+
+// read reads the block with hash h into p, which must be the size of
+// the block.
+func (x *syncBlockIndex) read(h string, p []byte) error {
+	x.once.Do(x.init)
+	for _, loc := range x.locs[h] {
+		f, err := os.Open(loc.path)
+		if errors.Is(err, fs.ErrNotExist) {
+			// Removed since it was hashed, other than by
+			// applySync or /removeall.
+			forgetSyncHashes(loc.path)
+			continue
+		} else if err != nil {
+			continue
+		}
+		_, err = f.ReadAt(p, loc.off)
+		f.Close()
+		if err == nil && buildlet.HashBlock(p) == h {
+			return nil
+		}
+	}
+	return fmt.Errorf("no block with hash %s; the directory changed since its manifest was listed", h)
+}
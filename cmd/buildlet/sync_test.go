@@ -0,0 +1,276 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/build/buildlet"
+)
+
+func newSyncTestClient(t *testing.T) (buildlet.Client, string) {
+	t.Helper()
+	old := *workDir
+	*workDir = t.TempDir()
+	t.Cleanup(func() { *workDir = old })
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/syncmanifest", handleSyncManifest)
+	mux.HandleFunc("/writesync", handleWriteSync)
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := buildlet.NewClient(u.Host, buildlet.NoKeyPair)
+	t.Cleanup(func() { cl.Close() })
+	return cl, *workDir
+}
+
+func syncManifest(t *testing.T, cl buildlet.Client, dir string) map[string]buildlet.SyncFile {
+	t.Helper()
+	m := make(map[string]buildlet.SyncFile)
+	err := cl.SyncManifest(context.Background(), dir, buildlet.SyncManifestOpts{Skip: []string{"skip"}}, func(f buildlet.SyncFile) {
+		m[f.Path] = f
+	})
+	if err != nil {
+		t.Fatalf("SyncManifest = %v", err)
+	}
+	return m
+}
+
+// syncOpFor returns an op writing data to path, and the literal data of
+// the blocks not in have.
+func syncOpFor(path string, data []byte, have map[string]bool) (*buildlet.SyncOp, []byte) {
+	blocks, size, _ := buildlet.HashBlocks(bytes.NewReader(data))
+	op := &buildlet.SyncOp{Path: path, Mode: 0644, Size: size, Blocks: blocks}
+	var lit []byte
+	for i, h := range blocks {
+		if !have[h] {
+			op.Literal = append(op.Literal, i)
+			off := i * buildlet.SyncBlockSize
+			lit = append(lit, data[off:off+op.BlockLen(i)]...)
+		}
+	}
+	return op, lit
+}
+
+func TestSync(t *testing.T) {
+	cl, wd := newSyncTestClient(t)
+	ctx := context.Background()
+
+	if m := syncManifest(t, cl, "tree"); len(m) != 0 {
+		t.Fatalf("manifest of missing dir = %v; want empty", m)
+	}
+
+	big := bytes.Repeat([]byte("0123456789abcdef"), buildlet.SyncBlockSize/16*3+10) // 4 blocks
+	for name, data := range map[string][]byte{
+		"tree/big":      big,
+		"tree/old":      []byte("old"),
+		"tree/skip/foo": []byte("skipped"),
+	} {
+		path := filepath.Join(wd, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := syncManifest(t, cl, "tree")
+	if len(m) != 2 || len(m["big"].Blocks) != 4 || m["big"].Size != int64(len(big)) || m["old"].Size != 3 {
+		t.Fatalf("manifest = %+v; want big and old", m)
+	}
+	have := make(map[string]bool)
+	for _, f := range m {
+		for _, h := range f.Blocks {
+			have[h] = true
+		}
+	}
+
+	// Change the last block of big, move it to a new name, and delete
+	// the original and old. Only the changed block should be sent.
+	big2 := append(bytes.Clone(big), "more"...)
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	op, lit := syncOpFor("dir/big2", big2, have)
+	if len(op.Literal) != 1 {
+		t.Fatalf("op sends blocks %v; want only the last", op.Literal)
+	}
+	for _, w := range []struct {
+		op  *buildlet.SyncOp
+		lit []byte
+	}{
+		{op, lit},
+		{&buildlet.SyncOp{Path: "big", Delete: true}, nil},
+		{&buildlet.SyncOp{Path: "old", Delete: true}, nil},
+	} {
+		if err := buildlet.WriteSyncOp(zw, w.op, w.lit); err != nil {
+			t.Fatal(err)
+		}
+	}
+	zw.Close()
+	if err := cl.PutSync(ctx, &buf, "tree"); err != nil {
+		t.Fatalf("PutSync = %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(wd, "tree", "dir", "big2"))
+	if err != nil || !bytes.Equal(got, big2) {
+		t.Errorf("big2 = %d bytes, %v; want %d bytes", len(got), err, len(big2))
+	}
+	m = syncManifest(t, cl, "tree")
+	if len(m) != 1 || m["dir/big2"].Size != int64(len(big2)) {
+		t.Errorf("manifest after sync = %+v; want only dir/big2", m)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(wd, "tree", "dir")); len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+}
+
+func TestSyncErrors(t *testing.T) {
+	cl, dir := newSyncTestClient(t)
+	keep := filepath.Join(dir, "tree", "keep")
+	if err := os.MkdirAll(filepath.Dir(keep), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keep, []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		desc string
+		op   *buildlet.SyncOp
+		lit  []byte
+		want string
+	}{
+		{
+			desc: "unknown block",
+			op:   &buildlet.SyncOp{Path: "f", Mode: 0644, Size: 1, Blocks: []string{buildlet.HashBlock([]byte("x"))}},
+			want: "no block with hash",
+		},
+		{
+			desc: "corrupt block",
+			op:   &buildlet.SyncOp{Path: "f", Mode: 0644, Size: 1, Blocks: []string{buildlet.HashBlock([]byte("x"))}, Literal: []int{0}},
+			lit:  []byte("y"),
+			want: "doesn't match its hash",
+		},
+		{
+			desc: "parent directory",
+			op:   &buildlet.SyncOp{Path: "../f", Delete: true},
+			want: "refers to a parent directory",
+		},
+		{
+			desc: "delete sync directory",
+			op:   &buildlet.SyncOp{Path: ".", Delete: true},
+			want: "refers to the sync directory itself",
+		},
+		{
+			desc: "delete sync directory via subdirectory",
+			op:   &buildlet.SyncOp{Path: "sub/..", Delete: true},
+			want: "refers to the sync directory itself",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			zw := gzip.NewWriter(&buf)
+			if err := buildlet.WriteSyncOp(zw, tt.op, tt.lit); err != nil {
+				t.Fatal(err)
+			}
+			zw.Close()
+			err := cl.PutSync(context.Background(), &buf, "tree")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("PutSync = %v; want error containing %q", err, tt.want)
+			}
+			if _, err := os.Stat(keep); err != nil {
+				t.Errorf("after PutSync: %v", err)
+			}
+		})
+	}
+}
+
+func TestSyncHashCache(t *testing.T) {
+	cl, wd := newSyncTestClient(t)
+	write := func(name, data string, mtime time.Time) string {
+		t.Helper()
+		path := filepath.Join(wd, "tree", name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	cached := func(path string) bool {
+		syncHashes.Lock()
+		defer syncHashes.Unlock()
+		_, ok := syncHashes.m[path]
+		return ok
+	}
+
+	// A file changed within the same timestamp tick as it was hashed
+	// keeps its size and mtime, but is hashed again.
+	now := time.Now().Truncate(time.Second)
+	a := write("a", "aaaa", now)
+	if m := syncManifest(t, cl, "tree"); m["a"].Blocks[0] != buildlet.HashBlock([]byte("aaaa")) {
+		t.Fatalf("manifest = %+v; want a with aaaa", m)
+	}
+	write("a", "bbbb", now)
+	if m := syncManifest(t, cl, "tree"); m["a"].Blocks[0] != buildlet.HashBlock([]byte("bbbb")) {
+		t.Errorf("manifest after rewrite = %+v; want a with bbbb", m)
+	}
+
+	// Hashes are dropped for files deleted by a sync, removed some
+	// other way, or removed with their directory, but kept for
+	// skipped directories.
+	b := write("b", "b", now)
+	c := write("dir/c", "c", now)
+	skipped := write("skip/d", "d", now)
+	syncManifest(t, cl, "tree")
+	for _, p := range []string{a, b, c} {
+		if !cached(p) {
+			t.Fatalf("%s not cached after listing it", p)
+		}
+	}
+	syncHashes.Lock()
+	syncHashes.m[skipped] = syncHashEntry{blocks: []string{buildlet.HashBlock([]byte("d"))}}
+	syncHashes.Unlock()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := buildlet.WriteSyncOp(zw, &buildlet.SyncOp{Path: "dir", Delete: true}, nil); err != nil {
+		t.Fatal(err)
+	}
+	zw.Close()
+	if err := cl.PutSync(context.Background(), &buf, "tree"); err != nil {
+		t.Fatalf("PutSync = %v", err)
+	}
+	if cached(c) {
+		t.Errorf("%s still cached after its directory was deleted by a sync", c)
+	}
+	if err := os.Remove(b); err != nil {
+		t.Fatal(err)
+	}
+	syncManifest(t, cl, "tree")
+	if cached(b) {
+		t.Errorf("%s still cached after a listing didn't find it", b)
+	}
+	if !cached(a) || !cached(skipped) {
+		t.Errorf("existing or skipped files were dropped from the cache")
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// acceptedConns are the connections accepted by /tcplisten listeners
+// that are waiting to be claimed by a /tcpproxy request, by ID.
+var acceptedConns = struct {
+	sync.Mutex
+	m map[string]net.Conn
+}{m: make(map[string]net.Conn)}
+
+// acceptedConnTimeout is how long an accepted connection waits to be
+// claimed before it's closed.
+const acceptedConnTimeout = time.Minute
+
+func targetPort(r *http.Request) (int, error) {
+	port, err := strconv.Atoi(r.Header.Get("X-Target-Port"))
+	if err != nil || port < 0 || port > 65535 {
+		return 0, badRequestf("invalid X-Target-Port %q", r.Header.Get("X-Target-Port"))
+	}
+	return port, nil
+}
+
+// handleTCPProxy connects the request's connection to a TCP port on the
+// buildlet's loopback interface, given by the X-Target-Port header, or
+// to a connection accepted by a /tcplisten listener, given by the
+// X-Accept-Conn header.
+func handleTCPProxy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "requires POST method", http.StatusBadRequest)
+		return
+	}
+	var target net.Conn
+	if id := r.Header.Get("X-Accept-Conn"); id != "" {
+		acceptedConns.Lock()
+		target = acceptedConns.m[id]
+		delete(acceptedConns.m, id)
+		acceptedConns.Unlock()
+		if target == nil {
+			http.Error(w, "unknown or expired connection "+id, http.StatusNotFound)
+			return
+		}
+	} else {
+		port, err := targetPort(r)
+		if err == nil && port == 0 {
+			err = badRequestf("missing X-Target-Port")
+		}
+		if err != nil {
+			http.Error(w, err.Error(), httpStatus(err))
+			return
+		}
+		target, err = net.Dial("tcp", net.JoinHostPort("localhost", strconv.Itoa(port)))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+	}
+	defer target.Close()
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "conn can't hijack", http.StatusInternalServerError)
+		return
+	}
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		log.Printf("tcpproxy hijack error: %v", err)
+		http.Error(w, "tcpproxy hijack error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer conn.Close()
+	fmt.Fprintf(conn, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: tcpproxy\r\nConnection: Upgrade\r\n\r\n")
+	errc := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(target, brw)
+		errc <- err
+	}()
+	go func() {
+		_, err := io.Copy(conn, target)
+		errc <- err
+	}()
+	<-errc
+}
+
+// handleTCPListen listens on the TCP port given by the X-Target-Port
+// header of the buildlet's loopback interface, or any free port if it's
+// 0, for as long as the request's connection stays open.
+//
+// Once listening, it sends the line "listening <addr>", and then the
+// line "conn <id>" for each connection it accepts, which the client
+// claims with a /tcpproxy request.
+func handleTCPListen(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "requires POST method", http.StatusBadRequest)
+		return
+	}
+	port, err := targetPort(r)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	ln, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(port)))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	defer ln.Close()
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "conn can't hijack", http.StatusInternalServerError)
+		return
+	}
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		log.Printf("tcplisten hijack error: %v", err)
+		http.Error(w, "tcplisten hijack error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer conn.Close()
+	fmt.Fprintf(conn, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: tcplisten\r\nConnection: Upgrade\r\n\r\n")
+	fmt.Fprintf(conn, "listening %s\n", ln.Addr())
+	log.Printf("tcplisten: listening on %s", ln.Addr())
+
+	// Stop listening once the client goes away.
+	go func() {
+		io.Copy(io.Discard, brw)
+		ln.Close()
+	}()
+	for {
+		c, err := ln.Accept()
+		if err != nil {
+			log.Printf("tcplisten: stopped listening on %s: %v", ln.Addr(), err)
+			return
+		}
+		id := rand.Text()
+		acceptedConns.Lock()
+		acceptedConns.m[id] = c
+		acceptedConns.Unlock()
+		time.AfterFunc(acceptedConnTimeout, func() {
+			acceptedConns.Lock()
+			defer acceptedConns.Unlock()
+			if acceptedConns.m[id] == c {
+				delete(acceptedConns.m, id)
+				c.Close()
+			}
+		})
+		if _, err := fmt.Fprintf(conn, "conn %s\n", id); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"golang.org/x/build/buildlet"
+)
+
+func newTCPForwardTestClient(t *testing.T) buildlet.Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tcpproxy", handleTCPProxy)
+	mux.HandleFunc("/tcplisten", handleTCPListen)
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := buildlet.NewClient(u.Host, buildlet.NoKeyPair)
+	t.Cleanup(func() { cl.Close() })
+	return cl
+}
+
+func TestTCPProxy(t *testing.T) {
+	cl := newTCPForwardTestClient(t)
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		c, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		line, _ := bufio.NewReader(c).ReadString('\n')
+		io.WriteString(c, "echo: "+line)
+	}()
+
+	rwc, err := cl.ProxyTCP(ln.Addr().(*net.TCPAddr).Port)
+	if err != nil {
+		t.Fatalf("ProxyTCP = %v", err)
+	}
+	defer rwc.Close()
+	io.WriteString(rwc, "hello\n")
+	got, err := bufio.NewReader(rwc).ReadString('\n')
+	if err != nil || got != "echo: hello\n" {
+		t.Errorf("read %q, %v; want echo of hello", got, err)
+	}
+
+	if _, err := cl.ProxyTCP(0); err == nil {
+		t.Errorf("ProxyTCP(0) succeeded; want error")
+	}
+}
+
+func TestTCPListen(t *testing.T) {
+	cl := newTCPForwardTestClient(t)
+	ln, err := cl.ListenTCP(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("ListenTCP = %v", err)
+	}
+	_, port, err := net.SplitHostPort(ln.Addr())
+	if p, _ := strconv.Atoi(port); err != nil || p == 0 {
+		t.Fatalf("listen address = %q; want a port", ln.Addr())
+	}
+
+	c, err := net.Dial("tcp", ln.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	rwc, err := ln.Accept()
+	if err != nil {
+		t.Fatalf("Accept = %v", err)
+	}
+	defer rwc.Close()
+	io.WriteString(c, "ping\n")
+	if got, err := bufio.NewReader(rwc).ReadString('\n'); err != nil || got != "ping\n" {
+		t.Errorf("accepted connection read %q, %v; want ping", got, err)
+	}
+	io.WriteString(rwc, "pong\n")
+	if got, err := bufio.NewReader(c).ReadString('\n'); err != nil || got != "pong\n" {
+		t.Errorf("dialed connection read %q, %v; want pong", got, err)
+	}
+
+	ln.Close()
+	if _, err := ln.Accept(); err == nil {
+		t.Errorf("Accept after Close succeeded; want error")
+	}
+}
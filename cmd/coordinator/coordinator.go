@@ -53,6 +53,7 @@ import (
 	"golang.org/x/build/internal/buildgo"
 	"golang.org/x/build/internal/buildstats"
 	"golang.org/x/build/internal/cloud"
+	"golang.org/x/build/internal/coordinator/autoscale"
 	"golang.org/x/build/internal/coordinator/pool"
 	"golang.org/x/build/internal/coordinator/pool/queue"
 	"golang.org/x/build/internal/coordinator/schedule"
@@ -111,8 +112,22 @@ var (
 	buildEnvName  = flag.String("env", "", "The build environment configuration to use. Not required if running in dev mode locally or prod mode on GCE.")
 	devEnableGCE  = flag.Bool("dev_gce", false, "Whether or not to enable the GCE pool when in dev mode. The pool is enabled by default in prod mode.")
 	devEnableEC2  = flag.Bool("dev_ec2", false, "Whether or not to enable the EC2 pool when in dev mode. The pool is enabled by default in prod mode.")
+
+	devLocalBuildlet   = flag.String("dev_local_buildlet", "", "Path to a buildlet binary built for this machine. If set in dev mode, the host types that can run on this machine get buildlets started locally from it instead of from the other pools.")
+	devLocalContainers = flag.String("dev_local_containers", "", "The Docker compatible tool, such as docker or podman, used in dev mode to run the local buildlets of host types with a container image in containers. If empty, local buildlets run as processes.")
+	devLocalRegistry   = flag.String("dev_local_registry", "", "The registry the container images of local buildlets are pulled from in dev mode. If empty, the production registry is used.")
+
+	schedPolicy       = flag.String("sched_policy", "priority", "The policy deciding which builds and gomotes waiting for buildlets get them first: 'priority' orders them by priority and age, 'fairshare' shares buildlets fairly among the classes of work and the users within each class.")
+	schedPreemptAfter = flag.Duration("sched_preempt_after", 0, "With -sched_policy=fairshare, how long work waits for a buildlet before lower priority post-submit or trybot work over its share may be canceled to make room. Zero disables preemption.")
+	schedTrace        = flag.String("sched_trace", "", "If non-empty, the file to record scheduling events to, for replaying with cmd/schedsim.")
+
+	autoscaleEnabled = flag.Bool("autoscale", false, "Whether to start GCE and EC2 buildlets ahead of the demand forecast from the past demand for them.")
+	autoscaleMaxWarm = flag.Int("autoscale_max_warm", 4, "With -autoscale, the most idle buildlets started ahead of demand to keep for each host type.")
 )
 
+// autoscaler prewarms buildlets for the forecast demand, if enabled.
+var autoscaler *autoscale.Autoscaler
+
 // LOCK ORDER:
 //   statusMu, buildStatus.mu, trySet.mu
 // (Other locks, such as the remoteBuildlet mutex should
@@ -231,6 +246,9 @@ func main() {
 
 	pool.SetProcessMetadata(processID, processStartTime)
 
+	schedClose := mustCreateScheduler()
+	defer schedClose()
+
 	if Version == "" && *mode == "dev" {
 		Version = "dev"
 	}
@@ -283,6 +301,11 @@ func main() {
 		defer ec2PoolClose()
 	}
 
+	if *mode == "dev" && *devLocalBuildlet != "" {
+		localPoolClose := mustCreateLocalBuildletPool()
+		defer localPoolClose()
+	}
+
 	if *mode == "dev" {
 		// Replace linux-amd64 with a config using a -localdev reverse
 		// buildlet so it is possible to run local builds by starting a
@@ -399,7 +422,7 @@ func main() {
 	if *mode == "dev" {
 		// TODO(crawshaw): do more in dev mode
 		gce.BuildletPool().SetEnabled(*devEnableGCE)
-		if *devEnableGCE || *devEnableEC2 {
+		if *devEnableGCE || *devEnableEC2 || *devLocalBuildlet != "" {
 			go findWorkLoop()
 		}
 	} else {
@@ -416,6 +439,10 @@ func main() {
 		// TODO(cmang): gccgo will need its own findWorkLoop
 	}
 
+	if *autoscaleEnabled {
+		startAutoscaler()
+	}
+
 	if *mode == "dev" {
 		// Use hostPathHandler in local development mode (only) to improve
 		// convenience of testing multiple domains that coordinator serves.
@@ -617,28 +644,23 @@ func getStatus(work buildgo.BuilderRev, statusPtrStr string) *buildStatus {
 	return nil
 }
 
-// cancelOnePostSubmitBuildWithHostType tries to cancel one
-// post-submit (non trybot) build with the provided host type and
-// reports whether it did so.
-//
-// It currently selects the one that's been running the least amount
-// of time, but that's not guaranteed.
-func cancelOnePostSubmitBuildWithHostType(hostType string) bool {
+// cancelBuildWithSchedItem cancels the build whose buildlet was
+// requested with si, so that the scheduler can preempt it, and reports
+// whether it did so. Buildlets of helpers and of finished builds aren't
+// canceled.
+func cancelBuildWithSchedItem(si *queue.SchedItem) bool {
 	statusMu.Lock()
 	defer statusMu.Unlock()
-	var best *buildStatus
 	for _, st := range status {
-		if st.isTry() || st.conf.HostType != hostType {
-			continue
-		}
-		if best == nil || st.startTime.After(best.startTime) {
-			best = st
+		st.mu.Lock()
+		match := st.schedItem == si
+		st.mu.Unlock()
+		if match {
+			go st.cancelBuild()
+			return true
 		}
 	}
-	if best != nil {
-		go best.cancelBuild()
-	}
-	return best != nil
+	return false
 }
 
 type byAge []*buildStatus
@@ -922,6 +944,31 @@ func findWorkLoop() {
 	}
 }
 
+// knownReleaseBranches are the release branches of the go repository
+// that findWork has seen. It's nil until findWork first sees branches.
+var knownReleaseBranches map[string]bool
+
+// noteReleaseBranches records the branches of the go repository with
+// post-submit work, and tells the autoscaler about the release branches
+// among them that are new since the first call. It's only called by
+// findWork.
+func noteReleaseBranches(branches map[string]bool) {
+	first := knownReleaseBranches == nil
+	if first {
+		knownReleaseBranches = make(map[string]bool)
+	}
+	for b := range branches {
+		if !strings.HasPrefix(b, "release-branch.") || knownReleaseBranches[b] {
+			continue
+		}
+		knownReleaseBranches[b] = true
+		if !first && autoscaler != nil {
+			log.Printf("new release branch %s; expecting more demand for buildlets", b)
+			autoscaler.Event(autoscale.EventReleaseBranch)
+		}
+	}
+}
+
 // findWork polls the https://build.golang.org/ dashboard once to find
 // post-submit work to do. It's called in a loop by findWorkLoop.
 func findWork() error {
@@ -942,6 +989,7 @@ func findWork() error {
 	seenSubrepo := make(map[string]bool)
 	commitTime := make(map[string]string)   // git rev => "2019-11-20T22:54:54Z" (time.RFC3339 from build.golang.org's JSON)
 	commitBranch := make(map[string]string) // git rev => "master"
+	goBranches := make(map[string]bool)     // branches of repo "go"
 
 	add := func(br buildgo.BuilderRev) {
 		var d commitDetail
@@ -980,6 +1028,7 @@ func findWork() error {
 		commitBranch[br.Revision] = br.Branch
 		awaitSnapshot := false
 		if br.Repo == "go" {
+			goBranches[br.Branch] = true
 			if br.Branch == "master" {
 				goRevisions = append(goRevisions, br.Revision)
 			} else if br.Branch == "dev.typeparams" {
@@ -1043,6 +1092,8 @@ func findWork() error {
 		}
 	}
 
+	noteReleaseBranches(goBranches)
+
 	// And to bootstrap new builders, see if we have any builders
 	// that the dashboard doesn't know about.
 	for b, builderInfo := range dashboard.Builders {
@@ -2239,3 +2290,67 @@ func mustCreateEC2BuildletPool(sc *secret.Client, isRemoteBuildlet func(instName
 	}
 	return ec2Pool.Close
 }
+
+// startAutoscaler starts the autoscaler of the GCE and EC2 buildlet
+// pools in use.
+func startAutoscaler() {
+	var clouds []autoscale.Cloud
+	if *mode == "prod" || *devEnableGCE {
+		clouds = append(clouds, pool.NewGCEConfiguration().BuildletPool())
+	}
+	if ec2Pool := pool.EC2BuildetPool(); ec2Pool != nil {
+		clouds = append(clouds, ec2Pool)
+	}
+	if len(clouds) == 0 {
+		log.Printf("autoscaling disabled: no GCE or EC2 buildlet pool")
+		return
+	}
+	autoscaler = autoscale.New(sched.Demand, clouds, autoscale.OptMaxWarm(*autoscaleMaxWarm))
+	go autoscaler.Run(context.Background())
+}
+
+// mustCreateScheduler replaces sched with a scheduler using the
+// scheduling flags.
+func mustCreateScheduler() (close func()) {
+	var opts []schedule.SchedulerOption
+	switch *schedPolicy {
+	case "priority":
+		if *schedPreemptAfter != 0 {
+			log.Fatalf("-sched_preempt_after requires -sched_policy=fairshare")
+		}
+	case "fairshare":
+		opts = append(opts, schedule.WithPolicy(&schedule.FairSharePolicy{PreemptAfter: *schedPreemptAfter}))
+		if *schedPreemptAfter > 0 {
+			opts = append(opts, schedule.WithPreempter(cancelBuildWithSchedItem))
+		}
+	default:
+		log.Fatalf("unknown -sched_policy %q", *schedPolicy)
+	}
+	close = func() {}
+	if *schedTrace != "" {
+		f, err := os.OpenFile(*schedTrace, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			log.Fatalf("opening scheduling trace: %v", err)
+		}
+		opts = append(opts, schedule.WithTrace(f))
+		close = func() { f.Close() }
+	}
+	sched = schedule.NewScheduler(opts...)
+	return close
+}
+
+func mustCreateLocalBuildletPool() (close func()) {
+	var opts []pool.LocalOpt
+	if *devLocalContainers != "" {
+		opts = append(opts, pool.LocalOptContainerRuntime(*devLocalContainers))
+	}
+	if *devLocalRegistry != "" {
+		opts = append(opts, pool.LocalOptImageRegistry(*devLocalRegistry))
+	}
+	localPool, err := pool.NewLocalBuildlet(*devLocalBuildlet, dashboard.Hosts, opts...)
+	if err != nil {
+		log.Fatalf("unable to create local buildlet pool: %s", err)
+	}
+	log.Printf("local buildlet pool running host types %q", localPool.HostTypes())
+	return localPool.Close
+}
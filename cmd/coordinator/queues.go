@@ -38,6 +38,9 @@ func handleQueues(w http.ResponseWriter, _ *http.Request) {
 	mergeStats(pool.ReversePool().QuotaStats())
 	mergeStats(pool.EC2BuildetPool().QuotaStats())
 	mergeStats(pool.NewGCEConfiguration().BuildletPool().QuotaStats())
+	if lp := pool.LocalBuildletPool(); lp != nil {
+		mergeStats(lp.QuotaStats())
+	}
 	if err := queuesTemplate.Execute(w, resp); err != nil {
 		log.Printf("handleQueues: %v", err)
 	}
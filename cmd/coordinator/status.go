@@ -31,6 +31,7 @@ import (
 	"github.com/google/go-github/v74/github"
 	"go.opencensus.io/stats"
 	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal/coordinator/autoscale"
 	"golang.org/x/build/internal/coordinator/pool"
 	"golang.org/x/build/internal/coordinator/schedule"
 	"golang.org/x/build/internal/secret"
@@ -572,7 +573,16 @@ func handleStatus(w http.ResponseWriter, r *http.Request) {
 	pool.ReversePool().WriteHTMLStatus(&buf)
 	data.ReversePoolStatus = template.HTML(buf.String())
 
+	if lp := pool.LocalBuildletPool(); lp != nil {
+		buf.Reset()
+		lp.WriteHTMLStatus(&buf)
+		data.LocalPoolStatus = template.HTML(buf.String())
+	}
+
 	data.SchedState = sched.State()
+	if autoscaler != nil {
+		data.Autoscale = autoscaler.Status()
+	}
 
 	buf.Reset()
 	if err := statusTmpl.Execute(&buf, data); err != nil {
@@ -635,7 +645,9 @@ type statusData struct {
 	GCEPoolStatus     template.HTML // TODO: embed template
 	EC2PoolStatus     template.HTML // TODO: embed template
 	ReversePoolStatus template.HTML // TODO: embed template
+	LocalPoolStatus   template.HTML // TODO: embed template
 	SchedState        schedule.SchedulerState
+	Autoscale         []autoscale.HostStatus
 	DiskFree          string
 	Version           string
 	HealthCheckers    []*healthChecker
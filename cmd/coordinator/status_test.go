@@ -16,6 +16,7 @@ import (
 	"testing"
 	"time"
 
+	"golang.org/x/build/internal/coordinator/autoscale"
 	"golang.org/x/build/internal/coordinator/schedule"
 )
 
@@ -154,6 +155,30 @@ func TestStatusSched(t *testing.T) {
 	}
 }
 
+func TestStatusAutoscale(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := statusTmpl.Execute(buf, statusData{}); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("<h2 id=forecast>")) {
+		t.Errorf("status page without autoscaling has a forecast section")
+	}
+
+	data := statusData{
+		Autoscale: []autoscale.HostStatus{
+			{HostType: "host-linux-amd64-bookworm", Demand: 12, Forecast: 15.25, Target: 3, Warm: 2},
+		},
+	}
+	buf.Reset()
+	if err := statusTmpl.Execute(buf, data); err != nil {
+		t.Fatal(err)
+	}
+	want := `<li><b>host-linux-amd64-bookworm</b>: 12 in use or waiting, 15.2 forecast, 2/3 prewarmed</li>`
+	if got := section(buf.Bytes(), "forecast"); !bytes.Contains(got, []byte(want)) {
+		t.Errorf("forecast section = %s; want it to contain %s", got, want)
+	}
+}
+
 // section returns the section of the status HTML page that starts
 // with <h2 id=$section> and ends with any other ^<h2 line.
 func section(in []byte, section string) []byte {
@@ -38,6 +38,7 @@ func create(args []string) error {
 	fs.Usage = func() {
 		log := usageLogger
 		log.Print("create usage: gomote create [create-opts] <type>")
+		log.Print("             gomote create [create-opts] -from-snapshot <name> [type]")
 		log.Print()
 		log.Print("If there's a valid group specified, new instances are")
 		log.Print("automatically added to the group. If the group in")
@@ -67,6 +68,10 @@ func create(args []string) error {
 		log.Print("Subrepo gomotes set up a copy of the subrepo in the workdir,")
 		log.Print("a full git checkout sync'd to tip-of-tree.")
 		log.Print()
+		log.Print("With -from-snapshot, the new instances start with the contents")
+		log.Print("of a snapshot made by 'gomote snapshot'. The builder type")
+		log.Print("defaults to the one the snapshot was made on.")
+		log.Print()
 		log.Print("Flags:")
 		fs.PrintDefaults()
 		os.Exit(1)
@@ -79,6 +84,7 @@ func create(args []string) error {
 	fs.BoolVar(&cfg.setup, "setup", false, "set up the instance by pushing GOROOT and building the Go toolchain")
 	fs.StringVar(&cfg.newGroup, "new-group", "", "also create a new group and add the new instances to it")
 	fs.BoolVar(&cfg.useGolangbuild, "use-golangbuild", true, "disable the installation of build dependencies installed by golangbuild")
+	fs.StringVar(&cfg.snapshot, "from-snapshot", "", "create the instances from the named snapshot")
 
 	fs.Parse(args)
 	if listBuilders {
@@ -91,10 +97,19 @@ func create(args []string) error {
 		}
 		return nil
 	}
-	if fs.NArg() != 1 {
+	var builderType string
+	switch {
+	case fs.NArg() == 1:
+		builderType = fs.Arg(0)
+	case fs.NArg() == 0 && cfg.snapshot != "":
+		snap, err := findSnapshot(context.Background(), cfg.snapshot)
+		if err != nil {
+			return err
+		}
+		builderType = snap.GetBuilderType()
+	default:
 		fs.Usage()
 	}
-	builderType := fs.Arg(0)
 	_, _, err := createInstances(context.Background(), builderType, &cfg)
 	return err
 }
@@ -105,6 +120,7 @@ type createConfig struct {
 	setup          bool
 	newGroup       string
 	useGolangbuild bool
+	snapshot       string // if set, the snapshot to create the instances from
 }
 
 func createInstances(ctx context.Context, builderType string, cfg *createConfig) ([]string, *groupData, error) {
@@ -138,7 +154,7 @@ func createInstances(ctx context.Context, builderType string, cfg *createConfig)
 			if !cfg.useGolangbuild {
 				exp = append(exp, "disable-golang-build")
 			}
-			stream, err := client.CreateInstance(ctx, &protos.CreateInstanceRequest{BuilderType: builderType, ExperimentOption: exp})
+			stream, err := client.CreateInstance(ctx, &protos.CreateInstanceRequest{BuilderType: builderType, Snapshot: cfg.snapshot, ExperimentOption: exp})
 			if err != nil {
 				return fmt.Errorf("failed to create buildlet: %w", err)
 			}
@@ -155,6 +171,9 @@ func createInstances(ctx context.Context, builderType string, cfg *createConfig)
 					log.Printf("still creating %s (%d) after %v; %d requests ahead of you\n", builderType, i+1, time.Since(start).Round(time.Second), update.GetWaitersAhead())
 				case update.GetStatus() == protos.CreateInstanceResponse_COMPLETE:
 					inst = update.GetInstance().GetGomoteId()
+					if update.GetFromWarmPool() && cfg.printStatus {
+						log.Printf("took %s (%d) from a warm pool\n", builderType, i+1)
+					}
 				}
 			}
 			fmt.Println(inst)
@@ -190,7 +209,7 @@ func createInstances(ctx context.Context, builderType string, cfg *createConfig)
 			if !detailedProgress {
 				log.Printf("Pushing GOROOT %q to %q...\n", goroot, inst)
 			}
-			if err := doPush(ctx, inst, goroot, false, detailedProgress); err != nil {
+			if err := doPush(ctx, inst, gorootPush(goroot), false, detailedProgress); err != nil {
 				return err
 			}
 
@@ -0,0 +1,175 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/build/internal/gomote/forward"
+	"golang.org/x/build/internal/gomote/protos"
+)
+
+func forwardPort(args []string) error {
+	fs := flag.NewFlagSet("forward", flag.ContinueOnError)
+	fs.Usage = func() {
+		log := usageLogger
+		log.Print("forward usage: gomote forward [forward-opts] [instance] <listen-port>:<target-port>")
+		log.Print()
+		log.Print("Forward connections to a local port to a port on the instance's loopback interface,")
+		log.Print("or with -R, connections to a port on the instance to a local port. A single port")
+		log.Print("means the same port on both ends. With -R, the listen port may be 0 to have the")
+		log.Print("instance pick a free one.")
+		log.Print()
+		log.Print("Instance name is optional if a group with a single instance is specified.")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	var reverse bool
+	fs.BoolVar(&reverse, "R", false, "listen on the instance and forward connections to the local machine")
+	fs.Parse(args)
+
+	var name, spec string
+	switch fs.NArg() {
+	case 1:
+		if activeGroup == nil || len(activeGroup.Instances) != 1 {
+			return errors.New("instance name is required unless the active group has exactly one instance")
+		}
+		name, spec = activeGroup.Instances[0], fs.Arg(0)
+	case 2:
+		name, spec = fs.Arg(0), fs.Arg(1)
+	default:
+		fs.Usage()
+	}
+	listenPort, targetPort, err := parseForwardSpec(spec)
+	if err != nil {
+		return err
+	}
+	if targetPort == 0 || listenPort == 0 && !reverse {
+		return fmt.Errorf("invalid ports %q: only the -R listen port may be 0", spec)
+	}
+	return doForward(context.Background(), name, listenPort, targetPort, reverse)
+}
+
+// parseForwardSpec parses a forwarding specification of the form
+// "listen:target" or "port".
+func parseForwardSpec(spec string) (listen, target int, err error) {
+	l, t, ok := strings.Cut(spec, ":")
+	if !ok {
+		t = l
+	}
+	if listen, err = parsePort(l); err != nil {
+		return 0, 0, err
+	}
+	if target, err = parsePort(t); err != nil {
+		return 0, 0, err
+	}
+	return listen, target, nil
+}
+
+func parsePort(s string) (int, error) {
+	port, err := strconv.Atoi(s)
+	if err != nil || port < 0 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %q", s)
+	}
+	return port, nil
+}
+
+func doForward(ctx context.Context, name string, listenPort, targetPort int, reverse bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := gomoteServerClient(ctx).ForwardPort(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to forward port: %w", err)
+	}
+	var sendMu sync.Mutex
+	send := func(req *protos.ForwardPortRequest) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.Send(req)
+	}
+	conns := forward.NewConns(func(id int64, data []byte, err error) error {
+		if data != nil {
+			return send(&protos.ForwardPortRequest{ConnId: id, Data: data})
+		}
+		req := &protos.ForwardPortRequest{ConnId: id, Close: true}
+		if err != nil {
+			req.Error = err.Error()
+		}
+		return send(req)
+	})
+	defer conns.CloseAll()
+
+	localAddr := func(port int) string {
+		return net.JoinHostPort("localhost", strconv.Itoa(port))
+	}
+	if reverse {
+		if err := send(&protos.ForwardPortRequest{GomoteId: name, RemotePort: int32(listenPort), Reverse: true}); err != nil {
+			return fmt.Errorf("unable to forward port: %w", err)
+		}
+	} else {
+		ln, err := net.Listen("tcp", localAddr(listenPort))
+		if err != nil {
+			return err
+		}
+		defer ln.Close()
+		if err := send(&protos.ForwardPortRequest{GomoteId: name, RemotePort: int32(targetPort)}); err != nil {
+			return fmt.Errorf("unable to forward port: %w", err)
+		}
+		log.Printf("Forwarding %s to port %d on %q...", ln.Addr(), targetPort, name)
+		go func() {
+			for id := int64(1); ; id++ {
+				c, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				if err := send(&protos.ForwardPortRequest{ConnId: id, Open: true}); err != nil {
+					c.Close()
+					return
+				}
+				conns.Add(id, c)
+			}
+		}()
+	}
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return errors.New("forwarding stopped by the server")
+		}
+		if err != nil {
+			return fmt.Errorf("forwarding failed: %w", err)
+		}
+		id := res.GetConnId()
+		switch {
+		case res.GetListenAddress() != "":
+			log.Printf("Forwarding %s on %q to port %d...", res.GetListenAddress(), name, targetPort)
+		case res.GetOpen():
+			c, err := net.Dial("tcp", localAddr(targetPort))
+			if err != nil {
+				log.Printf("Connection %d: %v", id, err)
+				send(&protos.ForwardPortRequest{ConnId: id, Close: true, Error: err.Error()})
+				continue
+			}
+			conns.Add(id, c)
+		case len(res.GetData()) > 0:
+			conns.Write(id, res.GetData())
+		case res.GetClose():
+			if res.GetError() != "" {
+				log.Printf("Connection %d: %s", id, res.GetError())
+			}
+			conns.CloseWrite(id)
+		}
+	}
+}
@@ -74,13 +74,18 @@ func doGetTar(ctx context.Context, name, dir string, out io.Writer) error {
 	if err != nil {
 		return fmt.Errorf("unable to retrieve tgz URL: %w", err)
 	}
+	return downloadTGZ(ctx, resp.GetUrl(), out)
+}
+
+// downloadTGZ downloads the tarball an instance wrote to url.
+func downloadTGZ(ctx context.Context, url string, out io.Writer) error {
 	httpClient := &http.Client{
 		Timeout: 10 * time.Second,
 		Transport: &http.Transport{
 			TLSHandshakeTimeout: 5 * time.Second,
 		},
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resp.GetUrl(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return fmt.Errorf("unable to create HTTP Request: %w", err)
 	}
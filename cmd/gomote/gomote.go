@@ -25,19 +25,26 @@ To list the subcommands, run "gomote" without arguments:
 
 	  create     create a buildlet; with no args, list types of buildlets
 	  destroy    destroy a buildlet
+	  forward    forward TCP ports to or from a buildlet
 	  gettar     extract a tar.gz from a buildlet
 	  list       list active buildlets
 	  login      create authentication credentials for the gomote services
 	  ls         list the contents of a directory on a buildlet
 	  ping       test whether a buildlet is alive and reachable
-	  push       sync your GOROOT directory to the buildlet
+	  push       sync your GOROOT, or another directory, to the buildlet
 	  put        put files on a buildlet
 	  puttar     extract a tar.gz to a buildlet
+	  record     record a group's session for replaying it later
+	  replay     replay a recorded session on new buildlets
 	  rm         delete files or directories
 	  rdp        RDP (Remote Desktop Protocol) to a Windows buildlet
 	  repro      reproduce a build by LUCI build ID
 	  run        run a command on a buildlet
+	  snapshot   save, list or delete snapshots of buildlets' files
 	  ssh        ssh to a buildlet
+	  usage      show your usage of instances and your quotas
+	  warmpool   keep buildlets ready for quick creation
+	  watch      push changes and rerun a command whenever local files change
 
 To list all the builder types available, run "create" with no arguments:
 
@@ -122,6 +129,34 @@ commands.
   - The run command always streams output to a temporary file regardless
     of any additional flags to avoid losing output due to terminal
     scrollback. It always prints the location of the file.
+  - The push command accepts the -src flag for pushing a directory other
+    than GOROOT, such as a checkout of an x/ repository. Only the new and
+    changed parts of files are sent, so pushing again after small edits
+    is quick.
+  - The run command accepts the -separate-stderr flag for keeping the
+    command's standard error out of its standard output, for instance to
+    parse the output of "go test -json". When a single instance runs the
+    command, gomote exits with the command's exit code.
+  - The forward command makes a port on the instance's loopback interface
+    reachable locally, for instance to look at a pprof or debugger server
+    started by a test. With -R, it goes the other way, letting the
+    instance reach a server running on your machine.
+  - The record command records what's done to a group's instances, with
+    the output of the commands run on them, in an archive that can be
+    shared. The replay command runs the same steps on new instances, of
+    the same or another builder type, to reproduce a bug elsewhere.
+  - The snapshot command saves a directory of an instance, such as a
+    built GOROOT, and the create command's -from-snapshot flag starts new
+    instances with it. Combined with the warmpool command, which keeps
+    such instances ready, this skips waiting for instances to start and
+    for make.bash to run.
+  - The watch command pushes a local directory to a group's instances
+    and reruns a command on them each time a file in it changes, printing
+    which instances passed and failed, for a tight edit-test loop across
+    platforms, as in "gomote watch -src=. -- go/bin/go test ./pkg".
+  - Instances that stay unused are destroyed, sooner for scarce builder
+    types. The usage command shows your quotas and past usage, and with
+    -watch, warns you before one of your instances is destroyed.
 
 Using some of these tricks, it's straightforward to hammer at some test
 to reproduce a rare failure, like so:
@@ -196,20 +231,27 @@ func registerCommand(name, des string, run func([]string) error) {
 func registerCommands() {
 	registerCommand("create", "create a buildlet; with no args, list types of buildlets", create)
 	registerCommand("destroy", "destroy a buildlet", destroy)
+	registerCommand("forward", "forward TCP ports to or from a buildlet", forwardPort)
 	registerCommand("gettar", "extract a tar.gz from a buildlet", getTar)
 	registerCommand("group", "manage groups of instances", group)
 	registerCommand("list", "list active buildlets", list)
 	registerCommand("login", "authenticate with the gomote service", login)
 	registerCommand("ls", "list the contents of a directory on a buildlet", ls)
 	registerCommand("ping", "test whether a buildlet is alive and reachable ", ping)
-	registerCommand("push", "sync your GOROOT directory to the buildlet", push)
+	registerCommand("push", "sync your GOROOT, or another directory, to the buildlet", push)
 	registerCommand("put", "put files on a buildlet", put)
 	registerCommand("puttar", "extract a tar.gz to a buildlet", putTar)
+	registerCommand("record", "record a group's session for replaying it later", record)
+	registerCommand("replay", "replay a recorded session on new buildlets", replay)
 	registerCommand("repro", "reproduce a build environment in a new buildlet", repro)
 	registerCommand("rdp", "Unimplimented: RDP (Remote Desktop Protocol) to a Windows buildlet", rdp)
 	registerCommand("rm", "delete files or directories", rm)
 	registerCommand("run", "run a command on a buildlet", run)
+	registerCommand("snapshot", "save, list or delete snapshots of buildlets' files", snapshot)
 	registerCommand("ssh", "ssh to a buildlet", ssh)
+	registerCommand("usage", "show your usage of instances and your quotas", showUsage)
+	registerCommand("warmpool", "keep buildlets ready for quick creation", warmPool)
+	registerCommand("watch", "push changes and rerun a command whenever local files change", watch)
 }
 
 var (
@@ -261,6 +303,11 @@ func main() {
 		usage()
 	}
 	if err := cmd.run(args[1:]); err != nil {
+		var ce *cmdFailedError
+		if errors.As(err, &ce) {
+			log.Printf("Error running %s: %v\n", cmdName, err)
+			os.Exit(ce.exitCode())
+		}
 		logAndExitf("Error running %s: %v\n", cmdName, err)
 	}
 }
@@ -276,6 +323,12 @@ func gomoteServerClient(ctx context.Context) protos.GomoteServiceClient {
 		}
 		logAndExitf("dialing the server=%s failed with: %s\n", *serverAddr, err)
 	}
+	if dir := recordingDir(); dir != "" {
+		return protos.NewGomoteServiceClient(&recordingConn{
+			ClientConnInterface: grpcClient,
+			rec:                 &recorder{dir: dir, command: os.Args[1:]},
+		})
+	}
 	return protos.NewGomoteServiceClient(grpcClient)
 }
 
@@ -21,29 +21,56 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
 
 	"golang.org/x/build/buildlet"
 	"golang.org/x/build/internal/gomote/protos"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func push(args []string) error {
 	fs := flag.NewFlagSet("push", flag.ContinueOnError)
 	var dryRun bool
 	fs.BoolVar(&dryRun, "dry-run", false, "print what would be done only")
+	var src, dir string
+	fs.StringVar(&src, "src", "", "local directory to push; defaults to $GOROOT")
+	fs.StringVar(&dir, "dir", "", "relative directory from the buildlet's work dir to push to; defaults to \"go\" for $GOROOT, or the base name of -src")
 	fs.Usage = func() {
 		log := usageLogger
-		log.Print("push usage: gomote push <instance>")
+		log.Print("push usage: gomote push [push-opts] <instance>")
+		log.Print()
+		log.Print("Only new and changed parts of files are sent, and files ignored by git are skipped.")
+		log.Print("Instance name is optional if a group is specified.")
 		fs.PrintDefaults()
 		os.Exit(1)
 	}
 	fs.Parse(args)
 
-	goroot, err := getGOROOT()
-	if err != nil {
-		return err
+	var spec pushSpec
+	if src == "" {
+		goroot, err := getGOROOT()
+		if err != nil {
+			return err
+		}
+		spec = gorootPush(goroot)
+	} else {
+		abs, err := filepath.Abs(src)
+		if err != nil {
+			return err
+		}
+		if fi, err := os.Stat(abs); err != nil {
+			return err
+		} else if !fi.IsDir() {
+			return fmt.Errorf("%s is not a directory", src)
+		}
+		spec = pushSpec{src: abs, dst: filepath.Base(abs)}
+	}
+	if dir != "" {
+		spec.dst = dir
 	}
 
 	var pushSet []string
@@ -61,71 +88,68 @@ func push(args []string) error {
 	eg, ctx := errgroup.WithContext(context.Background())
 	for _, inst := range pushSet {
 		eg.Go(func() error {
-			log.Printf("Pushing GOROOT %q to %q...\n", goroot, inst)
-			return doPush(ctx, inst, goroot, dryRun, detailedProgress)
+			if spec.goroot {
+				log.Printf("Pushing GOROOT %q to %q...\n", spec.src, inst)
+			} else {
+				log.Printf("Pushing %q to %q on %q...\n", spec.src, spec.dst, inst)
+			}
+			return doPush(ctx, inst, spec, dryRun, detailedProgress)
 		})
 	}
 	return eg.Wait()
 }
 
-func doPush(ctx context.Context, name, goroot string, dryRun, detailedProgress bool) error {
+// A pushSpec describes a local directory to push to an instance.
+type pushSpec struct {
+	src    string // local directory
+	dst    string // remote directory, relative to the work directory
+	goroot bool   // whether src is a GOROOT
+}
+
+// gorootPush returns the pushSpec for pushing a GOROOT to the
+// instance's "go" directory.
+func gorootPush(goroot string) pushSpec {
+	return pushSpec{src: goroot, dst: "go", goroot: true}
+}
+
+func doPush(ctx context.Context, name string, spec pushSpec, dryRun, detailedProgress bool) error {
+	return pushWithClient(ctx, gomoteServerClient(ctx), name, spec, dryRun, detailedProgress)
+}
+
+// pushWithClient is doPush using the given gomote server client.
+func pushWithClient(ctx context.Context, client protos.GomoteServiceClient, name string, spec pushSpec, dryRun, detailedProgress bool) error {
 	logf := func(s string, a ...any) {
 		if detailedProgress {
 			log.Printf(s, a...)
 		}
 	}
-	remote := map[string]buildlet.DirEntry{} // keys like "src/make.bash"
-
-	client := gomoteServerClient(ctx)
-	stream, err := client.ListDirectoryStreaming(ctx, &protos.ListDirectoryRequest{
-		GomoteId:  name,
-		Directory: ".",
-		Recursive: true,
-		SkipFiles: []string{
-			// Ignore binary output directories:
-			"go/pkg", "go/bin",
-			// We don't care about the digest of
-			// particular source files for Go 1.4.  And
-			// exclude /pkg. This leaves go1.4/bin, which
-			// is enough to know whether we have Go 1.4 or
-			// not.
-			"go1.4/src", "go1.4/pkg",
-			// Ignore the cache and tmp directories, these slowly grow, and will
-			// eventually cause the listing to exceed the maximum gRPC message
-			// size.
-			"gocache", "goplscache", "tmp",
-		},
-		Digest: true,
-	})
+	var skip []string
+	if spec.goroot {
+		// Ignore binary output directories.
+		skip = []string{"pkg", "bin"}
+	}
+	// remote maps files to digests comparable to the local ones:
+	// their joined block hashes, or with the tarball fallback for
+	// older buildlets, their SHA-1s.
+	remote, remoteBlocks, err := remoteSyncManifest(ctx, client, name, spec.dst, skip)
+	legacy := status.Code(err) == codes.Unimplemented
+	if legacy {
+		logf("Instance doesn't support incremental push; sending whole changed files")
+		remote, err = remoteDigests(ctx, client, name, spec.dst, skip)
+	}
 	if err != nil {
 		return fmt.Errorf("error listing buildlet's existing files: %w", err)
 	}
-	for {
-		resp, err := stream.Recv()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return fmt.Errorf("error listing buildlet's existing files: %w", err)
-		}
-		for _, entry := range resp.GetEntries() {
-			de := buildlet.DirEntry{Line: entry}
-			en := de.Name()
-			if strings.HasPrefix(en, "go/") && en != "go/" {
-				remote[en[len("go/"):]] = de
-			}
-		}
-	}
 
 	type fileInfo struct {
-		fi   os.FileInfo
-		sha1 string // if regular file
+		fi     os.FileInfo
+		digest string // if regular file
 	}
 	local := map[string]fileInfo{} // keys like "src/make.bash"
 
-	// Ensure that the goroot passed to filepath.Walk ends in a trailing slash,
-	// so that if GOROOT is a symlink we walk the underlying directory.
-	walkRoot := goroot
+	// Ensure that the directory passed to filepath.Walk ends in a trailing slash,
+	// so that if it is a symlink we walk the underlying directory.
+	walkRoot := spec.src
 	if walkRoot != "" && !os.IsPathSeparator(walkRoot[len(walkRoot)-1]) {
 		walkRoot += string(filepath.Separator)
 	}
@@ -137,9 +161,9 @@ func doPush(ctx context.Context, name, goroot string, dryRun, detailedProgress b
 		if err != nil {
 			return err
 		}
-		rel, err := filepath.Rel(goroot, path)
+		rel, err := filepath.Rel(spec.src, path)
 		if err != nil {
-			return fmt.Errorf("error calculating relative path from %q to %q", goroot, path)
+			return fmt.Errorf("error calculating relative path from %q to %q", spec.src, path)
 		}
 		rel = filepath.ToSlash(rel)
 		if rel == "." {
@@ -151,16 +175,17 @@ func doPush(ctx context.Context, name, goroot string, dryRun, detailedProgress b
 			}
 			return nil // .git is a file in `git worktree` checkouts.
 		}
-		if fi.IsDir() {
-			switch rel {
-			case "pkg", "bin":
-				return filepath.SkipDir
-			}
+		if fi.IsDir() && slices.Contains(skip, rel) {
+			return filepath.SkipDir
 		}
 		inf := fileInfo{fi: fi}
 		absToRel[path] = rel
 		if fi.Mode().IsRegular() {
-			inf.sha1, err = fileSHA1(path)
+			if legacy {
+				inf.digest, err = fileSHA1(path)
+			} else {
+				inf.digest, err = fileBlocksDigest(path)
+			}
 			if err != nil {
 				return err
 			}
@@ -168,73 +193,55 @@ func doPush(ctx context.Context, name, goroot string, dryRun, detailedProgress b
 		local[rel] = inf
 		return nil
 	}); err != nil {
-		return fmt.Errorf("error enumerating local GOROOT files: %w", err)
+		return fmt.Errorf("error enumerating local files: %w", err)
 	}
 
 	ignored := make(map[string]bool)
-	for _, path := range gitIgnored(goroot, absToRel) {
+	for _, path := range gitIgnored(spec.src, absToRel) {
 		ignored[absToRel[path]] = true
 		delete(local, absToRel[path])
 	}
 
 	var toDel []string
 	for rel := range remote {
-		if rel == "VERSION" {
-			// Don't delete this. It's harmless, and
-			// necessary. Clients can overwrite it if they
-			// want. But if there's no VERSION file there,
-			// make.bash/bat assumes there's a git repo in
-			// place, but there's not only not a git repo
-			// there with gomote, but there's no git tool
-			// available either.
-			continue
-		}
-		// Also don't delete the auto-generated files from cmd/dist.
-		// Otherwise gomote users can't gomote push + gomote run make.bash
-		// and then iteratively:
-		// -- hack locally
-		// -- gomote push
-		// -- gomote run go test -v ...
-		// Because the go test would fail remotely without
-		// these files if they were deleted by gomote push.
-		if isGoToolDistGenerated(rel) {
-			continue
+		if spec.goroot {
+			if rel == "VERSION" {
+				// Don't delete this. It's harmless, and
+				// necessary. Clients can overwrite it if they
+				// want. But if there's no VERSION file there,
+				// make.bash/bat assumes there's a git repo in
+				// place, but there's not only not a git repo
+				// there with gomote, but there's no git tool
+				// available either.
+				continue
+			}
+			// Also don't delete the auto-generated files from cmd/dist.
+			// Otherwise gomote users can't gomote push + gomote run make.bash
+			// and then iteratively:
+			// -- hack locally
+			// -- gomote push
+			// -- gomote run go test -v ...
+			// Because the go test would fail remotely without
+			// these files if they were deleted by gomote push.
+			if isGoToolDistGenerated(rel) {
+				continue
+			}
 		}
 		if ignored[rel] {
 			// Don't delete remote gitignored files; this breaks built toolchains.
 			continue
 		}
-		rel = strings.TrimRight(rel, "/")
-		if rel == "" {
-			continue
-		}
 		if _, ok := local[rel]; !ok {
 			toDel = append(toDel, rel)
 		}
 	}
-	if len(toDel) > 0 {
-		withGo := make([]string, len(toDel)) // with the "go/" prefix
-		for i, v := range toDel {
-			withGo[i] = "go/" + v
-		}
-		sort.Strings(withGo)
-		if dryRun {
-			logf("(Dry-run) Would have deleted remote files: %q", withGo)
-		} else {
-			logf("Deleting remote files: %q", withGo)
-			if _, err := client.RemoveFiles(ctx, &protos.RemoveFilesRequest{
-				GomoteId: name,
-				Paths:    withGo,
-			}); err != nil {
-				return fmt.Errorf("failed to delete remote unwanted files: %w", err)
-			}
-		}
-	}
+	sort.Strings(toDel)
+
 	var toSend []string
 	notHave := 0
 	const maxNotHavePrint = 5
 	for rel, inf := range local {
-		if isGoToolDistGenerated(rel) || rel == "VERSION.cache" {
+		if spec.goroot && (isGoToolDistGenerated(rel) || rel == "VERSION.cache") {
 			continue
 		}
 		if !inf.fi.Mode().IsRegular() {
@@ -251,45 +258,202 @@ func doPush(ctx context.Context, name, goroot string, dryRun, detailedProgress b
 			toSend = append(toSend, rel)
 			continue
 		}
-		if rem.Digest() != inf.sha1 {
-			logf("Remote's %s digest is %q; want %q", rel, rem.Digest(), inf.sha1)
+		if rem != inf.digest {
+			logf("Remote's %s differs", rel)
 			toSend = append(toSend, rel)
 		}
 	}
 	if notHave > maxNotHavePrint {
 		logf("Remote doesn't have %d files (only showed %d).", notHave, maxNotHavePrint)
 	}
-	_, localHasVersion := local["VERSION"]
-	if _, remoteHasVersion := remote["VERSION"]; !remoteHasVersion && !localHasVersion {
-		logf("Remote lacks a VERSION file; sending a fake one")
-		toSend = append(toSend, "VERSION")
+	if spec.goroot {
+		_, localHasVersion := local["VERSION"]
+		if _, remoteHasVersion := remote["VERSION"]; !remoteHasVersion && !localHasVersion {
+			logf("Remote lacks a VERSION file; sending a fake one")
+			toSend = append(toSend, "VERSION")
+		}
+	}
+	if len(toSend) == 0 && len(toDel) == 0 {
+		logf("Remote is up to date.")
+		return nil
+	}
+	sort.Strings(toSend)
+	if legacy {
+		return pushTarball(ctx, client, name, spec, toSend, toDel, dryRun, logf)
+	}
+	if len(toDel) > 0 {
+		logf("Deleting remote files: %q", toDel)
+	}
+	changes, st, err := generateSyncOps(spec, toSend, toDel, remoteBlocks)
+	if err != nil {
+		return err
+	}
+	logf("Uploading %d new/changed files (%d of %d blocks); %d byte sync stream", len(toSend), st.literal, st.blocks, changes.Len())
+	if dryRun {
+		logf("(Dry-run mode; not doing anything.")
+		return nil
+	}
+	resp, err := client.UploadFile(ctx, &protos.UploadFileRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to request credentials for a file upload: %w", err)
 	}
-	if len(toSend) > 0 {
-		sort.Strings(toSend)
-		tgz, err := generateDeltaTgz(goroot, toSend)
+	if dir := recordingDir(); dir != "" {
+		// The changes leave out blocks the instance already has,
+		// which a new instance replaying the session won't. Record
+		// changes that include every block instead.
+		full, _, err := generateSyncOps(spec, toSend, toDel, nil)
 		if err != nil {
 			return err
 		}
-		logf("Uploading %d new/changed files; %d byte .tar.gz", len(toSend), tgz.Len())
-		if dryRun {
-			logf("(Dry-run mode; not doing anything.")
-			return nil
+		if err := recordUploadContent(dir, full, resp.GetUrl()+resp.GetFields()["key"]); err != nil {
+			return err
+		}
+	}
+	if err := uploadToGCS(ctx, resp.GetFields(), changes, resp.GetObjectName(), resp.GetUrl()); err != nil {
+		return fmt.Errorf("unable to upload file to GCS: %w", err)
+	}
+	if _, err := client.WriteSyncFromURL(ctx, &protos.WriteSyncFromURLRequest{
+		GomoteId:  name,
+		Url:       fmt.Sprintf("%s%s", resp.GetUrl(), resp.GetObjectName()),
+		Directory: spec.dst,
+	}); err != nil {
+		return fmt.Errorf("failed writing changes to buildlet: %w", err)
+	}
+	return nil
+}
+
+// remoteSyncManifest lists the files in dir on the instance with the
+// sync protocol. It returns each file's joined block hashes, and the set
+// of all of their blocks.
+func remoteSyncManifest(ctx context.Context, client protos.GomoteServiceClient, name, dir string, skip []string) (map[string]string, map[string]bool, error) {
+	stream, err := client.SyncManifest(ctx, &protos.SyncManifestRequest{
+		GomoteId:  name,
+		Directory: dir,
+		SkipFiles: skip,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	remote := map[string]string{} // keys like "src/make.bash"
+	remoteBlocks := map[string]bool{}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return remote, remoteBlocks, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, f := range resp.GetFiles() {
+			remote[f.GetPath()] = strings.Join(f.GetBlocks(), ",")
+			for _, h := range f.GetBlocks() {
+				remoteBlocks[h] = true
+			}
+		}
+	}
+}
+
+// remoteDigests lists the files in dir on an instance that doesn't
+// support the sync protocol, with their SHA-1 digests.
+func remoteDigests(ctx context.Context, client protos.GomoteServiceClient, name, dir string, skip []string) (map[string]string, error) {
+	// List the whole work directory, since listing a directory that
+	// doesn't exist yet fails.
+	var skipFiles []string
+	for _, s := range skip {
+		skipFiles = append(skipFiles, dir+"/"+s)
+	}
+	for _, s := range []string{
+		// We don't care about the digest of
+		// particular source files for Go 1.4.  And
+		// exclude /pkg. This leaves go1.4/bin, which
+		// is enough to know whether we have Go 1.4 or
+		// not.
+		"go1.4/src", "go1.4/pkg",
+		// Ignore the cache and tmp directories, these slowly grow, and will
+		// eventually cause the listing to exceed the maximum gRPC message
+		// size.
+		"gocache", "goplscache", "tmp",
+	} {
+		if s != dir && !strings.HasPrefix(dir, s+"/") {
+			skipFiles = append(skipFiles, s)
+		}
+	}
+	stream, err := client.ListDirectoryStreaming(ctx, &protos.ListDirectoryRequest{
+		GomoteId:  name,
+		Directory: ".",
+		Recursive: true,
+		SkipFiles: skipFiles,
+		Digest:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	remote := map[string]string{} // keys like "src/make.bash"
+	prefix := dir + "/"
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return remote, nil
 		}
-		resp, err := client.UploadFile(ctx, &protos.UploadFileRequest{})
 		if err != nil {
-			return fmt.Errorf("unable to request credentials for a file upload: %w", err)
+			return nil, err
+		}
+		for _, entry := range resp.GetEntries() {
+			de := buildlet.DirEntry{Line: entry}
+			if en := de.Name(); strings.HasPrefix(en, prefix) && !de.IsDir() {
+				remote[en[len(prefix):]] = de.Digest()
+			}
 		}
-		if err := uploadToGCS(ctx, resp.GetFields(), tgz, resp.GetObjectName(), resp.GetUrl()); err != nil {
-			return fmt.Errorf("unable to upload file to GCS: %w", err)
+	}
+}
+
+// pushTarball is the fallback for doPush on instances that don't
+// support the sync protocol. It removes the files in toDel, and writes
+// those in toSend in a tarball.
+func pushTarball(ctx context.Context, client protos.GomoteServiceClient, name string, spec pushSpec, toSend, toDel []string, dryRun bool, logf func(string, ...any)) error {
+	if len(toDel) > 0 {
+		withDir := make([]string, len(toDel)) // with the spec.dst prefix
+		for i, v := range toDel {
+			withDir[i] = spec.dst + "/" + v
 		}
-		if _, err := client.WriteTGZFromURL(ctx, &protos.WriteTGZFromURLRequest{
-			GomoteId:  name,
-			Url:       fmt.Sprintf("%s%s", resp.GetUrl(), resp.GetObjectName()),
-			Directory: "go",
-		}); err != nil {
-			return fmt.Errorf("failed writing tarball to buildlet: %w", err)
+		if dryRun {
+			logf("(Dry-run) Would have deleted remote files: %q", withDir)
+		} else {
+			logf("Deleting remote files: %q", withDir)
+			if _, err := client.RemoveFiles(ctx, &protos.RemoveFilesRequest{
+				GomoteId: name,
+				Paths:    withDir,
+			}); err != nil {
+				return fmt.Errorf("failed to delete remote unwanted files: %w", err)
+			}
 		}
 	}
+	if len(toSend) == 0 {
+		return nil
+	}
+	tgz, err := generateDeltaTgz(spec, toSend)
+	if err != nil {
+		return err
+	}
+	logf("Uploading %d new/changed files; %d byte .tar.gz", len(toSend), tgz.Len())
+	if dryRun {
+		logf("(Dry-run mode; not doing anything.")
+		return nil
+	}
+	resp, err := client.UploadFile(ctx, &protos.UploadFileRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to request credentials for a file upload: %w", err)
+	}
+	if err := uploadToGCS(ctx, resp.GetFields(), tgz, resp.GetObjectName(), resp.GetUrl()); err != nil {
+		return fmt.Errorf("unable to upload file to GCS: %w", err)
+	}
+	if _, err := client.WriteTGZFromURL(ctx, &protos.WriteTGZFromURLRequest{
+		GomoteId:  name,
+		Url:       fmt.Sprintf("%s%s", resp.GetUrl(), resp.GetObjectName()),
+		Directory: spec.dst,
+	}); err != nil {
+		return fmt.Errorf("failed writing tarball to buildlet: %w", err)
+	}
 	return nil
 }
 
@@ -338,15 +502,65 @@ func gomoteDevelVersion(goroot string) (string, error) {
 	return fmt.Sprintf("go1.%s-devel_gomote", m[1]), nil
 }
 
-// file is forward-slash separated
-func generateDeltaTgz(goroot string, files []string) (*bytes.Buffer, error) {
+type syncStats struct {
+	blocks, literal int
+}
+
+// generateSyncOps returns a gzip-compressed stream of buildlet sync ops
+// that writes the files in toSend from spec.src and deletes those in
+// toDel, along with statistics about the blocks it sends. Blocks in
+// remoteBlocks are already on the buildlet, and aren't sent again.
+// The paths in toSend and toDel are forward-slash separated.
+func generateSyncOps(spec pushSpec, toSend, toDel []string, remoteBlocks map[string]bool) (*bytes.Buffer, syncStats, error) {
+	var buf bytes.Buffer
+	var st syncStats
+	zw := gzip.NewWriter(&buf)
+	for _, file := range toSend {
+		// Special.
+		if spec.goroot && file == "VERSION" && !localFileExists(filepath.Join(spec.src, file)) {
+			version, err := gomoteDevelVersion(spec.src)
+			if err != nil {
+				return nil, st, err
+			}
+			op := &buildlet.SyncOp{
+				Path:    "VERSION",
+				Mode:    0644,
+				Size:    int64(len(version)),
+				Blocks:  []string{buildlet.HashBlock([]byte(version))},
+				Literal: []int{0},
+			}
+			st.blocks++
+			st.literal++
+			if err := buildlet.WriteSyncOp(zw, op, []byte(version)); err != nil {
+				return nil, st, err
+			}
+			continue
+		}
+		if err := writeFileSyncOp(zw, spec.src, file, remoteBlocks, &st); err != nil {
+			return nil, st, err
+		}
+	}
+	for _, file := range toDel {
+		if err := buildlet.WriteSyncOp(zw, &buildlet.SyncOp{Path: file, Delete: true}, nil); err != nil {
+			return nil, st, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, st, err
+	}
+	return &buf, st, nil
+}
+
+// generateDeltaTgz returns a .tar.gz of files from spec.src. The paths
+// in files are forward-slash separated.
+func generateDeltaTgz(spec pushSpec, files []string) (*bytes.Buffer, error) {
 	var buf bytes.Buffer
 	zw := gzip.NewWriter(&buf)
 	tw := tar.NewWriter(zw)
 	for _, file := range files {
 		// Special.
-		if file == "VERSION" && !localFileExists(filepath.Join(goroot, file)) {
-			version, err := gomoteDevelVersion(goroot)
+		if spec.goroot && file == "VERSION" && !localFileExists(filepath.Join(spec.src, file)) {
+			version, err := gomoteDevelVersion(spec.src)
 			if err != nil {
 				return nil, err
 			}
@@ -362,7 +576,7 @@ func generateDeltaTgz(goroot string, files []string) (*bytes.Buffer, error) {
 			}
 			continue
 		}
-		f, err := os.Open(filepath.Join(goroot, file))
+		f, err := os.Open(filepath.Join(spec.src, file))
 		if err != nil {
 			return nil, err
 		}
@@ -400,10 +614,68 @@ func generateDeltaTgz(goroot string, files []string) (*bytes.Buffer, error) {
 	if err := zw.Close(); err != nil {
 		return nil, err
 	}
-
 	return &buf, nil
 }
 
+// writeFileSyncOp writes the sync op for the file dir/file to w, along
+// with the blocks of it that aren't in remoteBlocks.
+func writeFileSyncOp(w io.Writer, dir, file string, remoteBlocks map[string]bool, st *syncStats) error {
+	f, err := os.Open(filepath.Join(dir, file))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", file, err)
+	}
+	blocks, size, err := buildlet.HashBlocks(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	op := &buildlet.SyncOp{
+		Path:   file,
+		Mode:   fi.Mode().Perm(),
+		Size:   size,
+		Blocks: blocks,
+	}
+	if runtime.GOOS == "windows" && (strings.HasSuffix(file, ".bash") || strings.HasSuffix(file, ".rc")) {
+		// On Windows, the FileInfo won't have an executable mode bit, so if we
+		// send the mode as-is to the gomote, we'd lose the executable bit.
+		// Add the executable bit back for .bash and .rc files. As of 2025-07-17
+		// all those files have the executable bit set in git.
+		op.Mode |= 0100
+	}
+	var literal []byte
+	for i, h := range blocks {
+		st.blocks++
+		if remoteBlocks[h] {
+			continue
+		}
+		op.Literal = append(op.Literal, i)
+		off := int64(i) * buildlet.SyncBlockSize
+		literal = append(literal, data[off:off+int64(op.BlockLen(i))]...)
+		st.literal++
+	}
+	return buildlet.WriteSyncOp(w, op, literal)
+}
+
+// fileBlocksDigest returns the hashes of the buildlet sync protocol
+// blocks of the file at path, joined by commas.
+func fileBlocksDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	blocks, _, err := buildlet.HashBlocks(f)
+	return strings.Join(blocks, ","), err
+}
+
 func fileSHA1(path string) (string, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -5,7 +5,12 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"os/exec"
@@ -16,6 +21,10 @@ import (
 	"slices"
 	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/internal/gomote/protos"
 )
 
 func testGOROOT(t *testing.T) string {
@@ -120,3 +129,92 @@ func TestIsGoToolDistGenerated(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestGenerateSyncOps(t *testing.T) {
+	dir := t.TempDir()
+	big := bytes.Repeat([]byte("gopher!\n"), buildlet.SyncBlockSize/8+1) // 2 blocks
+	if err := os.WriteFile(filepath.Join(dir, "big"), big, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "small"), []byte("small"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	remoteBlocks := map[string]bool{
+		buildlet.HashBlock(big[:buildlet.SyncBlockSize]): true,
+	}
+
+	changes, st, err := generateSyncOps(pushSpec{src: dir, dst: "x"}, []string{"big", "small"}, []string{"gone"}, remoteBlocks)
+	if err != nil {
+		t.Fatalf("generateSyncOps = %v", err)
+	}
+	if st.blocks != 3 || st.literal != 2 {
+		t.Errorf("stats = %+v; want 2 of 3 blocks sent", st)
+	}
+	zr, err := gzip.NewReader(changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	br := bufio.NewReader(zr)
+	var got []string
+	for {
+		op, err := buildlet.ReadSyncOp(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ReadSyncOp = %v", err)
+		}
+		var lit []byte
+		for _, i := range op.Literal {
+			p := make([]byte, op.BlockLen(i))
+			if _, err := io.ReadFull(br, p); err != nil {
+				t.Fatalf("reading block %d of %s: %v", i, op.Path, err)
+			}
+			lit = append(lit, p...)
+		}
+		got = append(got, fmt.Sprintf("%s delete=%v mode=%v blocks=%d literal=%v %q", op.Path, op.Delete, op.Mode, len(op.Blocks), op.Literal, lit))
+	}
+	want := []string{
+		fmt.Sprintf("big delete=false mode=-rwxr-xr-x blocks=2 literal=[1] %q", big[buildlet.SyncBlockSize:]),
+		`small delete=false mode=-rw-r--r-- blocks=1 literal=[0] "small"`,
+		`gone delete=true mode=---------- blocks=0 literal=[] ""`,
+	}
+	if runtime.GOOS == "windows" {
+		t.Logf("ops: %q", got)
+		return // file modes differ
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ops:\ngot  %q\nwant %q", got, want)
+	}
+}
+
+func TestPushFallsBackToTarball(t *testing.T) {
+	fs, conn := setupFakeGomoteServer(t)
+	src := t.TempDir()
+	for name, content := range map[string]string{"same": "same", "changed": "new", "added": "added"} {
+		if err := os.WriteFile(filepath.Join(src, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sum := func(s string) string { return fmt.Sprintf("%x", sha1.Sum([]byte(s))) }
+	// The fake server doesn't implement SyncManifest, like gomote
+	// servers and buildlets that predate it.
+	fs.entries = []string{
+		"drwxr-xr-x\tdst/",
+		"-rw-r--r--\tdst/same\t4\t2026-10-18T12:00:00Z\t" + sum("same"),
+		"-rw-r--r--\tdst/changed\t3\t2026-10-18T12:00:00Z\t" + sum("old"),
+		"-rw-r--r--\tdst/gone\t4\t2026-10-18T12:00:00Z\t" + sum("gone"),
+		"-rw-r--r--\tother/file\t4\t2026-10-18T12:00:00Z\t" + sum("file"),
+	}
+	spec := pushSpec{src: src, dst: "dst"}
+	if err := pushWithClient(t.Context(), protos.NewGomoteServiceClient(conn), "inst", spec, false, false); err != nil {
+		t.Fatalf("pushWithClient = %v", err)
+	}
+	want := []string{
+		"rm inst dst/gone",
+		"tgz inst dst added changed",
+	}
+	if diff := cmp.Diff(want, fs.calls); diff != "" {
+		t.Errorf("server calls mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -337,6 +337,14 @@ func doPutFile(ctx context.Context, inst string, r io.Reader, dst string, mode o
 }
 
 func uploadToGCS(ctx context.Context, fields map[string]string, file io.Reader, filename, url string) error {
+	var recorded func()
+	if dir := recordingDir(); dir != "" && !uploadRecorded(url+fields["key"]) {
+		r, done, err := recordUpload(dir, file, url+fields["key"])
+		if err != nil {
+			return err
+		}
+		file, recorded = r, done
+	}
 	buf := new(bytes.Buffer)
 	mw := multipart.NewWriter(buf)
 
@@ -363,5 +371,8 @@ func uploadToGCS(ctx context.Context, fields map[string]string, file io.Reader,
 	if res.StatusCode != http.StatusNoContent {
 		return fmt.Errorf("http post failed: status code=%d", res.StatusCode)
 	}
+	if recorded != nil {
+		recorded()
+	}
 	return nil
 }
@@ -0,0 +1,575 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/build/internal/gomote/protos"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func record(args []string) error {
+	cm := map[string]struct {
+		run  func([]string) error
+		desc string
+	}{
+		"start": {startRecording, "start recording the active group's session"},
+		"stop":  {stopRecording, "stop recording and save the session to an archive"},
+	}
+	if len(args) == 0 {
+		var cmds []string
+		for cmd := range cm {
+			cmds = append(cmds, cmd)
+		}
+		sort.Strings(cmds)
+		usageLogger.Printf("Usage of gomote record: gomote [global-flags] record <cmd> [cmd-flags]\n")
+		usageLogger.Printf("Commands:\n")
+		for _, name := range cmds {
+			usageLogger.Printf("  %-8s %s\n", name, cm[name].desc)
+		}
+		usageLogger.Print()
+		usageLogger.Print("While a group's session is being recorded, the instances it creates and")
+		usageLogger.Print("destroys, the files put and pushed to them, the commands run on them with")
+		usageLogger.Print("their output, and the files fetched from them, are recorded. The saved")
+		usageLogger.Print("archive can be run again on new instances with \"gomote replay\".")
+		os.Exit(1)
+	}
+	subCmd := args[0]
+	sc, ok := cm[subCmd]
+	if !ok {
+		return fmt.Errorf("unknown sub-command %q\n", subCmd)
+	}
+	return sc.run(args[1:])
+}
+
+func startRecording(args []string) error {
+	usage := func() {
+		log.Print("record start usage: gomote record start")
+		log.Print()
+		log.Print("Instances already in the group are recorded as if they had been created")
+		log.Print("by the session, but what was done to them before isn't.")
+		os.Exit(1)
+	}
+	if len(args) != 0 {
+		usage()
+	}
+	if activeGroup == nil {
+		log.Print("No active group found. Use -group or GOMOTE_GROUP.")
+		usage()
+	}
+	dir, err := sessionDir(activeGroup.Name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("group %q is already being recorded", activeGroup.Name)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "blobs"), 0755); err != nil {
+		return fmt.Errorf("starting recording: %w", err)
+	}
+	rec := &recorder{dir: dir, command: []string{"record", "start"}}
+
+	// Record the instances the session starts with, so it can be
+	// replayed on new ones. The experiment options they were created
+	// with aren't known, so they're replayed with the defaults.
+	if len(activeGroup.Instances) > 0 {
+		ctx := context.Background()
+		resp, err := gomoteServerClient(ctx).ListInstances(ctx, &protos.ListInstancesRequest{})
+		if err != nil {
+			os.RemoveAll(dir)
+			return fmt.Errorf("unable to list instances: %w", err)
+		}
+		for _, inst := range resp.GetInstances() {
+			if !activeGroup.has(inst.GetGomoteId()) {
+				continue
+			}
+			err := rec.add(&sessionStep{
+				Time:   time.Now(),
+				Method: protos.GomoteService_CreateInstance_FullMethodName,
+			}, &protos.CreateInstanceRequest{
+				BuilderType: inst.GetBuilderType(),
+			}, &protos.CreateInstanceResponse{
+				Instance: inst,
+				Status:   protos.CreateInstanceResponse_COMPLETE,
+			})
+			if err != nil {
+				os.RemoveAll(dir)
+				return err
+			}
+		}
+	}
+	log.Printf("Recording the session of group %q. Stop with: gomote record stop <archive>", activeGroup.Name)
+	return nil
+}
+
+func stopRecording(args []string) error {
+	usage := func() {
+		log.Print("record stop usage: gomote record stop <archive.tar.gz>")
+		os.Exit(1)
+	}
+	if len(args) != 1 {
+		usage()
+	}
+	if activeGroup == nil {
+		log.Print("No active group found. Use -group or GOMOTE_GROUP.")
+		usage()
+	}
+	dir := recordingDir()
+	if dir == "" {
+		return fmt.Errorf("group %q isn't being recorded", activeGroup.Name)
+	}
+	f, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	if err := writeSessionArchive(f, dir); err != nil {
+		f.Close()
+		return fmt.Errorf("saving session: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+	log.Printf("Saved the session of group %q to %s.", activeGroup.Name, args[0])
+	return os.RemoveAll(dir)
+}
+
+// sessionStep is an RPC recorded in a session.
+type sessionStep struct {
+	// Time is when the RPC started, and Duration how long it took.
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration"`
+	// Command is the gomote command line that made the RPC.
+	Command []string `json:"command,omitempty"`
+	// Method is the full gRPC method name.
+	Method string `json:"method"`
+	// Request and Responses are the messages sent and received, in
+	// protobuf's JSON encoding.
+	Request   json.RawMessage   `json:"request"`
+	Responses []json.RawMessage `json:"responses,omitempty"`
+	// Error is the RPC's error, if it failed.
+	Error string `json:"error,omitempty"`
+	// Upload is the SHA-256 of the content uploaded to the request's
+	// URL, which is kept in the session's blobs.
+	Upload string `json:"upload,omitempty"`
+}
+
+// recordedMethods are the RPCs recorded in a session: those that change
+// instances, run commands on them, or fetch files from them.
+var recordedMethods = map[string]bool{
+	protos.GomoteService_AddBootstrap_FullMethodName:     true,
+	protos.GomoteService_CreateInstance_FullMethodName:   true,
+	protos.GomoteService_DestroyInstance_FullMethodName:  true,
+	protos.GomoteService_ExecuteCommand_FullMethodName:   true,
+	protos.GomoteService_ReadTGZToURL_FullMethodName:     true,
+	protos.GomoteService_RemoveFiles_FullMethodName:      true,
+	protos.GomoteService_WriteFileFromURL_FullMethodName: true,
+	protos.GomoteService_WriteSyncFromURL_FullMethodName: true,
+	protos.GomoteService_WriteTGZFromURL_FullMethodName:  true,
+}
+
+// sessionDir returns the directory a group's session is recorded in.
+// It's hidden in the group's directory so it isn't taken for an
+// instance.
+func sessionDir(group string) (string, error) {
+	dir, err := groupDirPath(group)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ".session"), nil
+}
+
+// recordingDir returns the directory the active group's session is
+// being recorded in, or "" if it isn't being recorded.
+func recordingDir() string {
+	if activeGroup == nil {
+		return ""
+	}
+	dir, err := sessionDir(activeGroup.Name)
+	if err != nil {
+		return ""
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return ""
+	}
+	return dir
+}
+
+// recordedUploads maps the URLs of the objects uploaded by this process
+// while recording to the SHA-256 of their content.
+var recordedUploads = struct {
+	sync.Mutex
+	m map[string]string
+}{m: make(map[string]string)}
+
+// recordUploadContent records r as the content uploaded to url, in
+// place of what's actually uploaded. uploadToGCS then doesn't record
+// the upload.
+func recordUploadContent(dir string, r io.Reader, url string) error {
+	r, done, err := recordUpload(dir, r, url)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return fmt.Errorf("recording upload: %w", err)
+	}
+	done()
+	return nil
+}
+
+// uploadRecorded reports whether the content uploaded to url has been
+// recorded.
+func uploadRecorded(url string) bool {
+	recordedUploads.Lock()
+	defer recordedUploads.Unlock()
+	_, ok := recordedUploads.m[url]
+	return ok
+}
+
+// recordUpload returns a reader that records what's read from r in the
+// session's blobs, and a function to call once it's been uploaded to
+// url.
+func recordUpload(dir string, r io.Reader, url string) (io.Reader, func(), error) {
+	f, err := os.CreateTemp(filepath.Join(dir, "blobs"), ".upload-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("recording upload: %w", err)
+	}
+	h := sha256.New()
+	done := func() {
+		defer os.Remove(f.Name())
+		if err := f.Close(); err != nil {
+			log.Printf("recording upload: %v", err)
+			return
+		}
+		sum := hex.EncodeToString(h.Sum(nil))
+		if err := os.Rename(f.Name(), filepath.Join(dir, "blobs", sum)); err != nil {
+			log.Printf("recording upload: %v", err)
+			return
+		}
+		recordedUploads.Lock()
+		recordedUploads.m[url] = sum
+		recordedUploads.Unlock()
+	}
+	return io.TeeReader(r, io.MultiWriter(f, h)), done, nil
+}
+
+// A recorder appends the RPCs made by this process to a session.
+type recorder struct {
+	dir     string
+	command []string
+
+	mu sync.Mutex
+}
+
+// add completes step with the request and responses, and appends it to
+// the session.
+func (r *recorder) add(step *sessionStep, req any, resps ...any) error {
+	var err error
+	if step.Request, err = marshalMessage(req); err != nil {
+		return err
+	}
+	for _, resp := range resps {
+		m, err := marshalMessage(resp)
+		if err != nil {
+			return err
+		}
+		step.Responses = append(step.Responses, m)
+	}
+	if step.Command == nil {
+		step.Command = r.command
+	}
+	if url := messageString(req, "url"); url != "" {
+		recordedUploads.Lock()
+		step.Upload = recordedUploads.m[url]
+		recordedUploads.Unlock()
+	}
+	line, err := json.Marshal(step)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, err := os.OpenFile(filepath.Join(r.dir, "session.jsonl"), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("recording session: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("recording session: %w", err)
+	}
+	return f.Close()
+}
+
+func marshalMessage(m any) (json.RawMessage, error) {
+	pm, ok := m.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("recording session: %T isn't a protobuf message", m)
+	}
+	return protojson.Marshal(pm)
+}
+
+// messageString returns the string field name of message m, or "" if
+// it doesn't have one.
+func messageString(m any, name protoreflect.Name) string {
+	pm, ok := m.(proto.Message)
+	if !ok {
+		return ""
+	}
+	fd := pm.ProtoReflect().Descriptor().Fields().ByName(name)
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return ""
+	}
+	return pm.ProtoReflect().Get(fd).String()
+}
+
+// recordingConn is a client connection that records the RPCs made over
+// it in a session.
+type recordingConn struct {
+	grpc.ClientConnInterface
+	rec *recorder
+}
+
+func (c *recordingConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	if !recordedMethods[method] {
+		return c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+	}
+	step := &sessionStep{Time: time.Now(), Method: method}
+	err := c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+	step.Duration = time.Since(step.Time)
+	var resps []any
+	if err != nil {
+		step.Error = err.Error()
+	} else {
+		resps = append(resps, reply)
+	}
+	if rerr := c.rec.add(step, args, resps...); rerr != nil {
+		log.Print(rerr)
+	}
+	return err
+}
+
+func (c *recordingConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	cs, err := c.ClientConnInterface.NewStream(ctx, desc, method, opts...)
+	if err != nil || !recordedMethods[method] {
+		return cs, err
+	}
+	s := &recordingStream{
+		ClientStream: cs,
+		rec:          c.rec,
+		step:         sessionStep{Time: time.Now(), Method: method},
+	}
+	// Commands that stop reading early, like run -until, never see the
+	// end of the stream.
+	go func() {
+		<-ctx.Done()
+		s.finish(ctx.Err())
+	}()
+	return s, nil
+}
+
+// recordingStream is a client stream that records its first request and
+// all its responses once it ends.
+type recordingStream struct {
+	grpc.ClientStream
+	rec *recorder
+
+	mu    sync.Mutex
+	step  sessionStep
+	req   any
+	resps []any
+	done  bool
+}
+
+func (s *recordingStream) SendMsg(m any) error {
+	s.mu.Lock()
+	if s.req == nil {
+		s.req = m
+	}
+	s.mu.Unlock()
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *recordingStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		s.mu.Lock()
+		if !s.done {
+			s.resps = append(s.resps, proto.Clone(m.(proto.Message)))
+		}
+		s.mu.Unlock()
+	case err == io.EOF:
+		s.finish(nil)
+	default:
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *recordingStream) finish(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done || s.req == nil {
+		return
+	}
+	s.done = true
+	s.step.Duration = time.Since(s.step.Time)
+	if err != nil {
+		s.step.Error = err.Error()
+	}
+	if rerr := s.rec.add(&s.step, s.req, s.resps...); rerr != nil {
+		log.Print(rerr)
+	}
+}
+
+// writeSessionArchive writes the session recorded in dir to w as a
+// gzipped tar file, with the steps in session.jsonl and the uploaded
+// content in blobs/<sha256>.
+func writeSessionArchive(w io.Writer, dir string) error {
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+	add := func(name, path string) error {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		fi, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    fi.Size(),
+			ModTime: fi.ModTime(),
+		}); err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, f)
+		return err
+	}
+	if err := add("session.jsonl", filepath.Join(dir, "session.jsonl")); err != nil {
+		return err
+	}
+	blobs, err := os.ReadDir(filepath.Join(dir, "blobs"))
+	if err != nil {
+		return err
+	}
+	for _, b := range blobs {
+		if strings.HasPrefix(b.Name(), ".") {
+			// An upload that never finished.
+			continue
+		}
+		if err := add("blobs/"+b.Name(), filepath.Join(dir, "blobs", b.Name())); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// A session is a recorded session read from an archive.
+type session struct {
+	steps []*sessionStep
+	// dir holds the session's blobs.
+	dir string
+}
+
+// readSessionArchive reads the session archive r, extracting its blobs
+// to dir.
+func readSessionArchive(r io.Reader, dir string) (*session, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	s := &session{dir: dir}
+	tr := tar.NewReader(zr)
+	for {
+		th, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch name := th.Name; {
+		case name == "session.jsonl":
+			sc := bufio.NewScanner(tr)
+			sc.Buffer(nil, 1<<30)
+			for sc.Scan() {
+				step := new(sessionStep)
+				if err := json.Unmarshal(sc.Bytes(), step); err != nil {
+					return nil, fmt.Errorf("reading step %d: %w", len(s.steps)+1, err)
+				}
+				s.steps = append(s.steps, step)
+			}
+			if err := sc.Err(); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(name, "blobs/"):
+			sum := strings.TrimPrefix(name, "blobs/")
+			if _, err := hex.DecodeString(sum); err != nil || len(sum) != sha256.Size*2 {
+				return nil, fmt.Errorf("invalid blob name %q", name)
+			}
+			if err := writeBlob(filepath.Join(dir, sum), sha256.New(), sum, tr); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(s.steps) == 0 {
+		return nil, errors.New("the archive doesn't contain any steps")
+	}
+	return s, nil
+}
+
+func writeBlob(path string, h hash.Hash, sum string, r io.Reader) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.MultiWriter(f, h), r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != sum {
+		return fmt.Errorf("blob %s is corrupted: its hash is %s", sum, got)
+	}
+	return nil
+}
+
+// blob returns the path of the blob with the given SHA-256.
+func (s *session) blob(sum string) (string, error) {
+	path := filepath.Join(s.dir, sum)
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("the archive is missing blob %s", sum)
+	}
+	return path, nil
+}
@@ -0,0 +1,424 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/internal/gomote/protos"
+	"golang.org/x/net/nettest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// fakeGomoteServer is a gomote server that logs the requests it gets,
+// and uploads to a fake GCS bucket.
+type fakeGomoteServer struct {
+	protos.UnimplementedGomoteServiceServer
+	bucket *httptest.Server
+
+	mu      sync.Mutex
+	created int
+	calls   []string
+	objects map[string]string
+	entries []string // listed by ListDirectoryStreaming
+	// blocks are the blocks on each instance, by hash. SyncManifest
+	// lists them as a single file, "blocks", and is unimplemented
+	// if blocks is nil.
+	blocks map[string]map[string][]byte
+}
+
+func (s *fakeGomoteServer) logf(format string, args ...any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, fmt.Sprintf(format, args...))
+}
+
+func (s *fakeGomoteServer) CreateInstance(req *protos.CreateInstanceRequest, stream grpc.ServerStreamingServer[protos.CreateInstanceResponse]) error {
+	s.mu.Lock()
+	s.created++
+	id := fmt.Sprintf("%s-%d", req.GetBuilderType(), s.created)
+	s.mu.Unlock()
+	s.logf("create %s", req.GetBuilderType())
+	stream.Send(&protos.CreateInstanceResponse{Status: protos.CreateInstanceResponse_WAITING})
+	return stream.Send(&protos.CreateInstanceResponse{
+		Instance: &protos.Instance{GomoteId: id, BuilderType: req.GetBuilderType()},
+		Status:   protos.CreateInstanceResponse_COMPLETE,
+	})
+}
+
+func (s *fakeGomoteServer) ExecuteCommand(req *protos.ExecuteCommandRequest, stream grpc.ServerStreamingServer[protos.ExecuteCommandResponse]) error {
+	s.logf("exec %s %s", req.GetGomoteId(), req.GetCommand())
+	stream.Send(&protos.ExecuteCommandResponse{Output: []byte("ran " + req.GetCommand() + "\n")})
+	return stream.Send(&protos.ExecuteCommandResponse{ExitStatus: &protos.ExitStatus{State: "ok"}})
+}
+
+func (s *fakeGomoteServer) RemoveFiles(ctx context.Context, req *protos.RemoveFilesRequest) (*protos.RemoveFilesResponse, error) {
+	s.logf("rm %s %s", req.GetGomoteId(), strings.Join(req.GetPaths(), " "))
+	if slices.Contains(req.GetPaths(), "missing") {
+		return nil, status.Errorf(codes.NotFound, "no such file")
+	}
+	return &protos.RemoveFilesResponse{}, nil
+}
+
+func (s *fakeGomoteServer) UploadFile(ctx context.Context, req *protos.UploadFileRequest) (*protos.UploadFileResponse, error) {
+	s.mu.Lock()
+	name := fmt.Sprintf("object-%d", len(s.objects))
+	s.mu.Unlock()
+	return &protos.UploadFileResponse{
+		Url:        s.bucket.URL + "/",
+		Fields:     map[string]string{"key": name},
+		ObjectName: name,
+	}, nil
+}
+
+func (s *fakeGomoteServer) WriteFileFromURL(ctx context.Context, req *protos.WriteFileFromURLRequest) (*protos.WriteFileFromURLResponse, error) {
+	name := strings.TrimPrefix(req.GetUrl(), s.bucket.URL+"/")
+	s.mu.Lock()
+	content := s.objects[name]
+	s.mu.Unlock()
+	s.logf("put %s %s %q", req.GetGomoteId(), req.GetFilename(), content)
+	return &protos.WriteFileFromURLResponse{}, nil
+}
+
+func (s *fakeGomoteServer) ListDirectoryStreaming(req *protos.ListDirectoryRequest, stream grpc.ServerStreamingServer[protos.ListDirectoryResponse]) error {
+	s.mu.Lock()
+	entries := s.entries
+	s.mu.Unlock()
+	return stream.Send(&protos.ListDirectoryResponse{Entries: entries})
+}
+
+func (s *fakeGomoteServer) WriteTGZFromURL(ctx context.Context, req *protos.WriteTGZFromURLRequest) (*protos.WriteTGZFromURLResponse, error) {
+	name := strings.TrimPrefix(req.GetUrl(), s.bucket.URL+"/")
+	s.mu.Lock()
+	content := s.objects[name]
+	s.mu.Unlock()
+	zr, err := gzip.NewReader(strings.NewReader(content))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	var files []string
+	tr := tar.NewReader(zr)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		files = append(files, h.Name)
+	}
+	s.logf("tgz %s %s %s", req.GetGomoteId(), req.GetDirectory(), strings.Join(files, " "))
+	return &protos.WriteTGZFromURLResponse{}, nil
+}
+
+func (s *fakeGomoteServer) SyncManifest(req *protos.SyncManifestRequest, stream grpc.ServerStreamingServer[protos.SyncManifestResponse]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.blocks == nil {
+		return status.Errorf(codes.Unimplemented, "method SyncManifest not implemented")
+	}
+	f := &protos.SyncFile{Path: "blocks"}
+	for h := range s.blocks[req.GetGomoteId()] {
+		f.Blocks = append(f.Blocks, h)
+	}
+	return stream.Send(&protos.SyncManifestResponse{Files: []*protos.SyncFile{f}})
+}
+
+// WriteSyncFromURL applies the changes like a buildlet would, failing
+// if they refer to blocks the instance doesn't have.
+func (s *fakeGomoteServer) WriteSyncFromURL(ctx context.Context, req *protos.WriteSyncFromURLRequest) (*protos.WriteSyncFromURLResponse, error) {
+	name := strings.TrimPrefix(req.GetUrl(), s.bucket.URL+"/")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zr, err := gzip.NewReader(strings.NewReader(s.objects[name]))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	have := s.blocks[req.GetGomoteId()]
+	if have == nil {
+		have = make(map[string][]byte)
+		s.blocks[req.GetGomoteId()] = have
+	}
+	var written []string
+	br := bufio.NewReader(zr)
+	for {
+		op, err := buildlet.ReadSyncOp(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if op.Delete {
+			written = append(written, "-"+op.Path)
+			continue
+		}
+		var content []byte
+		lit := op.Literal
+		for i, h := range op.Blocks {
+			if len(lit) > 0 && lit[0] == i {
+				lit = lit[1:]
+				p := make([]byte, op.BlockLen(i))
+				if _, err := io.ReadFull(br, p); err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+				}
+				have[h] = p
+			}
+			p, ok := have[h]
+			if !ok {
+				return nil, status.Errorf(codes.FailedPrecondition, "%s: no block with hash %s", op.Path, h)
+			}
+			content = append(content, p...)
+		}
+		written = append(written, fmt.Sprintf("%s=%q", op.Path, content))
+	}
+	s.calls = append(s.calls, fmt.Sprintf("sync %s %s %s", req.GetGomoteId(), req.GetDirectory(), strings.Join(written, " ")))
+	return &protos.WriteSyncFromURLResponse{}, nil
+}
+
+func (s *fakeGomoteServer) uploadHandler(w http.ResponseWriter, r *http.Request) {
+	f, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	content, _ := io.ReadAll(f)
+	s.mu.Lock()
+	s.objects[r.FormValue("key")] = string(content)
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setupFakeGomoteServer(t *testing.T) (*fakeGomoteServer, *grpc.ClientConn) {
+	fs := &fakeGomoteServer{objects: make(map[string]string)}
+	fs.bucket = httptest.NewServer(http.HandlerFunc(fs.uploadHandler))
+	lis, err := nettest.NewLocalListener("tcp")
+	if err != nil {
+		t.Fatalf(`nettest.NewLocalListener("tcp") = %s; want no error`, err)
+	}
+	s := grpc.NewServer()
+	protos.RegisterGomoteServiceServer(s, fs)
+	go s.Serve(lis)
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient(%s) = %v", lis.Addr(), err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+		fs.bucket.Close()
+	})
+	return fs, conn
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	fs, conn := setupFakeGomoteServer(t)
+
+	// Record a session.
+	recDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(recDir, "blobs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	client := protos.NewGomoteServiceClient(&recordingConn{
+		ClientConnInterface: conn,
+		rec:                 &recorder{dir: recDir, command: []string{"test"}},
+	})
+	stream, err := client.CreateInstance(ctx, &protos.CreateInstanceRequest{BuilderType: "linux-amd64"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	resp, err := client.UploadFile(ctx, &protos.UploadFileRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := resp.GetUrl() + resp.GetObjectName()
+	r, done, err := recordUpload(recDir, strings.NewReader("hello"), url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := uploadToGCS(ctx, resp.GetFields(), r, "hello.txt", resp.GetUrl()); err != nil {
+		t.Fatal(err)
+	}
+	done()
+	if _, err := client.WriteFileFromURL(ctx, &protos.WriteFileFromURLRequest{GomoteId: "linux-amd64-1", Url: url, Filename: "hello.txt"}); err != nil {
+		t.Fatal(err)
+	}
+	exec, err := client.ExecuteCommand(ctx, &protos.ExecuteCommandRequest{GomoteId: "linux-amd64-1", Command: "go/src/make.bash"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := exec.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.RemoveFiles(ctx, &protos.RemoveFilesRequest{GomoteId: "linux-amd64-1", Paths: []string{"missing"}}); err == nil {
+		t.Fatal("RemoveFiles succeeded; want error")
+	}
+
+	var archive bytes.Buffer
+	if err := writeSessionArchive(&archive, recDir); err != nil {
+		t.Fatalf("writeSessionArchive = %v", err)
+	}
+	s, err := readSessionArchive(&archive, t.TempDir())
+	if err != nil {
+		t.Fatalf("readSessionArchive = %v", err)
+	}
+	var methods []string
+	for _, step := range s.steps {
+		methods = append(methods, step.Method)
+	}
+	wantMethods := []string{
+		protos.GomoteService_CreateInstance_FullMethodName,
+		protos.GomoteService_WriteFileFromURL_FullMethodName,
+		protos.GomoteService_ExecuteCommand_FullMethodName,
+		protos.GomoteService_RemoveFiles_FullMethodName,
+	}
+	if diff := cmp.Diff(wantMethods, methods); diff != "" {
+		t.Fatalf("recorded methods mismatch (-want +got):\n%s", diff)
+	}
+	if s.steps[1].Upload == "" {
+		t.Errorf("upload of the WriteFileFromURL step wasn't recorded")
+	}
+	if s.steps[3].Error == "" {
+		t.Errorf("error of the RemoveFiles step wasn't recorded")
+	}
+
+	// Replay it on another builder type.
+	var stdout bytes.Buffer
+	rp := &replayer{
+		s:         s,
+		client:    protos.NewGomoteServiceClient(conn),
+		builder:   "openbsd-amd64",
+		stdout:    &stdout,
+		stderr:    io.Discard,
+		instances: make(map[string]string),
+	}
+	if err := rp.run(ctx); err != nil {
+		t.Fatalf("replay = %v", err)
+	}
+	wantCalls := []string{
+		"create linux-amd64",
+		`put linux-amd64-1 hello.txt "hello"`,
+		"exec linux-amd64-1 go/src/make.bash",
+		"rm linux-amd64-1 missing",
+		"create openbsd-amd64",
+		`put openbsd-amd64-2 hello.txt "hello"`,
+		"exec openbsd-amd64-2 go/src/make.bash",
+		"rm openbsd-amd64-2 missing",
+	}
+	if diff := cmp.Diff(wantCalls, fs.calls); diff != "" {
+		t.Errorf("server calls mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := stdout.String(), "openbsd-amd64-2\nran go/src/make.bash\n"; got != want {
+		t.Errorf("replay output = %q; want %q", got, want)
+	}
+}
+
+func TestRecordAndReplayPush(t *testing.T) {
+	ctx := context.Background()
+	fs, conn := setupFakeGomoteServer(t)
+	fs.blocks = make(map[string]map[string][]byte)
+
+	// Record in a group, as "gomote record start" does.
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	oldGroup := activeGroup
+	activeGroup = &groupData{Name: "rec"}
+	t.Cleanup(func() { activeGroup = oldGroup })
+	recDir, err := sessionDir(activeGroup.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(recDir, "blobs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	client := protos.NewGomoteServiceClient(&recordingConn{
+		ClientConnInterface: conn,
+		rec:                 &recorder{dir: recDir, command: []string{"test"}},
+	})
+	stream, err := client.CreateInstance(ctx, &protos.CreateInstanceRequest{BuilderType: "linux-amd64"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The instance already has the block of the file being pushed,
+	// so the push sends no blocks.
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "a.go"), []byte("package a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fs.blocks["linux-amd64-1"] = map[string][]byte{buildlet.HashBlock([]byte("package a")): []byte("package a")}
+	if err := pushWithClient(ctx, client, "linux-amd64-1", pushSpec{src: src, dst: "a"}, false, false); err != nil {
+		t.Fatalf("push = %v", err)
+	}
+
+	var archive bytes.Buffer
+	if err := writeSessionArchive(&archive, recDir); err != nil {
+		t.Fatalf("writeSessionArchive = %v", err)
+	}
+	s, err := readSessionArchive(&archive, t.TempDir())
+	if err != nil {
+		t.Fatalf("readSessionArchive = %v", err)
+	}
+
+	// Replay it on a new, empty instance.
+	activeGroup = nil
+	rp := &replayer{
+		s:         s,
+		client:    protos.NewGomoteServiceClient(conn),
+		stdout:    io.Discard,
+		stderr:    io.Discard,
+		instances: make(map[string]string),
+	}
+	if err := rp.run(ctx); err != nil {
+		t.Fatalf("replay = %v", err)
+	}
+	wantCalls := []string{
+		"create linux-amd64",
+		`sync linux-amd64-1 a a.go="package a" -blocks`,
+		"create linux-amd64",
+		`sync linux-amd64-2 a a.go="package a" -blocks`,
+	}
+	if diff := cmp.Diff(wantCalls, fs.calls); diff != "" {
+		t.Errorf("server calls mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/build/internal/gomote/protos"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func replay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	fs.Usage = func() {
+		log := usageLogger
+		log.Print("replay usage: gomote replay [replay-opts] <archive.tar.gz>")
+		log.Print()
+		log.Print("Replay runs the steps of a session recorded with \"gomote record\" on new")
+		log.Print("instances, in place of the ones the session used. If there's a valid group")
+		log.Print("specified, the new instances are added to it.")
+		log.Print()
+		log.Print("A step that fails stops the replay, unless it failed when it was recorded")
+		log.Print("too. Commands whose exit status differs from the recording are reported.")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	var builder string
+	var dryRun, destroy bool
+	fs.StringVar(&builder, "builder", "", "create instances of this builder type instead of the recorded ones")
+	fs.BoolVar(&dryRun, "dry-run", false, "list the recorded steps without running them")
+	fs.BoolVar(&destroy, "destroy", false, "destroy instances where the session did; by default, they're kept for inspection")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	dir, err := os.MkdirTemp("", "gomote-replay")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	s, err := readSessionArchive(f, dir)
+	if err != nil {
+		return fmt.Errorf("reading session %s: %w", fs.Arg(0), err)
+	}
+	if dryRun {
+		for i, step := range s.steps {
+			fmt.Printf("%d\t%s\n", i+1, describeStep(s.steps[0].Time, step))
+		}
+		return nil
+	}
+
+	ctx := context.Background()
+	r := &replayer{
+		s:         s,
+		client:    gomoteServerClient(ctx),
+		builder:   builder,
+		destroy:   destroy,
+		group:     activeGroup,
+		stdout:    os.Stdout,
+		stderr:    os.Stderr,
+		instances: make(map[string]string),
+	}
+	err = r.run(ctx)
+	if activeGroup != nil {
+		if uerr := updateGroup(activeGroup); uerr != nil {
+			err = errors.Join(err, uerr)
+		}
+	}
+	return err
+}
+
+// describeStep returns a line describing a recorded step, with its
+// time relative to start.
+func describeStep(start time.Time, step *sessionStep) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "+%v\t%s", step.Time.Sub(start).Round(time.Second), step.Method[strings.LastIndex(step.Method, "/")+1:])
+	if newReq, ok := replayRequests[step.Method]; ok {
+		req := newReq()
+		if err := protojson.Unmarshal(step.Request, req); err == nil {
+			if id := messageString(req, "gomote_id"); id != "" {
+				fmt.Fprintf(&b, " %s", id)
+			}
+			switch req := req.(type) {
+			case *protos.CreateInstanceRequest:
+				fmt.Fprintf(&b, " %s", req.GetBuilderType())
+			case *protos.ExecuteCommandRequest:
+				fmt.Fprintf(&b, ": %s", strings.Join(append([]string{req.GetCommand()}, req.GetArgs()...), " "))
+			case *protos.RemoveFilesRequest:
+				fmt.Fprintf(&b, ": %s", strings.Join(req.GetPaths(), " "))
+			case *protos.WriteFileFromURLRequest:
+				fmt.Fprintf(&b, ": %s", req.GetFilename())
+			case *protos.ReadTGZToURLRequest, *protos.WriteSyncFromURLRequest, *protos.WriteTGZFromURLRequest:
+				if dir := messageString(req, "directory"); dir != "" {
+					fmt.Fprintf(&b, ": %s", dir)
+				}
+			}
+		}
+	}
+	fmt.Fprintf(&b, " (%v)", step.Duration.Round(time.Millisecond))
+	if step.Error != "" {
+		fmt.Fprintf(&b, ": failed: %s", step.Error)
+	}
+	return b.String()
+}
+
+// replayRequests returns a new request message for each replayable
+// method.
+var replayRequests = map[string]func() proto.Message{
+	protos.GomoteService_AddBootstrap_FullMethodName:     func() proto.Message { return new(protos.AddBootstrapRequest) },
+	protos.GomoteService_CreateInstance_FullMethodName:   func() proto.Message { return new(protos.CreateInstanceRequest) },
+	protos.GomoteService_DestroyInstance_FullMethodName:  func() proto.Message { return new(protos.DestroyInstanceRequest) },
+	protos.GomoteService_ExecuteCommand_FullMethodName:   func() proto.Message { return new(protos.ExecuteCommandRequest) },
+	protos.GomoteService_ReadTGZToURL_FullMethodName:     func() proto.Message { return new(protos.ReadTGZToURLRequest) },
+	protos.GomoteService_RemoveFiles_FullMethodName:      func() proto.Message { return new(protos.RemoveFilesRequest) },
+	protos.GomoteService_WriteFileFromURL_FullMethodName: func() proto.Message { return new(protos.WriteFileFromURLRequest) },
+	protos.GomoteService_WriteSyncFromURL_FullMethodName: func() proto.Message { return new(protos.WriteSyncFromURLRequest) },
+	protos.GomoteService_WriteTGZFromURL_FullMethodName:  func() proto.Message { return new(protos.WriteTGZFromURLRequest) },
+}
+
+// A replayer runs the steps of a recorded session.
+type replayer struct {
+	s       *session
+	client  protos.GomoteServiceClient
+	builder string // builder type to create, if not the recorded one
+	destroy bool   // whether to destroy instances
+	group   *groupData
+
+	stdout, stderr io.Writer
+
+	// instances maps the session's instances to the ones that
+	// replace them.
+	instances map[string]string
+}
+
+func (r *replayer) run(ctx context.Context) error {
+	for i, step := range r.s.steps {
+		log.Printf("Step %d/%d: %s", i+1, len(r.s.steps), describeStep(r.s.steps[0].Time, step))
+		err := r.step(ctx, step)
+		switch {
+		case err != nil && step.Error != "":
+			log.Printf("Step %d failed, as it did when recorded: %v", i+1, err)
+		case err != nil:
+			return fmt.Errorf("step %d: %w", i+1, err)
+		case step.Error != "":
+			log.Printf("Step %d succeeded, but failed when recorded: %s", i+1, step.Error)
+		}
+	}
+	return nil
+}
+
+func (r *replayer) step(ctx context.Context, step *sessionStep) error {
+	req, err := r.request(ctx, step)
+	if err != nil {
+		return err
+	}
+	switch req := req.(type) {
+	case *protos.CreateInstanceRequest:
+		return r.create(ctx, step, req)
+	case *protos.DestroyInstanceRequest:
+		if !r.destroy {
+			log.Printf("Keeping %q; use -destroy to destroy it.", req.GetGomoteId())
+			return nil
+		}
+		if _, err := r.client.DestroyInstance(ctx, req); err != nil {
+			return fmt.Errorf("unable to destroy instance: %w", err)
+		}
+		if r.group != nil {
+			if err := pruneFromGroup(req.GetGomoteId(), r.group.Name); err != nil {
+				return err
+			}
+			r.group.Instances = slices.DeleteFunc(r.group.Instances, func(inst string) bool {
+				return inst == req.GetGomoteId()
+			})
+		}
+		return nil
+	case *protos.ExecuteCommandRequest:
+		return r.exec(ctx, step, req)
+	case *protos.ReadTGZToURLRequest:
+		resp, err := r.client.ReadTGZToURL(ctx, req)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve tgz URL: %w", err)
+		}
+		f, err := os.Create(fmt.Sprintf("%s.tar.gz", req.GetGomoteId()))
+		if err != nil {
+			return err
+		}
+		log.Printf("Downloading tarball for %q to %q...", req.GetGomoteId(), f.Name())
+		if err := downloadTGZ(ctx, resp.GetUrl(), f); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	case *protos.AddBootstrapRequest:
+		_, err = r.client.AddBootstrap(ctx, req)
+	case *protos.RemoveFilesRequest:
+		_, err = r.client.RemoveFiles(ctx, req)
+	case *protos.WriteFileFromURLRequest:
+		_, err = r.client.WriteFileFromURL(ctx, req)
+	case *protos.WriteSyncFromURLRequest:
+		_, err = r.client.WriteSyncFromURL(ctx, req)
+	case *protos.WriteTGZFromURLRequest:
+		_, err = r.client.WriteTGZFromURL(ctx, req)
+	}
+	return err
+}
+
+// request returns the request of a recorded step, adapted to the
+// instances and uploads of the replay.
+func (r *replayer) request(ctx context.Context, step *sessionStep) (proto.Message, error) {
+	newReq, ok := replayRequests[step.Method]
+	if !ok {
+		return nil, fmt.Errorf("unable to replay %s", step.Method)
+	}
+	req := newReq()
+	if err := protojson.Unmarshal(step.Request, req); err != nil {
+		return nil, fmt.Errorf("decoding request: %w", err)
+	}
+	m := req.ProtoReflect()
+	if fd := m.Descriptor().Fields().ByName("gomote_id"); fd != nil && m.Get(fd).String() != "" {
+		id := m.Get(fd).String()
+		inst, ok := r.instances[id]
+		if !ok {
+			return nil, fmt.Errorf("instance %q wasn't created by the session", id)
+		}
+		m.Set(fd, protoreflect.ValueOfString(inst))
+	}
+	if step.Upload != "" {
+		url, err := r.upload(ctx, step.Upload)
+		if err != nil {
+			return nil, err
+		}
+		m.Set(m.Descriptor().Fields().ByName("url"), protoreflect.ValueOfString(url))
+	}
+	if c, ok := req.(*protos.CreateInstanceRequest); ok && r.builder != "" {
+		c.BuilderType = r.builder
+	}
+	return req, nil
+}
+
+// upload uploads a blob of the session again, and returns its new URL.
+func (r *replayer) upload(ctx context.Context, sum string) (string, error) {
+	path, err := r.s.blob(sum)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	resp, err := r.client.UploadFile(ctx, &protos.UploadFileRequest{})
+	if err != nil {
+		return "", fmt.Errorf("unable to request credentials for a file upload: %w", err)
+	}
+	if err := uploadToGCS(ctx, resp.GetFields(), f, resp.GetObjectName(), resp.GetUrl()); err != nil {
+		return "", fmt.Errorf("unable to upload file to GCS: %w", err)
+	}
+	return resp.GetUrl() + resp.GetObjectName(), nil
+}
+
+func (r *replayer) create(ctx context.Context, step *sessionStep, req *protos.CreateInstanceRequest) error {
+	var recorded string
+	for _, m := range step.Responses {
+		resp := new(protos.CreateInstanceResponse)
+		if err := protojson.Unmarshal(m, resp); err == nil && resp.GetStatus() == protos.CreateInstanceResponse_COMPLETE {
+			recorded = resp.GetInstance().GetGomoteId()
+		}
+	}
+	stream, err := r.client.CreateInstance(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create buildlet: %w", err)
+	}
+	var inst string
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create buildlet: %w", err)
+		}
+		if update.GetStatus() == protos.CreateInstanceResponse_COMPLETE {
+			inst = update.GetInstance().GetGomoteId()
+		}
+	}
+	if inst == "" {
+		return errors.New("failed to create buildlet: no instance was returned")
+	}
+	if recorded != "" {
+		r.instances[recorded] = inst
+		log.Printf("Created %q in place of %q.", inst, recorded)
+	}
+	fmt.Fprintln(r.stdout, inst)
+	if r.group != nil {
+		r.group.Instances = append(r.group.Instances, inst)
+	}
+	return nil
+}
+
+func (r *replayer) exec(ctx context.Context, step *sessionStep, req *protos.ExecuteCommandRequest) error {
+	var recorded *protos.ExitStatus
+	for _, m := range step.Responses {
+		resp := new(protos.ExecuteCommandResponse)
+		if err := protojson.Unmarshal(m, resp); err == nil && resp.GetExitStatus() != nil {
+			recorded = resp.GetExitStatus()
+		}
+	}
+	stream, err := r.client.ExecuteCommand(ctx, req)
+	if err != nil {
+		return fmt.Errorf("unable to execute %s: %w", req.GetCommand(), err)
+	}
+	var status *protos.ExitStatus
+	var runErr error
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			runErr = fmt.Errorf("error running command %s: %w", req.GetCommand(), err)
+			break
+		}
+		r.stdout.Write(update.GetOutput())
+		r.stderr.Write(update.GetStderr())
+		if es := update.GetExitStatus(); es != nil {
+			status = es
+		}
+	}
+	if status != nil && recorded != nil && (status.GetCode() != recorded.GetCode() || status.GetSignal() != recorded.GetSignal()) {
+		log.Printf("Command %s; when recorded, it %s.", formatExitStatus(status), formatExitStatus(recorded))
+	}
+	return runErr
+}
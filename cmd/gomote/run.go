@@ -13,15 +13,19 @@ import (
 	"io"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/build/internal/gomote/protos"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/term"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // stringSlice implements flag.Value, specifically for storing environment
@@ -69,6 +73,28 @@ func run(args []string) error {
 	var untilPattern string
 	fs.StringVar(&untilPattern, "until", "", "Run command repeatedly until the output matches the provided regexp.")
 
+	var interactive, tty, interactiveTTY bool
+	fs.BoolVar(&interactive, "i", false, "Connect standard input to the command. Only one instance may be used.")
+	fs.BoolVar(&tty, "t", false, "Run the command in a pseudo-terminal, as for debuggers and shells. Only one instance may be used.")
+	fs.BoolVar(&interactiveTTY, "it", false, "Shorthand for -i -t.")
+
+	var separateStderr bool
+	fs.BoolVar(&separateStderr, "separate-stderr", false, "Keep the command's standard error separate from its standard output. Standard error is written to this process's standard error and saved next to the standard output file, with a .stderr suffix.")
+
+	var (
+		limitCPUs              float64
+		limitMemory, limitDisk int64
+		limitTime              time.Duration
+		limitProcs             int
+		noNetwork              bool
+	)
+	fs.Float64Var(&limitCPUs, "limit-cpus", 0, "If non-zero, the number of CPUs the command may use, such as 1.5. Linux only.")
+	fs.Int64Var(&limitMemory, "limit-memory", 0, "If non-zero, the memory the command may use, in MiB. The command is killed if it uses more. Linux only.")
+	fs.DurationVar(&limitTime, "limit-time", 0, "If non-zero, how long the command may run before it's killed.")
+	fs.IntVar(&limitProcs, "limit-procs", 0, "If non-zero, how many processes and threads the command may have at once. Linux only.")
+	fs.Int64Var(&limitDisk, "limit-disk", 0, "If non-zero, how much the files in the work directory may grow by while the command runs, in MiB. The command is killed if they grow more. It needs the instance to itself: it fails if other commands are running, and they fail while it runs.")
+	fs.BoolVar(&noNetwork, "no-network", false, "Run the command without network access, except to the loopback interface. Linux only.")
+
 	fs.Parse(args)
 	if fs.NArg() == 0 {
 		fs.Usage()
@@ -120,6 +146,40 @@ func run(args []string) error {
 		pathOpt = strings.Split(path, ",")
 	}
 
+	var limits *protos.ExecLimits
+	if limitCPUs != 0 || limitMemory != 0 || limitTime != 0 || limitProcs != 0 || limitDisk != 0 || noNetwork {
+		limits = &protos.ExecLimits{
+			Cpus:        limitCPUs,
+			MemoryBytes: limitMemory << 20,
+			WallTime:    durationpb.New(limitTime),
+			Processes:   int32(limitProcs),
+			DiskBytes:   limitDisk << 20,
+			NoNetwork:   noNetwork,
+		}
+	}
+
+	if interactiveTTY {
+		interactive, tty = true, true
+	}
+	if interactive || tty {
+		if len(runSet) != 1 {
+			return errors.New("-i and -t require a single instance, not a group")
+		}
+		if until != nil || collect {
+			return errors.New("-i and -t can't be used with -until or -collect")
+		}
+		return doRunInteractive(ctx, runSet[0], cmd, cmdArgs, interactive, tty,
+			runDir(dir),
+			runBuilderEnv(builderEnv),
+			runEnv(env),
+			runPath(pathOpt),
+			runSystem(sys),
+			runDebug(debug),
+			runFirewall(firewall),
+			runLimits(limits),
+		)
+	}
+
 	// Create temporary directory for output.
 	// This is useful even if we don't have multiple gomotes running, since
 	// it's easy to accidentally lose the output.
@@ -163,6 +223,19 @@ func run(args []string) error {
 			if len(runSet) == 1 {
 				outputs = append(outputs, os.Stdout)
 			}
+			var errf *os.File
+			var stderrs []io.Writer
+			if separateStderr {
+				errf, err = os.Create(filepath.Join(outDir, fmt.Sprintf("%s.stderr", inst)))
+				if err != nil {
+					return err
+				}
+				defer errf.Close()
+				stderrs = append(stderrs, errf)
+				if len(runSet) == 1 {
+					stderrs = append(stderrs, os.Stderr)
+				}
+			}
 			// Give ourselves the output too so that we can match against it.
 			var outBuf bytes.Buffer
 			if until != nil {
@@ -182,7 +255,12 @@ func run(args []string) error {
 					runSystem(sys),
 					runDebug(debug),
 					runFirewall(firewall),
+					runLimits(limits),
 					runWriters(outputs...),
+					runStderr(separateStderr, stderrs...),
+					runExitStatus(func(es *protos.ExitStatus) {
+						log.Printf("Command on %q %s\n", inst, formatExitStatus(es))
+					}),
 				)
 				// If it's just that the command failed, don't exit just yet, and don't return
 				// an error to the errgroup because we want the other commands to keep going.
@@ -201,6 +279,11 @@ func run(args []string) error {
 				if err := outf.Truncate(0); err != nil {
 					return fmt.Errorf("failed to truncate output file %q: %w", outf.Name(), err)
 				}
+				if errf != nil {
+					if err := errf.Truncate(0); err != nil {
+						return fmt.Errorf("failed to truncate output file %q: %w", errf.Name(), err)
+					}
+				}
 
 				log.Printf("No match found on %q, running again...\n", inst)
 			}
@@ -241,7 +324,11 @@ func run(args []string) error {
 	// running. We still want to handle them, though, because we want to make sure
 	// we exit with a non-zero exit code to reflect the command failure.
 	for _, ce := range cmdsFailed {
-		log.Printf("Command %q failed on %q: %v\n", ce.cmd, ce.inst, err)
+		log.Printf("Command %q failed on %q: %v\n", ce.cmd, ce.inst, ce.err)
+	}
+	if len(cmdsFailed) == 1 && len(runSet) == 1 {
+		// Let the caller exit with the command's exit code.
+		return cmdsFailed[0]
 	}
 	if len(cmdsFailed) > 0 {
 		return errors.New("one or more commands failed")
@@ -250,48 +337,191 @@ func run(args []string) error {
 }
 
 func doRun(ctx context.Context, inst, cmd string, cmdArgs []string, opts ...runOpt) error {
-	cfg := &runCfg{
-		req: protos.ExecuteCommandRequest{
-			AppendEnvironment: []string{},
-			Args:              cmdArgs,
-			Command:           cmd,
-			Path:              []string{},
-			GomoteId:          inst,
-		},
-	}
-	for _, opt := range opts {
-		opt(cfg)
-	}
-	if !cfg.req.SystemLevel {
-		cfg.req.SystemLevel = strings.HasPrefix(cmd, "/")
-	}
-
+	cfg := newRunCfg(inst, cmd, cmdArgs, opts)
 	outWriter := io.MultiWriter(cfg.outputs...)
+	errWriter := outWriter
+	if cfg.req.SeparateStderr {
+		errWriter = io.MultiWriter(cfg.stderrs...)
+	}
 	client := gomoteServerClient(ctx)
 	stream, err := client.ExecuteCommand(ctx, &cfg.req)
 	if err != nil {
 		return fmt.Errorf("unable to execute %s: %w", cmd, err)
 	}
+	var es *protos.ExitStatus
 	for {
 		update, err := stream.Recv()
 		if err == io.EOF {
 			return nil
 		}
 		if err != nil {
-			// execution error
+			// execution error, or the command ran and failed
+			if status.Code(err) == codes.Aborted || es != nil {
+				return &cmdFailedError{inst: inst, cmd: cmd, err: err, status: es}
+			}
+			// remote error
+			return fmt.Errorf("unable to execute %s: %w", cmd, err)
+		}
+		outWriter.Write(update.GetOutput())
+		errWriter.Write(update.GetStderr())
+		if es = update.GetExitStatus(); es != nil && cfg.onExit != nil {
+			cfg.onExit(es)
+		}
+	}
+}
+
+// formatExitStatus describes how a command ended and the resources it
+// used, for logging.
+func formatExitStatus(es *protos.ExitStatus) string {
+	var b strings.Builder
+	if es.GetSignal() != "" {
+		fmt.Fprintf(&b, "was killed by signal %q", es.GetSignal())
+	} else {
+		fmt.Fprintf(&b, "exited with code %d", es.GetCode())
+	}
+	fmt.Fprintf(&b, " after %v (user %v, system %v",
+		es.GetWallTime().AsDuration().Round(time.Millisecond),
+		es.GetUserTime().AsDuration().Round(time.Millisecond),
+		es.GetSystemTime().AsDuration().Round(time.Millisecond))
+	if rss := es.GetMaxRss(); rss > 0 {
+		fmt.Fprintf(&b, ", peak RSS %.1f MiB", float64(rss)/(1<<20))
+	}
+	b.WriteString(")")
+	if limit := es.GetLimitExceeded(); limit != "" {
+		fmt.Fprintf(&b, "; it exceeded its %s limit", limit)
+	}
+	return b.String()
+}
+
+// doRunInteractive runs cmd on inst with this process's standard input
+// connected to it if interactive is set, and in a pseudo-terminal if tty
+// is set.
+func doRunInteractive(ctx context.Context, inst, cmd string, cmdArgs []string, interactive, tty bool, opts ...runOpt) error {
+	cfg := newRunCfg(inst, cmd, cmdArgs, opts)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := gomoteServerClient(ctx).ExecuteCommandInteractive(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to execute %s: %w", cmd, err)
+	}
+	var sendMu sync.Mutex
+	send := func(req *protos.ExecuteCommandInteractiveRequest) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.Send(req)
+	}
+
+	start := &protos.ExecuteCommandInteractiveRequest{Command: &cfg.req}
+	sigc := make(chan os.Signal, 1)
+	if tty {
+		start.Tty = true
+		start.Term = os.Getenv("TERM")
+		start.WindowSize = terminalSize()
+		notifyResize(sigc)
+		if fd := int(os.Stdin.Fd()); interactive && term.IsTerminal(fd) {
+			// Let the remote terminal handle line editing, echo and
+			// control characters such as ^C.
+			old, err := term.MakeRaw(fd)
+			if err != nil {
+				return err
+			}
+			defer term.Restore(fd, old)
+		}
+	} else {
+		signal.Notify(sigc, os.Interrupt)
+	}
+	defer signal.Stop(sigc)
+	if err := send(start); err != nil {
+		return fmt.Errorf("unable to execute %s: %w", cmd, err)
+	}
+
+	if interactive {
+		go func() {
+			buf := make([]byte, 32<<10)
+			for {
+				n, err := os.Stdin.Read(buf)
+				if n > 0 {
+					if send(&protos.ExecuteCommandInteractiveRequest{Stdin: bytes.Clone(buf[:n])}) != nil {
+						return
+					}
+				}
+				if err != nil {
+					send(&protos.ExecuteCommandInteractiveRequest{CloseStdin: true})
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-sigc:
+				if sig == os.Interrupt {
+					send(&protos.ExecuteCommandInteractiveRequest{Signal: "INT"})
+				} else if ws := terminalSize(); ws != nil {
+					send(&protos.ExecuteCommandInteractiveRequest{WindowSize: ws})
+				}
+			}
+		}
+	}()
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return fmt.Errorf("unable to execute %s: connection closed before the command exited", cmd)
+		}
+		if err != nil {
 			if status.Code(err) == codes.Aborted {
 				return &cmdFailedError{inst: inst, cmd: cmd, err: err}
 			}
-			// remote error
 			return fmt.Errorf("unable to execute %s: %w", cmd, err)
 		}
-		fmt.Fprint(outWriter, string(update.GetOutput()))
+		os.Stdout.Write(res.GetStdout())
+		os.Stderr.Write(res.GetStderr())
+		if es := res.GetExitStatus(); es != nil {
+			if es.GetState() != "ok" {
+				return &cmdFailedError{inst: inst, cmd: cmd, err: errors.New(es.GetState()), status: es}
+			}
+			return nil
+		}
+	}
+}
+
+// terminalSize returns the size of the terminal on standard output,
+// or nil if it isn't one.
+func terminalSize() *protos.WindowSize {
+	cols, rows, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return nil
+	}
+	return &protos.WindowSize{Rows: uint32(rows), Cols: uint32(cols)}
+}
+
+func newRunCfg(inst, cmd string, cmdArgs []string, opts []runOpt) *runCfg {
+	cfg := &runCfg{
+		req: protos.ExecuteCommandRequest{
+			AppendEnvironment: []string{},
+			Args:              cmdArgs,
+			Command:           cmd,
+			Path:              []string{},
+			GomoteId:          inst,
+		},
 	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if !cfg.req.SystemLevel {
+		cfg.req.SystemLevel = strings.HasPrefix(cmd, "/")
+	}
+	return cfg
 }
 
 type cmdFailedError struct {
 	inst, cmd string
 	err       error
+	status    *protos.ExitStatus // nil if the command didn't run to completion
 }
 
 func (e *cmdFailedError) Error() string {
@@ -302,8 +532,19 @@ func (e *cmdFailedError) Unwrap() error {
 	return e.err
 }
 
+// exitCode returns the exit code gomote should exit with to reflect the
+// failure: the command's own if it had one, otherwise 1.
+func (e *cmdFailedError) exitCode() int {
+	if c := e.status.GetCode(); c > 0 && c < 256 {
+		return int(c)
+	}
+	return 1
+}
+
 type runCfg struct {
 	outputs []io.Writer
+	stderrs []io.Writer
+	onExit  func(*protos.ExitStatus)
 	req     protos.ExecuteCommandRequest
 }
 
@@ -351,8 +592,33 @@ func runFirewall(firewall bool) runOpt {
 	}
 }
 
+// runLimits sets limits on the resources the command may use, if
+// limits is non-nil.
+func runLimits(limits *protos.ExecLimits) runOpt {
+	return func(r *runCfg) {
+		r.req.Limits = limits
+	}
+}
+
 func runWriters(writers ...io.Writer) runOpt {
 	return func(r *runCfg) {
 		r.outputs = writers
 	}
 }
+
+// runStderr requests that the command's standard error be kept separate
+// from its standard output, and written to writers.
+func runStderr(separate bool, writers ...io.Writer) runOpt {
+	return func(r *runCfg) {
+		r.req.SeparateStderr = separate
+		r.stderrs = writers
+	}
+}
+
+// runExitStatus sets a function to call with the command's exit status
+// once it has finished.
+func runExitStatus(f func(*protos.ExitStatus)) runOpt {
+	return func(r *runCfg) {
+		r.onExit = f
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !unix
+
+package main
+
+import "os"
+
+// notifyResize does nothing, as there's no signal for changes to the
+// terminal's size.
+func notifyResize(c chan<- os.Signal) {}
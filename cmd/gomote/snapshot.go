@@ -0,0 +1,99 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/build/internal/gomote/protos"
+)
+
+func snapshot(args []string) error {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	fs.Usage = func() {
+		log := usageLogger
+		log.Print("snapshot usage: gomote snapshot [-dir dir] <instance> <name>")
+		log.Print("                gomote snapshot -list")
+		log.Print("                gomote snapshot -rm <name>")
+		log.Print()
+		log.Print("Saves a directory of an instance, its whole work directory by")
+		log.Print("default, as a named snapshot. New instances of the same builder")
+		log.Print("type can then start with its contents with")
+		log.Print("'gomote create -from-snapshot <name>', and warm pools can keep")
+		log.Print("such instances ready (see 'gomote warmpool').")
+		log.Print()
+		log.Print("Saving a snapshot with the name of an existing one replaces it.")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	var dir string
+	fs.StringVar(&dir, "dir", "", "relative directory from the instance's work dir to save")
+	var list bool
+	fs.BoolVar(&list, "list", false, "list your snapshots")
+	var remove bool
+	fs.BoolVar(&remove, "rm", false, "delete the named snapshot")
+	fs.Parse(args)
+
+	ctx := context.Background()
+	client := gomoteServerClient(ctx)
+	switch {
+	case list:
+		if fs.NArg() != 0 {
+			fs.Usage()
+		}
+		resp, err := client.ListSnapshots(ctx, &protos.ListSnapshotsRequest{})
+		if err != nil {
+			return fmt.Errorf("unable to list snapshots: %w", err)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tBUILDER\tDIR\tSIZE\tCREATED")
+		for _, s := range resp.GetSnapshots() {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", s.GetName(), s.GetBuilderType(), s.GetDirectory(), s.GetSize(),
+				time.Unix(s.GetCreated(), 0).Format(time.DateTime))
+		}
+		return tw.Flush()
+	case remove:
+		if fs.NArg() != 1 {
+			fs.Usage()
+		}
+		if _, err := client.DeleteSnapshot(ctx, &protos.DeleteSnapshotRequest{Name: fs.Arg(0)}); err != nil {
+			return fmt.Errorf("unable to delete snapshot: %w", err)
+		}
+		return nil
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+	}
+	resp, err := client.CreateSnapshot(ctx, &protos.CreateSnapshotRequest{
+		GomoteId:  fs.Arg(0),
+		Name:      fs.Arg(1),
+		Directory: dir,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create snapshot: %w", err)
+	}
+	fmt.Printf("saved snapshot %s of %s (%d bytes)\n", resp.GetSnapshot().GetName(), resp.GetSnapshot().GetBuilderType(), resp.GetSnapshot().GetSize())
+	return nil
+}
+
+// findSnapshot returns the description of one of the user's snapshots.
+func findSnapshot(ctx context.Context, name string) (*protos.Snapshot, error) {
+	client := gomoteServerClient(ctx)
+	resp, err := client.ListSnapshots(ctx, &protos.ListSnapshotsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list snapshots: %w", err)
+	}
+	for _, s := range resp.GetSnapshots() {
+		if s.GetName() == name {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("snapshot %q does not exist", name)
+}
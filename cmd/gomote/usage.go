@@ -0,0 +1,108 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/build/internal/gomote/protos"
+)
+
+func showUsage(args []string) error {
+	fs := flag.NewFlagSet("usage", flag.ContinueOnError)
+	fs.Usage = func() {
+		log := usageLogger
+		log.Print("usage usage: gomote usage [-since duration] [-group name]")
+		log.Print("             gomote usage -watch")
+		log.Print()
+		log.Print("Prints how many instances of each builder type you created,")
+		log.Print("how long they were alive and how long they were unused, along")
+		log.Print("with your quotas. With -group, prints the usage of all the")
+		log.Print("members of a quota group you're a member of.")
+		log.Print()
+		log.Print("With -watch, waits for warnings about your instances being")
+		log.Print("unused, and prints them until interrupted. Unused instances")
+		log.Print("are destroyed a few minutes after the warning unless they're")
+		log.Print("used again.")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	var (
+		since  time.Duration
+		group  string
+		follow bool
+	)
+	fs.DurationVar(&since, "since", 7*24*time.Hour, "how far back to report usage")
+	fs.StringVar(&group, "group", "", "name of a quota group to report the usage of")
+	fs.BoolVar(&follow, "watch", false, "print warnings about unused instances as they happen")
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		fs.Usage()
+	}
+
+	ctx := context.Background()
+	client := gomoteServerClient(ctx)
+	if follow {
+		stream, err := client.WatchInstances(ctx, &protos.WatchInstancesRequest{})
+		if err != nil {
+			return fmt.Errorf("unable to watch instances: %w", err)
+		}
+		for {
+			w, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("unable to watch instances: %w", err)
+			}
+			printUsageWarning(os.Stdout, w)
+		}
+	}
+
+	resp, err := client.GetUsage(ctx, &protos.GetUsageRequest{
+		Since: time.Now().Add(-since).Unix(),
+		Group: group,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get usage: %w", err)
+	}
+	return printUsage(os.Stdout, resp)
+}
+
+// printUsage prints usage records and quotas as tables.
+func printUsage(w io.Writer, resp *protos.GetUsageResponse) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "USER\tBUILDER\tINSTANCES\tALIVE\tIDLE\tRECLAIMED")
+	for _, r := range resp.GetRecords() {
+		alive := time.Duration(r.GetInstanceSeconds()) * time.Second
+		idle := time.Duration(r.GetIdleSeconds()) * time.Second
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%d\n", r.GetUser(), r.GetHostType(), r.GetInstances(), alive, idle, r.GetReclaimed())
+	}
+	if len(resp.GetQuotas()) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "QUOTA\tBUILDERS\tIN USE")
+		for _, q := range resp.GetQuotas() {
+			fmt.Fprintf(tw, "%s\t%s\t%d/%d\n", q.GetScope(), q.GetHostPattern(), q.GetInUse(), q.GetLimit())
+		}
+	}
+	return tw.Flush()
+}
+
+// printUsageWarning prints a warning about an instance.
+func printUsageWarning(w io.Writer, resp *protos.WatchInstancesResponse) {
+	at := time.Unix(resp.GetReclaimTime(), 0).Format(time.Kitchen)
+	switch resp.GetEvent() {
+	case protos.WatchInstancesResponse_IDLE:
+		fmt.Fprintf(w, "# %s is unused and will be destroyed at %s unless it's used.\n", resp.GetGomoteId(), at)
+	case protos.WatchInstancesResponse_RECLAIMED:
+		fmt.Fprintf(w, "# %s was destroyed at %s because it was unused.\n", resp.GetGomoteId(), at)
+	}
+}
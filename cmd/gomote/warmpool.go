@@ -0,0 +1,72 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"golang.org/x/build/internal/gomote/protos"
+)
+
+func warmPool(args []string) error {
+	fs := flag.NewFlagSet("warmpool", flag.ContinueOnError)
+	fs.Usage = func() {
+		log := usageLogger
+		log.Print("warmpool usage: gomote warmpool [-snapshot name] <type> <size>")
+		log.Print("                gomote warmpool")
+		log.Print()
+		log.Print("Keeps size instances of a builder type ready for you, so that")
+		log.Print("'gomote create' returns one of them right away instead of")
+		log.Print("waiting for a new instance to start. With -snapshot, the")
+		log.Print("instances start with the contents of the snapshot, and are")
+		log.Print("used by 'gomote create -from-snapshot'. A size of 0 removes")
+		log.Print("the warm pool. Warm pools that aren't used for a day are")
+		log.Print("removed.")
+		log.Print()
+		log.Print("With no arguments, lists your warm pools.")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	var snap string
+	fs.StringVar(&snap, "snapshot", "", "name of the snapshot to restore on the instances")
+	fs.Parse(args)
+
+	ctx := context.Background()
+	client := gomoteServerClient(ctx)
+	switch fs.NArg() {
+	case 0:
+		resp, err := client.ListWarmPools(ctx, &protos.ListWarmPoolsRequest{})
+		if err != nil {
+			return fmt.Errorf("unable to list warm pools: %w", err)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "BUILDER\tSNAPSHOT\tREADY\tERROR")
+		for _, p := range resp.GetWarmPools() {
+			fmt.Fprintf(tw, "%s\t%s\t%d/%d\t%s\n", p.GetBuilderType(), p.GetSnapshot(), p.GetReady(), p.GetSize(), p.GetLastError())
+		}
+		return tw.Flush()
+	case 2:
+		size, err := strconv.Atoi(fs.Arg(1))
+		if err != nil {
+			return fmt.Errorf("invalid size %q", fs.Arg(1))
+		}
+		if _, err := client.SetWarmPool(ctx, &protos.SetWarmPoolRequest{
+			BuilderType: fs.Arg(0),
+			Snapshot:    snap,
+			Size:        int32(size),
+		}); err != nil {
+			return fmt.Errorf("unable to set warm pool: %w", err)
+		}
+		return nil
+	default:
+		fs.Usage()
+	}
+	return nil
+}
@@ -0,0 +1,425 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// maxWatchRounds is the number of rounds shown in the result matrix of
+// the watch command.
+const maxWatchRounds = 8
+
+func watch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.Usage = func() {
+		log := usageLogger
+		log.Print("watch usage: gomote watch [watch-opts] [instance] -- <cmd> [args...]")
+		log.Print()
+		log.Print("Watches a local directory, $GOROOT by default, and whenever")
+		log.Print("files in it change, pushes the changes to the instance, or")
+		log.Print("to every instance of the group, and runs the command there")
+		log.Print("again. After each round, a matrix of the results on every")
+		log.Print("instance is printed. A change made while the command runs")
+		log.Print("interrupts it and starts a new round.")
+		log.Print()
+		log.Print("Instance name is optional if a group is specified.")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	var src, dir, runDirectory string
+	fs.StringVar(&src, "src", "", "local directory to watch and push; defaults to $GOROOT")
+	fs.StringVar(&dir, "dir", "", "relative directory from the buildlet's work dir to push to; defaults to \"go\" for $GOROOT, or the base name of -src")
+	fs.StringVar(&runDirectory, "rundir", "", "directory to run the command from, relative to the work dir; defaults to \"go/src\" for $GOROOT, or the directory pushed to")
+	var debounce time.Duration
+	fs.DurationVar(&debounce, "debounce", 300*time.Millisecond, "how long files must stay unchanged before a new round starts")
+	var env stringSlice
+	fs.Var(&env, "e", "Environment variable KEY=value. The -e flag may be repeated multiple times to add multiple things to the environment.")
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+	}
+
+	var spec pushSpec
+	if src == "" {
+		goroot, err := getGOROOT()
+		if err != nil {
+			return err
+		}
+		spec = gorootPush(goroot)
+	} else {
+		abs, err := filepath.Abs(src)
+		if err != nil {
+			return err
+		}
+		if fi, err := os.Stat(abs); err != nil {
+			return err
+		} else if !fi.IsDir() {
+			return fmt.Errorf("%s is not a directory", src)
+		}
+		spec = pushSpec{src: abs, dst: filepath.Base(abs)}
+	}
+	if dir != "" {
+		spec.dst = dir
+	}
+	if runDirectory == "" {
+		runDirectory = spec.dst
+		if spec.goroot {
+			runDirectory = path.Join(spec.dst, "src")
+		}
+	}
+
+	ctx := context.Background()
+	var watchSet []string
+	cmdArgs := fs.Args()
+	if err := doPing(ctx, fs.Arg(0)); instanceDoesNotExist(err) {
+		if activeGroup == nil {
+			return fmt.Errorf("instance %q: %w", fs.Arg(0), err)
+		}
+		watchSet = append(watchSet, activeGroup.Instances...)
+	} else if err == nil {
+		watchSet = append(watchSet, fs.Arg(0))
+		cmdArgs = cmdArgs[1:]
+	} else {
+		return fmt.Errorf("checking instance %q: %w", fs.Arg(0), err)
+	}
+	if len(cmdArgs) == 0 {
+		log.Print("missing command")
+		fs.Usage()
+	}
+	if len(watchSet) == 0 {
+		return errors.New("no instances to watch with")
+	}
+
+	outDir, err := os.MkdirTemp("", "gomote")
+	if err != nil {
+		return err
+	}
+	w := &watcher{
+		spec:     spec,
+		insts:    watchSet,
+		cmd:      cmdArgs[0],
+		cmdArgs:  cmdArgs[1:],
+		runOpts:  []runOpt{runDir(runDirectory), runEnv(env)},
+		outDir:   outDir,
+		debounce: debounce,
+		matrix:   &watchMatrix{insts: watchSet},
+		stdout:   os.Stdout,
+	}
+	changes, err := watchTree(ctx, spec.src, spec.skipWatched)
+	if err != nil {
+		return fmt.Errorf("unable to watch %s: %w", spec.src, err)
+	}
+	log.Printf("Watching %q; results are written to %q.\n", spec.src, outDir)
+	return w.loop(ctx, changes)
+}
+
+// skipWatched reports whether changes to the file or directory at rel,
+// relative to the pushed directory, are ignored by the watch command.
+func (spec pushSpec) skipWatched(rel string, isDir bool) bool {
+	rel = filepath.ToSlash(rel)
+	if rel == ".git" || strings.HasPrefix(rel, ".git/") || strings.Contains(rel, "/.git/") {
+		return true
+	}
+	if spec.goroot {
+		top, _, _ := strings.Cut(rel, "/")
+		if top == "pkg" || top == "bin" || isGoToolDistGenerated(rel) {
+			return true
+		}
+	}
+	return !isDir && isEditorBackup(rel)
+}
+
+// A watcher pushes a directory to instances and runs a command on them
+// each time the directory changes.
+type watcher struct {
+	spec     pushSpec
+	insts    []string
+	cmd      string
+	cmdArgs  []string
+	runOpts  []runOpt
+	outDir   string
+	debounce time.Duration
+	matrix   *watchMatrix
+	stdout   io.Writer
+
+	// round runs a round on an instance; if nil, it's pushRound.
+	round func(ctx context.Context, inst string, n int) string
+}
+
+// loop runs rounds, the first one right away and then whenever a file
+// is reported on changes, until changes is closed.
+func (w *watcher) loop(ctx context.Context, changes <-chan string) error {
+	n := 0
+	for {
+		n++
+		roundCtx, cancel := context.WithCancel(ctx)
+		done := make(chan map[string]string, 1)
+		go func() { done <- w.runRound(roundCtx, n) }()
+
+		// Wait for the round to finish, stopping it if something changes
+		// in the meantime.
+		var changed bool
+	wait:
+		for {
+			select {
+			case results := <-done:
+				if !changed {
+					w.matrix.add(n, results)
+					w.matrix.print(w.stdout)
+				}
+				break wait
+			case rel, ok := <-changes:
+				if !ok {
+					cancel()
+					<-done
+					return nil
+				}
+				if !changed {
+					log.Printf("%s changed; restarting round %d...\n", rel, n)
+					changed = true
+					cancel()
+				}
+			}
+		}
+		cancel()
+		if !changed {
+			rel, ok := <-changes
+			if !ok {
+				return nil
+			}
+			log.Printf("%s changed...\n", rel)
+		}
+		if !drainChanges(changes, w.debounce) {
+			return nil
+		}
+	}
+}
+
+// drainChanges waits until nothing has been reported on changes for d.
+// It reports false if changes was closed.
+func drainChanges(changes <-chan string, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	for {
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				return false
+			}
+			t.Reset(d)
+		case <-t.C:
+			return true
+		}
+	}
+}
+
+// runRound runs round n on all the instances, and returns the result on
+// each.
+func (w *watcher) runRound(ctx context.Context, n int) map[string]string {
+	round := w.round
+	if round == nil {
+		round = w.pushRound
+	}
+	var mu sync.Mutex
+	results := make(map[string]string)
+	var wg sync.WaitGroup
+	for _, inst := range w.insts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := round(ctx, inst, n)
+			mu.Lock()
+			results[inst] = r
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// pushRound pushes the changes to an instance and runs the command on it.
+// It returns "ok" if the command succeeds, "FAIL" if it fails, and "ERR"
+// if it can't be run.
+func (w *watcher) pushRound(ctx context.Context, inst string, n int) string {
+	if err := doPush(ctx, inst, w.spec, false, false); err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Unable to push to %q: %v\n", inst, err)
+		}
+		return "ERR"
+	}
+	outf, err := os.Create(filepath.Join(w.outDir, fmt.Sprintf("%s.%d.stdout", inst, n)))
+	if err != nil {
+		log.Printf("Unable to create output file: %v\n", err)
+		return "ERR"
+	}
+	defer outf.Close()
+	outputs := []io.Writer{outf}
+	if len(w.insts) == 1 {
+		outputs = append(outputs, w.stdout)
+	}
+	opts := append([]runOpt{runWriters(outputs...)}, w.runOpts...)
+	err = doRun(ctx, inst, w.cmd, w.cmdArgs, opts...)
+	var ce *cmdFailedError
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.As(err, &ce):
+		fmt.Fprintln(outf, ce.Error())
+		return "FAIL"
+	default:
+		if ctx.Err() == nil {
+			log.Printf("Unable to run command on %q: %v\n", inst, err)
+		}
+		return "ERR"
+	}
+}
+
+// A watchMatrix holds the results of the last rounds of the watch
+// command.
+type watchMatrix struct {
+	insts  []string
+	rounds []int
+	// results holds the results of each round, by instance.
+	results []map[string]string
+}
+
+// add adds the results of round n, forgetting the oldest round if there
+// are more than maxWatchRounds.
+func (m *watchMatrix) add(n int, results map[string]string) {
+	m.rounds = append(m.rounds, n)
+	m.results = append(m.results, results)
+	if len(m.rounds) > maxWatchRounds {
+		m.rounds = m.rounds[1:]
+		m.results = m.results[1:]
+	}
+}
+
+// print writes the results as a table with a row per instance and a
+// column per round.
+func (m *watchMatrix) print(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprint(tw, "INSTANCE")
+	for _, n := range m.rounds {
+		fmt.Fprintf(tw, "\t#%d", n)
+	}
+	fmt.Fprintln(tw)
+	for _, inst := range m.insts {
+		fmt.Fprint(tw, inst)
+		for _, results := range m.results {
+			fmt.Fprintf(tw, "\t%s", results[inst])
+		}
+		fmt.Fprintln(tw)
+	}
+	tw.Flush()
+}
+
+// pollTree reports changes to the files in the tree rooted at root,
+// other than those skip reports, by comparing the tree's state every
+// interval. Changes are reported as paths relative to root on the
+// returned channel, which is closed once ctx is done.
+func pollTree(ctx context.Context, root string, skip func(rel string, isDir bool) bool, interval time.Duration) (<-chan string, error) {
+	prev, err := scanTree(root, skip)
+	if err != nil {
+		return nil, err
+	}
+	c := make(chan string)
+	go func() {
+		defer close(c)
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+			cur, err := scanTree(root, skip)
+			if err != nil {
+				log.Printf("unable to scan %s: %v", root, err)
+				continue
+			}
+			var changed []string
+			for rel, st := range cur {
+				if prev[rel] != st {
+					changed = append(changed, rel)
+				}
+			}
+			for rel := range prev {
+				if _, ok := cur[rel]; !ok {
+					changed = append(changed, rel)
+				}
+			}
+			prev = cur
+			for _, rel := range changed {
+				select {
+				case c <- rel:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return c, nil
+}
+
+// fileState is the state of a file compared by pollTree.
+type fileState struct {
+	mode    fs.FileMode
+	size    int64
+	modTime time.Time
+}
+
+// scanTree returns the state of the files in the tree rooted at root,
+// by path relative to root.
+func scanTree(root string, skip func(rel string, isDir bool) bool) (map[string]fileState, error) {
+	files := make(map[string]fileState)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil // removed while walking
+		}
+		if err != nil {
+			return err
+		}
+		if p == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		if skip(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		fi, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		files[rel] = fileState{mode: fi.Mode(), size: fi.Size(), modTime: fi.ModTime()}
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const inotifyMask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_MODIFY | unix.IN_ATTRIB |
+	unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_DELETE_SELF
+
+// watchTree reports changes to the files in the tree rooted at root,
+// other than those skip reports, as paths relative to root on the
+// returned channel, which is closed once ctx is done. It uses inotify,
+// or polls the tree if inotify can't watch all of it.
+func watchTree(ctx context.Context, root string, skip func(rel string, isDir bool) bool) (<-chan string, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		log.Printf("inotify is unavailable, polling for changes instead: %v", err)
+		return pollTree(ctx, root, skip, time.Second)
+	}
+	// The file is non-blocking, so reads use the runtime poller, and
+	// closing it interrupts them.
+	f := os.NewFile(uintptr(fd), "inotify")
+	iw := &inotifyWatcher{f: f, fd: fd, root: root, skip: skip, dirs: make(map[int]string)}
+	if err := iw.addTree(root, nil); err != nil {
+		f.Close()
+		log.Printf("unable to watch %s with inotify, polling for changes instead: %v", root, err)
+		return pollTree(ctx, root, skip, time.Second)
+	}
+	c := make(chan string)
+	go func() {
+		<-ctx.Done()
+		f.Close()
+	}()
+	go iw.read(ctx, c)
+	return c, nil
+}
+
+// inotifyWatcher watches a tree of directories with inotify.
+type inotifyWatcher struct {
+	f    *os.File
+	fd   int
+	root string
+	skip func(rel string, isDir bool) bool
+	dirs map[int]string // watch descriptor to path relative to root
+}
+
+// addTree watches dir and its subdirectories. If found isn't nil, it's
+// called with the files already in them.
+func (iw *inotifyWatcher) addTree(dir string, found func(rel string)) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(iw.root, p)
+		if err != nil {
+			return err
+		}
+		if rel != "." && iw.skip(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			if found != nil {
+				found(rel)
+			}
+			return nil
+		}
+		wd, err := unix.InotifyAddWatch(iw.fd, p, inotifyMask|unix.IN_ONLYDIR)
+		if errors.Is(err, unix.ENOENT) {
+			return nil
+		} else if err != nil {
+			return &fs.PathError{Op: "inotify_add_watch", Path: p, Err: err}
+		}
+		iw.dirs[wd] = rel
+		return nil
+	})
+}
+
+// read reads inotify events until the inotify file is closed, and
+// reports the paths they're about on c, which it then closes.
+func (iw *inotifyWatcher) read(ctx context.Context, c chan<- string) {
+	defer close(c)
+	buf := make([]byte, 64<<10)
+	for {
+		n, err := iw.f.Read(buf)
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				log.Printf("unable to read inotify events: %v", err)
+			}
+			return
+		}
+		var changed []string
+		for off := 0; off+unix.SizeofInotifyEvent <= n; {
+			ev := (*unix.InotifyEvent)(unsafe.Pointer(&buf[off]))
+			name := buf[off+unix.SizeofInotifyEvent : off+unix.SizeofInotifyEvent+int(ev.Len)]
+			off += unix.SizeofInotifyEvent + int(ev.Len)
+			if ev.Mask&unix.IN_Q_OVERFLOW != 0 {
+				// Events were lost; report that anything may have changed.
+				changed = append(changed, ".")
+				continue
+			}
+			dir, ok := iw.dirs[int(ev.Wd)]
+			if !ok {
+				continue
+			}
+			if ev.Mask&unix.IN_IGNORED != 0 {
+				delete(iw.dirs, int(ev.Wd))
+				continue
+			}
+			if ev.Mask&unix.IN_DELETE_SELF != 0 {
+				continue // reported by the parent directory
+			}
+			rel := filepath.Join(dir, string(bytes.TrimRight(name, "\x00")))
+			isDir := ev.Mask&unix.IN_ISDIR != 0
+			if iw.skip(rel, isDir) {
+				continue
+			}
+			changed = append(changed, rel)
+			if isDir && ev.Mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0 {
+				// Watch the new directory, and report the files that
+				// were created in it before it was watched.
+				err := iw.addTree(filepath.Join(iw.root, rel), func(rel string) {
+					changed = append(changed, rel)
+				})
+				if err != nil {
+					log.Printf("unable to watch %s: %v", rel, err)
+				}
+			}
+		}
+		for _, rel := range changed {
+			select {
+			case c <- rel:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// watchTree reports changes to the files in the tree rooted at root,
+// other than those skip reports, as paths relative to root on the
+// returned channel, which is closed once ctx is done. It polls the tree
+// for changes.
+func watchTree(ctx context.Context, root string, skip func(rel string, isDir bool) bool) (<-chan string, error) {
+	return pollTree(ctx, root, skip, time.Second)
+}
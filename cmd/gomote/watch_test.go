@@ -0,0 +1,187 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatchTree(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		watch func(ctx context.Context, root string, skip func(string, bool) bool) (<-chan string, error)
+	}{
+		{"native", watchTree},
+		{"polling", func(ctx context.Context, root string, skip func(string, bool) bool) (<-chan string, error) {
+			return pollTree(ctx, root, skip, 10*time.Millisecond)
+		}},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			root := t.TempDir()
+			if err := os.Mkdir(filepath.Join(root, ".git"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			spec := pushSpec{src: root, dst: "x"}
+			changes, err := tc.watch(ctx, root, spec.skipWatched)
+			if err != nil {
+				t.Fatalf("watching %s = %v", root, err)
+			}
+			// waitFor waits to be told about a change to rel, failing
+			// if it's told about a change to one of the ignored files.
+			waitFor := func(rel string) {
+				t.Helper()
+				timeout := time.After(10 * time.Second)
+				for {
+					select {
+					case got := <-changes:
+						if got == rel {
+							return
+						}
+						if strings.Contains(got, ".git") || strings.HasSuffix(got, "~") {
+							t.Fatalf("got change to ignored file %s", got)
+						}
+					case <-timeout:
+						t.Fatalf("no change to %s reported", rel)
+					}
+				}
+			}
+			write := func(rel string) {
+				t.Helper()
+				if err := os.WriteFile(filepath.Join(root, rel), []byte(rel), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			write(".git/index")
+			write("a.go~")
+			write("a.go")
+			waitFor("a.go")
+			if err := os.Mkdir(filepath.Join(root, "sub"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			write(filepath.Join("sub", "b.go"))
+			waitFor(filepath.Join("sub", "b.go"))
+			if err := os.Remove(filepath.Join(root, "a.go")); err != nil {
+				t.Fatal(err)
+			}
+			waitFor("a.go")
+
+			cancel()
+			for range changes {
+				// Wait for the channel to be closed.
+			}
+		})
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatcherLoop(t *testing.T) {
+	var out syncBuffer
+	started := make(chan int, 10)
+	insts := []string{"inst-a", "inst-b"}
+	w := &watcher{
+		insts:    insts,
+		debounce: time.Millisecond,
+		matrix:   &watchMatrix{insts: insts},
+		stdout:   &out,
+		round: func(ctx context.Context, inst string, n int) string {
+			if inst == "inst-a" {
+				started <- n
+			}
+			switch n {
+			case 2:
+				// Wait for a change to interrupt the round.
+				<-ctx.Done()
+				return "ERR"
+			case 3:
+				if inst == "inst-b" {
+					return "FAIL"
+				}
+			}
+			return "ok"
+		},
+	}
+	waitOutput := func(s string) {
+		t.Helper()
+		deadline := time.Now().Add(10 * time.Second)
+		for !strings.Contains(out.String(), s) {
+			if time.Now().After(deadline) {
+				t.Fatalf("output %q doesn't contain %q", out.String(), s)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	changes := make(chan string)
+	done := make(chan error, 1)
+	go func() { done <- w.loop(context.Background(), changes) }()
+	waitOutput("#1")
+	changes <- "a.go"
+	if n := <-started; n != 1 {
+		t.Fatalf("round %d started; want round 1", n)
+	}
+	if n := <-started; n != 2 {
+		t.Fatalf("round %d started; want round 2", n)
+	}
+	changes <- "b.go" // interrupts round 2
+	waitOutput("#3")
+	close(changes)
+	if err := <-done; err != nil {
+		t.Fatalf("loop = %v; want no error", err)
+	}
+
+	var want bytes.Buffer
+	m := &watchMatrix{insts: insts}
+	m.add(1, map[string]string{"inst-a": "ok", "inst-b": "ok"})
+	m.add(3, map[string]string{"inst-a": "ok", "inst-b": "FAIL"})
+	m.print(&want)
+	if !strings.HasSuffix(out.String(), want.String()) {
+		t.Errorf("output ends with:\n%s\nwant:\n%s", out.String(), want.String())
+	}
+}
+
+func TestWatchMatrix(t *testing.T) {
+	m := &watchMatrix{insts: []string{"a", "bb"}}
+	for n := 1; n <= maxWatchRounds+2; n++ {
+		m.add(n, map[string]string{"a": "ok", "bb": "FAIL"})
+	}
+	var buf bytes.Buffer
+	m.print(&buf)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("printed %d lines; want 3:\n%s", len(lines), buf.String())
+	}
+	if got := strings.Fields(lines[0]); len(got) != maxWatchRounds+1 || got[1] != "#3" {
+		t.Errorf("header = %q; want rounds #3 to #%d", lines[0], maxWatchRounds+2)
+	}
+	if got := strings.Fields(lines[2]); got[0] != "bb" || got[1] != "FAIL" {
+		t.Errorf("row = %q; want bb's failures", lines[2])
+	}
+}
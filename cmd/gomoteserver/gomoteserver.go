@@ -11,6 +11,7 @@ import (
 	"flag"
 	"log"
 	"net/http"
+	"os"
 	"strings"
 
 	"cloud.google.com/go/compute/metadata"
@@ -21,6 +22,7 @@ import (
 	"go.chromium.org/luci/hardcoded/chromeinfra"
 	"go.chromium.org/luci/swarming/client/swarming"
 	"golang.org/x/build/buildenv"
+	"golang.org/x/build/dashboard"
 	"golang.org/x/build/internal/access"
 	"golang.org/x/build/internal/coordinator/pool"
 	"golang.org/x/build/internal/coordinator/remote"
@@ -39,6 +41,11 @@ var (
 	sshAddr      = flag.String("ssh_addr", ":2222", "Address the gomote SSH server should listen on")
 	buildEnvName = flag.String("env", "", "The build environment configuration to use. Not required if running in dev mode locally or prod mode on GCE.")
 	mode         = flag.String("mode", "", "Valid modes are 'dev', 'prod', or '' for auto-detect. dev means localhost development, not be confused with staging on go-dashboard-dev, which is still the 'prod' mode.")
+	quotaConfig  = flag.String("quota-config", "", "Path to a JSON file configuring the quotas of users and the idle timeouts of instances. If empty, there are no quotas.")
+	maxSnapshot  = flag.Int64("max-snapshot-size", 2<<30, "The largest compressed size of a snapshot, in bytes.")
+
+	localBuildlet   = flag.String("local-buildlet", "", "Path to a buildlet binary built for this machine. If set, instances are started locally from it rather than as swarming tasks, for the builders whose host type can run on this machine.")
+	localContainers = flag.String("local-containers", "", "The Docker compatible tool, such as docker or podman, used to run the local instances of host types with a container image in containers. If empty, local instances run as processes.")
 )
 
 var Version string // set by linker -X
@@ -83,7 +90,18 @@ func main() {
 	}
 	grpcServer := grpc.NewServer(opts...)
 	rdv := rendezvous.New(ctx)
-	gomoteServer, err := gomote.NewSwarming(sp, sshCA, gomoteBucket, mustStorageClient(), rdv, mustSwarmingClient(ctx), mustBuildersClient(ctx))
+	gomoteOpts := []gomote.SwarmingOption{gomote.QuotaOption(mustQuotaConfig()), gomote.SnapshotSizeOption(*maxSnapshot)}
+	var swarmingClient swarming.Client
+	var buildersClient buildbucketpb.BuildersClient
+	if *localBuildlet != "" {
+		// Local instances need no LUCI clients, nor the credentials to create them.
+		lp := mustLocalBuildletPool()
+		defer lp.Close()
+		gomoteOpts = append(gomoteOpts, gomote.LocalBuildletOption(lp))
+	} else {
+		swarmingClient, buildersClient = mustSwarmingClient(ctx), mustBuildersClient(ctx)
+	}
+	gomoteServer, err := gomote.NewSwarming(sp, sshCA, gomoteBucket, mustStorageClient(), rdv, swarmingClient, buildersClient, gomoteOpts...)
 	if err != nil {
 		log.Fatalf("unable to create gomote server: %s", err)
 	}
@@ -122,6 +140,34 @@ func mustRetrieveSSHCertificateAuthority() (privateKey []byte) {
 	return privateKey
 }
 
+func mustLocalBuildletPool() *pool.LocalBuildlet {
+	var opts []pool.LocalOpt
+	if *localContainers != "" {
+		opts = append(opts, pool.LocalOptContainerRuntime(*localContainers))
+	}
+	lp, err := pool.NewLocalBuildlet(*localBuildlet, dashboard.Hosts, opts...)
+	if err != nil {
+		log.Fatalf("unable to create local buildlet pool: %s", err)
+	}
+	log.Printf("starting local instances of host types %q", lp.HostTypes())
+	return lp
+}
+
+func mustQuotaConfig() *gomote.QuotaConfig {
+	if *quotaConfig == "" {
+		return nil
+	}
+	data, err := os.ReadFile(*quotaConfig)
+	if err != nil {
+		log.Fatalf("unable to read quota configuration: %s", err)
+	}
+	cfg, err := gomote.ParseQuotaConfig(data)
+	if err != nil {
+		log.Fatalf("unable to parse %s: %s", *quotaConfig, err)
+	}
+	return cfg
+}
+
 func mustStorageClient() *storage.Client {
 	if metadata.OnGCE() {
 		sc, err := pool.StorageClient(context.Background())
@@ -46,6 +46,7 @@ var (
 	config          = flag.String("config", "", "If non-empty, the name of a pre-defined config. Valid options are 'go' to be the primary Go server; 'godata' to run the server locally using the godata package, and 'devgo' to act like 'go', but mirror from godata at start-up.")
 	dataDir         = flag.String("data-dir", "", "Local directory to write protobuf files to (default $HOME/var/maintnerd)")
 	debug           = flag.Bool("debug", false, "Print debug logging information")
+	snapshot        = flag.String("snapshot", "", "If non-empty, a file in which to keep a snapshot of the loaded corpus, so later start-ups only replay the mutations logged since. Only supported for disk-based logs.")
 	githubRateLimit = flag.Int("github-rate", 10, "Rate to limit GitHub requests (in queries per second, 0 is treated as unlimited)")
 
 	bucket         = flag.String("bucket", "", "if non-empty, Google Cloud Storage bucket to use for log storage. If the bucket name contains a \"/\", the part after the slash will be a prefix for the segments.")
@@ -188,6 +189,9 @@ func main() {
 	t0 := time.Now()
 
 	if logger != nil {
+		if *snapshot != "" {
+			corpus.UseSnapshot(*snapshot)
+		}
 		if err := corpus.Initialize(ctx, logger); err != nil {
 			// TODO: if Initialize only partially syncs the data, we need to delete
 			// whatever files it created, since Github returns events newest first
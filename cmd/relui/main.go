@@ -18,6 +18,8 @@ import (
 	"net/http"
 	"net/mail"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 
 	cloudbuild "cloud.google.com/go/cloudbuild/apiv1/v2"
@@ -59,6 +61,7 @@ var (
 	downUp      = flag.Bool("migrate-down-up", false, "Run all Up migration steps, then the last down migration step, followed by the final up migration. Exits after completion.")
 	migrateOnly = flag.Bool("migrate-only", false, "Exit after running migrations. Migrations are run by default.")
 	pgConnect   = flag.String("pg-connect", "", "Postgres connection string or URI. If empty, libpq connection defaults are used.")
+	sqlitePath  = flag.String("sqlite", "", "Path to a SQLite database file to use instead of Postgres, for local development. The file is created if it doesn't exist.")
 
 	scratchFilesBase = flag.String("scratch-files-base", "", "Storage for scratch files. gs://bucket/path or file:///path/to/scratch.")
 	signedFilesBase  = flag.String("signed-files-base", "", "Storage for signed files. gs://bucket/path or file:///path/to/signed.")
@@ -76,6 +79,9 @@ var (
 
 	buildbucketHost = flag.String("buildbucket-host", "", "Buildbucket host to use for tasks")
 	criaService     = flag.String("cria-service", "chrome-infra-auth", "CrIA service name")
+
+	dryRun    = flag.Bool("dry-run", false, "Allow starting workflows in dry-run mode. Dry-run workflows read from real services, but record writes to Gerrit, GitHub, Cloud Build triggers, mail, social media and the website instead of making them, and fail on any other write. The recorded actions are stored in the database and linked from each workflow's page. Other workflows are unaffected.")
+	dryRunDir = flag.String("dry-run-dir", "", "Directory for the scratch, signed and serving files of dry-run workflows. If empty, a temporary directory is used.")
 )
 
 func main() {
@@ -111,23 +117,40 @@ func main() {
 	flag.Parse()
 
 	ctx := context.Background()
-	if err := relui.InitDB(ctx, *pgConnect); err != nil {
-		log.Fatalf("relui.InitDB() = %v", err)
-	}
-	if *migrateOnly {
-		return
-	}
-	if *downUp {
-		if err := relui.MigrateDB(*pgConnect, true); err != nil {
-			log.Fatalf("relui.MigrateDB() = %v", err)
+	if *sqlitePath != "" {
+		if err := relui.MigrateSQLiteDB(*sqlitePath, *downUp); err != nil {
+			log.Fatalf("relui.MigrateSQLiteDB() = %v", err)
+		}
+		if *migrateOnly || *downUp {
+			return
+		}
+	} else {
+		if err := relui.InitDB(ctx, *pgConnect); err != nil {
+			log.Fatalf("relui.InitDB() = %v", err)
+		}
+		if *migrateOnly {
+			return
+		}
+		if *downUp {
+			if err := relui.MigrateDB(*pgConnect, true); err != nil {
+				log.Fatalf("relui.MigrateDB() = %v", err)
+			}
+			return
 		}
-		return
 	}
 
 	var dbPool db.PGDBTX
-	dbPool, err := pgxpool.Connect(ctx, *pgConnect)
-	if err != nil {
-		log.Fatalln("pgxpool.Connect:", err)
+	var err error
+	if *sqlitePath != "" {
+		dbPool, err = db.OpenSQLite(*sqlitePath)
+		if err != nil {
+			log.Fatalln("db.OpenSQLite:", err)
+		}
+	} else {
+		dbPool, err = pgxpool.Connect(ctx, *pgConnect)
+		if err != nil {
+			log.Fatalln("pgxpool.Connect:", err)
+		}
 	}
 	defer dbPool.Close()
 	dbPool = &relui.MetricsDB{PGDBTX: dbPool}
@@ -144,75 +167,74 @@ func main() {
 	if err != nil {
 		log.Fatalf("reading GCP credentials: %v", err)
 	}
-	gerritClient := &task.RealGerritClient{
+	deps := &workflowDeps{
+		db:              dbPool,
+		annMail:         annMail,
+		goplsAnnMail:    goplsAnnMail,
+		vscodeGoAnnMail: vscodeGoAnnMail,
+	}
+	deps.gerrit = &task.RealGerritClient{
 		Gitiles: "https://go.googlesource.com", GitilesAuth: creds.TokenSource,
 		Client: gerrit.NewClient("https://go-review.googlesource.com", gerrit.OAuth2Auth(creds.TokenSource)),
 	}
-	privateGerritClient := &task.RealGerritClient{
+	deps.privateGerrit = &task.RealGerritClient{
 		Gitiles: "https://go-internal.googlesource.com", GitilesAuth: creds.TokenSource,
 		Client: gerrit.NewClient("https://go-internal-review.googlesource.com", gerrit.OAuth2Auth(creds.TokenSource)),
 	}
-	modproxyTestGerritClient := &task.RealGerritClient{
+	deps.modproxyTestGerrit = &task.RealGerritClient{
 		Gitiles: "https://golang-modproxy-test.googlesource.com", GitilesAuth: creds.TokenSource,
 		Client: gerrit.NewClient("https://golang-modproxy-test-review.googlesource.com", gerrit.OAuth2Auth(creds.TokenSource)),
 	}
-	gitClient := &task.Git{}
-	gitClient.UseOAuth2Auth(creds.TokenSource)
-	var mailFunc func(*workflow.TaskContext, task.MailHeader, task.MailContent) error
+	deps.gerritHTTP = oauth2.NewClient(ctx, creds.TokenSource)
+	deps.git = &task.Git{}
+	deps.git.UseOAuth2Auth(creds.TokenSource)
 	switch {
 	case *sendgridAPIKey != "" && mailjetAPIKey != (secret.MailjetCredentials{}):
 		log.Fatalln("at most one of -sendgrid-api-key and -mailjet-api-key can be set at once")
 	case *sendgridAPIKey != "":
-		mailFunc = task.NewSendGridMailClient(*sendgridAPIKey).SendMail
+		deps.sendMail = task.NewSendGridMailClient(*sendgridAPIKey).SendMail
 	case mailjetAPIKey != (secret.MailjetCredentials{}):
-		mailFunc = task.NewMailjetMailClient(mailjetAPIKey).SendMail
+		deps.sendMail = task.NewMailjetMailClient(mailjetAPIKey).SendMail
 	default:
-		mailFunc = task.ReleaseCoordinatorAsTheMailSender{ApproveAction: relui.ApproveActionDep(dbPool)}.SendMail
+		deps.sendMail = task.ReleaseCoordinatorAsTheMailSender{ApproveAction: relui.ApproveActionDep(dbPool)}.SendMail
 	}
-	var mastodonClient task.Poster
 	if mastodonAPI != (secret.MastodonCredentials{}) {
 		var err error
-		mastodonClient, err = task.NewMastodonClient(mastodonAPI)
+		deps.mastodon, err = task.NewMastodonClient(mastodonAPI)
 		if err != nil {
 			log.Fatalln("task.NewMastodonClient:", err)
 		}
 	}
-	var blueskyClient task.Poster
 	if blueskyAPI != (secret.BlueskyCredentials{}) {
 		var err error
-		blueskyClient, err = task.NewBlueskyClient(blueskyAPI)
+		deps.bluesky, err = task.NewBlueskyClient(blueskyAPI)
 		if err != nil {
 			log.Fatalln("task.NewBlueskyClient:", err)
 		}
 	}
-	commTasks := task.CommunicationTasks{
-		SecurityCommunicationTasks: task.SecurityCommunicationTasks{
-			PrivateGerrit: privateGerritClient,
-		},
-		AnnounceMailTasks: task.AnnounceMailTasks{
-			SendMail:           mailFunc,
-			AnnounceMailHeader: annMail,
-		},
-		SocialMediaTasks: task.SocialMediaTasks{
-			TwitterClient:  task.NewTwitterClient(twitterAPI),
-			MastodonClient: mastodonClient,
-			BlueskyClient:  blueskyClient,
-		},
-	}
-	dh := relui.NewDefinitionHolder()
+	deps.twitter = task.NewTwitterClient(twitterAPI)
 	userPassAuth := buildlet.UserPass{
 		Username: "user-relui",
 		Password: key(*masterKey, "user-relui"),
 	}
+	deps.publishFile = func(_ *workflow.TaskContext, f task.WebsiteFile) error {
+		return publishFile(*websiteUploadURL, userPassAuth, f)
+	}
 	gcsClient, err := storage.NewClient(ctx)
 	if err != nil {
 		log.Fatalf("Could not connect to GCS: %v", err)
 	}
+	deps.gcs = gcsClient
+	deps.scratchFS = &task.ScratchFS{
+		BaseURL: *scratchFilesBase,
+		GCS:     gcsClient,
+	}
+	deps.signedURL, deps.servingURL = *signedFilesBase, *servingFilesBase
 	cbClient, err := cloudbuild.NewClient(ctx)
 	if err != nil {
 		log.Fatalf("Could not connect to Cloud Build: %v", err)
 	}
-	cloudBuildClient := &task.RealCloudBuildClient{
+	deps.cloudBuild = &task.RealCloudBuildClient{
 		BuildClient:   cbClient,
 		StorageClient: gcsClient,
 		ScriptProject: *cloudBuildProject,
@@ -232,13 +254,19 @@ func main() {
 			log.Fatalln("swarming.NewClient:", err)
 		}
 	}
-	var buildBucketClient task.BuildBucketClient
+	deps.swarming = &task.RealSwarmingClient{
+		SwarmingClient: swarmingClient,
+		SwarmingURL:    *swarmingURL,
+		ServiceAccount: *swarmingAccount,
+		Realm:          *swarmingRealm,
+		Pool:           *swarmingPool,
+	}
 	if *buildbucketHost != "" {
 		luciHTTPClient, err := auth.NewAuthenticator(ctx, auth.SilentLogin, auth.Options{GCEAllowAsDefault: true}).Client()
 		if err != nil {
 			log.Fatalln("auth.NewAuthenticator:", err)
 		}
-		buildBucketClient = &task.RealBuildBucketClient{
+		deps.buildBucket = &task.RealBuildBucketClient{
 			BuildersClient: pb.NewBuildersClient(&prpc.Client{
 				C:    luciHTTPClient,
 				Host: *buildbucketHost,
@@ -249,6 +277,11 @@ func main() {
 			}),
 		}
 	}
+	githubHTTPClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: *githubToken}))
+	deps.github = &task.GitHubClient{
+		V3: github.NewClient(githubHTTPClient),
+		V4: githubv4.NewClient(githubHTTPClient),
+	}
 
 	var gr *metrics.MonitoredResource
 	if metadata.OnGCE() {
@@ -271,60 +304,196 @@ func main() {
 		grpc.StreamInterceptor(access.RequireIAPAuthStreamInterceptor(iapAudience)))
 	signServer := sign.NewServer()
 	protos.RegisterReleaseServiceServer(grpcServer, signServer)
-	buildTasks := &relui.BuildReleaseTasks{
-		GerritClient:         gerritClient,
-		GerritProject:        "go",
-		GerritHTTPClient:     oauth2.NewClient(ctx, creds.TokenSource),
-		PrivateGerritClient:  privateGerritClient,
-		PrivateGerritProject: "go",
-		Git:                  gitClient,
-		SignService:          signServer,
-		GCSClient:            gcsClient,
-		ScratchFS: &task.ScratchFS{
-			BaseURL: *scratchFilesBase,
-			GCS:     gcsClient,
+	deps.signService = signServer
+
+	var base *url.URL
+	if *baseURL != "" {
+		base, err = url.Parse(*baseURL)
+		if err != nil {
+			log.Fatalf("url.Parse(%q) = %v, %v", *baseURL, base, err)
+		}
+	}
+	l := &relui.PGListener{
+		DB:                        dbPool,
+		BaseURL:                   base,
+		ScheduleFailureMailHeader: schedMail,
+		SendMail:                  nil, // TODO(go.dev/issue/74777): Restore email notifications about workflow failures.
+	}
+	// Workflows are registered with the worker that runs them,
+	// so that they can start child workflows.
+	dh := relui.NewDefinitionHolder()
+	w := relui.NewWorker(dh, dbPool, l)
+	if err := registerWorkflows(ctx, dh, w, deps); err != nil {
+		log.Fatalln(err)
+	}
+
+	// Workflows started in dry-run mode get their own definitions,
+	// registered with clients that record writes in trace instead
+	// of making them.
+	var dryRunDH *relui.DefinitionHolder
+	if *dryRun {
+		dir := *dryRunDir
+		if dir == "" {
+			if dir, err = os.MkdirTemp("", "relui-dry-run-"); err != nil {
+				log.Fatalln(err)
+			}
+		}
+		trace := &task.DryRunTrace{Store: &relui.DBDryRunStore{DB: dbPool}}
+		dryRunDeps, err := deps.dryRun(trace, dir)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		dryRunDH = relui.NewDefinitionHolder()
+		if err := registerWorkflows(ctx, dryRunDH, w, dryRunDeps); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if dryRunDH != nil {
+		w.EnableDryRun(dryRunDH)
+	}
+	go w.Run(ctx)
+	if err := w.ResumeAll(ctx); err != nil {
+		log.Printf("w.ResumeAll() = %v", err)
+	}
+	var prod bool // are we operating from symbolic-datum-552
+	var criaDB *criadb.AuthDatabase
+	if metadata.OnGCE() {
+		project, err := metadata.ProjectID()
+		if err != nil {
+			log.Fatalln("failed to read project ID from metadata server")
+		}
+		prod = project == "symbolic-datum-552"
+		if prod {
+			criaDB, err = criadb.NewDatabase(*criaService)
+			if err != nil {
+				log.Fatalf("failed to create cria authdb: %s", err)
+			}
+		}
+	} else {
+		criaDB = criadb.NewDevDatabase()
+	}
+	var h http.Handler = relui.NewServer(dbPool, w, base, siteHeader, ms, criaDB)
+	if prod {
+		iapAudience := buildenv.Production.IAPServiceAudience("relui-internal")
+		h = access.RequireIAPAuthHandler(h, iapAudience)
+	}
+	log.Fatalln(https.ListenAndServe(ctx, &ochttp.Handler{Handler: GRPCHandler(grpcServer, h)}))
+}
+
+// workflowDeps holds the clients and settings
+// that workflows are registered with.
+type workflowDeps struct {
+	db                                        db.PGDBTX
+	gerrit, privateGerrit, modproxyTestGerrit task.DestructiveGerritClient
+	gerritHTTP                                *http.Client
+	git                                       *task.Git
+	github                                    task.GitHubClientInterface
+	sendMail                                  func(*workflow.TaskContext, task.MailHeader, task.MailContent) error
+	twitter, mastodon, bluesky                task.Poster
+	gcs                                       *storage.Client
+	scratchFS                                 *task.ScratchFS
+	signedURL, servingURL                     string
+	cloudBuild                                task.CloudBuildClient
+	buildBucket                               task.BuildBucketClient // May be nil.
+	swarming                                  task.SwarmingClient
+	signService                               sign.Service
+	publishFile                               func(*workflow.TaskContext, task.WebsiteFile) error
+
+	annMail, goplsAnnMail, vscodeGoAnnMail task.MailHeader
+}
+
+// dryRun returns a copy of d for dry-run workflows. Every client that can
+// write is wrapped to record its writes in trace, or refuse them. There's
+// no GCS client: scratch, signed and serving files are kept under dir.
+func (d *workflowDeps) dryRun(trace *task.DryRunTrace, dir string) (*workflowDeps, error) {
+	dd := *d
+	dd.gerrit = &task.DryRunGerritClient{GerritClient: d.gerrit, Trace: trace}
+	dd.privateGerrit = &task.DryRunGerritClient{GerritClient: d.privateGerrit, Trace: trace}
+	dd.modproxyTestGerrit = &task.DryRunGerritClient{GerritClient: d.modproxyTestGerrit, Trace: trace}
+	dd.gerritHTTP = &http.Client{Transport: &task.DryRunTransport{Base: d.gerritHTTP.Transport, Trace: trace}}
+	git := *d.git
+	git.DryRun = trace
+	dd.git = &git
+	dd.github = &task.DryRunGitHubClient{GitHubClientInterface: d.github, Trace: trace}
+	dd.sendMail = trace.SendMail
+	dd.twitter = &task.DryRunPoster{Service: "twitter", Trace: trace}
+	dd.mastodon = &task.DryRunPoster{Service: "mastodon", Trace: trace}
+	dd.bluesky = &task.DryRunPoster{Service: "bluesky", Trace: trace}
+	dd.gcs = nil
+	for _, sub := range []string{"scratch", "signed", "serving"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, err
+		}
+	}
+	dd.scratchFS = &task.ScratchFS{BaseURL: "file://" + filepath.ToSlash(filepath.Join(dir, "scratch"))}
+	dd.signedURL = "file://" + filepath.ToSlash(filepath.Join(dir, "signed"))
+	dd.servingURL = "file://" + filepath.ToSlash(filepath.Join(dir, "serving"))
+	dd.cloudBuild = &task.DryRunCloudBuildClient{CloudBuildClient: d.cloudBuild, Trace: trace}
+	if d.buildBucket != nil {
+		dd.buildBucket = &task.DryRunBuildBucketClient{BuildBucketClient: d.buildBucket, Trace: trace}
+	}
+	dd.swarming = &task.DryRunSwarmingClient{SwarmingClient: d.swarming, Trace: trace}
+	dd.signService = &task.DryRunSignService{SignService: d.signService, Trace: trace}
+	dd.publishFile = trace.PublishFile
+	return &dd, nil
+}
+
+// registerWorkflows registers all of relui's workflows in dh,
+// using the clients in d. Child workflows are run by w.
+func registerWorkflows(ctx context.Context, dh *relui.DefinitionHolder, w *relui.Worker, d *workflowDeps) error {
+	commTasks := task.CommunicationTasks{
+		SecurityCommunicationTasks: task.SecurityCommunicationTasks{
+			PrivateGerrit: d.privateGerrit,
 		},
-		SignedURL:         *signedFilesBase,
-		ServingURL:        *servingFilesBase,
-		DownloadURL:       *edgeCacheURL,
-		ProxyPrefix:       "https://proxy.golang.org/golang.org/toolchain/@v",
-		CloudBuildClient:  cloudBuildClient,
-		BuildBucketClient: buildBucketClient,
-		SwarmingClient: &task.RealSwarmingClient{
-			SwarmingClient: swarmingClient,
-			SwarmingURL:    *swarmingURL,
-			ServiceAccount: *swarmingAccount,
-			Realm:          *swarmingRealm,
-			Pool:           *swarmingPool,
+		AnnounceMailTasks: task.AnnounceMailTasks{
+			SendMail:           d.sendMail,
+			AnnounceMailHeader: d.annMail,
 		},
-		GoogleDockerBuildProject: "symbolic-datum-552",
-		GoogleDockerBuildTrigger: "golang-publish-internal-boringcrypto",
-		PublishFile: func(f task.WebsiteFile) error {
-			return publishFile(*websiteUploadURL, userPassAuth, f)
+		SocialMediaTasks: task.SocialMediaTasks{
+			TwitterClient:  d.twitter,
+			MastodonClient: d.mastodon,
+			BlueskyClient:  d.bluesky,
 		},
-		ApproveAction: relui.ApproveActionDep(dbPool),
 	}
-	githubHTTPClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: *githubToken}))
-	githubClient := &task.GitHubClient{
-		V3: github.NewClient(githubHTTPClient),
-		V4: githubv4.NewClient(githubHTTPClient),
+	buildTasks := &relui.BuildReleaseTasks{
+		GerritClient:             d.gerrit,
+		GerritProject:            "go",
+		GerritHTTPClient:         d.gerritHTTP,
+		PrivateGerritClient:      d.privateGerrit,
+		PrivateGerritProject:     "go",
+		Git:                      d.git,
+		SignService:              d.signService,
+		GCSClient:                d.gcs,
+		ScratchFS:                d.scratchFS,
+		SignedURL:                d.signedURL,
+		ServingURL:               d.servingURL,
+		DownloadURL:              *edgeCacheURL,
+		ProxyPrefix:              "https://proxy.golang.org/golang.org/toolchain/@v",
+		CloudBuildClient:         d.cloudBuild,
+		BuildBucketClient:        d.buildBucket,
+		SwarmingClient:           d.swarming,
+		GoogleDockerBuildProject: "symbolic-datum-552",
+		GoogleDockerBuildTrigger: "golang-publish-internal-boringcrypto",
+		PublishFile:              d.publishFile,
+		ApproveAction:            relui.ApproveActionDep(d.db),
 	}
 	milestoneTasks := &task.MilestoneTasks{
-		Client:        githubClient,
+		Client:        d.github,
 		RepoOwner:     "golang",
 		RepoName:      "go",
-		ApproveAction: relui.ApproveActionDep(dbPool),
+		ApproveAction: relui.ApproveActionDep(d.db),
 	}
 	versionTasks := &task.VersionTasks{
-		Gerrit:     gerritClient,
-		CloudBuild: cloudBuildClient,
+		Gerrit:     d.gerrit,
+		CloudBuild: d.cloudBuild,
 		GoProject:  "go",
 		GoDirectiveXReposTasks: task.GoDirectiveXReposTasks{
-			Gerrit:     gerritClient,
-			CloudBuild: cloudBuildClient,
+			Gerrit:     d.gerrit,
+			CloudBuild: d.cloudBuild,
 		},
 		UpdateProxyTestRepoTasks: task.UpdateProxyTestRepoTasks{
-			Gerrit:  modproxyTestGerritClient,
+			Gerrit:  d.modproxyTestGerrit,
 			Project: "latest-go-version", Branch: "main",
 			ChangeLink: func(changeID string) string {
 				parts := strings.SplitN(changeID, "~", 3)
@@ -336,12 +505,12 @@ func main() {
 		},
 	}
 	cycleTasks := task.ReleaseCycleTasks{
-		Gerrit:        gerritClient,
-		GitHub:        githubClient,
-		ApproveAction: relui.ApproveActionDep(dbPool),
+		Gerrit:        d.gerrit,
+		GitHub:        d.github,
+		ApproveAction: relui.ApproveActionDep(d.db),
 	}
-	if err := relui.RegisterReleaseWorkflows(ctx, dh, buildTasks, milestoneTasks, versionTasks, cycleTasks, commTasks); err != nil {
-		log.Fatalf("RegisterReleaseWorkflows: %v", err)
+	if err := relui.RegisterReleaseWorkflows(ctx, dh, w, buildTasks, milestoneTasks, versionTasks, cycleTasks, commTasks); err != nil {
+		return fmt.Errorf("RegisterReleaseWorkflows: %v", err)
 	}
 
 	ignoreProjects := map[string]bool{}
@@ -350,16 +519,16 @@ func main() {
 	}
 	tagTasks := &task.TagXReposTasks{
 		IgnoreProjects: ignoreProjects,
-		Gerrit:         gerritClient,
-		CloudBuild:     cloudBuildClient,
-		BuildBucket:    buildBucketClient,
+		Gerrit:         d.gerrit,
+		CloudBuild:     d.cloudBuild,
+		BuildBucket:    d.buildBucket,
 	}
 	dh.RegisterDefinition("Tag x/ repos", tagTasks.NewDefinition())
 	dh.RegisterDefinition("Tag a single x/ repo", tagTasks.NewSingleDefinition())
 
 	bundleTasks := &task.BundleNSSRootsTask{
-		Gerrit:     gerritClient,
-		CloudBuild: cloudBuildClient,
+		Gerrit:     d.gerrit,
+		CloudBuild: d.cloudBuild,
 	}
 	dh.RegisterDefinition("Update x/crypto NSS root bundle", bundleTasks.NewDefinition())
 
@@ -367,108 +536,67 @@ func main() {
 	dh.RegisterDefinition("Announce blog post", relui.NewAnnounceBlogPostWorkflow(commTasks.SocialMediaTasks))
 
 	releaseVSCodeGoTasks := task.ReleaseVSCodeGoTasks{
-		GitHub:             githubClient,
-		Gerrit:             gerritClient,
-		CloudBuild:         cloudBuildClient,
-		ApproveAction:      relui.ApproveActionDep(dbPool),
-		SendMail:           mailFunc,
-		AnnounceMailHeader: vscodeGoAnnMail,
+		GitHub:             d.github,
+		Gerrit:             d.gerrit,
+		CloudBuild:         d.cloudBuild,
+		ApproveAction:      relui.ApproveActionDep(d.db),
+		SendMail:           d.sendMail,
+		AnnounceMailHeader: d.vscodeGoAnnMail,
 	}
 	dh.RegisterDefinition("Create a vscode-go release candidate", releaseVSCodeGoTasks.NewPrereleaseDefinition())
-	dh.RegisterDefinition("Release a vscode-go stable version", releaseVSCodeGoTasks.NewReleaseDefinition())
+	dh.RegisterDefinition(relui.VSCodeGoReleaseWorkflow, releaseVSCodeGoTasks.NewReleaseDefinition())
 	dh.RegisterDefinition("Release a vscode-go insider version", releaseVSCodeGoTasks.NewInsiderDefinition())
 
 	tagTelemetryTasks := &task.TagTelemetryTasks{
-		Gerrit:     gerritClient,
-		CloudBuild: cloudBuildClient,
+		Gerrit:     d.gerrit,
+		CloudBuild: d.cloudBuild,
 	}
 	dh.RegisterDefinition("Tag a new version of x/telemetry/config (if necessary)", tagTelemetryTasks.NewDefinition())
 
 	goplsTasks := task.ReleaseGoplsTasks{
-		GitHub:             githubClient,
-		Gerrit:             gerritClient,
-		CloudBuild:         cloudBuildClient,
-		SendMail:           mailFunc,
-		AnnounceMailHeader: goplsAnnMail,
-		ApproveAction:      relui.ApproveActionDep(dbPool),
+		GitHub:             d.github,
+		Gerrit:             d.gerrit,
+		CloudBuild:         d.cloudBuild,
+		SendMail:           d.sendMail,
+		AnnounceMailHeader: d.goplsAnnMail,
+		ApproveAction:      relui.ApproveActionDep(d.db),
 	}
 	dh.RegisterDefinition("Prepare a pre-release gopls candidate", goplsTasks.NewPrereleaseDefinition())
-	dh.RegisterDefinition("Release gopls", goplsTasks.NewReleaseDefinition())
+	dh.RegisterDefinition(relui.GoplsReleaseWorkflow, goplsTasks.NewReleaseDefinition())
 
 	govulncheckActionTasks := task.ReleaseGovulncheckActionTasks{
-		Gerrit:        gerritClient,
-		GitHub:        githubClient,
-		ApproveAction: relui.ApproveActionDep(dbPool),
+		Gerrit:        d.gerrit,
+		GitHub:        d.github,
+		ApproveAction: relui.ApproveActionDep(d.db),
 	}
 	dh.RegisterDefinition("Tag govulncheck-action", govulncheckActionTasks.NewDefinition())
 
 	privateSyncTask := &task.PrivateMasterSyncTask{
-		Git:              gitClient,
+		Git:              d.git,
 		PrivateGerritURL: "https://go-internal.googlesource.com/golang/go-private",
 		Ref:              "public",
 	}
 	dh.RegisterDefinition("Sync go-private master branch with public", privateSyncTask.NewDefinition())
 
 	privateXPatchTask := &task.PrivXPatch{
-		Git:           gitClient,
-		PublicGerrit:  gerritClient,
-		PrivateGerrit: privateGerritClient,
+		Git:           d.git,
+		PublicGerrit:  d.gerrit,
+		PrivateGerrit: d.privateGerrit,
 		PublicRepoURL: func(repo string) string {
 			return "https://go.googlesource.com/" + repo
 		},
-		ApproveAction:      relui.ApproveActionDep(dbPool),
-		SendMail:           mailFunc,
-		AnnounceMailHeader: annMail,
+		ApproveAction:      relui.ApproveActionDep(d.db),
+		SendMail:           d.sendMail,
+		AnnounceMailHeader: d.annMail,
 	}
 	dh.RegisterDefinition("Publish a private patch to a x/ repo", privateXPatchTask.NewDefinition(tagTasks))
 
 	securityReleaseCoalesceTask := &task.SecurityReleaseCoalesceTask{
-		PrivateGerrit: privateGerritClient,
+		PrivateGerrit: d.privateGerrit,
 		Version:       versionTasks,
 	}
 	dh.RegisterDefinition("Prepare internal security release branches", securityReleaseCoalesceTask.NewDefinition())
-
-	var base *url.URL
-	if *baseURL != "" {
-		base, err = url.Parse(*baseURL)
-		if err != nil {
-			log.Fatalf("url.Parse(%q) = %v, %v", *baseURL, base, err)
-		}
-	}
-	l := &relui.PGListener{
-		DB:                        dbPool,
-		BaseURL:                   base,
-		ScheduleFailureMailHeader: schedMail,
-		SendMail:                  nil, // TODO(go.dev/issue/74777): Restore email notifications about workflow failures.
-	}
-	w := relui.NewWorker(dh, dbPool, l)
-	go w.Run(ctx)
-	if err := w.ResumeAll(ctx); err != nil {
-		log.Printf("w.ResumeAll() = %v", err)
-	}
-	var prod bool // are we operating from symbolic-datum-552
-	var criaDB *criadb.AuthDatabase
-	if metadata.OnGCE() {
-		project, err := metadata.ProjectID()
-		if err != nil {
-			log.Fatalln("failed to read project ID from metadata server")
-		}
-		prod = project == "symbolic-datum-552"
-		if prod {
-			criaDB, err = criadb.NewDatabase(*criaService)
-			if err != nil {
-				log.Fatalf("failed to create cria authdb: %s", err)
-			}
-		}
-	} else {
-		criaDB = criadb.NewDevDatabase()
-	}
-	var h http.Handler = relui.NewServer(dbPool, w, base, siteHeader, ms, criaDB)
-	if prod {
-		iapAudience := buildenv.Production.IAPServiceAudience("relui-internal")
-		h = access.RequireIAPAuthHandler(h, iapAudience)
-	}
-	log.Fatalln(https.ListenAndServe(ctx, &ochttp.Handler{Handler: GRPCHandler(grpcServer, h)}))
+	return nil
 }
 
 // GRPCHandler creates handler which intercepts requests intended for a GRPC server and directs the calls to the server.
@@ -0,0 +1,137 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+// Schedsim replays the scheduling traces recorded by the coordinator
+// with its -sched_trace flag under different scheduling policies, and
+// reports how long each class of work waited for buildlets.
+//
+// For example, to compare the default policy with a fair-share policy
+// preempting work for callers waiting longer than five minutes, with
+// only two buildlets of a reverse host type:
+//
+//	schedsim -policy=priority,fairshare -preempt-after=5m \
+//		-capacity=host-darwin-arm64-reverse=2 sched.trace
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/build/internal/coordinator/schedule"
+)
+
+var (
+	flagPolicy       = flag.String("policy", "priority,fairshare", "comma-separated list of the `policies` to replay the trace with: priority or fairshare")
+	flagCapacity     = flag.String("capacity", "", "comma-separated `host-type=N` buildlet counts; host types not listed have as many buildlets as were in use at once in the trace")
+	flagWeights      = flag.String("weights", "", "comma-separated `class=weight` shares of the classes of work with the fairshare policy; default gomote=4,try=2,post-submit=1")
+	flagHalfLife     = flag.Duration("half-life", schedule.DefaultHalfLife, "half-life of past usage with the fairshare policy")
+	flagPreemptAfter = flag.Duration("preempt-after", 0, "how long work waits before preempting other work with the fairshare policy; zero disables preemption")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: schedsim [flags] trace-file\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("schedsim: ")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() != 1 {
+		usage()
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	trace, err := schedule.ReadTrace(f)
+	f.Close()
+	if err != nil {
+		log.Fatalf("reading %s: %v", flag.Arg(0), err)
+	}
+	capacity, err := parseCounts(*flagCapacity)
+	if err != nil {
+		log.Fatalf("-capacity: %v", err)
+	}
+	weights, err := parseWeights(*flagWeights)
+	if err != nil {
+		log.Fatalf("-weights: %v", err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "POLICY\tCLASS\tSTARTS\tMEAN WAIT\tMAX WAIT\n")
+	for _, name := range strings.Split(*flagPolicy, ",") {
+		var p schedule.Policy
+		switch name {
+		case "priority":
+			p = schedule.PriorityPolicy{}
+		case "fairshare":
+			p = &schedule.FairSharePolicy{
+				Weights:      weights,
+				HalfLife:     *flagHalfLife,
+				PreemptAfter: *flagPreemptAfter,
+			}
+		default:
+			log.Fatalf("unknown policy %q", name)
+		}
+		res, err := schedule.Simulate(trace, capacity, p)
+		if err != nil {
+			log.Fatalf("simulating %s: %v", name, err)
+		}
+		for _, c := range slices.Sorted(maps.Keys(res.Classes)) {
+			ws := res.Classes[c]
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%v\t%v\n", name, c, ws.Count, ws.Mean().Round(time.Second), ws.Max.Round(time.Second))
+		}
+		fmt.Fprintf(tw, "%s\t(%d preempted, %d canceled)\t\t\t\n", name, res.Preemptions, res.Canceled)
+	}
+	tw.Flush()
+}
+
+// parseCounts parses comma-separated key=N pairs.
+func parseCounts(s string) (map[string]int, error) {
+	m := make(map[string]int)
+	for kv := range strings.SplitSeq(s, ",") {
+		if kv == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(kv, "=")
+		n, err := strconv.Atoi(v)
+		if !ok || err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid count %q", kv)
+		}
+		m[k] = n
+	}
+	return m, nil
+}
+
+// parseWeights parses comma-separated class=weight pairs. It returns nil
+// for an empty string.
+func parseWeights(s string) (map[schedule.Class]float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	m := make(map[schedule.Class]float64)
+	for kv := range strings.SplitSeq(s, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		w, err := strconv.ParseFloat(v, 64)
+		if !ok || err != nil || w <= 0 {
+			return nil, fmt.Errorf("invalid weight %q", kv)
+		}
+		m[schedule.Class(k)] = w
+	}
+	return m, nil
+}
@@ -7,6 +7,7 @@
 package dashboard
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"slices"
@@ -254,11 +255,6 @@ var Hosts = map[string]*HostConfig{
 			"GOROOT_BOOTSTRAP=/var/root/go-ios-arm64-bootstrap",
 		},
 	},
-	"host-linux-amd64-alpine": {
-		Notes:          "Alpine container",
-		ContainerImage: "linux-x86-alpine:latest",
-		SSHUsername:    "root",
-	},
 	"host-linux-amd64-androidemu": {
 		Notes:          "Debian Bullseye w/ Android SDK + emulator (use nested virt)",
 		ContainerImage: "android-amd64-emu:bff27c0c9263",
@@ -584,42 +580,51 @@ func gh(githubUsername string) *gophers.Person {
 
 func init() {
 	for key, c := range Hosts {
-		if key == "" {
-			panic("empty string key in Hosts")
-		}
-		if c.HostType == "" {
-			c.HostType = key
-		}
-		if c.HostType != key {
-			panic(fmt.Sprintf("HostType %q != key %q", c.HostType, key))
-		}
-		if c.HostArch == "" {
-			f := strings.Split(c.HostType, "-")
-			if len(f) < 3 {
-				panic(fmt.Sprintf("invalid HostType %q", c.HostType))
-			}
-			c.HostArch = f[1] + "-" + f[2] // "linux-amd64"
-			if f[2] == "arm" {
-				c.HostArch += "-7" // assume newer ARM
-			}
-		}
-		if c.GoBootstrap == "" {
-			c.GoBootstrap = GoBootstrap
+		if err := initHost(key, c); err != nil {
+			panic(err)
 		}
-		nSet := 0
-		if c.VMImage != "" {
-			nSet++
-		}
-		if c.ContainerImage != "" && !c.IsEC2 {
-			nSet++
-		}
-		if c.IsReverse {
-			nSet++
+	}
+}
+
+// initHost fills in the defaults of the host config c with the given key
+// in Hosts, and checks that it's consistent.
+func initHost(key string, c *HostConfig) error {
+	if key == "" {
+		return errors.New("empty string key in Hosts")
+	}
+	if c.HostType == "" {
+		c.HostType = key
+	}
+	if c.HostType != key {
+		return fmt.Errorf("HostType %q != key %q", c.HostType, key)
+	}
+	if c.HostArch == "" {
+		f := strings.Split(c.HostType, "-")
+		if len(f) < 3 {
+			return fmt.Errorf("invalid HostType %q", c.HostType)
 		}
-		if nSet != 1 {
-			panic(fmt.Sprintf("exactly one of VMImage, ContainerImage, IsReverse must be set for host %q; got %v", key, nSet))
+		c.HostArch = f[1] + "-" + f[2] // "linux-amd64"
+		if f[2] == "arm" {
+			c.HostArch += "-7" // assume newer ARM
 		}
 	}
+	if c.GoBootstrap == "" {
+		c.GoBootstrap = GoBootstrap
+	}
+	nSet := 0
+	if c.VMImage != "" {
+		nSet++
+	}
+	if c.ContainerImage != "" && !c.IsEC2 {
+		nSet++
+	}
+	if c.IsReverse {
+		nSet++
+	}
+	if nSet != 1 {
+		return fmt.Errorf("exactly one of VMImage, ContainerImage, IsReverse must be set for host %q; got %v", key, nSet)
+	}
+	return nil
 }
 
 // CosArch defines the different COS images types used.
@@ -876,6 +881,12 @@ type BuildConfig struct {
 
 	// isRestricted marks if a builder should be restricted to a subset of users.
 	isRestricted bool
+
+	// stopped marks a builder stopped in the coordinator, whose buildsRepo
+	// policy addBuilder replaced with one that builds nothing.
+	// declaredBuildsRepo is the policy it was declared with.
+	stopped            bool
+	declaredBuildsRepo func(repo, branch, goBranch string) bool
 }
 
 // Env returns the environment variables this builder should run with.
@@ -1138,6 +1149,21 @@ func (c *BuildConfig) AllScript() string {
 
 func (c *BuildConfig) IsTryOnly() bool { return c.tryOnly }
 
+// IsStopped reports whether the builder is stopped in the coordinator,
+// so that it builds nothing. See golang.org/x/build/internal/migration.
+func (c *BuildConfig) IsStopped() bool { return c.stopped }
+
+// Declared returns c as it was declared, building the repos and branches
+// of its policy even if it's stopped in the coordinator.
+func (c *BuildConfig) Declared() *BuildConfig {
+	if !c.stopped {
+		return c
+	}
+	d := *c
+	d.buildsRepo, d.stopped, d.declaredBuildsRepo = c.declaredBuildsRepo, false, nil
+	return &d
+}
+
 // PrivateGoProxy for a builder has its own Go proxy instead of proxy.golang.org
 func (c *BuildConfig) PrivateGoProxy() bool { return c.privateGoProxy }
 
@@ -1562,15 +1588,6 @@ func init() {
 		numTestHelpers:    1,
 		numTryTestHelpers: 3,
 	})
-	addBuilder(BuildConfig{
-		Name:  "linux-386-softfloat",
-		Notes: "GO386=softfloat",
-		buildsRepo: func(repo, branch, goBranch string) bool {
-			return repo == "go" || repo == "crypto"
-		},
-		HostType: "host-linux-amd64-bullseye",
-		env:      []string{"GOARCH=386", "GOHOSTARCH=386", "GO386=softfloat"},
-	})
 	addBuilder(BuildConfig{
 		Name:       "linux-amd64",
 		HostType:   "host-linux-amd64-bullseye",
@@ -1594,15 +1611,6 @@ func init() {
 		numTestHelpers:    1,
 		numTryTestHelpers: 4,
 	})
-	addBuilder(BuildConfig{
-		Name:       "linux-amd64-vmx",
-		HostType:   "host-linux-amd64-bullseye-vmx",
-		buildsRepo: disabledBuilder,
-	})
-	addBuilder(BuildConfig{
-		Name:     "linux-amd64-alpine",
-		HostType: "host-linux-amd64-alpine",
-	})
 
 	// addMiscCompileGo1 adds a misc-compile TryBot that
 	// runs buildall.bash on the specified target ("$goos-$goarch").
@@ -2862,49 +2870,64 @@ func init() {
 
 // addBuilder adds c to the Builders map after doing some checks.
 func addBuilder(c BuildConfig) {
+	if err := checkBuilder(&c, Builders, Hosts); err != nil {
+		panic(err)
+	}
+
+	if migration.BuildersPortedToLUCI[c.Name] && migration.StopPortedBuilder {
+		c.stopped, c.declaredBuildsRepo = true, c.buildsRepo
+		c.buildsRepo = func(_, _, _ string) bool { return false }
+		c.Notes = "Unavailable in the coordinator. Use LUCI (https://go.dev/wiki/LUCI) instead."
+	} else if migration.StopAllLegacyBuilders {
+		c.stopped, c.declaredBuildsRepo = true, c.buildsRepo
+		c.buildsRepo = func(_, _, _ string) bool { return false }
+		c.Notes = "Unavailable in the coordinator. Look for a tracking issue to add this builder to LUCI (https://go.dev/wiki/LUCI) instead."
+	}
+
+	Builders[c.Name] = &c
+}
+
+// checkBuilder checks that c can be added to builders, with its host
+// type defined in hosts.
+func checkBuilder(c *BuildConfig, builders map[string]*BuildConfig, hosts map[string]*HostConfig) error {
 	if c.Name == "" {
-		panic("empty name")
+		return errors.New("empty name")
 	}
 	if c.HostType == "" {
-		panic(fmt.Sprintf("missing HostType for builder %q", c.Name))
+		return fmt.Errorf("missing HostType for builder %q", c.Name)
+	}
+	if _, dup := builders[c.Name]; dup {
+		return fmt.Errorf("dup name %s", c.Name)
 	}
-	if _, dup := Builders[c.Name]; dup {
-		panic("dup name " + c.Name)
+	hc, ok := hosts[c.HostType]
+	if !ok {
+		return fmt.Errorf("undefined HostType %q for builder %q", c.HostType, c.Name)
 	}
-	if c.HostConfig().GoogleReverse && !c.IsReverse() {
-		panic("GoogleReverse is set but the builder isn't reverse")
+	if c.TestHostConf != nil {
+		hc = c.TestHostConf
 	}
-	if _, ok := Hosts[c.HostType]; !ok {
-		panic(fmt.Sprintf("undefined HostType %q for builder %q", c.HostType, c.Name))
+	if hc.GoogleReverse && !hc.IsReverse {
+		return fmt.Errorf("GoogleReverse is set but the builder %q isn't reverse", c.Name)
 	}
 	if c.SkipSnapshot && (c.numTestHelpers > 0 || c.numTryTestHelpers > 0) {
-		panic(fmt.Sprintf("config %q's SkipSnapshot is not compatible with sharded test helpers", c.Name))
+		return fmt.Errorf("config %q's SkipSnapshot is not compatible with sharded test helpers", c.Name)
 	}
 	for i, issue := range c.KnownIssues {
 		if issue == 0 {
-			panic(fmt.Errorf("config %q's KnownIssues slice has a zero issue at index %d", c.Name, i))
+			return fmt.Errorf("config %q's KnownIssues slice has a zero issue at index %d", c.Name, i)
 		}
 	}
 
 	types := 0
-	for _, fn := range []func() bool{c.IsReverse, c.IsContainer, c.IsVM} {
-		if fn() {
+	for _, b := range []bool{hc.IsReverse, hc.IsContainer(), hc.IsVM()} {
+		if b {
 			types++
 		}
 	}
 	if types != 1 {
-		panic(fmt.Sprintf("build config %q host type inconsistent (must be Reverse, Image, or VM)", c.Name))
-	}
-
-	if migration.BuildersPortedToLUCI[c.Name] && migration.StopPortedBuilder {
-		c.buildsRepo = func(_, _, _ string) bool { return false }
-		c.Notes = "Unavailable in the coordinator. Use LUCI (https://go.dev/wiki/LUCI) instead."
-	} else if migration.StopAllLegacyBuilders {
-		c.buildsRepo = func(_, _, _ string) bool { return false }
-		c.Notes = "Unavailable in the coordinator. Look for a tracking issue to add this builder to LUCI (https://go.dev/wiki/LUCI) instead."
+		return fmt.Errorf("build config %q host type inconsistent (must be Reverse, Image, or VM)", c.Name)
 	}
-
-	Builders[c.Name] = &c
+	return nil
 }
 
 // TestingKnobForceEnableLinuxAMD64 is a helper intended to be used in tests
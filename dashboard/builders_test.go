@@ -1176,3 +1176,21 @@ func listPorts() ([]string, error) {
 	}
 	return strings.Fields(string(out)), nil
 }
+
+func TestDeclared(t *testing.T) {
+	bc := Builders["linux-386-softfloat"]
+	stopped := migration.BuildersPortedToLUCI[bc.Name] && migration.StopPortedBuilder || migration.StopAllLegacyBuilders
+	if bc.IsStopped() != stopped {
+		t.Errorf("IsStopped() = %v; want %v", bc.IsStopped(), stopped)
+	}
+	d := bc.Declared()
+	if d.IsStopped() {
+		t.Errorf("Declared().IsStopped() = true")
+	}
+	if !d.BuildsRepoPostSubmit("crypto", "master", "master") || d.BuildsRepoPostSubmit("net", "master", "master") {
+		t.Errorf("Declared() doesn't build what linux-386-softfloat is declared to build")
+	}
+	if got := bc.BuildsRepoPostSubmit("crypto", "master", "master"); got == stopped {
+		t.Errorf("BuildsRepoPostSubmit(crypto) = %v with the builder stopped %v", got, stopped)
+	}
+}
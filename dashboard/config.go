@@ -0,0 +1,469 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dashboard
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/build/internal/gophers"
+	"golang.org/x/build/types"
+	"gopkg.in/yaml.v3"
+)
+
+// buildersYAML is the declarative part of the Hosts and Builders tables.
+// New builders should be defined there rather than in Go.
+//
+// The migration to it is partial. So far only a few builders have moved:
+// the softfloat, VMX and Alpine linux builders, and the Alpine host type.
+// The rest, about a hundred builders and most host types, are still Go
+// literals in builders.go. About half of those builders decide what they
+// build with buildsRepo, tryBot or distTestAdjust funcs. Many of the funcs
+// could be stated as RepoPolicy rules as they are, but some build other
+// repos on other branches than Go, or skip dist tests by substring, which
+// a Config can't express yet.
+//
+// What's next: move the builders that need no funcs, or whose funcs are
+// RepoPolicy rules, a few hosts at a time, checking with
+// cmd/builderconfig that each move builds the same repos and branches as
+// the Go it replaces; then let a builder have a RepoPolicy per group of
+// repos and more general dist test patterns, and move the rest; and
+// finally remove the Go literals and the func fields of BuildConfig.
+//
+//go:embed builders.yaml
+var buildersYAML []byte
+
+func init() {
+	c, err := ParseConfig(buildersYAML)
+	if err != nil {
+		panic(fmt.Sprintf("builders.yaml: %v", err))
+	}
+	if err := AddConfig(c); err != nil {
+		panic(fmt.Sprintf("builders.yaml: %v", err))
+	}
+}
+
+// A Config is a declarative definition of host and builder configs,
+// usually read from a YAML file such as builders.yaml by ParseConfig.
+//
+// Its builders can use the host types it defines and those in Hosts.
+// Unlike the buildsRepo and tryBot funcs of BuildConfig, which builds
+// a builder does is declared by RepoPolicy rules, so that the effect of
+// a change can be checked without reading Go code.
+type Config struct {
+	Hosts    []*HostDef    `yaml:"hosts"`
+	Builders []*BuilderDef `yaml:"builders"`
+}
+
+// A HostDef is the definition of a HostConfig in a Config.
+// See HostConfig for the meaning of its fields.
+type HostDef struct {
+	Name string `yaml:"name"` // the host type, such as "host-linux-amd64-bullseye"
+
+	HostArch    string `yaml:"host_arch"`
+	GoBootstrap string `yaml:"go_bootstrap"`
+
+	// Exactly one of VMImage, ContainerImage and Reverse must be set,
+	// except that EC2 hosts may set both VMImage and ContainerImage.
+	VMImage        string `yaml:"vm_image"`
+	ContainerImage string `yaml:"container_image"`
+	Reverse        bool   `yaml:"reverse"`
+
+	MachineType     string `yaml:"machine_type"`
+	RegularDisk     bool   `yaml:"regular_disk"`
+	MinCPUPlatform  string `yaml:"min_cpu_platform"`
+	CosArchitecture string `yaml:"cos_architecture"` // "cos-101-lts" (the default) or "cos-arm64-stable"
+	RootDriveSizeGB int64  `yaml:"root_drive_size_gb"`
+	NestedVirt      bool   `yaml:"nested_virt"`
+	KonletVMImage   string `yaml:"konlet_vm_image"`
+
+	EC2                 bool          `yaml:"ec2"`
+	CustomDeleteTimeout time.Duration `yaml:"custom_delete_timeout"` // such as "45m"
+
+	ExpectNum       int  `yaml:"expect_num"`
+	HermeticReverse bool `yaml:"hermetic_reverse"`
+	GoogleReverse   bool `yaml:"google_reverse"`
+
+	Env         []string `yaml:"env"`
+	Owners      []string `yaml:"owners"` // GitHub usernames; empty means golang-dev
+	Notes       string   `yaml:"notes"`
+	SSHUsername string   `yaml:"ssh_username"`
+}
+
+// A BuilderDef is the definition of a BuildConfig in a Config.
+// See BuildConfig for the meaning of its fields.
+type BuilderDef struct {
+	Name        string `yaml:"name"`
+	Host        string `yaml:"host"` // the host type
+	Notes       string `yaml:"notes"`
+	KnownIssues []int  `yaml:"known_issues"`
+
+	// Repos is the policy of which repos and branches the builder
+	// builds, both post-submit and as a trybot. If nil, it builds the
+	// repos built by default on all branches.
+	Repos *RepoPolicy `yaml:"repos"`
+	// TryBot is the policy of which of those repos and branches the
+	// builder builds as a trybot. If nil, it isn't a trybot.
+	TryBot  *RepoPolicy `yaml:"trybot"`
+	TryOnly bool        `yaml:"try_only"`
+
+	// MinGo is the minimum Go version, such as "1.22", the builder
+	// is allowed to use.
+	MinGo string `yaml:"min_go"`
+
+	// SkipDistTests and SkipTryDistTests list the cmd/dist tests,
+	// in the Go 1.20 format, the builder doesn't run at all or in
+	// normal trybot runs respectively. A trailing "*" matches any
+	// suffix, as in "test:*".
+	SkipDistTests    []string `yaml:"skip_dist_tests"`
+	SkipTryDistTests []string `yaml:"skip_try_dist_tests"`
+
+	CompileOnly         bool     `yaml:"compile_only"`
+	FlakyNet            bool     `yaml:"flaky_net"`
+	RunBench            bool     `yaml:"run_bench"`
+	SkipSnapshot        bool     `yaml:"skip_snapshot"`
+	StopAfterMake       bool     `yaml:"stop_after_make"`
+	PrivateGoProxy      bool     `yaml:"private_go_proxy"`
+	InstallRacePackages []string `yaml:"install_race_packages"`
+	GoDeps              []string `yaml:"go_deps"`
+	TestHelpers         int      `yaml:"test_helpers"`
+	TryTestHelpers      int      `yaml:"try_test_helpers"`
+	Env                 []string `yaml:"env"`
+	MakeScriptArgs      []string `yaml:"make_script_args"`
+	AllScriptArgs       []string `yaml:"all_script_args"`
+	Restricted          bool     `yaml:"restricted"`
+}
+
+// A RepoPolicy declares which repos ("go", "net", etc.) and branches a
+// builder builds.
+//
+// A repo is built if it's matched by Include, or if Default is set and
+// it's built by default, unless it's matched by Exclude. Then its branch
+// must match Branches and the branch of Go must match GoBranches and be
+// within MinGo and MaxGo.
+//
+// The repos and branches are patterns that match exactly, or, if they end
+// in "*", match any string with the prefix before the "*".
+type RepoPolicy struct {
+	Default bool     `yaml:"default"` // build the repos built by default, such as "go", "net" and "tools"
+	Include []string `yaml:"include"` // repos to build
+	Exclude []string `yaml:"exclude"` // repos not to build
+
+	Branches   []string `yaml:"branches"`    // branches of the repo to build; none means all
+	GoBranches []string `yaml:"go_branches"` // branches of Go to build against; none means all
+
+	// MinGo and MaxGo, such as "1.22", bound the release branches of
+	// Go to build against. With MinGo, master and dev branches are
+	// built; with MaxGo, they aren't.
+	MinGo string `yaml:"min_go"`
+	MaxGo string `yaml:"max_go"`
+}
+
+// ParseConfig parses a Config in YAML. Fields that aren't part of the
+// format are an error.
+func ParseConfig(data []byte) (*Config, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	c := new(Config)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Compile returns the host and build configs that c defines, after
+// checking them. Its builders' host types must be defined by c or in
+// Hosts. The error reports all the problems found.
+//
+// Compile doesn't check for conflicts with Hosts and Builders, and the
+// build configs it returns build what they declare even if the builders
+// have been stopped in the coordinator, so that their coverage can be
+// compared by DiffCoverage.
+func (c *Config) Compile() (map[string]*HostConfig, map[string]*BuildConfig, error) {
+	var errs []error
+	hosts := make(map[string]*HostConfig)
+	for _, hd := range c.Hosts {
+		hc, err := hd.compile()
+		if err == nil {
+			if _, dup := hosts[hd.Name]; dup {
+				err = errors.New("defined more than once")
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("host %q: %v", hd.Name, err))
+			continue
+		}
+		hosts[hd.Name] = hc
+	}
+
+	allHosts := maps.Clone(Hosts)
+	maps.Copy(allHosts, hosts)
+	builders := make(map[string]*BuildConfig)
+	for _, bd := range c.Builders {
+		bc, err := bd.compile()
+		if err == nil {
+			err = checkBuilder(bc, builders, allHosts)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("builder %q: %v", bd.Name, err))
+			continue
+		}
+		builders[bd.Name] = bc
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+	return hosts, builders, nil
+}
+
+// AddConfig adds the host and build configs that c defines to Hosts and
+// Builders. It reports an error, and adds nothing, if c has problems or
+// redefines existing hosts or builders.
+func AddConfig(c *Config) error {
+	hosts, builders, err := c.Compile()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for k := range hosts {
+		if _, dup := Hosts[k]; dup {
+			errs = append(errs, fmt.Errorf("host %q: already defined", k))
+		}
+	}
+	for k := range builders {
+		if _, dup := Builders[k]; dup {
+			errs = append(errs, fmt.Errorf("builder %q: already defined", k))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	maps.Copy(Hosts, hosts)
+	for _, bd := range c.Builders {
+		addBuilder(*builders[bd.Name])
+	}
+	return nil
+}
+
+func (hd *HostDef) compile() (*HostConfig, error) {
+	hc := &HostConfig{
+		HostArch:            hd.HostArch,
+		GoBootstrap:         hd.GoBootstrap,
+		VMImage:             hd.VMImage,
+		ContainerImage:      hd.ContainerImage,
+		IsReverse:           hd.Reverse,
+		machineType:         hd.MachineType,
+		RegularDisk:         hd.RegularDisk,
+		MinCPUPlatform:      hd.MinCPUPlatform,
+		cosArchitecture:     CosArch(hd.CosArchitecture),
+		IsEC2:               hd.EC2,
+		CustomDeleteTimeout: hd.CustomDeleteTimeout,
+		ExpectNum:           hd.ExpectNum,
+		HermeticReverse:     hd.HermeticReverse,
+		GoogleReverse:       hd.GoogleReverse,
+		NestedVirt:          hd.NestedVirt,
+		KonletVMImage:       hd.KonletVMImage,
+		env:                 hd.Env,
+		Notes:               hd.Notes,
+		SSHUsername:         hd.SSHUsername,
+		RootDriveSizeGB:     hd.RootDriveSizeGB,
+	}
+	if err := initHost(hd.Name, hc); err != nil {
+		return nil, err
+	}
+	switch hc.cosArchitecture {
+	case "", CosArchAMD64, CosArchARM64:
+	default:
+		return nil, fmt.Errorf("unknown cos_architecture %q", hd.CosArchitecture)
+	}
+	if err := checkEnv(hd.Env); err != nil {
+		return nil, err
+	}
+	for _, u := range hd.Owners {
+		p := gophers.GetPerson("@" + u)
+		if p == nil {
+			return nil, fmt.Errorf("owner with GitHub username %q does not exist in the golang.org/x/build/internal/gophers package", u)
+		}
+		hc.Owners = append(hc.Owners, p)
+	}
+	return hc, nil
+}
+
+func (bd *BuilderDef) compile() (*BuildConfig, error) {
+	bc := &BuildConfig{
+		Name:                bd.Name,
+		HostType:            bd.Host,
+		KnownIssues:         bd.KnownIssues,
+		Notes:               bd.Notes,
+		tryOnly:             bd.TryOnly,
+		CompileOnly:         bd.CompileOnly,
+		FlakyNet:            bd.FlakyNet,
+		RunBench:            bd.RunBench,
+		SkipSnapshot:        bd.SkipSnapshot,
+		StopAfterMake:       bd.StopAfterMake,
+		privateGoProxy:      bd.PrivateGoProxy,
+		InstallRacePackages: bd.InstallRacePackages,
+		GoDeps:              bd.GoDeps,
+		numTestHelpers:      bd.TestHelpers,
+		numTryTestHelpers:   bd.TryTestHelpers,
+		env:                 bd.Env,
+		makeScriptArgs:      bd.MakeScriptArgs,
+		allScriptArgs:       bd.AllScriptArgs,
+		isRestricted:        bd.Restricted,
+	}
+	if bd.Name != "" && !strings.Contains(bd.Name, "-") {
+		return nil, errors.New(`name must have the form "GOOS-GOARCH" or "GOOS-GOARCH-suffix"`)
+	}
+	if bd.TestHelpers < 0 || bd.TryTestHelpers < 0 {
+		return nil, errors.New("negative number of test helpers")
+	}
+	if err := checkEnv(bd.Env); err != nil {
+		return nil, err
+	}
+	if bd.MinGo != "" {
+		v, err := parseGo1(bd.MinGo)
+		if err != nil {
+			return nil, fmt.Errorf("min_go: %v", err)
+		}
+		bc.MinimumGoVersion = types.MajorMinor{Major: 1, Minor: v}
+	}
+	if bd.Repos != nil {
+		f, err := bd.Repos.compile()
+		if err != nil {
+			return nil, fmt.Errorf("repos: %v", err)
+		}
+		bc.buildsRepo = f
+	}
+	if bd.TryBot != nil {
+		f, err := bd.TryBot.compile()
+		if err != nil {
+			return nil, fmt.Errorf("trybot: %v", err)
+		}
+		bc.tryBot = f
+	} else if bd.TryOnly {
+		return nil, errors.New("try_only is set but trybot isn't")
+	}
+	if len(bd.SkipDistTests) > 0 || len(bd.SkipTryDistTests) > 0 {
+		for _, pat := range append(bd.SkipDistTests, bd.SkipTryDistTests...) {
+			if err := checkPattern(pat); err != nil {
+				return nil, fmt.Errorf("skip_dist_tests: %v", err)
+			}
+		}
+		skip, skipTry := bd.SkipDistTests, bd.SkipTryDistTests
+		bc.distTestAdjust = func(run bool, distTest string, isNormalTry bool) bool {
+			if matchAny(skip, distTest) || isNormalTry && matchAny(skipTry, distTest) {
+				return false
+			}
+			return run
+		}
+	}
+	return bc, nil
+}
+
+// compile returns the buildsRepo or tryBot policy func of p.
+func (p *RepoPolicy) compile() (func(repo, branch, goBranch string) bool, error) {
+	for _, list := range [][]string{p.Include, p.Exclude, p.Branches, p.GoBranches} {
+		for _, pat := range list {
+			if err := checkPattern(pat); err != nil {
+				return nil, err
+			}
+		}
+	}
+	var minGo, maxGo int
+	var err error
+	if p.MinGo != "" {
+		if minGo, err = parseGo1(p.MinGo); err != nil {
+			return nil, fmt.Errorf("min_go: %v", err)
+		}
+	}
+	if p.MaxGo != "" {
+		if maxGo, err = parseGo1(p.MaxGo); err != nil {
+			return nil, fmt.Errorf("max_go: %v", err)
+		}
+		if p.MinGo != "" && maxGo < minGo {
+			return nil, fmt.Errorf("max_go %s is less than min_go %s", p.MaxGo, p.MinGo)
+		}
+	}
+	p = &RepoPolicy{
+		Default:    p.Default,
+		Include:    slices.Clone(p.Include),
+		Exclude:    slices.Clone(p.Exclude),
+		Branches:   slices.Clone(p.Branches),
+		GoBranches: slices.Clone(p.GoBranches),
+	}
+	return func(repo, branch, goBranch string) bool {
+		b := p.Default && buildRepoByDefault(repo) || matchAny(p.Include, repo)
+		if !b || matchAny(p.Exclude, repo) {
+			return false
+		}
+		if len(p.Branches) > 0 && !matchAny(p.Branches, branch) {
+			return false
+		}
+		if len(p.GoBranches) > 0 && !matchAny(p.GoBranches, goBranch) {
+			return false
+		}
+		if minGo != 0 && !atLeastGo1(goBranch, minGo) {
+			return false
+		}
+		if maxGo != 0 && !atMostGo1(goBranch, maxGo) {
+			return false
+		}
+		return true
+	}, nil
+}
+
+// parseGo1 parses a Go version of the form "1.N" and returns N.
+func parseGo1(v string) (int, error) {
+	minor, ok := strings.CutPrefix(v, "1.")
+	n, err := strconv.Atoi(minor)
+	if !ok || err != nil || n < 0 || minor != strconv.Itoa(n) {
+		return 0, fmt.Errorf("invalid Go version %q, want the form 1.N", v)
+	}
+	return n, nil
+}
+
+// checkPattern checks that pat is a valid pattern of a RepoPolicy or the
+// dist tests to skip: a non-empty string with "*" at most at its end.
+func checkPattern(pat string) error {
+	if pat == "" {
+		return errors.New("empty pattern")
+	}
+	if i := strings.Index(pat, "*"); i >= 0 && i != len(pat)-1 {
+		return fmt.Errorf("invalid pattern %q: * is only allowed at the end", pat)
+	}
+	return nil
+}
+
+// matchAny reports whether s matches any of the patterns.
+func matchAny(patterns []string, s string) bool {
+	for _, pat := range patterns {
+		if prefix, ok := strings.CutSuffix(pat, "*"); ok {
+			if strings.HasPrefix(s, prefix) {
+				return true
+			}
+		} else if s == pat {
+			return true
+		}
+	}
+	return false
+}
+
+// checkEnv checks that env is a list of "key=value" pairs.
+func checkEnv(env []string) error {
+	for _, kv := range env {
+		if k, _, ok := strings.Cut(kv, "="); !ok || k == "" {
+			return fmt.Errorf("invalid env entry %q, want key=value", kv)
+		}
+	}
+	return nil
+}
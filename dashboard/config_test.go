@@ -0,0 +1,250 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dashboard
+
+import (
+	"strings"
+	"testing"
+)
+
+const testConfig = `
+hosts:
+  - name: host-linux-riscv64-example
+    reverse: true
+    expect_num: 1
+    notes: RISC-V board
+    owners: [bradfitz]
+
+builders:
+  - name: linux-riscv64-example
+    host: host-linux-riscv64-example
+    known_issues: [12345]
+    repos:
+      default: true
+      include: [exp]
+      exclude: [perf, pkg*]
+      min_go: "1.23"
+    trybot:
+      include: [go, sys]
+      go_branches: [master]
+    skip_dist_tests: ["test:*"]
+    skip_try_dist_tests: [api]
+    env: [GO_TEST_TIMEOUT_SCALE=4]
+
+  - name: linux-amd64-oldgo
+    host: host-linux-amd64-bullseye
+    repos:
+      include: [go]
+      max_go: "1.24"
+`
+
+func TestConfigCompile(t *testing.T) {
+	c, err := ParseConfig([]byte(testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hosts, builders, err := c.Compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hc := hosts["host-linux-riscv64-example"]
+	if hc == nil {
+		t.Fatal("missing host config")
+	}
+	if !hc.IsReverse || hc.HostArch != "linux-riscv64" || hc.GoBootstrap != GoBootstrap || len(hc.Owners) != 1 {
+		t.Errorf("host config = %+v", hc)
+	}
+
+	bc := builders["linux-riscv64-example"]
+	if bc == nil {
+		t.Fatal("missing builder config")
+	}
+	bc.TestHostConf = hc // the host isn't in Hosts
+	for _, tt := range []struct {
+		repo, branch, goBranch string
+		post, try              bool
+	}{
+		{"go", "master", "master", true, true},
+		{"go", "release-branch.go1.24", "release-branch.go1.24", true, false},
+		{"go", "release-branch.go1.22", "release-branch.go1.22", false, false},
+		{"sys", "master", "master", true, true},
+		{"sys", "master", "release-branch.go1.24", true, false},
+		{"net", "master", "master", true, false},
+		{"exp", "master", "master", true, false},
+		{"perf", "master", "master", false, false},
+		{"pkgsite", "master", "master", false, false},
+		{"website", "master", "master", false, false},
+	} {
+		if got := bc.BuildsRepoPostSubmit(tt.repo, tt.branch, tt.goBranch); got != tt.post {
+			t.Errorf("BuildsRepoPostSubmit(%q, %q, %q) = %v; want %v", tt.repo, tt.branch, tt.goBranch, got, tt.post)
+		}
+		if got := bc.BuildsRepoTryBot(tt.repo, tt.branch, tt.goBranch); got != tt.try {
+			t.Errorf("BuildsRepoTryBot(%q, %q, %q) = %v; want %v", tt.repo, tt.branch, tt.goBranch, got, tt.try)
+		}
+	}
+	for _, tt := range []struct {
+		distTest    string
+		isNormalTry bool
+		want        bool
+	}{
+		{"test:0_5", false, false},
+		{"api", false, true},
+		{"api", true, false},
+		{"go_test:os", true, true},
+	} {
+		if got := bc.ShouldRunDistTest(tt.distTest, tt.isNormalTry); got != tt.want {
+			t.Errorf("ShouldRunDistTest(%q, %v) = %v; want %v", tt.distTest, tt.isNormalTry, got, tt.want)
+		}
+	}
+	if got := bc.Env(); !strings.Contains(strings.Join(got, " "), "GO_TEST_TIMEOUT_SCALE=4") {
+		t.Errorf("Env() = %q; want GO_TEST_TIMEOUT_SCALE=4", got)
+	}
+
+	old := builders["linux-amd64-oldgo"]
+	if !old.BuildsRepoPostSubmit("go", "release-branch.go1.24", "release-branch.go1.24") {
+		t.Errorf("linux-amd64-oldgo doesn't build go1.24")
+	}
+	if old.BuildsRepoPostSubmit("go", "master", "master") {
+		t.Errorf("linux-amd64-oldgo builds master")
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name, config, want string
+	}{
+		{"unknown field", "builders:\n  - name: linux-amd64-x\n    hots: host-linux-amd64-bullseye\n", "field hots not found"},
+		{"undefined host", "builders:\n  - name: linux-amd64-x\n    host: host-nope\n", `undefined HostType "host-nope"`},
+		{"dup builder", "builders:\n  - name: linux-amd64-x\n    host: host-linux-amd64-bullseye\n  - name: linux-amd64-x\n    host: host-linux-amd64-bullseye\n", "dup name"},
+		{"no host kind", "hosts:\n  - name: host-linux-amd64-x\n", "exactly one of VMImage"},
+		{"bad host name", "hosts:\n  - name: host-linux\n    reverse: true\n", "invalid HostType"},
+		{"unknown owner", "hosts:\n  - name: host-linux-amd64-x\n    reverse: true\n    owners: [no-such-gopher-0]\n", "does not exist"},
+		{"google reverse", "hosts:\n  - name: host-linux-amd64-x\n    vm_image: x\n    google_reverse: true\nbuilders:\n  - name: linux-amd64-x\n    host: host-linux-amd64-x\n", "isn't reverse"},
+		{"zero issue", "builders:\n  - name: linux-amd64-x\n    host: host-linux-amd64-bullseye\n    known_issues: [0]\n", "zero issue"},
+		{"bad pattern", "builders:\n  - name: linux-amd64-x\n    host: host-linux-amd64-bullseye\n    repos:\n      include: ['*x']\n", "only allowed at the end"},
+		{"bad version", "builders:\n  - name: linux-amd64-x\n    host: host-linux-amd64-bullseye\n    repos:\n      min_go: go1.22\n", "want the form 1.N"},
+		{"bad env", "builders:\n  - name: linux-amd64-x\n    host: host-linux-amd64-bullseye\n    env: [GOARCH]\n", "want key=value"},
+		{"try only", "builders:\n  - name: linux-amd64-x\n    host: host-linux-amd64-bullseye\n    try_only: true\n", "trybot isn't"},
+		{"snapshot helpers", "builders:\n  - name: linux-amd64-x\n    host: host-linux-amd64-bullseye\n    skip_snapshot: true\n    test_helpers: 2\n", "SkipSnapshot"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseConfig([]byte(tt.config))
+			if err == nil {
+				_, _, err = c.Compile()
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v; want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddConfigConflicts(t *testing.T) {
+	c, err := ParseConfig([]byte("builders:\n  - name: linux-amd64\n    host: host-linux-amd64-bullseye\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := Builders["linux-amd64"]
+	if err := AddConfig(c); err == nil || !strings.Contains(err.Error(), "already defined") {
+		t.Errorf("AddConfig of an existing builder: got error %v; want already defined", err)
+	}
+	if Builders["linux-amd64"] != old {
+		t.Errorf("AddConfig replaced an existing builder")
+	}
+}
+
+func TestBuildersYAML(t *testing.T) {
+	c, err := ParseConfig(buildersYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, hd := range c.Hosts {
+		if Hosts[hd.Name] == nil {
+			t.Errorf("host %q of builders.yaml isn't in Hosts", hd.Name)
+		}
+	}
+	for _, bd := range c.Builders {
+		if Builders[bd.Name] == nil {
+			t.Errorf("builder %q of builders.yaml isn't in Builders", bd.Name)
+		}
+	}
+	for i := 1; i < len(c.Hosts); i++ {
+		if c.Hosts[i-1].Name > c.Hosts[i].Name {
+			t.Errorf("builders.yaml hosts unsorted: %s before %s", c.Hosts[i-1].Name, c.Hosts[i].Name)
+		}
+	}
+}
+
+func TestDiffCoverage(t *testing.T) {
+	compile := func(config string) map[string]*BuildConfig {
+		t.Helper()
+		c, err := ParseConfig([]byte(config))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, builders, err := c.Compile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return builders
+	}
+	old := compile(`
+builders:
+  - name: linux-amd64-x
+    host: host-linux-amd64-bullseye
+    repos:
+      include: [go, net]
+    trybot:
+      include: [go]
+  - name: linux-amd64-y
+    host: host-linux-amd64-bullseye
+    repos:
+      include: [go]
+`)
+	new := compile(`
+builders:
+  - name: linux-amd64-x
+    host: host-linux-amd64-bullseye
+    repos:
+      include: [go, net]
+      go_branches: [master]
+    trybot:
+      include: [go, net]
+  - name: linux-amd64-z
+    host: host-linux-amd64-bullseye
+    repos:
+      include: [sys]
+      branches: [master]
+      go_branches: [master]
+`)
+	rbs := RepoBranches([]string{"go", "net", "sys"}, []string{"master", "release-branch.go1.25"})
+	added, removed := DiffCoverage(old, new, rbs)
+
+	format := func(cov []Coverage) string {
+		var lines []string
+		for _, c := range cov {
+			s := c.Builder + " " + c.Repo + "@" + c.Branch + " go@" + c.GoBranch
+			if c.TryBot {
+				s += " try"
+			}
+			lines = append(lines, s)
+		}
+		return strings.Join(lines, "\n")
+	}
+	wantAdded := `linux-amd64-x net@master go@master try
+linux-amd64-z sys@master go@master`
+	wantRemoved := `linux-amd64-x go@release-branch.go1.25 go@release-branch.go1.25
+linux-amd64-x go@release-branch.go1.25 go@release-branch.go1.25 try
+linux-amd64-x net@master go@release-branch.go1.25
+linux-amd64-y go@master go@master
+linux-amd64-y go@release-branch.go1.25 go@release-branch.go1.25`
+	if got := format(added); got != wantAdded {
+		t.Errorf("added:\n%s\nwant:\n%s", got, wantAdded)
+	}
+	if got := format(removed); got != wantRemoved {
+		t.Errorf("removed:\n%s\nwant:\n%s", got, wantRemoved)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dashboard
+
+import (
+	"cmp"
+	"slices"
+)
+
+// A RepoBranch is a branch of a repo ("go", "net", etc.) to build with a
+// branch of Go. If Repo is "go", GoBranch is the same as Branch.
+type RepoBranch struct {
+	Repo     string
+	Branch   string
+	GoBranch string
+}
+
+// RepoBranches returns the usual combinations of repos and branches to
+// build: each of goBranches of "go", and the "master" branch of each of
+// the other repos with each of goBranches.
+func RepoBranches(repos, goBranches []string) []RepoBranch {
+	var rbs []RepoBranch
+	for _, repo := range repos {
+		for _, goBranch := range goBranches {
+			branch := "master"
+			if repo == "go" {
+				branch = goBranch
+			}
+			rbs = append(rbs, RepoBranch{repo, branch, goBranch})
+		}
+	}
+	return rbs
+}
+
+// A Coverage is a repo and branch combination that a builder builds,
+// as reported by BuildConfig.BuildsRepoPostSubmit or, if TryBot is set,
+// BuildConfig.BuildsRepoTryBot.
+type Coverage struct {
+	Builder string
+	RepoBranch
+	TryBot bool
+}
+
+// CoverageOf returns the combinations of rbs that builders build, sorted
+// by builder, repo and branch.
+func CoverageOf(builders map[string]*BuildConfig, rbs []RepoBranch) []Coverage {
+	var cov []Coverage
+	for name, bc := range builders {
+		for _, rb := range rbs {
+			if bc.BuildsRepoPostSubmit(rb.Repo, rb.Branch, rb.GoBranch) {
+				cov = append(cov, Coverage{name, rb, false})
+			}
+			if bc.BuildsRepoTryBot(rb.Repo, rb.Branch, rb.GoBranch) {
+				cov = append(cov, Coverage{name, rb, true})
+			}
+		}
+	}
+	slices.SortFunc(cov, compareCoverage)
+	return cov
+}
+
+func compareCoverage(a, b Coverage) int {
+	if c := cmp.Compare(a.Builder, b.Builder); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Repo, b.Repo); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Branch, b.Branch); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.GoBranch, b.GoBranch); c != 0 {
+		return c
+	}
+	if a.TryBot == b.TryBot {
+		return 0
+	} else if b.TryBot {
+		return -1
+	}
+	return 1
+}
+
+// DiffCoverage returns the combinations of rbs that the builders in new
+// build and those in old don't, and the other way around. A builder
+// missing from old or new builds nothing there.
+func DiffCoverage(old, new map[string]*BuildConfig, rbs []RepoBranch) (added, removed []Coverage) {
+	oldCov := CoverageOf(old, rbs)
+	newCov := CoverageOf(new, rbs)
+	for _, c := range newCov {
+		if _, found := slices.BinarySearchFunc(oldCov, c, compareCoverage); !found {
+			added = append(added, c)
+		}
+	}
+	for _, c := range oldCov {
+		if _, found := slices.BinarySearchFunc(newCov, c, compareCoverage); !found {
+			removed = append(removed, c)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+// Package autoscale starts buildlets ahead of the forecast demand for
+// them, so that builds don't wait for their VMs to boot.
+package autoscale
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/build/internal"
+)
+
+// A Cloud is a buildlet pool that can start buildlets ahead of demand.
+// Its idle prewarmed buildlets go to the next callers of its GetBuildlet.
+type Cloud interface {
+	// HostTypes returns the host types whose buildlets the pool can
+	// prewarm.
+	HostTypes() []string
+	// Warm returns the number of prewarmed buildlets of hostType that
+	// are idle or starting.
+	Warm(hostType string) int
+	// Prewarm starts a buildlet of hostType if there is quota for it
+	// that no other work is waiting for, and reports whether it did.
+	Prewarm(hostType string) bool
+	// Cool stops an idle prewarmed buildlet of hostType, and reports
+	// whether there was one.
+	Cool(hostType string) bool
+}
+
+// EventReleaseBranch is the kind of event of a new release branch,
+// which many post-submit builds follow.
+const EventReleaseBranch = "release-branch"
+
+// An Autoscaler periodically observes the demand for buildlets, forecasts
+// it, and keeps idle buildlets prewarmed in its clouds for the demand
+// forecast to exceed the current one.
+type Autoscaler struct {
+	demand     func() map[string]int
+	clouds     []Cloud
+	forecaster *Forecaster
+	lead       time.Duration
+	maxWarm    int
+
+	mu     sync.Mutex
+	status map[string]HostStatus // host type -> latest status
+}
+
+// An Option configures an Autoscaler.
+type Option func(*Autoscaler)
+
+// OptLead sets how far ahead the Autoscaler prewarms buildlets for the
+// forecast demand. It should be about how long buildlets take to start.
+// The default is 5 minutes.
+func OptLead(d time.Duration) Option {
+	return func(a *Autoscaler) {
+		a.lead = d
+	}
+}
+
+// OptMaxWarm sets the most prewarmed buildlets the Autoscaler keeps of
+// each host type. The default is 4.
+func OptMaxWarm(n int) Option {
+	return func(a *Autoscaler) {
+		a.maxWarm = n
+	}
+}
+
+// OptForecaster sets the Forecaster of the Autoscaler, such as one that
+// already learned the demand.
+func OptForecaster(f *Forecaster) Option {
+	return func(a *Autoscaler) {
+		a.forecaster = f
+	}
+}
+
+// New returns an Autoscaler of the host types of clouds. demand returns
+// the number of callers waiting for or holding a buildlet of each host
+// type, such as from Scheduler.Demand.
+func New(demand func() map[string]int, clouds []Cloud, opts ...Option) *Autoscaler {
+	a := &Autoscaler{
+		demand:     demand,
+		clouds:     clouds,
+		forecaster: new(Forecaster),
+		lead:       5 * time.Minute,
+		maxWarm:    4,
+		status:     make(map[string]HostStatus),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Run scales the clouds every minute until ctx is done.
+func (a *Autoscaler) Run(ctx context.Context) {
+	internal.PeriodicallyDo(ctx, time.Minute, func(_ context.Context, now time.Time) {
+		a.scale(now)
+	})
+}
+
+// Event records that an event of the given kind, such as
+// EventReleaseBranch, happened now.
+func (a *Autoscaler) Event(kind string) {
+	a.forecaster.Event(kind, time.Now())
+}
+
+// scale observes the demand at now and prewarms or stops buildlets for
+// the demand forecast after the lead time.
+func (a *Autoscaler) scale(now time.Time) {
+	demand := a.demand()
+	a.forecaster.Observe(now, demand)
+	for _, c := range a.clouds {
+		for _, hostType := range c.HostTypes() {
+			forecast := a.forecaster.Forecast(hostType, now.Add(a.lead))
+			// The callers in demand already have or are getting
+			// buildlets, so only prewarm for new ones.
+			target := int(math.Round(forecast)) - demand[hostType]
+			target = min(max(target, 0), a.maxWarm)
+			warm := c.Warm(hostType)
+			for warm < target && c.Prewarm(hostType) {
+				warm++
+			}
+			for warm > target && c.Cool(hostType) {
+				warm--
+			}
+			a.setStatus(HostStatus{
+				HostType: hostType,
+				Demand:   demand[hostType],
+				Forecast: forecast,
+				Target:   target,
+				Warm:     warm,
+			})
+		}
+	}
+}
+
+func (a *Autoscaler) setStatus(st HostStatus) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if st.Demand == 0 && st.Forecast < 0.5 && st.Warm == 0 {
+		delete(a.status, st.HostType)
+		return
+	}
+	a.status[st.HostType] = st
+}
+
+// HostStatus is the autoscaling status of a host type.
+type HostStatus struct {
+	HostType string
+	Demand   int     // callers waiting for or holding buildlets
+	Forecast float64 // demand forecast after the lead time
+	Target   int     // prewarmed buildlets wanted
+	Warm     int     // prewarmed buildlets idle or starting
+}
+
+// Status returns the autoscaling status of the host types with demand,
+// forecast demand or prewarmed buildlets, sorted by host type.
+func (a *Autoscaler) Status() []HostStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var sts []HostStatus
+	for _, st := range a.status {
+		sts = append(sts, st)
+	}
+	slices.SortFunc(sts, func(x, y HostStatus) int { return strings.Compare(x.HostType, y.HostType) })
+	return sts
+}
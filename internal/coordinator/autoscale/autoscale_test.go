@@ -0,0 +1,87 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package autoscale
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeCloud is a Cloud with a quota of buildlets.
+type fakeCloud struct {
+	hostTypes []string
+	quota     int            // buildlets that may still be started
+	warm      map[string]int // host type -> prewarmed buildlets
+}
+
+func (c *fakeCloud) HostTypes() []string      { return c.hostTypes }
+func (c *fakeCloud) Warm(hostType string) int { return c.warm[hostType] }
+
+func (c *fakeCloud) Prewarm(hostType string) bool {
+	if c.quota == 0 {
+		return false
+	}
+	c.quota--
+	c.warm[hostType]++
+	return true
+}
+
+func (c *fakeCloud) Cool(hostType string) bool {
+	if c.warm[hostType] == 0 {
+		return false
+	}
+	c.quota++
+	c.warm[hostType]--
+	return true
+}
+
+func TestAutoscaler(t *testing.T) {
+	cloud := &fakeCloud{
+		hostTypes: []string{"host-a", "host-b"},
+		quota:     3,
+		warm:      make(map[string]int),
+	}
+	demand := map[string]int{}
+	f := new(Forecaster)
+	start := time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)
+	observeDays(f, start, 7, 6, 0)
+	a := New(func() map[string]int { return demand }, []Cloud{cloud}, OptForecaster(f), OptLead(10*time.Minute))
+
+	// Before the usual afternoon peak, buildlets are prewarmed up to the
+	// quota.
+	now := start.Add(7*24*time.Hour + 13*time.Hour + 55*time.Minute)
+	a.scale(now)
+	if got := cloud.warm["host-a"]; got != 3 {
+		t.Errorf("prewarmed host-a buildlets = %d; want 3, the quota", got)
+	}
+	if got := cloud.warm["host-b"]; got != 0 {
+		t.Errorf("prewarmed host-b buildlets = %d; want 0", got)
+	}
+
+	// As demand arrives, fewer buildlets are kept prewarmed.
+	demand["host-a"] = 5
+	a.scale(now.Add(10 * time.Minute))
+	if got := cloud.warm["host-a"]; got != 1 {
+		t.Errorf("prewarmed host-a buildlets with demand = %d; want 1", got)
+	}
+	sts := a.Status()
+	if len(sts) != 1 || sts[0].HostType != "host-a" || sts[0].Demand != 5 || sts[0].Warm != 1 {
+		t.Errorf("Status() = %+v; want host-a with demand 5 and 1 warm", sts)
+	}
+
+	// After the peak, the prewarmed buildlets are stopped.
+	demand["host-a"] = 0
+	for t := now.Add(time.Hour); t.Before(now.Add(4 * time.Hour)); t = t.Add(time.Minute) {
+		a.scale(t)
+	}
+	if got := cloud.warm["host-a"]; got != 0 {
+		t.Errorf("prewarmed host-a buildlets after the peak = %d; want 0", got)
+	}
+	if sts := a.Status(); len(sts) != 0 {
+		t.Errorf("Status() after the peak = %+v; want none", sts)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package autoscale
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// slotsPerWeek is the number of hourly slots in the weekly demand
+	// profile of a host type.
+	slotsPerWeek = 7 * 24
+
+	// eventHours is how many hours after an event its effect on demand
+	// is learned and forecast.
+	eventHours = 24
+
+	// levelAlpha is the weight of each observation in the recent
+	// demand level.
+	levelAlpha = 0.2
+
+	// profileAlpha is the weight of each hour's peak demand in the
+	// learned weekly profile and event effects.
+	profileAlpha = 0.3
+)
+
+// A Forecaster learns the demand for buildlets of each host type from
+// periodic observations, and forecasts it.
+//
+// It learns the peak demand of each hour of the week, which captures
+// daily and weekly cycles, and the level of recent demand. It also learns
+// how events, such as a release branch cut, change the demand in the
+// hours after them relative to that weekly profile, and forecasts the
+// same change after the next such event.
+//
+// The zero value is ready to use.
+type Forecaster struct {
+	mu     sync.Mutex
+	hosts  map[string]*hostDemand
+	events []event // in the last eventHours
+}
+
+type event struct {
+	kind string
+	at   time.Time
+}
+
+// hostDemand is what a Forecaster learned about a host type.
+type hostDemand struct {
+	level  float64               // exponentially weighted recent demand
+	weekly [slotsPerWeek]float64 // learned peak demand of each hour of the week
+	seen   [slotsPerWeek]bool    // whether weekly was learned
+
+	hour     time.Time // the start of the hour being observed
+	hourPeak int       // the peak demand in that hour
+
+	// ratios are the learned peak demand in each hour after an event
+	// of each kind, relative to the weekly profile. Zero means not
+	// learned.
+	ratios map[string]*[eventHours]float64
+}
+
+// slot returns the slot of the weekly profile for t.
+func slot(t time.Time) int {
+	t = t.UTC()
+	return int(t.Weekday())*24 + t.Hour()
+}
+
+// baseline returns the demand expected from the weekly profile at the
+// hour of slot s.
+func (hd *hostDemand) baseline(s int) float64 {
+	if hd.seen[s] {
+		return hd.weekly[s]
+	}
+	return hd.level
+}
+
+// Observe records the demand for buildlets of each host type at t.
+// Host types missing from demand had none.
+func (f *Forecaster) Observe(t time.Time, demand map[string]int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.hosts == nil {
+		f.hosts = make(map[string]*hostDemand)
+	}
+	for hostType, d := range demand {
+		if d > 0 && f.hosts[hostType] == nil {
+			f.hosts[hostType] = &hostDemand{level: float64(d)}
+		}
+	}
+	hour := t.Truncate(time.Hour)
+	for hostType, hd := range f.hosts {
+		d := demand[hostType]
+		hd.level += levelAlpha * (float64(d) - hd.level)
+		if !hd.hour.Equal(hour) {
+			if !hd.hour.IsZero() {
+				f.learnHour(hd)
+			}
+			hd.hour, hd.hourPeak = hour, 0
+		}
+		hd.hourPeak = max(hd.hourPeak, d)
+	}
+	f.events = dropOldEvents(f.events, t)
+}
+
+// learnHour folds the peak demand of the hour hd was observing into
+// what hd learned. f.mu must be held.
+func (f *Forecaster) learnHour(hd *hostDemand) {
+	s := slot(hd.hour)
+	peak := float64(hd.hourPeak)
+	base := hd.baseline(s)
+	inEvent := false
+	for _, e := range f.events {
+		h := eventHour(e, hd.hour)
+		if h < 0 {
+			continue
+		}
+		inEvent = true
+		if base < 1 {
+			// Too little usual demand to learn a ratio from.
+			continue
+		}
+		if hd.ratios == nil {
+			hd.ratios = make(map[string]*[eventHours]float64)
+		}
+		r := hd.ratios[e.kind]
+		if r == nil {
+			r = new([eventHours]float64)
+			hd.ratios[e.kind] = r
+		}
+		if r[h] == 0 {
+			r[h] = peak / base
+		} else {
+			r[h] += profileAlpha * (peak/base - r[h])
+		}
+	}
+	// Demand shaped by events isn't part of the weekly profile.
+	if inEvent {
+		return
+	}
+	if !hd.seen[s] {
+		hd.weekly[s], hd.seen[s] = peak, true
+	} else {
+		hd.weekly[s] += profileAlpha * (peak - hd.weekly[s])
+	}
+}
+
+// eventHour returns the number of whole hours from e to t, or -1 if t
+// isn't in the eventHours after e.
+func eventHour(e event, t time.Time) int {
+	d := t.Sub(e.at.Truncate(time.Hour))
+	if d < 0 || d >= eventHours*time.Hour {
+		return -1
+	}
+	return int(d / time.Hour)
+}
+
+// dropOldEvents returns events without those too old to affect the
+// demand at t.
+func dropOldEvents(events []event, t time.Time) []event {
+	live := events[:0]
+	for _, e := range events {
+		if t.Sub(e.at) < (eventHours+1)*time.Hour {
+			live = append(live, e)
+		}
+	}
+	return live
+}
+
+// Event records that an event of the given kind, such as a release
+// branch cut, happened at t.
+func (f *Forecaster) Event(kind string, t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event{kind, t})
+}
+
+// Forecast returns the demand for buildlets of hostType expected at t,
+// which should be close to the time of the latest observation: the peak
+// demand usual at that hour of the week, scaled by the learned effect of
+// recent events, and at least the recent demand level.
+func (f *Forecaster) Forecast(hostType string, t time.Time) float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	hd := f.hosts[hostType]
+	if hd == nil {
+		return 0
+	}
+	ratio := 1.0
+	for _, e := range f.events {
+		h := eventHour(e, t)
+		if h < 0 || hd.ratios[e.kind] == nil {
+			continue
+		}
+		if r := hd.ratios[e.kind][h]; r > ratio {
+			ratio = r
+		}
+	}
+	return max(hd.baseline(slot(t))*ratio, hd.level)
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package autoscale
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// observeDays observes a week day's demand every minute for days days
+// from start, with demand busy between 14:00 and 16:00 UTC and idle
+// otherwise.
+func observeDays(f *Forecaster, start time.Time, days, busy, idle int) time.Time {
+	t := start
+	for end := start.Add(time.Duration(days) * 24 * time.Hour); t.Before(end); t = t.Add(time.Minute) {
+		d := idle
+		if h := t.UTC().Hour(); h >= 14 && h < 16 {
+			d = busy
+		}
+		f.Observe(t, map[string]int{"host-a": d})
+	}
+	return t
+}
+
+func TestForecastWeekly(t *testing.T) {
+	f := new(Forecaster)
+	start := time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC) // a Sunday
+	end := observeDays(f, start, 7*3, 10, 1)
+
+	if got := f.Forecast("host-unknown", end); got != 0 {
+		t.Errorf("Forecast(host-unknown) = %v; want 0", got)
+	}
+	// At midnight, the afternoon peak is coming.
+	if got := f.Forecast("host-a", end.Add(14*time.Hour)); math.Abs(got-10) > 0.5 {
+		t.Errorf("Forecast(host-a, 14:00) = %v; want about 10", got)
+	}
+	if got := f.Forecast("host-a", end.Add(10*time.Hour)); math.Abs(got-1) > 0.5 {
+		t.Errorf("Forecast(host-a, 10:00) = %v; want about 1", got)
+	}
+}
+
+func TestForecastEvent(t *testing.T) {
+	f := new(Forecaster)
+	start := time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)
+	t0 := observeDays(f, start, 7, 4, 2)
+
+	// A release branch cut doubles the demand for three hours.
+	f.Event(EventReleaseBranch, t0)
+	for t := t0; t.Before(t0.Add(24 * time.Hour)); t = t.Add(time.Minute) {
+		d := 2
+		if t.Sub(t0) < 3*time.Hour {
+			d = 4
+		}
+		f.Observe(t, map[string]int{"host-a": d})
+	}
+	t1 := observeDays(f, t0.Add(24*time.Hour), 6, 4, 2)
+	if got := f.Forecast("host-a", t1.Add(time.Hour)); math.Abs(got-2) > 0.5 {
+		t.Errorf("Forecast(host-a) without event = %v; want about 2", got)
+	}
+	f.Event(EventReleaseBranch, t1)
+	if got := f.Forecast("host-a", t1.Add(time.Hour)); math.Abs(got-4) > 0.5 {
+		t.Errorf("Forecast(host-a) an hour after the event = %v; want about 4", got)
+	}
+	if got := f.Forecast("host-a", t1.Add(5*time.Hour)); math.Abs(got-2) > 0.5 {
+		t.Errorf("Forecast(host-a) five hours after the event = %v; want about 2", got)
+	}
+}
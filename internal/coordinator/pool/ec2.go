@@ -13,6 +13,7 @@ import (
 	"html"
 	"io"
 	"log"
+	"slices"
 	"sync"
 	"time"
 
@@ -87,6 +88,8 @@ type EC2Buildlet struct {
 	cancelPoll context.CancelFunc
 	// pollWait waits for all pollers to terminate polling.
 	pollWait sync.WaitGroup
+	// warm holds the buildlets started by Prewarm.
+	warm warmBuildlets
 }
 
 // ec2BuildletClient represents an EC2 buildlet client in the buildlet package.
@@ -153,21 +156,31 @@ func (eb *EC2Buildlet) GetBuildlet(ctx context.Context, hostType string, lg Logg
 	if !ok {
 		return nil, fmt.Errorf("ec2 pool: unknown host type %q", hostType)
 	}
+	if bc := eb.warm.take(hostType); bc != nil {
+		lg.LogEventTime("using_prewarmed_buildlet", bc.InstanceName())
+		return bc, nil
+	}
 	instName := instanceName(hostType, 7)
+	qsp := lg.CreateSpan("awaiting_ec2_quota")
+	err := eb.ledger.ReserveResources(ctx, instName, hconf.MachineType(), si)
+	qsp.Done(err)
+	if err != nil {
+		return nil, err
+	}
+	return eb.startBuildlet(ctx, hostType, hconf, instName, lg)
+}
+
+// startBuildlet starts the VM instName for a buildlet of hostType, whose
+// resources are reserved in the ledger.
+func (eb *EC2Buildlet) startBuildlet(ctx context.Context, hostType string, hconf *dashboard.HostConfig, instName string, lg Logger) (bc buildlet.Client, err error) {
 	log.Printf("Creating EC2 VM %q for %s", instName, hostType)
 	kp, err := buildlet.NewKeyPair()
 	if err != nil {
 		log.Printf("failed to create TLS key pair for %s: %s", hostType, err)
+		eb.ledger.Remove(instName)
 		return nil, fmt.Errorf("failed to create TLS key pair: %w", err)
 	}
 
-	qsp := lg.CreateSpan("awaiting_ec2_quota")
-	err = eb.ledger.ReserveResources(ctx, instName, hconf.MachineType(), si)
-	qsp.Done(err)
-	if err != nil {
-		return nil, err
-	}
-
 	ec2BuildletSpan := lg.CreateSpan("create_ec2_buildlet", instName)
 	defer func() { ec2BuildletSpan.Done(err) }()
 
@@ -177,7 +190,7 @@ func (eb *EC2Buildlet) GetBuildlet(ctx context.Context, hostType string, lg Logg
 		curSpan         = createSpan
 		instanceCreated bool
 	)
-	bc, err := eb.buildletClient.StartNewVM(ctx, eb.buildEnv, hconf, instName, hostType, &buildlet.VMOpts{
+	bc, err = eb.buildletClient.StartNewVM(ctx, eb.buildEnv, hconf, instName, hostType, &buildlet.VMOpts{
 		Zone:     "", // allow the EC2 api pick an availability zone with capacity
 		TLS:      kp,
 		Meta:     make(map[string]string),
@@ -218,6 +231,49 @@ func (eb *EC2Buildlet) GetBuildlet(ctx context.Context, hostType string, lg Logg
 	return bc, nil
 }
 
+// HostTypes returns the host types whose buildlets the pool creates,
+// which are the ones it can prewarm.
+func (eb *EC2Buildlet) HostTypes() []string {
+	var hostTypes []string
+	for hostType, hconf := range eb.hosts {
+		if hconf.IsEC2 {
+			hostTypes = append(hostTypes, hostType)
+		}
+	}
+	slices.Sort(hostTypes)
+	return hostTypes
+}
+
+// Warm returns the number of buildlets of hostType started by Prewarm
+// that are idle or starting.
+func (eb *EC2Buildlet) Warm(hostType string) int {
+	return eb.warm.count(hostType)
+}
+
+// Prewarm starts a buildlet of hostType that the next GetBuildlet for
+// hostType gets right away, if there are resources for it that no caller
+// of GetBuildlet is waiting for. It reports whether it started one.
+func (eb *EC2Buildlet) Prewarm(hostType string) bool {
+	hconf, ok := eb.hosts[hostType]
+	if !ok || !hconf.IsEC2 {
+		return false
+	}
+	instName := instanceName(hostType, 7)
+	if !eb.ledger.TryReserveResources(instName, hconf.MachineType(), &queue.SchedItem{HostType: hostType, RequestTime: time.Now()}) {
+		return false
+	}
+	eb.warm.start(hostType, determineDeleteTimeout(hconf), func(ctx context.Context, lg Logger) (buildlet.Client, error) {
+		return eb.startBuildlet(ctx, hostType, hconf, instName, lg)
+	})
+	return true
+}
+
+// Cool stops an idle buildlet of hostType started by Prewarm, and
+// reports whether there was one.
+func (eb *EC2Buildlet) Cool(hostType string) bool {
+	return eb.warm.cool(hostType)
+}
+
 func (eb *EC2Buildlet) QuotaStats() map[string]*queue.QuotaStats {
 	return map[string]*queue.QuotaStats{
 		"ec2-cpu": eb.ledger.cpuQueue.ToExported(),
@@ -10,6 +10,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"testing"
 	"time"
@@ -275,6 +277,77 @@ func TestEC2BuildletGetBuildletLogger(t *testing.T) {
 	}
 }
 
+func TestEC2BuildletPrewarm(t *testing.T) {
+	// The machine types of EC2 hosts can't be set outside of the
+	// dashboard package, so use a real one.
+	var host string
+	for _, hostType := range slices.Sorted(maps.Keys(dashboard.Hosts)) {
+		if dashboard.Hosts[hostType].IsEC2 {
+			host = hostType
+			break
+		}
+	}
+	if host == "" {
+		t.Skip("no EC2 host types")
+	}
+	l := newLedger()
+	l.UpdateInstanceTypes([]*cloud.InstanceType{
+		{
+			Type: dashboard.Hosts[host].MachineType(),
+			CPU:  16,
+		},
+	})
+	l.SetCPULimit(40)
+	bp := &EC2Buildlet{
+		buildletClient: &fakeEC2BuildletClient{
+			createVMRequestSuccess: true,
+			VMCreated:              true,
+			buildletCreated:        true,
+		},
+		buildEnv: &buildenv.Environment{},
+		ledger:   l,
+		hosts: map[string]*dashboard.HostConfig{
+			host:           dashboard.Hosts[host],
+			"host-not-ec2": {},
+		},
+	}
+	if got, want := bp.HostTypes(), []string{host}; !cmp.Equal(got, want) {
+		t.Errorf("HostTypes() = %q; want %q", got, want)
+	}
+	if bp.Prewarm("host-not-ec2") {
+		t.Errorf("Prewarm(%q) = true; want false", "host-not-ec2")
+	}
+	for i, want := range []bool{true, true, false} {
+		if got := bp.Prewarm(host); got != want {
+			t.Errorf("Prewarm(%q) #%d = %t; want %t", host, i, got, want)
+		}
+	}
+	idle := func() int {
+		bp.warm.mu.Lock()
+		defer bp.warm.mu.Unlock()
+		return len(bp.warm.idle[host])
+	}
+	for deadline := time.Now().Add(5 * time.Second); idle() != 2; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("prewarmed buildlets = %d after 5s; want 2", idle())
+		}
+	}
+	if !bp.Cool(host) || bp.Warm(host) != 1 {
+		t.Errorf("Cool(%q) didn't stop a prewarmed buildlet: %d warm; want 1", host, bp.Warm(host))
+	}
+
+	// There is no quota left, so only the prewarmed buildlet is
+	// available right away.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := bp.GetBuildlet(ctx, host, noopEventTimeLogger{}, new(queue.SchedItem)); err != nil {
+		t.Errorf("GetBuildlet(ctx, %q) = _, %s; want the prewarmed buildlet", host, err)
+	}
+	if got := bp.Warm(host); got != 0 {
+		t.Errorf("Warm(%q) = %d after GetBuildlet; want 0", host, got)
+	}
+}
+
 func TestEC2BuildletString(t *testing.T) {
 	testCases := []struct {
 		desc      string
@@ -360,6 +360,8 @@ type GCEBuildlet struct {
 	n2dcpuQueue *queue.Quota
 	t2acpuQueue *queue.Quota
 	inst        map[string]time.Time // GCE VM instance name -> creationTime
+
+	warm warmBuildlets // buildlets started by Prewarm
 }
 
 func (p *GCEBuildlet) pollQuotaLoop() {
@@ -453,6 +455,10 @@ func (p *GCEBuildlet) GetBuildlet(ctx context.Context, hostType string, lg Logge
 	if !ok {
 		return nil, fmt.Errorf("gcepool: unknown host type %q", hostType)
 	}
+	if bc := p.warm.take(hostType); bc != nil {
+		lg.LogEventTime("using_prewarmed_buildlet", bc.InstanceName())
+		return bc, nil
+	}
 	qsp := lg.CreateSpan("awaiting_gce_quota")
 	instItem := p.instQueue.Enqueue(1, si)
 	if err := instItem.Await(ctx); err != nil {
@@ -466,7 +472,12 @@ func (p *GCEBuildlet) GetBuildlet(ctx context.Context, hostType string, lg Logge
 		instItem.ReturnQuota()
 		return nil, err
 	}
+	return p.startBuildlet(ctx, hostType, hconf, lg, instItem, cpuItem)
+}
 
+// startBuildlet starts a VM for a buildlet of hostType, using the quota
+// held by instItem and cpuItem.
+func (p *GCEBuildlet) startBuildlet(ctx context.Context, hostType string, hconf *dashboard.HostConfig, lg Logger, instItem, cpuItem *queue.Item) (bc buildlet.Client, err error) {
 	instName := instanceName(hostType, 7)
 	instName = strings.Replace(instName, "_", "-", -1) // Issue 22905; can't use underscores in GCE VMs
 	p.setInstanceUsed(instName, true)
@@ -532,6 +543,55 @@ func (p *GCEBuildlet) GetBuildlet(ctx context.Context, hostType string, lg Logge
 	return bc, nil
 }
 
+// HostTypes returns the host types whose buildlets the pool creates,
+// which are the ones it can prewarm.
+func (p *GCEBuildlet) HostTypes() []string {
+	var hostTypes []string
+	for hostType, hconf := range dashboard.Hosts {
+		if !hconf.IsEC2 && !hconf.IsReverse && (hconf.IsVM() || hconf.IsContainer()) {
+			hostTypes = append(hostTypes, hostType)
+		}
+	}
+	slices.Sort(hostTypes)
+	return hostTypes
+}
+
+// Warm returns the number of buildlets of hostType started by Prewarm
+// that are idle or starting.
+func (p *GCEBuildlet) Warm(hostType string) int {
+	return p.warm.count(hostType)
+}
+
+// Prewarm starts a buildlet of hostType that the next GetBuildlet for
+// hostType gets right away, if there is quota for it that no caller of
+// GetBuildlet is waiting for. It reports whether it started one.
+func (p *GCEBuildlet) Prewarm(hostType string) bool {
+	hconf, ok := dashboard.Hosts[hostType]
+	if p.disabled || !ok {
+		return false
+	}
+	si := &queue.SchedItem{HostType: hostType, RequestTime: time.Now()}
+	instItem := p.instQueue.TryEnqueue(1, si)
+	if instItem == nil {
+		return false
+	}
+	cpuItem := p.queueForMachineType(hconf.MachineType()).TryEnqueue(GCENumCPU(hconf.MachineType()), si)
+	if cpuItem == nil {
+		instItem.ReturnQuota()
+		return false
+	}
+	p.warm.start(hostType, determineDeleteTimeout(hconf), func(ctx context.Context, lg Logger) (buildlet.Client, error) {
+		return p.startBuildlet(ctx, hostType, hconf, lg, instItem, cpuItem)
+	})
+	return true
+}
+
+// Cool stops an idle buildlet of hostType started by Prewarm, and
+// reports whether there was one.
+func (p *GCEBuildlet) Cool(hostType string) bool {
+	return p.warm.cool(hostType)
+}
+
 // WriteHTMLStatus writes the status of the buildlet pool to an io.Writer.
 func (p *GCEBuildlet) WriteHTMLStatus(w io.Writer) {
 	fmt.Fprintf(w, "<b>GCE pool</b> capacity: %s", p.capacityString())
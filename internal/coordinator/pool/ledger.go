@@ -70,6 +70,29 @@ func (l *ledger) ReserveResources(ctx context.Context, instName, vmType string,
 	if err := item.Await(ctx); err != nil {
 		return err
 	}
+	l.addEntry(instName, instType, item)
+	return nil
+}
+
+// TryReserveResources is like ReserveResources, but reserves only spare
+// resources that nothing else is waiting for, and reports whether it
+// reserved them instead of waiting.
+func (l *ledger) TryReserveResources(instName, vmType string, si *queue.SchedItem) bool {
+	instType, err := l.PrepareReservationRequest(instName, vmType)
+	if err != nil || instType.CPU <= 0 {
+		return false
+	}
+	item := l.cpuQueue.TryEnqueue(int(instType.CPU), si)
+	if item == nil {
+		return false
+	}
+	l.addEntry(instName, instType, item)
+	return true
+}
+
+// addEntry records that the resources held by item are reserved for
+// instName.
+func (l *ledger) addEntry(instName string, instType *cloud.InstanceType, item *queue.Item) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
@@ -84,7 +107,6 @@ func (l *ledger) ReserveResources(ctx context.Context, instName, vmType string,
 			quota:        item,
 		}
 	}
-	return nil
 }
 
 // PrepareReservationRequest ensures all the preconditions necessary for a reservation request are
@@ -0,0 +1,488 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"maps"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/build/buildenv"
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal/coordinator/pool/queue"
+)
+
+var _ Buildlet = (*LocalBuildlet)(nil)
+
+// localBuildlet is the package level local buildlet pool. It is nil
+// unless NewLocalBuildlet has been called.
+//
+// TODO(golang.org/issues/38337) remove once a package level variable is no longer
+// required by the main package.
+var localBuildlet *LocalBuildlet
+
+// LocalBuildletPool retrieves the package level LocalBuildlet pool set by the
+// constructor, or nil if there is none.
+//
+// TODO(golang.org/issues/38337) remove once a package level variable is no longer
+// required by the main package.
+func LocalBuildletPool() *LocalBuildlet {
+	return localBuildlet
+}
+
+const (
+	// localContainerLabel labels the containers started by a LocalBuildlet pool.
+	localContainerLabel = "org.golang.build.pool=local"
+	// localContainerPort is the port buildlets listen on inside containers.
+	localContainerPort = "5936"
+	// defaultLocalMaxInstances is the default limit on the number of
+	// buildlets a LocalBuildlet pool runs at once.
+	defaultLocalMaxInstances = 4
+)
+
+// LocalOpt is optional configuration for the local buildlet pool.
+type LocalOpt func(*LocalBuildlet)
+
+// LocalOptContainerRuntime runs the buildlets of host types with a container
+// image in containers, using the Docker compatible command line tool at path,
+// such as "docker" or "podman". Without it, all buildlets run as processes.
+func LocalOptContainerRuntime(path string) LocalOpt {
+	return func(lb *LocalBuildlet) {
+		lb.containerRuntime = path
+	}
+}
+
+// LocalOptImageRegistry sets the registry the container images of host types
+// are pulled from. It defaults to the production project's gcr.io registry.
+func LocalOptImageRegistry(registry string) LocalOpt {
+	return func(lb *LocalBuildlet) {
+		lb.registry = strings.TrimSuffix(registry, "/")
+	}
+}
+
+// LocalOptMaxInstances limits the number of buildlets the pool runs at once.
+func LocalOptMaxInstances(n int) LocalOpt {
+	return func(lb *LocalBuildlet) {
+		lb.maxInstances = n
+	}
+}
+
+// LocalOptWorkDir sets the directory under which the work directories of
+// buildlets running as processes are created. It defaults to os.TempDir.
+func LocalOptWorkDir(dir string) LocalOpt {
+	return func(lb *LocalBuildlet) {
+		lb.workDir = dir
+	}
+}
+
+// LocalBuildlet manages a pool of buildlets running on the local machine,
+// either as processes or in containers. It lets the coordinator and the
+// gomote server run without any cloud resources.
+type LocalBuildlet struct {
+	// buildletBinary is the path of the cmd/buildlet binary to run. It must
+	// be built for the local machine.
+	buildletBinary string
+	// hosts provides the host configuration for all hosts. It is passed in to facilitate
+	// testing.
+	hosts map[string]*dashboard.HostConfig
+	// containerRuntime is the Docker compatible tool used to run containers,
+	// or empty to run all buildlets as processes.
+	containerRuntime string
+	// registry is the registry container images are pulled from.
+	registry string
+	// maxInstances is the limit on the number of running buildlets.
+	maxInstances int
+	// workDir is where the work directories of processes are created.
+	workDir string
+	// quota limits the number of running buildlets to maxInstances.
+	quota *queue.Quota
+
+	mu        sync.Mutex
+	instances map[string]*localInstance // keyed by instance name
+}
+
+// A localInstance is a buildlet started by a LocalBuildlet pool.
+type localInstance struct {
+	name     string
+	hostType string
+	created  time.Time
+	addr     string // the ip:port the buildlet listens on
+
+	// Set for buildlets running as processes.
+	cmd     *exec.Cmd
+	workDir string
+	exited  chan struct{} // closed once the process has exited
+
+	// Set for buildlets running in containers.
+	container string
+}
+
+// NewLocalBuildlet creates a new local buildlet pool which runs the buildlet
+// binary at buildletBinary, built for the local machine. Host types whose
+// GOOS and GOARCH match the local machine's run as processes. If a container
+// runtime is set, host types with a container image run in containers of
+// that image instead, which requires a Linux machine. Containers left behind
+// by previous pools are removed.
+func NewLocalBuildlet(buildletBinary string, hosts map[string]*dashboard.HostConfig, opts ...LocalOpt) (*LocalBuildlet, error) {
+	bin, err := filepath.Abs(buildletBinary)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(bin); err != nil {
+		return nil, fmt.Errorf("unable to create local pool: %w", err)
+	}
+	lb := &LocalBuildlet{
+		buildletBinary: bin,
+		hosts:          hosts,
+		registry:       "gcr.io/" + buildenv.Production.ProjectName,
+		maxInstances:   defaultLocalMaxInstances,
+		workDir:        os.TempDir(),
+		quota:          queue.NewQuota(),
+		instances:      make(map[string]*localInstance),
+	}
+	for _, opt := range opts {
+		opt(lb)
+	}
+	if lb.maxInstances < 1 {
+		return nil, errors.New("unable to create local pool: the instance limit must be positive")
+	}
+	if lb.containerRuntime != "" {
+		if runtime.GOOS != "linux" {
+			return nil, fmt.Errorf("unable to create local pool: containers are not supported on %s", runtime.GOOS)
+		}
+		if _, err := exec.LookPath(lb.containerRuntime); err != nil {
+			return nil, fmt.Errorf("unable to create local pool: %w", err)
+		}
+		lb.destroyUntrackedContainers()
+	}
+	lb.quota.UpdateLimit(lb.maxInstances)
+
+	// TODO(golang.org/issues/38337) remove once a package level variable is no longer
+	// required by the main package.
+	localBuildlet = lb
+	return lb, nil
+}
+
+// inContainer reports whether the buildlets of hconf run in containers.
+func (lb *LocalBuildlet) inContainer(hconf *dashboard.HostConfig) bool {
+	return lb.containerRuntime != "" && hconf.IsContainer()
+}
+
+// Supports reports whether the pool can run buildlets of the host type.
+func (lb *LocalBuildlet) Supports(hostType string) bool {
+	hconf, ok := lb.hosts[hostType]
+	if !ok {
+		return false
+	}
+	goos, goarch, _ := strings.Cut(hconf.HostArch, "-")
+	goarch, _, _ = strings.Cut(goarch, "-")
+	if lb.inContainer(hconf) {
+		return goos == "linux" && goarch == runtime.GOARCH
+	}
+	return goos == runtime.GOOS && goarch == runtime.GOARCH
+}
+
+// HostTypes returns the sorted host types the pool can run buildlets of.
+func (lb *LocalBuildlet) HostTypes() []string {
+	var hostTypes []string
+	for hostType := range lb.hosts {
+		if lb.Supports(hostType) {
+			hostTypes = append(hostTypes, hostType)
+		}
+	}
+	slices.Sort(hostTypes)
+	return hostTypes
+}
+
+// GetBuildlet starts a buildlet and returns a client for it.
+func (lb *LocalBuildlet) GetBuildlet(ctx context.Context, hostType string, lg Logger, si *queue.SchedItem) (buildlet.Client, error) {
+	hconf, ok := lb.hosts[hostType]
+	if !ok {
+		return nil, fmt.Errorf("local pool: unknown host type %q", hostType)
+	}
+	if !lb.Supports(hostType) {
+		return nil, fmt.Errorf("local pool: host type %q can't run on %s/%s", hostType, runtime.GOOS, runtime.GOARCH)
+	}
+	qsp := lg.CreateSpan("awaiting_local_quota")
+	err := lb.quota.AwaitQueue(ctx, 1, si)
+	qsp.Done(err)
+	if err != nil {
+		return nil, err
+	}
+	instName := instanceName(hostType, 7)
+	kp, err := buildlet.NewKeyPair()
+	if err != nil {
+		lb.quota.ReturnQuota(1)
+		log.Printf("failed to create TLS key pair for %s: %s", hostType, err)
+		return nil, fmt.Errorf("failed to create TLS key pair: %w", err)
+	}
+
+	sp := lg.CreateSpan("create_local_buildlet", instName)
+	inst := &localInstance{name: instName, hostType: hostType, created: time.Now()}
+	lb.mu.Lock()
+	lb.instances[instName] = inst
+	lb.mu.Unlock()
+	if lb.inContainer(hconf) {
+		log.Printf("Starting local container %q for %s", instName, hostType)
+		err = lb.startContainer(ctx, inst, hconf, kp)
+	} else {
+		log.Printf("Starting local buildlet process %q for %s", instName, hostType)
+		err = lb.startProcess(inst, kp)
+	}
+	if err != nil {
+		sp.Done(err)
+		log.Printf("local buildlet creation failed for %s: %v", hostType, err)
+		lb.buildletDone(instName)
+		return nil, err
+	}
+	bc := buildlet.NewClient(inst.addr, kp)
+	bc.SetDescription(fmt.Sprintf("local buildlet: %s", instName))
+	bc.SetOnHeartbeatFailure(func() {
+		log.Printf("local buildlet %q failed heartbeat", instName)
+		lb.buildletDone(instName)
+	})
+	bc.SetInstanceName(instName)
+	wsp := lg.CreateSpan("wait_buildlet_start", instName)
+	err = lb.waitBuildlet(ctx, inst, bc)
+	wsp.Done(err)
+	sp.Done(err)
+	if err != nil {
+		log.Printf("local buildlet %q failed to start: %v", instName, err)
+		lb.buildletDone(instName)
+		bc.Close()
+		return nil, err
+	}
+	return bc, nil
+}
+
+// buildletEnv returns the environment variables the buildlet reads its TLS
+// key pair and password from when it isn't running on a cloud provider.
+func buildletEnv(kp buildlet.KeyPair) []string {
+	return []string{
+		"META_tls_cert=" + kp.CertPEM,
+		"META_tls_key=" + kp.KeyPEM,
+		"META_password=" + kp.Password(),
+	}
+}
+
+// startProcess starts the buildlet of inst as a process.
+func (lb *LocalBuildlet) startProcess(inst *localInstance, kp buildlet.KeyPair) error {
+	addr, err := freeLocalAddr()
+	if err != nil {
+		return err
+	}
+	inst.addr = addr
+	inst.workDir, err = os.MkdirTemp(lb.workDir, inst.name+"-")
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(lb.buildletBinary, "--listen="+addr, "--halt=false", "--workdir="+inst.workDir)
+	cmd.Env = append(os.Environ(), buildletEnv(kp)...)
+	// Run the buildlet in a process group of its own, so that it can be
+	// killed along with the processes it started.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	inst.cmd = cmd
+	inst.exited = make(chan struct{})
+	go func() {
+		err := cmd.Wait()
+		log.Printf("local buildlet process %q exited: %v", inst.name, err)
+		close(inst.exited)
+	}()
+	return nil
+}
+
+// freeLocalAddr returns a loopback address with a port that's currently
+// free.
+func freeLocalAddr() (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer ln.Close()
+	return ln.Addr().String(), nil
+}
+
+// startContainer starts the buildlet of inst in a container of the image of
+// hconf. The buildlet binary is mounted into the container and replaces the
+// image's entrypoint, which would otherwise download a buildlet using the
+// metadata of a cloud instance.
+func (lb *LocalBuildlet) startContainer(ctx context.Context, inst *localInstance, hconf *dashboard.HostConfig, kp buildlet.KeyPair) error {
+	const bin = "/usr/local/bin/buildlet"
+	image := lb.registry + "/" + hconf.ContainerImage
+	args := []string{"run", "--detach", "--rm",
+		"--name=" + inst.name,
+		"--label=" + localContainerLabel,
+		"--publish=127.0.0.1::" + localContainerPort,
+		"--volume=" + lb.buildletBinary + ":" + bin + ":ro",
+		"--entrypoint=" + bin,
+	}
+	// Pass the key pair through the environment of the runtime rather
+	// than on its command line.
+	env := buildletEnv(kp)
+	for _, kv := range env {
+		k, _, _ := strings.Cut(kv, "=")
+		args = append(args, "--env="+k)
+	}
+	args = append(args, image, "--listen=:"+localContainerPort, "--halt=false")
+	cmd := exec.CommandContext(ctx, lb.containerRuntime, args...)
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s run %s: %v: %s", lb.containerRuntime, image, err, out)
+	}
+	inst.container = inst.name
+	out, err = exec.CommandContext(ctx, lb.containerRuntime, "port", inst.container, localContainerPort+"/tcp").Output()
+	if err != nil {
+		return fmt.Errorf("%s port %s: %w", lb.containerRuntime, inst.container, err)
+	}
+	// There may be a line for each address family.
+	for line := range strings.Lines(string(out)) {
+		if addr := strings.TrimSpace(line); strings.HasPrefix(addr, "127.0.0.1:") {
+			inst.addr = addr
+			return nil
+		}
+	}
+	return fmt.Errorf("no published port for container %s: %q", inst.container, out)
+}
+
+// waitBuildlet waits for the buildlet of inst to answer status requests
+// from bc.
+func (lb *LocalBuildlet) waitBuildlet(ctx context.Context, inst *localInstance, bc buildlet.Client) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	for {
+		sctx, scancel := context.WithTimeout(ctx, 5*time.Second)
+		_, err := bc.Status(sctx)
+		scancel()
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("buildlet %s didn't start: %w", inst.name, err)
+		case <-inst.exited: // nil for containers
+			return fmt.Errorf("buildlet %s exited before starting", inst.name)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// buildletDone stops the buildlet of an instance, removes what's left of it,
+// and releases its place in the pool. It does nothing if the instance is
+// already gone.
+func (lb *LocalBuildlet) buildletDone(instName string) {
+	lb.mu.Lock()
+	inst, ok := lb.instances[instName]
+	delete(lb.instances, instName)
+	lb.mu.Unlock()
+	if !ok {
+		return
+	}
+	defer lb.quota.ReturnQuota(1)
+	if inst.cmd != nil {
+		syscall.Kill(-inst.cmd.Process.Pid, syscall.SIGKILL)
+		<-inst.exited
+	}
+	if inst.workDir != "" {
+		if err := os.RemoveAll(inst.workDir); err != nil {
+			log.Printf("local buildlet %s: removing work directory: %v", instName, err)
+		}
+	}
+	if inst.container != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if out, err := exec.CommandContext(ctx, lb.containerRuntime, "rm", "--force", inst.container).CombinedOutput(); err != nil {
+			log.Printf("local container %s deletion failed: %v: %s", inst.container, err, out)
+		}
+	}
+}
+
+// destroyUntrackedContainers removes the containers left behind by previous
+// local pools.
+func (lb *LocalBuildlet) destroyUntrackedContainers() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, lb.containerRuntime, "ps", "--all", "--quiet", "--filter=label="+localContainerLabel).Output()
+	if err != nil {
+		log.Printf("failed to query for local containers: %v", err)
+		return
+	}
+	ids := strings.Fields(string(out))
+	if len(ids) == 0 {
+		return
+	}
+	log.Printf("removing %d untracked local containers", len(ids))
+	if out, err := exec.CommandContext(ctx, lb.containerRuntime, append([]string{"rm", "--force"}, ids...)...).CombinedOutput(); err != nil {
+		log.Printf("failed cleaning local containers: %v: %s", err, out)
+	}
+}
+
+func (lb *LocalBuildlet) QuotaStats() map[string]*queue.QuotaStats {
+	return map[string]*queue.QuotaStats{
+		"local": lb.quota.ToExported(),
+	}
+}
+
+// String gives a report of capacity usage for the local buildlet pool.
+func (lb *LocalBuildlet) String() string {
+	return fmt.Sprintf("Local pool capacity: %s", lb.capacityString())
+}
+
+// capacityString() gives a report of capacity usage.
+func (lb *LocalBuildlet) capacityString() string {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	return fmt.Sprintf("%d/%d instances", len(lb.instances), lb.maxInstances)
+}
+
+// WriteHTMLStatus writes the status of the local buildlet pool to an io.Writer.
+func (lb *LocalBuildlet) WriteHTMLStatus(w io.Writer) {
+	fmt.Fprintf(w, "<b>Local pool</b> capacity: %s", lb.capacityString())
+
+	lb.mu.Lock()
+	insts := slices.SortedFunc(maps.Values(lb.instances), func(a, b *localInstance) int {
+		return a.created.Compare(b.created)
+	})
+	lb.mu.Unlock()
+	if len(insts) > 0 {
+		fmt.Fprintf(w, "<ul>")
+		for _, inst := range insts {
+			fmt.Fprintf(w, "<li>%v, %s</li>\n", html.EscapeString(inst.name), friendlyDuration(time.Since(inst.created)))
+		}
+		fmt.Fprintf(w, "</ul>")
+	}
+}
+
+// Close stops all the buildlets of the pool.
+func (lb *LocalBuildlet) Close() {
+	lb.mu.Lock()
+	names := slices.Collect(maps.Keys(lb.instances))
+	lb.mu.Unlock()
+	for _, name := range names {
+		lb.buildletDone(name)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package pool
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal/coordinator/pool/queue"
+)
+
+var localTestHosts = map[string]*dashboard.HostConfig{
+	"host-local": {
+		HostType: "host-local",
+		HostArch: runtime.GOOS + "-" + runtime.GOARCH,
+	},
+	"host-local-container": {
+		HostType:       "host-local-container",
+		HostArch:       "linux-" + runtime.GOARCH,
+		ContainerImage: "linux-x86-bookworm:latest",
+	},
+	"host-other": {
+		HostType: "host-other",
+		HostArch: "plan9-386",
+	},
+}
+
+// buildBuildlet builds cmd/buildlet and returns the path of the binary.
+func buildBuildlet(t *testing.T) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping test that builds the buildlet in short mode")
+	}
+	goBin := filepath.Join(runtime.GOROOT(), "bin", "go")
+	bin := filepath.Join(t.TempDir(), "buildlet")
+	out, err := exec.Command(goBin, "build", "-o", bin, "golang.org/x/build/cmd/buildlet").CombinedOutput()
+	if err != nil {
+		t.Fatalf("building buildlet: %v\n%s", err, out)
+	}
+	return bin
+}
+
+func TestLocalBuildletSupports(t *testing.T) {
+	bin := filepath.Join(t.TempDir(), "buildlet")
+	if err := os.WriteFile(bin, nil, 0755); err != nil {
+		t.Fatal(err)
+	}
+	lb, err := NewLocalBuildlet(bin, localTestHosts)
+	if err != nil {
+		t.Fatalf("NewLocalBuildlet() = _, %s; want no error", err)
+	}
+	// Without a container runtime, host types with a container image
+	// run as processes too.
+	want := []string{"host-local"}
+	if runtime.GOOS == "linux" {
+		want = append(want, "host-local-container")
+	}
+	if got := lb.HostTypes(); !slices.Equal(got, want) {
+		t.Errorf("HostTypes() = %q; want %q", got, want)
+	}
+	for _, hostType := range []string{"host-other", "host-unknown"} {
+		if lb.Supports(hostType) {
+			t.Errorf("Supports(%q) = true; want false", hostType)
+		}
+	}
+	if _, err := lb.GetBuildlet(context.Background(), "host-other", noopEventTimeLogger{}, new(queue.SchedItem)); err == nil {
+		t.Errorf("GetBuildlet(ctx, %q) = _, nil; want error", "host-other")
+	}
+}
+
+func TestLocalBuildletGetBuildlet(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("exec test uses a Linux shell command")
+	}
+	bin := buildBuildlet(t)
+	workDir := t.TempDir()
+	lb, err := NewLocalBuildlet(bin, localTestHosts, LocalOptMaxInstances(1), LocalOptWorkDir(workDir))
+	if err != nil {
+		t.Fatalf("NewLocalBuildlet() = _, %s; want no error", err)
+	}
+	defer lb.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	bc, err := lb.GetBuildlet(ctx, "host-local", noopEventTimeLogger{}, new(queue.SchedItem))
+	if err != nil {
+		t.Fatalf("GetBuildlet() = _, %s; want no error", err)
+	}
+	if !strings.HasPrefix(bc.InstanceName(), "buildlet-local-rn") {
+		t.Errorf("InstanceName() = %q; want buildlet-local-rn prefix", bc.InstanceName())
+	}
+	if got, want := lb.capacityString(), "1/1 instances"; got != want {
+		t.Errorf("capacityString() = %q; want %q", got, want)
+	}
+	var out bytes.Buffer
+	remoteErr, execErr := bc.Exec(ctx, "/bin/sh", buildlet.ExecOpts{
+		Output:      &out,
+		Args:        []string{"-c", "echo hello"},
+		SystemLevel: true,
+	})
+	if execErr != nil || remoteErr != nil {
+		t.Fatalf("Exec() = %v, %v; want no error", remoteErr, execErr)
+	}
+	if got, want := out.String(), "hello\n"; got != want {
+		t.Errorf("Exec() output = %q; want %q", got, want)
+	}
+
+	// The pool is full, so another buildlet has to wait for this one.
+	wctx, wcancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer wcancel()
+	if _, err := lb.GetBuildlet(wctx, "host-local", noopEventTimeLogger{}, new(queue.SchedItem)); err == nil {
+		t.Fatalf("GetBuildlet() on a full pool = _, nil; want error")
+	}
+
+	bc.Close()
+	for lb.capacityString() != "0/1 instances" {
+		if ctx.Err() != nil {
+			t.Fatalf("buildlet still running after Close: %s", lb.capacityString())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if ents, err := os.ReadDir(workDir); err != nil || len(ents) != 0 {
+		t.Errorf("work directory contents after Close = %v, %v; want none", ents, err)
+	}
+}
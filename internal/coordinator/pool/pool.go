@@ -114,6 +114,7 @@ func isBuildlet(name string) bool {
 var TestPoolHook func(*dashboard.HostConfig) Buildlet
 
 // ForHost returns the appropriate buildlet depending on the host configuration that is passed it.
+// If a local buildlet pool has been created, it is used for all the host types it supports.
 // The returned buildlet can be overridden for testing purposes by registering a test hook.
 func ForHost(conf *dashboard.HostConfig) Buildlet {
 	if TestPoolHook != nil {
@@ -123,6 +124,8 @@ func ForHost(conf *dashboard.HostConfig) Buildlet {
 		panic("nil conf")
 	}
 	switch {
+	case localBuildlet != nil && localBuildlet.Supports(conf.HostType):
+		return localBuildlet
 	case conf.IsEC2:
 		return EC2BuildetPool()
 	case conf.IsVM(), conf.IsContainer():
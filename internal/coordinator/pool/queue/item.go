@@ -45,6 +45,12 @@ type SchedItem struct {
 	CommitTime  time.Time
 	RequestTime time.Time
 	User        string
+
+	// Ordering, if non-nil, orders the item in queues relative to the
+	// items with the same Ordering, in place of Less. Orderings are
+	// compared with ==. A Scheduler sets it to order its items by its
+	// Policy.
+	Ordering Ordering `json:"-"`
 }
 
 // Priority returns the BuildletPriority for a SchedItem.
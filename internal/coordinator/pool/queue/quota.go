@@ -13,6 +13,22 @@ import (
 	"sync"
 )
 
+// An Ordering decides which of the items waiting for quota get it first.
+// Its result may change over time, for example with past usage.
+type Ordering interface {
+	// Less reports whether a should get quota before b.
+	Less(a, b *SchedItem) bool
+}
+
+// less reports whether a should get quota before b: by their Ordering
+// if they have the same one, and by SchedItem.Less otherwise.
+func less(a, b *SchedItem) bool {
+	if a.Ordering != nil && a.Ordering == b.Ordering {
+		return a.Ordering.Less(a, b)
+	}
+	return a.Less(b)
+}
+
 // NewQuota returns an initialized *Quota ready for use.
 func NewQuota() *Quota {
 	return &Quota{
@@ -60,10 +76,14 @@ func (q *Quota) updated() {
 func (q *Quota) tryPop() *Item {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	if !(q.queue.Len() != 0 && q.queue.Peek().cost <= q.limit-q.used-q.untrackedUsed) {
+	if q.queue.Len() == 0 {
+		return nil
+	}
+	i := q.queue.First()
+	if (*q.queue)[i].cost > q.limit-q.used-q.untrackedUsed {
 		return nil
 	}
-	b := q.queue.PopBuildlet()
+	b := heap.Remove(q.queue, i).(*Item)
 	q.used += b.cost
 	b.ready()
 	return b
@@ -133,6 +153,28 @@ func (q *Quota) Quotas() Usage {
 // Enqueue a build and return an Item. See Item's documentation for
 // waiting and releasing quota.
 func (q *Quota) Enqueue(cost int, si *SchedItem) *Item {
+	item := q.newItem(cost, si)
+	q.push(item)
+	return item
+}
+
+// TryEnqueue is like Enqueue, but returns an Item already holding its
+// quota, or nil if the quota isn't available or other items are waiting
+// for it. It is for work that should only use spare quota.
+func (q *Quota) TryEnqueue(cost int, si *SchedItem) *Item {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.queue.Len() != 0 || cost > q.limit-q.used-q.untrackedUsed {
+		return nil
+	}
+	item := q.newItem(cost, si)
+	item.index = -1
+	q.used += cost
+	item.ready()
+	return item
+}
+
+func (q *Quota) newItem(cost int, si *SchedItem) *Item {
 	item := &Item{
 		cost:    cost,
 		release: func() { q.ReturnQuota(cost) },
@@ -140,7 +182,6 @@ func (q *Quota) Enqueue(cost int, si *SchedItem) *Item {
 		build:   si,
 	}
 	item.cancel = func() { q.cancel(item) }
-	q.push(item)
 	return item
 }
 
@@ -183,7 +224,7 @@ func (q *Quota) ToExported() *QuotaStats {
 	q.mu.Unlock()
 
 	sort.Slice(qs.Items, func(i, j int) bool {
-		return qs.Items[i].Build.Less(qs.Items[j].Build)
+		return less(qs.Items[i].Build, qs.Items[j].Build)
 	})
 	return qs
 }
@@ -242,12 +283,15 @@ func (i *Item) ready() {
 }
 
 // A buildletQueue implements heap.Interface and holds Items.
+//
+// An Ordering's result may change while items wait, which breaks the heap
+// invariant, so the first item is found with First instead of by position.
 type buildletQueue []*Item
 
 func (q buildletQueue) Len() int { return len(q) }
 
 func (q buildletQueue) Less(i, j int) bool {
-	return q[i].build.Less(q[j].build)
+	return less(q[i].build, q[j].build)
 }
 
 func (q buildletQueue) Swap(i, j int) {
@@ -273,10 +317,13 @@ func (q *buildletQueue) Pop() any {
 	return item
 }
 
-func (q *buildletQueue) PopBuildlet() *Item {
-	return heap.Pop(q).(*Item)
-}
-
-func (q buildletQueue) Peek() *Item {
-	return q[0]
+// First returns the index of the item that should get quota first.
+func (q buildletQueue) First() int {
+	first := 0
+	for i := 1; i < len(q); i++ {
+		if q.Less(i, first) {
+			first = i
+		}
+	}
+	return first
 }
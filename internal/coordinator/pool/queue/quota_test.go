@@ -8,6 +8,7 @@ package queue
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -220,3 +221,87 @@ func TestQueueToExported(t *testing.T) {
 		t.Errorf("q.ToExported() mismatch (-want +got):\n%s", diff)
 	}
 }
+
+// userOrdering prefers items of the users in order.
+type userOrdering []string
+
+func (o *userOrdering) Less(a, b *SchedItem) bool {
+	return slices.Index(*o, a.User) < slices.Index(*o, b.User)
+}
+
+func TestQueueOrdering(t *testing.T) {
+	order := &userOrdering{"gopher", "rsc"}
+
+	q := NewQuota()
+	q.UpdateQuotas(1, 1)
+	items := map[string]*Item{
+		"rsc":    q.Enqueue(1, &SchedItem{User: "rsc", Ordering: order}),
+		"gopher": q.Enqueue(1, &SchedItem{User: "gopher", Ordering: order}),
+	}
+	if got := q.ToExported().Items[0].Build.User; got != "gopher" {
+		t.Errorf("first exported item is for %q; want %q", got, "gopher")
+	}
+	// The ordering changes while the items wait.
+	(*order)[0], (*order)[1] = (*order)[1], (*order)[0]
+	for _, user := range *order {
+		q.ReturnQuota(1)
+		select {
+		case <-items[user].popped:
+		case <-time.After(time.Second):
+			t.Fatalf("item for %q not popped; want popped in order %q", user, order)
+		}
+	}
+}
+
+func TestQueueMixedOrderings(t *testing.T) {
+	// Items with different Orderings, or none, are ordered by
+	// SchedItem.Less, so one Ordering doesn't reorder another's items.
+	q := NewQuota()
+	q.Enqueue(1, &SchedItem{User: "gopher", Ordering: &userOrdering{"gopher"}})
+	q.Enqueue(1, &SchedItem{User: "rsc", IsRelease: true, Ordering: &userOrdering{"rsc"}})
+	q.Enqueue(1, &SchedItem{User: "bradfitz", IsGomote: true})
+	var got []string
+	for _, it := range q.ToExported().Items {
+		got = append(got, it.Build.User)
+	}
+	if want := []string{"rsc", "bradfitz", "gopher"}; !slices.Equal(got, want) {
+		t.Errorf("exported items are for %q; want %q", got, want)
+	}
+}
+
+func TestQueueTryEnqueue(t *testing.T) {
+	q := NewQuota()
+	q.UpdateQuotas(0, 2)
+	item := q.TryEnqueue(2, new(SchedItem))
+	if item == nil {
+		t.Fatalf("q.TryEnqueue(2) = nil with 2 available; want item")
+	}
+	if got := q.Quotas().Used; got != 2 {
+		t.Errorf("used quota after TryEnqueue = %d; want 2", got)
+	}
+	if item := q.TryEnqueue(1, new(SchedItem)); item != nil {
+		t.Errorf("q.TryEnqueue(1) = item with none available; want nil")
+	}
+	// Waiting items go first.
+	waiting := q.Enqueue(1, new(SchedItem))
+	q.UpdateLimit(4)
+	if item := q.TryEnqueue(1, new(SchedItem)); item == nil {
+		t.Errorf("q.TryEnqueue(1) = nil after the waiting item got quota; want item")
+	}
+	if err := waiting.Await(context.Background()); err != nil {
+		t.Errorf("waiting.Await() = %v; want no error", err)
+	}
+	// An item that doesn't fit in the spare quota still goes first.
+	big := q.Enqueue(2, new(SchedItem))
+	q.UpdateLimit(5)
+	if item := q.TryEnqueue(1, new(SchedItem)); item != nil {
+		t.Errorf("q.TryEnqueue(1) = item while an item waits; want nil")
+	}
+	item.ReturnQuota()
+	if err := big.Await(context.Background()); err != nil {
+		t.Errorf("big.Await() = %v; want no error", err)
+	}
+	if got := q.Quotas().Used; got != 4 {
+		t.Errorf("used quota = %d; want 4", got)
+	}
+}
@@ -48,6 +48,7 @@ import (
 	"golang.org/x/build/buildlet"
 	"golang.org/x/build/dashboard"
 	"golang.org/x/build/internal/coordinator/pool/queue"
+	"golang.org/x/build/internal/muxconn"
 	"golang.org/x/build/revdial/v2"
 )
 
@@ -468,7 +469,7 @@ type reverseBuildlet struct {
 	sessRand string
 
 	client  buildlet.Client
-	conn    net.Conn
+	conn    reverseConn
 	regTime time.Time // when it was first connected
 
 	// hostType is the configuration of this machine.
@@ -484,6 +485,13 @@ type reverseBuildlet struct {
 	inHealthCheck bool
 }
 
+// reverseConn is the connection to a reverse buildlet: a net.Conn, or a
+// *muxconn.Session, which outlives the connections it's carried by.
+type reverseConn interface {
+	io.Closer
+	RemoteAddr() net.Addr
+}
+
 // HandleReverse handles reverse buildlet connections.
 func HandleReverse(w http.ResponseWriter, r *http.Request) {
 	if r.TLS == nil {
@@ -531,19 +539,42 @@ func HandleReverse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := (&http.Response{StatusCode: http.StatusSwitchingProtocols, Proto: "HTTP/1.1"}).Write(conn); err != nil {
+	muxMode := r.Header.Get(muxconn.Header)
+	res := &http.Response{StatusCode: http.StatusSwitchingProtocols, Proto: "HTTP/1.1"}
+	if muxMode != "" {
+		res.Header = http.Header{muxconn.Header: {"1"}}
+	}
+	if err := res.Write(conn); err != nil {
 		log.Printf("error writing upgrade response to reverse buildlet %s (%s) at %s: %v", hostname, hostType, r.RemoteAddr, err)
 		conn.Close()
 		return
 	}
 
+	var (
+		rconn         reverseConn = conn
+		revDialerDone <-chan struct{}
+		dialer        func(context.Context) (net.Conn, error)
+	)
+	if muxMode != "" {
+		// The buildlet multiplexes its connections over a session,
+		// which it resumes after reconnecting.
+		sess, resumed, err := muxconn.Server(conn, nil)
+		if err != nil {
+			log.Printf("error starting session with reverse buildlet %s (%s) at %s: %v", hostname, hostType, r.RemoteAddr, err)
+			return
+		}
+		if resumed {
+			return
+		}
+		rconn, revDialerDone, dialer = sess, sess.Done(), sess.Dial
+	} else {
+		revDialer := revdial.NewDialer(conn, "/revdial")
+		revDialerDone, dialer = revDialer.Done(), revDialer.Dial
+	}
+
 	log.Printf("Registering reverse buildlet %q (%s) for host type %v; buildletVersion=%v",
 		hostname, r.RemoteAddr, hostType, buildletVersion)
 
-	revDialer := revdial.NewDialer(conn, "/revdial")
-	revDialerDone := revDialer.Done()
-	dialer := revDialer.Dial
-
 	client := buildlet.NewClient(hostname, buildlet.NoKeyPair)
 	client.SetHTTPClient(&http.Client{
 		Transport: &http.Transport{
@@ -563,7 +594,7 @@ func HandleReverse(w http.ResponseWriter, r *http.Request) {
 		isDead.Lock()
 		isDead.v = true
 		isDead.Unlock()
-		conn.Close()
+		rconn.Close()
 		reversePool.nukeBuildlet(client)
 	})
 
@@ -583,12 +614,12 @@ func HandleReverse(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		log.Printf("Reverse connection %s/%s for %s did not answer status after %v: %v",
 			hostname, r.RemoteAddr, hostType, time.Since(tstatus), err)
-		conn.Close()
+		rconn.Close()
 		return
 	}
 	if status.Version < minBuildletVersion {
 		log.Printf("Buildlet too old (need version %d or newer): %s, %+v", minBuildletVersion, r.RemoteAddr, status)
-		conn.Close()
+		rconn.Close()
 		return
 	}
 	log.Printf("Buildlet %s/%s: %+v for %s", hostname, r.RemoteAddr, status, hostType)
@@ -599,7 +630,7 @@ func HandleReverse(w http.ResponseWriter, r *http.Request) {
 		version:   buildletVersion,
 		hostType:  hostType,
 		client:    client,
-		conn:      conn,
+		conn:      rconn,
 		inUseTime: now,
 		regTime:   now,
 	}
@@ -0,0 +1,198 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package pool
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/internal/spanlog"
+)
+
+// warmBuildlets holds the buildlets a pool started ahead of demand, until
+// GetBuildlet hands them out. The zero value is ready to use.
+type warmBuildlets struct {
+	mu       sync.Mutex
+	idle     map[string][]warmBuildlet // host type -> started buildlets
+	starting map[string]int            // host type -> buildlets starting
+
+	now func() time.Time // for tests; nil means time.Now
+}
+
+// A warmBuildlet is an idle buildlet started ahead of demand.
+type warmBuildlet struct {
+	bc      buildlet.Client
+	created time.Time // when its VM was requested
+	// stale is when it's too close to being deleted by its delete
+	// timeout to be handed out.
+	stale time.Time
+}
+
+// warmMaxAge returns how long after its creation a warm buildlet with
+// deleteTimeout may still be handed out. The rest of its lifetime is
+// left for the build that gets it.
+func warmMaxAge(deleteTimeout time.Duration) time.Duration {
+	return deleteTimeout / 4
+}
+
+func (w *warmBuildlets) timeNow() time.Time {
+	if w.now != nil {
+		return w.now()
+	}
+	return time.Now()
+}
+
+// count returns the number of buildlets of hostType that are idle or
+// starting.
+func (w *warmBuildlets) count(hostType string) int {
+	w.mu.Lock()
+	stale := w.pruneLocked(hostType)
+	n := len(w.idle[hostType]) + w.starting[hostType]
+	w.mu.Unlock()
+	closeAll(stale)
+	return n
+}
+
+// start starts a buildlet of hostType with create, which holds the
+// quota for it. The buildlet is deleted deleteTimeout after it's
+// created.
+func (w *warmBuildlets) start(hostType string, deleteTimeout time.Duration, create func(ctx context.Context, lg Logger) (buildlet.Client, error)) {
+	w.mu.Lock()
+	if w.starting == nil {
+		w.starting = make(map[string]int)
+		w.idle = make(map[string][]warmBuildlet)
+	}
+	w.starting[hostType]++
+	w.mu.Unlock()
+
+	go func() {
+		created := w.timeNow()
+		bc, err := create(context.Background(), warmLogger{hostType})
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.starting[hostType]--
+		if err != nil {
+			log.Printf("prewarming a %s buildlet failed: %v", hostType, err)
+			return
+		}
+		w.idle[hostType] = append(w.idle[hostType], warmBuildlet{bc, created, created.Add(warmMaxAge(deleteTimeout))})
+	}()
+}
+
+// take returns the most recently created idle buildlet of hostType, or
+// nil if there is none.
+func (w *warmBuildlets) take(hostType string) buildlet.Client {
+	w.mu.Lock()
+	stale := w.pruneLocked(hostType)
+	var bc buildlet.Client
+	if i := w.newestLocked(hostType); i >= 0 {
+		bc = w.idle[hostType][i].bc
+		w.idle[hostType] = slices.Delete(w.idle[hostType], i, i+1)
+	}
+	w.mu.Unlock()
+	closeAll(stale)
+	return bc
+}
+
+// cool stops the oldest idle buildlet of hostType, which is the closest
+// to going stale, and reports whether there was one.
+func (w *warmBuildlets) cool(hostType string) bool {
+	w.mu.Lock()
+	stale := w.pruneLocked(hostType)
+	idle := w.idle[hostType]
+	if len(idle) == 0 {
+		w.mu.Unlock()
+		closeAll(stale)
+		return len(stale) > 0
+	}
+	oldest := 0
+	for i, wb := range idle {
+		if wb.created.Before(idle[oldest].created) {
+			oldest = i
+		}
+	}
+	stale = append(stale, idle[oldest].bc)
+	w.idle[hostType] = slices.Delete(idle, oldest, oldest+1)
+	w.mu.Unlock()
+	closeAll(stale)
+	return true
+}
+
+// newestLocked returns the index of the most recently created idle
+// buildlet of hostType, or -1 if there is none. w.mu must be held.
+func (w *warmBuildlets) newestLocked(hostType string) int {
+	newest := -1
+	for i, wb := range w.idle[hostType] {
+		if newest < 0 || wb.created.After(w.idle[hostType][newest].created) {
+			newest = i
+		}
+	}
+	return newest
+}
+
+// pruneLocked drops the idle buildlets of hostType that died, whose
+// heartbeat failure already released their resources, and removes
+// those that went stale, which it returns for the caller to close once
+// w.mu is released. w.mu must be held.
+func (w *warmBuildlets) pruneLocked(hostType string) (stale []buildlet.Client) {
+	if len(w.idle[hostType]) == 0 {
+		return nil
+	}
+	now := w.timeNow()
+	live := w.idle[hostType][:0]
+	for _, wb := range w.idle[hostType] {
+		switch {
+		case wb.bc.IsBroken():
+		case !now.Before(wb.stale):
+			log.Printf("prewarm %s: stopping %s, created %v ago", hostType, wb.bc.InstanceName(), now.Sub(wb.created).Round(time.Second))
+			stale = append(stale, wb.bc)
+		default:
+			live = append(live, wb)
+		}
+	}
+	clear(w.idle[hostType][len(live):])
+	w.idle[hostType] = live
+	return stale
+}
+
+// closeAll closes bcs, which releases their resources.
+func closeAll(bcs []buildlet.Client) {
+	for _, bc := range bcs {
+		bc.Close()
+	}
+}
+
+// warmLogger logs the events of starting a buildlet ahead of demand.
+type warmLogger struct {
+	hostType string
+}
+
+func (l warmLogger) LogEventTime(event string, optText ...string) {
+	log.Printf("prewarm %s: %s %s", l.hostType, event, strings.Join(optText, " "))
+}
+
+func (l warmLogger) CreateSpan(event string, optText ...string) spanlog.Span {
+	return &warmSpan{lg: l, event: event, start: time.Now()}
+}
+
+type warmSpan struct {
+	lg    warmLogger
+	event string
+	start time.Time
+}
+
+func (s *warmSpan) Done(err error) error {
+	if err != nil {
+		s.lg.LogEventTime(s.event, "failed after", time.Since(s.start).String(), err.Error())
+	}
+	return err
+}
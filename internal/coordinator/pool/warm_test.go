@@ -0,0 +1,110 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package pool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/build/buildlet"
+)
+
+// warmTestClient is a fake buildlet that records whether it was closed.
+type warmTestClient struct {
+	*buildlet.FakeClient
+	mu     sync.Mutex
+	closed bool
+}
+
+func (c *warmTestClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func (c *warmTestClient) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func TestWarmBuildletsAge(t *testing.T) {
+	const host = "host-test"
+	var mu sync.Mutex
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	setNow := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+	w := &warmBuildlets{now: func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}}
+
+	// Start a, b and c ten minutes apart. With a delete timeout of 2h,
+	// they may be handed out for 30 minutes.
+	clients := map[string]*warmTestClient{}
+	for i, name := range []string{"a", "b", "c"} {
+		if i > 0 {
+			setNow(10 * time.Minute)
+		}
+		bc := &warmTestClient{FakeClient: &buildlet.FakeClient{}}
+		bc.SetInstanceName(name)
+		clients[name] = bc
+		w.start(host, 2*time.Hour, func(context.Context, Logger) (buildlet.Client, error) {
+			return bc, nil
+		})
+		for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+			w.mu.Lock()
+			started := len(w.idle[host]) == i+1
+			w.mu.Unlock()
+			if started {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("buildlet %q didn't start", name)
+			}
+		}
+	}
+
+	// The newest buildlet is handed out first.
+	if bc := w.take(host); bc == nil || bc.InstanceName() != "c" {
+		t.Fatalf("take = %v; want buildlet c", bc)
+	}
+	// The oldest is stopped first.
+	if !w.cool(host) || !clients["a"].isClosed() {
+		t.Errorf("cool didn't stop buildlet a")
+	}
+	if got := w.count(host); got != 1 {
+		t.Errorf("count = %d; want 1", got)
+	}
+
+	// b goes stale 30 minutes after it was created, and is stopped
+	// rather than handed out.
+	setNow(19 * time.Minute)
+	if got := w.count(host); got != 1 {
+		t.Errorf("count = %d after 29 minutes; want 1", got)
+	}
+	setNow(time.Minute)
+	if bc := w.take(host); bc != nil {
+		t.Errorf("take = %v; want nil once buildlet b is stale", bc.InstanceName())
+	}
+	if !clients["b"].isClosed() {
+		t.Errorf("stale buildlet b wasn't stopped")
+	}
+	if got := w.count(host); got != 0 {
+		t.Errorf("count = %d; want 0", got)
+	}
+	if clients["c"].isClosed() {
+		t.Errorf("buildlet c was stopped after it was handed out")
+	}
+}
@@ -43,7 +43,7 @@ func (rb *Buildlet) Renew(ctx context.Context, rbs *Buildlets) {
 	default:
 	}
 	if got := rbs.M[rb.Name]; got == rb {
-		rb.Expires = time.Now().Add(remoteBuildletIdleTimeout)
+		rb.Expires = time.Now().Add(IdleTimeout)
 		time.AfterFunc(time.Minute, func() { rb.Renew(ctx, rbs) })
 	}
 }
@@ -18,10 +18,11 @@ import (
 	"golang.org/x/build/internal"
 )
 
-const (
-	remoteBuildletIdleTimeout   = 30 * time.Minute
-	remoteBuildletCleanInterval = time.Minute
-)
+// IdleTimeout is how long a session can stay unused before it expires
+// and its buildlet is destroyed.
+const IdleTimeout = 30 * time.Minute
+
+const remoteBuildletCleanInterval = time.Minute
 
 // Session stores the metadata for a remote buildlet Session.
 type Session struct {
@@ -30,6 +31,7 @@ type Session struct {
 	Expires        time.Time
 	HostType       string
 	ID             string // unique identifier for instance "user-bradfitz-linux-amd64-0"
+	LastUsed       time.Time
 	OwnerID        string // identity aware proxy user id: "accounts.google.com:userIDvalue"
 	SwarmingTaskID string // swarming task ID (if the session is a swarming task).
 	buildlet       buildlet.Client
@@ -38,7 +40,8 @@ type Session struct {
 // renew extends the expiration timestamp for a session.
 // The SessionPool lock should be held before calling.
 func (s *Session) renew() {
-	s.Expires = time.Now().Add(remoteBuildletIdleTimeout)
+	s.LastUsed = time.Now()
+	s.Expires = s.LastUsed.Add(IdleTimeout)
 }
 
 // isExpired determines if the remote buildlet session has expired.
@@ -86,9 +89,10 @@ func (sp *SessionPool) AddSession(ownerID, username, builderType, hostType, swar
 				BuilderType:    builderType,
 				buildlet:       bc,
 				Created:        now,
-				Expires:        now.Add(remoteBuildletIdleTimeout),
+				Expires:        now.Add(IdleTimeout),
 				HostType:       hostType,
 				ID:             name,
+				LastUsed:       now,
 				OwnerID:        ownerID,
 				SwarmingTaskID: swarmingTaskID,
 			}
@@ -169,6 +173,7 @@ func (sp *SessionPool) List() []*Session {
 			Expires:        s.Expires,
 			HostType:       s.HostType,
 			ID:             s.ID,
+			LastUsed:       s.LastUsed,
 			OwnerID:        s.OwnerID,
 			Created:        s.Created,
 			SwarmingTaskID: s.SwarmingTaskID,
@@ -195,9 +200,11 @@ func (sp *SessionPool) Session(buildletName string) (*Session, error) {
 		s.renew()
 		return &Session{
 			BuilderType:    s.BuilderType,
+			Created:        s.Created,
 			Expires:        s.Expires,
 			HostType:       s.HostType,
 			ID:             s.ID,
+			LastUsed:       s.LastUsed,
 			OwnerID:        s.OwnerID,
 			SwarmingTaskID: s.SwarmingTaskID,
 		}, nil
@@ -24,6 +24,9 @@ func TestSessionRenew(t *testing.T) {
 	if !s.Expires.After(start) {
 		t.Errorf("Session.expires = %s; want a time > %s", s.Expires, start)
 	}
+	if s.LastUsed.Before(start) {
+		t.Errorf("Session.LastUsed = %s; want a time >= %s", s.LastUsed, start)
+	}
 }
 
 func TestSessionIsExpired(t *testing.T) {
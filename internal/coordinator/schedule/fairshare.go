@@ -0,0 +1,199 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package schedule
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/build/internal/coordinator/pool/queue"
+)
+
+// DefaultWeights are the class weights of a FairSharePolicy without
+// Weights. Release work always goes first and needs no weight.
+var DefaultWeights = map[Class]float64{
+	ClassGomote:     4,
+	ClassTry:        2,
+	ClassPostSubmit: 1,
+}
+
+// DefaultHalfLife is the half-life of past usage in a FairSharePolicy
+// without a HalfLife.
+const DefaultHalfLife = 30 * time.Minute
+
+// FairSharePolicy is a Policy that shares buildlets among the classes of
+// work in proportion to their weights, and equally among the users within
+// a class. Usage is measured in buildlet time and decays with time, so
+// that a class or user that used less than its share recently goes ahead
+// of one that used more. Work with equal usage is ordered as by
+// SchedItem.Less, and release work always goes first.
+//
+// Work waiting longer than PreemptAfter may preempt running work of a
+// lower priority class that used more than its share. Release and gomote
+// work is never preempted.
+//
+// The zero value is ready to use and preempts nothing.
+type FairSharePolicy struct {
+	// Weights are the relative shares of the classes. Nil means
+	// DefaultWeights. Classes without a weight have weight 1.
+	Weights map[Class]float64
+	// HalfLife is how long it takes for past usage to count half.
+	// Zero means DefaultHalfLife.
+	HalfLife time.Duration
+	// PreemptAfter is how long work waits before it may preempt other
+	// work. Zero means work is never preempted.
+	PreemptAfter time.Duration
+
+	mu     sync.Mutex
+	shares map[shareKey]*share
+}
+
+// shareKey identifies the usage of a class, or of a user within a class.
+type shareKey struct {
+	class Class
+	user  string // empty for the whole class
+}
+
+// share is the decayed usage of a class or user.
+type share struct {
+	usage   float64   // decayed buildlet seconds as of at
+	at      time.Time // when usage was last brought up to date
+	running int       // buildlets held since at
+}
+
+// usageAt returns the decayed usage at t, counting the buildlets held
+// since s.at as used until t.
+func (s *share) usageAt(t time.Time, halfLife time.Duration) float64 {
+	d := t.Sub(s.at)
+	if d <= 0 {
+		return s.usage
+	}
+	decay := math.Exp2(-d.Seconds() / halfLife.Seconds())
+	// The running buildlets' usage, integrated with decay over d.
+	accrued := float64(s.running) * halfLife.Seconds() / math.Ln2 * (1 - decay)
+	return s.usage*decay + accrued
+}
+
+func (p *FairSharePolicy) halfLife() time.Duration {
+	if p.HalfLife > 0 {
+		return p.HalfLife
+	}
+	return DefaultHalfLife
+}
+
+func (p *FairSharePolicy) weight(c Class) float64 {
+	weights := p.Weights
+	if weights == nil {
+		weights = DefaultWeights
+	}
+	if w, ok := weights[c]; ok && w > 0 {
+		return w
+	}
+	return 1
+}
+
+// usage returns the decayed usage of k at t, ignoring usage of less than
+// a buildlet second. p.mu must be held.
+func (p *FairSharePolicy) usage(k shareKey, t time.Time) float64 {
+	s := p.shares[k]
+	if s == nil {
+		return 0
+	}
+	if u := s.usageAt(t, p.halfLife()); u >= 1 {
+		return u
+	}
+	return 0
+}
+
+// classShare returns the usage of class c at t relative to its weight.
+// p.mu must be held.
+func (p *FairSharePolicy) classShare(c Class, t time.Time) float64 {
+	return p.usage(shareKey{class: c}, t) / p.weight(c)
+}
+
+// update adds delta to the buildlets held by the class and user of si
+// at t. p.mu must be held.
+func (p *FairSharePolicy) update(si *queue.SchedItem, delta int, t time.Time) {
+	if p.shares == nil {
+		p.shares = make(map[shareKey]*share)
+	}
+	c := ClassOf(si)
+	for _, k := range []shareKey{{class: c}, {class: c, user: si.User}} {
+		s := p.shares[k]
+		if s == nil {
+			s = &share{at: t}
+			p.shares[k] = s
+		}
+		s.usage, s.at = s.usageAt(t, p.halfLife()), t
+		s.running = max(0, s.running+delta)
+		if s.running == 0 && s.usage < 1 {
+			// Forget shares that no longer matter.
+			delete(p.shares, k)
+		}
+	}
+}
+
+func (p *FairSharePolicy) Less(a, b *queue.SchedItem, now time.Time) bool {
+	ca, cb := ClassOf(a), ClassOf(b)
+	if (ca == ClassRelease) != (cb == ClassRelease) {
+		return ca == ClassRelease
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var ua, ub float64
+	if ca != cb {
+		ua, ub = p.classShare(ca, now), p.classShare(cb, now)
+	} else {
+		ua, ub = p.usage(shareKey{ca, a.User}, now), p.usage(shareKey{cb, b.User}, now)
+	}
+	if ua != ub {
+		return ua < ub
+	}
+	return a.Less(b)
+}
+
+func (p *FairSharePolicy) Started(si *queue.SchedItem, t time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.update(si, 1, t)
+}
+
+func (p *FairSharePolicy) Finished(si *queue.SchedItem, _, t time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.update(si, -1, t)
+}
+
+func (p *FairSharePolicy) Preempt(waiting *queue.SchedItem, running []Running, now time.Time) *queue.SchedItem {
+	if p.PreemptAfter <= 0 || now.Sub(waiting.RequestTime) < p.PreemptAfter {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	wc := ClassOf(waiting)
+	waitingShare := p.classShare(wc, now)
+	var victim *Running
+	for i, r := range running {
+		rc := ClassOf(r.Item)
+		if rc == ClassRelease || rc == ClassGomote || r.Item.Priority() <= waiting.Priority() {
+			continue
+		}
+		if p.classShare(rc, now) <= waitingShare {
+			continue
+		}
+		// Preempt the most recently started work, which loses the
+		// least progress.
+		if victim == nil || r.Start.After(victim.Start) {
+			victim = &running[i]
+		}
+	}
+	if victim == nil {
+		return nil
+	}
+	return victim.Item
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package schedule
+
+import (
+	"time"
+
+	"golang.org/x/build/internal/coordinator/pool/queue"
+)
+
+// A Class is a class of scheduled work.
+type Class string
+
+const (
+	ClassRelease    Class = "release"
+	ClassGomote     Class = "gomote"
+	ClassTry        Class = "try"
+	ClassPostSubmit Class = "post-submit"
+)
+
+// ClassOf returns the class of the work of si.
+func ClassOf(si *queue.SchedItem) Class {
+	switch si.Priority() {
+	case queue.PriorityUrgent:
+		return ClassRelease
+	case queue.PriorityInteractive:
+		return ClassGomote
+	case queue.PriorityAutomated:
+		return ClassTry
+	default:
+		return ClassPostSubmit
+	}
+}
+
+// A Policy decides which callers waiting for buildlets get them first,
+// and which running work is preempted to make room for more important
+// work. Its methods may be called concurrently.
+type Policy interface {
+	// Less reports whether a should get a buildlet before b at now.
+	Less(a, b *queue.SchedItem, now time.Time) bool
+
+	// Started records that the work of si got its buildlet at t.
+	Started(si *queue.SchedItem, t time.Time)
+
+	// Finished records that the work of si, which got its buildlet at
+	// start, released it at t.
+	Finished(si *queue.SchedItem, start, t time.Time)
+
+	// Preempt returns the work in running to stop so that waiting gets
+	// a buildlet sooner, or nil if none should be. All of running holds
+	// buildlets of waiting's host type.
+	Preempt(waiting *queue.SchedItem, running []Running, now time.Time) *queue.SchedItem
+}
+
+// Running is work holding a buildlet.
+type Running struct {
+	Item  *queue.SchedItem
+	Start time.Time // when the work got its buildlet
+}
+
+// PriorityPolicy is the default Policy. Callers get buildlets in the
+// order of SchedItem.Less, and nothing is preempted.
+type PriorityPolicy struct{}
+
+func (PriorityPolicy) Less(a, b *queue.SchedItem, _ time.Time) bool    { return a.Less(b) }
+func (PriorityPolicy) Started(*queue.SchedItem, time.Time)             {}
+func (PriorityPolicy) Finished(*queue.SchedItem, time.Time, time.Time) {}
+func (PriorityPolicy) Preempt(*queue.SchedItem, []Running, time.Time) *queue.SchedItem {
+	return nil
+}
+
+// ordering adapts a Policy to a queue.Ordering.
+type ordering struct{ p Policy }
+
+func (o *ordering) Less(a, b *queue.SchedItem) bool { return o.p.Less(a, b, time.Now()) }
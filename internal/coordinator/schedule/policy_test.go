@@ -0,0 +1,88 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/build/internal/coordinator/pool/queue"
+)
+
+func TestFairSharePolicyLess(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := new(FairSharePolicy)
+	heavy := &queue.SchedItem{IsTry: true, User: "heavy", RequestTime: t0}
+	light := &queue.SchedItem{IsTry: true, User: "light", RequestTime: t0.Add(time.Minute)}
+	post := &queue.SchedItem{RequestTime: t0}
+	release := &queue.SchedItem{IsRelease: true, RequestTime: t0.Add(time.Hour)}
+
+	// Without usage, the order is that of SchedItem.Less.
+	if !p.Less(heavy, light, t0) || p.Less(light, heavy, t0) {
+		t.Errorf("without usage, the earlier try work should go first")
+	}
+
+	for range 3 {
+		p.Started(heavy, t0)
+	}
+	now := t0.Add(10 * time.Minute)
+	if !p.Less(light, heavy, now) {
+		t.Errorf("the user with less usage should go first")
+	}
+	if p.Less(heavy, post, now) || !p.Less(post, heavy, now) {
+		t.Errorf("post-submit work should go before try work over its share")
+	}
+	if !p.Less(release, post, now) || !p.Less(release, heavy, now) {
+		t.Errorf("release work should always go first")
+	}
+
+	// The usage decays once the work finishes.
+	for range 3 {
+		p.Finished(heavy, t0, now)
+	}
+	if !p.Less(light, heavy, now.Add(time.Hour)) {
+		t.Errorf("recent usage should still count an hour later")
+	}
+	if !p.Less(heavy, light, now.Add(24*time.Hour)) {
+		t.Errorf("usage should be forgotten a day later")
+	}
+}
+
+func TestFairSharePolicyPreempt(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := &FairSharePolicy{PreemptAfter: 5 * time.Minute}
+	old := &queue.SchedItem{RequestTime: t0}
+	young := &queue.SchedItem{RequestTime: t0}
+	try := &queue.SchedItem{IsTry: true, RequestTime: t0}
+	gomote := &queue.SchedItem{IsGomote: true, RequestTime: t0}
+	p.Started(old, t0)
+	p.Started(young, t0.Add(time.Minute))
+	p.Started(gomote, t0)
+	running := []Running{{old, t0}, {young, t0.Add(time.Minute)}, {gomote, t0}}
+
+	waiter := &queue.SchedItem{IsGomote: true, RequestTime: t0.Add(time.Minute)}
+	if got := p.Preempt(waiter, running, t0.Add(2*time.Minute)); got != nil {
+		t.Errorf("Preempt before PreemptAfter = %v; want nil", got)
+	}
+	if got := p.Preempt(waiter, running, t0.Add(10*time.Minute)); got != young {
+		t.Errorf("Preempt = %v; want the most recently started post-submit work", got)
+	}
+	// Gomote work isn't preempted, and work doesn't preempt work of its
+	// own class.
+	for _, r := range []Running{{gomote, t0}, {try, t0}} {
+		p.Started(r.Item, r.Start)
+		if got := p.Preempt(waiter, []Running{r}, t0.Add(time.Hour)); got != nil {
+			t.Errorf("Preempt(%s work) = %v; want nil", ClassOf(r.Item), got)
+		}
+	}
+	if got := p.Preempt(try, running[:1], t0.Add(time.Hour)); got != old {
+		t.Errorf("Preempt for try work = %v; want post-submit work", got)
+	}
+	if got := new(FairSharePolicy).Preempt(waiter, running, t0.Add(time.Hour)); got != nil {
+		t.Errorf("Preempt without PreemptAfter = %v; want nil", got)
+	}
+}
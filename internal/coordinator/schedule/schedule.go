@@ -8,14 +8,19 @@ package schedule
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"maps"
+	"slices"
 	"sort"
 	"sync"
 	"time"
 
 	"golang.org/x/build/buildlet"
 	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal"
 	"golang.org/x/build/internal/coordinator/pool"
 	"golang.org/x/build/internal/coordinator/pool/queue"
 	"golang.org/x/build/internal/spanlog"
@@ -38,14 +43,83 @@ type Scheduler struct {
 	hostsCreating map[string]int // hostType -> count
 
 	lastProgress map[string]time.Time // hostType -> time last delivered buildlet
+
+	// running contains the work holding buildlets, keyed by host type.
+	running map[string]map[*queue.SchedItem]runningWork // hostType -> item -> work
+
+	// preempted contains the running work chosen for preemption, and
+	// preempting the waiters that work was preempted for, so that each
+	// is preempted and preempts at most once.
+	preempted  map[*queue.SchedItem]bool
+	preempting map[*queue.SchedItem]bool
+
+	policy   Policy
+	ordering queue.Ordering // orders this scheduler's items by policy
+	preempt  func(*queue.SchedItem) bool
+	trace    *traceWriter
+}
+
+// runningWork is the state of work holding a buildlet.
+type runningWork struct {
+	id    int64     // the trace ID of the request
+	start time.Time // when the work got its buildlet
+}
+
+// A SchedulerOption configures a Scheduler.
+type SchedulerOption func(*Scheduler)
+
+// WithPolicy makes the scheduler use p to order callers waiting for
+// buildlets and to choose work to preempt. The default is PriorityPolicy.
+func WithPolicy(p Policy) SchedulerOption {
+	return func(s *Scheduler) {
+		s.policy = p
+	}
+}
+
+// WithPreempter makes the scheduler preempt running work that its
+// Policy chooses by calling preempt, which stops the work so that it
+// closes its buildlet. preempt reports whether it stopped the work; work
+// it didn't stop may be chosen again.
+func WithPreempter(preempt func(*queue.SchedItem) bool) SchedulerOption {
+	return func(s *Scheduler) {
+		s.preempt = preempt
+	}
+}
+
+// WithTrace makes the scheduler record its scheduling events to w,
+// one JSON-encoded TraceEvent per line, for replaying with Simulate.
+func WithTrace(w io.Writer) SchedulerOption {
+	return func(s *Scheduler) {
+		// IDs start at the current time, so that the traces of
+		// successive schedulers appended to one file don't share IDs.
+		s.trace = &traceWriter{enc: json.NewEncoder(w), nextID: time.Now().UnixNano()}
+	}
 }
 
+// preemptPeriod is how often the scheduler looks for work to preempt.
+const preemptPeriod = 10 * time.Second
+
 // NewScheduler returns a new scheduler.
-func NewScheduler() *Scheduler {
+func NewScheduler(opts ...SchedulerOption) *Scheduler {
 	s := &Scheduler{
 		hostsCreating: make(map[string]int),
 		waiting:       make(map[string]map[*queue.SchedItem]bool),
 		lastProgress:  make(map[string]time.Time),
+		running:       make(map[string]map[*queue.SchedItem]runningWork),
+		preempted:     make(map[*queue.SchedItem]bool),
+		preempting:    make(map[*queue.SchedItem]bool),
+		policy:        PriorityPolicy{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	// The order of waiting callers is kept by the buildlet pools, which
+	// schedulers share, so it goes with each caller's SchedItem.
+	s.ordering = &ordering{s.policy}
+	if s.preempt != nil {
+		go internal.PeriodicallyDo(context.Background(), preemptPeriod, func(_ context.Context, now time.Time) {
+			s.preemptOnce(now)
+		})
 	}
 	return s
 }
@@ -70,6 +144,7 @@ func (s *Scheduler) removeWaiter(si *queue.SchedItem) {
 	if m := s.waiting[si.HostType]; m != nil {
 		delete(m, si)
 	}
+	delete(s.preempting, si)
 }
 
 func (s *Scheduler) addWaiter(si *queue.SchedItem) {
@@ -150,6 +225,25 @@ func (s *Scheduler) State() (st SchedulerState) {
 	return st
 }
 
+// Demand returns the number of callers waiting for or holding a buildlet
+// of each host type.
+func (s *Scheduler) Demand() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	demand := make(map[string]int)
+	for hostType, m := range s.waiting {
+		if len(m) > 0 {
+			demand[hostType] += len(m)
+		}
+	}
+	for hostType, m := range s.running {
+		if len(m) > 0 {
+			demand[hostType] += len(m)
+		}
+	}
+	return demand
+}
+
 // WaiterState returns tells waiter how many callers are on the line
 // in front of them.
 func (s *Scheduler) WaiterState(waiter *queue.SchedItem) (ws types.BuildletWaitStatus) {
@@ -158,7 +252,7 @@ func (s *Scheduler) WaiterState(waiter *queue.SchedItem) (ws types.BuildletWaitS
 
 	m := s.waiting[waiter.HostType]
 	for si := range m {
-		if si.Less(waiter) {
+		if s.policy.Less(si, waiter, time.Now()) {
 			ws.Ahead++
 		}
 	}
@@ -175,9 +269,113 @@ func (s *Scheduler) GetBuildlet(ctx context.Context, si *queue.SchedItem) (build
 		return nil, fmt.Errorf("invalid SchedItem.HostType %q", si.HostType)
 	}
 	si.RequestTime = time.Now()
+	si.Ordering = s.ordering
+	id := s.trace.request(si)
 
 	s.addWaiter(si)
 	defer s.removeWaiter(si)
 
-	return pool.ForHost(hostConf).GetBuildlet(ctx, si.HostType, stderrLogger{}, si)
+	bc, err := pool.ForHost(hostConf).GetBuildlet(ctx, si.HostType, stderrLogger{}, si)
+	if err != nil {
+		s.trace.record(id, TraceCancel, nil)
+		return nil, err
+	}
+	return s.started(id, si, bc), nil
+}
+
+// started records that si got the buildlet bc, and returns bc wrapped to
+// record when si releases it.
+func (s *Scheduler) started(id int64, si *queue.SchedItem, bc buildlet.Client) buildlet.Client {
+	start := time.Now()
+	s.mu.Lock()
+	if _, ok := s.running[si.HostType]; !ok {
+		s.running[si.HostType] = make(map[*queue.SchedItem]runningWork)
+	}
+	s.running[si.HostType][si] = runningWork{id: id, start: start}
+	s.mu.Unlock()
+	s.policy.Started(si, start)
+	s.trace.record(id, TraceStart, nil)
+
+	return &scheduledClient{
+		Client: bc,
+		finished: sync.OnceFunc(func() {
+			s.mu.Lock()
+			delete(s.running[si.HostType], si)
+			delete(s.preempted, si)
+			s.mu.Unlock()
+			s.policy.Finished(si, start, time.Now())
+			s.trace.record(id, TraceFinish, nil)
+		}),
+	}
+}
+
+// scheduledClient is a buildlet client handed out by the Scheduler.
+type scheduledClient struct {
+	buildlet.Client
+	finished func()
+}
+
+func (c *scheduledClient) Close() error {
+	err := c.Client.Close()
+	c.finished()
+	return err
+}
+
+// preemptOnce preempts the running work that the policy chooses for the
+// callers waiting at now.
+func (s *Scheduler) preemptOnce(now time.Time) {
+	type preemption struct {
+		id     int64 // the trace ID of the preempted work
+		waiter *queue.SchedItem
+	}
+	victims := make(map[*queue.SchedItem]preemption)
+	s.mu.Lock()
+	for hostType, m := range s.waiting {
+		var running []Running
+		for si, rw := range s.running[hostType] {
+			if !s.preempted[si] {
+				running = append(running, Running{Item: si, Start: rw.start})
+			}
+		}
+		if len(running) == 0 {
+			continue
+		}
+		waiters := slices.SortedFunc(maps.Keys(m), func(a, b *queue.SchedItem) int {
+			if s.policy.Less(a, b, now) {
+				return -1
+			}
+			if s.policy.Less(b, a, now) {
+				return 1
+			}
+			return 0
+		})
+		for _, w := range waiters {
+			if s.preempting[w] {
+				continue
+			}
+			victim := s.policy.Preempt(w, running, now)
+			if victim == nil {
+				continue
+			}
+			s.preempting[w] = true
+			s.preempted[victim] = true
+			victims[victim] = preemption{s.running[hostType][victim].id, w}
+			running = slices.DeleteFunc(running, func(r Running) bool { return r.Item == victim })
+		}
+	}
+	s.mu.Unlock()
+
+	for si, p := range victims {
+		if !s.preempt(si) {
+			// The work can't be preempted, so let the waiter
+			// preempt other work.
+			s.mu.Lock()
+			delete(s.preempted, si)
+			delete(s.preempting, p.waiter)
+			s.mu.Unlock()
+			continue
+		}
+		s.trace.record(p.id, TracePreempt, nil)
+		log.Printf("sched: preempted %s work on %s for %s work", ClassOf(si), si.HostType, ClassOf(p.waiter))
+	}
 }
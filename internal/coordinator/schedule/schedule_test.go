@@ -7,9 +7,11 @@
 package schedule
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"runtime"
+	"slices"
 	"testing"
 	"time"
 
@@ -205,3 +207,69 @@ func TestScheduler(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedulerPreempt(t *testing.T) {
+	defer func() { cpool.TestPoolHook = nil }()
+	pool := &fakePool{poolChan: map[string]chan any{"test-host-foo": make(chan any, 1)}}
+	cpool.TestPoolHook = func(*dashboard.HostConfig) cpool.Buildlet { return pool }
+
+	var trace bytes.Buffer
+	preempted := make(chan *queue.SchedItem, 2)
+	s := NewScheduler(
+		WithPolicy(&FairSharePolicy{PreemptAfter: time.Minute}),
+		WithPreempter(func(si *queue.SchedItem) bool {
+			preempted <- si
+			return true
+		}),
+		WithTrace(&trace),
+	)
+
+	pool.poolChan["test-host-foo"] <- buildlet.NewClient("127.0.0.1:9999", buildlet.NoKeyPair) // dummy
+	post := newGetBuildletCall(&queue.SchedItem{HostType: "test-host-foo"})
+	post.start(t, s)
+	post.wantGetBuildlet(t, s)
+	gomote := newGetBuildletCall(&queue.SchedItem{HostType: "test-host-foo", IsGomote: true})
+	gomote.start(t, s)
+	defer gomote.ctxCancel()
+	if got, want := s.Demand()["test-host-foo"], 2; got != want {
+		t.Errorf("Demand() = %d; want %d for the running and the waiting work", got, want)
+	}
+
+	s.preemptOnce(time.Now())
+	s.preemptOnce(time.Now().Add(time.Hour))
+	s.preemptOnce(time.Now().Add(2 * time.Hour))
+	select {
+	case si := <-preempted:
+		if si != post.si {
+			t.Fatalf("preempted %+v; want the post-submit work", si)
+		}
+	default:
+		t.Fatalf("nothing preempted; want the post-submit work")
+	}
+	select {
+	case si := <-preempted:
+		t.Fatalf("preempted %+v again; want one preemption", si)
+	default:
+	}
+
+	post.gotClient.Close()
+	if !trueSoon(func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.running["test-host-foo"]) == 0
+	}) {
+		t.Errorf("preempted work still running after Close")
+	}
+	evs, err := ReadTrace(&trace)
+	if err != nil {
+		t.Fatalf("ReadTrace = _, %v", err)
+	}
+	var kinds []TraceKind
+	for _, ev := range evs {
+		kinds = append(kinds, ev.Kind)
+	}
+	want := []TraceKind{TraceRequest, TraceStart, TraceRequest, TracePreempt, TraceFinish}
+	if !slices.Equal(kinds, want) {
+		t.Errorf("trace events = %q; want %q", kinds, want)
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package schedule
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"golang.org/x/build/internal/coordinator/pool/queue"
+)
+
+// SimResult is the outcome of replaying a trace with Simulate.
+type SimResult struct {
+	// Classes are the waits for buildlets by class of work.
+	Classes map[Class]*WaitStats
+	// Users are the waits for buildlets by user. Work without a user
+	// is counted under the empty user.
+	Users map[string]*WaitStats
+	// Preemptions is the number of times running work was preempted.
+	Preemptions int
+	// Canceled is the number of callers that gave up waiting.
+	Canceled int
+}
+
+// WaitStats summarizes how long work waited for buildlets.
+type WaitStats struct {
+	Count int
+	Total time.Duration
+	Max   time.Duration
+}
+
+func (ws *WaitStats) add(d time.Duration) {
+	ws.Count++
+	ws.Total += d
+	ws.Max = max(ws.Max, d)
+}
+
+// Mean returns the mean wait.
+func (ws *WaitStats) Mean() time.Duration {
+	if ws.Count == 0 {
+		return 0
+	}
+	return ws.Total / time.Duration(ws.Count)
+}
+
+// simJob is a request for a buildlet in a simulation.
+type simJob struct {
+	item   *queue.SchedItem
+	arrive time.Time     // when the buildlet was requested
+	hold   time.Duration // how long the work holds its buildlet
+	cancel time.Time     // when the caller gave up, or zero
+
+	queued     time.Time // when the job last started waiting
+	start      time.Time // when the job last got a buildlet
+	finish     time.Time // when the job releases its buildlet
+	preempting bool      // whether the job preempted work while waiting
+}
+
+// Simulate replays the requests for buildlets in trace, as recorded by a
+// Scheduler with WithTrace, on a virtual clock with policy p ordering the
+// waiting work and preempting running work.
+//
+// Each request holds its buildlet for as long as in the trace. Requests
+// canceled in the trace before getting a buildlet give up at the same
+// time, or release the buildlet then if they got one sooner. Preempted
+// work waits again and then runs from the start. capacity is the number
+// of buildlets of each host type; host types without a capacity have as
+// many as were held at once in the trace.
+func Simulate(trace []TraceEvent, capacity map[string]int, p Policy) (*SimResult, error) {
+	jobs, held, err := traceJobs(trace)
+	if err != nil {
+		return nil, err
+	}
+	res := &SimResult{
+		Classes: make(map[Class]*WaitStats),
+		Users:   make(map[string]*WaitStats),
+	}
+	if len(jobs) == 0 {
+		return res, nil
+	}
+	capOf := func(hostType string) int {
+		if c, ok := capacity[hostType]; ok {
+			return c
+		}
+		return max(1, held[hostType])
+	}
+
+	var (
+		now     = jobs[0].arrive
+		next    = 0 // index in jobs of the next arrival
+		waiting = make(map[string][]*simJob)
+		running = make(map[string][]*simJob)
+	)
+	byPolicy := func(a, b *simJob) int {
+		if p.Less(a.item, b.item, now) {
+			return -1
+		}
+		if p.Less(b.item, a.item, now) {
+			return 1
+		}
+		return 0
+	}
+	startJob := func(hostType string, j *simJob) {
+		wait := now.Sub(j.queued)
+		c := ClassOf(j.item)
+		if res.Classes[c] == nil {
+			res.Classes[c] = new(WaitStats)
+		}
+		res.Classes[c].add(wait)
+		if res.Users[j.item.User] == nil {
+			res.Users[j.item.User] = new(WaitStats)
+		}
+		res.Users[j.item.User].add(wait)
+		j.start, j.finish, j.preempting = now, now.Add(j.hold), false
+		if !j.cancel.IsZero() {
+			j.finish = maxTime(now, j.cancel)
+		}
+		p.Started(j.item, now)
+		running[hostType] = append(running[hostType], j)
+	}
+
+	for next < len(jobs) || len(waiting) > 0 || len(running) > 0 {
+		// Release the buildlets of finished work.
+		for hostType, rs := range running {
+			running[hostType] = slices.DeleteFunc(rs, func(j *simJob) bool {
+				if j.finish.After(now) {
+					return false
+				}
+				p.Finished(j.item, j.start, j.finish)
+				return true
+			})
+			if len(running[hostType]) == 0 {
+				delete(running, hostType)
+			}
+		}
+		// Queue new requests and drop canceled ones.
+		for ; next < len(jobs) && !jobs[next].arrive.After(now); next++ {
+			j := jobs[next]
+			waiting[j.item.HostType] = append(waiting[j.item.HostType], j)
+		}
+		for hostType, ws := range waiting {
+			waiting[hostType] = slices.DeleteFunc(ws, func(j *simJob) bool {
+				if !j.cancel.IsZero() && !j.cancel.After(now) {
+					res.Canceled++
+					return true
+				}
+				return false
+			})
+		}
+		for _, hostType := range slices.Sorted(maps.Keys(waiting)) {
+			ws := waiting[hostType]
+			slices.SortStableFunc(ws, byPolicy)
+			// Preempt work for waiters that can't get a buildlet.
+			if free := capOf(hostType) - len(running[hostType]); free < len(ws) {
+				for _, w := range ws[free:] {
+					if w.preempting {
+						continue
+					}
+					rs := make([]Running, len(running[hostType]))
+					for i, j := range running[hostType] {
+						rs[i] = Running{Item: j.item, Start: j.start}
+					}
+					victim := p.Preempt(w.item, rs, now)
+					if victim == nil {
+						continue
+					}
+					i := slices.IndexFunc(running[hostType], func(j *simJob) bool { return j.item == victim })
+					if i < 0 {
+						return nil, fmt.Errorf("policy preempted work that isn't running")
+					}
+					v := running[hostType][i]
+					running[hostType] = slices.Delete(running[hostType], i, i+1)
+					p.Finished(v.item, v.start, now)
+					v.queued = now
+					ws = append(ws, v)
+					w.preempting = true
+					res.Preemptions++
+				}
+				slices.SortStableFunc(ws, byPolicy)
+			}
+			for len(ws) > 0 && len(running[hostType]) < capOf(hostType) {
+				startJob(hostType, ws[0])
+				ws = ws[1:]
+			}
+			if len(ws) == 0 {
+				delete(waiting, hostType)
+			} else {
+				waiting[hostType] = ws
+			}
+		}
+
+		// Advance the clock to the next event.
+		var t time.Time
+		later := func(u time.Time) {
+			if u.After(now) && (t.IsZero() || u.Before(t)) {
+				t = u
+			}
+		}
+		if next < len(jobs) {
+			later(jobs[next].arrive)
+		}
+		for _, rs := range running {
+			for _, j := range rs {
+				later(j.finish)
+			}
+		}
+		for _, ws := range waiting {
+			for _, j := range ws {
+				if !j.cancel.IsZero() {
+					later(j.cancel)
+				}
+			}
+		}
+		if len(waiting) > 0 {
+			// Waiters may preempt work as they wait longer.
+			later(now.Add(preemptPeriod))
+		}
+		if t.IsZero() {
+			if len(waiting) > 0 {
+				return nil, fmt.Errorf("simulation stuck with work waiting for host types without capacity")
+			}
+			break
+		}
+		now = t
+	}
+	return res, nil
+}
+
+// traceJobs returns the requests in trace sorted by arrival, and the
+// most buildlets held at once in trace for each host type.
+func traceJobs(trace []TraceEvent) (jobs []*simJob, held map[string]int, err error) {
+	trace = slices.Clone(trace)
+	sort.SliceStable(trace, func(i, j int) bool { return trace[i].Time.Before(trace[j].Time) })
+	held = make(map[string]int)
+	holding := make(map[string]int)
+	byID := make(map[int64]*simJob)
+	for _, ev := range trace {
+		if ev.Kind == TraceRequest {
+			if ev.Item == nil {
+				return nil, nil, fmt.Errorf("request %d has no item", ev.ID)
+			}
+			item := *ev.Item
+			item.RequestTime = ev.Time
+			j := &simJob{item: &item, arrive: ev.Time, queued: ev.Time}
+			byID[ev.ID] = j
+			jobs = append(jobs, j)
+			continue
+		}
+		j := byID[ev.ID]
+		if j == nil {
+			// The request predates the trace.
+			continue
+		}
+		switch ev.Kind {
+		case TraceStart:
+			j.start = ev.Time
+			holding[j.item.HostType]++
+			held[j.item.HostType] = max(held[j.item.HostType], holding[j.item.HostType])
+		case TraceFinish:
+			j.hold = ev.Time.Sub(j.start)
+			holding[j.item.HostType]--
+			j.start = time.Time{}
+			delete(byID, ev.ID)
+		case TraceCancel:
+			j.cancel = ev.Time
+			delete(byID, ev.ID)
+		}
+	}
+	if len(trace) > 0 {
+		end := trace[len(trace)-1].Time
+		for _, j := range byID {
+			if !j.start.IsZero() {
+				// Work still holding its buildlet at the end of the trace.
+				j.hold = end.Sub(j.start)
+				j.start = time.Time{}
+			} else {
+				// Work still waiting at the end of the trace.
+				j.cancel = end
+			}
+		}
+	}
+	return jobs, held, nil
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
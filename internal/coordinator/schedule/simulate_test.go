@@ -0,0 +1,124 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package schedule
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"golang.org/x/build/internal/coordinator/pool/queue"
+)
+
+// traceBuilder builds scheduling traces for tests.
+type traceBuilder struct {
+	t0     time.Time
+	events []TraceEvent
+}
+
+// add adds a request for si at offset at that got a buildlet right away
+// and held it for hold.
+func (b *traceBuilder) add(si queue.SchedItem, at, hold time.Duration) {
+	id := int64(len(b.events) + 1)
+	b.events = append(b.events,
+		TraceEvent{Time: b.t0.Add(at), Kind: TraceRequest, ID: id, Item: &si},
+		TraceEvent{Time: b.t0.Add(at), Kind: TraceStart, ID: id},
+		TraceEvent{Time: b.t0.Add(at + hold), Kind: TraceFinish, ID: id},
+	)
+}
+
+func TestSimulateGomoteBehindPostSubmit(t *testing.T) {
+	b := &traceBuilder{t0: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	for i := range 20 {
+		b.add(queue.SchedItem{HostType: "host-reverse"}, time.Duration(i)*time.Second, 30*time.Minute)
+	}
+	b.add(queue.SchedItem{HostType: "host-reverse", IsGomote: true, User: "gopher"}, time.Minute, time.Hour)
+	capacity := map[string]int{"host-reverse": 2}
+
+	res, err := Simulate(b.events, capacity, PriorityPolicy{})
+	if err != nil {
+		t.Fatalf("Simulate(PriorityPolicy) = _, %v", err)
+	}
+	if got, want := res.Classes[ClassGomote].Max, 29*time.Minute; got != want {
+		t.Errorf("PriorityPolicy gomote wait = %v; want %v", got, want)
+	}
+	if res.Preemptions != 0 {
+		t.Errorf("PriorityPolicy preemptions = %d; want 0", res.Preemptions)
+	}
+
+	res, err = Simulate(b.events, capacity, &FairSharePolicy{PreemptAfter: 2 * time.Minute})
+	if err != nil {
+		t.Fatalf("Simulate(FairSharePolicy) = _, %v", err)
+	}
+	if got, want := res.Classes[ClassGomote].Max, 2*time.Minute; got != want {
+		t.Errorf("FairSharePolicy gomote wait = %v; want %v", got, want)
+	}
+	if res.Preemptions != 1 {
+		t.Errorf("FairSharePolicy preemptions = %d; want 1", res.Preemptions)
+	}
+	if got, want := res.Classes[ClassPostSubmit].Count, 21; got != want {
+		t.Errorf("post-submit starts = %d; want %d, including the restart of the preempted work", got, want)
+	}
+}
+
+func TestSimulateUserShares(t *testing.T) {
+	b := &traceBuilder{t0: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	for i := range 10 {
+		b.add(queue.SchedItem{HostType: "host-a", IsTry: true, User: "heavy"}, time.Duration(i)*time.Second, 10*time.Minute)
+	}
+	b.add(queue.SchedItem{HostType: "host-a", IsTry: true, User: "light"}, time.Minute, 10*time.Minute)
+	capacity := map[string]int{"host-a": 1}
+
+	waits := make(map[string]time.Duration)
+	for name, p := range map[string]Policy{"priority": PriorityPolicy{}, "fairshare": new(FairSharePolicy)} {
+		res, err := Simulate(b.events, capacity, p)
+		if err != nil {
+			t.Fatalf("Simulate(%s) = _, %v", name, err)
+		}
+		waits[name] = res.Users["light"].Max
+	}
+	if got, want := waits["priority"], 99*time.Minute; got != want {
+		t.Errorf("PriorityPolicy wait of light user = %v; want %v", got, want)
+	}
+	if got, want := waits["fairshare"], 9*time.Minute; got != want {
+		t.Errorf("FairSharePolicy wait of light user = %v; want %v", got, want)
+	}
+}
+
+func TestSimulateCapacityFromTrace(t *testing.T) {
+	b := &traceBuilder{t0: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	for range 3 {
+		b.add(queue.SchedItem{HostType: "host-a"}, 0, time.Minute)
+	}
+	// A request that gave up waiting for a buildlet.
+	b.events = append(b.events,
+		TraceEvent{Time: b.t0, Kind: TraceRequest, ID: 100, Item: &queue.SchedItem{HostType: "host-a", IsTry: true}},
+		TraceEvent{Time: b.t0.Add(30 * time.Second), Kind: TraceCancel, ID: 100},
+	)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, ev := range b.events {
+		enc.Encode(ev)
+	}
+	trace, err := ReadTrace(&buf)
+	if err != nil {
+		t.Fatalf("ReadTrace = _, %v", err)
+	}
+	res, err := Simulate(trace, nil, PriorityPolicy{})
+	if err != nil {
+		t.Fatalf("Simulate = _, %v", err)
+	}
+	// The try work goes first, so one post-submit request waits for a
+	// buildlet of the three held at once in the trace.
+	if got, want := res.Classes[ClassPostSubmit].Max, 30*time.Second; got != want {
+		t.Errorf("post-submit wait = %v; want %v", got, want)
+	}
+	if ws := res.Classes[ClassTry]; ws == nil || ws.Count != 1 || ws.Max != 0 {
+		t.Errorf("try waits = %+v; want one without waiting", ws)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package schedule
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/build/internal/coordinator/pool/queue"
+)
+
+// A TraceKind is a kind of scheduling event.
+type TraceKind string
+
+const (
+	// TraceRequest is a caller asking for a buildlet.
+	TraceRequest TraceKind = "request"
+	// TraceStart is a caller getting its buildlet.
+	TraceStart TraceKind = "start"
+	// TraceFinish is a caller releasing its buildlet.
+	TraceFinish TraceKind = "finish"
+	// TraceCancel is a caller giving up before getting a buildlet.
+	TraceCancel TraceKind = "cancel"
+	// TracePreempt is running work being preempted.
+	TracePreempt TraceKind = "preempt"
+)
+
+// A TraceEvent is a scheduling event recorded by a Scheduler, for
+// replaying with Simulate.
+type TraceEvent struct {
+	Time time.Time
+	Kind TraceKind
+	// ID identifies the request across its events.
+	ID int64
+	// Item is the requested buildlet, set for TraceRequest events.
+	Item *queue.SchedItem `json:",omitempty"`
+}
+
+// traceWriter writes TraceEvents as JSON lines.
+// A nil *traceWriter discards them.
+type traceWriter struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	nextID int64
+}
+
+// request records a request for si and returns its ID.
+func (w *traceWriter) request(si *queue.SchedItem) int64 {
+	if w == nil {
+		return 0
+	}
+	w.mu.Lock()
+	w.nextID++
+	id := w.nextID
+	w.mu.Unlock()
+	w.record(id, TraceRequest, si)
+	return id
+}
+
+// record records an event of the request id.
+func (w *traceWriter) record(id int64, kind TraceKind, si *queue.SchedItem) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	// Errors writing the trace don't affect scheduling, and are dropped.
+	w.enc.Encode(TraceEvent{Time: time.Now(), Kind: kind, ID: id, Item: si})
+}
+
+// ReadTrace reads the TraceEvents written by a Scheduler with
+// WithTrace.
+func ReadTrace(r io.Reader) ([]TraceEvent, error) {
+	var evs []TraceEvent
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	for n := 1; sc.Scan(); n++ {
+		var ev TraceEvent
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		evs = append(evs, ev)
+	}
+	return evs, sc.Err()
+}
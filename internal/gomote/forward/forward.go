@@ -0,0 +1,140 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package forward keeps track of the TCP connections multiplexed over a
+// gomote ForwardPort stream, on either end of it.
+package forward
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// Conns are the connections forwarded over a stream, by ID.
+//
+// Each connection's two directions finish separately: reading it
+// finishes when it reaches EOF, and writing it finishes when the other
+// end of the stream reports that its side reached EOF. The connection
+// is closed once both have.
+type Conns struct {
+	send func(id int64, data []byte, err error) error
+
+	mu sync.Mutex
+	m  map[int64]*conn
+}
+
+type conn struct {
+	rwc                 io.ReadWriteCloser
+	readDone, writeDone bool
+}
+
+// NewConns returns an empty set of connections.
+//
+// The send function is called to send what's read from connection id
+// to the other end of the stream. When reading ends, it's called with
+// nil data and the error, if reading failed for any reason but EOF.
+func NewConns(send func(id int64, data []byte, err error) error) *Conns {
+	return &Conns{send: send, m: make(map[int64]*conn)}
+}
+
+// Add starts forwarding what's read from rwc as connection id.
+func (cs *Conns) Add(id int64, rwc io.ReadWriteCloser) {
+	cs.mu.Lock()
+	if old := cs.m[id]; old != nil {
+		old.rwc.Close()
+	}
+	cs.m[id] = &conn{rwc: rwc}
+	cs.mu.Unlock()
+	go func() {
+		buf := make([]byte, 32<<10)
+		for {
+			n, err := rwc.Read(buf)
+			if n > 0 {
+				if cs.send(id, bytes.Clone(buf[:n]), nil) != nil {
+					cs.Remove(id)
+					return
+				}
+			}
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				cs.send(id, nil, err)
+				cs.finish(id, func(c *conn) { c.readDone = true })
+				return
+			}
+		}
+	}()
+}
+
+// Write writes data from the other end of the stream to connection id.
+// If that fails, it closes the connection and reports the error to the
+// other end.
+func (cs *Conns) Write(id int64, data []byte) {
+	cs.mu.Lock()
+	c := cs.m[id]
+	cs.mu.Unlock()
+	if c == nil {
+		return
+	}
+	if _, err := c.rwc.Write(data); err != nil {
+		cs.Remove(id)
+		cs.send(id, nil, err)
+	}
+}
+
+// CloseWrite records that the other end of connection id reached EOF,
+// and passes that on if the connection supports it.
+func (cs *Conns) CloseWrite(id int64) {
+	cs.finish(id, func(c *conn) {
+		c.writeDone = true
+		if cw, ok := c.rwc.(interface{ CloseWrite() error }); ok {
+			cw.CloseWrite()
+		}
+	})
+}
+
+// finish calls f with connection id, and then closes and forgets the
+// connection if both its directions are finished.
+func (cs *Conns) finish(id int64, f func(*conn)) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	c := cs.m[id]
+	if c == nil {
+		return
+	}
+	f(c)
+	if c.readDone && c.writeDone {
+		c.rwc.Close()
+		delete(cs.m, id)
+	}
+}
+
+// Remove closes and forgets connection id.
+func (cs *Conns) Remove(id int64) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if c := cs.m[id]; c != nil {
+		c.rwc.Close()
+		delete(cs.m, id)
+	}
+}
+
+// Len returns the number of open connections.
+func (cs *Conns) Len() int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return len(cs.m)
+}
+
+// CloseAll closes and forgets all the connections.
+func (cs *Conns) CloseAll() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	for id, c := range cs.m {
+		c.rwc.Close()
+		delete(cs.m, id)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package forward
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+type sent struct {
+	id   int64
+	data string
+	eof  bool
+}
+
+func TestConns(t *testing.T) {
+	sends := make(chan sent, 10)
+	cs := NewConns(func(id int64, data []byte, err error) error {
+		if err != nil {
+			t.Errorf("send(%d) error = %v; want nil", id, err)
+		}
+		sends <- sent{id, string(data), data == nil}
+		return nil
+	})
+	defer cs.CloseAll()
+
+	local, remote := net.Pipe()
+	cs.Add(1, local)
+
+	// Data read from the connection is sent.
+	go io.WriteString(remote, "hello")
+	if got := <-sends; got != (sent{1, "hello", false}) {
+		t.Errorf("sent %+v; want hello on connection 1", got)
+	}
+
+	// Data from the other end is written to the connection.
+	go cs.Write(1, []byte("world"))
+	buf := make([]byte, 5)
+	if _, err := io.ReadFull(remote, buf); err != nil || string(buf) != "world" {
+		t.Errorf("read %q, %v; want world", buf, err)
+	}
+
+	// The connection stays open until both directions finish.
+	cs.CloseWrite(1)
+	if n := cs.Len(); n != 1 {
+		t.Errorf("after CloseWrite, Len = %d; want 1", n)
+	}
+	remote.Close()
+	if got := <-sends; got != (sent{1, "", true}) {
+		t.Errorf("sent %+v; want EOF on connection 1", got)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for cs.Len() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("connection not closed after both directions finished")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Writes to unknown connections are dropped.
+	cs.Write(2, []byte("dropped"))
+}
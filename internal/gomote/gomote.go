@@ -18,6 +18,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -28,7 +29,9 @@ import (
 	"golang.org/x/build/buildlet"
 	"golang.org/x/build/internal"
 	"golang.org/x/build/internal/access"
+	"golang.org/x/build/internal/coordinator/pool"
 	"golang.org/x/build/internal/coordinator/remote"
+	"golang.org/x/build/internal/gomote/forward"
 	"golang.org/x/build/internal/gomote/protos"
 	goluci "golang.org/x/build/internal/luci"
 	"golang.org/x/build/internal/rendezvous"
@@ -36,6 +39,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // expDisableGolangbuild disables the use of golangbuild during the swarming task gomote bootstrap process.
@@ -63,26 +67,51 @@ type SwarmingServer struct {
 	buildersClient          BuildersClient
 	buildlets               *remote.SessionPool
 	gceBucketName           string
+	localBuildlets          *pool.LocalBuildlet // nil unless instances run locally
+	maxSnapshotSize         int64               // in bytes; 0 means defaultMaxSnapshotSize
 	rendezvous              rendezvousClient
+	snapshots               snapshotStore
 	sshCertificateAuthority ssh.Signer
 	swarmingClient          swarming.Client
+	usage                   *usageTracker
+	warmPools               *warmPools
+}
+
+// SwarmingOption is an option to set for the gomote server.
+type SwarmingOption func(*SwarmingServer)
+
+// QuotaOption sets the quotas of users and the idle timeouts of instances.
+func QuotaOption(cfg *QuotaConfig) SwarmingOption {
+	return func(ss *SwarmingServer) {
+		ss.usage.cfg = cfg
+	}
 }
 
 // NewSwarming creates a gomote server. If the rawCAPriKey is invalid, the program will exit.
-func NewSwarming(rsp *remote.SessionPool, rawCAPriKey []byte, gomoteGCSBucket string, storageClient *storage.Client, rdv *rendezvous.Rendezvous, swarmClient swarming.Client, buildersClient buildbucketpb.BuildersClient) (*SwarmingServer, error) {
+func NewSwarming(rsp *remote.SessionPool, rawCAPriKey []byte, gomoteGCSBucket string, storageClient *storage.Client, rdv *rendezvous.Rendezvous, swarmClient swarming.Client, buildersClient buildbucketpb.BuildersClient, opts ...SwarmingOption) (*SwarmingServer, error) {
 	signer, err := ssh.ParsePrivateKey(rawCAPriKey)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse raw certificate authority private key into signer=%w", err)
 	}
-	return &SwarmingServer{
+	ss := &SwarmingServer{
 		bucket:                  storageClient.Bucket(gomoteGCSBucket),
 		buildersClient:          buildersClient,
 		buildlets:               rsp,
 		gceBucketName:           gomoteGCSBucket,
 		rendezvous:              rdv,
+		snapshots:               &gcsSnapshotStore{bucket: storageClient.Bucket(gomoteGCSBucket)},
 		sshCertificateAuthority: signer,
 		swarmingClient:          swarmClient,
-	}, nil
+		usage:                   newUsageTracker(nil),
+	}
+	for _, opt := range opts {
+		opt(ss)
+	}
+	ss.warmPools = newWarmPools(context.Background(), ss.startWarmInstance)
+	go internal.PeriodicallyDo(context.Background(), usageMaintainInterval, func(ctx context.Context, _ time.Time) {
+		ss.usage.maintain(ss.buildlets)
+	})
+	return ss, nil
 }
 
 // Authenticate will allow the caller to verify that they are properly authenticated and authorized to interact with the
@@ -109,6 +138,10 @@ func (ss *SwarmingServer) AddBootstrap(ctx context.Context, req *protos.AddBoots
 		// the helper function returns meaningful GRPC error.
 		return nil, err
 	}
+	if ss.localBuildlets != nil {
+		// Local builders have no bootstrap version configured.
+		return &protos.AddBootstrapResponse{}, nil
+	}
 	bs, err := ss.validBuilders(ctx)
 	if err != nil {
 		return nil, err
@@ -155,48 +188,61 @@ func (ss *SwarmingServer) CreateInstance(req *protos.CreateInstanceRequest, stre
 		log.Printf("CreateInstance access.IAPFromContext(ctx) = nil, %s", err)
 		return status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
 	}
-	if req.GetBuilderType() == "" {
+	builderType := req.GetBuilderType()
+	var snap *protos.Snapshot
+	var snapObject string
+	if req.GetSnapshot() != "" {
+		snap, snapObject, err = ss.snapshot(stream.Context(), creds.ID, req.GetSnapshot())
+		if err != nil {
+			return err
+		}
+		if builderType == "" {
+			builderType = snap.GetBuilderType()
+		} else if builderType != snap.GetBuilderType() {
+			return status.Errorf(codes.FailedPrecondition, "snapshot %q was taken on builder type %s", req.GetSnapshot(), snap.GetBuilderType())
+		}
+	}
+	if builderType == "" {
 		return status.Errorf(codes.InvalidArgument, "invalid builder type")
 	}
-	bs, err := ss.validBuilders(stream.Context())
+	starter, err := ss.builderStarter(stream.Context(), builderType)
 	if err != nil {
 		return err
 	}
-	builder, ok := bs[req.GetBuilderType()]
-	if !ok {
-		return status.Errorf(codes.InvalidArgument, "unknown builder type")
-	}
 	userName, err := emailToUser(creds.Email)
 	if err != nil {
 		return status.Errorf(codes.Internal, "invalid user email format")
 	}
+	useGolangbuild := !slices.Contains(req.GetExperimentOption(), expDisableGolangbuild)
+	if useGolangbuild {
+		if wi := ss.warmPools.take(warmPoolKey{ownerID: creds.ID, builderType: builderType, snapshot: req.GetSnapshot()}); wi != nil {
+			// The instance already holds a place against the caller's quota.
+			defer wi.release()
+			return ss.sendCreatedInstance(stream, creds, userName, builderType, starter.hostType, wi.bc, wi.taskID, true)
+		}
+	}
+	release, err := ss.usage.reserve(creds.Email, starter.hostType)
+	if err != nil {
+		return quotaStatusError(err)
+	}
+	defer release()
 	type result struct {
 		buildletClient buildlet.Client
 		taskID         string
 		err            error
 	}
 	rc := make(chan result, 1)
-	dimensions := make(map[string]string)
-
-	for _, bd := range builder.GetConfig().GetDimensions() {
-		k, v, ok := strings.Cut(bd, ":")
-		if ok {
-			dimensions[k] = v
-		} else {
-			log.Printf("failed dimension cut: %s", bd)
-		}
-	}
 	name := fmt.Sprintf("gomote-%s-%s", userName, uuid.NewString())
-	cp, err := builderProperties(builder)
-	if err != nil {
-		log.Printf("CreateInstance: builder configuration not found for %s: %s", builder.GetId().GetBuilder(), err)
-		return status.Errorf(codes.Internal, "invalid builder configuration")
-	}
-	useGolangbuild := !slices.Contains(req.GetExperimentOption(), expDisableGolangbuild)
 	go func() {
-		bc, taskID, err := ss.startNewSwarmingTask(stream.Context(), name, dimensions, cp, &SwarmOpts{}, useGolangbuild)
+		bc, taskID, err := starter.start(stream.Context(), name, useGolangbuild)
 		if err != nil {
-			log.Printf("startNewSwarmingTask() = %s", err)
+			log.Printf("unable to start instance %s: %s", name, err)
+		} else if snap != nil {
+			if err = ss.restoreSnapshot(stream.Context(), bc, snap, snapObject); err != nil {
+				log.Printf("unable to restore snapshot %q on %s: %s", snap.GetName(), name, err)
+				err = fmt.Errorf("unable to restore snapshot %q: %w", snap.GetName(), err)
+				bc.Close()
+			}
 		}
 		rc <- result{buildletClient: bc, taskID: taskID, err: err}
 	}()
@@ -217,40 +263,65 @@ func (ss *SwarmingServer) CreateInstance(req *protos.CreateInstanceRequest, stre
 		case r := <-rc:
 			if r.err != nil {
 				log.Printf("error creating gomote buildlet instance=%s: %s", name, r.err)
-				if cp.KnownIssue != 0 {
-					return status.Errorf(codes.Internal, "gomote creation failed: note that builder has known issue go.dev/issue/%d", cp.KnownIssue)
+				if starter.knownIssue != 0 {
+					return status.Errorf(codes.Internal, "gomote creation failed: note that builder has known issue go.dev/issue/%d", starter.knownIssue)
 				}
 				return status.Errorf(codes.Internal, "gomote creation failed instance=%s", name)
 			}
-			gomoteID := ss.buildlets.AddSession(creds.ID, userName, req.GetBuilderType(), req.GetBuilderType(), r.taskID, r.buildletClient)
-			log.Printf("created buildlet %s for %s (%s)", gomoteID, userName, r.buildletClient.String())
-			session, err := ss.buildlets.Session(gomoteID)
-			if err != nil {
-				return status.Errorf(codes.Internal, "unable to query for gomote timeout") // this should never happen
-			}
-			wd, err := r.buildletClient.WorkDir(stream.Context())
-			if err != nil {
-				return status.Errorf(codes.Internal, "could not read working dir: %v", err)
-			}
-			err = stream.Send(&protos.CreateInstanceResponse{
-				Instance: &protos.Instance{
-					GomoteId:    gomoteID,
-					BuilderType: req.GetBuilderType(),
-					HostType:    "swarming task",
-					Expires:     session.Expires.Unix(),
-					WorkingDir:  wd,
-				},
-				Status:       protos.CreateInstanceResponse_COMPLETE,
-				WaitersAhead: 0,
-			})
-			if err != nil {
-				return status.Errorf(codes.Internal, "unable to stream result: %s", err)
-			}
-			return nil
+			return ss.sendCreatedInstance(stream, creds, userName, builderType, starter.hostType, r.buildletClient, r.taskID, false)
 		}
 	}
 }
 
+// sendCreatedInstance adds a session for a newly created instance and sends it to the caller.
+func (ss *SwarmingServer) sendCreatedInstance(stream protos.GomoteService_CreateInstanceServer, creds *access.IAPFields, userName, builderType, hostType string, bc buildlet.Client, taskID string, fromWarmPool bool) error {
+	gomoteID := ss.buildlets.AddSession(creds.ID, userName, builderType, hostType, taskID, bc)
+	ss.usage.start(gomoteID, creds.ID, creds.Email, hostType)
+	log.Printf("created buildlet %s for %s (%s)", gomoteID, userName, bc.String())
+	session, err := ss.buildlets.Session(gomoteID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to query for gomote timeout") // this should never happen
+	}
+	wd, err := bc.WorkDir(stream.Context())
+	if err != nil {
+		return status.Errorf(codes.Internal, "could not read working dir: %v", err)
+	}
+	respHostType := "swarming task"
+	if ss.localBuildlets != nil {
+		respHostType = hostType
+	}
+	err = stream.Send(&protos.CreateInstanceResponse{
+		Instance: &protos.Instance{
+			GomoteId:    gomoteID,
+			BuilderType: builderType,
+			HostType:    respHostType,
+			Expires:     session.Expires.Unix(),
+			WorkingDir:  wd,
+		},
+		Status:       protos.CreateInstanceResponse_COMPLETE,
+		WaitersAhead: 0,
+		FromWarmPool: fromWarmPool,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to stream result: %s", err)
+	}
+	return nil
+}
+
+// builderDimensions returns the swarming dimensions of a builder.
+func builderDimensions(builder *buildbucketpb.BuilderItem) map[string]string {
+	dimensions := make(map[string]string)
+	for _, bd := range builder.GetConfig().GetDimensions() {
+		k, v, ok := strings.Cut(bd, ":")
+		if ok {
+			dimensions[k] = v
+		} else {
+			log.Printf("failed dimension cut: %s", bd)
+		}
+	}
+	return dimensions
+}
+
 // DestroyInstance will destroy a gomote instance. It will ensure that the caller is authenticated and is the owner of the instance
 // before it destroys the instance.
 func (ss *SwarmingServer) DestroyInstance(ctx context.Context, req *protos.DestroyInstanceRequest) (*protos.DestroyInstanceResponse, error) {
@@ -271,6 +342,7 @@ func (ss *SwarmingServer) DestroyInstance(ctx context.Context, req *protos.Destr
 		log.Printf("DestroyInstance remote.DestroySession(%s) = %s", req.GetGomoteId(), err)
 		return nil, status.Errorf(codes.Internal, "unable to destroy gomote instance")
 	}
+	ss.usage.end(req.GetGomoteId())
 	// TODO(go.dev/issue/63819) consider destroying the bot after the task has ended.
 	return &protos.DestroyInstanceResponse{}, nil
 }
@@ -290,7 +362,8 @@ func (ss *SwarmingServer) ExecuteCommand(req *protos.ExecuteCommandRequest, stre
 	if builderType == "" {
 		builderType = ses.BuilderType
 	}
-	remoteErr, execErr := bc.Exec(stream.Context(), req.GetCommand(), buildlet.ExecOpts{
+	var result *buildlet.ExecResult
+	opts := buildlet.ExecOpts{
 		Dir:         req.GetDirectory(),
 		SystemLevel: req.GetSystemLevel(),
 		Output: &streamWriter{writeFunc: func(p []byte) (int, error) {
@@ -306,11 +379,33 @@ func (ss *SwarmingServer) ExecuteCommand(req *protos.ExecuteCommandRequest, stre
 		ExtraEnv: req.GetAppendEnvironment(),
 		Debug:    req.GetDebug(),
 		Path:     req.GetPath(),
-	})
+		OnExit:   func(res *buildlet.ExecResult) { result = res },
+		Limits:   execLimits(req.GetLimits()),
+	}
+	if req.GetSeparateStderr() {
+		opts.Stderr = &streamWriter{writeFunc: func(p []byte) (int, error) {
+			err := stream.Send(&protos.ExecuteCommandResponse{
+				Stderr: p,
+			})
+			if err != nil {
+				return 0, fmt.Errorf("unable to send data=%w", err)
+			}
+			return len(p), nil
+		}}
+	}
+	remoteErr, execErr := bc.Exec(stream.Context(), req.GetCommand(), opts)
+	if errors.Is(execErr, buildlet.ErrExecBusy) {
+		return execBusyError(req.GetGomoteId())
+	}
 	if execErr != nil {
 		// there were system errors preventing the command from being started or seen to completion.
 		return status.Errorf(codes.Aborted, "unable to execute command: %s", execErr)
 	}
+	if result != nil {
+		if err := stream.Send(&protos.ExecuteCommandResponse{ExitStatus: exitStatus(result)}); err != nil {
+			return status.Errorf(codes.Internal, "unable to stream result: %s", err)
+		}
+	}
 	if remoteErr != nil {
 		// the command failed remotely
 		return status.Errorf(codes.Unknown, "command execution failed: %s", remoteErr)
@@ -318,6 +413,237 @@ func (ss *SwarmingServer) ExecuteCommand(req *protos.ExecuteCommandRequest, stre
 	return nil
 }
 
+// ExecuteCommandInteractive will execute a command on a gomote instance, with the command's standard input, and
+// optionally a pseudo-terminal, connected to the caller. The first message from the caller starts the command, and
+// the last message to the caller reports how it ended.
+func (ss *SwarmingServer) ExecuteCommandInteractive(stream protos.GomoteService_ExecuteCommandInteractiveServer) error {
+	creds, err := access.IAPFromContext(stream.Context())
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	start, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "missing command")
+	}
+	req := start.GetCommand()
+	if req == nil {
+		return status.Errorf(codes.InvalidArgument, "the first message must set the command")
+	}
+	_, bc, err := ss.sessionAndClient(stream.Context(), req.GetGomoteId(), creds.ID)
+	if err != nil {
+		// the helper function returns meaningful GRPC error.
+		return err
+	}
+	// The output writers are only called by the session's receiving
+	// goroutine, which finishes before the exit status is sent, so
+	// stream.Send is never called concurrently.
+	send := func(res *protos.ExecuteCommandInteractiveResponse) (int, error) {
+		if err := stream.Send(res); err != nil {
+			return 0, fmt.Errorf("unable to send data=%w", err)
+		}
+		return len(res.GetStdout()) + len(res.GetStderr()), nil
+	}
+	es, err := bc.StartExec(stream.Context(), req.GetCommand(), buildlet.ExecSessionOpts{
+		ExecOpts: buildlet.ExecOpts{
+			Dir:         req.GetDirectory(),
+			SystemLevel: req.GetSystemLevel(),
+			Output: &streamWriter{writeFunc: func(p []byte) (int, error) {
+				return send(&protos.ExecuteCommandInteractiveResponse{Stdout: p})
+			}},
+			Stderr: &streamWriter{writeFunc: func(p []byte) (int, error) {
+				return send(&protos.ExecuteCommandInteractiveResponse{Stderr: p})
+			}},
+			Args:     req.GetArgs(),
+			ExtraEnv: req.GetAppendEnvironment(),
+			Debug:    req.GetDebug(),
+			Path:     req.GetPath(),
+			Limits:   execLimits(req.GetLimits()),
+		},
+		TTY:  start.GetTty(),
+		Term: start.GetTerm(),
+		Rows: uint16(start.GetWindowSize().GetRows()),
+		Cols: uint16(start.GetWindowSize().GetCols()),
+	})
+	if errors.Is(err, buildlet.ErrExecBusy) {
+		return execBusyError(req.GetGomoteId())
+	}
+	if err != nil {
+		return status.Errorf(codes.Aborted, "unable to execute command: %s", err)
+	}
+	defer es.Close()
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err == io.EOF {
+				// The caller has nothing more to send, but still
+				// wants the command's output.
+				return
+			} else if err != nil {
+				es.Close()
+				return
+			}
+			if len(in.GetStdin()) > 0 {
+				es.Write(in.GetStdin())
+			}
+			if in.GetCloseStdin() {
+				es.CloseStdin()
+			}
+			if ws := in.GetWindowSize(); ws != nil {
+				es.Resize(uint16(ws.GetRows()), uint16(ws.GetCols()))
+			}
+			if sig := in.GetSignal(); sig != "" {
+				es.Signal(sig)
+			}
+		}
+	}()
+	res, err := es.Wait()
+	if err != nil {
+		// there were system errors preventing the command from being seen to completion.
+		return status.Errorf(codes.Aborted, "unable to execute command: %s", err)
+	}
+	return stream.Send(&protos.ExecuteCommandInteractiveResponse{
+		ExitStatus: exitStatus(res),
+	})
+}
+
+// exitStatus converts the result of a command run on a buildlet to its
+// protobuf form.
+func exitStatus(res *buildlet.ExecResult) *protos.ExitStatus {
+	return &protos.ExitStatus{
+		Code:          int32(res.ExitCode),
+		Signal:        res.Signal,
+		State:         res.State,
+		WallTime:      durationpb.New(res.WallTime),
+		UserTime:      durationpb.New(res.UserTime),
+		SystemTime:    durationpb.New(res.SystemTime),
+		MaxRss:        res.MaxRSS,
+		LimitExceeded: res.LimitExceeded,
+	}
+}
+
+// execBusyError is the error returned when an instance refuses to start a command alongside another because
+// one of them has a disk limit.
+func execBusyError(gomoteID string) error {
+	return status.Errorf(codes.Unavailable, "instance %s is busy running a command with a disk limit, or can't run one alongside other commands; retry once they're done", gomoteID)
+}
+
+// execLimits converts limits on the resources of a command from their
+// protobuf form.
+func execLimits(lim *protos.ExecLimits) *buildlet.ExecLimits {
+	if lim == nil {
+		return nil
+	}
+	return &buildlet.ExecLimits{
+		CPU:       lim.GetCpus(),
+		Memory:    lim.GetMemoryBytes(),
+		WallTime:  lim.GetWallTime().AsDuration(),
+		Processes: int(lim.GetProcesses()),
+		Disk:      lim.GetDiskBytes(),
+		NoNetwork: lim.GetNoNetwork(),
+	}
+}
+
+// ForwardPort forwards TCP connections between the caller and a port on the gomote instance's loopback interface,
+// multiplexing them over the stream. In reverse mode the gomote instance listens on the port and forwards the
+// connections it accepts to the caller.
+func (ss *SwarmingServer) ForwardPort(stream protos.GomoteService_ForwardPortServer) error {
+	creds, err := access.IAPFromContext(stream.Context())
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	start, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "missing forwarding request")
+	}
+	port, reverse := int(start.GetRemotePort()), start.GetReverse()
+	if port < 0 || port > 65535 || port == 0 && !reverse {
+		return status.Errorf(codes.InvalidArgument, "invalid port %d", port)
+	}
+	_, bc, err := ss.sessionAndClient(stream.Context(), start.GetGomoteId(), creds.ID)
+	if err != nil {
+		// the helper function returns meaningful GRPC error.
+		return err
+	}
+
+	// The connections' goroutines may outlive this method, which must
+	// not call stream.Send after it returns.
+	var sendMu sync.Mutex
+	done := false
+	send := func(res *protos.ForwardPortResponse) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		if done {
+			return errors.New("stream closed")
+		}
+		return stream.Send(res)
+	}
+	conns := forward.NewConns(func(id int64, data []byte, err error) error {
+		if data != nil {
+			return send(&protos.ForwardPortResponse{ConnId: id, Data: data})
+		}
+		res := &protos.ForwardPortResponse{ConnId: id, Close: true}
+		if err != nil {
+			res.Error = err.Error()
+		}
+		return send(res)
+	})
+	defer func() {
+		sendMu.Lock()
+		done = true
+		sendMu.Unlock()
+		conns.CloseAll()
+	}()
+
+	if reverse {
+		ln, err := bc.ListenTCP(stream.Context(), port)
+		if err != nil {
+			return status.Errorf(codes.FailedPrecondition, "unable to listen on port %d: %s", port, err)
+		}
+		defer ln.Close()
+		if err := send(&protos.ForwardPortResponse{ListenAddress: ln.Addr()}); err != nil {
+			return err
+		}
+		go func() {
+			for id := int64(1); ; id++ {
+				c, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				if err := send(&protos.ForwardPortResponse{ConnId: id, Open: true}); err != nil {
+					c.Close()
+					return
+				}
+				conns.Add(id, c)
+			}
+		}()
+	}
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		id := req.GetConnId()
+		switch {
+		case req.GetOpen():
+			if reverse {
+				return status.Errorf(codes.InvalidArgument, "can't open connections in reverse mode")
+			}
+			c, err := bc.ProxyTCP(port)
+			if err != nil {
+				send(&protos.ForwardPortResponse{ConnId: id, Close: true, Error: err.Error()})
+				continue
+			}
+			conns.Add(id, c)
+		case len(req.GetData()) > 0:
+			conns.Write(id, req.GetData())
+		case req.GetClose():
+			conns.CloseWrite(id)
+		}
+	}
+}
+
 // InstanceAlive will ensure that the gomote instance is still alive and will extend the timeout. The requester must be authenticated.
 func (ss *SwarmingServer) InstanceAlive(ctx context.Context, req *protos.InstanceAliveRequest) (*protos.InstanceAliveResponse, error) {
 	creds, err := access.IAPFromContext(ctx)
@@ -483,6 +809,9 @@ func (ss *SwarmingServer) ListSwarmingBuilders(ctx context.Context, req *protos.
 		log.Printf("ListSwarmingInstances access.IAPFromContext(ctx) = nil, %s", err)
 		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
 	}
+	if ss.localBuildlets != nil {
+		return &protos.ListSwarmingBuildersResponse{Builders: ss.localBuilders()}, nil
+	}
 	bs, err := ss.validBuilders(ctx)
 	if err != nil {
 		return nil, err
@@ -638,6 +967,85 @@ func (ss *SwarmingServer) signURLForUpload(object string) (url string, fields ma
 	return pv4.URL, pv4.Fields, nil
 }
 
+// SyncManifest streams the regular files in a directory on the gomote instance, with the hashes of their blocks.
+func (ss *SwarmingServer) SyncManifest(req *protos.SyncManifestRequest, stream grpc.ServerStreamingServer[protos.SyncManifestResponse]) error {
+	creds, err := access.IAPFromContext(stream.Context())
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	if req.GetGomoteId() == "" {
+		return status.Errorf(codes.InvalidArgument, "invalid gomote ID")
+	}
+	_, bc, err := ss.sessionAndClient(stream.Context(), req.GetGomoteId(), creds.ID)
+	if err != nil {
+		// the helper function returns meaningful GRPC error.
+		return err
+	}
+	const chunkSize = 100
+	var files []*protos.SyncFile
+	var sendErr error
+	flush := func() {
+		if len(files) > 0 && sendErr == nil {
+			sendErr = stream.Send(&protos.SyncManifestResponse{Files: files})
+		}
+		files = nil
+	}
+	err = bc.SyncManifest(stream.Context(), req.GetDirectory(), buildlet.SyncManifestOpts{Skip: req.GetSkipFiles()}, func(f buildlet.SyncFile) {
+		files = append(files, &protos.SyncFile{
+			Path:   f.Path,
+			Mode:   uint32(f.Mode),
+			Size:   f.Size,
+			Blocks: f.Blocks,
+		})
+		if len(files) == chunkSize {
+			flush()
+		}
+	})
+	if errors.Is(err, buildlet.ErrSyncUnsupported) {
+		return status.Errorf(codes.Unimplemented, "unable to list files: %s", err)
+	}
+	if err != nil {
+		return status.Errorf(codes.Aborted, "unable to list files: %s", err)
+	}
+	flush()
+	return sendErr
+}
+
+// WriteSyncFromURL has the gomote instance retrieve a stream of changes to a directory from the passed in URL and apply them.
+func (ss *SwarmingServer) WriteSyncFromURL(ctx context.Context, req *protos.WriteSyncFromURLRequest) (*protos.WriteSyncFromURLResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("WriteSyncFromURL access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	if req.GetGomoteId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid gomote ID")
+	}
+	if req.GetUrl() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "missing URL")
+	}
+	_, bc, err := ss.sessionAndClient(ctx, req.GetGomoteId(), creds.ID)
+	if err != nil {
+		// the helper function returns meaningful GRPC error.
+		return nil, err
+	}
+	url := req.GetUrl()
+	if onObjectStore(ss.gceBucketName, url) {
+		object, err := objectFromURL(ss.gceBucketName, url)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid URL")
+		}
+		url, err = ss.signURLForDownload(object)
+		if err != nil {
+			return nil, status.Errorf(codes.Aborted, "unable to sign url for download: %s", err)
+		}
+	}
+	if err := bc.PutSyncFromURL(ctx, url, req.GetDirectory()); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "unable to apply changes: %s", err)
+	}
+	return &protos.WriteSyncFromURLResponse{}, nil
+}
+
 // WriteFileFromURL initiates an HTTP request to the passed in URL and streams the contents of the request to the gomote instance.
 func (ss *SwarmingServer) WriteFileFromURL(ctx context.Context, req *protos.WriteFileFromURLRequest) (*protos.WriteFileFromURLResponse, error) {
 	creds, err := access.IAPFromContext(ctx)
@@ -7,6 +7,7 @@
 package gomote
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -15,7 +16,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -35,28 +38,36 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 )
 
 const testSwarmingBucketName = "unit-testing-bucket-swarming"
 
-func fakeGomoteSwarmingServer(t *testing.T, ctx context.Context, swarmClient swarming.Client, rdv rendezvousClient) protos.GomoteServiceServer {
+func fakeGomoteSwarmingServer(t *testing.T, ctx context.Context, swarmClient swarming.Client, rdv rendezvousClient, opts ...SwarmingOption) protos.GomoteServiceServer {
 	signer, err := ssh.ParsePrivateKey([]byte(devCertCAPrivate))
 	if err != nil {
 		t.Fatalf("unable to parse raw certificate authority private key into signer=%s", err)
 	}
-	return &SwarmingServer{
+	ss := &SwarmingServer{
 		bucket:                  &fakeBucketHandler{bucketName: testSwarmingBucketName},
 		buildlets:               remote.NewSessionPool(ctx),
 		gceBucketName:           testSwarmingBucketName,
+		snapshots:               &fakeSnapshotStore{m: make(map[string]*protos.Snapshot)},
 		sshCertificateAuthority: signer,
 		rendezvous:              rdv,
 		swarmingClient:          swarmClient,
 		buildersClient:          &FakeBuildersClient{},
+		usage:                   newUsageTracker(nil),
+	}
+	ss.warmPools = newWarmPools(ctx, ss.startWarmInstance)
+	for _, opt := range opts {
+		opt(ss)
 	}
+	return ss
 }
 
-func setupGomoteSwarmingTest(t *testing.T, ctx context.Context, swarmClient swarming.Client) protos.GomoteServiceClient {
+func setupGomoteSwarmingTest(t *testing.T, ctx context.Context, swarmClient swarming.Client, opts ...SwarmingOption) protos.GomoteServiceClient {
 	lis, err := nettest.NewLocalListener("tcp")
 	if err != nil {
 		t.Fatalf("unable to create net listener: %s", err)
@@ -64,7 +75,7 @@ func setupGomoteSwarmingTest(t *testing.T, ctx context.Context, swarmClient swar
 	rdv := rendezvous.NewFake(context.Background(), func(ctx context.Context, jwt string) bool { return true })
 	sopts := access.FakeIAPAuthInterceptorOptions()
 	s := grpc.NewServer(sopts...)
-	protos.RegisterGomoteServiceServer(s, fakeGomoteSwarmingServer(t, ctx, swarmClient, rdv))
+	protos.RegisterGomoteServiceServer(s, fakeGomoteSwarmingServer(t, ctx, swarmClient, rdv, opts...))
 	go s.Serve(lis)
 
 	// create GRPC client
@@ -304,6 +315,171 @@ func TestSwarmingCreateInstanceError(t *testing.T) {
 	}
 }
 
+func TestSwarmingCreateSnapshot(t *testing.T) {
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+	gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
+	req := &protos.CreateSnapshotRequest{GomoteId: gomoteID, Name: "built", Directory: "go"}
+	if _, err := client.CreateSnapshot(ctx, req); err != nil {
+		t.Fatalf("client.CreateSnapshot(ctx, %v) = _, %s; want no error", req, err)
+	}
+	resp, err := client.ListSnapshots(ctx, &protos.ListSnapshotsRequest{})
+	if err != nil {
+		t.Fatalf("client.ListSnapshots(ctx, req) = _, %s; want no error", err)
+	}
+	want := []*protos.Snapshot{{
+		Name:        "built",
+		BuilderType: "gotip-linux-amd64-boringcrypto",
+		Directory:   "go",
+		Size:        int64(len("the gopher goes to the sea and fights the kraken")),
+	}}
+	if diff := cmp.Diff(want, resp.GetSnapshots(), protocmp.Transform(), protocmp.IgnoreFields(&protos.Snapshot{}, "created")); diff != "" {
+		t.Errorf("ListSnapshots() mismatch (-want, +got):\n%s", diff)
+	}
+
+	// An instance created from the snapshot has the snapshot's builder type.
+	got, err := createSwarmingInstance(ctx, client, &protos.CreateInstanceRequest{Snapshot: "built"})
+	if err != nil {
+		t.Fatalf("CreateInstance from snapshot = %s; want no error", err)
+	}
+	if bt := got.GetInstance().GetBuilderType(); bt != "gotip-linux-amd64-boringcrypto" {
+		t.Errorf("instance created from snapshot has builder type %q; want %q", bt, "gotip-linux-amd64-boringcrypto")
+	}
+
+	if _, err := client.DeleteSnapshot(ctx, &protos.DeleteSnapshotRequest{Name: "built"}); err != nil {
+		t.Fatalf("client.DeleteSnapshot(ctx, req) = _, %s; want no error", err)
+	}
+	if _, err := client.DeleteSnapshot(ctx, &protos.DeleteSnapshotRequest{Name: "built"}); status.Code(err) != codes.NotFound {
+		t.Errorf("second client.DeleteSnapshot(ctx, req) = _, %s; want %s", err, codes.NotFound)
+	}
+	resp, err = client.ListSnapshots(ctx, &protos.ListSnapshotsRequest{})
+	if err != nil {
+		t.Fatalf("client.ListSnapshots(ctx, req) = _, %s; want no error", err)
+	}
+	if len(resp.GetSnapshots()) != 0 {
+		t.Errorf("ListSnapshots() = %v after deletion; want none", resp.GetSnapshots())
+	}
+}
+
+func TestSwarmingCreateSnapshotTooLarge(t *testing.T) {
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	// The fake buildlet's tar is 48 bytes.
+	for _, tc := range []struct {
+		max      int64
+		wantCode codes.Code
+	}{
+		{47, codes.ResourceExhausted},
+		{48, codes.OK},
+	} {
+		client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple(), SnapshotSizeOption(tc.max))
+		gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
+		req := &protos.CreateSnapshotRequest{GomoteId: gomoteID, Name: "built", Directory: "go"}
+		if _, err := client.CreateSnapshot(ctx, req); status.Code(err) != tc.wantCode {
+			t.Fatalf("client.CreateSnapshot(ctx, %v) with a maximum of %d bytes = _, %v; want %s", req, tc.max, err, tc.wantCode)
+		}
+		resp, err := client.ListSnapshots(ctx, &protos.ListSnapshotsRequest{})
+		if err != nil {
+			t.Fatalf("client.ListSnapshots(ctx, req) = _, %s; want no error", err)
+		}
+		if stored := len(resp.GetSnapshots()) == 1; stored != (tc.wantCode == codes.OK) {
+			t.Errorf("ListSnapshots() = %v with a maximum of %d bytes; want it stored only if it fits", resp.GetSnapshots(), tc.max)
+		}
+	}
+}
+
+func TestSwarmingCreateSnapshotError(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stdout)
+
+	testCases := []struct {
+		desc     string
+		ctx      context.Context
+		name     string
+		wantCode codes.Code
+	}{
+		{
+			desc:     "unauthenticated request",
+			ctx:      context.Background(),
+			name:     "built",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			desc:     "invalid name",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			name:     "../built",
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "wrong gomote id",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAPWithUser("foo", "bar")),
+			name:     "built",
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+			gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
+			req := &protos.CreateSnapshotRequest{GomoteId: gomoteID, Name: tc.name}
+			got, err := client.CreateSnapshot(tc.ctx, req)
+			if err != nil && status.Code(err) != tc.wantCode {
+				t.Fatalf("unexpected error: %s; want %s", err, tc.wantCode)
+			}
+			if err == nil {
+				t.Fatalf("client.CreateSnapshot(ctx, %v) = %v, nil; want error", req, got)
+			}
+		})
+	}
+}
+
+func TestSwarmingCreateInstanceFromSnapshotError(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stdout)
+
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+	gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
+	if _, err := client.CreateSnapshot(ctx, &protos.CreateSnapshotRequest{GomoteId: gomoteID, Name: "built"}); err != nil {
+		t.Fatalf("client.CreateSnapshot(ctx, req) = _, %s; want no error", err)
+	}
+	testCases := []struct {
+		desc     string
+		ctx      context.Context
+		request  *protos.CreateInstanceRequest
+		wantCode codes.Code
+	}{
+		{
+			desc:     "missing snapshot",
+			ctx:      ctx,
+			request:  &protos.CreateInstanceRequest{Snapshot: "missing"},
+			wantCode: codes.NotFound,
+		},
+		{
+			desc:     "snapshot of another user",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAPWithUser("foo", "bar")),
+			request:  &protos.CreateInstanceRequest{Snapshot: "built"},
+			wantCode: codes.NotFound,
+		},
+		{
+			desc:     "builder type mismatch",
+			ctx:      ctx,
+			request:  &protos.CreateInstanceRequest{BuilderType: "gotip-linux-amd64-boringcrypto-test_only", Snapshot: "built"},
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := createSwarmingInstance(tc.ctx, client, tc.request)
+			if err == nil {
+				t.Fatalf("CreateInstance(ctx, %v) = %v, nil; want error", tc.request, got)
+			}
+			if status.Code(err) != tc.wantCode {
+				t.Fatalf("unexpected error: %s; want %s", err, tc.wantCode)
+			}
+		})
+	}
+}
+
 func TestSwarmingDestroyInstance(t *testing.T) {
 	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
 	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
@@ -406,6 +582,46 @@ func TestSwarmingExecuteCommand(t *testing.T) {
 	}
 }
 
+func TestSwarmingExecuteCommandSeparateStderr(t *testing.T) {
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+	gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
+	stream, err := client.ExecuteCommand(ctx, &protos.ExecuteCommandRequest{
+		GomoteId:       gomoteID,
+		Command:        "ls",
+		Args:           []string{"-alh"},
+		SeparateStderr: true,
+	})
+	if err != nil {
+		t.Fatalf("client.ExecuteCommand(ctx, req) = response, %s; want no error", err)
+	}
+	var stdout, stderr []byte
+	var es *protos.ExitStatus
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("stream.Recv() = _, %s; want no error", err)
+		}
+		stdout = append(stdout, res.GetOutput()...)
+		stderr = append(stderr, res.GetStderr()...)
+		if res.GetExitStatus() != nil {
+			es = res.GetExitStatus()
+		}
+	}
+	if len(stdout) == 0 || bytes.Contains(stdout, stderr) {
+		t.Errorf("stdout = %q; want non-empty output without stderr", stdout)
+	}
+	if len(stderr) == 0 {
+		t.Errorf("stderr = %q; want non-empty", stderr)
+	}
+	if es == nil || es.GetCode() != 0 || es.GetState() != "ok" {
+		t.Errorf("exit status = %v; want success", es)
+	}
+}
+
 func TestSwarmingExecuteCommandError(t *testing.T) {
 	// This test will create a gomote instance and attempt to call TestExecuteCommand.
 	// If overrideID is set to true, the test will use a different gomoteID than
@@ -482,6 +698,209 @@ func TestSwarmingExecuteCommandError(t *testing.T) {
 	}
 }
 
+func TestSwarmingExecuteCommandInteractive(t *testing.T) {
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+	gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
+	stream, err := client.ExecuteCommandInteractive(ctx)
+	if err != nil {
+		t.Fatalf("client.ExecuteCommandInteractive(ctx) = _, %s; want no error", err)
+	}
+	reqs := []*protos.ExecuteCommandInteractiveRequest{
+		{
+			Command: &protos.ExecuteCommandRequest{
+				GomoteId: gomoteID,
+				Command:  "cat",
+			},
+			Tty:        true,
+			WindowSize: &protos.WindowSize{Rows: 24, Cols: 80},
+		},
+		{Stdin: []byte("hello, ")},
+		{Stdin: []byte("gopher"), CloseStdin: true},
+	}
+	for _, req := range reqs {
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("stream.Send(%v) = %s; want no error", req, err)
+		}
+	}
+	var out []byte
+	var exit *protos.ExitStatus
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("stream.Recv() = _, %s; want no error", err)
+		}
+		out = append(out, res.GetStdout()...)
+		if res.GetExitStatus() != nil {
+			exit = res.GetExitStatus()
+		}
+	}
+	if string(out) != "hello, gopher" {
+		t.Errorf("output = %q; want %q", out, "hello, gopher")
+	}
+	if exit.GetState() != "ok" || exit.GetCode() != 0 {
+		t.Errorf("exit status = %v; want ok", exit)
+	}
+}
+
+func TestSwarmingExecuteCommandInteractiveError(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		ctx      context.Context
+		req      *protos.ExecuteCommandInteractiveRequest
+		wantCode codes.Code
+	}{
+		{
+			desc:     "unauthenticated request",
+			ctx:      context.Background(),
+			req:      &protos.ExecuteCommandInteractiveRequest{Command: &protos.ExecuteCommandRequest{Command: "ls"}},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			desc:     "missing command",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			req:      &protos.ExecuteCommandInteractiveRequest{Stdin: []byte("hi")},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "gomote does not exist",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			req:      &protos.ExecuteCommandInteractiveRequest{Command: &protos.ExecuteCommandRequest{GomoteId: "chucky", Command: "ls"}},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+			stream, err := client.ExecuteCommandInteractive(tc.ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			stream.Send(tc.req)
+			res, err := stream.Recv()
+			if err == nil {
+				t.Fatalf("stream.Recv() = %v, nil; want error", res)
+			}
+			if status.Code(err) != tc.wantCode {
+				t.Fatalf("stream.Recv() = _, %s; want code %s", err, tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestSwarmingForwardPort(t *testing.T) {
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+	gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
+	stream, err := client.ForwardPort(ctx)
+	if err != nil {
+		t.Fatalf("client.ForwardPort(ctx) = _, %s; want no error", err)
+	}
+	// The fake buildlet's ports echo what they're sent.
+	reqs := []*protos.ForwardPortRequest{
+		{GomoteId: gomoteID, RemotePort: 8080},
+		{ConnId: 1, Open: true},
+		{ConnId: 1, Data: []byte("hello")},
+		{ConnId: 2, Open: true},
+		{ConnId: 2, Data: []byte("world")},
+	}
+	for _, req := range reqs {
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("stream.Send(%v) = %s; want no error", req, err)
+		}
+	}
+	got := make(map[int64]string)
+	for len(got[1]) < 5 || len(got[2]) < 5 {
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("stream.Recv() = _, %s; want no error", err)
+		}
+		got[res.GetConnId()] += string(res.GetData())
+	}
+	if got[1] != "hello" || got[2] != "world" {
+		t.Errorf("received %q; want each connection's data echoed", got)
+	}
+	stream.CloseSend()
+}
+
+func TestSwarmingForwardPortReverse(t *testing.T) {
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+	gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
+	stream, err := client.ForwardPort(ctx)
+	if err != nil {
+		t.Fatalf("client.ForwardPort(ctx) = _, %s; want no error", err)
+	}
+	if err := stream.Send(&protos.ForwardPortRequest{GomoteId: gomoteID, Reverse: true}); err != nil {
+		t.Fatalf("stream.Send(req) = %s; want no error", err)
+	}
+	var got []string
+	for len(got) < 3 {
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("stream.Recv() = _, %s; want no error", err)
+		}
+		got = append(got, fmt.Sprintf("addr=%q conn=%d open=%v data=%q", res.GetListenAddress(), res.GetConnId(), res.GetOpen(), res.GetData()))
+	}
+	want := []string{
+		`addr="127.0.0.1:12345" conn=0 open=false data=""`,
+		`addr="" conn=1 open=true data=""`,
+		`addr="" conn=1 open=false data="hello from the fake buildlet\n"`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("responses mismatch (-want +got):\n%s", diff)
+	}
+	stream.CloseSend()
+}
+
+func TestSwarmingForwardPortError(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		ctx      context.Context
+		req      *protos.ForwardPortRequest
+		wantCode codes.Code
+	}{
+		{
+			desc:     "unauthenticated request",
+			ctx:      context.Background(),
+			req:      &protos.ForwardPortRequest{RemotePort: 8080},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			desc:     "missing port",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			req:      &protos.ForwardPortRequest{GomoteId: "chucky"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "gomote does not exist",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			req:      &protos.ForwardPortRequest{GomoteId: "chucky", RemotePort: 8080},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+			stream, err := client.ForwardPort(tc.ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			stream.Send(tc.req)
+			res, err := stream.Recv()
+			if err == nil {
+				t.Fatalf("stream.Recv() = %v, nil; want error", res)
+			}
+			if status.Code(err) != tc.wantCode {
+				t.Fatalf("stream.Recv() = _, %s; want code %s", err, tc.wantCode)
+			}
+		})
+	}
+}
+
 func TestSwarmingInstanceAlive(t *testing.T) {
 	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
 	gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
@@ -765,6 +1184,215 @@ func TestSwarmingRemoveFiles(t *testing.T) {
 	}
 }
 
+func TestSwarmingSetWarmPool(t *testing.T) {
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+	req := &protos.SetWarmPoolRequest{BuilderType: "gotip-linux-amd64-boringcrypto", Size: 1}
+	if _, err := client.SetWarmPool(ctx, req); err != nil {
+		t.Fatalf("client.SetWarmPool(ctx, %v) = _, %s; want no error", req, err)
+	}
+	for {
+		resp, err := client.ListWarmPools(ctx, &protos.ListWarmPoolsRequest{})
+		if err != nil {
+			t.Fatalf("client.ListWarmPools(ctx, req) = _, %s; want no error", err)
+		}
+		if pools := resp.GetWarmPools(); len(pools) == 1 && pools[0].GetReady() == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	got, err := createSwarmingInstance(ctx, client, &protos.CreateInstanceRequest{BuilderType: "gotip-linux-amd64-boringcrypto"})
+	if err != nil {
+		t.Fatalf("CreateInstance = %s; want no error", err)
+	}
+	if !got.GetFromWarmPool() {
+		t.Errorf("CreateInstance didn't take the instance from the warm pool")
+	}
+
+	req.Size = 0
+	if _, err := client.SetWarmPool(ctx, req); err != nil {
+		t.Fatalf("client.SetWarmPool(ctx, %v) = _, %s; want no error", req, err)
+	}
+	resp, err := client.ListWarmPools(ctx, &protos.ListWarmPoolsRequest{})
+	if err != nil {
+		t.Fatalf("client.ListWarmPools(ctx, req) = _, %s; want no error", err)
+	}
+	if len(resp.GetWarmPools()) != 0 {
+		t.Errorf("ListWarmPools() = %v after removal; want none", resp.GetWarmPools())
+	}
+}
+
+func TestSwarmingSetWarmPoolError(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stdout)
+
+	testCases := []struct {
+		desc     string
+		ctx      context.Context
+		request  *protos.SetWarmPoolRequest
+		wantCode codes.Code
+	}{
+		{
+			desc:     "unauthenticated request",
+			ctx:      context.Background(),
+			request:  &protos.SetWarmPoolRequest{BuilderType: "gotip-linux-amd64-boringcrypto", Size: 1},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			desc:     "size too large",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			request:  &protos.SetWarmPoolRequest{BuilderType: "gotip-linux-amd64-boringcrypto", Size: maxWarmPoolSize + 1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "invalid builder type",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			request:  &protos.SetWarmPoolRequest{BuilderType: "funky-time-builder", Size: 1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "missing snapshot",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			request:  &protos.SetWarmPoolRequest{BuilderType: "gotip-linux-amd64-boringcrypto", Snapshot: "missing", Size: 1},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+			got, err := client.SetWarmPool(tc.ctx, tc.request)
+			if err != nil && status.Code(err) != tc.wantCode {
+				t.Fatalf("unexpected error: %s; want %s", err, tc.wantCode)
+			}
+			if err == nil {
+				t.Fatalf("client.SetWarmPool(ctx, %v) = %v, nil; want error", tc.request, got)
+			}
+		})
+	}
+}
+
+// testServerQuotas limits users to one gomote instance, and lets the
+// user of fakeIAP see the usage of the group of foo.
+const testServerQuotas = `{
+	"default": [{"host": "gotip-linux-*", "max": 1}],
+	"groups": [{"name": "team", "members": ["example@gmail.com", "foo@gmail.com"]}]
+}`
+
+func mustParseQuotaConfig(t *testing.T, data string) *QuotaConfig {
+	t.Helper()
+	cfg, err := ParseQuotaConfig([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestSwarmingCreateInstanceQuota(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stdout)
+
+	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple(), QuotaOption(mustParseQuotaConfig(t, testServerQuotas)))
+	gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	req := &protos.CreateInstanceRequest{BuilderType: "gotip-linux-amd64-boringcrypto"}
+	if _, err := createSwarmingInstance(ctx, client, req); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("createSwarmingInstance() over quota = %v; want %s", err, codes.ResourceExhausted)
+	}
+	// Other users have their own quota.
+	otherCtx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAPWithUser("foo", "bar"))
+	if _, err := createSwarmingInstance(otherCtx, client, req); err != nil {
+		t.Fatalf("createSwarmingInstance() for another user = %v; want no error", err)
+	}
+	if _, err := client.DestroyInstance(ctx, &protos.DestroyInstanceRequest{GomoteId: gomoteID}); err != nil {
+		t.Fatalf("client.DestroyInstance(ctx, %s) = %v; want no error", gomoteID, err)
+	}
+	if _, err := createSwarmingInstance(ctx, client, req); err != nil {
+		t.Fatalf("createSwarmingInstance() after destroying an instance = %v; want no error", err)
+	}
+}
+
+func TestSwarmingGetUsage(t *testing.T) {
+	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple(), QuotaOption(mustParseQuotaConfig(t, testServerQuotas)))
+	gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	if _, err := client.DestroyInstance(ctx, &protos.DestroyInstanceRequest{GomoteId: gomoteID}); err != nil {
+		t.Fatalf("client.DestroyInstance(ctx, %s) = %v; want no error", gomoteID, err)
+	}
+	mustCreateSwarmingInstance(t, client, fakeIAPWithUser("foo", "bar"))
+
+	resp, err := client.GetUsage(ctx, &protos.GetUsageRequest{})
+	if err != nil {
+		t.Fatalf("client.GetUsage(ctx, req) = %v; want no error", err)
+	}
+	wantRecords := []*protos.UsageRecord{{User: "example@gmail.com", HostType: "gotip-linux-amd64-boringcrypto", Instances: 1}}
+	wantQuotas := []*protos.QuotaStatus{{Scope: "user", HostPattern: "gotip-linux-*", Limit: 1}}
+	opts := []cmp.Option{protocmp.Transform(), protocmp.IgnoreFields(&protos.UsageRecord{}, "instance_seconds", "idle_seconds")}
+	if diff := cmp.Diff(wantRecords, resp.GetRecords(), opts...); diff != "" {
+		t.Errorf("GetUsage() records mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantQuotas, resp.GetQuotas(), opts...); diff != "" {
+		t.Errorf("GetUsage() quotas mismatch (-want +got):\n%s", diff)
+	}
+
+	resp, err = client.GetUsage(ctx, &protos.GetUsageRequest{Group: "team"})
+	if err != nil {
+		t.Fatalf("client.GetUsage(ctx, team) = %v; want no error", err)
+	}
+	wantRecords = append(wantRecords, &protos.UsageRecord{User: "foo@gmail.com", HostType: "gotip-linux-amd64-boringcrypto", Instances: 1})
+	if diff := cmp.Diff(wantRecords, resp.GetRecords(), opts...); diff != "" {
+		t.Errorf("GetUsage(team) records mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSwarmingGetUsageError(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stdout)
+
+	testCases := []struct {
+		desc     string
+		ctx      context.Context
+		request  *protos.GetUsageRequest
+		wantCode codes.Code
+	}{
+		{
+			desc:     "unauthenticated request",
+			ctx:      context.Background(),
+			request:  &protos.GetUsageRequest{},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			desc:     "negative start time",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			request:  &protos.GetUsageRequest{Since: -1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "unknown group",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			request:  &protos.GetUsageRequest{Group: "nope"},
+			wantCode: codes.NotFound,
+		},
+		{
+			desc:     "not a member",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAPWithUser("user-x", "email-y")),
+			request:  &protos.GetUsageRequest{Group: "team"},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple(), QuotaOption(mustParseQuotaConfig(t, testServerQuotas)))
+			got, err := client.GetUsage(tc.ctx, tc.request)
+			if err != nil && status.Code(err) != tc.wantCode {
+				t.Fatalf("unexpected error: %s; want %s", err, tc.wantCode)
+			}
+			if err == nil {
+				t.Fatalf("client.GetUsage(ctx, %v) = %v, nil; want error", tc.request, got)
+			}
+		})
+	}
+}
+
 func TestSwarmingSignSSHKey(t *testing.T) {
 	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
 	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
@@ -1052,6 +1680,60 @@ func TestSwarmingWriteTGZFromURL(t *testing.T) {
 	}
 }
 
+func TestSwarmingWriteSyncFromURL(t *testing.T) {
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+	gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
+	if _, err := client.WriteSyncFromURL(ctx, &protos.WriteSyncFromURLRequest{
+		GomoteId:  gomoteID,
+		Directory: "foo",
+		Url:       fmt.Sprintf("https://storage.googleapis.com/%s/sync.gz?field=x", testBucketName),
+	}); err != nil {
+		t.Fatalf("client.WriteSyncFromURL(ctx, req) = response, %s; want no error", err)
+	}
+	if _, err := client.WriteSyncFromURL(ctx, &protos.WriteSyncFromURLRequest{
+		GomoteId:  gomoteID,
+		Directory: "foo",
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("client.WriteSyncFromURL(ctx, req) without URL = %v; want %s", err, codes.InvalidArgument)
+	}
+}
+
+func TestSwarmingSyncManifest(t *testing.T) {
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
+	gomoteID := mustCreateSwarmingInstance(t, client, fakeIAP())
+	stream, err := client.SyncManifest(ctx, &protos.SyncManifestRequest{
+		GomoteId:  gomoteID,
+		Directory: "go",
+	})
+	if err != nil {
+		t.Fatalf("client.SyncManifest(ctx, req) = response, %s; want no error", err)
+	}
+	var files []*protos.SyncFile
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("stream.Recv() = _, %s; want no error", err)
+		}
+		files = append(files, res.GetFiles()...)
+	}
+	if len(files) != 1 || files[0].GetPath() != "VERSION" || len(files[0].GetBlocks()) != 1 {
+		t.Errorf("files = %v; want the fake VERSION file", files)
+	}
+
+	stream, err = client.SyncManifest(ctx, &protos.SyncManifestRequest{GomoteId: "chucky"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("SyncManifest of nonexistent instance = %v; want %s", err, codes.NotFound)
+	}
+}
+
 func TestSwarmingWriteTGZFromURLGomoteStaging(t *testing.T) {
 	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
 	client := setupGomoteSwarmingTest(t, context.Background(), mockSwarmClientSimple())
@@ -1298,6 +1980,26 @@ func mustCreateSwarmingInstance(t *testing.T, client protos.GomoteServiceClient,
 	return gomoteID
 }
 
+// createSwarmingInstance creates an instance and returns the last update
+// about it.
+func createSwarmingInstance(ctx context.Context, client protos.GomoteServiceClient, req *protos.CreateInstanceRequest) (*protos.CreateInstanceResponse, error) {
+	stream, err := client.CreateInstance(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var last *protos.CreateInstanceResponse
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			return last, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		last = update
+	}
+}
+
 type FakeBuildersClient struct{}
 
 func (fbc *FakeBuildersClient) GetBuilder(ctx context.Context, in *buildbucketpb.GetBuilderRequest, opts ...grpc.CallOption) (*buildbucketpb.BuilderItem, error) {
@@ -1402,6 +2104,57 @@ func (fbc *fakeBucketHandler) Object(name string) *storage.ObjectHandle {
 	return &storage.ObjectHandle{}
 }
 
+// fakeSnapshotStore is a snapshotStore that keeps snapshots in memory.
+type fakeSnapshotStore struct {
+	mu sync.Mutex
+	m  map[string]*protos.Snapshot // keyed by owner ID and name
+}
+
+func (s *fakeSnapshotStore) Put(ctx context.Context, ownerID string, snap *protos.Snapshot, tgz io.Reader) error {
+	b, err := io.ReadAll(tgz)
+	if err != nil {
+		return err
+	}
+	snap.Size = int64(len(b))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[ownerID+"/"+snap.GetName()] = proto.Clone(snap).(*protos.Snapshot)
+	return nil
+}
+
+func (s *fakeSnapshotStore) Get(ctx context.Context, ownerID, name string) (*protos.Snapshot, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snap, ok := s.m[ownerID+"/"+name]
+	if !ok {
+		return nil, "", errSnapshotNotFound
+	}
+	return proto.Clone(snap).(*protos.Snapshot), snapshotObject(ownerID, name), nil
+}
+
+func (s *fakeSnapshotStore) List(ctx context.Context, ownerID string) ([]*protos.Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var snaps []*protos.Snapshot
+	for k, snap := range s.m {
+		if strings.HasPrefix(k, ownerID+"/") {
+			snaps = append(snaps, proto.Clone(snap).(*protos.Snapshot))
+		}
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].GetName() < snaps[j].GetName() })
+	return snaps, nil
+}
+
+func (s *fakeSnapshotStore) Delete(ctx context.Context, ownerID, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.m[ownerID+"/"+name]; !ok {
+		return errSnapshotNotFound
+	}
+	delete(s.m, ownerID+"/"+name)
+	return nil
+}
+
 func fakeIAP() access.IAPFields {
 	return fakeIAPWithUser("example", "randomuuidstuff")
 }
@@ -0,0 +1,122 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package gomote
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal/coordinator/pool"
+	"golang.org/x/build/internal/coordinator/pool/queue"
+	"golang.org/x/build/internal/coordinator/schedule"
+	"golang.org/x/build/internal/spanlog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LocalBuildletOption makes the gomote server start its instances in the
+// local buildlet pool lb instead of as swarming tasks. The builder types are
+// then those of dashboard.Builders whose host type lb supports.
+func LocalBuildletOption(lb *pool.LocalBuildlet) SwarmingOption {
+	return func(ss *SwarmingServer) {
+		ss.localBuildlets = lb
+	}
+}
+
+// A builderStarter starts instances of a builder type.
+type builderStarter struct {
+	// knownIssue is the issue number of a known issue with the builder, if any.
+	knownIssue int
+	// hostType is the host type that instances of the builder run on. It's
+	// what quotas, idle timeouts and usage reports match against. LUCI
+	// builders have no host type separate from the builder itself, so for
+	// them it's the builder type.
+	hostType string
+	// start starts an instance named name. The task ID is empty for
+	// instances that aren't swarming tasks.
+	start func(ctx context.Context, name string, useGolangbuild bool) (bc buildlet.Client, taskID string, err error)
+}
+
+// builderStarter returns the builderStarter for a builder type. It returns a
+// gRPC status error if the builder type isn't valid.
+func (ss *SwarmingServer) builderStarter(ctx context.Context, builderType string) (*builderStarter, error) {
+	if ss.localBuildlets != nil {
+		return ss.localBuilderStarter(builderType)
+	}
+	bs, err := ss.validBuilders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	builder, ok := bs[builderType]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown builder type")
+	}
+	cp, err := builderProperties(builder)
+	if err != nil {
+		log.Printf("builder configuration not found for %s: %s", builder.GetId().GetBuilder(), err)
+		return nil, status.Errorf(codes.Internal, "invalid builder configuration")
+	}
+	dimensions := builderDimensions(builder)
+	return &builderStarter{
+		knownIssue: cp.KnownIssue,
+		hostType:   builderType,
+		start: func(ctx context.Context, name string, useGolangbuild bool) (buildlet.Client, string, error) {
+			return ss.startNewSwarmingTask(ctx, name, dimensions, cp, &SwarmOpts{}, useGolangbuild)
+		},
+	}, nil
+}
+
+// localBuilderStarter returns the builderStarter for a builder type whose
+// instances run in the local buildlet pool.
+func (ss *SwarmingServer) localBuilderStarter(builderType string) (*builderStarter, error) {
+	bconf, ok := dashboard.Builders[builderType]
+	if !ok || !ss.localBuildlets.Supports(bconf.HostType) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown builder type")
+	}
+	return &builderStarter{
+		hostType: bconf.HostType,
+		start: func(ctx context.Context, name string, _ bool) (buildlet.Client, string, error) {
+			bc, err := ss.localBuildlets.GetBuildlet(ctx, bconf.HostType, localLogger{name}, &queue.SchedItem{
+				HostType:    bconf.HostType,
+				IsGomote:    true,
+				RequestTime: time.Now(),
+			})
+			return bc, "", err
+		},
+	}, nil
+}
+
+// localBuilders returns the sorted builder types whose instances can run in
+// the local buildlet pool.
+func (ss *SwarmingServer) localBuilders() []string {
+	var builders []string
+	for name, bconf := range dashboard.Builders {
+		if ss.localBuildlets.Supports(bconf.HostType) {
+			builders = append(builders, name)
+		}
+	}
+	slices.Sort(builders)
+	return builders
+}
+
+// localLogger logs the events of starting a local instance.
+type localLogger struct {
+	name string // the instance's name
+}
+
+func (l localLogger) LogEventTime(event string, optText ...string) {
+	log.Printf("%s: %s %s", l.name, event, strings.Join(optText, " "))
+}
+
+func (l localLogger) CreateSpan(event string, optText ...string) spanlog.Span {
+	return schedule.CreateSpan(l, event, optText...)
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package gomote
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal/access"
+	"golang.org/x/build/internal/coordinator/pool"
+	"golang.org/x/build/internal/gomote/protos"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// buildBuildlet builds cmd/buildlet and returns the path of the binary.
+func buildBuildlet(t *testing.T) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping test that builds the buildlet in short mode")
+	}
+	bin := filepath.Join(t.TempDir(), "buildlet")
+	goBin := filepath.Join(runtime.GOROOT(), "bin", "go")
+	if out, err := exec.Command(goBin, "build", "-o", bin, "golang.org/x/build/cmd/buildlet").CombinedOutput(); err != nil {
+		t.Fatalf("building buildlet: %v\n%s", err, out)
+	}
+	return bin
+}
+
+// setupGomoteLocalTest starts a gomote server whose instances run in a local
+// buildlet pool of the buildlet binary bin, and returns a client for it.
+func setupGomoteLocalTest(t *testing.T, bin string) protos.GomoteServiceClient {
+	lp, err := pool.NewLocalBuildlet(bin, dashboard.Hosts, pool.LocalOptWorkDir(t.TempDir()))
+	if err != nil {
+		t.Fatalf("pool.NewLocalBuildlet() = _, %s; want no error", err)
+	}
+	t.Cleanup(lp.Close)
+	return setupGomoteSwarmingTest(t, context.Background(), nil, LocalBuildletOption(lp))
+}
+
+func TestLocalCreateInstance(t *testing.T) {
+	client := setupGomoteLocalTest(t, buildBuildlet(t))
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	lres, err := client.ListSwarmingBuilders(ctx, &protos.ListSwarmingBuildersRequest{})
+	if err != nil {
+		t.Fatalf("client.ListSwarmingBuilders = nil, %s; want no error", err)
+	}
+	if len(lres.GetBuilders()) == 0 {
+		t.Skipf("no builders run on %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+	builderType := lres.GetBuilders()[0]
+	cres, err := createSwarmingInstance(ctx, client, &protos.CreateInstanceRequest{BuilderType: builderType})
+	if err != nil {
+		t.Fatalf("CreateInstance(%q) = _, %s; want no error", builderType, err)
+	}
+	inst := cres.GetInstance()
+	if got, want := inst.GetHostType(), dashboard.Builders[builderType].HostType; got != want {
+		t.Errorf("instance host type = %q; want %q", got, want)
+	}
+	ures, err := client.GetUsage(ctx, &protos.GetUsageRequest{})
+	if err != nil {
+		t.Fatalf("client.GetUsage(ctx, req) = %v; want no error", err)
+	}
+	if recs := ures.GetRecords(); len(recs) != 1 || recs[0].GetHostType() != dashboard.Builders[builderType].HostType {
+		t.Errorf("usage records = %v; want one for host type %q", recs, dashboard.Builders[builderType].HostType)
+	}
+
+	stream, err := client.ExecuteCommand(ctx, &protos.ExecuteCommandRequest{
+		GomoteId:    inst.GetGomoteId(),
+		Command:     "/bin/sh",
+		Args:        []string{"-c", "echo hello"},
+		SystemLevel: true,
+	})
+	if err != nil {
+		t.Fatalf("client.ExecuteCommand(ctx, req) = response, %s; want no error", err)
+	}
+	var out []byte
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("stream.Recv() = _, %s; want no error", err)
+		}
+		out = append(out, res.GetOutput()...)
+	}
+	if got, want := string(out), "hello\n"; got != want {
+		t.Errorf("command output = %q; want %q", got, want)
+	}
+
+	if _, err := client.DestroyInstance(ctx, &protos.DestroyInstanceRequest{GomoteId: inst.GetGomoteId()}); err != nil {
+		t.Fatalf("client.DestroyInstance() = _, %s; want no error", err)
+	}
+}
+
+func TestLocalCreateInstanceError(t *testing.T) {
+	// The instances are never started, so any file will do as the buildlet.
+	bin := filepath.Join(t.TempDir(), "buildlet")
+	if err := os.WriteFile(bin, nil, 0755); err != nil {
+		t.Fatal(err)
+	}
+	client := setupGomoteLocalTest(t, bin)
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	// Builders whose host type can't run on this machine are unknown.
+	for _, builderType := range []string{"no-such-builder", "plan9-386"} {
+		_, err := createSwarmingInstance(ctx, client, &protos.CreateInstanceRequest{BuilderType: builderType})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CreateInstance(%q) = _, %v; want %s", builderType, err, codes.InvalidArgument)
+		}
+	}
+}
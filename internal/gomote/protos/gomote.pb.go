@@ -13,6 +13,7 @@ package protos
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -73,6 +74,57 @@ func (CreateInstanceResponse_Status) EnumDescriptor() ([]byte, []int) {
 	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{5, 0}
 }
 
+type WatchInstancesResponse_Event int32
+
+const (
+	WatchInstancesResponse_UNKNOWN WatchInstancesResponse_Event = 0
+	// The instance has been unused for a while and will be destroyed unless it's used.
+	WatchInstancesResponse_IDLE WatchInstancesResponse_Event = 1
+	// The instance was destroyed because it was unused.
+	WatchInstancesResponse_RECLAIMED WatchInstancesResponse_Event = 2
+)
+
+// Enum value maps for WatchInstancesResponse_Event.
+var (
+	WatchInstancesResponse_Event_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "IDLE",
+		2: "RECLAIMED",
+	}
+	WatchInstancesResponse_Event_value = map[string]int32{
+		"UNKNOWN":   0,
+		"IDLE":      1,
+		"RECLAIMED": 2,
+	}
+)
+
+func (x WatchInstancesResponse_Event) Enum() *WatchInstancesResponse_Event {
+	p := new(WatchInstancesResponse_Event)
+	*p = x
+	return p
+}
+
+func (x WatchInstancesResponse_Event) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchInstancesResponse_Event) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_gomote_protos_gomote_proto_enumTypes[1].Descriptor()
+}
+
+func (WatchInstancesResponse_Event) Type() protoreflect.EnumType {
+	return &file_internal_gomote_protos_gomote_proto_enumTypes[1]
+}
+
+func (x WatchInstancesResponse_Event) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchInstancesResponse_Event.Descriptor instead.
+func (WatchInstancesResponse_Event) EnumDescriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{53, 0}
+}
+
 // AuthenticateRequest specifies the data needed for an authentication request.
 type AuthenticateRequest struct {
 	state         protoimpl.MessageState
@@ -260,6 +312,9 @@ type CreateInstanceRequest struct {
 
 	BuilderType      string   `protobuf:"bytes,1,opt,name=builder_type,json=builderType,proto3" json:"builder_type,omitempty"`
 	ExperimentOption []string `protobuf:"bytes,2,rep,name=experiment_option,json=experimentOption,proto3" json:"experiment_option,omitempty"`
+	// The name of a snapshot of the caller's to restore on the instance. If builder_type
+	// is empty, the snapshot's builder type is used.
+	Snapshot string `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
 }
 
 func (x *CreateInstanceRequest) Reset() {
@@ -308,6 +363,13 @@ func (x *CreateInstanceRequest) GetExperimentOption() []string {
 	return nil
 }
 
+func (x *CreateInstanceRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
 // CreateInstanceResponse contains data about a created gomote instance.
 type CreateInstanceResponse struct {
 	state         protoimpl.MessageState
@@ -321,6 +383,8 @@ type CreateInstanceResponse struct {
 	// Waiters ahead is the count of how many instances are being scheduled for
 	// creation before the current instance creation request.
 	WaitersAhead int64 `protobuf:"varint,3,opt,name=waiters_ahead,json=waitersAhead,proto3" json:"waiters_ahead,omitempty"`
+	// Whether the instance was taken from one of the caller's warm pools.
+	FromWarmPool bool `protobuf:"varint,4,opt,name=from_warm_pool,json=fromWarmPool,proto3" json:"from_warm_pool,omitempty"`
 }
 
 func (x *CreateInstanceResponse) Reset() {
@@ -376,6 +440,217 @@ func (x *CreateInstanceResponse) GetWaitersAhead() int64 {
 	return 0
 }
 
+func (x *CreateInstanceResponse) GetFromWarmPool() bool {
+	if x != nil {
+		return x.FromWarmPool
+	}
+	return false
+}
+
+// CreateSnapshotRequest specifies the data needed to snapshot a gomote instance.
+type CreateSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier for a gomote instance.
+	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
+	// The name of the snapshot. A snapshot of the caller's with the same name is replaced.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The directory, relative to the work directory, to save. The whole work directory
+	// is saved if it's empty.
+	Directory string `protobuf:"bytes,3,opt,name=directory,proto3" json:"directory,omitempty"`
+}
+
+func (x *CreateSnapshotRequest) Reset() {
+	*x = CreateSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSnapshotRequest) ProtoMessage() {}
+
+func (x *CreateSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateSnapshotRequest) GetGomoteId() string {
+	if x != nil {
+		return x.GomoteId
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+// CreateSnapshotResponse contains the created snapshot.
+type CreateSnapshotResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshot *Snapshot `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *CreateSnapshotResponse) Reset() {
+	*x = CreateSnapshotResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSnapshotResponse) ProtoMessage() {}
+
+func (x *CreateSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateSnapshotResponse) GetSnapshot() *Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+// DeleteSnapshotRequest specifies the snapshot to delete.
+type DeleteSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the snapshot.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *DeleteSnapshotRequest) Reset() {
+	*x = DeleteSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSnapshotRequest) ProtoMessage() {}
+
+func (x *DeleteSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteSnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// DeleteSnapshotResponse contains the results from deleting a snapshot.
+type DeleteSnapshotResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteSnapshotResponse) Reset() {
+	*x = DeleteSnapshotResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSnapshotResponse) ProtoMessage() {}
+
+func (x *DeleteSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{9}
+}
+
 // DestroyInstanceRequest specifies the data needed to destroy a gomote instance.
 type DestroyInstanceRequest struct {
 	state         protoimpl.MessageState
@@ -389,7 +664,7 @@ type DestroyInstanceRequest struct {
 func (x *DestroyInstanceRequest) Reset() {
 	*x = DestroyInstanceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[6]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -402,7 +677,7 @@ func (x *DestroyInstanceRequest) String() string {
 func (*DestroyInstanceRequest) ProtoMessage() {}
 
 func (x *DestroyInstanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[6]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,7 +690,7 @@ func (x *DestroyInstanceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DestroyInstanceRequest.ProtoReflect.Descriptor instead.
 func (*DestroyInstanceRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{6}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DestroyInstanceRequest) GetGomoteId() string {
@@ -435,7 +710,7 @@ type DestroyInstanceResponse struct {
 func (x *DestroyInstanceResponse) Reset() {
 	*x = DestroyInstanceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[7]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -448,7 +723,7 @@ func (x *DestroyInstanceResponse) String() string {
 func (*DestroyInstanceResponse) ProtoMessage() {}
 
 func (x *DestroyInstanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[7]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -461,7 +736,7 @@ func (x *DestroyInstanceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DestroyInstanceResponse.ProtoReflect.Descriptor instead.
 func (*DestroyInstanceResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{7}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{11}
 }
 
 // ExecuteCommandRequest specifies the data needed to execute a command on a gomote instance.
@@ -494,12 +769,17 @@ type ExecuteCommandRequest struct {
 	Args []string `protobuf:"bytes,8,rep,name=args,proto3" json:"args,omitempty"`
 	// Optional alternate builder to act like. It must be a compatible builder.
 	ImitateHostType string `protobuf:"bytes,9,opt,name=imitate_host_type,json=imitateHostType,proto3" json:"imitate_host_type,omitempty"`
+	// Controls whether the command's standard error is sent separately from its
+	// standard output.
+	SeparateStderr bool `protobuf:"varint,10,opt,name=separate_stderr,json=separateStderr,proto3" json:"separate_stderr,omitempty"`
+	// Optional limits on the resources the command may use.
+	Limits *ExecLimits `protobuf:"bytes,11,opt,name=limits,proto3" json:"limits,omitempty"`
 }
 
 func (x *ExecuteCommandRequest) Reset() {
 	*x = ExecuteCommandRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[8]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -512,7 +792,7 @@ func (x *ExecuteCommandRequest) String() string {
 func (*ExecuteCommandRequest) ProtoMessage() {}
 
 func (x *ExecuteCommandRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[8]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -525,7 +805,7 @@ func (x *ExecuteCommandRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteCommandRequest.ProtoReflect.Descriptor instead.
 func (*ExecuteCommandRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{8}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ExecuteCommandRequest) GetGomoteId() string {
@@ -591,33 +871,58 @@ func (x *ExecuteCommandRequest) GetImitateHostType() string {
 	return ""
 }
 
-// ExecuteCommandResponse contains data about the executed command.
-type ExecuteCommandResponse struct {
+func (x *ExecuteCommandRequest) GetSeparateStderr() bool {
+	if x != nil {
+		return x.SeparateStderr
+	}
+	return false
+}
+
+func (x *ExecuteCommandRequest) GetLimits() *ExecLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+// ExecLimits are limits on the resources a command may use. Zero values mean no limit.
+// The CPU, memory, process and network limits are only supported on Linux.
+type ExecLimits struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The output from the executed command.
-	Output []byte `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
-}
-
-func (x *ExecuteCommandResponse) Reset() {
-	*x = ExecuteCommandResponse{}
+	// The number of CPUs the command may use, such as 1.5.
+	Cpus float64 `protobuf:"fixed64,1,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	// The memory the command may use, in bytes.
+	MemoryBytes int64 `protobuf:"varint,2,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`
+	// How long the command may run.
+	WallTime *durationpb.Duration `protobuf:"bytes,3,opt,name=wall_time,json=wallTime,proto3" json:"wall_time,omitempty"`
+	// How many processes and threads the command may have at once.
+	Processes int32 `protobuf:"varint,4,opt,name=processes,proto3" json:"processes,omitempty"`
+	// How many bytes the files in the work directory may grow by while the command runs.
+	DiskBytes int64 `protobuf:"varint,5,opt,name=disk_bytes,json=diskBytes,proto3" json:"disk_bytes,omitempty"`
+	// Run the command without network access, except to the loopback interface.
+	NoNetwork bool `protobuf:"varint,6,opt,name=no_network,json=noNetwork,proto3" json:"no_network,omitempty"`
+}
+
+func (x *ExecLimits) Reset() {
+	*x = ExecLimits{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[9]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExecuteCommandResponse) String() string {
+func (x *ExecLimits) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExecuteCommandResponse) ProtoMessage() {}
+func (*ExecLimits) ProtoMessage() {}
 
-func (x *ExecuteCommandResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[9]
+func (x *ExecLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,54 +933,1643 @@ func (x *ExecuteCommandResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecuteCommandResponse.ProtoReflect.Descriptor instead.
-func (*ExecuteCommandResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ExecLimits.ProtoReflect.Descriptor instead.
+func (*ExecLimits) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ExecuteCommandResponse) GetOutput() []byte {
+func (x *ExecLimits) GetCpus() float64 {
 	if x != nil {
-		return x.Output
+		return x.Cpus
+	}
+	return 0
+}
+
+func (x *ExecLimits) GetMemoryBytes() int64 {
+	if x != nil {
+		return x.MemoryBytes
+	}
+	return 0
+}
+
+func (x *ExecLimits) GetWallTime() *durationpb.Duration {
+	if x != nil {
+		return x.WallTime
 	}
 	return nil
 }
 
-// Instance contains descriptive information about a gomote instance.
-type Instance struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *ExecLimits) GetProcesses() int32 {
+	if x != nil {
+		return x.Processes
+	}
+	return 0
+}
+
+func (x *ExecLimits) GetDiskBytes() int64 {
+	if x != nil {
+		return x.DiskBytes
+	}
+	return 0
+}
+
+func (x *ExecLimits) GetNoNetwork() bool {
+	if x != nil {
+		return x.NoNetwork
+	}
+	return false
+}
+
+// ExecuteCommandResponse contains data about the executed command.
+type ExecuteCommandResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The output from the executed command. It includes the command's standard
+	// error unless separate_stderr was set.
+	Output []byte `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+	// The standard error of the executed command, if separate_stderr was set.
+	Stderr []byte `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	// How the command ended. It's set in the last message of the stream, if the
+	// command ran to completion, even if it failed.
+	ExitStatus *ExitStatus `protobuf:"bytes,3,opt,name=exit_status,json=exitStatus,proto3" json:"exit_status,omitempty"`
+}
+
+func (x *ExecuteCommandResponse) Reset() {
+	*x = ExecuteCommandResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecuteCommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteCommandResponse) ProtoMessage() {}
+
+func (x *ExecuteCommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteCommandResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteCommandResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExecuteCommandResponse) GetOutput() []byte {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *ExecuteCommandResponse) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *ExecuteCommandResponse) GetExitStatus() *ExitStatus {
+	if x != nil {
+		return x.ExitStatus
+	}
+	return nil
+}
+
+// ExecuteCommandInteractiveRequest is a message from the caller of an interactive command.
+// The first message of the stream starts the command, and must set command and may set tty.
+// Later messages feed the running command and must not set either.
+type ExecuteCommandInteractiveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The command to execute.
+	Command *ExecuteCommandRequest `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	// Controls whether the command is run in a pseudo-terminal, which merges its standard
+	// output and standard error.
+	Tty bool `protobuf:"varint,2,opt,name=tty,proto3" json:"tty,omitempty"`
+	// The TERM environment variable for the pseudo-terminal.
+	Term string `protobuf:"bytes,3,opt,name=term,proto3" json:"term,omitempty"`
+	// The size of the pseudo-terminal. In the first message, it's the initial size.
+	// In later messages, it resizes the pseudo-terminal.
+	WindowSize *WindowSize `protobuf:"bytes,4,opt,name=window_size,json=windowSize,proto3" json:"window_size,omitempty"`
+	// Data to write to the command's standard input.
+	Stdin []byte `protobuf:"bytes,5,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	// Closes the command's standard input, after writing any stdin in the same message.
+	CloseStdin bool `protobuf:"varint,6,opt,name=close_stdin,json=closeStdin,proto3" json:"close_stdin,omitempty"`
+	// The name of a signal to send to the command, without the "SIG" prefix, such as "INT".
+	Signal string `protobuf:"bytes,7,opt,name=signal,proto3" json:"signal,omitempty"`
+}
+
+func (x *ExecuteCommandInteractiveRequest) Reset() {
+	*x = ExecuteCommandInteractiveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecuteCommandInteractiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteCommandInteractiveRequest) ProtoMessage() {}
+
+func (x *ExecuteCommandInteractiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteCommandInteractiveRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteCommandInteractiveRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExecuteCommandInteractiveRequest) GetCommand() *ExecuteCommandRequest {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *ExecuteCommandInteractiveRequest) GetTty() bool {
+	if x != nil {
+		return x.Tty
+	}
+	return false
+}
+
+func (x *ExecuteCommandInteractiveRequest) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *ExecuteCommandInteractiveRequest) GetWindowSize() *WindowSize {
+	if x != nil {
+		return x.WindowSize
+	}
+	return nil
+}
+
+func (x *ExecuteCommandInteractiveRequest) GetStdin() []byte {
+	if x != nil {
+		return x.Stdin
+	}
+	return nil
+}
+
+func (x *ExecuteCommandInteractiveRequest) GetCloseStdin() bool {
+	if x != nil {
+		return x.CloseStdin
+	}
+	return false
+}
+
+func (x *ExecuteCommandInteractiveRequest) GetSignal() string {
+	if x != nil {
+		return x.Signal
+	}
+	return ""
+}
+
+// WindowSize is the size of a pseudo-terminal.
+type WindowSize struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows uint32 `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Cols uint32 `protobuf:"varint,2,opt,name=cols,proto3" json:"cols,omitempty"`
+}
+
+func (x *WindowSize) Reset() {
+	*x = WindowSize{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WindowSize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowSize) ProtoMessage() {}
+
+func (x *WindowSize) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowSize.ProtoReflect.Descriptor instead.
+func (*WindowSize) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WindowSize) GetRows() uint32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *WindowSize) GetCols() uint32 {
+	if x != nil {
+		return x.Cols
+	}
+	return 0
+}
+
+// ExecuteCommandInteractiveResponse contains output from an interactive command,
+// or how it ended.
+type ExecuteCommandInteractiveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Output from the command's standard output, or its pseudo-terminal.
+	Stdout []byte `protobuf:"bytes,1,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	// Output from the command's standard error.
+	Stderr []byte `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	// How the command ended. It's set in the last message of the stream.
+	ExitStatus *ExitStatus `protobuf:"bytes,3,opt,name=exit_status,json=exitStatus,proto3" json:"exit_status,omitempty"`
+}
+
+func (x *ExecuteCommandInteractiveResponse) Reset() {
+	*x = ExecuteCommandInteractiveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecuteCommandInteractiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteCommandInteractiveResponse) ProtoMessage() {}
+
+func (x *ExecuteCommandInteractiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteCommandInteractiveResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteCommandInteractiveResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ExecuteCommandInteractiveResponse) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *ExecuteCommandInteractiveResponse) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *ExecuteCommandInteractiveResponse) GetExitStatus() *ExitStatus {
+	if x != nil {
+		return x.ExitStatus
+	}
+	return nil
+}
+
+// ExitStatus describes how a command ended.
+type ExitStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The command's exit code, or -1 if it was killed by a signal.
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// The signal that killed the command, if any.
+	Signal string `protobuf:"bytes,2,opt,name=signal,proto3" json:"signal,omitempty"`
+	// The state of the command: "ok" if it succeeded, or a description of how it failed.
+	State string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// How long the command ran.
+	WallTime *durationpb.Duration `protobuf:"bytes,4,opt,name=wall_time,json=wallTime,proto3" json:"wall_time,omitempty"`
+	// The user and system CPU time used by the command.
+	UserTime   *durationpb.Duration `protobuf:"bytes,5,opt,name=user_time,json=userTime,proto3" json:"user_time,omitempty"`
+	SystemTime *durationpb.Duration `protobuf:"bytes,6,opt,name=system_time,json=systemTime,proto3" json:"system_time,omitempty"`
+	// The command's peak resident set size in bytes, or zero if unknown.
+	MaxRss int64 `protobuf:"varint,7,opt,name=max_rss,json=maxRss,proto3" json:"max_rss,omitempty"`
+	// The limit the command exceeded, such as "memory", if any.
+	LimitExceeded string `protobuf:"bytes,8,opt,name=limit_exceeded,json=limitExceeded,proto3" json:"limit_exceeded,omitempty"`
+}
+
+func (x *ExitStatus) Reset() {
+	*x = ExitStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExitStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExitStatus) ProtoMessage() {}
+
+func (x *ExitStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExitStatus.ProtoReflect.Descriptor instead.
+func (*ExitStatus) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExitStatus) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ExitStatus) GetSignal() string {
+	if x != nil {
+		return x.Signal
+	}
+	return ""
+}
+
+func (x *ExitStatus) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ExitStatus) GetWallTime() *durationpb.Duration {
+	if x != nil {
+		return x.WallTime
+	}
+	return nil
+}
+
+func (x *ExitStatus) GetUserTime() *durationpb.Duration {
+	if x != nil {
+		return x.UserTime
+	}
+	return nil
+}
+
+func (x *ExitStatus) GetSystemTime() *durationpb.Duration {
+	if x != nil {
+		return x.SystemTime
+	}
+	return nil
+}
+
+func (x *ExitStatus) GetMaxRss() int64 {
+	if x != nil {
+		return x.MaxRss
+	}
+	return 0
+}
+
+func (x *ExitStatus) GetLimitExceeded() string {
+	if x != nil {
+		return x.LimitExceeded
+	}
+	return ""
+}
+
+// ForwardPortRequest is a message from the caller in a ForwardPort stream. The first message sets up the forwarding
+// and the rest carry the caller's side of the forwarded connections.
+type ForwardPortRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier for a gomote instance. Set in the first message only.
+	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
+	// The port on the gomote instance's loopback interface. Set in the first message only.
+	// If reverse is set, it may be 0 to listen on any free port.
+	RemotePort int32 `protobuf:"varint,2,opt,name=remote_port,json=remotePort,proto3" json:"remote_port,omitempty"`
+	// Whether the gomote instance listens on remote_port and forwards the connections it accepts to the caller,
+	// rather than the caller forwarding connections to remote_port. Set in the first message only.
+	Reverse bool `protobuf:"varint,3,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	// The identifier of the connection the rest of the message is about, chosen by the side that opened it.
+	ConnId int64 `protobuf:"varint,4,opt,name=conn_id,json=connId,proto3" json:"conn_id,omitempty"`
+	// The caller opened a new connection, to be connected to remote_port. Not allowed in reverse mode.
+	Open bool `protobuf:"varint,5,opt,name=open,proto3" json:"open,omitempty"`
+	// Data the caller's end of the connection received.
+	Data []byte `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	// The caller's end of the connection reached EOF, or failed if error is set.
+	Close bool   `protobuf:"varint,7,opt,name=close,proto3" json:"close,omitempty"`
+	Error string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *ForwardPortRequest) Reset() {
+	*x = ForwardPortRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForwardPortRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardPortRequest) ProtoMessage() {}
+
+func (x *ForwardPortRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardPortRequest.ProtoReflect.Descriptor instead.
+func (*ForwardPortRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ForwardPortRequest) GetGomoteId() string {
+	if x != nil {
+		return x.GomoteId
+	}
+	return ""
+}
+
+func (x *ForwardPortRequest) GetRemotePort() int32 {
+	if x != nil {
+		return x.RemotePort
+	}
+	return 0
+}
+
+func (x *ForwardPortRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+func (x *ForwardPortRequest) GetConnId() int64 {
+	if x != nil {
+		return x.ConnId
+	}
+	return 0
+}
+
+func (x *ForwardPortRequest) GetOpen() bool {
+	if x != nil {
+		return x.Open
+	}
+	return false
+}
+
+func (x *ForwardPortRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ForwardPortRequest) GetClose() bool {
+	if x != nil {
+		return x.Close
+	}
+	return false
+}
+
+func (x *ForwardPortRequest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ForwardPortResponse is a message from the gomote instance's side of a ForwardPort stream.
+type ForwardPortResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The address the gomote instance listens on. Sent in the first message in reverse mode.
+	ListenAddress string `protobuf:"bytes,1,opt,name=listen_address,json=listenAddress,proto3" json:"listen_address,omitempty"`
+	// The identifier of the connection the rest of the message is about.
+	ConnId int64 `protobuf:"varint,2,opt,name=conn_id,json=connId,proto3" json:"conn_id,omitempty"`
+	// The gomote instance accepted a new connection on the port it listens on, in reverse mode.
+	Open bool `protobuf:"varint,3,opt,name=open,proto3" json:"open,omitempty"`
+	// Data the gomote instance's end of the connection received.
+	Data []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// The gomote instance's end of the connection reached EOF, or failed if error is set.
+	Close bool   `protobuf:"varint,5,opt,name=close,proto3" json:"close,omitempty"`
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *ForwardPortResponse) Reset() {
+	*x = ForwardPortResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForwardPortResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardPortResponse) ProtoMessage() {}
+
+func (x *ForwardPortResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardPortResponse.ProtoReflect.Descriptor instead.
+func (*ForwardPortResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ForwardPortResponse) GetListenAddress() string {
+	if x != nil {
+		return x.ListenAddress
+	}
+	return ""
+}
+
+func (x *ForwardPortResponse) GetConnId() int64 {
+	if x != nil {
+		return x.ConnId
+	}
+	return 0
+}
+
+func (x *ForwardPortResponse) GetOpen() bool {
+	if x != nil {
+		return x.Open
+	}
+	return false
+}
+
+func (x *ForwardPortResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ForwardPortResponse) GetClose() bool {
+	if x != nil {
+		return x.Close
+	}
+	return false
+}
+
+func (x *ForwardPortResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// GetUsageRequest specifies the usage to report.
+type GetUsageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The start of the period to report, in Unix epoch time format. Usage since the server started is
+	// reported if it's zero.
+	Since int64 `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	// The name of a quota group the caller is a member of, to report the usage of all its members.
+	Group string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetUsageRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *GetUsageRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+// GetUsageResponse contains the usage of gomote instances and the quotas that apply.
+type GetUsageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The usage, by user and host type.
+	Records []*UsageRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// The quotas that apply to the caller, or to the group.
+	Quotas []*QuotaStatus `protobuf:"bytes,2,rep,name=quotas,proto3" json:"quotas,omitempty"`
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetUsageResponse) GetRecords() []*UsageRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *GetUsageResponse) GetQuotas() []*QuotaStatus {
+	if x != nil {
+		return x.Quotas
+	}
+	return nil
+}
+
+// UsageRecord is the usage of instances of a host type by a user.
+type UsageRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The email address of the user.
+	User     string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	HostType string `protobuf:"bytes,2,opt,name=host_type,json=hostType,proto3" json:"host_type,omitempty"`
+	// The number of instances created.
+	Instances int64 `protobuf:"varint,3,opt,name=instances,proto3" json:"instances,omitempty"`
+	// The time the instances were alive, in seconds.
+	InstanceSeconds int64 `protobuf:"varint,4,opt,name=instance_seconds,json=instanceSeconds,proto3" json:"instance_seconds,omitempty"`
+	// The time the instances were alive but unused, in seconds.
+	IdleSeconds int64 `protobuf:"varint,5,opt,name=idle_seconds,json=idleSeconds,proto3" json:"idle_seconds,omitempty"`
+	// The number of instances destroyed because they were unused.
+	Reclaimed int64 `protobuf:"varint,6,opt,name=reclaimed,proto3" json:"reclaimed,omitempty"`
+}
+
+func (x *UsageRecord) Reset() {
+	*x = UsageRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UsageRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageRecord) ProtoMessage() {}
+
+func (x *UsageRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageRecord.ProtoReflect.Descriptor instead.
+func (*UsageRecord) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UsageRecord) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *UsageRecord) GetHostType() string {
+	if x != nil {
+		return x.HostType
+	}
+	return ""
+}
+
+func (x *UsageRecord) GetInstances() int64 {
+	if x != nil {
+		return x.Instances
+	}
+	return 0
+}
+
+func (x *UsageRecord) GetInstanceSeconds() int64 {
+	if x != nil {
+		return x.InstanceSeconds
+	}
+	return 0
+}
+
+func (x *UsageRecord) GetIdleSeconds() int64 {
+	if x != nil {
+		return x.IdleSeconds
+	}
+	return 0
+}
+
+func (x *UsageRecord) GetReclaimed() int64 {
+	if x != nil {
+		return x.Reclaimed
+	}
+	return 0
+}
+
+// QuotaStatus describes a limit on the number of concurrent instances and how much of it is used.
+type QuotaStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// What the limit applies to: "user" for the caller's own limit, or the name of a quota group.
+	Scope string `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	// The pattern, as for path.Match, of the host types the limit applies to.
+	HostPattern string `protobuf:"bytes,2,opt,name=host_pattern,json=hostPattern,proto3" json:"host_pattern,omitempty"`
+	// The largest number of concurrent instances.
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// The number of instances counting against the limit.
+	InUse int32 `protobuf:"varint,4,opt,name=in_use,json=inUse,proto3" json:"in_use,omitempty"`
+}
+
+func (x *QuotaStatus) Reset() {
+	*x = QuotaStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuotaStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotaStatus) ProtoMessage() {}
+
+func (x *QuotaStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotaStatus.ProtoReflect.Descriptor instead.
+func (*QuotaStatus) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *QuotaStatus) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *QuotaStatus) GetHostPattern() string {
+	if x != nil {
+		return x.HostPattern
+	}
+	return ""
+}
+
+func (x *QuotaStatus) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *QuotaStatus) GetInUse() int32 {
+	if x != nil {
+		return x.InUse
+	}
+	return 0
+}
+
+// Instance contains descriptive information about a gomote instance.
+type Instance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier for a gomote instance.
+	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
+	// Builder type for the gomote instance.
+	BuilderType string `protobuf:"bytes,2,opt,name=builder_type,json=builderType,proto3" json:"builder_type,omitempty"`
+	// Host type for the gomote instance.
+	HostType string `protobuf:"bytes,3,opt,name=host_type,json=hostType,proto3" json:"host_type,omitempty"`
+	// The timestamp for when the builder instance will expire. It is
+	// represented in Unix epoch time format.
+	Expires int64 `protobuf:"varint,4,opt,name=expires,proto3" json:"expires,omitempty"`
+	// The working directory of the instance.
+	WorkingDir string `protobuf:"bytes,5,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+}
+
+func (x *Instance) Reset() {
+	*x = Instance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Instance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Instance) ProtoMessage() {}
+
+func (x *Instance) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Instance.ProtoReflect.Descriptor instead.
+func (*Instance) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Instance) GetGomoteId() string {
+	if x != nil {
+		return x.GomoteId
+	}
+	return ""
+}
+
+func (x *Instance) GetBuilderType() string {
+	if x != nil {
+		return x.BuilderType
+	}
+	return ""
+}
+
+func (x *Instance) GetHostType() string {
+	if x != nil {
+		return x.HostType
+	}
+	return ""
+}
+
+func (x *Instance) GetExpires() int64 {
+	if x != nil {
+		return x.Expires
+	}
+	return 0
+}
+
+func (x *Instance) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+// InstanceAliveRequest specifies the data needed to check the liveness of a gomote instance.
+type InstanceAliveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier for a gomote instance.
+	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
+}
+
+func (x *InstanceAliveRequest) Reset() {
+	*x = InstanceAliveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstanceAliveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceAliveRequest) ProtoMessage() {}
+
+func (x *InstanceAliveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceAliveRequest.ProtoReflect.Descriptor instead.
+func (*InstanceAliveRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *InstanceAliveRequest) GetGomoteId() string {
+	if x != nil {
+		return x.GomoteId
+	}
+	return ""
+}
+
+// InstanceAliveResponse contains instance liveness state.
+type InstanceAliveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *InstanceAliveResponse) Reset() {
+	*x = InstanceAliveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstanceAliveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceAliveResponse) ProtoMessage() {}
+
+func (x *InstanceAliveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceAliveResponse.ProtoReflect.Descriptor instead.
+func (*InstanceAliveResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{27}
+}
+
+// ListDirectoryRequest specifies the data needed to list contents of a directory from a gomote instance.
+type ListDirectoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier for a gomote instance.
+	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
+	// The directory to list the contents of. The directory dir itself is not included.
+	Directory string `protobuf:"bytes,2,opt,name=directory,proto3" json:"directory,omitempty"`
+	// Controls whether the directory is listed recursively.
+	Recursive bool `protobuf:"varint,3,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	// The directories to skip, relative to the main directory.
+	// Each item should contain only forward slashes and not start or end in slashes.
+	SkipFiles []string `protobuf:"bytes,4,rep,name=skip_files,json=skipFiles,proto3" json:"skip_files,omitempty"`
+	// Controls whether the SHA-1 of regular files are returned.
+	Digest bool `protobuf:"varint,5,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *ListDirectoryRequest) Reset() {
+	*x = ListDirectoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDirectoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDirectoryRequest) ProtoMessage() {}
+
+func (x *ListDirectoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDirectoryRequest.ProtoReflect.Descriptor instead.
+func (*ListDirectoryRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListDirectoryRequest) GetGomoteId() string {
+	if x != nil {
+		return x.GomoteId
+	}
+	return ""
+}
+
+func (x *ListDirectoryRequest) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+func (x *ListDirectoryRequest) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+func (x *ListDirectoryRequest) GetSkipFiles() []string {
+	if x != nil {
+		return x.SkipFiles
+	}
+	return nil
+}
+
+func (x *ListDirectoryRequest) GetDigest() bool {
+	if x != nil {
+		return x.Digest
+	}
+	return false
+}
+
+// ListDirectoryResponse contains the directory listing of a gomote instance.
+type ListDirectoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The directory entries.
+	Entries []string `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *ListDirectoryResponse) Reset() {
+	*x = ListDirectoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDirectoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDirectoryResponse) ProtoMessage() {}
+
+func (x *ListDirectoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDirectoryResponse.ProtoReflect.Descriptor instead.
+func (*ListDirectoryResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListDirectoryResponse) GetEntries() []string {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// ListInstancesRequest specifies the data needed to list the live gomote instances owned by the caller.
+type ListInstancesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListInstancesRequest) Reset() {
+	*x = ListInstancesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInstancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstancesRequest) ProtoMessage() {}
+
+func (x *ListInstancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstancesRequest.ProtoReflect.Descriptor instead.
+func (*ListInstancesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{30}
+}
+
+// ListInstancesResponse contains the list of live gomote instances owned by the caller.
+type ListInstancesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Instances []*Instance `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
+}
+
+func (x *ListInstancesResponse) Reset() {
+	*x = ListInstancesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInstancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstancesResponse) ProtoMessage() {}
+
+func (x *ListInstancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstancesResponse.ProtoReflect.Descriptor instead.
+func (*ListInstancesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListInstancesResponse) GetInstances() []*Instance {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+// ListSnapshotsRequest specifies the data needed to list snapshots.
+type ListSnapshotsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSnapshotsRequest) Reset() {
+	*x = ListSnapshotsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsRequest) ProtoMessage() {}
+
+func (x *ListSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{32}
+}
+
+// ListSnapshotsResponse contains the caller's snapshots.
+type ListSnapshotsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshots []*Snapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+}
+
+func (x *ListSnapshotsResponse) Reset() {
+	*x = ListSnapshotsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsResponse) ProtoMessage() {}
+
+func (x *ListSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListSnapshotsResponse) GetSnapshots() []*Snapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+// ListSwarmingBuildersRequest specifies the data needed to list all swarming builders.
+type ListSwarmingBuildersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSwarmingBuildersRequest) Reset() {
+	*x = ListSwarmingBuildersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSwarmingBuildersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSwarmingBuildersRequest) ProtoMessage() {}
+
+func (x *ListSwarmingBuildersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSwarmingBuildersRequest.ProtoReflect.Descriptor instead.
+func (*ListSwarmingBuildersRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{34}
+}
+
+// ListSwarmingBuildersResponse contains a list of swarming builders.
+type ListSwarmingBuildersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Builders []string `protobuf:"bytes,1,rep,name=builders,proto3" json:"builders,omitempty"`
+}
+
+func (x *ListSwarmingBuildersResponse) Reset() {
+	*x = ListSwarmingBuildersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSwarmingBuildersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSwarmingBuildersResponse) ProtoMessage() {}
+
+func (x *ListSwarmingBuildersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSwarmingBuildersResponse.ProtoReflect.Descriptor instead.
+func (*ListSwarmingBuildersResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListSwarmingBuildersResponse) GetBuilders() []string {
+	if x != nil {
+		return x.Builders
+	}
+	return nil
+}
+
+// ListWarmPoolsRequest specifies the data needed to list warm pools.
+type ListWarmPoolsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListWarmPoolsRequest) Reset() {
+	*x = ListWarmPoolsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListWarmPoolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarmPoolsRequest) ProtoMessage() {}
+
+func (x *ListWarmPoolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarmPoolsRequest.ProtoReflect.Descriptor instead.
+func (*ListWarmPoolsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{36}
+}
+
+// ListWarmPoolsResponse contains the caller's warm pools.
+type ListWarmPoolsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WarmPools []*WarmPool `protobuf:"bytes,1,rep,name=warm_pools,json=warmPools,proto3" json:"warm_pools,omitempty"`
+}
+
+func (x *ListWarmPoolsResponse) Reset() {
+	*x = ListWarmPoolsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListWarmPoolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarmPoolsResponse) ProtoMessage() {}
+
+func (x *ListWarmPoolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarmPoolsResponse.ProtoReflect.Descriptor instead.
+func (*ListWarmPoolsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListWarmPoolsResponse) GetWarmPools() []*WarmPool {
+	if x != nil {
+		return x.WarmPools
+	}
+	return nil
+}
+
+// ReadTGZToURLRequest specifies the data needed to retrieve a tar and zipped directory from a gomote instance.
+type ReadTGZToURLRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
 	// The unique identifier for a gomote instance.
 	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
-	// Builder type for the gomote instance.
-	BuilderType string `protobuf:"bytes,2,opt,name=builder_type,json=builderType,proto3" json:"builder_type,omitempty"`
-	// Host type for the gomote instance.
-	HostType string `protobuf:"bytes,3,opt,name=host_type,json=hostType,proto3" json:"host_type,omitempty"`
-	// The timestamp for when the builder instance will expire. It is
-	// represented in Unix epoch time format.
-	Expires int64 `protobuf:"varint,4,opt,name=expires,proto3" json:"expires,omitempty"`
-	// The working directory of the instance.
-	WorkingDir string `protobuf:"bytes,5,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	// The relative directory from the gomote's work directory to tar up.
+	Directory string `protobuf:"bytes,2,opt,name=directory,proto3" json:"directory,omitempty"`
 }
 
-func (x *Instance) Reset() {
-	*x = Instance{}
+func (x *ReadTGZToURLRequest) Reset() {
+	*x = ReadTGZToURLRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[10]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Instance) String() string {
+func (x *ReadTGZToURLRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Instance) ProtoMessage() {}
+func (*ReadTGZToURLRequest) ProtoMessage() {}
 
-func (x *Instance) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[10]
+func (x *ReadTGZToURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -686,73 +2580,105 @@ func (x *Instance) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Instance.ProtoReflect.Descriptor instead.
-func (*Instance) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ReadTGZToURLRequest.ProtoReflect.Descriptor instead.
+func (*ReadTGZToURLRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *Instance) GetGomoteId() string {
+func (x *ReadTGZToURLRequest) GetGomoteId() string {
 	if x != nil {
 		return x.GomoteId
 	}
 	return ""
 }
 
-func (x *Instance) GetBuilderType() string {
+func (x *ReadTGZToURLRequest) GetDirectory() string {
 	if x != nil {
-		return x.BuilderType
+		return x.Directory
 	}
 	return ""
 }
 
-func (x *Instance) GetHostType() string {
-	if x != nil {
-		return x.HostType
+// ReadTGZToURLResponse contains a URL where the tar and zipped directory from a gomote instance can be downloaded from.
+type ReadTGZToURLResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// URL to retrieve the tarball from.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *ReadTGZToURLResponse) Reset() {
+	*x = ReadTGZToURLResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *Instance) GetExpires() int64 {
-	if x != nil {
-		return x.Expires
+func (x *ReadTGZToURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadTGZToURLResponse) ProtoMessage() {}
+
+func (x *ReadTGZToURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Instance) GetWorkingDir() string {
+// Deprecated: Use ReadTGZToURLResponse.ProtoReflect.Descriptor instead.
+func (*ReadTGZToURLResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ReadTGZToURLResponse) GetUrl() string {
 	if x != nil {
-		return x.WorkingDir
+		return x.Url
 	}
 	return ""
 }
 
-// InstanceAliveRequest specifies the data needed to check the liveness of a gomote instance.
-type InstanceAliveRequest struct {
+// RemoveFilesRequest specifies the data needed to remove files or directories from a gomote instance.
+type RemoveFilesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// The unique identifier for a gomote instance.
 	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
+	// The list of paths for files or directories to remove from the file system.
+	// When everything should be deleted, "." should be used.
+	// The paths are relative to the work directory.
+	Paths []string `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
 }
 
-func (x *InstanceAliveRequest) Reset() {
-	*x = InstanceAliveRequest{}
+func (x *RemoveFilesRequest) Reset() {
+	*x = RemoveFilesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[11]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InstanceAliveRequest) String() string {
+func (x *RemoveFilesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InstanceAliveRequest) ProtoMessage() {}
+func (*RemoveFilesRequest) ProtoMessage() {}
 
-func (x *InstanceAliveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[11]
+func (x *RemoveFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -763,42 +2689,49 @@ func (x *InstanceAliveRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InstanceAliveRequest.ProtoReflect.Descriptor instead.
-func (*InstanceAliveRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use RemoveFilesRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFilesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *InstanceAliveRequest) GetGomoteId() string {
+func (x *RemoveFilesRequest) GetGomoteId() string {
 	if x != nil {
 		return x.GomoteId
 	}
 	return ""
 }
 
-// InstanceAliveResponse contains instance liveness state.
-type InstanceAliveResponse struct {
+func (x *RemoveFilesRequest) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+// RemoveFilesResponse contains the results from removing files or directories from a gomote instance.
+type RemoveFilesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *InstanceAliveResponse) Reset() {
-	*x = InstanceAliveResponse{}
+func (x *RemoveFilesResponse) Reset() {
+	*x = RemoveFilesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[12]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InstanceAliveResponse) String() string {
+func (x *RemoveFilesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InstanceAliveResponse) ProtoMessage() {}
+func (*RemoveFilesResponse) ProtoMessage() {}
 
-func (x *InstanceAliveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[12]
+func (x *RemoveFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -809,47 +2742,42 @@ func (x *InstanceAliveResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InstanceAliveResponse.ProtoReflect.Descriptor instead.
-func (*InstanceAliveResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use RemoveFilesResponse.ProtoReflect.Descriptor instead.
+func (*RemoveFilesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{41}
 }
 
-// ListDirectoryRequest specifies the data needed to list contents of a directory from a gomote instance.
-type ListDirectoryRequest struct {
+// SetWarmPoolRequest specifies a warm pool of instances to keep ready for the caller.
+type SetWarmPoolRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The unique identifier for a gomote instance.
-	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
-	// The directory to list the contents of. The directory dir itself is not included.
-	Directory string `protobuf:"bytes,2,opt,name=directory,proto3" json:"directory,omitempty"`
-	// Controls whether the directory is listed recursively.
-	Recursive bool `protobuf:"varint,3,opt,name=recursive,proto3" json:"recursive,omitempty"`
-	// The directories to skip, relative to the main directory.
-	// Each item should contain only forward slashes and not start or end in slashes.
-	SkipFiles []string `protobuf:"bytes,4,rep,name=skip_files,json=skipFiles,proto3" json:"skip_files,omitempty"`
-	// Controls whether the SHA-1 of regular files are returned.
-	Digest bool `protobuf:"varint,5,opt,name=digest,proto3" json:"digest,omitempty"`
+	// The builder type of the instances.
+	BuilderType string `protobuf:"bytes,1,opt,name=builder_type,json=builderType,proto3" json:"builder_type,omitempty"`
+	// The name of a snapshot of the caller's to restore on the instances, if any.
+	Snapshot string `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// The number of instances to keep ready. Zero removes the warm pool.
+	Size int32 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func (x *ListDirectoryRequest) Reset() {
-	*x = ListDirectoryRequest{}
+func (x *SetWarmPoolRequest) Reset() {
+	*x = SetWarmPoolRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[13]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListDirectoryRequest) String() string {
+func (x *SetWarmPoolRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDirectoryRequest) ProtoMessage() {}
+func (*SetWarmPoolRequest) ProtoMessage() {}
 
-func (x *ListDirectoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[13]
+func (x *SetWarmPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -860,73 +2788,58 @@ func (x *ListDirectoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDirectoryRequest.ProtoReflect.Descriptor instead.
-func (*ListDirectoryRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use SetWarmPoolRequest.ProtoReflect.Descriptor instead.
+func (*SetWarmPoolRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *ListDirectoryRequest) GetGomoteId() string {
+func (x *SetWarmPoolRequest) GetBuilderType() string {
 	if x != nil {
-		return x.GomoteId
+		return x.BuilderType
 	}
 	return ""
 }
 
-func (x *ListDirectoryRequest) GetDirectory() string {
+func (x *SetWarmPoolRequest) GetSnapshot() string {
 	if x != nil {
-		return x.Directory
+		return x.Snapshot
 	}
 	return ""
 }
 
-func (x *ListDirectoryRequest) GetRecursive() bool {
-	if x != nil {
-		return x.Recursive
-	}
-	return false
-}
-
-func (x *ListDirectoryRequest) GetSkipFiles() []string {
-	if x != nil {
-		return x.SkipFiles
-	}
-	return nil
-}
-
-func (x *ListDirectoryRequest) GetDigest() bool {
+func (x *SetWarmPoolRequest) GetSize() int32 {
 	if x != nil {
-		return x.Digest
+		return x.Size
 	}
-	return false
+	return 0
 }
 
-// ListDirectoryResponse contains the directory listing of a gomote instance.
-type ListDirectoryResponse struct {
+// SetWarmPoolResponse contains the warm pool that was set.
+type SetWarmPoolResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The directory entries.
-	Entries []string `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	WarmPool *WarmPool `protobuf:"bytes,1,opt,name=warm_pool,json=warmPool,proto3" json:"warm_pool,omitempty"`
 }
 
-func (x *ListDirectoryResponse) Reset() {
-	*x = ListDirectoryResponse{}
+func (x *SetWarmPoolResponse) Reset() {
+	*x = SetWarmPoolResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[14]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListDirectoryResponse) String() string {
+func (x *SetWarmPoolResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDirectoryResponse) ProtoMessage() {}
+func (*SetWarmPoolResponse) ProtoMessage() {}
 
-func (x *ListDirectoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[14]
+func (x *SetWarmPoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -937,42 +2850,47 @@ func (x *ListDirectoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDirectoryResponse.ProtoReflect.Descriptor instead.
-func (*ListDirectoryResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use SetWarmPoolResponse.ProtoReflect.Descriptor instead.
+func (*SetWarmPoolResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *ListDirectoryResponse) GetEntries() []string {
+func (x *SetWarmPoolResponse) GetWarmPool() *WarmPool {
 	if x != nil {
-		return x.Entries
+		return x.WarmPool
 	}
 	return nil
 }
 
-// ListInstancesRequest specifies the data needed to list the live gomote instances owned by the caller.
-type ListInstancesRequest struct {
+// SignSSHKeyRequest specifies the data needed to sign a public SSH key which attaches a certificate to the key.
+type SignSSHKeyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier for a gomote instance.
+	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
+	// A user provided public SSH key which the user intends to initiate an SSH session with.
+	PublicSshKey []byte `protobuf:"bytes,2,opt,name=public_ssh_key,json=publicSshKey,proto3" json:"public_ssh_key,omitempty"`
 }
 
-func (x *ListInstancesRequest) Reset() {
-	*x = ListInstancesRequest{}
+func (x *SignSSHKeyRequest) Reset() {
+	*x = SignSSHKeyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[15]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListInstancesRequest) String() string {
+func (x *SignSSHKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListInstancesRequest) ProtoMessage() {}
+func (*SignSSHKeyRequest) ProtoMessage() {}
 
-func (x *ListInstancesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[15]
+func (x *SignSSHKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -983,37 +2901,53 @@ func (x *ListInstancesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListInstancesRequest.ProtoReflect.Descriptor instead.
-func (*ListInstancesRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use SignSSHKeyRequest.ProtoReflect.Descriptor instead.
+func (*SignSSHKeyRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{44}
 }
 
-// ListInstancesResponse contains the list of live gomote instances owned by the caller.
-type ListInstancesResponse struct {
+func (x *SignSSHKeyRequest) GetGomoteId() string {
+	if x != nil {
+		return x.GomoteId
+	}
+	return ""
+}
+
+func (x *SignSSHKeyRequest) GetPublicSshKey() []byte {
+	if x != nil {
+		return x.PublicSshKey
+	}
+	return nil
+}
+
+// SignSSHKeyResponse contains the results from a request to sign a public SSH key.
+type SignSSHKeyResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Instances []*Instance `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
+	// A signed SSH key can be used in conjunction with the associated private key to initiate an SSH session to a gomote instance.
+	// The certificate attached to the key will contain principles which restrict the instance that can be logged into.
+	SignedPublicSshKey []byte `protobuf:"bytes,1,opt,name=signed_public_ssh_key,json=signedPublicSshKey,proto3" json:"signed_public_ssh_key,omitempty"`
 }
 
-func (x *ListInstancesResponse) Reset() {
-	*x = ListInstancesResponse{}
+func (x *SignSSHKeyResponse) Reset() {
+	*x = SignSSHKeyResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[16]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListInstancesResponse) String() string {
+func (x *SignSSHKeyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListInstancesResponse) ProtoMessage() {}
+func (*SignSSHKeyResponse) ProtoMessage() {}
 
-func (x *ListInstancesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[16]
+func (x *SignSSHKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1024,42 +2958,53 @@ func (x *ListInstancesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListInstancesResponse.ProtoReflect.Descriptor instead.
-func (*ListInstancesResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use SignSSHKeyResponse.ProtoReflect.Descriptor instead.
+func (*SignSSHKeyResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *ListInstancesResponse) GetInstances() []*Instance {
+func (x *SignSSHKeyResponse) GetSignedPublicSshKey() []byte {
 	if x != nil {
-		return x.Instances
+		return x.SignedPublicSshKey
 	}
 	return nil
 }
 
-// ListSwarmingBuildersRequest specifies the data needed to list all swarming builders.
-type ListSwarmingBuildersRequest struct {
+// Snapshot describes a saved directory of a gomote instance.
+type Snapshot struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The name of the snapshot, unique among its owner's snapshots.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The builder type of the instance the snapshot was taken from.
+	BuilderType string `protobuf:"bytes,2,opt,name=builder_type,json=builderType,proto3" json:"builder_type,omitempty"`
+	// The directory, relative to the work directory, that was saved.
+	Directory string `protobuf:"bytes,3,opt,name=directory,proto3" json:"directory,omitempty"`
+	// When the snapshot was taken, in Unix epoch time format.
+	Created int64 `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"`
+	// The size of the snapshot's tar.gz in bytes.
+	Size int64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func (x *ListSwarmingBuildersRequest) Reset() {
-	*x = ListSwarmingBuildersRequest{}
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[17]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListSwarmingBuildersRequest) String() string {
+func (x *Snapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSwarmingBuildersRequest) ProtoMessage() {}
+func (*Snapshot) ProtoMessage() {}
 
-func (x *ListSwarmingBuildersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[17]
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1070,88 +3015,78 @@ func (x *ListSwarmingBuildersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSwarmingBuildersRequest.ProtoReflect.Descriptor instead.
-func (*ListSwarmingBuildersRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{17}
-}
-
-// ListSwarmingBuildersResponse contains a list of swarming builders.
-type ListSwarmingBuildersResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Builders []string `protobuf:"bytes,1,rep,name=builders,proto3" json:"builders,omitempty"`
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *ListSwarmingBuildersResponse) Reset() {
-	*x = ListSwarmingBuildersResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[18]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Snapshot) GetName() string {
+	if x != nil {
+		return x.Name
 	}
+	return ""
 }
 
-func (x *ListSwarmingBuildersResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Snapshot) GetBuilderType() string {
+	if x != nil {
+		return x.BuilderType
+	}
+	return ""
 }
 
-func (*ListSwarmingBuildersResponse) ProtoMessage() {}
-
-func (x *ListSwarmingBuildersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[18]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Snapshot) GetDirectory() string {
+	if x != nil {
+		return x.Directory
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use ListSwarmingBuildersResponse.ProtoReflect.Descriptor instead.
-func (*ListSwarmingBuildersResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{18}
+func (x *Snapshot) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
 }
 
-func (x *ListSwarmingBuildersResponse) GetBuilders() []string {
+func (x *Snapshot) GetSize() int64 {
 	if x != nil {
-		return x.Builders
+		return x.Size
 	}
-	return nil
+	return 0
 }
 
-// ReadTGZToURLRequest specifies the data needed to retrieve a tar and zipped directory from a gomote instance.
-type ReadTGZToURLRequest struct {
+// SyncManifestRequest specifies the data needed to list the regular files in a directory on a gomote instance.
+type SyncManifestRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// The unique identifier for a gomote instance.
 	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
-	// The relative directory from the gomote's work directory to tar up.
+	// The directory to list, relative to the work directory. It need not exist.
 	Directory string `protobuf:"bytes,2,opt,name=directory,proto3" json:"directory,omitempty"`
+	// The directories to skip, relative to the main directory.
+	// Each item should contain only forward slashes and not start or end in slashes.
+	SkipFiles []string `protobuf:"bytes,3,rep,name=skip_files,json=skipFiles,proto3" json:"skip_files,omitempty"`
 }
 
-func (x *ReadTGZToURLRequest) Reset() {
-	*x = ReadTGZToURLRequest{}
+func (x *SyncManifestRequest) Reset() {
+	*x = SyncManifestRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[19]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ReadTGZToURLRequest) String() string {
+func (x *SyncManifestRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadTGZToURLRequest) ProtoMessage() {}
+func (*SyncManifestRequest) ProtoMessage() {}
 
-func (x *ReadTGZToURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[19]
+func (x *SyncManifestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1162,52 +3097,58 @@ func (x *ReadTGZToURLRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadTGZToURLRequest.ProtoReflect.Descriptor instead.
-func (*ReadTGZToURLRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use SyncManifestRequest.ProtoReflect.Descriptor instead.
+func (*SyncManifestRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *ReadTGZToURLRequest) GetGomoteId() string {
+func (x *SyncManifestRequest) GetGomoteId() string {
 	if x != nil {
 		return x.GomoteId
 	}
 	return ""
 }
 
-func (x *ReadTGZToURLRequest) GetDirectory() string {
+func (x *SyncManifestRequest) GetDirectory() string {
 	if x != nil {
 		return x.Directory
 	}
 	return ""
 }
 
-// ReadTGZToURLResponse contains a URL where the tar and zipped directory from a gomote instance can be downloaded from.
-type ReadTGZToURLResponse struct {
+func (x *SyncManifestRequest) GetSkipFiles() []string {
+	if x != nil {
+		return x.SkipFiles
+	}
+	return nil
+}
+
+// SyncManifestResponse contains some of the files in a directory of a gomote instance.
+type SyncManifestResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// URL to retrieve the tarball from.
-	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Files []*SyncFile `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
 }
 
-func (x *ReadTGZToURLResponse) Reset() {
-	*x = ReadTGZToURLResponse{}
+func (x *SyncManifestResponse) Reset() {
+	*x = SyncManifestResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[20]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ReadTGZToURLResponse) String() string {
+func (x *SyncManifestResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadTGZToURLResponse) ProtoMessage() {}
+func (*SyncManifestResponse) ProtoMessage() {}
 
-func (x *ReadTGZToURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[20]
+func (x *SyncManifestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1218,49 +3159,50 @@ func (x *ReadTGZToURLResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadTGZToURLResponse.ProtoReflect.Descriptor instead.
-func (*ReadTGZToURLResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use SyncManifestResponse.ProtoReflect.Descriptor instead.
+func (*SyncManifestResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *ReadTGZToURLResponse) GetUrl() string {
+func (x *SyncManifestResponse) GetFiles() []*SyncFile {
 	if x != nil {
-		return x.Url
+		return x.Files
 	}
-	return ""
+	return nil
 }
 
-// RemoveFilesRequest specifies the data needed to remove files or directories from a gomote instance.
-type RemoveFilesRequest struct {
+// SyncFile describes a regular file on a gomote instance.
+type SyncFile struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The unique identifier for a gomote instance.
-	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
-	// The list of paths for files or directories to remove from the file system.
-	// When everything should be deleted, "." should be used.
-	// The paths are relative to the work directory.
-	Paths []string `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	// The file's path, slash-separated and relative to the listed directory.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// The file mode.
+	Mode uint32 `protobuf:"fixed32,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Size int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// The hex SHA-256 hashes of the file's 64 KiB blocks, in order.
+	Blocks []string `protobuf:"bytes,4,rep,name=blocks,proto3" json:"blocks,omitempty"`
 }
 
-func (x *RemoveFilesRequest) Reset() {
-	*x = RemoveFilesRequest{}
+func (x *SyncFile) Reset() {
+	*x = SyncFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[21]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RemoveFilesRequest) String() string {
+func (x *SyncFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveFilesRequest) ProtoMessage() {}
+func (*SyncFile) ProtoMessage() {}
 
-func (x *RemoveFilesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[21]
+func (x *SyncFile) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1271,49 +3213,63 @@ func (x *RemoveFilesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveFilesRequest.ProtoReflect.Descriptor instead.
-func (*RemoveFilesRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use SyncFile.ProtoReflect.Descriptor instead.
+func (*SyncFile) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *RemoveFilesRequest) GetGomoteId() string {
+func (x *SyncFile) GetPath() string {
 	if x != nil {
-		return x.GomoteId
+		return x.Path
 	}
 	return ""
 }
 
-func (x *RemoveFilesRequest) GetPaths() []string {
+func (x *SyncFile) GetMode() uint32 {
 	if x != nil {
-		return x.Paths
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *SyncFile) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *SyncFile) GetBlocks() []string {
+	if x != nil {
+		return x.Blocks
 	}
 	return nil
 }
 
-// RemoveFilesResponse contains the results from removing files or directories from a gomote instance.
-type RemoveFilesResponse struct {
+// UploadFileRequest specifies the data needed to create a request to upload an object to GCS.
+type UploadFileRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *RemoveFilesResponse) Reset() {
-	*x = RemoveFilesResponse{}
+func (x *UploadFileRequest) Reset() {
+	*x = UploadFileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[22]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RemoveFilesResponse) String() string {
+func (x *UploadFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveFilesResponse) ProtoMessage() {}
+func (*UploadFileRequest) ProtoMessage() {}
 
-func (x *RemoveFilesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[22]
+func (x *UploadFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1324,40 +3280,42 @@ func (x *RemoveFilesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveFilesResponse.ProtoReflect.Descriptor instead.
-func (*RemoveFilesResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use UploadFileRequest.ProtoReflect.Descriptor instead.
+func (*UploadFileRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{50}
 }
 
-// SignSSHKeyRequest specifies the data needed to sign a public SSH key which attaches a certificate to the key.
-type SignSSHKeyRequest struct {
+// UploadFileResponse contains the results from a request to upload an object to GCS.
+type UploadFileResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The unique identifier for a gomote instance.
-	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
-	// A user provided public SSH key which the user intends to initiate an SSH session with.
-	PublicSshKey []byte `protobuf:"bytes,2,opt,name=public_ssh_key,json=publicSshKey,proto3" json:"public_ssh_key,omitempty"`
+	// URL to post file to.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Form fields used when http posting files to GCS.
+	Fields map[string]string `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Name used to reference the object.
+	ObjectName string `protobuf:"bytes,3,opt,name=object_name,json=objectName,proto3" json:"object_name,omitempty"`
 }
 
-func (x *SignSSHKeyRequest) Reset() {
-	*x = SignSSHKeyRequest{}
+func (x *UploadFileResponse) Reset() {
+	*x = UploadFileResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[23]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SignSSHKeyRequest) String() string {
+func (x *UploadFileResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignSSHKeyRequest) ProtoMessage() {}
+func (*UploadFileResponse) ProtoMessage() {}
 
-func (x *SignSSHKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[23]
+func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1368,53 +3326,56 @@ func (x *SignSSHKeyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignSSHKeyRequest.ProtoReflect.Descriptor instead.
-func (*SignSSHKeyRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use UploadFileResponse.ProtoReflect.Descriptor instead.
+func (*UploadFileResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *SignSSHKeyRequest) GetGomoteId() string {
+func (x *UploadFileResponse) GetUrl() string {
 	if x != nil {
-		return x.GomoteId
+		return x.Url
 	}
 	return ""
 }
 
-func (x *SignSSHKeyRequest) GetPublicSshKey() []byte {
+func (x *UploadFileResponse) GetFields() map[string]string {
 	if x != nil {
-		return x.PublicSshKey
+		return x.Fields
 	}
 	return nil
 }
 
-// SignSSHKeyResponse contains the results from a request to sign a public SSH key.
-type SignSSHKeyResponse struct {
+func (x *UploadFileResponse) GetObjectName() string {
+	if x != nil {
+		return x.ObjectName
+	}
+	return ""
+}
+
+// WatchInstancesRequest specifies the data needed to watch the caller's instances.
+type WatchInstancesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	// A signed SSH key can be used in conjunction with the associated private key to initiate an SSH session to a gomote instance.
-	// The certificate attached to the key will contain principles which restrict the instance that can be logged into.
-	SignedPublicSshKey []byte `protobuf:"bytes,1,opt,name=signed_public_ssh_key,json=signedPublicSshKey,proto3" json:"signed_public_ssh_key,omitempty"`
 }
 
-func (x *SignSSHKeyResponse) Reset() {
-	*x = SignSSHKeyResponse{}
+func (x *WatchInstancesRequest) Reset() {
+	*x = WatchInstancesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[24]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SignSSHKeyResponse) String() string {
+func (x *WatchInstancesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignSSHKeyResponse) ProtoMessage() {}
+func (*WatchInstancesRequest) ProtoMessage() {}
 
-func (x *SignSSHKeyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[24]
+func (x *WatchInstancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1425,42 +3386,41 @@ func (x *SignSSHKeyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignSSHKeyResponse.ProtoReflect.Descriptor instead.
-func (*SignSSHKeyResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{24}
-}
-
-func (x *SignSSHKeyResponse) GetSignedPublicSshKey() []byte {
-	if x != nil {
-		return x.SignedPublicSshKey
-	}
-	return nil
+// Deprecated: Use WatchInstancesRequest.ProtoReflect.Descriptor instead.
+func (*WatchInstancesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{52}
 }
 
-// UploadFileRequest specifies the data needed to create a request to upload an object to GCS.
-type UploadFileRequest struct {
+// WatchInstancesResponse contains a warning about one of the caller's instances.
+type WatchInstancesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier for a gomote instance.
+	GomoteId string                       `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
+	Event    WatchInstancesResponse_Event `protobuf:"varint,2,opt,name=event,proto3,enum=protos.WatchInstancesResponse_Event" json:"event,omitempty"`
+	// When the instance will be or was destroyed, in Unix epoch time format.
+	ReclaimTime int64 `protobuf:"varint,3,opt,name=reclaim_time,json=reclaimTime,proto3" json:"reclaim_time,omitempty"`
 }
 
-func (x *UploadFileRequest) Reset() {
-	*x = UploadFileRequest{}
+func (x *WatchInstancesResponse) Reset() {
+	*x = WatchInstancesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[25]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UploadFileRequest) String() string {
+func (x *WatchInstancesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadFileRequest) ProtoMessage() {}
+func (*WatchInstancesResponse) ProtoMessage() {}
 
-func (x *UploadFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[25]
+func (x *WatchInstancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1471,42 +3431,66 @@ func (x *UploadFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadFileRequest.ProtoReflect.Descriptor instead.
-func (*UploadFileRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use WatchInstancesResponse.ProtoReflect.Descriptor instead.
+func (*WatchInstancesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{53}
 }
 
-// UploadFileResponse contains the results from a request to upload an object to GCS.
-type UploadFileResponse struct {
+func (x *WatchInstancesResponse) GetGomoteId() string {
+	if x != nil {
+		return x.GomoteId
+	}
+	return ""
+}
+
+func (x *WatchInstancesResponse) GetEvent() WatchInstancesResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return WatchInstancesResponse_UNKNOWN
+}
+
+func (x *WatchInstancesResponse) GetReclaimTime() int64 {
+	if x != nil {
+		return x.ReclaimTime
+	}
+	return 0
+}
+
+// WriteFileFromURLRequest specifies the data needed to request that a gomote download the contents of a URL and place
+// the contents in a file.
+type WriteFileFromURLRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// URL to post file to.
-	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	// Form fields used when http posting files to GCS.
-	Fields map[string]string `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Name used to reference the object.
-	ObjectName string `protobuf:"bytes,3,opt,name=object_name,json=objectName,proto3" json:"object_name,omitempty"`
+	// The unique identifier for a gomote instance.
+	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
+	// URL to post get file from.
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// The filename as it should appear at the destination.
+	Filename string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	// The file mode.
+	Mode uint32 `protobuf:"fixed32,4,opt,name=mode,proto3" json:"mode,omitempty"`
 }
 
-func (x *UploadFileResponse) Reset() {
-	*x = UploadFileResponse{}
+func (x *WriteFileFromURLRequest) Reset() {
+	*x = WriteFileFromURLRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[26]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UploadFileResponse) String() string {
+func (x *WriteFileFromURLRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadFileResponse) ProtoMessage() {}
+func (*WriteFileFromURLRequest) ProtoMessage() {}
 
-func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[26]
+func (x *WriteFileFromURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1517,66 +3501,110 @@ func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadFileResponse.ProtoReflect.Descriptor instead.
-func (*UploadFileResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use WriteFileFromURLRequest.ProtoReflect.Descriptor instead.
+func (*WriteFileFromURLRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *UploadFileResponse) GetUrl() string {
+func (x *WriteFileFromURLRequest) GetGomoteId() string {
+	if x != nil {
+		return x.GomoteId
+	}
+	return ""
+}
+
+func (x *WriteFileFromURLRequest) GetUrl() string {
 	if x != nil {
 		return x.Url
 	}
 	return ""
 }
 
-func (x *UploadFileResponse) GetFields() map[string]string {
+func (x *WriteFileFromURLRequest) GetFilename() string {
 	if x != nil {
-		return x.Fields
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *WriteFileFromURLRequest) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+// WriteFileFromURLResponse contains the results from requesting that a file be downloaded onto a gomote instance.
+type WriteFileFromURLResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *WriteFileFromURLResponse) Reset() {
+	*x = WriteFileFromURLResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteFileFromURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteFileFromURLResponse) ProtoMessage() {}
+
+func (x *WriteFileFromURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *UploadFileResponse) GetObjectName() string {
-	if x != nil {
-		return x.ObjectName
-	}
-	return ""
+// Deprecated: Use WriteFileFromURLResponse.ProtoReflect.Descriptor instead.
+func (*WriteFileFromURLResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{55}
 }
 
-// WriteFileFromURLRequest specifies the data needed to request that a gomote download the contents of a URL and place
-// the contents in a file.
-type WriteFileFromURLRequest struct {
+// WriteSyncFromURLRequest specifies the data needed to retrieve a stream of changes to a directory and apply them
+// to the file system of a gomote instance.
+type WriteSyncFromURLRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// The unique identifier for a gomote instance.
 	GomoteId string `protobuf:"bytes,1,opt,name=gomote_id,json=gomoteId,proto3" json:"gomote_id,omitempty"`
-	// URL to post get file from.
+	// URL to get the gzip-compressed changes from.
 	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
-	// The filename as it should appear at the destination.
-	Filename string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
-	// The file mode.
-	Mode uint32 `protobuf:"fixed32,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	// The directory to change, relative to the work directory. It is created if necessary.
+	Directory string `protobuf:"bytes,3,opt,name=directory,proto3" json:"directory,omitempty"`
 }
 
-func (x *WriteFileFromURLRequest) Reset() {
-	*x = WriteFileFromURLRequest{}
+func (x *WriteSyncFromURLRequest) Reset() {
+	*x = WriteSyncFromURLRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[27]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WriteFileFromURLRequest) String() string {
+func (x *WriteSyncFromURLRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteFileFromURLRequest) ProtoMessage() {}
+func (*WriteSyncFromURLRequest) ProtoMessage() {}
 
-func (x *WriteFileFromURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[27]
+func (x *WriteSyncFromURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1587,63 +3615,56 @@ func (x *WriteFileFromURLRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteFileFromURLRequest.ProtoReflect.Descriptor instead.
-func (*WriteFileFromURLRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use WriteSyncFromURLRequest.ProtoReflect.Descriptor instead.
+func (*WriteSyncFromURLRequest) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *WriteFileFromURLRequest) GetGomoteId() string {
+func (x *WriteSyncFromURLRequest) GetGomoteId() string {
 	if x != nil {
 		return x.GomoteId
 	}
 	return ""
 }
 
-func (x *WriteFileFromURLRequest) GetUrl() string {
+func (x *WriteSyncFromURLRequest) GetUrl() string {
 	if x != nil {
 		return x.Url
 	}
 	return ""
 }
 
-func (x *WriteFileFromURLRequest) GetFilename() string {
+func (x *WriteSyncFromURLRequest) GetDirectory() string {
 	if x != nil {
-		return x.Filename
+		return x.Directory
 	}
 	return ""
 }
 
-func (x *WriteFileFromURLRequest) GetMode() uint32 {
-	if x != nil {
-		return x.Mode
-	}
-	return 0
-}
-
-// WriteFileFromURLResponse contains the results from requesting that a file be downloaded onto a gomote instance.
-type WriteFileFromURLResponse struct {
+// WriteSyncFromURLResponse contains the results from applying changes to a directory of a gomote instance.
+type WriteSyncFromURLResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *WriteFileFromURLResponse) Reset() {
-	*x = WriteFileFromURLResponse{}
+func (x *WriteSyncFromURLResponse) Reset() {
+	*x = WriteSyncFromURLResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[28]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WriteFileFromURLResponse) String() string {
+func (x *WriteSyncFromURLResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteFileFromURLResponse) ProtoMessage() {}
+func (*WriteSyncFromURLResponse) ProtoMessage() {}
 
-func (x *WriteFileFromURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[28]
+func (x *WriteSyncFromURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1654,9 +3675,9 @@ func (x *WriteFileFromURLResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteFileFromURLResponse.ProtoReflect.Descriptor instead.
-func (*WriteFileFromURLResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use WriteSyncFromURLResponse.ProtoReflect.Descriptor instead.
+func (*WriteSyncFromURLResponse) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{57}
 }
 
 // WriteTGZFromURLRequest specifies the data needed to retrieve a file and expand it onto the file system of a gomote instance.
@@ -1677,7 +3698,7 @@ type WriteTGZFromURLRequest struct {
 func (x *WriteTGZFromURLRequest) Reset() {
 	*x = WriteTGZFromURLRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[29]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1690,7 +3711,7 @@ func (x *WriteTGZFromURLRequest) String() string {
 func (*WriteTGZFromURLRequest) ProtoMessage() {}
 
 func (x *WriteTGZFromURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[29]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1703,7 +3724,7 @@ func (x *WriteTGZFromURLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteTGZFromURLRequest.ProtoReflect.Descriptor instead.
 func (*WriteTGZFromURLRequest) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{29}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *WriteTGZFromURLRequest) GetGomoteId() string {
@@ -1737,7 +3758,7 @@ type WriteTGZFromURLResponse struct {
 func (x *WriteTGZFromURLResponse) Reset() {
 	*x = WriteTGZFromURLResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[30]
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1750,7 +3771,7 @@ func (x *WriteTGZFromURLResponse) String() string {
 func (*WriteTGZFromURLResponse) ProtoMessage() {}
 
 func (x *WriteTGZFromURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[30]
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1763,7 +3784,92 @@ func (x *WriteTGZFromURLResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteTGZFromURLResponse.ProtoReflect.Descriptor instead.
 func (*WriteTGZFromURLResponse) Descriptor() ([]byte, []int) {
-	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{30}
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{59}
+}
+
+// WarmPool describes a set of instances kept ready for a user.
+type WarmPool struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The builder type of the instances.
+	BuilderType string `protobuf:"bytes,1,opt,name=builder_type,json=builderType,proto3" json:"builder_type,omitempty"`
+	// The snapshot restored on the instances, if any.
+	Snapshot string `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// The number of instances to keep ready.
+	Size int32 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// The number of instances that are ready.
+	Ready int32 `protobuf:"varint,4,opt,name=ready,proto3" json:"ready,omitempty"`
+	// The last error starting an instance for the pool, if the last attempt failed.
+	LastError string `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+}
+
+func (x *WarmPool) Reset() {
+	*x = WarmPool{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_gomote_protos_gomote_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WarmPool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarmPool) ProtoMessage() {}
+
+func (x *WarmPool) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_gomote_protos_gomote_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarmPool.ProtoReflect.Descriptor instead.
+func (*WarmPool) Descriptor() ([]byte, []int) {
+	return file_internal_gomote_protos_gomote_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *WarmPool) GetBuilderType() string {
+	if x != nil {
+		return x.BuilderType
+	}
+	return ""
+}
+
+func (x *WarmPool) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+func (x *WarmPool) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *WarmPool) GetReady() int32 {
+	if x != nil {
+		return x.Ready
+	}
+	return 0
+}
+
+func (x *WarmPool) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
 }
 
 var File_internal_gomote_protos_gomote_proto protoreflect.FileDescriptor
@@ -1771,7 +3877,9 @@ var File_internal_gomote_protos_gomote_proto protoreflect.FileDescriptor
 var file_internal_gomote_protos_gomote_proto_rawDesc = []byte{
 	0x0a, 0x23, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x6f, 0x6d, 0x6f, 0x74,
 	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x22, 0x15, 0x0a,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x1a, 0x1e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x15, 0x0a,
 	0x13, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x22, 0x16, 0x0a, 0x14, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69,
 	0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x32, 0x0a, 0x13,
@@ -1782,236 +3890,521 @@ var file_internal_gomote_protos_gomote_proto_rawDesc = []byte{
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x6f, 0x6f, 0x74,
 	0x73, 0x74, 0x72, 0x61, 0x70, 0x5f, 0x67, 0x6f, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x0e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x47, 0x6f, 0x55,
-	0x72, 0x6c, 0x22, 0x67, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x62,
-	0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2b,
-	0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x72,
-	0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xdc, 0x01, 0x0a, 0x16,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x77, 0x61, 0x69, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x61,
-	0x68, 0x65, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x77, 0x61, 0x69, 0x74,
-	0x65, 0x72, 0x73, 0x41, 0x68, 0x65, 0x61, 0x64, 0x22, 0x30, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12,
-	0x0b, 0x0a, 0x07, 0x57, 0x41, 0x49, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08,
-	0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x22, 0x35, 0x0a, 0x16, 0x44, 0x65,
-	0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49,
-	0x64, 0x22, 0x19, 0x0a, 0x17, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa8, 0x02, 0x0a,
-	0x15, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74,
-	0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x21, 0x0a,
-	0x0c, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x0b, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4c, 0x65, 0x76, 0x65, 0x6c,
-	0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x12, 0x2d, 0x0a, 0x12, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64,
-	0x5f, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x11, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f,
-	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69,
-	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18,
-	0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x69,
-	0x6d, 0x69, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x6d, 0x69, 0x74, 0x61, 0x74, 0x65, 0x48,
-	0x6f, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x30, 0x0a, 0x16, 0x45, 0x78, 0x65, 0x63, 0x75,
-	0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0xa2, 0x01, 0x0a, 0x08, 0x49, 0x6e,
-	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74,
-	0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64,
-	0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x12, 0x1f, 0x0a,
-	0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x22, 0x33,
-	0x0a, 0x14, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74,
-	0x65, 0x49, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x41,
-	0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa6, 0x01, 0x0a,
-	0x14, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
-	0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
-	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x65, 0x12, 0x1d,
-	0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x70, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x0a,
-	0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64,
-	0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x31, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0x47, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x69, 0x6e, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x09,
-	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x1d, 0x0a, 0x1b, 0x4c, 0x69, 0x73,
-	0x74, 0x53, 0x77, 0x61, 0x72, 0x6d, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3a, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74,
-	0x53, 0x77, 0x61, 0x72, 0x6d, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c,
-	0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x62, 0x75, 0x69, 0x6c,
-	0x64, 0x65, 0x72, 0x73, 0x22, 0x50, 0x0a, 0x13, 0x52, 0x65, 0x61, 0x64, 0x54, 0x47, 0x5a, 0x54,
-	0x6f, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67,
-	0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x28, 0x0a, 0x14, 0x52, 0x65, 0x61, 0x64, 0x54, 0x47,
-	0x5a, 0x54, 0x6f, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10,
-	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
-	0x22, 0x47, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52,
+	0x72, 0x6c, 0x22, 0x83, 0x01, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x2b, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x65, 0x78, 0x70, 0x65,
+	0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x82, 0x02, 0x0a, 0x16, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x3d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x77, 0x61, 0x69, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x61, 0x68, 0x65, 0x61,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x77, 0x61, 0x69, 0x74, 0x65, 0x72, 0x73,
+	0x41, 0x68, 0x65, 0x61, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x77, 0x61,
+	0x72, 0x6d, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x66,
+	0x72, 0x6f, 0x6d, 0x57, 0x61, 0x72, 0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x22, 0x30, 0x0a, 0x06, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41, 0x49, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12,
+	0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x22, 0x66, 0x0a,
+	0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74,
-	0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0x15, 0x0a, 0x13, 0x52, 0x65, 0x6d,
-	0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x56, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f,
+	0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x46, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2c, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x2b, 0x0a,
+	0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x18, 0x0a, 0x16, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x35, 0x0a, 0x16, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x22, 0x19, 0x0a, 0x17, 0x44,
+	0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xfd, 0x02, 0x0a, 0x15, 0x45, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65,
+	0x62, 0x75, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67,
+	0x12, 0x2d, 0x0a, 0x12, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x5f, 0x65, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x61, 0x70,
+	0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x69, 0x6d, 0x69, 0x74, 0x61, 0x74, 0x65,
+	0x5f, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x69, 0x6d, 0x69, 0x74, 0x61, 0x74, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x74,
+	0x64, 0x65, 0x72, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x65, 0x70, 0x61,
+	0x72, 0x61, 0x74, 0x65, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x2a, 0x0a, 0x06, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0xd7, 0x01, 0x0a, 0x0a, 0x45, 0x78, 0x65, 0x63, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x70, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x04, 0x63, 0x70, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x09,
+	0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x77, 0x61, 0x6c, 0x6c,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f, 0x5f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6e, 0x6f, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x22, 0x7d, 0x0a, 0x16, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x33, 0x0a, 0x0b, 0x65, 0x78,
+	0x69, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x0a, 0x65, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22,
+	0x85, 0x02, 0x0a, 0x20, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x74, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x74, 0x74, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x65, 0x72, 0x6d, 0x12, 0x33, 0x0a, 0x0b, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x0a, 0x77, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x64, 0x69,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x1f,
+	0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x22, 0x34, 0x0a, 0x0a, 0x57, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x6c,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x22, 0x88, 0x01,
+	0x0a, 0x21, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64,
+	0x65, 0x72, 0x72, 0x12, 0x33, 0x0a, 0x0b, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x45, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0a, 0x65, 0x78,
+	0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xba, 0x02, 0x0a, 0x0a, 0x45, 0x78, 0x69,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x36, 0x0a, 0x09, 0x77, 0x61, 0x6c,
+	0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x77, 0x61, 0x6c, 0x6c, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x36, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x73, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x52, 0x73, 0x73, 0x12, 0x25,
+	0x0a, 0x0e, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x65, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63,
+	0x65, 0x65, 0x64, 0x65, 0x64, 0x22, 0xd9, 0x01, 0x0a, 0x12, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72,
+	0x64, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65,
+	0x76, 0x65, 0x72, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x76,
+	0x65, 0x72, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6f, 0x70, 0x65,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x22, 0xa9, 0x01, 0x0a, 0x13, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x50, 0x6f, 0x72,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x17, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x70, 0x65,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x3d, 0x0a,
+	0x0f, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x6e, 0x0a, 0x10,
+	0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12,
+	0x2b, 0x0a, 0x06, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x73, 0x22, 0xc8, 0x01, 0x0a,
+	0x0b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72,
+	0x12, 0x1b, 0x0a, 0x09, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x69, 0x64,
+	0x6c, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63,
+	0x6c, 0x61, 0x69, 0x6d, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x72, 0x65,
+	0x63, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x64, 0x22, 0x73, 0x0a, 0x0b, 0x51, 0x75, 0x6f, 0x74, 0x61,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x68, 0x6f, 0x73, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x68, 0x6f, 0x73, 0x74, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x22, 0xa2, 0x01, 0x0a,
+	0x08, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d,
+	0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f,
+	0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65,
+	0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x6f, 0x73,
+	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f,
+	0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69,
+	0x72, 0x22, 0x33, 0x0a, 0x14, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x6c, 0x69,
+	0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d,
+	0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f,
+	0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0xa6, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f,
+	0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d,
+	0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x76,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x70, 0x46, 0x69, 0x6c, 0x65, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x31, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x4c,
+	0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x47, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x16, 0x0a, 0x14,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x47, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a,
+	0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x52, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x22, 0x1d, 0x0a,
+	0x1b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x72, 0x6d, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3a, 0x0a, 0x1c,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x72, 0x6d, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74,
+	0x57, 0x61, 0x72, 0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x48, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x61, 0x72, 0x6d, 0x50, 0x6f, 0x6f, 0x6c,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x0a, 0x77, 0x61, 0x72,
+	0x6d, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x61, 0x72, 0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x52,
+	0x09, 0x77, 0x61, 0x72, 0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x22, 0x50, 0x0a, 0x13, 0x52, 0x65,
+	0x61, 0x64, 0x54, 0x47, 0x5a, 0x54, 0x6f, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x28, 0x0a, 0x14,
+	0x52, 0x65, 0x61, 0x64, 0x54, 0x47, 0x5a, 0x54, 0x6f, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x47, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74,
+	0x68, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22,
+	0x15, 0x0a, 0x13, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x67, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x57, 0x61, 0x72,
+	0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22,
+	0x44, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x57, 0x61, 0x72, 0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x77, 0x61, 0x72, 0x6d, 0x5f, 0x70,
+	0x6f, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x57, 0x61, 0x72, 0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x08, 0x77, 0x61, 0x72,
+	0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x22, 0x56, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x53, 0x53, 0x48,
+	0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f,
+	0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67,
+	0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x5f, 0x73, 0x73, 0x68, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0c, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x53, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x22, 0x47, 0x0a,
+	0x12, 0x53, 0x69, 0x67, 0x6e, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x15, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x5f, 0x73, 0x73, 0x68, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x12, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x53, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x22, 0x8d, 0x01, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x6f, 0x0a, 0x13, 0x53, 0x79, 0x6e, 0x63, 0x4d, 0x61,
+	0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x70,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6b,
+	0x69, 0x70, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x3e, 0x0a, 0x14, 0x53, 0x79, 0x6e, 0x63, 0x4d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x26, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x46, 0x69, 0x6c, 0x65,
+	0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x5e, 0x0a, 0x08, 0x53, 0x79, 0x6e, 0x63, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x07, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0x13, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc2, 0x01, 0x0a,
+	0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x3e, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x17, 0x0a, 0x15, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc3, 0x01, 0x0a, 0x16, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f,
 	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
-	0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x73, 0x73, 0x68,
-	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x75, 0x62, 0x6c,
-	0x69, 0x63, 0x53, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x22, 0x47, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e,
-	0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31,
-	0x0a, 0x15, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f,
-	0x73, 0x73, 0x68, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x73,
-	0x69, 0x67, 0x6e, 0x65, 0x64, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x53, 0x73, 0x68, 0x4b, 0x65,
-	0x79, 0x22, 0x13, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc2, 0x01, 0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a,
-	0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
-	0x3e, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46,
-	0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x69, 0x65, 0x6c,
-	0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12,
-	0x1f, 0x0a, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4e, 0x61, 0x6d, 0x65,
-	0x1a, 0x39, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x78, 0x0a, 0x17, 0x57,
-	0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74,
-	0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x07, 0x52,
-	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x1a, 0x0a, 0x18, 0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69,
-	0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x65, 0x0a, 0x16, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f,
+	0x49, 0x64, 0x12, 0x3a, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x21,
+	0x0a, 0x0c, 0x72, 0x65, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x54, 0x69, 0x6d,
+	0x65, 0x22, 0x2d, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x44, 0x4c, 0x45, 0x10,
+	0x01, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x45, 0x43, 0x4c, 0x41, 0x49, 0x4d, 0x45, 0x44, 0x10, 0x02,
+	0x22, 0x78, 0x0a, 0x17, 0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f,
 	0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67,
 	0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
 	0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69,
-	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64,
-	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x19, 0x0a, 0x17, 0x57, 0x72, 0x69, 0x74,
-	0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x32, 0xad, 0x0a, 0x0a, 0x0d, 0x47, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
-	0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41,
-	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x68,
-	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x41, 0x64, 0x64, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72,
-	0x61, 0x70, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x42,
-	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x42, 0x6f, 0x6f, 0x74,
-	0x73, 0x74, 0x72, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x53, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x30, 0x01, 0x12, 0x54, 0x0a, 0x0f, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49,
-	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0e, 0x45, 0x78,
-	0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1d, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d,
-	0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d,
-	0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12,
-	0x4e, 0x0a, 0x0d, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x6c, 0x69, 0x76, 0x65,
-	0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x4e, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
-	0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69,
-	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x59, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x07, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x1a, 0x0a, 0x18, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x66, 0x0a, 0x17, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53,
+	0x79, 0x6e, 0x63, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
+	0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x1a,
+	0x0a, 0x18, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x46, 0x72, 0x6f, 0x6d, 0x55,
+	0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x65, 0x0a, 0x16, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x49,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x72, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x79, 0x22, 0x19, 0x0a, 0x17, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f,
+	0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x92, 0x01, 0x0a,
+	0x08, 0x57, 0x61, 0x72, 0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x65, 0x61,
+	0x64, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x32, 0xc1, 0x11, 0x0a, 0x0d, 0x47, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x75, 0x74,
+	0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e,
+	0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x4b, 0x0a, 0x0c, 0x41, 0x64, 0x64, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70,
+	0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x42, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a,
+	0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x30, 0x01, 0x12, 0x51, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0f, 0x44, 0x65, 0x73, 0x74,
+	0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53,
+	0x0a, 0x0e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x30, 0x01, 0x12, 0x76, 0x0a, 0x19, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x12, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0b, 0x46,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x3f, 0x0a, 0x08, 0x47, 0x65, 0x74,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x47,
+	0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0d, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x1c, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x6c, 0x69,
+	0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x6c, 0x69, 0x76, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0d, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1c, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4e, 0x0a, 0x0d, 0x4c, 0x69,
-	0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x14, 0x4c, 0x69,
-	0x73, 0x74, 0x53, 0x77, 0x61, 0x72, 0x6d, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65,
-	0x72, 0x73, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x16, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x69, 0x6e, 0x67, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4e, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61,
+	0x72, 0x6d, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x12, 0x23, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x72, 0x6d,
+	0x69, 0x6e, 0x67, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74,
 	0x53, 0x77, 0x61, 0x72, 0x6d, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x72, 0x6d, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x69,
-	0x6c, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x4b, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x64, 0x54, 0x47, 0x5a, 0x54, 0x6f, 0x55, 0x52, 0x4c, 0x12,
-	0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x54, 0x47, 0x5a,
-	0x54, 0x6f, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x54, 0x47, 0x5a, 0x54, 0x6f, 0x55,
-	0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0b,
-	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0a, 0x53, 0x69, 0x67, 0x6e, 0x53, 0x53,
-	0x48, 0x4b, 0x65, 0x79, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x69,
-	0x67, 0x6e, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x53, 0x53, 0x48,
-	0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a,
-	0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x10, 0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c,
-	0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55,
-	0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d,
-	0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a,
-	0x0f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c,
-	0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54,
-	0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54,
-	0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2e, 0x6f, 0x72,
-	0x67, 0x2f, 0x78, 0x2f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x2f, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0d, 0x4c, 0x69,
+	0x73, 0x74, 0x57, 0x61, 0x72, 0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x61, 0x72, 0x6d, 0x50, 0x6f, 0x6f,
+	0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x61, 0x72, 0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x52, 0x65,
+	0x61, 0x64, 0x54, 0x47, 0x5a, 0x54, 0x6f, 0x55, 0x52, 0x4c, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x54, 0x47, 0x5a, 0x54, 0x6f, 0x55, 0x52, 0x4c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x52, 0x65, 0x61, 0x64, 0x54, 0x47, 0x5a, 0x54, 0x6f, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x48, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x57, 0x61, 0x72, 0x6d, 0x50, 0x6f, 0x6f, 0x6c,
+	0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x61, 0x72,
+	0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x61, 0x72, 0x6d, 0x50, 0x6f, 0x6f,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0a, 0x53,
+	0x69, 0x67, 0x6e, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x69,
+	0x67, 0x6e, 0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x4d, 0x0a, 0x0c, 0x53, 0x79, 0x6e, 0x63, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x79, 0x6e, 0x63,
+	0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x4d, 0x61, 0x6e,
+	0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x45, 0x0a, 0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12,
+	0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x57, 0x0a,
+	0x10, 0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52,
+	0x4c, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x10, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53,
+	0x79, 0x6e, 0x63, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x46, 0x72, 0x6f,
+	0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x46, 0x72,
+	0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x54, 0x0a, 0x0f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55,
+	0x52, 0x4c, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x54, 0x47, 0x5a, 0x46, 0x72, 0x6f, 0x6d, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2e,
+	0x6f, 0x72, 0x67, 0x2f, 0x78, 0x2f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2026,85 +4419,156 @@ func file_internal_gomote_protos_gomote_proto_rawDescGZIP() []byte {
 	return file_internal_gomote_protos_gomote_proto_rawDescData
 }
 
-var file_internal_gomote_protos_gomote_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_gomote_protos_gomote_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_internal_gomote_protos_gomote_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_internal_gomote_protos_gomote_proto_msgTypes = make([]protoimpl.MessageInfo, 62)
 var file_internal_gomote_protos_gomote_proto_goTypes = []interface{}{
-	(CreateInstanceResponse_Status)(0),   // 0: protos.CreateInstanceResponse.Status
-	(*AuthenticateRequest)(nil),          // 1: protos.AuthenticateRequest
-	(*AuthenticateResponse)(nil),         // 2: protos.AuthenticateResponse
-	(*AddBootstrapRequest)(nil),          // 3: protos.AddBootstrapRequest
-	(*AddBootstrapResponse)(nil),         // 4: protos.AddBootstrapResponse
-	(*CreateInstanceRequest)(nil),        // 5: protos.CreateInstanceRequest
-	(*CreateInstanceResponse)(nil),       // 6: protos.CreateInstanceResponse
-	(*DestroyInstanceRequest)(nil),       // 7: protos.DestroyInstanceRequest
-	(*DestroyInstanceResponse)(nil),      // 8: protos.DestroyInstanceResponse
-	(*ExecuteCommandRequest)(nil),        // 9: protos.ExecuteCommandRequest
-	(*ExecuteCommandResponse)(nil),       // 10: protos.ExecuteCommandResponse
-	(*Instance)(nil),                     // 11: protos.Instance
-	(*InstanceAliveRequest)(nil),         // 12: protos.InstanceAliveRequest
-	(*InstanceAliveResponse)(nil),        // 13: protos.InstanceAliveResponse
-	(*ListDirectoryRequest)(nil),         // 14: protos.ListDirectoryRequest
-	(*ListDirectoryResponse)(nil),        // 15: protos.ListDirectoryResponse
-	(*ListInstancesRequest)(nil),         // 16: protos.ListInstancesRequest
-	(*ListInstancesResponse)(nil),        // 17: protos.ListInstancesResponse
-	(*ListSwarmingBuildersRequest)(nil),  // 18: protos.ListSwarmingBuildersRequest
-	(*ListSwarmingBuildersResponse)(nil), // 19: protos.ListSwarmingBuildersResponse
-	(*ReadTGZToURLRequest)(nil),          // 20: protos.ReadTGZToURLRequest
-	(*ReadTGZToURLResponse)(nil),         // 21: protos.ReadTGZToURLResponse
-	(*RemoveFilesRequest)(nil),           // 22: protos.RemoveFilesRequest
-	(*RemoveFilesResponse)(nil),          // 23: protos.RemoveFilesResponse
-	(*SignSSHKeyRequest)(nil),            // 24: protos.SignSSHKeyRequest
-	(*SignSSHKeyResponse)(nil),           // 25: protos.SignSSHKeyResponse
-	(*UploadFileRequest)(nil),            // 26: protos.UploadFileRequest
-	(*UploadFileResponse)(nil),           // 27: protos.UploadFileResponse
-	(*WriteFileFromURLRequest)(nil),      // 28: protos.WriteFileFromURLRequest
-	(*WriteFileFromURLResponse)(nil),     // 29: protos.WriteFileFromURLResponse
-	(*WriteTGZFromURLRequest)(nil),       // 30: protos.WriteTGZFromURLRequest
-	(*WriteTGZFromURLResponse)(nil),      // 31: protos.WriteTGZFromURLResponse
-	nil,                                  // 32: protos.UploadFileResponse.FieldsEntry
+	(CreateInstanceResponse_Status)(0),        // 0: protos.CreateInstanceResponse.Status
+	(WatchInstancesResponse_Event)(0),         // 1: protos.WatchInstancesResponse.Event
+	(*AuthenticateRequest)(nil),               // 2: protos.AuthenticateRequest
+	(*AuthenticateResponse)(nil),              // 3: protos.AuthenticateResponse
+	(*AddBootstrapRequest)(nil),               // 4: protos.AddBootstrapRequest
+	(*AddBootstrapResponse)(nil),              // 5: protos.AddBootstrapResponse
+	(*CreateInstanceRequest)(nil),             // 6: protos.CreateInstanceRequest
+	(*CreateInstanceResponse)(nil),            // 7: protos.CreateInstanceResponse
+	(*CreateSnapshotRequest)(nil),             // 8: protos.CreateSnapshotRequest
+	(*CreateSnapshotResponse)(nil),            // 9: protos.CreateSnapshotResponse
+	(*DeleteSnapshotRequest)(nil),             // 10: protos.DeleteSnapshotRequest
+	(*DeleteSnapshotResponse)(nil),            // 11: protos.DeleteSnapshotResponse
+	(*DestroyInstanceRequest)(nil),            // 12: protos.DestroyInstanceRequest
+	(*DestroyInstanceResponse)(nil),           // 13: protos.DestroyInstanceResponse
+	(*ExecuteCommandRequest)(nil),             // 14: protos.ExecuteCommandRequest
+	(*ExecLimits)(nil),                        // 15: protos.ExecLimits
+	(*ExecuteCommandResponse)(nil),            // 16: protos.ExecuteCommandResponse
+	(*ExecuteCommandInteractiveRequest)(nil),  // 17: protos.ExecuteCommandInteractiveRequest
+	(*WindowSize)(nil),                        // 18: protos.WindowSize
+	(*ExecuteCommandInteractiveResponse)(nil), // 19: protos.ExecuteCommandInteractiveResponse
+	(*ExitStatus)(nil),                        // 20: protos.ExitStatus
+	(*ForwardPortRequest)(nil),                // 21: protos.ForwardPortRequest
+	(*ForwardPortResponse)(nil),               // 22: protos.ForwardPortResponse
+	(*GetUsageRequest)(nil),                   // 23: protos.GetUsageRequest
+	(*GetUsageResponse)(nil),                  // 24: protos.GetUsageResponse
+	(*UsageRecord)(nil),                       // 25: protos.UsageRecord
+	(*QuotaStatus)(nil),                       // 26: protos.QuotaStatus
+	(*Instance)(nil),                          // 27: protos.Instance
+	(*InstanceAliveRequest)(nil),              // 28: protos.InstanceAliveRequest
+	(*InstanceAliveResponse)(nil),             // 29: protos.InstanceAliveResponse
+	(*ListDirectoryRequest)(nil),              // 30: protos.ListDirectoryRequest
+	(*ListDirectoryResponse)(nil),             // 31: protos.ListDirectoryResponse
+	(*ListInstancesRequest)(nil),              // 32: protos.ListInstancesRequest
+	(*ListInstancesResponse)(nil),             // 33: protos.ListInstancesResponse
+	(*ListSnapshotsRequest)(nil),              // 34: protos.ListSnapshotsRequest
+	(*ListSnapshotsResponse)(nil),             // 35: protos.ListSnapshotsResponse
+	(*ListSwarmingBuildersRequest)(nil),       // 36: protos.ListSwarmingBuildersRequest
+	(*ListSwarmingBuildersResponse)(nil),      // 37: protos.ListSwarmingBuildersResponse
+	(*ListWarmPoolsRequest)(nil),              // 38: protos.ListWarmPoolsRequest
+	(*ListWarmPoolsResponse)(nil),             // 39: protos.ListWarmPoolsResponse
+	(*ReadTGZToURLRequest)(nil),               // 40: protos.ReadTGZToURLRequest
+	(*ReadTGZToURLResponse)(nil),              // 41: protos.ReadTGZToURLResponse
+	(*RemoveFilesRequest)(nil),                // 42: protos.RemoveFilesRequest
+	(*RemoveFilesResponse)(nil),               // 43: protos.RemoveFilesResponse
+	(*SetWarmPoolRequest)(nil),                // 44: protos.SetWarmPoolRequest
+	(*SetWarmPoolResponse)(nil),               // 45: protos.SetWarmPoolResponse
+	(*SignSSHKeyRequest)(nil),                 // 46: protos.SignSSHKeyRequest
+	(*SignSSHKeyResponse)(nil),                // 47: protos.SignSSHKeyResponse
+	(*Snapshot)(nil),                          // 48: protos.Snapshot
+	(*SyncManifestRequest)(nil),               // 49: protos.SyncManifestRequest
+	(*SyncManifestResponse)(nil),              // 50: protos.SyncManifestResponse
+	(*SyncFile)(nil),                          // 51: protos.SyncFile
+	(*UploadFileRequest)(nil),                 // 52: protos.UploadFileRequest
+	(*UploadFileResponse)(nil),                // 53: protos.UploadFileResponse
+	(*WatchInstancesRequest)(nil),             // 54: protos.WatchInstancesRequest
+	(*WatchInstancesResponse)(nil),            // 55: protos.WatchInstancesResponse
+	(*WriteFileFromURLRequest)(nil),           // 56: protos.WriteFileFromURLRequest
+	(*WriteFileFromURLResponse)(nil),          // 57: protos.WriteFileFromURLResponse
+	(*WriteSyncFromURLRequest)(nil),           // 58: protos.WriteSyncFromURLRequest
+	(*WriteSyncFromURLResponse)(nil),          // 59: protos.WriteSyncFromURLResponse
+	(*WriteTGZFromURLRequest)(nil),            // 60: protos.WriteTGZFromURLRequest
+	(*WriteTGZFromURLResponse)(nil),           // 61: protos.WriteTGZFromURLResponse
+	(*WarmPool)(nil),                          // 62: protos.WarmPool
+	nil,                                       // 63: protos.UploadFileResponse.FieldsEntry
+	(*durationpb.Duration)(nil),               // 64: google.protobuf.Duration
 }
 var file_internal_gomote_protos_gomote_proto_depIdxs = []int32{
-	11, // 0: protos.CreateInstanceResponse.instance:type_name -> protos.Instance
+	27, // 0: protos.CreateInstanceResponse.instance:type_name -> protos.Instance
 	0,  // 1: protos.CreateInstanceResponse.status:type_name -> protos.CreateInstanceResponse.Status
-	11, // 2: protos.ListInstancesResponse.instances:type_name -> protos.Instance
-	32, // 3: protos.UploadFileResponse.fields:type_name -> protos.UploadFileResponse.FieldsEntry
-	1,  // 4: protos.GomoteService.Authenticate:input_type -> protos.AuthenticateRequest
-	3,  // 5: protos.GomoteService.AddBootstrap:input_type -> protos.AddBootstrapRequest
-	5,  // 6: protos.GomoteService.CreateInstance:input_type -> protos.CreateInstanceRequest
-	7,  // 7: protos.GomoteService.DestroyInstance:input_type -> protos.DestroyInstanceRequest
-	9,  // 8: protos.GomoteService.ExecuteCommand:input_type -> protos.ExecuteCommandRequest
-	12, // 9: protos.GomoteService.InstanceAlive:input_type -> protos.InstanceAliveRequest
-	14, // 10: protos.GomoteService.ListDirectory:input_type -> protos.ListDirectoryRequest
-	14, // 11: protos.GomoteService.ListDirectoryStreaming:input_type -> protos.ListDirectoryRequest
-	16, // 12: protos.GomoteService.ListInstances:input_type -> protos.ListInstancesRequest
-	18, // 13: protos.GomoteService.ListSwarmingBuilders:input_type -> protos.ListSwarmingBuildersRequest
-	20, // 14: protos.GomoteService.ReadTGZToURL:input_type -> protos.ReadTGZToURLRequest
-	22, // 15: protos.GomoteService.RemoveFiles:input_type -> protos.RemoveFilesRequest
-	24, // 16: protos.GomoteService.SignSSHKey:input_type -> protos.SignSSHKeyRequest
-	26, // 17: protos.GomoteService.UploadFile:input_type -> protos.UploadFileRequest
-	28, // 18: protos.GomoteService.WriteFileFromURL:input_type -> protos.WriteFileFromURLRequest
-	30, // 19: protos.GomoteService.WriteTGZFromURL:input_type -> protos.WriteTGZFromURLRequest
-	2,  // 20: protos.GomoteService.Authenticate:output_type -> protos.AuthenticateResponse
-	4,  // 21: protos.GomoteService.AddBootstrap:output_type -> protos.AddBootstrapResponse
-	6,  // 22: protos.GomoteService.CreateInstance:output_type -> protos.CreateInstanceResponse
-	8,  // 23: protos.GomoteService.DestroyInstance:output_type -> protos.DestroyInstanceResponse
-	10, // 24: protos.GomoteService.ExecuteCommand:output_type -> protos.ExecuteCommandResponse
-	13, // 25: protos.GomoteService.InstanceAlive:output_type -> protos.InstanceAliveResponse
-	15, // 26: protos.GomoteService.ListDirectory:output_type -> protos.ListDirectoryResponse
-	15, // 27: protos.GomoteService.ListDirectoryStreaming:output_type -> protos.ListDirectoryResponse
-	17, // 28: protos.GomoteService.ListInstances:output_type -> protos.ListInstancesResponse
-	19, // 29: protos.GomoteService.ListSwarmingBuilders:output_type -> protos.ListSwarmingBuildersResponse
-	21, // 30: protos.GomoteService.ReadTGZToURL:output_type -> protos.ReadTGZToURLResponse
-	23, // 31: protos.GomoteService.RemoveFiles:output_type -> protos.RemoveFilesResponse
-	25, // 32: protos.GomoteService.SignSSHKey:output_type -> protos.SignSSHKeyResponse
-	27, // 33: protos.GomoteService.UploadFile:output_type -> protos.UploadFileResponse
-	29, // 34: protos.GomoteService.WriteFileFromURL:output_type -> protos.WriteFileFromURLResponse
-	31, // 35: protos.GomoteService.WriteTGZFromURL:output_type -> protos.WriteTGZFromURLResponse
-	20, // [20:36] is the sub-list for method output_type
-	4,  // [4:20] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	48, // 2: protos.CreateSnapshotResponse.snapshot:type_name -> protos.Snapshot
+	15, // 3: protos.ExecuteCommandRequest.limits:type_name -> protos.ExecLimits
+	64, // 4: protos.ExecLimits.wall_time:type_name -> google.protobuf.Duration
+	20, // 5: protos.ExecuteCommandResponse.exit_status:type_name -> protos.ExitStatus
+	14, // 6: protos.ExecuteCommandInteractiveRequest.command:type_name -> protos.ExecuteCommandRequest
+	18, // 7: protos.ExecuteCommandInteractiveRequest.window_size:type_name -> protos.WindowSize
+	20, // 8: protos.ExecuteCommandInteractiveResponse.exit_status:type_name -> protos.ExitStatus
+	64, // 9: protos.ExitStatus.wall_time:type_name -> google.protobuf.Duration
+	64, // 10: protos.ExitStatus.user_time:type_name -> google.protobuf.Duration
+	64, // 11: protos.ExitStatus.system_time:type_name -> google.protobuf.Duration
+	25, // 12: protos.GetUsageResponse.records:type_name -> protos.UsageRecord
+	26, // 13: protos.GetUsageResponse.quotas:type_name -> protos.QuotaStatus
+	27, // 14: protos.ListInstancesResponse.instances:type_name -> protos.Instance
+	48, // 15: protos.ListSnapshotsResponse.snapshots:type_name -> protos.Snapshot
+	62, // 16: protos.ListWarmPoolsResponse.warm_pools:type_name -> protos.WarmPool
+	62, // 17: protos.SetWarmPoolResponse.warm_pool:type_name -> protos.WarmPool
+	51, // 18: protos.SyncManifestResponse.files:type_name -> protos.SyncFile
+	63, // 19: protos.UploadFileResponse.fields:type_name -> protos.UploadFileResponse.FieldsEntry
+	1,  // 20: protos.WatchInstancesResponse.event:type_name -> protos.WatchInstancesResponse.Event
+	2,  // 21: protos.GomoteService.Authenticate:input_type -> protos.AuthenticateRequest
+	4,  // 22: protos.GomoteService.AddBootstrap:input_type -> protos.AddBootstrapRequest
+	6,  // 23: protos.GomoteService.CreateInstance:input_type -> protos.CreateInstanceRequest
+	8,  // 24: protos.GomoteService.CreateSnapshot:input_type -> protos.CreateSnapshotRequest
+	10, // 25: protos.GomoteService.DeleteSnapshot:input_type -> protos.DeleteSnapshotRequest
+	12, // 26: protos.GomoteService.DestroyInstance:input_type -> protos.DestroyInstanceRequest
+	14, // 27: protos.GomoteService.ExecuteCommand:input_type -> protos.ExecuteCommandRequest
+	17, // 28: protos.GomoteService.ExecuteCommandInteractive:input_type -> protos.ExecuteCommandInteractiveRequest
+	21, // 29: protos.GomoteService.ForwardPort:input_type -> protos.ForwardPortRequest
+	23, // 30: protos.GomoteService.GetUsage:input_type -> protos.GetUsageRequest
+	28, // 31: protos.GomoteService.InstanceAlive:input_type -> protos.InstanceAliveRequest
+	30, // 32: protos.GomoteService.ListDirectory:input_type -> protos.ListDirectoryRequest
+	30, // 33: protos.GomoteService.ListDirectoryStreaming:input_type -> protos.ListDirectoryRequest
+	32, // 34: protos.GomoteService.ListInstances:input_type -> protos.ListInstancesRequest
+	34, // 35: protos.GomoteService.ListSnapshots:input_type -> protos.ListSnapshotsRequest
+	36, // 36: protos.GomoteService.ListSwarmingBuilders:input_type -> protos.ListSwarmingBuildersRequest
+	38, // 37: protos.GomoteService.ListWarmPools:input_type -> protos.ListWarmPoolsRequest
+	40, // 38: protos.GomoteService.ReadTGZToURL:input_type -> protos.ReadTGZToURLRequest
+	42, // 39: protos.GomoteService.RemoveFiles:input_type -> protos.RemoveFilesRequest
+	44, // 40: protos.GomoteService.SetWarmPool:input_type -> protos.SetWarmPoolRequest
+	46, // 41: protos.GomoteService.SignSSHKey:input_type -> protos.SignSSHKeyRequest
+	49, // 42: protos.GomoteService.SyncManifest:input_type -> protos.SyncManifestRequest
+	52, // 43: protos.GomoteService.UploadFile:input_type -> protos.UploadFileRequest
+	54, // 44: protos.GomoteService.WatchInstances:input_type -> protos.WatchInstancesRequest
+	56, // 45: protos.GomoteService.WriteFileFromURL:input_type -> protos.WriteFileFromURLRequest
+	58, // 46: protos.GomoteService.WriteSyncFromURL:input_type -> protos.WriteSyncFromURLRequest
+	60, // 47: protos.GomoteService.WriteTGZFromURL:input_type -> protos.WriteTGZFromURLRequest
+	3,  // 48: protos.GomoteService.Authenticate:output_type -> protos.AuthenticateResponse
+	5,  // 49: protos.GomoteService.AddBootstrap:output_type -> protos.AddBootstrapResponse
+	7,  // 50: protos.GomoteService.CreateInstance:output_type -> protos.CreateInstanceResponse
+	9,  // 51: protos.GomoteService.CreateSnapshot:output_type -> protos.CreateSnapshotResponse
+	11, // 52: protos.GomoteService.DeleteSnapshot:output_type -> protos.DeleteSnapshotResponse
+	13, // 53: protos.GomoteService.DestroyInstance:output_type -> protos.DestroyInstanceResponse
+	16, // 54: protos.GomoteService.ExecuteCommand:output_type -> protos.ExecuteCommandResponse
+	19, // 55: protos.GomoteService.ExecuteCommandInteractive:output_type -> protos.ExecuteCommandInteractiveResponse
+	22, // 56: protos.GomoteService.ForwardPort:output_type -> protos.ForwardPortResponse
+	24, // 57: protos.GomoteService.GetUsage:output_type -> protos.GetUsageResponse
+	29, // 58: protos.GomoteService.InstanceAlive:output_type -> protos.InstanceAliveResponse
+	31, // 59: protos.GomoteService.ListDirectory:output_type -> protos.ListDirectoryResponse
+	31, // 60: protos.GomoteService.ListDirectoryStreaming:output_type -> protos.ListDirectoryResponse
+	33, // 61: protos.GomoteService.ListInstances:output_type -> protos.ListInstancesResponse
+	35, // 62: protos.GomoteService.ListSnapshots:output_type -> protos.ListSnapshotsResponse
+	37, // 63: protos.GomoteService.ListSwarmingBuilders:output_type -> protos.ListSwarmingBuildersResponse
+	39, // 64: protos.GomoteService.ListWarmPools:output_type -> protos.ListWarmPoolsResponse
+	41, // 65: protos.GomoteService.ReadTGZToURL:output_type -> protos.ReadTGZToURLResponse
+	43, // 66: protos.GomoteService.RemoveFiles:output_type -> protos.RemoveFilesResponse
+	45, // 67: protos.GomoteService.SetWarmPool:output_type -> protos.SetWarmPoolResponse
+	47, // 68: protos.GomoteService.SignSSHKey:output_type -> protos.SignSSHKeyResponse
+	50, // 69: protos.GomoteService.SyncManifest:output_type -> protos.SyncManifestResponse
+	53, // 70: protos.GomoteService.UploadFile:output_type -> protos.UploadFileResponse
+	55, // 71: protos.GomoteService.WatchInstances:output_type -> protos.WatchInstancesResponse
+	57, // 72: protos.GomoteService.WriteFileFromURL:output_type -> protos.WriteFileFromURLResponse
+	59, // 73: protos.GomoteService.WriteSyncFromURL:output_type -> protos.WriteSyncFromURLResponse
+	61, // 74: protos.GomoteService.WriteTGZFromURL:output_type -> protos.WriteTGZFromURLResponse
+	48, // [48:75] is the sub-list for method output_type
+	21, // [21:48] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_internal_gomote_protos_gomote_proto_init() }
@@ -2125,8 +4589,140 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AuthenticateResponse); i {
+		file_internal_gomote_protos_gomote_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthenticateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddBootstrapRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddBootstrapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateInstanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateInstanceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSnapshotResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSnapshotResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DestroyInstanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DestroyInstanceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecuteCommandRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2137,8 +4733,8 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddBootstrapRequest); i {
+		file_internal_gomote_protos_gomote_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecLimits); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2149,8 +4745,8 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddBootstrapResponse); i {
+		file_internal_gomote_protos_gomote_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecuteCommandResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2161,8 +4757,8 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateInstanceRequest); i {
+		file_internal_gomote_protos_gomote_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecuteCommandInteractiveRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2173,8 +4769,8 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateInstanceResponse); i {
+		file_internal_gomote_protos_gomote_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WindowSize); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2185,8 +4781,8 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DestroyInstanceRequest); i {
+		file_internal_gomote_protos_gomote_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecuteCommandInteractiveResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2197,8 +4793,8 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DestroyInstanceResponse); i {
+		file_internal_gomote_protos_gomote_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExitStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2209,8 +4805,8 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExecuteCommandRequest); i {
+		file_internal_gomote_protos_gomote_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForwardPortRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2221,8 +4817,8 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExecuteCommandResponse); i {
+		file_internal_gomote_protos_gomote_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForwardPortResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2233,7 +4829,55 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUsageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUsageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UsageRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuotaStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Instance); i {
 			case 0:
 				return &v.state
@@ -2245,7 +4889,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*InstanceAliveRequest); i {
 			case 0:
 				return &v.state
@@ -2257,7 +4901,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*InstanceAliveResponse); i {
 			case 0:
 				return &v.state
@@ -2269,7 +4913,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListDirectoryRequest); i {
 			case 0:
 				return &v.state
@@ -2281,7 +4925,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListDirectoryResponse); i {
 			case 0:
 				return &v.state
@@ -2293,7 +4937,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListInstancesRequest); i {
 			case 0:
 				return &v.state
@@ -2305,7 +4949,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListInstancesResponse); i {
 			case 0:
 				return &v.state
@@ -2317,7 +4961,31 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSnapshotsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSnapshotsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListSwarmingBuildersRequest); i {
 			case 0:
 				return &v.state
@@ -2329,7 +4997,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListSwarmingBuildersResponse); i {
 			case 0:
 				return &v.state
@@ -2341,7 +5009,31 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListWarmPoolsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListWarmPoolsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReadTGZToURLRequest); i {
 			case 0:
 				return &v.state
@@ -2353,7 +5045,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReadTGZToURLResponse); i {
 			case 0:
 				return &v.state
@@ -2365,7 +5057,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RemoveFilesRequest); i {
 			case 0:
 				return &v.state
@@ -2377,7 +5069,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RemoveFilesResponse); i {
 			case 0:
 				return &v.state
@@ -2389,7 +5081,31 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetWarmPoolRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetWarmPoolResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignSSHKeyRequest); i {
 			case 0:
 				return &v.state
@@ -2401,7 +5117,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignSSHKeyResponse); i {
 			case 0:
 				return &v.state
@@ -2413,7 +5129,55 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncManifestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncManifestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UploadFileRequest); i {
 			case 0:
 				return &v.state
@@ -2425,7 +5189,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UploadFileResponse); i {
 			case 0:
 				return &v.state
@@ -2437,7 +5201,31 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchInstancesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchInstancesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WriteFileFromURLRequest); i {
 			case 0:
 				return &v.state
@@ -2449,7 +5237,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WriteFileFromURLResponse); i {
 			case 0:
 				return &v.state
@@ -2461,7 +5249,31 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteSyncFromURLRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteSyncFromURLResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WriteTGZFromURLRequest); i {
 			case 0:
 				return &v.state
@@ -2473,7 +5285,7 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
-		file_internal_gomote_protos_gomote_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_gomote_protos_gomote_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WriteTGZFromURLResponse); i {
 			case 0:
 				return &v.state
@@ -2485,14 +5297,26 @@ func file_internal_gomote_protos_gomote_proto_init() {
 				return nil
 			}
 		}
+		file_internal_gomote_protos_gomote_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WarmPool); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_gomote_protos_gomote_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   32,
+			NumEnums:      2,
+			NumMessages:   62,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
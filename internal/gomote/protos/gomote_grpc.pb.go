@@ -23,22 +23,33 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GomoteService_Authenticate_FullMethodName           = "/protos.GomoteService/Authenticate"
-	GomoteService_AddBootstrap_FullMethodName           = "/protos.GomoteService/AddBootstrap"
-	GomoteService_CreateInstance_FullMethodName         = "/protos.GomoteService/CreateInstance"
-	GomoteService_DestroyInstance_FullMethodName        = "/protos.GomoteService/DestroyInstance"
-	GomoteService_ExecuteCommand_FullMethodName         = "/protos.GomoteService/ExecuteCommand"
-	GomoteService_InstanceAlive_FullMethodName          = "/protos.GomoteService/InstanceAlive"
-	GomoteService_ListDirectory_FullMethodName          = "/protos.GomoteService/ListDirectory"
-	GomoteService_ListDirectoryStreaming_FullMethodName = "/protos.GomoteService/ListDirectoryStreaming"
-	GomoteService_ListInstances_FullMethodName          = "/protos.GomoteService/ListInstances"
-	GomoteService_ListSwarmingBuilders_FullMethodName   = "/protos.GomoteService/ListSwarmingBuilders"
-	GomoteService_ReadTGZToURL_FullMethodName           = "/protos.GomoteService/ReadTGZToURL"
-	GomoteService_RemoveFiles_FullMethodName            = "/protos.GomoteService/RemoveFiles"
-	GomoteService_SignSSHKey_FullMethodName             = "/protos.GomoteService/SignSSHKey"
-	GomoteService_UploadFile_FullMethodName             = "/protos.GomoteService/UploadFile"
-	GomoteService_WriteFileFromURL_FullMethodName       = "/protos.GomoteService/WriteFileFromURL"
-	GomoteService_WriteTGZFromURL_FullMethodName        = "/protos.GomoteService/WriteTGZFromURL"
+	GomoteService_Authenticate_FullMethodName              = "/protos.GomoteService/Authenticate"
+	GomoteService_AddBootstrap_FullMethodName              = "/protos.GomoteService/AddBootstrap"
+	GomoteService_CreateInstance_FullMethodName            = "/protos.GomoteService/CreateInstance"
+	GomoteService_CreateSnapshot_FullMethodName            = "/protos.GomoteService/CreateSnapshot"
+	GomoteService_DeleteSnapshot_FullMethodName            = "/protos.GomoteService/DeleteSnapshot"
+	GomoteService_DestroyInstance_FullMethodName           = "/protos.GomoteService/DestroyInstance"
+	GomoteService_ExecuteCommand_FullMethodName            = "/protos.GomoteService/ExecuteCommand"
+	GomoteService_ExecuteCommandInteractive_FullMethodName = "/protos.GomoteService/ExecuteCommandInteractive"
+	GomoteService_ForwardPort_FullMethodName               = "/protos.GomoteService/ForwardPort"
+	GomoteService_GetUsage_FullMethodName                  = "/protos.GomoteService/GetUsage"
+	GomoteService_InstanceAlive_FullMethodName             = "/protos.GomoteService/InstanceAlive"
+	GomoteService_ListDirectory_FullMethodName             = "/protos.GomoteService/ListDirectory"
+	GomoteService_ListDirectoryStreaming_FullMethodName    = "/protos.GomoteService/ListDirectoryStreaming"
+	GomoteService_ListInstances_FullMethodName             = "/protos.GomoteService/ListInstances"
+	GomoteService_ListSnapshots_FullMethodName             = "/protos.GomoteService/ListSnapshots"
+	GomoteService_ListSwarmingBuilders_FullMethodName      = "/protos.GomoteService/ListSwarmingBuilders"
+	GomoteService_ListWarmPools_FullMethodName             = "/protos.GomoteService/ListWarmPools"
+	GomoteService_ReadTGZToURL_FullMethodName              = "/protos.GomoteService/ReadTGZToURL"
+	GomoteService_RemoveFiles_FullMethodName               = "/protos.GomoteService/RemoveFiles"
+	GomoteService_SetWarmPool_FullMethodName               = "/protos.GomoteService/SetWarmPool"
+	GomoteService_SignSSHKey_FullMethodName                = "/protos.GomoteService/SignSSHKey"
+	GomoteService_SyncManifest_FullMethodName              = "/protos.GomoteService/SyncManifest"
+	GomoteService_UploadFile_FullMethodName                = "/protos.GomoteService/UploadFile"
+	GomoteService_WatchInstances_FullMethodName            = "/protos.GomoteService/WatchInstances"
+	GomoteService_WriteFileFromURL_FullMethodName          = "/protos.GomoteService/WriteFileFromURL"
+	GomoteService_WriteSyncFromURL_FullMethodName          = "/protos.GomoteService/WriteSyncFromURL"
+	GomoteService_WriteTGZFromURL_FullMethodName           = "/protos.GomoteService/WriteTGZFromURL"
 )
 
 // GomoteServiceClient is the client API for GomoteService service.
@@ -53,10 +64,24 @@ type GomoteServiceClient interface {
 	AddBootstrap(ctx context.Context, in *AddBootstrapRequest, opts ...grpc.CallOption) (*AddBootstrapResponse, error)
 	// CreateInstance creates a gomote instance.
 	CreateInstance(ctx context.Context, in *CreateInstanceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CreateInstanceResponse], error)
+	// CreateSnapshot saves a directory of a gomote instance as a named snapshot, which new instances can be
+	// created from.
+	CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*CreateSnapshotResponse, error)
+	// DeleteSnapshot deletes a snapshot owned by the caller.
+	DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*DeleteSnapshotResponse, error)
 	// DestroyInstance destroys a gomote instance.
 	DestroyInstance(ctx context.Context, in *DestroyInstanceRequest, opts ...grpc.CallOption) (*DestroyInstanceResponse, error)
 	// ExecuteCommand executes a command on the gomote instance.
 	ExecuteCommand(ctx context.Context, in *ExecuteCommandRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecuteCommandResponse], error)
+	// ExecuteCommandInteractive executes a command on the gomote instance with its standard input,
+	// and optionally a pseudo-terminal, connected to the caller.
+	ExecuteCommandInteractive(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecuteCommandInteractiveRequest, ExecuteCommandInteractiveResponse], error)
+	// ForwardPort forwards TCP connections between the caller and a port on the loopback interface of the gomote
+	// instance. The connections are multiplexed over the stream.
+	ForwardPort(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ForwardPortRequest, ForwardPortResponse], error)
+	// GetUsage reports the caller's usage of gomote instances, or that of a group they're a member of, and the
+	// quotas that apply to them.
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
 	// InstanceAlive gives the liveness state of a gomote instance.
 	InstanceAlive(ctx context.Context, in *InstanceAliveRequest, opts ...grpc.CallOption) (*InstanceAliveResponse, error)
 	// ListDirectory lists the contents of a directory on an gomote instance.
@@ -65,20 +90,36 @@ type GomoteServiceClient interface {
 	ListDirectoryStreaming(ctx context.Context, in *ListDirectoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListDirectoryResponse], error)
 	// ListInstances lists all of the live gomote instances owned by the caller.
 	ListInstances(ctx context.Context, in *ListInstancesRequest, opts ...grpc.CallOption) (*ListInstancesResponse, error)
+	// ListSnapshots lists the snapshots owned by the caller.
+	ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error)
 	// ListSwarmingBuilders lists all of the swarming builders for the project.
 	ListSwarmingBuilders(ctx context.Context, in *ListSwarmingBuildersRequest, opts ...grpc.CallOption) (*ListSwarmingBuildersResponse, error)
+	// ListWarmPools lists the caller's warm pools.
+	ListWarmPools(ctx context.Context, in *ListWarmPoolsRequest, opts ...grpc.CallOption) (*ListWarmPoolsResponse, error)
 	// ReadTGZToURL tars and zips a directory which exists on the gomote instance and returns a URL where it can be
 	// downloaded from.
 	ReadTGZToURL(ctx context.Context, in *ReadTGZToURLRequest, opts ...grpc.CallOption) (*ReadTGZToURLResponse, error)
 	// RemoveFiles removes files or directories from the gomote instance.
 	RemoveFiles(ctx context.Context, in *RemoveFilesRequest, opts ...grpc.CallOption) (*RemoveFilesResponse, error)
+	// SetWarmPool sets how many instances of a builder type, optionally created from a snapshot, are kept ready
+	// for the caller, so that creating one doesn't have to wait.
+	SetWarmPool(ctx context.Context, in *SetWarmPoolRequest, opts ...grpc.CallOption) (*SetWarmPoolResponse, error)
 	// SignSSHKey signs an SSH public key which can be used to SSH into instances owned by the caller.
 	SignSSHKey(ctx context.Context, in *SignSSHKeyRequest, opts ...grpc.CallOption) (*SignSSHKeyResponse, error)
+	// SyncManifest lists the regular files in a directory on the gomote instance along with the hashes of their
+	// blocks, for use in computing the changes to send with WriteSyncFromURL.
+	SyncManifest(ctx context.Context, in *SyncManifestRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SyncManifestResponse], error)
 	// UploadFile generates a signed URL and associated fields to be used when uploading the object to GCS. Once uploaded
 	// the corresponding Write endpoint can be used to send the file to the gomote instance.
 	UploadFile(ctx context.Context, in *UploadFileRequest, opts ...grpc.CallOption) (*UploadFileResponse, error)
+	// WatchInstances streams warnings about the caller's instances, such as idle instances which are about
+	// to be destroyed, until the caller cancels it.
+	WatchInstances(ctx context.Context, in *WatchInstancesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchInstancesResponse], error)
 	// WriteFileFromURL
 	WriteFileFromURL(ctx context.Context, in *WriteFileFromURLRequest, opts ...grpc.CallOption) (*WriteFileFromURLResponse, error)
+	// WriteSyncFromURL retrieves a stream of changes to a directory, in the buildlet's sync format, from a URL and
+	// applies it to the file system of a gomote instance.
+	WriteSyncFromURL(ctx context.Context, in *WriteSyncFromURLRequest, opts ...grpc.CallOption) (*WriteSyncFromURLResponse, error)
 	// WriteTGZFromURL retrieves a tar and zipped file from a URL and expands it onto the file system of a gomote instance.
 	WriteTGZFromURL(ctx context.Context, in *WriteTGZFromURLRequest, opts ...grpc.CallOption) (*WriteTGZFromURLResponse, error)
 }
@@ -130,6 +171,26 @@ func (c *gomoteServiceClient) CreateInstance(ctx context.Context, in *CreateInst
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GomoteService_CreateInstanceClient = grpc.ServerStreamingClient[CreateInstanceResponse]
 
+func (c *gomoteServiceClient) CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*CreateSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSnapshotResponse)
+	err := c.cc.Invoke(ctx, GomoteService_CreateSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gomoteServiceClient) DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*DeleteSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSnapshotResponse)
+	err := c.cc.Invoke(ctx, GomoteService_DeleteSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gomoteServiceClient) DestroyInstance(ctx context.Context, in *DestroyInstanceRequest, opts ...grpc.CallOption) (*DestroyInstanceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DestroyInstanceResponse)
@@ -159,6 +220,42 @@ func (c *gomoteServiceClient) ExecuteCommand(ctx context.Context, in *ExecuteCom
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GomoteService_ExecuteCommandClient = grpc.ServerStreamingClient[ExecuteCommandResponse]
 
+func (c *gomoteServiceClient) ExecuteCommandInteractive(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecuteCommandInteractiveRequest, ExecuteCommandInteractiveResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GomoteService_ServiceDesc.Streams[2], GomoteService_ExecuteCommandInteractive_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExecuteCommandInteractiveRequest, ExecuteCommandInteractiveResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GomoteService_ExecuteCommandInteractiveClient = grpc.BidiStreamingClient[ExecuteCommandInteractiveRequest, ExecuteCommandInteractiveResponse]
+
+func (c *gomoteServiceClient) ForwardPort(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ForwardPortRequest, ForwardPortResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GomoteService_ServiceDesc.Streams[3], GomoteService_ForwardPort_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ForwardPortRequest, ForwardPortResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GomoteService_ForwardPortClient = grpc.BidiStreamingClient[ForwardPortRequest, ForwardPortResponse]
+
+func (c *gomoteServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, GomoteService_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gomoteServiceClient) InstanceAlive(ctx context.Context, in *InstanceAliveRequest, opts ...grpc.CallOption) (*InstanceAliveResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(InstanceAliveResponse)
@@ -181,7 +278,7 @@ func (c *gomoteServiceClient) ListDirectory(ctx context.Context, in *ListDirecto
 
 func (c *gomoteServiceClient) ListDirectoryStreaming(ctx context.Context, in *ListDirectoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListDirectoryResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GomoteService_ServiceDesc.Streams[2], GomoteService_ListDirectoryStreaming_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GomoteService_ServiceDesc.Streams[4], GomoteService_ListDirectoryStreaming_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -208,6 +305,16 @@ func (c *gomoteServiceClient) ListInstances(ctx context.Context, in *ListInstanc
 	return out, nil
 }
 
+func (c *gomoteServiceClient) ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSnapshotsResponse)
+	err := c.cc.Invoke(ctx, GomoteService_ListSnapshots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gomoteServiceClient) ListSwarmingBuilders(ctx context.Context, in *ListSwarmingBuildersRequest, opts ...grpc.CallOption) (*ListSwarmingBuildersResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListSwarmingBuildersResponse)
@@ -218,6 +325,16 @@ func (c *gomoteServiceClient) ListSwarmingBuilders(ctx context.Context, in *List
 	return out, nil
 }
 
+func (c *gomoteServiceClient) ListWarmPools(ctx context.Context, in *ListWarmPoolsRequest, opts ...grpc.CallOption) (*ListWarmPoolsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWarmPoolsResponse)
+	err := c.cc.Invoke(ctx, GomoteService_ListWarmPools_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gomoteServiceClient) ReadTGZToURL(ctx context.Context, in *ReadTGZToURLRequest, opts ...grpc.CallOption) (*ReadTGZToURLResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ReadTGZToURLResponse)
@@ -238,6 +355,16 @@ func (c *gomoteServiceClient) RemoveFiles(ctx context.Context, in *RemoveFilesRe
 	return out, nil
 }
 
+func (c *gomoteServiceClient) SetWarmPool(ctx context.Context, in *SetWarmPoolRequest, opts ...grpc.CallOption) (*SetWarmPoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetWarmPoolResponse)
+	err := c.cc.Invoke(ctx, GomoteService_SetWarmPool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gomoteServiceClient) SignSSHKey(ctx context.Context, in *SignSSHKeyRequest, opts ...grpc.CallOption) (*SignSSHKeyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SignSSHKeyResponse)
@@ -248,6 +375,25 @@ func (c *gomoteServiceClient) SignSSHKey(ctx context.Context, in *SignSSHKeyRequ
 	return out, nil
 }
 
+func (c *gomoteServiceClient) SyncManifest(ctx context.Context, in *SyncManifestRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SyncManifestResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GomoteService_ServiceDesc.Streams[5], GomoteService_SyncManifest_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SyncManifestRequest, SyncManifestResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GomoteService_SyncManifestClient = grpc.ServerStreamingClient[SyncManifestResponse]
+
 func (c *gomoteServiceClient) UploadFile(ctx context.Context, in *UploadFileRequest, opts ...grpc.CallOption) (*UploadFileResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UploadFileResponse)
@@ -258,6 +404,25 @@ func (c *gomoteServiceClient) UploadFile(ctx context.Context, in *UploadFileRequ
 	return out, nil
 }
 
+func (c *gomoteServiceClient) WatchInstances(ctx context.Context, in *WatchInstancesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchInstancesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GomoteService_ServiceDesc.Streams[6], GomoteService_WatchInstances_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchInstancesRequest, WatchInstancesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GomoteService_WatchInstancesClient = grpc.ServerStreamingClient[WatchInstancesResponse]
+
 func (c *gomoteServiceClient) WriteFileFromURL(ctx context.Context, in *WriteFileFromURLRequest, opts ...grpc.CallOption) (*WriteFileFromURLResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(WriteFileFromURLResponse)
@@ -268,6 +433,16 @@ func (c *gomoteServiceClient) WriteFileFromURL(ctx context.Context, in *WriteFil
 	return out, nil
 }
 
+func (c *gomoteServiceClient) WriteSyncFromURL(ctx context.Context, in *WriteSyncFromURLRequest, opts ...grpc.CallOption) (*WriteSyncFromURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteSyncFromURLResponse)
+	err := c.cc.Invoke(ctx, GomoteService_WriteSyncFromURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gomoteServiceClient) WriteTGZFromURL(ctx context.Context, in *WriteTGZFromURLRequest, opts ...grpc.CallOption) (*WriteTGZFromURLResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(WriteTGZFromURLResponse)
@@ -290,10 +465,24 @@ type GomoteServiceServer interface {
 	AddBootstrap(context.Context, *AddBootstrapRequest) (*AddBootstrapResponse, error)
 	// CreateInstance creates a gomote instance.
 	CreateInstance(*CreateInstanceRequest, grpc.ServerStreamingServer[CreateInstanceResponse]) error
+	// CreateSnapshot saves a directory of a gomote instance as a named snapshot, which new instances can be
+	// created from.
+	CreateSnapshot(context.Context, *CreateSnapshotRequest) (*CreateSnapshotResponse, error)
+	// DeleteSnapshot deletes a snapshot owned by the caller.
+	DeleteSnapshot(context.Context, *DeleteSnapshotRequest) (*DeleteSnapshotResponse, error)
 	// DestroyInstance destroys a gomote instance.
 	DestroyInstance(context.Context, *DestroyInstanceRequest) (*DestroyInstanceResponse, error)
 	// ExecuteCommand executes a command on the gomote instance.
 	ExecuteCommand(*ExecuteCommandRequest, grpc.ServerStreamingServer[ExecuteCommandResponse]) error
+	// ExecuteCommandInteractive executes a command on the gomote instance with its standard input,
+	// and optionally a pseudo-terminal, connected to the caller.
+	ExecuteCommandInteractive(grpc.BidiStreamingServer[ExecuteCommandInteractiveRequest, ExecuteCommandInteractiveResponse]) error
+	// ForwardPort forwards TCP connections between the caller and a port on the loopback interface of the gomote
+	// instance. The connections are multiplexed over the stream.
+	ForwardPort(grpc.BidiStreamingServer[ForwardPortRequest, ForwardPortResponse]) error
+	// GetUsage reports the caller's usage of gomote instances, or that of a group they're a member of, and the
+	// quotas that apply to them.
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
 	// InstanceAlive gives the liveness state of a gomote instance.
 	InstanceAlive(context.Context, *InstanceAliveRequest) (*InstanceAliveResponse, error)
 	// ListDirectory lists the contents of a directory on an gomote instance.
@@ -302,20 +491,36 @@ type GomoteServiceServer interface {
 	ListDirectoryStreaming(*ListDirectoryRequest, grpc.ServerStreamingServer[ListDirectoryResponse]) error
 	// ListInstances lists all of the live gomote instances owned by the caller.
 	ListInstances(context.Context, *ListInstancesRequest) (*ListInstancesResponse, error)
+	// ListSnapshots lists the snapshots owned by the caller.
+	ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error)
 	// ListSwarmingBuilders lists all of the swarming builders for the project.
 	ListSwarmingBuilders(context.Context, *ListSwarmingBuildersRequest) (*ListSwarmingBuildersResponse, error)
+	// ListWarmPools lists the caller's warm pools.
+	ListWarmPools(context.Context, *ListWarmPoolsRequest) (*ListWarmPoolsResponse, error)
 	// ReadTGZToURL tars and zips a directory which exists on the gomote instance and returns a URL where it can be
 	// downloaded from.
 	ReadTGZToURL(context.Context, *ReadTGZToURLRequest) (*ReadTGZToURLResponse, error)
 	// RemoveFiles removes files or directories from the gomote instance.
 	RemoveFiles(context.Context, *RemoveFilesRequest) (*RemoveFilesResponse, error)
+	// SetWarmPool sets how many instances of a builder type, optionally created from a snapshot, are kept ready
+	// for the caller, so that creating one doesn't have to wait.
+	SetWarmPool(context.Context, *SetWarmPoolRequest) (*SetWarmPoolResponse, error)
 	// SignSSHKey signs an SSH public key which can be used to SSH into instances owned by the caller.
 	SignSSHKey(context.Context, *SignSSHKeyRequest) (*SignSSHKeyResponse, error)
+	// SyncManifest lists the regular files in a directory on the gomote instance along with the hashes of their
+	// blocks, for use in computing the changes to send with WriteSyncFromURL.
+	SyncManifest(*SyncManifestRequest, grpc.ServerStreamingServer[SyncManifestResponse]) error
 	// UploadFile generates a signed URL and associated fields to be used when uploading the object to GCS. Once uploaded
 	// the corresponding Write endpoint can be used to send the file to the gomote instance.
 	UploadFile(context.Context, *UploadFileRequest) (*UploadFileResponse, error)
+	// WatchInstances streams warnings about the caller's instances, such as idle instances which are about
+	// to be destroyed, until the caller cancels it.
+	WatchInstances(*WatchInstancesRequest, grpc.ServerStreamingServer[WatchInstancesResponse]) error
 	// WriteFileFromURL
 	WriteFileFromURL(context.Context, *WriteFileFromURLRequest) (*WriteFileFromURLResponse, error)
+	// WriteSyncFromURL retrieves a stream of changes to a directory, in the buildlet's sync format, from a URL and
+	// applies it to the file system of a gomote instance.
+	WriteSyncFromURL(context.Context, *WriteSyncFromURLRequest) (*WriteSyncFromURLResponse, error)
 	// WriteTGZFromURL retrieves a tar and zipped file from a URL and expands it onto the file system of a gomote instance.
 	WriteTGZFromURL(context.Context, *WriteTGZFromURLRequest) (*WriteTGZFromURLResponse, error)
 	mustEmbedUnimplementedGomoteServiceServer()
@@ -337,12 +542,27 @@ func (UnimplementedGomoteServiceServer) AddBootstrap(context.Context, *AddBootst
 func (UnimplementedGomoteServiceServer) CreateInstance(*CreateInstanceRequest, grpc.ServerStreamingServer[CreateInstanceResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method CreateInstance not implemented")
 }
+func (UnimplementedGomoteServiceServer) CreateSnapshot(context.Context, *CreateSnapshotRequest) (*CreateSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSnapshot not implemented")
+}
+func (UnimplementedGomoteServiceServer) DeleteSnapshot(context.Context, *DeleteSnapshotRequest) (*DeleteSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSnapshot not implemented")
+}
 func (UnimplementedGomoteServiceServer) DestroyInstance(context.Context, *DestroyInstanceRequest) (*DestroyInstanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyInstance not implemented")
 }
 func (UnimplementedGomoteServiceServer) ExecuteCommand(*ExecuteCommandRequest, grpc.ServerStreamingServer[ExecuteCommandResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method ExecuteCommand not implemented")
 }
+func (UnimplementedGomoteServiceServer) ExecuteCommandInteractive(grpc.BidiStreamingServer[ExecuteCommandInteractiveRequest, ExecuteCommandInteractiveResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExecuteCommandInteractive not implemented")
+}
+func (UnimplementedGomoteServiceServer) ForwardPort(grpc.BidiStreamingServer[ForwardPortRequest, ForwardPortResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ForwardPort not implemented")
+}
+func (UnimplementedGomoteServiceServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
 func (UnimplementedGomoteServiceServer) InstanceAlive(context.Context, *InstanceAliveRequest) (*InstanceAliveResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InstanceAlive not implemented")
 }
@@ -355,24 +575,42 @@ func (UnimplementedGomoteServiceServer) ListDirectoryStreaming(*ListDirectoryReq
 func (UnimplementedGomoteServiceServer) ListInstances(context.Context, *ListInstancesRequest) (*ListInstancesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListInstances not implemented")
 }
+func (UnimplementedGomoteServiceServer) ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSnapshots not implemented")
+}
 func (UnimplementedGomoteServiceServer) ListSwarmingBuilders(context.Context, *ListSwarmingBuildersRequest) (*ListSwarmingBuildersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSwarmingBuilders not implemented")
 }
+func (UnimplementedGomoteServiceServer) ListWarmPools(context.Context, *ListWarmPoolsRequest) (*ListWarmPoolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWarmPools not implemented")
+}
 func (UnimplementedGomoteServiceServer) ReadTGZToURL(context.Context, *ReadTGZToURLRequest) (*ReadTGZToURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadTGZToURL not implemented")
 }
 func (UnimplementedGomoteServiceServer) RemoveFiles(context.Context, *RemoveFilesRequest) (*RemoveFilesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveFiles not implemented")
 }
+func (UnimplementedGomoteServiceServer) SetWarmPool(context.Context, *SetWarmPoolRequest) (*SetWarmPoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWarmPool not implemented")
+}
 func (UnimplementedGomoteServiceServer) SignSSHKey(context.Context, *SignSSHKeyRequest) (*SignSSHKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignSSHKey not implemented")
 }
+func (UnimplementedGomoteServiceServer) SyncManifest(*SyncManifestRequest, grpc.ServerStreamingServer[SyncManifestResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SyncManifest not implemented")
+}
 func (UnimplementedGomoteServiceServer) UploadFile(context.Context, *UploadFileRequest) (*UploadFileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UploadFile not implemented")
 }
+func (UnimplementedGomoteServiceServer) WatchInstances(*WatchInstancesRequest, grpc.ServerStreamingServer[WatchInstancesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchInstances not implemented")
+}
 func (UnimplementedGomoteServiceServer) WriteFileFromURL(context.Context, *WriteFileFromURLRequest) (*WriteFileFromURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WriteFileFromURL not implemented")
 }
+func (UnimplementedGomoteServiceServer) WriteSyncFromURL(context.Context, *WriteSyncFromURLRequest) (*WriteSyncFromURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteSyncFromURL not implemented")
+}
 func (UnimplementedGomoteServiceServer) WriteTGZFromURL(context.Context, *WriteTGZFromURLRequest) (*WriteTGZFromURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WriteTGZFromURL not implemented")
 }
@@ -444,6 +682,42 @@ func _GomoteService_CreateInstance_Handler(srv interface{}, stream grpc.ServerSt
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GomoteService_CreateInstanceServer = grpc.ServerStreamingServer[CreateInstanceResponse]
 
+func _GomoteService_CreateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GomoteServiceServer).CreateSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GomoteService_CreateSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GomoteServiceServer).CreateSnapshot(ctx, req.(*CreateSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GomoteService_DeleteSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GomoteServiceServer).DeleteSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GomoteService_DeleteSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GomoteServiceServer).DeleteSnapshot(ctx, req.(*DeleteSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GomoteService_DestroyInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DestroyInstanceRequest)
 	if err := dec(in); err != nil {
@@ -473,6 +747,38 @@ func _GomoteService_ExecuteCommand_Handler(srv interface{}, stream grpc.ServerSt
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GomoteService_ExecuteCommandServer = grpc.ServerStreamingServer[ExecuteCommandResponse]
 
+func _GomoteService_ExecuteCommandInteractive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GomoteServiceServer).ExecuteCommandInteractive(&grpc.GenericServerStream[ExecuteCommandInteractiveRequest, ExecuteCommandInteractiveResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GomoteService_ExecuteCommandInteractiveServer = grpc.BidiStreamingServer[ExecuteCommandInteractiveRequest, ExecuteCommandInteractiveResponse]
+
+func _GomoteService_ForwardPort_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GomoteServiceServer).ForwardPort(&grpc.GenericServerStream[ForwardPortRequest, ForwardPortResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GomoteService_ForwardPortServer = grpc.BidiStreamingServer[ForwardPortRequest, ForwardPortResponse]
+
+func _GomoteService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GomoteServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GomoteService_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GomoteServiceServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GomoteService_InstanceAlive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InstanceAliveRequest)
 	if err := dec(in); err != nil {
@@ -538,6 +844,24 @@ func _GomoteService_ListInstances_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GomoteService_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GomoteServiceServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GomoteService_ListSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GomoteServiceServer).ListSnapshots(ctx, req.(*ListSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GomoteService_ListSwarmingBuilders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListSwarmingBuildersRequest)
 	if err := dec(in); err != nil {
@@ -556,6 +880,24 @@ func _GomoteService_ListSwarmingBuilders_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GomoteService_ListWarmPools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWarmPoolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GomoteServiceServer).ListWarmPools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GomoteService_ListWarmPools_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GomoteServiceServer).ListWarmPools(ctx, req.(*ListWarmPoolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GomoteService_ReadTGZToURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReadTGZToURLRequest)
 	if err := dec(in); err != nil {
@@ -592,6 +934,24 @@ func _GomoteService_RemoveFiles_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GomoteService_SetWarmPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWarmPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GomoteServiceServer).SetWarmPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GomoteService_SetWarmPool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GomoteServiceServer).SetWarmPool(ctx, req.(*SetWarmPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GomoteService_SignSSHKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SignSSHKeyRequest)
 	if err := dec(in); err != nil {
@@ -610,6 +970,17 @@ func _GomoteService_SignSSHKey_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GomoteService_SyncManifest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SyncManifestRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GomoteServiceServer).SyncManifest(m, &grpc.GenericServerStream[SyncManifestRequest, SyncManifestResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GomoteService_SyncManifestServer = grpc.ServerStreamingServer[SyncManifestResponse]
+
 func _GomoteService_UploadFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UploadFileRequest)
 	if err := dec(in); err != nil {
@@ -628,6 +999,17 @@ func _GomoteService_UploadFile_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GomoteService_WatchInstances_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchInstancesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GomoteServiceServer).WatchInstances(m, &grpc.GenericServerStream[WatchInstancesRequest, WatchInstancesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GomoteService_WatchInstancesServer = grpc.ServerStreamingServer[WatchInstancesResponse]
+
 func _GomoteService_WriteFileFromURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WriteFileFromURLRequest)
 	if err := dec(in); err != nil {
@@ -646,6 +1028,24 @@ func _GomoteService_WriteFileFromURL_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GomoteService_WriteSyncFromURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteSyncFromURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GomoteServiceServer).WriteSyncFromURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GomoteService_WriteSyncFromURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GomoteServiceServer).WriteSyncFromURL(ctx, req.(*WriteSyncFromURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GomoteService_WriteTGZFromURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WriteTGZFromURLRequest)
 	if err := dec(in); err != nil {
@@ -679,10 +1079,22 @@ var GomoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddBootstrap",
 			Handler:    _GomoteService_AddBootstrap_Handler,
 		},
+		{
+			MethodName: "CreateSnapshot",
+			Handler:    _GomoteService_CreateSnapshot_Handler,
+		},
+		{
+			MethodName: "DeleteSnapshot",
+			Handler:    _GomoteService_DeleteSnapshot_Handler,
+		},
 		{
 			MethodName: "DestroyInstance",
 			Handler:    _GomoteService_DestroyInstance_Handler,
 		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _GomoteService_GetUsage_Handler,
+		},
 		{
 			MethodName: "InstanceAlive",
 			Handler:    _GomoteService_InstanceAlive_Handler,
@@ -695,10 +1107,18 @@ var GomoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListInstances",
 			Handler:    _GomoteService_ListInstances_Handler,
 		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _GomoteService_ListSnapshots_Handler,
+		},
 		{
 			MethodName: "ListSwarmingBuilders",
 			Handler:    _GomoteService_ListSwarmingBuilders_Handler,
 		},
+		{
+			MethodName: "ListWarmPools",
+			Handler:    _GomoteService_ListWarmPools_Handler,
+		},
 		{
 			MethodName: "ReadTGZToURL",
 			Handler:    _GomoteService_ReadTGZToURL_Handler,
@@ -707,6 +1127,10 @@ var GomoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveFiles",
 			Handler:    _GomoteService_RemoveFiles_Handler,
 		},
+		{
+			MethodName: "SetWarmPool",
+			Handler:    _GomoteService_SetWarmPool_Handler,
+		},
 		{
 			MethodName: "SignSSHKey",
 			Handler:    _GomoteService_SignSSHKey_Handler,
@@ -719,6 +1143,10 @@ var GomoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "WriteFileFromURL",
 			Handler:    _GomoteService_WriteFileFromURL_Handler,
 		},
+		{
+			MethodName: "WriteSyncFromURL",
+			Handler:    _GomoteService_WriteSyncFromURL_Handler,
+		},
 		{
 			MethodName: "WriteTGZFromURL",
 			Handler:    _GomoteService_WriteTGZFromURL_Handler,
@@ -735,11 +1163,33 @@ var GomoteService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GomoteService_ExecuteCommand_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExecuteCommandInteractive",
+			Handler:       _GomoteService_ExecuteCommandInteractive_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ForwardPort",
+			Handler:       _GomoteService_ForwardPort_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "ListDirectoryStreaming",
 			Handler:       _GomoteService_ListDirectoryStreaming_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SyncManifest",
+			Handler:       _GomoteService_SyncManifest_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchInstances",
+			Handler:       _GomoteService_WatchInstances_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "internal/gomote/protos/gomote.proto",
 }
@@ -0,0 +1,180 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package gomote
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"time"
+)
+
+// QuotaConfig configures the limits on the number of gomote instances
+// users can have at once, and how long instances can stay unused before
+// they're destroyed. Host types are matched against patterns as with
+// path.Match; for swarming instances, the host type is the builder type.
+type QuotaConfig struct {
+	// Default are the limits of the users who don't have their own.
+	Default []QuotaLimit `json:"default"`
+	// Users maps the email addresses of users to their limits.
+	Users map[string][]QuotaLimit `json:"users"`
+	// Groups are sets of users who share limits, in addition to their own.
+	Groups []QuotaGroup `json:"groups"`
+	// Idle are the idle timeouts of host types. Instances are destroyed
+	// once they've been unused for the timeout of the first pattern they
+	// match, or for the session pool's timeout if none does. Timeouts
+	// longer than the session pool's have no effect.
+	Idle []IdleTimeout `json:"idle"`
+}
+
+// QuotaLimit limits the number of concurrent instances of the host
+// types matching a pattern.
+type QuotaLimit struct {
+	HostPattern string `json:"host"`
+	Max         int    `json:"max"`
+}
+
+// QuotaGroup is a set of users whose instances count against the same
+// limits.
+type QuotaGroup struct {
+	Name    string       `json:"name"`
+	Members []string     `json:"members"` // email addresses
+	Limits  []QuotaLimit `json:"limits"`
+}
+
+// IdleTimeout is how long instances of the host types matching a pattern
+// can stay unused.
+type IdleTimeout struct {
+	HostPattern string `json:"host"`
+	Timeout     string `json:"timeout"` // as for time.ParseDuration
+
+	timeout time.Duration
+}
+
+// ParseQuotaConfig parses a JSON quota configuration.
+func ParseQuotaConfig(data []byte) (*QuotaConfig, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	cfg := new(QuotaConfig)
+	if err := dec.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("invalid quota configuration: %w", err)
+	}
+	checkLimits := func(what string, limits []QuotaLimit) error {
+		for _, l := range limits {
+			if _, err := path.Match(l.HostPattern, ""); err != nil || l.HostPattern == "" {
+				return fmt.Errorf("invalid host pattern %q in %s limits", l.HostPattern, what)
+			}
+			if l.Max < 0 {
+				return fmt.Errorf("negative limit for %q in %s limits", l.HostPattern, what)
+			}
+		}
+		return nil
+	}
+	if err := checkLimits("default", cfg.Default); err != nil {
+		return nil, err
+	}
+	for user, limits := range cfg.Users {
+		if err := checkLimits(user+"'s", limits); err != nil {
+			return nil, err
+		}
+	}
+	seen := make(map[string]bool)
+	for _, g := range cfg.Groups {
+		if g.Name == "" || seen[g.Name] {
+			return nil, fmt.Errorf("quota groups must have unique, non-empty names; got %q", g.Name)
+		}
+		seen[g.Name] = true
+		if err := checkLimits("group "+g.Name, g.Limits); err != nil {
+			return nil, err
+		}
+	}
+	for i, it := range cfg.Idle {
+		if _, err := path.Match(it.HostPattern, ""); err != nil || it.HostPattern == "" {
+			return nil, fmt.Errorf("invalid host pattern %q in idle timeouts", it.HostPattern)
+		}
+		d, err := time.ParseDuration(it.Timeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid idle timeout %q for %q", it.Timeout, it.HostPattern)
+		}
+		cfg.Idle[i].timeout = d
+	}
+	return cfg, nil
+}
+
+// scopedLimit is a limit along with the users whose instances count
+// against it.
+type scopedLimit struct {
+	scope   string   // "user", or the name of a group
+	members []string // email addresses
+	QuotaLimit
+}
+
+// limits returns the limits that apply to a user. A nil configuration
+// has no limits.
+func (c *QuotaConfig) limits(email string) []scopedLimit {
+	if c == nil {
+		return nil
+	}
+	own, ok := c.Users[email]
+	if !ok {
+		own = c.Default
+	}
+	var ls []scopedLimit
+	for _, l := range own {
+		ls = append(ls, scopedLimit{scope: "user", members: []string{email}, QuotaLimit: l})
+	}
+	for _, g := range c.Groups {
+		if slices.Contains(g.Members, email) {
+			ls = append(ls, g.scoped()...)
+		}
+	}
+	return ls
+}
+
+// group returns the group with the given name.
+func (c *QuotaConfig) group(name string) (QuotaGroup, bool) {
+	if c == nil {
+		return QuotaGroup{}, false
+	}
+	for _, g := range c.Groups {
+		if g.Name == name {
+			return g, true
+		}
+	}
+	return QuotaGroup{}, false
+}
+
+// scoped returns the limits of group g.
+func (g QuotaGroup) scoped() []scopedLimit {
+	var ls []scopedLimit
+	for _, l := range g.Limits {
+		ls = append(ls, scopedLimit{scope: g.Name, members: g.Members, QuotaLimit: l})
+	}
+	return ls
+}
+
+// idleTimeout returns the idle timeout of a host type, or zero if it
+// has none.
+func (c *QuotaConfig) idleTimeout(hostType string) time.Duration {
+	if c == nil {
+		return 0
+	}
+	for _, it := range c.Idle {
+		if ok, _ := path.Match(it.HostPattern, hostType); ok {
+			return it.timeout
+		}
+	}
+	return 0
+}
+
+// matches reports whether a limit applies to a host type.
+func (l QuotaLimit) matches(hostType string) bool {
+	ok, _ := path.Match(l.HostPattern, hostType)
+	return ok
+}
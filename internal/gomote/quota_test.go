@@ -0,0 +1,90 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package gomote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testQuotaConfig = `{
+	"default": [{"host": "*-darwin-*", "max": 1}],
+	"users": {"power@golang.org": [{"host": "*-darwin-*", "max": 3}]},
+	"groups": [{
+		"name": "riscv",
+		"members": ["power@golang.org", "user@golang.org"],
+		"limits": [{"host": "*-riscv64*", "max": 2}]
+	}],
+	"idle": [{"host": "*-darwin-*", "timeout": "15m"}]
+}`
+
+func TestParseQuotaConfig(t *testing.T) {
+	cfg, err := ParseQuotaConfig([]byte(testQuotaConfig))
+	if err != nil {
+		t.Fatalf("ParseQuotaConfig() = %v; want no error", err)
+	}
+	if got := cfg.idleTimeout("gotip-darwin-arm64"); got != 15*time.Minute {
+		t.Errorf("idleTimeout(darwin) = %s; want 15m", got)
+	}
+	if got := cfg.idleTimeout("gotip-linux-amd64"); got != 0 {
+		t.Errorf("idleTimeout(linux) = %s; want 0", got)
+	}
+
+	type limit struct {
+		Scope string
+		Host  string
+		Max   int
+	}
+	limits := func(email string) []limit {
+		var ls []limit
+		for _, l := range cfg.limits(email) {
+			ls = append(ls, limit{l.scope, l.HostPattern, l.Max})
+		}
+		return ls
+	}
+	for _, tc := range []struct {
+		email string
+		want  []limit
+	}{
+		{"other@golang.org", []limit{{"user", "*-darwin-*", 1}}},
+		{"user@golang.org", []limit{{"user", "*-darwin-*", 1}, {"riscv", "*-riscv64*", 2}}},
+		{"power@golang.org", []limit{{"user", "*-darwin-*", 3}, {"riscv", "*-riscv64*", 2}}},
+	} {
+		if diff := cmp.Diff(tc.want, limits(tc.email)); diff != "" {
+			t.Errorf("limits(%q) mismatch (-want +got):\n%s", tc.email, diff)
+		}
+	}
+
+	var nilCfg *QuotaConfig
+	if ls := nilCfg.limits("user@golang.org"); len(ls) != 0 {
+		t.Errorf("limits of nil configuration = %v; want none", ls)
+	}
+}
+
+func TestParseQuotaConfigError(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		cfg  string
+	}{
+		{"invalid JSON", `{`},
+		{"unknown field", `{"defaults": []}`},
+		{"bad pattern", `{"default": [{"host": "[", "max": 1}]}`},
+		{"empty pattern", `{"default": [{"max": 1}]}`},
+		{"negative limit", `{"users": {"a@golang.org": [{"host": "*", "max": -1}]}}`},
+		{"unnamed group", `{"groups": [{"members": ["a@golang.org"]}]}`},
+		{"duplicate group", `{"groups": [{"name": "g"}, {"name": "g"}]}`},
+		{"bad timeout", `{"idle": [{"host": "*", "timeout": "soon"}]}`},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := ParseQuotaConfig([]byte(tc.cfg)); err == nil {
+				t.Errorf("ParseQuotaConfig(%s) = _, nil; want error", tc.cfg)
+			}
+		})
+	}
+}
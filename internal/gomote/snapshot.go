@@ -0,0 +1,293 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package gomote
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/internal/access"
+	"golang.org/x/build/internal/gomote/protos"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxSnapshotsPerOwner is the largest number of snapshots a user can have.
+const maxSnapshotsPerOwner = 20
+
+// defaultMaxSnapshotSize is the largest compressed size of a snapshot,
+// unless set by SnapshotSizeOption.
+const defaultMaxSnapshotSize = 2 << 30
+
+// SnapshotSizeOption sets the largest compressed size of a snapshot, in
+// bytes. Snapshots larger than that aren't stored.
+func SnapshotSizeOption(max int64) SwarmingOption {
+	return func(ss *SwarmingServer) {
+		ss.maxSnapshotSize = max
+	}
+}
+
+// errSnapshotTooLarge is returned by a snapshotSizeLimiter's Read once
+// more than the limit has been read.
+var errSnapshotTooLarge = errors.New("snapshot too large")
+
+// snapshotSizeLimiter reads from r, failing with errSnapshotTooLarge
+// rather than returning more than n more bytes.
+type snapshotSizeLimiter struct {
+	r io.Reader
+	n int64
+}
+
+func (l *snapshotSizeLimiter) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) > l.n {
+		return 0, errSnapshotTooLarge
+	}
+	l.n -= int64(n)
+	return n, err
+}
+
+// validSnapshotName matches the valid names of snapshots.
+var validSnapshotName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{0,63}$`)
+
+// errSnapshotNotFound is returned by a snapshotStore for a snapshot that
+// doesn't exist.
+var errSnapshotNotFound = errors.New("snapshot not found")
+
+// snapshotStore stores the snapshots of users.
+type snapshotStore interface {
+	// Put stores the tar.gz of a snapshot, replacing any of the owner's
+	// snapshots with the same name, and sets its size.
+	Put(ctx context.Context, ownerID string, snap *protos.Snapshot, tgz io.Reader) error
+	// Get returns a snapshot and the name of the object on the gomote
+	// bucket holding its tar.gz.
+	Get(ctx context.Context, ownerID, name string) (*protos.Snapshot, string, error)
+	// List returns the snapshots of an owner, sorted by name.
+	List(ctx context.Context, ownerID string) ([]*protos.Snapshot, error)
+	// Delete deletes a snapshot.
+	Delete(ctx context.Context, ownerID, name string) error
+}
+
+// gcsSnapshotStore stores snapshots on the gomote bucket, with their
+// descriptions in the objects' metadata.
+type gcsSnapshotStore struct {
+	bucket *storage.BucketHandle
+}
+
+// snapshotPrefix returns the prefix of the names of an owner's snapshot
+// objects.
+func snapshotPrefix(ownerID string) string {
+	sum := sha256.Sum256([]byte(ownerID))
+	return "snapshots/" + hex.EncodeToString(sum[:8]) + "/"
+}
+
+func snapshotObject(ownerID, name string) string {
+	return snapshotPrefix(ownerID) + name + ".tar.gz"
+}
+
+func (s *gcsSnapshotStore) Put(ctx context.Context, ownerID string, snap *protos.Snapshot, tgz io.Reader) error {
+	// Canceling the context stops the write without saving the object.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w := s.bucket.Object(snapshotObject(ownerID, snap.GetName())).NewWriter(ctx)
+	w.ContentType = "application/gzip"
+	w.Metadata = map[string]string{
+		"builder-type": snap.GetBuilderType(),
+		"directory":    snap.GetDirectory(),
+		"created":      strconv.FormatInt(snap.GetCreated(), 10),
+	}
+	n, err := io.Copy(w, tgz)
+	if err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	snap.Size = n
+	return nil
+}
+
+func snapshotFromAttrs(name string, attrs *storage.ObjectAttrs) *protos.Snapshot {
+	created, _ := strconv.ParseInt(attrs.Metadata["created"], 10, 64)
+	return &protos.Snapshot{
+		Name:        name,
+		BuilderType: attrs.Metadata["builder-type"],
+		Directory:   attrs.Metadata["directory"],
+		Created:     created,
+		Size:        attrs.Size,
+	}
+}
+
+func (s *gcsSnapshotStore) Get(ctx context.Context, ownerID, name string) (*protos.Snapshot, string, error) {
+	object := snapshotObject(ownerID, name)
+	attrs, err := s.bucket.Object(object).Attrs(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, "", errSnapshotNotFound
+	} else if err != nil {
+		return nil, "", err
+	}
+	return snapshotFromAttrs(name, attrs), object, nil
+}
+
+func (s *gcsSnapshotStore) List(ctx context.Context, ownerID string) ([]*protos.Snapshot, error) {
+	prefix := snapshotPrefix(ownerID)
+	it := s.bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	var snaps []*protos.Snapshot
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name, ok := strings.CutSuffix(strings.TrimPrefix(attrs.Name, prefix), ".tar.gz")
+		if !ok {
+			continue
+		}
+		snaps = append(snaps, snapshotFromAttrs(name, attrs))
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].GetName() < snaps[j].GetName() })
+	return snaps, nil
+}
+
+func (s *gcsSnapshotStore) Delete(ctx context.Context, ownerID, name string) error {
+	err := s.bucket.Object(snapshotObject(ownerID, name)).Delete(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return errSnapshotNotFound
+	}
+	return err
+}
+
+// CreateSnapshot saves a directory of a gomote instance as a named snapshot.
+func (ss *SwarmingServer) CreateSnapshot(ctx context.Context, req *protos.CreateSnapshotRequest) (*protos.CreateSnapshotResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("CreateSnapshot access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	if req.GetGomoteId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid gomote ID")
+	}
+	if !validSnapshotName.MatchString(req.GetName()) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid snapshot name %q", req.GetName())
+	}
+	ses, bc, err := ss.sessionAndClient(ctx, req.GetGomoteId(), creds.ID)
+	if err != nil {
+		// the helper function returns meaningful GRPC error.
+		return nil, err
+	}
+	snaps, err := ss.snapshots.List(ctx, creds.ID)
+	if err != nil {
+		log.Printf("CreateSnapshot: unable to list snapshots: %s", err)
+		return nil, status.Errorf(codes.Internal, "unable to list snapshots")
+	}
+	replaced := false
+	for _, s := range snaps {
+		replaced = replaced || s.GetName() == req.GetName()
+	}
+	if !replaced && len(snaps) >= maxSnapshotsPerOwner {
+		return nil, status.Errorf(codes.ResourceExhausted, "no more than %d snapshots are allowed; delete some first", maxSnapshotsPerOwner)
+	}
+	tgz, err := bc.GetTar(ctx, req.GetDirectory())
+	if err != nil {
+		return nil, status.Errorf(codes.Aborted, "unable to retrieve tar from gomote instance: %s", err)
+	}
+	defer tgz.Close()
+	snap := &protos.Snapshot{
+		Name:        req.GetName(),
+		BuilderType: ses.BuilderType,
+		Directory:   req.GetDirectory(),
+		Created:     time.Now().Unix(),
+	}
+	max := ss.maxSnapshotSize
+	if max == 0 {
+		max = defaultMaxSnapshotSize
+	}
+	err = ss.snapshots.Put(ctx, creds.ID, snap, &snapshotSizeLimiter{r: tgz, n: max})
+	if errors.Is(err, errSnapshotTooLarge) {
+		return nil, status.Errorf(codes.ResourceExhausted, "directory %q is larger than the maximum snapshot size of %d bytes", req.GetDirectory(), max)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Aborted, "unable to store snapshot: %s", err)
+	}
+	if replaced {
+		ss.warmPools.replaceSnapshot(creds.ID, snap.GetName())
+	}
+	return &protos.CreateSnapshotResponse{Snapshot: snap}, nil
+}
+
+// DeleteSnapshot deletes a snapshot owned by the caller.
+func (ss *SwarmingServer) DeleteSnapshot(ctx context.Context, req *protos.DeleteSnapshotRequest) (*protos.DeleteSnapshotResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("DeleteSnapshot access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	if !validSnapshotName.MatchString(req.GetName()) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid snapshot name %q", req.GetName())
+	}
+	err = ss.snapshots.Delete(ctx, creds.ID, req.GetName())
+	if errors.Is(err, errSnapshotNotFound) {
+		return nil, status.Errorf(codes.NotFound, "snapshot %q does not exist", req.GetName())
+	} else if err != nil {
+		log.Printf("DeleteSnapshot: unable to delete snapshot: %s", err)
+		return nil, status.Errorf(codes.Internal, "unable to delete snapshot")
+	}
+	return &protos.DeleteSnapshotResponse{}, nil
+}
+
+// ListSnapshots lists the snapshots owned by the caller.
+func (ss *SwarmingServer) ListSnapshots(ctx context.Context, req *protos.ListSnapshotsRequest) (*protos.ListSnapshotsResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("ListSnapshots access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	snaps, err := ss.snapshots.List(ctx, creds.ID)
+	if err != nil {
+		log.Printf("ListSnapshots: unable to list snapshots: %s", err)
+		return nil, status.Errorf(codes.Internal, "unable to list snapshots")
+	}
+	return &protos.ListSnapshotsResponse{Snapshots: snaps}, nil
+}
+
+// snapshot returns one of the caller's snapshots and the name of its
+// object, or a meaningful GRPC error.
+func (ss *SwarmingServer) snapshot(ctx context.Context, ownerID, name string) (*protos.Snapshot, string, error) {
+	snap, object, err := ss.snapshots.Get(ctx, ownerID, name)
+	if errors.Is(err, errSnapshotNotFound) {
+		return nil, "", status.Errorf(codes.NotFound, "snapshot %q does not exist", name)
+	} else if err != nil {
+		log.Printf("unable to read snapshot %q: %s", name, err)
+		return nil, "", status.Errorf(codes.Internal, "unable to read snapshot")
+	}
+	return snap, object, nil
+}
+
+// restoreSnapshot writes a snapshot stored in object to an instance.
+func (ss *SwarmingServer) restoreSnapshot(ctx context.Context, bc buildlet.Client, snap *protos.Snapshot, object string) error {
+	url, err := ss.signURLForDownload(object)
+	if err != nil {
+		return err
+	}
+	return bc.PutTarFromURL(ctx, url, snap.GetDirectory())
+}
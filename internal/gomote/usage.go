@@ -0,0 +1,432 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package gomote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/build/internal/access"
+	"golang.org/x/build/internal/coordinator/remote"
+	"golang.org/x/build/internal/gomote/protos"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// usageMaintainInterval is how often idle instances are looked for,
+	// and the usage ledger updated.
+	usageMaintainInterval = time.Minute
+	// idleGrace is how long an instance can be unused before the time
+	// it's unused is counted as idle.
+	idleGrace = 5 * time.Minute
+	// idleWarningLead is how long before an idle instance is destroyed
+	// its owner is warned.
+	idleWarningLead = 5 * time.Minute
+	// usageRetention is how long the usage of destroyed instances is
+	// kept in the ledger.
+	usageRetention = 30 * 24 * time.Hour
+)
+
+// quotaError is returned when a user would have more instances than a
+// limit allows.
+type quotaError struct {
+	limit scopedLimit
+}
+
+func (e *quotaError) Error() string {
+	if e.limit.scope == "user" {
+		return fmt.Sprintf("quota exceeded: no more than %d instances of %s are allowed per user", e.limit.Max, e.limit.HostPattern)
+	}
+	return fmt.Sprintf("quota exceeded: group %s can't have more than %d instances of %s", e.limit.scope, e.limit.Max, e.limit.HostPattern)
+}
+
+// usageEntry is the usage of an instance.
+type usageEntry struct {
+	gomoteID string
+	ownerID  string
+	email    string
+	hostType string
+	created  time.Time
+	ended    time.Time // zero while the instance is alive
+	lastUsed time.Time
+	idle     time.Duration // time unused beyond idleGrace
+	checked  time.Time     // when idle was last updated
+	warned   bool          // whether the owner was warned the instance is idle
+	// reclaimed is whether the instance was destroyed for being idle.
+	reclaimed bool
+}
+
+// reservation holds a place against the quota of a user for an instance
+// being created.
+type reservation struct {
+	email    string
+	hostType string
+}
+
+// usageTracker enforces the quotas of users, destroys the instances
+// they leave unused, and keeps a ledger of how instances are used.
+//
+// The ledger is kept in memory, so it only covers the time since the
+// server started. The usage of each destroyed instance is also logged.
+type usageTracker struct {
+	cfg *QuotaConfig
+	now func() time.Time
+
+	mu       sync.Mutex
+	active   map[string]*usageEntry // by gomote ID
+	ended    []*usageEntry
+	pending  map[*reservation]bool
+	watchers map[string]map[chan *protos.WatchInstancesResponse]bool // by owner ID
+}
+
+// newUsageTracker returns a usageTracker enforcing the quotas in cfg,
+// which may be nil.
+func newUsageTracker(cfg *QuotaConfig) *usageTracker {
+	return &usageTracker{
+		cfg:      cfg,
+		now:      time.Now,
+		active:   make(map[string]*usageEntry),
+		pending:  make(map[*reservation]bool),
+		watchers: make(map[string]map[chan *protos.WatchInstancesResponse]bool),
+	}
+}
+
+// reserve holds a place for an instance of a host type against the
+// quotas of a user. It returns a *quotaError if a quota is exceeded.
+// The place must be released once the instance is started, or failed
+// to be.
+func (ut *usageTracker) reserve(email, hostType string) (release func(), err error) {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	for _, l := range ut.cfg.limits(email) {
+		if l.matches(hostType) && ut.inUse(l) >= l.Max {
+			return nil, &quotaError{limit: l}
+		}
+	}
+	r := &reservation{email: email, hostType: hostType}
+	ut.pending[r] = true
+	return func() {
+		ut.mu.Lock()
+		defer ut.mu.Unlock()
+		delete(ut.pending, r)
+	}, nil
+}
+
+// inUse returns the number of instances counting against a limit.
+// ut.mu must be held.
+func (ut *usageTracker) inUse(l scopedLimit) int {
+	n := 0
+	for _, e := range ut.active {
+		if slices.Contains(l.members, e.email) && l.matches(e.hostType) {
+			n++
+		}
+	}
+	for r := range ut.pending {
+		if slices.Contains(l.members, r.email) && l.matches(r.hostType) {
+			n++
+		}
+	}
+	return n
+}
+
+// start records the creation of an instance.
+func (ut *usageTracker) start(gomoteID, ownerID, email, hostType string) {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	now := ut.now()
+	ut.active[gomoteID] = &usageEntry{
+		gomoteID: gomoteID,
+		ownerID:  ownerID,
+		email:    email,
+		hostType: hostType,
+		created:  now,
+		lastUsed: now,
+		checked:  now,
+	}
+}
+
+// end records the destruction of an instance.
+func (ut *usageTracker) end(gomoteID string) {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	ut.endLocked(gomoteID)
+}
+
+// endLocked records the destruction of an instance. ut.mu must be held.
+func (ut *usageTracker) endLocked(gomoteID string) {
+	e, ok := ut.active[gomoteID]
+	if !ok {
+		return
+	}
+	delete(ut.active, gomoteID)
+	e.ended = ut.now()
+	ut.ended = append(ut.ended, e)
+	log.Printf("usage: instance %s of %s for %s: alive %s, idle %s, reclaimed %t",
+		e.gomoteID, e.hostType, e.email, e.ended.Sub(e.created).Round(time.Second), e.idle.Round(time.Second), e.reclaimed)
+}
+
+// idleTimeout returns how long an instance of a host type can stay
+// unused before it's destroyed.
+func (ut *usageTracker) idleTimeout(hostType string) time.Duration {
+	if d := ut.cfg.idleTimeout(hostType); d > 0 && d < remote.IdleTimeout {
+		return d
+	}
+	return remote.IdleTimeout
+}
+
+// maintain updates the ledger with the sessions of sp, warns the owners
+// of the instances that are about to be destroyed for being idle, and
+// destroys those that have been idle for too long.
+func (ut *usageTracker) maintain(sp *remote.SessionPool) {
+	ut.mu.Lock()
+	now := ut.now()
+	sessions := make(map[string]*remote.Session)
+	for _, s := range sp.List() {
+		sessions[s.ID] = s
+	}
+	var reclaim []string
+	for id, e := range ut.active {
+		s, ok := sessions[id]
+		if !ok {
+			// The session pool destroyed the instance when it expired,
+			// or the instance was destroyed some other way.
+			e.reclaimed = now.Sub(e.lastUsed) >= remote.IdleTimeout
+			ut.endLocked(id)
+			continue
+		}
+		if s.LastUsed.After(e.lastUsed) {
+			e.lastUsed = s.LastUsed
+			e.warned = false
+		}
+		if from := latest(e.checked, e.lastUsed.Add(idleGrace)); now.After(from) {
+			e.idle += now.Sub(from)
+		}
+		e.checked = now
+
+		timeout := ut.idleTimeout(e.hostType)
+		reclaimAt := e.lastUsed.Add(timeout)
+		lead := min(idleWarningLead, timeout/2)
+		switch {
+		case !now.Before(reclaimAt):
+			e.reclaimed = true
+			reclaim = append(reclaim, id)
+		case !now.Before(reclaimAt.Add(-lead)) && !e.warned:
+			e.warned = true
+			ut.notifyLocked(e.ownerID, &protos.WatchInstancesResponse{
+				GomoteId:    id,
+				Event:       protos.WatchInstancesResponse_IDLE,
+				ReclaimTime: reclaimAt.Unix(),
+			})
+		}
+	}
+	for _, id := range reclaim {
+		e := ut.active[id]
+		ut.notifyLocked(e.ownerID, &protos.WatchInstancesResponse{
+			GomoteId:    id,
+			Event:       protos.WatchInstancesResponse_RECLAIMED,
+			ReclaimTime: now.Unix(),
+		})
+		ut.endLocked(id)
+	}
+	ut.ended = slices.DeleteFunc(ut.ended, func(e *usageEntry) bool {
+		return now.Sub(e.ended) > usageRetention
+	})
+	ut.mu.Unlock()
+
+	// Destroying instances may be slow, so it's done without the lock.
+	for _, id := range reclaim {
+		log.Printf("usage: destroying idle instance %s", id)
+		if err := sp.DestroySession(id); err != nil {
+			log.Printf("usage: unable to destroy idle instance %s: %s", id, err)
+		}
+	}
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// watch returns a channel on which the warnings about the instances of
+// an owner are sent, and a function to stop watching them.
+func (ut *usageTracker) watch(ownerID string) (<-chan *protos.WatchInstancesResponse, func()) {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	c := make(chan *protos.WatchInstancesResponse, 16)
+	if ut.watchers[ownerID] == nil {
+		ut.watchers[ownerID] = make(map[chan *protos.WatchInstancesResponse]bool)
+	}
+	ut.watchers[ownerID][c] = true
+	return c, func() {
+		ut.mu.Lock()
+		defer ut.mu.Unlock()
+		delete(ut.watchers[ownerID], c)
+		if len(ut.watchers[ownerID]) == 0 {
+			delete(ut.watchers, ownerID)
+		}
+	}
+}
+
+// notifyLocked sends a warning to the watchers of an owner's instances,
+// dropping it for those which are too slow to receive it.
+// ut.mu must be held.
+func (ut *usageTracker) notifyLocked(ownerID string, w *protos.WatchInstancesResponse) {
+	for c := range ut.watchers[ownerID] {
+		select {
+		case c <- w:
+		default:
+		}
+	}
+}
+
+// report returns the usage of the instances of a set of users since a
+// time, by user and host type.
+func (ut *usageTracker) report(emails []string, since time.Time) []*protos.UsageRecord {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	now := ut.now()
+	type key struct{ email, hostType string }
+	records := make(map[key]*protos.UsageRecord)
+	add := func(e *usageEntry) {
+		if !slices.Contains(emails, e.email) {
+			return
+		}
+		end := e.ended
+		if end.IsZero() {
+			end = now
+		}
+		if !end.After(since) {
+			return
+		}
+		k := key{e.email, e.hostType}
+		r, ok := records[k]
+		if !ok {
+			r = &protos.UsageRecord{User: e.email, HostType: e.hostType}
+			records[k] = r
+		}
+		start := latest(e.created, since)
+		alive := end.Sub(start)
+		idle := e.idle
+		if total := end.Sub(e.created); start.After(e.created) && total > 0 {
+			// Count the part of the idle time in the period, assuming
+			// it's spread evenly.
+			idle = time.Duration(float64(idle) * float64(alive) / float64(total))
+		}
+		if !e.created.Before(since) {
+			r.Instances++
+		}
+		r.InstanceSeconds += int64(alive / time.Second)
+		r.IdleSeconds += int64(idle / time.Second)
+		if e.reclaimed {
+			r.Reclaimed++
+		}
+	}
+	for _, e := range ut.ended {
+		add(e)
+	}
+	for _, e := range ut.active {
+		add(e)
+	}
+	var rs []*protos.UsageRecord
+	for _, r := range records {
+		rs = append(rs, r)
+	}
+	sort.Slice(rs, func(i, j int) bool {
+		if rs[i].GetUser() != rs[j].GetUser() {
+			return rs[i].GetUser() < rs[j].GetUser()
+		}
+		return rs[i].GetHostType() < rs[j].GetHostType()
+	})
+	return rs
+}
+
+// quotaStatus returns how much of each of a set of limits is used.
+func (ut *usageTracker) quotaStatus(limits []scopedLimit) []*protos.QuotaStatus {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	var qs []*protos.QuotaStatus
+	for _, l := range limits {
+		qs = append(qs, &protos.QuotaStatus{
+			Scope:       l.scope,
+			HostPattern: l.HostPattern,
+			Limit:       int32(l.Max),
+			InUse:       int32(ut.inUse(l)),
+		})
+	}
+	return qs
+}
+
+// quotaStatusError converts an error from reserving an instance to a
+// meaningful GRPC error.
+func quotaStatusError(err error) error {
+	var qe *quotaError
+	if errors.As(err, &qe) {
+		return status.Error(codes.ResourceExhausted, qe.Error())
+	}
+	return status.Errorf(codes.Internal, "unable to check quota: %s", err)
+}
+
+// GetUsage reports the usage of gomote instances by the caller, or by the members of a quota group they're in,
+// and the quotas that apply to them.
+func (ss *SwarmingServer) GetUsage(ctx context.Context, req *protos.GetUsageRequest) (*protos.GetUsageResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("GetUsage access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	if req.GetSince() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid start time")
+	}
+	since := time.Unix(req.GetSince(), 0)
+	if req.GetGroup() == "" {
+		return &protos.GetUsageResponse{
+			Records: ss.usage.report([]string{creds.Email}, since),
+			Quotas:  ss.usage.quotaStatus(ss.usage.cfg.limits(creds.Email)),
+		}, nil
+	}
+	g, ok := ss.usage.cfg.group(req.GetGroup())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "quota group %q does not exist", req.GetGroup())
+	}
+	if !slices.Contains(g.Members, creds.Email) {
+		return nil, status.Errorf(codes.PermissionDenied, "not a member of quota group %q", req.GetGroup())
+	}
+	return &protos.GetUsageResponse{
+		Records: ss.usage.report(g.Members, since),
+		Quotas:  ss.usage.quotaStatus(g.scoped()),
+	}, nil
+}
+
+// WatchInstances streams warnings about the caller's instances until the caller cancels it.
+func (ss *SwarmingServer) WatchInstances(req *protos.WatchInstancesRequest, stream protos.GomoteService_WatchInstancesServer) error {
+	creds, err := access.IAPFromContext(stream.Context())
+	if err != nil {
+		log.Printf("WatchInstances access.IAPFromContext(ctx) = nil, %s", err)
+		return status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	c, stop := ss.usage.watch(creds.ID)
+	defer stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case w := <-c:
+			if err := stream.Send(w); err != nil {
+				return status.Errorf(codes.Internal, "unable to stream warning: %s", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package gomote
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/internal/coordinator/remote"
+	"golang.org/x/build/internal/gomote/protos"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func newTestUsageTracker(t *testing.T) *usageTracker {
+	cfg, err := ParseQuotaConfig([]byte(testQuotaConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newUsageTracker(cfg)
+}
+
+func TestUsageTrackerReserve(t *testing.T) {
+	ut := newTestUsageTracker(t)
+	var qe *quotaError
+
+	// A pending creation counts against the limit.
+	release, err := ut.reserve("user@golang.org", "gotip-darwin-arm64")
+	if err != nil {
+		t.Fatalf("reserve() = %v; want no error", err)
+	}
+	if _, err := ut.reserve("user@golang.org", "gotip-darwin-arm64"); !errors.As(err, &qe) {
+		t.Fatalf("second reserve() = %v; want quota error", err)
+	}
+	// So does a created instance.
+	ut.start("user-darwin-0", "owner", "user@golang.org", "gotip-darwin-arm64")
+	release()
+	if _, err := ut.reserve("user@golang.org", "gotip-darwin-arm64"); !errors.As(err, &qe) {
+		t.Fatalf("reserve() with a live instance = %v; want quota error", err)
+	}
+	ut.end("user-darwin-0")
+	if _, err := ut.reserve("user@golang.org", "gotip-darwin-arm64"); err != nil {
+		t.Fatalf("reserve() after the instance ended = %v; want no error", err)
+	}
+
+	// Other host types aren't limited.
+	for range 5 {
+		if _, err := ut.reserve("user@golang.org", "gotip-linux-amd64"); err != nil {
+			t.Fatalf("reserve(linux) = %v; want no error", err)
+		}
+	}
+
+	// Group members share their group's limit.
+	if _, err := ut.reserve("user@golang.org", "gotip-linux-riscv64"); err != nil {
+		t.Fatalf("reserve(riscv) = %v; want no error", err)
+	}
+	if _, err := ut.reserve("power@golang.org", "gotip-linux-riscv64"); err != nil {
+		t.Fatalf("reserve(riscv) = %v; want no error", err)
+	}
+	if _, err := ut.reserve("power@golang.org", "gotip-linux-riscv64"); !errors.As(err, &qe) || qe.limit.scope != "riscv" {
+		t.Fatalf("reserve(riscv) over the group's limit = %v; want the group's quota error", err)
+	}
+}
+
+func TestUsageTrackerMaintain(t *testing.T) {
+	sp := remote.NewSessionPool(context.Background())
+	defer sp.Close()
+	darwin := sp.AddSession("owner", "user", "gotip-darwin-arm64", "gotip-darwin-arm64", "", &buildlet.FakeClient{})
+	linux := sp.AddSession("owner", "user", "gotip-linux-amd64", "gotip-linux-amd64", "", &buildlet.FakeClient{})
+	// The sessions were last used before now.
+	ut := newTestUsageTracker(t)
+	now := time.Now()
+	ut.now = func() time.Time { return now }
+	ut.start(darwin, "owner", "user@golang.org", "gotip-darwin-arm64")
+	ut.start(linux, "owner", "user@golang.org", "gotip-linux-amd64")
+	warnings, stop := ut.watch("owner")
+	defer stop()
+
+	// The darwin instance's idle timeout is 15 minutes, so its owner is
+	// warned after 10.
+	now = now.Add(11 * time.Minute)
+	ut.maintain(sp)
+	select {
+	case w := <-warnings:
+		want := &protos.WatchInstancesResponse{GomoteId: darwin, Event: protos.WatchInstancesResponse_IDLE}
+		if diff := cmp.Diff(want, w, protocmp.Transform(), protocmp.IgnoreFields(want, "reclaim_time")); diff != "" {
+			t.Errorf("warning mismatch (-want +got):\n%s", diff)
+		}
+	default:
+		t.Fatal("no warning about the idle instance")
+	}
+	now = now.Add(5 * time.Minute)
+	ut.maintain(sp)
+	if w := <-warnings; w.GetEvent() != protos.WatchInstancesResponse_RECLAIMED || w.GetGomoteId() != darwin {
+		t.Errorf("warning = %v; want %s to be reclaimed", w, darwin)
+	}
+	if _, err := sp.Session(darwin); err == nil {
+		t.Errorf("idle instance %s wasn't destroyed", darwin)
+	}
+	if _, err := sp.Session(linux); err != nil {
+		t.Errorf("instance %s was destroyed before the session pool's idle timeout", linux)
+	}
+
+	// Instances destroyed some other way are noticed.
+	if err := sp.DestroySession(linux); err != nil {
+		t.Fatal(err)
+	}
+	ut.maintain(sp)
+
+	got := ut.report([]string{"user@golang.org"}, time.Time{})
+	want := []*protos.UsageRecord{
+		{
+			User:            "user@golang.org",
+			HostType:        "gotip-darwin-arm64",
+			Instances:       1,
+			InstanceSeconds: int64(16 * time.Minute / time.Second),
+			IdleSeconds:     int64(11 * time.Minute / time.Second),
+			Reclaimed:       1,
+		},
+		{
+			User:            "user@golang.org",
+			HostType:        "gotip-linux-amd64",
+			Instances:       1,
+			InstanceSeconds: int64(16 * time.Minute / time.Second),
+			IdleSeconds:     int64(11 * time.Minute / time.Second),
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("report() mismatch (-want +got):\n%s", diff)
+	}
+	if got := ut.report([]string{"other@golang.org"}, time.Time{}); len(got) != 0 {
+		t.Errorf("report() of another user = %v; want none", got)
+	}
+	if got := ut.report([]string{"user@golang.org"}, now.Add(time.Minute)); len(got) != 0 {
+		t.Errorf("report() since after the instances ended = %v; want none", got)
+	}
+}
@@ -0,0 +1,374 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package gomote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/internal"
+	"golang.org/x/build/internal/access"
+	"golang.org/x/build/internal/gomote/protos"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxWarmPoolSize is the largest number of instances a warm pool keeps ready.
+	maxWarmPoolSize = 4
+	// maxWarmPoolsPerOwner is the largest number of warm pools a user can have.
+	maxWarmPoolsPerOwner = 4
+	// warmInstanceMaxAge is how long an instance is kept ready before it's
+	// replaced by a new one.
+	warmInstanceMaxAge = time.Hour
+	// warmPoolIdleTimeout is how long a warm pool is kept after it was last
+	// set or taken from.
+	warmPoolIdleTimeout = 24 * time.Hour
+	// warmPoolRetryDelay is how long a warm pool waits to start an instance
+	// after it failed to.
+	warmPoolRetryDelay = time.Minute
+	// warmPoolMaintainInterval is how often warm pools are refilled and
+	// their old instances replaced.
+	warmPoolMaintainInterval = time.Minute
+)
+
+// errTooManyWarmPools is returned when a user would have more than
+// maxWarmPoolsPerOwner warm pools.
+var errTooManyWarmPools = errors.New("too many warm pools")
+
+// warmPoolKey identifies a warm pool.
+type warmPoolKey struct {
+	ownerID     string
+	builderType string
+	snapshot    string // restored on the instances, if set
+}
+
+// warmInstance is an instance kept ready in a warm pool.
+type warmInstance struct {
+	bc      buildlet.Client
+	taskID  string
+	started time.Time
+	// release releases the instance's place against its owner's quota.
+	release func()
+}
+
+// warmPool is a set of instances kept ready for a user.
+type warmPool struct {
+	email    string // of the owner
+	size     int
+	ready    []*warmInstance
+	starting int       // number of instances being started
+	lastErr  error     // error from the last start, if it failed
+	lastFail time.Time // when the last start failed
+	lastUsed time.Time // when the pool was last set or taken from
+}
+
+// startWarmInstanceFunc starts an instance for a warm pool of the user
+// with the given email address.
+type startWarmInstanceFunc func(ctx context.Context, key warmPoolKey, email string) (*warmInstance, error)
+
+// warmPools keeps instances ready for users, so that creating one of a
+// builder type they often use doesn't have to wait for a new swarming
+// task to start.
+type warmPools struct {
+	ctx   context.Context
+	start startWarmInstanceFunc
+
+	mu    sync.Mutex
+	pools map[warmPoolKey]*warmPool
+}
+
+// newWarmPools returns an empty set of warm pools, which uses start to
+// start their instances. The pools are maintained until ctx is done.
+func newWarmPools(ctx context.Context, start startWarmInstanceFunc) *warmPools {
+	wp := &warmPools{
+		ctx:   ctx,
+		start: start,
+		pools: make(map[warmPoolKey]*warmPool),
+	}
+	go internal.PeriodicallyDo(ctx, warmPoolMaintainInterval, func(ctx context.Context, t time.Time) {
+		wp.maintain(t)
+	})
+	return wp
+}
+
+// set sets the size of a warm pool, creating or removing it as needed,
+// and starts filling it.
+func (wp *warmPools) set(key warmPoolKey, email string, size int) (*protos.WarmPool, error) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	p, ok := wp.pools[key]
+	if size == 0 {
+		if ok {
+			delete(wp.pools, key)
+			closeWarmInstances(p.ready)
+		}
+		return &protos.WarmPool{BuilderType: key.builderType, Snapshot: key.snapshot}, nil
+	}
+	if !ok {
+		n := 0
+		for k := range wp.pools {
+			if k.ownerID == key.ownerID {
+				n++
+			}
+		}
+		if n >= maxWarmPoolsPerOwner {
+			return nil, errTooManyWarmPools
+		}
+		p = &warmPool{email: email}
+		wp.pools[key] = p
+	}
+	p.size = size
+	p.lastUsed = time.Now()
+	p.lastErr = nil
+	if len(p.ready) > size {
+		closeWarmInstances(p.ready[size:])
+		p.ready = p.ready[:size]
+	}
+	wp.fill(key, p)
+	return p.proto(key), nil
+}
+
+// take removes a ready instance from a warm pool, and starts replacing
+// it. It returns nil if there's no such pool or it has no ready instance.
+// The instance's place against the quota must be released once it's
+// been added to the session pool.
+func (wp *warmPools) take(key warmPoolKey) *warmInstance {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	p, ok := wp.pools[key]
+	if !ok {
+		return nil
+	}
+	p.lastUsed = time.Now()
+	var wi *warmInstance
+	if len(p.ready) > 0 {
+		wi = p.ready[0]
+		p.ready = p.ready[1:]
+	}
+	wp.fill(key, p)
+	return wi
+}
+
+// list returns a user's warm pools, sorted by builder type.
+func (wp *warmPools) list(ownerID string) []*protos.WarmPool {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	var pools []*protos.WarmPool
+	for key, p := range wp.pools {
+		if key.ownerID == ownerID {
+			pools = append(pools, p.proto(key))
+		}
+	}
+	sort.Slice(pools, func(i, j int) bool {
+		if pools[i].GetBuilderType() != pools[j].GetBuilderType() {
+			return pools[i].GetBuilderType() < pools[j].GetBuilderType()
+		}
+		return pools[i].GetSnapshot() < pools[j].GetSnapshot()
+	})
+	return pools
+}
+
+// replaceSnapshot replaces the ready instances of a user's warm pools
+// that restore a snapshot, after it changed.
+func (wp *warmPools) replaceSnapshot(ownerID, snapshot string) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	for key, p := range wp.pools {
+		if key.ownerID == ownerID && key.snapshot == snapshot {
+			closeWarmInstances(p.ready)
+			p.ready = nil
+			p.lastErr = nil
+			wp.fill(key, p)
+		}
+	}
+}
+
+// maintain removes the warm pools that haven't been used for a while,
+// replaces the instances that have been ready for too long, and refills
+// the pools.
+func (wp *warmPools) maintain(now time.Time) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	for key, p := range wp.pools {
+		if now.Sub(p.lastUsed) > warmPoolIdleTimeout {
+			log.Printf("warm pool %s for %s: removing unused pool", key.builderType, p.email)
+			delete(wp.pools, key)
+			closeWarmInstances(p.ready)
+			continue
+		}
+		var fresh []*warmInstance
+		for _, wi := range p.ready {
+			if now.Sub(wi.started) > warmInstanceMaxAge {
+				closeWarmInstances([]*warmInstance{wi})
+			} else {
+				fresh = append(fresh, wi)
+			}
+		}
+		p.ready = fresh
+		if p.lastErr != nil && now.Sub(p.lastFail) < warmPoolRetryDelay {
+			continue
+		}
+		wp.fill(key, p)
+	}
+}
+
+// fill starts as many instances as a warm pool is missing.
+// wp.mu must be held.
+func (wp *warmPools) fill(key warmPoolKey, p *warmPool) {
+	for len(p.ready)+p.starting < p.size {
+		p.starting++
+		go wp.startInstance(key, p)
+	}
+}
+
+// startInstance starts an instance for warm pool p, and adds it to the
+// pool if it's still wanted.
+func (wp *warmPools) startInstance(key warmPoolKey, p *warmPool) {
+	wi, err := wp.start(wp.ctx, key, p.email)
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	p.starting--
+	if err != nil {
+		log.Printf("warm pool %s for %s: unable to start instance: %s", key.builderType, p.email, err)
+		p.lastErr = err
+		p.lastFail = time.Now()
+		return
+	}
+	p.lastErr = nil
+	if wp.pools[key] != p || len(p.ready) >= p.size {
+		closeWarmInstances([]*warmInstance{wi})
+		return
+	}
+	wi.started = time.Now()
+	p.ready = append(p.ready, wi)
+}
+
+// proto returns the description of warm pool p.
+func (p *warmPool) proto(key warmPoolKey) *protos.WarmPool {
+	wp := &protos.WarmPool{
+		BuilderType: key.builderType,
+		Snapshot:    key.snapshot,
+		Size:        int32(p.size),
+		Ready:       int32(len(p.ready)),
+	}
+	if p.lastErr != nil {
+		wp.LastError = p.lastErr.Error()
+	}
+	return wp
+}
+
+// closeWarmInstances destroys instances in the background.
+func closeWarmInstances(wis []*warmInstance) {
+	for _, wi := range wis {
+		wi.release()
+		go func() {
+			if err := wi.bc.Close(); err != nil {
+				log.Printf("warm pool: unable to close buildlet %s: %s", wi.taskID, err)
+			}
+		}()
+	}
+}
+
+// SetWarmPool sets how many instances of a builder type, optionally created from a snapshot, are kept ready for
+// the caller.
+func (ss *SwarmingServer) SetWarmPool(ctx context.Context, req *protos.SetWarmPoolRequest) (*protos.SetWarmPoolResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("SetWarmPool access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	if req.GetSize() < 0 || req.GetSize() > maxWarmPoolSize {
+		return nil, status.Errorf(codes.InvalidArgument, "warm pool size must be between 0 and %d", maxWarmPoolSize)
+	}
+	if req.GetBuilderType() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid builder type")
+	}
+	if _, err := emailToUser(creds.Email); err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid user email format")
+	}
+	if req.GetSize() > 0 {
+		if _, err := ss.builderStarter(ctx, req.GetBuilderType()); err != nil {
+			return nil, err
+		}
+		if req.GetSnapshot() != "" {
+			snap, _, err := ss.snapshot(ctx, creds.ID, req.GetSnapshot())
+			if err != nil {
+				return nil, err
+			}
+			if snap.GetBuilderType() != req.GetBuilderType() {
+				return nil, status.Errorf(codes.FailedPrecondition, "snapshot %q was taken on builder type %s", req.GetSnapshot(), snap.GetBuilderType())
+			}
+		}
+	}
+	key := warmPoolKey{ownerID: creds.ID, builderType: req.GetBuilderType(), snapshot: req.GetSnapshot()}
+	pool, err := ss.warmPools.set(key, creds.Email, int(req.GetSize()))
+	if errors.Is(err, errTooManyWarmPools) {
+		return nil, status.Errorf(codes.ResourceExhausted, "no more than %d warm pools are allowed", maxWarmPoolsPerOwner)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to set warm pool: %s", err)
+	}
+	return &protos.SetWarmPoolResponse{WarmPool: pool}, nil
+}
+
+// ListWarmPools lists the caller's warm pools.
+func (ss *SwarmingServer) ListWarmPools(ctx context.Context, req *protos.ListWarmPoolsRequest) (*protos.ListWarmPoolsResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("ListWarmPools access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	return &protos.ListWarmPoolsResponse{WarmPools: ss.warmPools.list(creds.ID)}, nil
+}
+
+// startWarmInstance starts an instance for a warm pool, restoring its snapshot if it has one. The instance
+// holds a place against its owner's quota.
+func (ss *SwarmingServer) startWarmInstance(ctx context.Context, key warmPoolKey, email string) (wi *warmInstance, err error) {
+	userName, err := emailToUser(email)
+	if err != nil {
+		return nil, err
+	}
+	starter, err := ss.builderStarter(ctx, key.builderType)
+	if err != nil {
+		return nil, err
+	}
+	var snap *protos.Snapshot
+	var snapObject string
+	if key.snapshot != "" {
+		if snap, snapObject, err = ss.snapshots.Get(ctx, key.ownerID, key.snapshot); err != nil {
+			return nil, fmt.Errorf("unable to read snapshot %q: %w", key.snapshot, err)
+		}
+	}
+	release, err := ss.usage.reserve(email, starter.hostType)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			release()
+		}
+	}()
+	name := fmt.Sprintf("gomote-%s-%s", userName, uuid.NewString())
+	bc, taskID, err := starter.start(ctx, name, true)
+	if err != nil {
+		return nil, err
+	}
+	if snap != nil {
+		if err := ss.restoreSnapshot(ctx, bc, snap, snapObject); err != nil {
+			bc.Close()
+			return nil, fmt.Errorf("unable to restore snapshot %q: %w", key.snapshot, err)
+		}
+	}
+	return &warmInstance{bc: bc, taskID: taskID, release: release}, nil
+}
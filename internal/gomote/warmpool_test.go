@@ -0,0 +1,111 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package gomote
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/build/buildlet"
+)
+
+// newTestWarmPools returns warm pools whose instances are fake buildlet
+// clients, and a count of the instances started.
+func newTestWarmPools(t *testing.T) (*warmPools, *atomic.Int32) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	var started atomic.Int32
+	wp := newWarmPools(ctx, func(ctx context.Context, key warmPoolKey, email string) (*warmInstance, error) {
+		started.Add(1)
+		return &warmInstance{bc: &buildlet.FakeClient{}, taskID: "task", release: func() {}}, nil
+	})
+	return wp, &started
+}
+
+// waitReady waits for a warm pool to have n ready instances.
+func waitReady(t *testing.T, wp *warmPools, ownerID string, n int32) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		pools := wp.list(ownerID)
+		if len(pools) == 1 && pools[0].GetReady() == n {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("warm pool of %s never had %d ready instances: %v", ownerID, n, wp.list(ownerID))
+}
+
+func TestWarmPoolFillAndTake(t *testing.T) {
+	wp, started := newTestWarmPools(t)
+	key := warmPoolKey{ownerID: "owner", builderType: "gotip-linux-amd64"}
+	if wi := wp.take(key); wi != nil {
+		t.Fatalf("take() = %v before the pool exists; want nil", wi)
+	}
+	if _, err := wp.set(key, "user@example.com", 2); err != nil {
+		t.Fatalf("set() = %s; want no error", err)
+	}
+	waitReady(t, wp, "owner", 2)
+	if wi := wp.take(key); wi == nil {
+		t.Fatal("take() = nil; want an instance")
+	}
+	// The pool is refilled after an instance was taken.
+	waitReady(t, wp, "owner", 2)
+	if got := started.Load(); got != 3 {
+		t.Errorf("started %d instances; want 3", got)
+	}
+	if _, err := wp.set(key, "user@example.com", 0); err != nil {
+		t.Fatalf("set(0) = %s; want no error", err)
+	}
+	if pools := wp.list("owner"); len(pools) != 0 {
+		t.Errorf("list() = %v after removing the pool; want none", pools)
+	}
+}
+
+func TestWarmPoolTooMany(t *testing.T) {
+	wp, _ := newTestWarmPools(t)
+	for i := range maxWarmPoolsPerOwner {
+		key := warmPoolKey{ownerID: "owner", builderType: "gotip-linux-amd64", snapshot: string(rune('a' + i))}
+		if _, err := wp.set(key, "user@example.com", 1); err != nil {
+			t.Fatalf("set(%v) = %s; want no error", key, err)
+		}
+	}
+	key := warmPoolKey{ownerID: "owner", builderType: "gotip-linux-arm64"}
+	if _, err := wp.set(key, "user@example.com", 1); !errors.Is(err, errTooManyWarmPools) {
+		t.Errorf("set() = %v; want %v", err, errTooManyWarmPools)
+	}
+	// Other users aren't limited by it.
+	key.ownerID = "other"
+	if _, err := wp.set(key, "user@example.com", 1); err != nil {
+		t.Errorf("set(%v) = %s; want no error", key, err)
+	}
+}
+
+func TestWarmPoolMaintain(t *testing.T) {
+	wp, started := newTestWarmPools(t)
+	key := warmPoolKey{ownerID: "owner", builderType: "gotip-linux-amd64"}
+	if _, err := wp.set(key, "user@example.com", 1); err != nil {
+		t.Fatalf("set() = %s; want no error", err)
+	}
+	waitReady(t, wp, "owner", 1)
+
+	// Old instances are replaced.
+	wp.maintain(time.Now().Add(warmInstanceMaxAge + time.Minute))
+	waitReady(t, wp, "owner", 1)
+	if got := started.Load(); got != 2 {
+		t.Errorf("started %d instances; want 2", got)
+	}
+
+	// Unused pools are removed.
+	wp.maintain(time.Now().Add(warmPoolIdleTimeout + time.Minute))
+	if pools := wp.list("owner"); len(pools) != 0 {
+		t.Errorf("list() = %v after the pool was idle; want none", pools)
+	}
+}
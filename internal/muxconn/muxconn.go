@@ -0,0 +1,748 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package muxconn multiplexes streams over a single long-lived
+// connection, and keeps them alive across reconnections.
+//
+// It's the transport between reverse buildlets and the coordinator or
+// the gomote server. Unlike revdial, which dials a new connection for
+// each request, all of a buildlet's HTTP requests (exec, file transfer,
+// heartbeats and port proxying) are carried by streams of one Session.
+// Each stream has its own flow control, so a slow reader of one stream
+// doesn't stall the others.
+//
+// When the connection breaks, the side that dialed it, which is the
+// buildlet, redials and resumes the session: both sides retransmit the
+// frames the other didn't receive, so streams, and the commands whose
+// output they carry, survive a flaky network. A session that isn't
+// resumed within Config.ResumeTimeout is closed.
+//
+// Each frame is a one-byte frame type, a four-byte big-endian stream ID,
+// a four-byte big-endian payload length and the payload. The frames that
+// belong to streams are numbered implicitly, in the order they're sent,
+// and acknowledged by periodic ack frames, which also serve as
+// heartbeats. A connection starts with the client sending a hello frame
+// and the server replying with one.
+package muxconn
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Header is the HTTP header with which a buildlet asks to use a
+// session, when registering with the coordinator or the gomote server,
+// and with which the server agrees to. Its value is "1" for a new
+// session, and "resume" when the buildlet reconnects to resume one.
+const Header = "X-Buildlet-Mux"
+
+// Config configures a Session. The zero value, or a nil *Config, uses
+// the defaults.
+type Config struct {
+	// KeepAlive is how often each side sends an ack frame. A
+	// connection on which nothing is received for three times as
+	// long is considered broken. The default is 10 seconds.
+	KeepAlive time.Duration
+
+	// ResumeTimeout is how long a session waits to be resumed after
+	// its connection broke before it's closed. The default is 1 minute.
+	ResumeTimeout time.Duration
+
+	// Window is the number of bytes each side of a stream may send
+	// before the other side reads them. The default is 256 KiB.
+	Window int
+}
+
+func (c *Config) keepAlive() time.Duration {
+	if c == nil || c.KeepAlive == 0 {
+		return 10 * time.Second
+	}
+	return c.KeepAlive
+}
+
+func (c *Config) resumeTimeout() time.Duration {
+	if c == nil || c.ResumeTimeout == 0 {
+		return time.Minute
+	}
+	return c.ResumeTimeout
+}
+
+func (c *Config) window() int {
+	if c == nil || c.Window == 0 {
+		return 256 << 10
+	}
+	return c.Window
+}
+
+// A frameType is the type of a frame.
+type frameType byte
+
+const (
+	frameHello  frameType = 1 // payload is a JSON hello; not numbered
+	frameAck    frameType = 2 // payload is the big-endian uint64 number of the last frame received; not numbered
+	frameOpen   frameType = 3 // opens a stream; empty payload
+	frameData   frameType = 4 // payload is data for a stream
+	frameWindow frameType = 5 // payload is a big-endian uint32 increment of the stream's window
+	frameFin    frameType = 6 // the sender won't send more data on the stream; empty payload
+	frameReset  frameType = 7 // the stream was aborted; empty payload
+	frameGoAway frameType = 8 // the session is closed; empty payload
+)
+
+// numbered reports whether frames of type t are retransmitted after a
+// reconnection.
+func (t frameType) numbered() bool {
+	return t >= frameOpen
+}
+
+const (
+	maxFramePayload  = 64 << 10
+	maxDataPayload   = 32 << 10
+	handshakeTimeout = 30 * time.Second
+	writeTimeout     = 30 * time.Second
+	ackEvery         = 64 // numbered frames
+	acceptBacklog    = 128
+)
+
+// A frame is a numbered frame that may need to be retransmitted.
+type frame struct {
+	seq     uint64
+	typ     frameType
+	id      uint32
+	payload []byte
+}
+
+func writeFrame(w io.Writer, typ frameType, id uint32, payload []byte) error {
+	buf := make([]byte, 9+len(payload))
+	buf[0] = byte(typ)
+	binary.BigEndian.PutUint32(buf[1:5], id)
+	binary.BigEndian.PutUint32(buf[5:9], uint32(len(payload)))
+	copy(buf[9:], payload)
+	_, err := w.Write(buf)
+	return err
+}
+
+func readFrame(r io.Reader) (typ frameType, id uint32, payload []byte, err error) {
+	var hdr [9]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return 0, 0, nil, err
+	}
+	n := binary.BigEndian.Uint32(hdr[5:9])
+	if n > maxFramePayload {
+		return 0, 0, nil, fmt.Errorf("muxconn: frame payload of %d bytes is too large", n)
+	}
+	payload = make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, 0, nil, err
+	}
+	return frameType(hdr[0]), binary.BigEndian.Uint32(hdr[1:5]), payload, nil
+}
+
+// A hello is the payload of the hello frames that start a connection.
+type hello struct {
+	Session string `json:"session,omitempty"` // empty for a new session
+	Secret  string `json:"secret,omitempty"`  // proves the client owns the session
+	Recv    uint64 `json:"recv"`              // number of the last frame received
+	Error   string `json:"error,omitempty"`   // why the server refused the session
+}
+
+func writeHello(w io.Writer, h hello) error {
+	b, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	return writeFrame(w, frameHello, 0, b)
+}
+
+func readHello(r io.Reader) (hello, error) {
+	typ, _, payload, err := readFrame(r)
+	if err != nil {
+		return hello{}, err
+	}
+	if typ != frameHello {
+		return hello{}, fmt.Errorf("muxconn: got frame of type %d; want hello", typ)
+	}
+	var h hello
+	if err := json.Unmarshal(payload, &h); err != nil {
+		return hello{}, fmt.Errorf("muxconn: bad hello: %w", err)
+	}
+	return h, nil
+}
+
+var (
+	// ErrClosed is returned by the methods of a closed Session, and
+	// of its streams.
+	ErrClosed = errors.New("muxconn: session closed")
+
+	errResumeTimeout = errors.New("muxconn: session not resumed in time")
+	errReset         = errors.New("muxconn: stream reset by peer")
+	errPeerClosed    = errors.New("muxconn: session closed by peer")
+)
+
+// A Session carries streams over a connection that it replaces when it
+// breaks. It implements net.Listener, accepting the streams the other
+// side opens.
+type Session struct {
+	id     string
+	secret string
+	cfg    *Config
+	redial func(context.Context) (net.Conn, error) // nil on the server side
+
+	recvSeq  atomic.Uint64 // number of the last numbered frame received
+	lastRecv atomic.Int64  // when a frame was last received, in Unix nanoseconds
+	ackc     chan struct{} // asks keepAlive to send an ack
+
+	// wmu guards the connection and the frames sent on it.
+	wmu        sync.Mutex
+	conn       net.Conn      // nil while disconnected
+	lastAddr   net.Addr      // remote address of the last connection
+	readerDone chan struct{} // closed when the reader of conn is done
+	gen        int           // incremented when a connection is attached
+	sendSeq    uint64        // number of the last numbered frame sent
+	unacked    []frame       // numbered frames the peer hasn't acknowledged
+
+	mu      sync.Mutex
+	streams map[uint32]*Stream
+	nextID  uint32
+
+	acceptc   chan *Stream
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error // valid after done is closed
+}
+
+func newSession(cfg *Config, redial func(context.Context) (net.Conn, error)) *Session {
+	s := &Session{
+		cfg:     cfg,
+		redial:  redial,
+		ackc:    make(chan struct{}, 1),
+		streams: make(map[uint32]*Stream),
+		nextID:  2,
+		acceptc: make(chan *Stream, acceptBacklog),
+		done:    make(chan struct{}),
+	}
+	if redial != nil {
+		// The client opens streams with odd IDs, and the server
+		// with even ones.
+		s.nextID = 1
+	}
+	go s.keepAlive()
+	return s
+}
+
+var (
+	sessionsMu sync.Mutex
+	sessions   = map[string]*Session{} // server sessions by ID
+)
+
+// Client starts a session over conn, a new connection to a server.
+// When the connection breaks, redial is called to make a new one over
+// which the session is resumed, until ResumeTimeout has passed.
+func Client(conn net.Conn, redial func(context.Context) (net.Conn, error), cfg *Config) (*Session, error) {
+	s := newSession(cfg, redial)
+	if err := s.clientHandshake(conn); err != nil {
+		s.closeWithErr(err)
+		return nil, err
+	}
+	return s, nil
+}
+
+// Server accepts a connection from a client, which is either for a new
+// session or to resume one. In the latter case, resumed is true and the
+// caller has nothing more to do: the session goes on over conn.
+// Server closes conn if it returns an error.
+func Server(conn net.Conn, cfg *Config) (s *Session, resumed bool, err error) {
+	conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	br := bufio.NewReader(conn)
+	h, err := readHello(br)
+	if err != nil {
+		conn.Close()
+		return nil, false, err
+	}
+	conn.SetDeadline(time.Time{})
+
+	if h.Session == "" {
+		s = newSession(cfg, nil)
+		s.id, s.secret = randomID(), randomID()
+		sessionsMu.Lock()
+		sessions[s.id] = s
+		sessionsMu.Unlock()
+		if err := s.attach(conn, br, 0, &hello{Session: s.id, Secret: s.secret}); err != nil {
+			s.closeWithErr(err)
+			return nil, false, err
+		}
+		return s, false, nil
+	}
+
+	sessionsMu.Lock()
+	s = sessions[h.Session]
+	sessionsMu.Unlock()
+	if s == nil || subtle.ConstantTimeCompare([]byte(s.secret), []byte(h.Secret)) != 1 {
+		writeHello(conn, hello{Error: "unknown session"})
+		conn.Close()
+		return nil, false, fmt.Errorf("muxconn: can't resume unknown session %q", h.Session)
+	}
+	// The old connection may not look broken yet. Stop reading from
+	// it so that the number of the last frame received is final.
+	s.detach()
+	if err := s.attach(conn, br, h.Recv, &hello{Recv: s.recvSeq.Load()}); err != nil {
+		// Wait for another attempt.
+		s.lost(s.currentGen())
+		return nil, false, err
+	}
+	log.Printf("muxconn: resumed session %s from %s", s.id, conn.RemoteAddr())
+	return s, true, nil
+}
+
+func randomID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// clientHandshake starts or resumes the session over conn.
+func (s *Session) clientHandshake(conn net.Conn) error {
+	conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	err := writeHello(conn, hello{Session: s.id, Secret: s.secret, Recv: s.recvSeq.Load()})
+	br := bufio.NewReader(conn)
+	var h hello
+	if err == nil {
+		h, err = readHello(br)
+	}
+	if err == nil && h.Error != "" {
+		err = fmt.Errorf("muxconn: server refused session: %s", h.Error)
+	}
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	conn.SetDeadline(time.Time{})
+	if s.id == "" {
+		s.id, s.secret = h.Session, h.Secret
+	}
+	return s.attach(conn, br, h.Recv, nil)
+}
+
+// attach starts using conn, after writing reply if it's non-nil and
+// retransmitting the frames after peerRecv.
+func (s *Session) attach(conn net.Conn, br *bufio.Reader, peerRecv uint64, reply *hello) error {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	select {
+	case <-s.done:
+		conn.Close()
+		return ErrClosed
+	default:
+	}
+	s.ackedLocked(peerRecv)
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	var err error
+	if reply != nil {
+		err = writeHello(conn, *reply)
+	}
+	for _, f := range s.unacked {
+		if err != nil {
+			break
+		}
+		err = writeFrame(conn, f.typ, f.id, f.payload)
+	}
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	s.conn = conn
+	s.lastAddr = conn.RemoteAddr()
+	s.gen++
+	s.readerDone = make(chan struct{})
+	s.lastRecv.Store(time.Now().UnixNano())
+	go s.readLoop(conn, br, s.readerDone)
+	return nil
+}
+
+// detach stops using the current connection, if any, and waits for its
+// reader to be done.
+func (s *Session) detach() {
+	s.wmu.Lock()
+	conn, done := s.conn, s.readerDone
+	s.conn = nil
+	s.wmu.Unlock()
+	if conn != nil {
+		conn.Close()
+	}
+	if done != nil {
+		<-done
+	}
+}
+
+func (s *Session) currentGen() int {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	return s.gen
+}
+
+// connLost handles the breakage of conn.
+func (s *Session) connLost(conn net.Conn, err error) {
+	s.wmu.Lock()
+	if s.conn != conn {
+		s.wmu.Unlock()
+		return
+	}
+	s.conn = nil
+	gen := s.gen
+	s.wmu.Unlock()
+	conn.Close()
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+	log.Printf("muxconn: connection of session %s to %s broke: %v", s.id, conn.RemoteAddr(), err)
+	s.lost(gen)
+}
+
+// lost starts resuming the session after the connection of generation
+// gen was lost. The client redials; the server waits for it to.
+func (s *Session) lost(gen int) {
+	if s.redial != nil {
+		go s.reconnect(gen)
+		return
+	}
+	time.AfterFunc(s.cfg.resumeTimeout(), func() {
+		s.wmu.Lock()
+		resumed := s.gen != gen || s.conn != nil
+		s.wmu.Unlock()
+		if !resumed {
+			s.closeWithErr(errResumeTimeout)
+		}
+	})
+}
+
+// reconnect redials until the session is resumed or ResumeTimeout has
+// passed.
+func (s *Session) reconnect(gen int) {
+	s.detach()
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.resumeTimeout())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	delay := 100 * time.Millisecond
+	for {
+		if s.currentGen() != gen {
+			return // resumed by another attempt
+		}
+		conn, err := s.redial(ctx)
+		if err == nil {
+			if err = s.clientHandshake(conn); err == nil {
+				log.Printf("muxconn: resumed session %s", s.id)
+				return
+			}
+		}
+		log.Printf("muxconn: resuming session %s: %v", s.id, err)
+		select {
+		case <-ctx.Done():
+			s.closeWithErr(errResumeTimeout)
+			return
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, 5*time.Second)
+	}
+}
+
+// readLoop reads and handles frames from conn until it breaks.
+func (s *Session) readLoop(conn net.Conn, br *bufio.Reader, done chan struct{}) {
+	defer close(done)
+	unacked := 0
+	for {
+		typ, id, payload, err := readFrame(br)
+		if err != nil {
+			s.connLost(conn, err)
+			return
+		}
+		s.lastRecv.Store(time.Now().UnixNano())
+		if err := s.handle(typ, id, payload); err != nil {
+			log.Printf("muxconn: session %s: %v", s.id, err)
+			s.closeWithErr(err)
+			return
+		}
+		if typ.numbered() {
+			s.recvSeq.Add(1)
+			if unacked++; unacked >= ackEvery {
+				unacked = 0
+				select {
+				case s.ackc <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// handle handles a frame received from the peer. It must not block on
+// sending frames, so that both sides keep reading.
+func (s *Session) handle(typ frameType, id uint32, payload []byte) error {
+	switch typ {
+	case frameAck:
+		if len(payload) != 8 {
+			return errors.New("bad ack frame")
+		}
+		s.wmu.Lock()
+		s.ackedLocked(binary.BigEndian.Uint64(payload))
+		s.wmu.Unlock()
+		return nil
+	case frameGoAway:
+		s.closeWithErr(errPeerClosed)
+		return nil
+	case frameOpen:
+		s.mu.Lock()
+		if _, ok := s.streams[id]; ok {
+			s.mu.Unlock()
+			return fmt.Errorf("stream %d opened twice", id)
+		}
+		st := s.newStreamLocked(id)
+		s.mu.Unlock()
+		select {
+		case s.acceptc <- st:
+		default:
+			st.setReset()
+			go s.send(frameReset, id, nil)
+		}
+		return nil
+	}
+
+	s.mu.Lock()
+	st := s.streams[id]
+	s.mu.Unlock()
+	if st == nil {
+		if typ == frameData || typ == frameFin {
+			go s.send(frameReset, id, nil)
+		}
+		return nil
+	}
+	switch typ {
+	case frameData:
+		return st.received(payload)
+	case frameWindow:
+		if len(payload) != 4 {
+			return errors.New("bad window frame")
+		}
+		st.addWindow(int(binary.BigEndian.Uint32(payload)))
+	case frameFin:
+		st.receivedFin()
+	case frameReset:
+		st.setReset()
+	default:
+		return fmt.Errorf("unknown frame type %d", typ)
+	}
+	return nil
+}
+
+// ackedLocked forgets the frames the peer received, up to number seq.
+func (s *Session) ackedLocked(seq uint64) {
+	i := 0
+	for i < len(s.unacked) && s.unacked[i].seq <= seq {
+		i++
+	}
+	if i == len(s.unacked) {
+		s.unacked = nil
+	} else {
+		s.unacked = s.unacked[i:]
+	}
+}
+
+// send sends a numbered frame, or queues it to be sent once the session
+// is resumed.
+func (s *Session) send(typ frameType, id uint32, payload []byte) error {
+	s.wmu.Lock()
+	select {
+	case <-s.done:
+		s.wmu.Unlock()
+		return s.err
+	default:
+	}
+	s.sendSeq++
+	s.unacked = append(s.unacked, frame{seq: s.sendSeq, typ: typ, id: id, payload: payload})
+	conn := s.conn
+	var err error
+	if conn != nil {
+		conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		err = writeFrame(conn, typ, id, payload)
+	}
+	s.wmu.Unlock()
+	if err != nil {
+		s.connLost(conn, err)
+	}
+	return nil
+}
+
+// sendAck sends an ack frame, if connected.
+func (s *Session) sendAck() {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], s.recvSeq.Load())
+	s.wmu.Lock()
+	conn := s.conn
+	var err error
+	if conn != nil {
+		conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		err = writeFrame(conn, frameAck, 0, b[:])
+	}
+	s.wmu.Unlock()
+	if err != nil {
+		s.connLost(conn, err)
+	}
+}
+
+// keepAlive sends ack frames, and notices when nothing was received
+// for too long.
+func (s *Session) keepAlive() {
+	interval := s.cfg.keepAlive()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ackc:
+			s.sendAck()
+		case <-t.C:
+			s.sendAck()
+			s.wmu.Lock()
+			conn := s.conn
+			s.wmu.Unlock()
+			if conn != nil && time.Since(time.Unix(0, s.lastRecv.Load())) > 3*interval {
+				s.connLost(conn, errors.New("keepalive timeout"))
+			}
+		}
+	}
+}
+
+// Dial opens a stream to the other side, which accepts it with Accept.
+// It has the signature of the dialers of buildlet clients.
+func (s *Session) Dial(ctx context.Context) (net.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	id := s.nextID
+	s.nextID += 2
+	st := s.newStreamLocked(id)
+	s.mu.Unlock()
+	if err := s.send(frameOpen, id, nil); err != nil {
+		s.removeStream(id)
+		return nil, err
+	}
+	return st, nil
+}
+
+// Accept waits for and returns the next stream the other side opens.
+func (s *Session) Accept() (net.Conn, error) {
+	select {
+	case st := <-s.acceptc:
+		return st, nil
+	case <-s.done:
+		return nil, s.err
+	}
+}
+
+// Addr returns the session's address, which identifies it.
+func (s *Session) Addr() net.Addr { return addr(s.id) }
+
+// RemoteAddr returns the remote address of the session's current or
+// last connection.
+func (s *Session) RemoteAddr() net.Addr {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	return s.lastAddr
+}
+
+// Done returns a channel that's closed when the session is closed, by
+// either side or because it wasn't resumed in time.
+func (s *Session) Done() <-chan struct{} { return s.done }
+
+// Close closes the session and all its streams.
+func (s *Session) Close() error {
+	s.wmu.Lock()
+	if conn := s.conn; conn != nil {
+		conn.SetWriteDeadline(time.Now().Add(time.Second))
+		writeFrame(conn, frameGoAway, 0, nil)
+	}
+	s.wmu.Unlock()
+	s.closeWithErr(ErrClosed)
+	return nil
+}
+
+func (s *Session) closeWithErr(err error) {
+	s.closeOnce.Do(func() {
+		s.wmu.Lock()
+		s.err = err
+		close(s.done)
+		if s.conn != nil {
+			s.conn.Close()
+			s.conn = nil
+		}
+		s.unacked = nil
+		s.wmu.Unlock()
+
+		if s.redial == nil {
+			sessionsMu.Lock()
+			if sessions[s.id] == s {
+				delete(sessions, s.id)
+			}
+			sessionsMu.Unlock()
+		}
+		s.mu.Lock()
+		streams := s.streams
+		s.streams = nil
+		s.mu.Unlock()
+		for _, st := range streams {
+			st.mu.Lock()
+			st.broadcastLocked()
+			st.mu.Unlock()
+		}
+	})
+}
+
+func (s *Session) newStreamLocked(id uint32) *Stream {
+	st := &Stream{
+		s:       s,
+		id:      id,
+		window:  s.cfg.window(),
+		changed: make(chan struct{}),
+	}
+	if s.streams != nil {
+		s.streams[id] = st
+	}
+	return st
+}
+
+func (s *Session) removeStream(id uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.streams, id)
+}
+
+// An addr is the address of a session, or of one of its streams.
+type addr string
+
+func (addr) Network() string  { return "muxconn" }
+func (a addr) String() string { return string(a) }
@@ -0,0 +1,379 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package muxconn
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testConfig = &Config{
+	KeepAlive:     50 * time.Millisecond,
+	ResumeTimeout: 5 * time.Second,
+	Window:        4 << 10,
+}
+
+// A testConn is a connection that can be made to silently drop what's
+// written to it, like a network that went away without closing
+// connections.
+type testConn struct {
+	net.Conn
+	blackhole atomic.Bool
+}
+
+func (c *testConn) Write(p []byte) (int, error) {
+	if c.blackhole.Load() {
+		return len(p), nil
+	}
+	return c.Conn.Write(p)
+}
+
+// A testNet connects a client session to a server session over TCP.
+type testNet struct {
+	addr     string
+	sessions chan *Session
+	failDial atomic.Bool
+
+	mu    sync.Mutex
+	conns []*testConn // both sides of all connections
+}
+
+func newTestNet(t *testing.T, cfg *Config) (*testNet, *Session, *Session) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	tn := &testNet{addr: ln.Addr().String(), sessions: make(chan *Session, 1)}
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				s, resumed, err := Server(tn.track(c), cfg)
+				if err == nil && !resumed {
+					tn.sessions <- s
+				}
+			}()
+		}
+	}()
+	conn, err := tn.dial(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := Client(conn, tn.dial, cfg)
+	if err != nil {
+		t.Fatalf("Client() = %v", err)
+	}
+	server := <-tn.sessions
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	return tn, client, server
+}
+
+func (tn *testNet) track(c net.Conn) *testConn {
+	tc := &testConn{Conn: c}
+	tn.mu.Lock()
+	defer tn.mu.Unlock()
+	tn.conns = append(tn.conns, tc)
+	return tc
+}
+
+func (tn *testNet) dial(ctx context.Context) (net.Conn, error) {
+	if tn.failDial.Load() {
+		return nil, errors.New("network is down")
+	}
+	var d net.Dialer
+	c, err := d.DialContext(ctx, "tcp", tn.addr)
+	if err != nil {
+		return nil, err
+	}
+	return tn.track(c), nil
+}
+
+// breakConns closes all connections.
+func (tn *testNet) breakConns() {
+	tn.mu.Lock()
+	defer tn.mu.Unlock()
+	for _, c := range tn.conns {
+		c.Close()
+	}
+}
+
+// blackholeConns makes all connections drop what's written to them.
+func (tn *testNet) blackholeConns() {
+	tn.mu.Lock()
+	defer tn.mu.Unlock()
+	for _, c := range tn.conns {
+		c.blackhole.Store(true)
+	}
+}
+
+// echo accepts streams and echoes what's written to them.
+func echo(s *Session) {
+	for {
+		c, err := s.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			io.Copy(c, c)
+			c.(*Stream).CloseWrite()
+		}()
+	}
+}
+
+// checkEcho writes data to a stream of s and checks that it's echoed.
+// If midway is non-nil, it's called after writing half of it.
+func checkEcho(t *testing.T, s *Session, data []byte, midway func()) {
+	t.Helper()
+	c, err := s.Dial(context.Background())
+	if err != nil {
+		t.Fatalf("Dial() = %v", err)
+	}
+	defer c.Close()
+	errc := make(chan error, 1)
+	go func() {
+		half := len(data) / 2
+		_, err := c.Write(data[:half])
+		if err == nil && midway != nil {
+			midway()
+		}
+		if err == nil {
+			_, err = c.Write(data[half:])
+		}
+		if err == nil {
+			err = c.(*Stream).CloseWrite()
+		}
+		errc <- err
+	}()
+	got, err := io.ReadAll(c)
+	if err != nil {
+		t.Fatalf("reading echo: %v", err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("writing: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("got %d bytes echoed, differing from the %d bytes written", len(got), len(data))
+	}
+}
+
+func randomData(n int) []byte {
+	b := make([]byte, n)
+	rand.Read(b)
+	return b
+}
+
+func TestStreams(t *testing.T) {
+	_, client, server := newTestNet(t, testConfig)
+	go echo(client)
+	go echo(server)
+
+	// Many concurrent streams in both directions, each writing more
+	// than its window.
+	var wg sync.WaitGroup
+	for i := range 20 {
+		s := client
+		if i%2 == 0 {
+			s = server
+		}
+		wg.Go(func() {
+			checkEcho(t, s, randomData(100<<10), nil)
+		})
+	}
+	wg.Wait()
+
+	// Closed streams are forgotten.
+	for _, s := range []*Session{client, server} {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			s.mu.Lock()
+			n := len(s.streams)
+			s.mu.Unlock()
+			if n == 0 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("session still has %d streams", n)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+func TestSlowReader(t *testing.T) {
+	_, client, server := newTestNet(t, testConfig)
+	go echo(server)
+
+	// A stream whose data isn't read doesn't stall the others.
+	stalled, err := server.Dial(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stalled.Close()
+	go stalled.Write(randomData(100 << 10))
+	c, err := client.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	checkEcho(t, client, randomData(100<<10), nil)
+}
+
+func TestHTTP(t *testing.T) {
+	_, client, server := newTestNet(t, testConfig)
+	// As with reverse buildlets, the client serves HTTP on the streams
+	// the server opens.
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// HTTP/1 handlers can't write while reading the request.
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body)
+	})}
+	go srv.Serve(client)
+	defer srv.Close()
+	hc := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return server.Dial(ctx)
+		},
+	}}
+	for i := range 10 {
+		data := randomData(i * 10 << 10)
+		res, err := hc.Post("http://buildlet/echo", "application/octet-stream", bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("Post() = %v", err)
+		}
+		got, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			t.Fatalf("reading response: %v", err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("got %d bytes; want %d", len(got), len(data))
+		}
+	}
+}
+
+func TestResume(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		break_ func(*testNet)
+	}{
+		{"closed", (*testNet).breakConns},
+		{"blackholed", (*testNet).blackholeConns},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			tn, client, server := newTestNet(t, testConfig)
+			go echo(server)
+			go echo(client)
+			checkEcho(t, client, randomData(64<<10), func() { tc.break_(tn) })
+			checkEcho(t, server, randomData(64<<10), func() { tc.break_(tn) })
+			select {
+			case <-client.Done():
+				t.Fatalf("client session closed: %v", client.err)
+			case <-server.Done():
+				t.Fatalf("server session closed: %v", server.err)
+			default:
+			}
+		})
+	}
+}
+
+func TestResumeTimeout(t *testing.T) {
+	cfg := *testConfig
+	cfg.ResumeTimeout = 200 * time.Millisecond
+	tn, client, server := newTestNet(t, &cfg)
+	c, err := client.Dial(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tn.failDial.Store(true)
+	tn.breakConns()
+	for _, s := range []*Session{client, server} {
+		select {
+		case <-s.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("session wasn't closed after failing to resume")
+		}
+	}
+	if _, err := c.Read(make([]byte, 1)); !errors.Is(err, errResumeTimeout) {
+		t.Errorf("Read() = %v; want %v", err, errResumeTimeout)
+	}
+	if _, err := server.Accept(); err == nil {
+		t.Error("Accept() on closed session = nil error; want error")
+	}
+}
+
+func TestClose(t *testing.T) {
+	_, client, server := newTestNet(t, testConfig)
+	server.Close()
+	select {
+	case <-client.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("client session wasn't closed after the server closed it")
+	}
+	if _, err := client.Dial(context.Background()); err == nil {
+		t.Error("Dial() on closed session = nil error; want error")
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	_, client, server := newTestNet(t, testConfig)
+	c, err := client.Dial(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc, err := server.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetReadDeadline(time.Now().Add(20 * time.Millisecond))
+	if _, err := c.Read(make([]byte, 1)); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("Read() = %v; want %v", err, os.ErrDeadlineExceeded)
+	}
+
+	// A deadline in the past interrupts a pending Read.
+	c.SetReadDeadline(time.Time{})
+	errc := make(chan error, 1)
+	go func() {
+		_, err := c.Read(make([]byte, 1))
+		errc <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	c.SetReadDeadline(time.Unix(1, 0))
+	if err := <-errc; !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("interrupted Read() = %v; want %v", err, os.ErrDeadlineExceeded)
+	}
+
+	// The stream still works afterwards.
+	c.SetReadDeadline(time.Time{})
+	fmt.Fprint(sc, "hello")
+	buf := make([]byte, 5)
+	if _, err := io.ReadFull(c, buf); err != nil || string(buf) != "hello" {
+		t.Fatalf("ReadFull() = %q, %v; want %q", buf, err, "hello")
+	}
+
+	// Writes time out while the window is full.
+	c.SetWriteDeadline(time.Now().Add(50 * time.Millisecond))
+	if _, err := c.Write(randomData(2 * testConfig.Window)); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("Write() = %v; want %v", err, os.ErrDeadlineExceeded)
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package muxconn
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// A Stream is a bidirectional stream of a Session. It implements
+// net.Conn.
+type Stream struct {
+	s  *Session
+	id uint32
+
+	wmu sync.Mutex // serializes Writes
+
+	mu        sync.Mutex
+	changed   chan struct{} // closed and replaced when the fields below change
+	buf       bytes.Buffer  // received data not read yet
+	consumed  int           // bytes read that the peer wasn't told about
+	window    int           // bytes that may be sent
+	finRecv   bool          // the peer won't send more
+	finSent   bool          // we won't send more
+	closed    bool          // Close was called
+	reset     bool          // the stream was aborted
+	rdeadline time.Time
+	wdeadline time.Time
+}
+
+var _ net.Conn = (*Stream)(nil)
+
+// broadcastLocked wakes up the Reads and Writes waiting for a change.
+func (st *Stream) broadcastLocked() {
+	close(st.changed)
+	st.changed = make(chan struct{})
+}
+
+// waitLocked waits, with st.mu held, for a change to the stream, for the
+// session to be closed, or for the deadline.
+func (st *Stream) waitLocked(deadline time.Time) {
+	changed := st.changed
+	st.mu.Unlock()
+	defer st.mu.Lock()
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		t := time.NewTimer(time.Until(deadline))
+		defer t.Stop()
+		timeout = t.C
+	}
+	select {
+	case <-changed:
+	case <-timeout:
+	case <-st.s.done:
+	}
+}
+
+// errLocked returns the error that ends the stream, if any.
+func (st *Stream) errLocked() error {
+	switch {
+	case st.closed:
+		return net.ErrClosed
+	case st.reset:
+		return errReset
+	}
+	select {
+	case <-st.s.done:
+		return st.s.err
+	default:
+	}
+	return nil
+}
+
+func deadlinePassed(t time.Time) bool {
+	return !t.IsZero() && !time.Now().Before(t)
+}
+
+// Read reads data sent by the other side of the stream.
+func (st *Stream) Read(p []byte) (int, error) {
+	st.mu.Lock()
+	for {
+		if st.buf.Len() > 0 {
+			n, _ := st.buf.Read(p)
+			st.consumed += n
+			var credit int
+			if st.consumed >= st.s.cfg.window()/4 && !st.finRecv {
+				credit, st.consumed = st.consumed, 0
+			}
+			st.mu.Unlock()
+			if credit > 0 {
+				var b [4]byte
+				binary.BigEndian.PutUint32(b[:], uint32(credit))
+				st.s.send(frameWindow, st.id, b[:])
+			}
+			return n, nil
+		}
+		if st.finRecv && !st.closed {
+			st.mu.Unlock()
+			return 0, io.EOF
+		}
+		if err := st.errLocked(); err != nil {
+			st.mu.Unlock()
+			return 0, err
+		}
+		if deadlinePassed(st.rdeadline) {
+			st.mu.Unlock()
+			return 0, os.ErrDeadlineExceeded
+		}
+		st.waitLocked(st.rdeadline)
+	}
+}
+
+// Write sends data to the other side of the stream, waiting for it to
+// read earlier data if its window is full.
+func (st *Stream) Write(p []byte) (n int, err error) {
+	st.wmu.Lock()
+	defer st.wmu.Unlock()
+	for len(p) > 0 {
+		st.mu.Lock()
+		for {
+			if st.finSent {
+				err = net.ErrClosed
+				if st.reset {
+					err = errReset
+				}
+			} else {
+				err = st.errLocked()
+			}
+			if err == nil && st.window == 0 && deadlinePassed(st.wdeadline) {
+				err = os.ErrDeadlineExceeded
+			}
+			if err != nil || st.window > 0 {
+				break
+			}
+			st.waitLocked(st.wdeadline)
+		}
+		if err != nil {
+			st.mu.Unlock()
+			return n, err
+		}
+		k := min(len(p), st.window, maxDataPayload)
+		st.window -= k
+		st.mu.Unlock()
+		if err := st.s.send(frameData, st.id, bytes.Clone(p[:k])); err != nil {
+			return n, err
+		}
+		n += k
+		p = p[k:]
+	}
+	return n, nil
+}
+
+// CloseWrite tells the other side that no more data will be sent, which
+// it reads as io.EOF.
+func (st *Stream) CloseWrite() error {
+	st.mu.Lock()
+	if st.finSent || st.reset {
+		st.mu.Unlock()
+		return nil
+	}
+	st.finSent = true
+	done := st.finRecv
+	st.broadcastLocked()
+	st.mu.Unlock()
+	err := st.s.send(frameFin, st.id, nil)
+	if done {
+		st.s.removeStream(st.id)
+	}
+	return err
+}
+
+// Close closes the stream. Data the other side sends afterwards is
+// discarded.
+func (st *Stream) Close() error {
+	st.mu.Lock()
+	if st.closed {
+		st.mu.Unlock()
+		return nil
+	}
+	st.closed = true
+	sendFin := !st.finSent && !st.reset
+	st.finSent = true
+	done := st.finRecv || st.reset
+	// Return the window to a peer that may be waiting for it.
+	var credit int
+	if !done {
+		credit = st.consumed + st.buf.Len()
+	}
+	st.buf.Reset()
+	st.consumed = 0
+	st.broadcastLocked()
+	st.mu.Unlock()
+	if credit > 0 {
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], uint32(credit))
+		st.s.send(frameWindow, st.id, b[:])
+	}
+	if sendFin {
+		st.s.send(frameFin, st.id, nil)
+	}
+	if done {
+		st.s.removeStream(st.id)
+	}
+	return nil
+}
+
+// received handles data from the peer.
+func (st *Stream) received(p []byte) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.closed || st.reset {
+		// Nobody will read it, but the peer may still be writing.
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], uint32(len(p)))
+		go st.s.send(frameWindow, st.id, b[:])
+		return nil
+	}
+	if st.finRecv {
+		return fmt.Errorf("data after end of stream %d", st.id)
+	}
+	if st.buf.Len()+st.consumed+len(p) > st.s.cfg.window() {
+		return fmt.Errorf("peer overflowed the window of stream %d", st.id)
+	}
+	st.buf.Write(p)
+	st.broadcastLocked()
+	return nil
+}
+
+func (st *Stream) addWindow(n int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.window += n
+	st.broadcastLocked()
+}
+
+func (st *Stream) receivedFin() {
+	st.mu.Lock()
+	st.finRecv = true
+	done := st.closed || st.finSent
+	st.broadcastLocked()
+	st.mu.Unlock()
+	if done {
+		st.s.removeStream(st.id)
+	}
+}
+
+func (st *Stream) setReset() {
+	st.mu.Lock()
+	st.reset = true
+	st.broadcastLocked()
+	st.mu.Unlock()
+	st.s.removeStream(st.id)
+}
+
+// LocalAddr returns the address of the stream.
+func (st *Stream) LocalAddr() net.Addr { return st.addr() }
+
+// RemoteAddr returns the address of the stream.
+func (st *Stream) RemoteAddr() net.Addr { return st.addr() }
+
+func (st *Stream) addr() net.Addr { return addr(fmt.Sprintf("%s/%d", st.s.id, st.id)) }
+
+// SetDeadline sets the read and write deadlines of the stream.
+func (st *Stream) SetDeadline(t time.Time) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.rdeadline, st.wdeadline = t, t
+	st.broadcastLocked()
+	return nil
+}
+
+// SetReadDeadline sets the deadline of Reads.
+func (st *Stream) SetReadDeadline(t time.Time) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.rdeadline = t
+	st.broadcastLocked()
+	return nil
+}
+
+// SetWriteDeadline sets the deadline of Writes waiting for the window
+// to open.
+func (st *Stream) SetWriteDeadline(t time.Time) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.wdeadline = t
+	st.broadcastLocked()
+	return nil
+}
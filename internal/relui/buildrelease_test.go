@@ -22,21 +22,28 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"cloud.google.com/go/cloudbuild/apiv1/v2/cloudbuildpb"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v74/github"
 	"github.com/google/uuid"
+	"github.com/shurcooL/githubv4"
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
 	"golang.org/x/build/gerrit"
 	"golang.org/x/build/internal"
 	"golang.org/x/build/internal/gcsfs"
 	"golang.org/x/build/internal/releasetargets"
+	"golang.org/x/build/internal/relui/sign"
 	"golang.org/x/build/internal/task"
 	"golang.org/x/build/internal/workflow"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestRelease(t *testing.T) {
@@ -142,7 +149,7 @@ func newReleaseTestDeps(t *testing.T, previousTag string, major int, wantVersion
 	// Set up the fake website to publish to.
 	var filesMu sync.Mutex
 	files := map[string]task.WebsiteFile{}
-	publishFile := func(f task.WebsiteFile) error {
+	publishFile := func(_ *workflow.TaskContext, f task.WebsiteFile) error {
 		filesMu.Lock()
 		defer filesMu.Unlock()
 		files[strings.TrimPrefix(f.Filename, wantVersion+".")] = f
@@ -219,8 +226,7 @@ func newReleaseTestDeps(t *testing.T, previousTag string, major int, wantVersion
 		SwarmingClient:           task.NewFakeSwarmingClient(t, fakeGo),
 		ApproveAction: func(ctx *workflow.TaskContext) error {
 			switch ctx.TaskName {
-			case "Confirm PRIVATE-track security CLs",
-				"Wait for Release Coordinator Approval":
+			case "Confirm PRIVATE-track security CLs":
 				return nil
 			default:
 				return fmt.Errorf("unexpected approval request for %q", ctx.TaskName)
@@ -244,6 +250,84 @@ func newReleaseTestDeps(t *testing.T, previousTag string, major int, wantVersion
 	}
 }
 
+// TestReleaseDryRun runs a minor release with its clients wrapped
+// as they are for dry-run workflows, around fakes that fail the test
+// on any write. The release must stop at a write that can't be faked,
+// without making it.
+func TestReleaseDryRun(t *testing.T) {
+	deps := newReleaseTestDeps(t, "go1.26", 26, "go1.26.1")
+	trace := &task.DryRunTrace{Store: &task.FakeDryRunStore{}}
+	guard := writeGuard{t}
+	gerritClient := &task.DryRunGerritClient{GerritClient: &guardedGerrit{deps.gerrit, guard}, Trace: trace}
+	buildTasks := *deps.buildTasks
+	cloudBuild := &task.DryRunCloudBuildClient{CloudBuildClient: &guardedCloudBuild{buildTasks.CloudBuildClient, guard}, Trace: trace}
+	buildTasks.GerritClient = gerritClient
+	buildTasks.GerritHTTPClient = &http.Client{Transport: &task.DryRunTransport{Base: guardedTransport{http.DefaultTransport, guard}, Trace: trace}}
+	buildTasks.Git = &task.Git{DryRun: trace}
+	buildTasks.ScratchFS = &task.ScratchFS{BaseURL: "file://" + filepath.ToSlash(t.TempDir())}
+	buildTasks.SignedURL = "file://" + filepath.ToSlash(t.TempDir())
+	buildTasks.ServingURL = "file://" + filepath.ToSlash(t.TempDir())
+	buildTasks.SignService = &task.DryRunSignService{SignService: guardedSignService{buildTasks.SignService, guard}, Trace: trace}
+	buildTasks.PublishFile = trace.PublishFile
+	buildTasks.CloudBuildClient = cloudBuild
+	buildTasks.BuildBucketClient = &task.DryRunBuildBucketClient{BuildBucketClient: guardedBuildBucket{buildTasks.BuildBucketClient, guard}, Trace: trace}
+	buildTasks.SwarmingClient = &task.DryRunSwarmingClient{SwarmingClient: guardedSwarming{buildTasks.SwarmingClient, guard}, Trace: trace}
+	versionTasks := *deps.versionTasks
+	versionTasks.Gerrit = gerritClient
+	versionTasks.CloudBuild = &task.DryRunCloudBuildClient{CloudBuildClient: &guardedCloudBuild{versionTasks.CloudBuild, guard}, Trace: trace}
+	versionTasks.GoDirectiveXReposTasks.Gerrit = gerritClient
+	versionTasks.GoDirectiveXReposTasks.CloudBuild = versionTasks.CloudBuild
+	milestoneTasks := *deps.milestoneTasks
+	milestoneTasks.Client = &task.DryRunGitHubClient{GitHubClientInterface: &guardedGitHub{milestoneTasks.Client, guard}, Trace: trace}
+
+	tagsBefore, err := deps.gerrit.ListTags(deps.ctx, "go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	historyBefore := deps.goRepo.History()
+
+	wd := workflow.New(workflow.ACL{})
+	v := addSingleReleaseWorkflow(&buildTasks, &milestoneTasks, &versionTasks, wd, 26, task.KindMinor, workflow.Const([]string{"heschi", "dmitshur"}))
+	workflow.Output(wd, "Published Go version", v)
+	w, err := workflow.Start(wd, map[string]any{
+		"Targets to skip testing (or 'all') (optional)": []string{"js-wasm-node18", "js-wasm"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Run(deps.ctx, &verboseListener{t: t, onStall: deps.cancel, approve: w}); err == nil {
+		t.Fatalf("dry-run release finished, want it to stop at a refused write")
+	}
+
+	actions, err := trace.Actions(deps.ctx, w.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var denied []task.DryRunAction
+	for _, a := range actions {
+		t.Logf("recorded: %v", a)
+		if a.Denied {
+			denied = append(denied, a)
+		}
+	}
+	if len(denied) == 0 {
+		t.Errorf("no writes were refused, want at least one")
+	}
+	if len(deps.publishedFiles) != 0 {
+		t.Errorf("published %d files in a dry run, want none", len(deps.publishedFiles))
+	}
+	tagsAfter, err := deps.gerrit.ListTags(context.Background(), "go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(tagsBefore, tagsAfter); diff != "" {
+		t.Errorf("tags changed in a dry run (-before +after):\n%s", diff)
+	}
+	if diff := cmp.Diff(historyBefore, deps.goRepo.History()); diff != "" {
+		t.Errorf("history changed in a dry run (-before +after):\n%s", diff)
+	}
+}
+
 func testRelease(t *testing.T, prevTag string, major int, wantVersion string, kind task.ReleaseKind) {
 	deps := newReleaseTestDeps(t, prevTag, major, wantVersion)
 	wd := workflow.New(workflow.ACL{})
@@ -263,7 +347,7 @@ func testRelease(t *testing.T, prevTag string, major int, wantVersion string, ki
 	if err != nil {
 		t.Fatal(err)
 	}
-	outputs, err := w.Run(deps.ctx, &verboseListener{t: t, onStall: deps.cancel})
+	outputs, err := w.Run(deps.ctx, &verboseListener{t: t, onStall: deps.cancel, approve: w})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -515,12 +599,12 @@ esac
 	}
 
 	if mergeFixes {
-		_, err = w.Run(deps.ctx, &verboseListener{t: t})
+		_, err = w.Run(deps.ctx, &verboseListener{t: t, onStall: deps.cancel, approve: w})
 		if err != nil {
 			t.Fatal(err)
 		}
 	} else {
-		runToFailure(t, deps.ctx, w, "Check branch state matches source archive", &verboseListener{t: t})
+		runToFailure(t, deps.ctx, w, "Check branch state matches source archive", &verboseListener{t: t, onStall: deps.cancel, approve: w})
 		return
 	}
 	checkTGZ(t, deps.buildTasks.DownloadURL, deps.publishedFiles, "src.tar.gz", task.WebsiteFile{
@@ -558,7 +642,7 @@ func TestAdvisoryTestsFail(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if _, err := w.Run(deps.ctx, &verboseListener{t: t}); err != nil {
+	if _, err := w.Run(deps.ctx, &verboseListener{t: t, onStall: deps.cancel, approve: w}); err != nil {
 		t.Fatal(err)
 	}
 	if testApprovals.Load() != 1 {
@@ -793,11 +877,170 @@ func (g *reviewerCheckGerrit) CreateAutoSubmitChange(ctx *workflow.TaskContext,
 	return g.FakeGerrit.CreateAutoSubmitChange(ctx, input, reviewers, contents)
 }
 
+// writeGuard fails a test on any write that reaches the clients below.
+// They wrap fakes, and are in turn wrapped by dry-run clients, which
+// must not pass writes through.
+type writeGuard struct{ t *testing.T }
+
+func (g writeGuard) wrote(method string) error {
+	g.t.Errorf("dry run passed %s through to the real client", method)
+	return fmt.Errorf("%s: unexpected write in dry run", method)
+}
+
+type guardedGerrit struct {
+	task.GerritClient
+	writeGuard
+}
+
+func (g *guardedGerrit) CreateAutoSubmitChange(*workflow.TaskContext, gerrit.ChangeInput, []string, map[string]string) (string, error) {
+	return "", g.wrote("CreateAutoSubmitChange")
+}
+
+func (g *guardedGerrit) Tag(context.Context, string, string, string) error {
+	return g.wrote("Tag")
+}
+
+func (g *guardedGerrit) CreateBranch(context.Context, string, string, gerrit.BranchInput) (string, error) {
+	return "", g.wrote("CreateBranch")
+}
+
+func (g *guardedGerrit) SubmitChange(context.Context, string) (gerrit.ChangeInfo, error) {
+	return gerrit.ChangeInfo{}, g.wrote("SubmitChange")
+}
+
+func (g *guardedGerrit) SetHashtags(context.Context, string, gerrit.HashtagsInput) error {
+	return g.wrote("SetHashtags")
+}
+
+func (g *guardedGerrit) CreateCherryPick(context.Context, string, string, string) (gerrit.ChangeInfo, bool, error) {
+	return gerrit.ChangeInfo{}, false, g.wrote("CreateCherryPick")
+}
+
+func (g *guardedGerrit) RebaseChange(context.Context, string, string) (gerrit.ChangeInfo, error) {
+	return gerrit.ChangeInfo{}, g.wrote("RebaseChange")
+}
+
+func (g *guardedGerrit) MoveChange(context.Context, string, string) (gerrit.ChangeInfo, error) {
+	return gerrit.ChangeInfo{}, g.wrote("MoveChange")
+}
+
+type guardedCloudBuild struct {
+	task.CloudBuildClient
+	writeGuard
+}
+
+func (c *guardedCloudBuild) RunBuildTrigger(context.Context, string, string, map[string]string) (task.CloudBuild, error) {
+	return task.CloudBuild{}, c.wrote("RunBuildTrigger")
+}
+
+func (c *guardedCloudBuild) GenerateAutoSubmitChange(*workflow.TaskContext, gerrit.ChangeInput, []string) (string, error) {
+	return "", c.wrote("GenerateAutoSubmitChange")
+}
+
+func (c *guardedCloudBuild) RunScript(context.Context, string, string, []string) (task.CloudBuild, error) {
+	return task.CloudBuild{}, c.wrote("RunScript")
+}
+
+func (c *guardedCloudBuild) RunCustomSteps(context.Context, func(string) []*cloudbuildpb.BuildStep, *task.CloudBuildOptions) (task.CloudBuild, error) {
+	return task.CloudBuild{}, c.wrote("RunCustomSteps")
+}
+
+type guardedGitHub struct {
+	task.GitHubClientInterface
+	writeGuard
+}
+
+func (c *guardedGitHub) FetchMilestone(ctx context.Context, owner, repo, name string, create bool) (int, error) {
+	if create {
+		return 0, c.wrote("FetchMilestone with create")
+	}
+	return c.GitHubClientInterface.FetchMilestone(ctx, owner, repo, name, create)
+}
+
+func (c *guardedGitHub) CreateIssue(context.Context, string, string, *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	return nil, nil, c.wrote("CreateIssue")
+}
+
+func (c *guardedGitHub) EditIssue(context.Context, string, string, int, *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	return nil, nil, c.wrote("EditIssue")
+}
+
+func (c *guardedGitHub) EditMilestone(context.Context, string, string, int, *github.Milestone) (*github.Milestone, *github.Response, error) {
+	return nil, nil, c.wrote("EditMilestone")
+}
+
+func (c *guardedGitHub) PostComment(context.Context, githubv4.ID, string) error {
+	return c.wrote("PostComment")
+}
+
+func (c *guardedGitHub) CreateRelease(context.Context, string, string, *github.RepositoryRelease) (*github.RepositoryRelease, error) {
+	return nil, c.wrote("CreateRelease")
+}
+
+func (c *guardedGitHub) PublishRelease(context.Context, string, string, *github.RepositoryRelease) (*github.RepositoryRelease, error) {
+	return nil, c.wrote("PublishRelease")
+}
+
+func (c *guardedGitHub) UploadReleaseAsset(context.Context, string, string, int64, string, fs.File) (*github.ReleaseAsset, error) {
+	return nil, c.wrote("UploadReleaseAsset")
+}
+
+type guardedBuildBucket struct {
+	task.BuildBucketClient
+	writeGuard
+}
+
+func (c guardedBuildBucket) RunBuild(context.Context, string, string, *buildbucketpb.GitilesCommit, map[string]*structpb.Value) (int64, error) {
+	return 0, c.wrote("RunBuild")
+}
+
+type guardedSwarming struct {
+	task.SwarmingClient
+	writeGuard
+}
+
+func (c guardedSwarming) RunTask(context.Context, map[string]string, string, map[string]string) (string, error) {
+	return "", c.wrote("RunTask")
+}
+
+type guardedSignService struct {
+	sign.Service
+	writeGuard
+}
+
+func (s guardedSignService) SignArtifact(context.Context, sign.BuildType, []string) (string, error) {
+	return "", s.wrote("SignArtifact")
+}
+
+func (s guardedSignService) CancelSigning(context.Context, string) error {
+	return s.wrote("CancelSigning")
+}
+
+type guardedTransport struct {
+	http.RoundTripper
+	writeGuard
+}
+
+func (t guardedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil, t.wrote(req.Method + " " + req.URL.String())
+	}
+	return t.RoundTripper.RoundTrip(req)
+}
+
 type verboseListener struct {
 	t       *testing.T
 	onStall func()
+	// If approve is set, the listener supplies the approvals of its
+	// Approval tasks named in releaseApprovals as soon as they start
+	// waiting, the way a release coordinator would, and fails the test
+	// on any other Approval.
+	approve *workflow.Workflow
 }
 
+// releaseApprovals are the Approval tasks a release test expects to wait on.
+var releaseApprovals = []string{"Wait for Release Coordinator Approval"}
+
 func (l *verboseListener) WorkflowStalled(workflowID uuid.UUID) error {
 	l.t.Logf("workflow %q: stalled", workflowID.String())
 	if l.onStall != nil {
@@ -806,7 +1049,27 @@ func (l *verboseListener) WorkflowStalled(workflowID uuid.UUID) error {
 	return nil
 }
 
+func (l *verboseListener) WorkflowPaused(workflowID uuid.UUID, paused bool) error {
+	l.t.Logf("workflow %q: paused: %v", workflowID.String(), paused)
+	return nil
+}
+
 func (l *verboseListener) TaskStateChanged(_ uuid.UUID, _ string, st *workflow.TaskState) error {
+	if st.AwaitingInput && l.approve != nil {
+		if !slices.Contains(releaseApprovals, st.Name) {
+			l.t.Errorf("unexpected approval request for %q", st.Name)
+			if l.onStall != nil {
+				l.onStall()
+			}
+			return nil
+		}
+		// SupplyInput waits for Run, which is calling us.
+		go func() {
+			if err := l.approve.SupplyInput(context.Background(), st.Name, []byte("{}")); err != nil {
+				l.t.Errorf("approving %q: %v", st.Name, err)
+			}
+		}()
+	}
 	switch {
 	case !st.Finished:
 		l.t.Logf("task %-10v: started", st.Name)
@@ -11,6 +11,16 @@ import (
 	"github.com/google/uuid"
 )
 
+type DryRunAction struct {
+	ID         int32
+	WorkflowID uuid.UUID
+	TaskName   string
+	Service    string
+	Action     string
+	Denied     bool
+	CreatedAt  time.Time
+}
+
 type Schedule struct {
 	ID              int32
 	WorkflowName    string
@@ -46,13 +56,17 @@ type TaskLog struct {
 }
 
 type Workflow struct {
-	ID         uuid.UUID
-	Params     sql.NullString
-	Name       sql.NullString
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	Finished   bool
-	Output     string
-	Error      string
-	ScheduleID sql.NullInt32
+	ID               uuid.UUID
+	Params           sql.NullString
+	Name             sql.NullString
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Finished         bool
+	Output           string
+	Error            string
+	ScheduleID       sql.NullInt32
+	Paused           bool
+	ParentWorkflowID uuid.NullUUID
+	ParentTaskName   sql.NullString
+	DryRun           bool
 }
@@ -0,0 +1,390 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgproto3/v2"
+	"github.com/jackc/pgx/v4"
+	"github.com/mattn/go-sqlite3"
+)
+
+// SQLiteDB is a PGDBTX backed by a SQLite database, for running relui
+// locally without Postgres. It translates the queries in this package
+// from Postgres's dialect to SQLite's, so the generated Queries work
+// unchanged. Its schema is created by relui.MigrateSQLiteDB.
+type SQLiteDB struct {
+	db *sql.DB
+}
+
+// OpenSQLite opens the SQLite database in the file at path,
+// creating it if it doesn't exist.
+func OpenSQLite(path string) (*SQLiteDB, error) {
+	db, err := sql.Open("sqlite3", SQLiteDSN(path))
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open(%q, %q) = %w", "sqlite3", path, err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("db.Ping() = %w", err)
+	}
+	return &SQLiteDB{db: db}, nil
+}
+
+// SQLiteDSN returns the go-sqlite3 data source name for the database in
+// the file at path. Foreign keys are enforced, as they are in Postgres,
+// and concurrent writers wait for each other rather than failing.
+func SQLiteDSN(path string) string {
+	opts := url.Values{
+		"_foreign_keys": {"on"},
+		"_busy_timeout": {"10000"},
+		"_journal_mode": {"WAL"},
+		"_txlock":       {"immediate"},
+	}
+	return "file:" + path + "?" + opts.Encode()
+}
+
+func (s *SQLiteDB) Exec(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
+	return sqliteExec(ctx, s.db, query, args)
+}
+
+func (s *SQLiteDB) Query(ctx context.Context, query string, args ...any) (pgx.Rows, error) {
+	return sqliteQuery(ctx, s.db, query, args)
+}
+
+func (s *SQLiteDB) QueryRow(ctx context.Context, query string, args ...any) pgx.Row {
+	return sqliteQueryRow(ctx, s.db, query, args)
+}
+
+// BeginFunc runs f in a transaction, which is committed if f returns
+// nil and rolled back otherwise.
+func (s *SQLiteDB) BeginFunc(ctx context.Context, f func(pgx.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := f(&sqliteTx{tx: tx}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLiteDB) Close() {
+	s.db.Close()
+}
+
+// errSQLiteUnsupported is returned by the methods of the pgx interfaces
+// that SQLiteDB has no equivalent for.
+var errSQLiteUnsupported = errors.New("not supported by the SQLite backend")
+
+// sqliteTx is a pgx.Tx for a SQLite transaction. Only the methods used
+// by Queries do anything; the others return errSQLiteUnsupported, or
+// zero values if they can't return an error.
+type sqliteTx struct {
+	tx *sql.Tx
+}
+
+var _ pgx.Tx = (*sqliteTx)(nil)
+
+func (t *sqliteTx) Exec(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
+	return sqliteExec(ctx, t.tx, query, args)
+}
+
+func (t *sqliteTx) Query(ctx context.Context, query string, args ...any) (pgx.Rows, error) {
+	return sqliteQuery(ctx, t.tx, query, args)
+}
+
+func (t *sqliteTx) QueryRow(ctx context.Context, query string, args ...any) pgx.Row {
+	return sqliteQueryRow(ctx, t.tx, query, args)
+}
+
+func (t *sqliteTx) Commit(ctx context.Context) error   { return t.tx.Commit() }
+func (t *sqliteTx) Rollback(ctx context.Context) error { return t.tx.Rollback() }
+
+func (t *sqliteTx) Begin(ctx context.Context) (pgx.Tx, error) { return nil, errSQLiteUnsupported }
+func (t *sqliteTx) BeginFunc(ctx context.Context, f func(pgx.Tx) error) error {
+	return errSQLiteUnsupported
+}
+func (t *sqliteTx) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return 0, errSQLiteUnsupported
+}
+func (t *sqliteTx) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return sqliteBatchResults{}
+}
+func (t *sqliteTx) LargeObjects() pgx.LargeObjects { return pgx.LargeObjects{} }
+func (t *sqliteTx) Prepare(ctx context.Context, name, sql string) (*pgconn.StatementDescription, error) {
+	return nil, errSQLiteUnsupported
+}
+func (t *sqliteTx) QueryFunc(ctx context.Context, sql string, args []any, scans []any, f func(pgx.QueryFuncRow) error) (pgconn.CommandTag, error) {
+	return nil, errSQLiteUnsupported
+}
+func (t *sqliteTx) Conn() *pgx.Conn { return nil }
+
+// sqliteBatchResults is the pgx.BatchResults of a batch sent in a SQLite
+// transaction, which always fails.
+type sqliteBatchResults struct{}
+
+func (sqliteBatchResults) Exec() (pgconn.CommandTag, error) { return nil, errSQLiteUnsupported }
+func (sqliteBatchResults) Query() (pgx.Rows, error)         { return nil, errSQLiteUnsupported }
+func (sqliteBatchResults) QueryRow() pgx.Row                { return sqliteErrRow{} }
+func (sqliteBatchResults) QueryFunc(scans []any, f func(pgx.QueryFuncRow) error) (pgconn.CommandTag, error) {
+	return nil, errSQLiteUnsupported
+}
+func (sqliteBatchResults) Close() error { return nil }
+
+// sqliteErrRow is a pgx.Row for a query that couldn't be run.
+type sqliteErrRow struct{}
+
+func (sqliteErrRow) Scan(dest ...any) error { return errSQLiteUnsupported }
+
+// sqlDBTX is the subset of methods shared by *sql.DB and *sql.Tx.
+type sqlDBTX interface {
+	ExecContext(context.Context, string, ...any) (sql.Result, error)
+	QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...any) *sql.Row
+}
+
+func sqliteExec(ctx context.Context, db sqlDBTX, query string, args []any) (pgconn.CommandTag, error) {
+	res, err := db.ExecContext(ctx, toSQLite(query), sqliteArgs(args)...)
+	if err != nil {
+		return nil, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	return pgconn.CommandTag(fmt.Sprintf("EXEC %d", n)), nil
+}
+
+func sqliteQuery(ctx context.Context, db sqlDBTX, query string, args []any) (pgx.Rows, error) {
+	rows, err := db.QueryContext(ctx, toSQLite(query), sqliteArgs(args)...)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{rows: rows}, nil
+}
+
+func sqliteQueryRow(ctx context.Context, db sqlDBTX, query string, args []any) pgx.Row {
+	return sqliteRow{db.QueryRowContext(ctx, toSQLite(query), sqliteArgs(args)...)}
+}
+
+// sqliteRows is a pgx.Rows for SQLite query results. Postgres's
+// wire-level details, such as field descriptions, aren't available.
+type sqliteRows struct {
+	rows *sql.Rows
+}
+
+var _ pgx.Rows = (*sqliteRows)(nil)
+
+func (r *sqliteRows) Close()                 { r.rows.Close() }
+func (r *sqliteRows) Err() error             { return r.rows.Err() }
+func (r *sqliteRows) Next() bool             { return r.rows.Next() }
+func (r *sqliteRows) Scan(dest ...any) error { return r.rows.Scan(sqliteDest(dest)...) }
+
+func (r *sqliteRows) CommandTag() pgconn.CommandTag                  { return nil }
+func (r *sqliteRows) FieldDescriptions() []pgproto3.FieldDescription { return nil }
+func (r *sqliteRows) RawValues() [][]byte                            { return nil }
+
+// Values returns the values of the current row, as stored by SQLite.
+func (r *sqliteRows) Values() ([]any, error) {
+	cols, err := r.rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, len(cols))
+	dest := make([]any, len(cols))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := r.rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+type sqliteRow struct {
+	row *sql.Row
+}
+
+// Scan is like sql.Row.Scan, but returns pgx.ErrNoRows rather than
+// sql.ErrNoRows, which is what callers of Queries check for.
+func (r sqliteRow) Scan(dest ...any) error {
+	err := r.row.Scan(sqliteDest(dest)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return pgx.ErrNoRows
+	}
+	return err
+}
+
+// sqliteQueries are SQLite versions of the queries that use Postgres
+// features with no mechanical translation.
+var sqliteQueries = map[string]string{
+	workflowsByNames: `-- name: WorkflowsByNames :many
+SELECT id, params, name, created_at, updated_at, finished, output, error, schedule_id, paused, parent_workflow_id, parent_task_name, dry_run
+FROM workflows
+WHERE name IN (SELECT value FROM json_each(?1))
+ORDER BY created_at DESC
+`,
+	tasks: `-- name: Tasks :many
+WITH most_recent_logs AS (
+    SELECT workflow_id, task_name, MAX(updated_at) AS updated_at
+    FROM task_logs
+    GROUP BY workflow_id, task_name
+)
+SELECT tasks.workflow_id, tasks.name, tasks.finished, tasks.result, tasks.error, tasks.created_at, tasks.updated_at, tasks.approved_at, tasks.ready_for_approval, tasks.started, tasks.retry_count,
+       MAX(COALESCE(most_recent_logs.updated_at, tasks.updated_at), tasks.updated_at) AS most_recent_update
+FROM tasks
+LEFT JOIN most_recent_logs ON tasks.workflow_id = most_recent_logs.workflow_id AND
+                              tasks.name = most_recent_logs.task_name
+ORDER BY most_recent_update DESC
+`,
+	tasksForWorkflowSorted: `-- name: TasksForWorkflowSorted :many
+WITH most_recent_logs AS (
+    SELECT workflow_id, task_name, MAX(updated_at) AS updated_at
+    FROM task_logs
+    GROUP BY workflow_id, task_name
+)
+SELECT tasks.workflow_id, tasks.name, tasks.finished, tasks.result, tasks.error, tasks.created_at, tasks.updated_at, tasks.approved_at, tasks.ready_for_approval, tasks.started, tasks.retry_count,
+       MAX(COALESCE(most_recent_logs.updated_at, tasks.updated_at), tasks.updated_at) AS most_recent_update
+FROM tasks
+LEFT JOIN most_recent_logs ON tasks.workflow_id = most_recent_logs.workflow_id AND
+                              tasks.name = most_recent_logs.task_name
+WHERE tasks.workflow_id = ?1
+ORDER BY most_recent_update DESC
+`,
+	schedulesLastRun: `-- name: SchedulesLastRun :many
+WITH last_scheduled_run AS (
+    SELECT schedule_id, id, created_at, workflows.error, finished,
+           ROW_NUMBER() OVER (PARTITION BY schedule_id ORDER BY workflows.created_at DESC) AS n
+    FROM workflows
+)
+SELECT schedules.id,
+       last_scheduled_run.id AS workflow_id,
+       last_scheduled_run.created_at AS workflow_created_at,
+       last_scheduled_run.error AS workflow_error,
+       last_scheduled_run.finished AS workflow_finished
+FROM schedules
+LEFT OUTER JOIN last_scheduled_run ON last_scheduled_run.schedule_id = schedules.id AND last_scheduled_run.n = 1
+`,
+}
+
+var (
+	pgCast  = regexp.MustCompile(`::[a-z]+(\[\])?`)
+	pgParam = regexp.MustCompile(`\$([0-9]+)`)
+)
+
+// toSQLite translates query from Postgres's dialect to SQLite's.
+// Type casts are dropped, and $N parameters become ?N, since SQLite
+// numbers $N parameters in the order they appear rather than by N.
+func toSQLite(query string) string {
+	if q, ok := sqliteQueries[query]; ok {
+		return q
+	}
+	query = pgCast.ReplaceAllString(query, "")
+	return pgParam.ReplaceAllString(query, "?$1")
+}
+
+// sqliteArgs converts query arguments to the types they're stored as in
+// SQLite. Times are stored in UTC, so that they sort correctly as text,
+// and string slices are stored as JSON arrays.
+func sqliteArgs(args []any) []any {
+	converted := make([]any, len(args))
+	for i, arg := range args {
+		switch arg := arg.(type) {
+		case time.Time:
+			converted[i] = arg.UTC()
+		case sql.NullTime:
+			arg.Time = arg.Time.UTC()
+			converted[i] = arg
+		case []string:
+			b, err := json.Marshal(arg)
+			if err != nil {
+				panic(err) // Can't happen.
+			}
+			converted[i] = string(b)
+		default:
+			converted[i] = arg
+		}
+	}
+	return converted
+}
+
+// sqliteDest wraps scan destinations that database/sql can't fill from
+// SQLite's values. SQLite has no time type: go-sqlite3 converts columns
+// declared as timestamps to time.Time, but computed columns are text.
+func sqliteDest(dest []any) []any {
+	wrapped := make([]any, len(dest))
+	for i, d := range dest {
+		switch d := d.(type) {
+		case *time.Time:
+			wrapped[i] = sqliteTime{t: d}
+		case *sql.NullTime:
+			wrapped[i] = sqliteTime{null: d}
+		default:
+			wrapped[i] = d
+		}
+	}
+	return wrapped
+}
+
+// sqliteTime scans a SQLite timestamp into a time.Time or sql.NullTime.
+type sqliteTime struct {
+	t    *time.Time
+	null *sql.NullTime
+}
+
+func (s sqliteTime) Scan(src any) error {
+	var t time.Time
+	switch src := src.(type) {
+	case nil:
+		if s.null != nil {
+			*s.null = sql.NullTime{}
+		} else {
+			*s.t = time.Time{}
+		}
+		return nil
+	case time.Time:
+		t = src
+	case string:
+		var err error
+		if t, err = parseSQLiteTime(src); err != nil {
+			return err
+		}
+	case []byte:
+		var err error
+		if t, err = parseSQLiteTime(string(src)); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("can't scan %T into a time", src)
+	}
+	if s.null != nil {
+		*s.null = sql.NullTime{Time: t, Valid: true}
+	} else {
+		*s.t = t
+	}
+	return nil
+}
+
+func parseSQLiteTime(s string) (time.Time, error) {
+	s = strings.TrimSuffix(s, "Z")
+	for _, format := range sqlite3.SQLiteTimestampFormats {
+		if t, err := time.ParseInLocation(format, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("can't parse %q as a time", s)
+}
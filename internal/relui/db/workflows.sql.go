@@ -47,6 +47,82 @@ func (q *Queries) ApproveTask(ctx context.Context, arg ApproveTaskParams) (Task,
 	return i, err
 }
 
+const childWorkflow = `-- name: ChildWorkflow :one
+SELECT id, params, name, created_at, updated_at, finished, output, error, schedule_id, paused, parent_workflow_id, parent_task_name, dry_run
+FROM workflows
+WHERE parent_workflow_id = $1
+  AND parent_task_name = $2
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+type ChildWorkflowParams struct {
+	ParentWorkflowID uuid.NullUUID
+	ParentTaskName   sql.NullString
+}
+
+func (q *Queries) ChildWorkflow(ctx context.Context, arg ChildWorkflowParams) (Workflow, error) {
+	row := q.db.QueryRow(ctx, childWorkflow, arg.ParentWorkflowID, arg.ParentTaskName)
+	var i Workflow
+	err := row.Scan(
+		&i.ID,
+		&i.Params,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Finished,
+		&i.Output,
+		&i.Error,
+		&i.ScheduleID,
+		&i.Paused,
+		&i.ParentWorkflowID,
+		&i.ParentTaskName,
+		&i.DryRun,
+	)
+	return i, err
+}
+
+const childWorkflows = `-- name: ChildWorkflows :many
+SELECT id, params, name, created_at, updated_at, finished, output, error, schedule_id, paused, parent_workflow_id, parent_task_name, dry_run
+FROM workflows
+WHERE parent_workflow_id = $1
+ORDER BY created_at
+`
+
+func (q *Queries) ChildWorkflows(ctx context.Context, parentWorkflowID uuid.NullUUID) ([]Workflow, error) {
+	rows, err := q.db.Query(ctx, childWorkflows, parentWorkflowID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Workflow
+	for rows.Next() {
+		var i Workflow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Params,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Finished,
+			&i.Output,
+			&i.Error,
+			&i.ScheduleID,
+			&i.Paused,
+			&i.ParentWorkflowID,
+			&i.ParentTaskName,
+			&i.DryRun,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const clearWorkflowSchedule = `-- name: ClearWorkflowSchedule :many
 UPDATE workflows
 SET schedule_id = NULL
@@ -74,6 +150,43 @@ func (q *Queries) ClearWorkflowSchedule(ctx context.Context, dollar_1 int32) ([]
 	return items, nil
 }
 
+const createDryRunAction = `-- name: CreateDryRunAction :one
+INSERT INTO dry_run_actions (workflow_id, task_name, service, action, denied, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, workflow_id, task_name, service, action, denied, created_at
+`
+
+type CreateDryRunActionParams struct {
+	WorkflowID uuid.UUID
+	TaskName   string
+	Service    string
+	Action     string
+	Denied     bool
+	CreatedAt  time.Time
+}
+
+func (q *Queries) CreateDryRunAction(ctx context.Context, arg CreateDryRunActionParams) (DryRunAction, error) {
+	row := q.db.QueryRow(ctx, createDryRunAction,
+		arg.WorkflowID,
+		arg.TaskName,
+		arg.Service,
+		arg.Action,
+		arg.Denied,
+		arg.CreatedAt,
+	)
+	var i DryRunAction
+	err := row.Scan(
+		&i.ID,
+		&i.WorkflowID,
+		&i.TaskName,
+		&i.Service,
+		&i.Action,
+		&i.Denied,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createSchedule = `-- name: CreateSchedule :one
 INSERT INTO schedules (workflow_name, workflow_params, spec, once, interval_minutes, created_at, updated_at)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
@@ -189,18 +302,22 @@ func (q *Queries) CreateTaskLog(ctx context.Context, arg CreateTaskLogParams) (T
 }
 
 const createWorkflow = `-- name: CreateWorkflow :one
-INSERT INTO workflows (id, params, name, schedule_id, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, params, name, created_at, updated_at, finished, output, error, schedule_id
+INSERT INTO workflows (id, params, name, schedule_id, created_at, updated_at, dry_run, parent_workflow_id,
+                       parent_task_name)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, params, name, created_at, updated_at, finished, output, error, schedule_id, paused, parent_workflow_id, parent_task_name, dry_run
 `
 
 type CreateWorkflowParams struct {
-	ID         uuid.UUID
-	Params     sql.NullString
-	Name       sql.NullString
-	ScheduleID sql.NullInt32
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID               uuid.UUID
+	Params           sql.NullString
+	Name             sql.NullString
+	ScheduleID       sql.NullInt32
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DryRun           bool
+	ParentWorkflowID uuid.NullUUID
+	ParentTaskName   sql.NullString
 }
 
 func (q *Queries) CreateWorkflow(ctx context.Context, arg CreateWorkflowParams) (Workflow, error) {
@@ -211,6 +328,9 @@ func (q *Queries) CreateWorkflow(ctx context.Context, arg CreateWorkflowParams)
 		arg.ScheduleID,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.DryRun,
+		arg.ParentWorkflowID,
+		arg.ParentTaskName,
 	)
 	var i Workflow
 	err := row.Scan(
@@ -223,6 +343,10 @@ func (q *Queries) CreateWorkflow(ctx context.Context, arg CreateWorkflowParams)
 		&i.Output,
 		&i.Error,
 		&i.ScheduleID,
+		&i.Paused,
+		&i.ParentWorkflowID,
+		&i.ParentTaskName,
+		&i.DryRun,
 	)
 	return i, err
 }
@@ -250,13 +374,48 @@ func (q *Queries) DeleteSchedule(ctx context.Context, id int32) (Schedule, error
 	return i, err
 }
 
+const dryRunActionsForWorkflow = `-- name: DryRunActionsForWorkflow :many
+SELECT dry_run_actions.id, dry_run_actions.workflow_id, dry_run_actions.task_name, dry_run_actions.service, dry_run_actions.action, dry_run_actions.denied, dry_run_actions.created_at
+FROM dry_run_actions
+WHERE workflow_id = $1
+ORDER BY created_at, id
+`
+
+func (q *Queries) DryRunActionsForWorkflow(ctx context.Context, workflowID uuid.UUID) ([]DryRunAction, error) {
+	rows, err := q.db.Query(ctx, dryRunActionsForWorkflow, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DryRunAction
+	for rows.Next() {
+		var i DryRunAction
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkflowID,
+			&i.TaskName,
+			&i.Service,
+			&i.Action,
+			&i.Denied,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const failUnfinishedTasks = `-- name: FailUnfinishedTasks :exec
 UPDATE tasks
     SET finished = TRUE,
     started      = TRUE,
     error        = 'task interrupted before completion',
     updated_at   = $2
-WHERE workflow_id = $1 and started and not finished
+WHERE workflow_id = $1 and started and not finished and not ready_for_approval
 `
 
 type FailUnfinishedTasksParams struct {
@@ -657,7 +816,7 @@ func (q *Queries) TasksForWorkflowSorted(ctx context.Context, workflowID uuid.UU
 }
 
 const unfinishedWorkflows = `-- name: UnfinishedWorkflows :many
-SELECT workflows.id, workflows.params, workflows.name, workflows.created_at, workflows.updated_at, workflows.finished, workflows.output, workflows.error, workflows.schedule_id
+SELECT workflows.id, workflows.params, workflows.name, workflows.created_at, workflows.updated_at, workflows.finished, workflows.output, workflows.error, workflows.schedule_id, workflows.paused, workflows.parent_workflow_id, workflows.parent_task_name, workflows.dry_run
 FROM workflows
 WHERE workflows.finished = FALSE
 `
@@ -681,6 +840,10 @@ func (q *Queries) UnfinishedWorkflows(ctx context.Context) ([]Workflow, error) {
 			&i.Output,
 			&i.Error,
 			&i.ScheduleID,
+			&i.Paused,
+			&i.ParentWorkflowID,
+			&i.ParentTaskName,
+			&i.DryRun,
 		); err != nil {
 			return nil, err
 		}
@@ -725,6 +888,41 @@ func (q *Queries) UpdateTaskReadyForApproval(ctx context.Context, arg UpdateTask
 	return i, err
 }
 
+const updateWorkflowPaused = `-- name: UpdateWorkflowPaused :one
+UPDATE workflows
+SET paused     = $2,
+    updated_at = $3
+WHERE id = $1
+RETURNING id, params, name, created_at, updated_at, finished, output, error, schedule_id, paused, parent_workflow_id, parent_task_name, dry_run
+`
+
+type UpdateWorkflowPausedParams struct {
+	ID        uuid.UUID
+	Paused    bool
+	UpdatedAt time.Time
+}
+
+func (q *Queries) UpdateWorkflowPaused(ctx context.Context, arg UpdateWorkflowPausedParams) (Workflow, error) {
+	row := q.db.QueryRow(ctx, updateWorkflowPaused, arg.ID, arg.Paused, arg.UpdatedAt)
+	var i Workflow
+	err := row.Scan(
+		&i.ID,
+		&i.Params,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Finished,
+		&i.Output,
+		&i.Error,
+		&i.ScheduleID,
+		&i.Paused,
+		&i.ParentWorkflowID,
+		&i.ParentTaskName,
+		&i.DryRun,
+	)
+	return i, err
+}
+
 const upsertTask = `-- name: UpsertTask :one
 INSERT INTO tasks (workflow_id, name, started, finished, result, error, created_at, updated_at,
                    retry_count)
@@ -783,7 +981,7 @@ func (q *Queries) UpsertTask(ctx context.Context, arg UpsertTaskParams) (Task, e
 }
 
 const workflow = `-- name: Workflow :one
-SELECT id, params, name, created_at, updated_at, finished, output, error, schedule_id
+SELECT id, params, name, created_at, updated_at, finished, output, error, schedule_id, paused, parent_workflow_id, parent_task_name, dry_run
 FROM workflows
 WHERE id = $1
 `
@@ -801,6 +999,10 @@ func (q *Queries) Workflow(ctx context.Context, id uuid.UUID) (Workflow, error)
 		&i.Output,
 		&i.Error,
 		&i.ScheduleID,
+		&i.Paused,
+		&i.ParentWorkflowID,
+		&i.ParentTaskName,
+		&i.DryRun,
 	)
 	return i, err
 }
@@ -824,7 +1026,7 @@ SET finished   = $2,
     error      = $4,
     updated_at = $5
 WHERE workflows.id = $1
-RETURNING id, params, name, created_at, updated_at, finished, output, error, schedule_id
+RETURNING id, params, name, created_at, updated_at, finished, output, error, schedule_id, paused, parent_workflow_id, parent_task_name, dry_run
 `
 
 type WorkflowFinishedParams struct {
@@ -854,6 +1056,10 @@ func (q *Queries) WorkflowFinished(ctx context.Context, arg WorkflowFinishedPara
 		&i.Output,
 		&i.Error,
 		&i.ScheduleID,
+		&i.Paused,
+		&i.ParentWorkflowID,
+		&i.ParentTaskName,
+		&i.DryRun,
 	)
 	return i, err
 }
@@ -917,7 +1123,7 @@ func (q *Queries) WorkflowSidebar(ctx context.Context) ([]WorkflowSidebarRow, er
 
 const workflows = `-- name: Workflows :many
 
-SELECT id, params, name, created_at, updated_at, finished, output, error, schedule_id
+SELECT id, params, name, created_at, updated_at, finished, output, error, schedule_id, paused, parent_workflow_id, parent_task_name, dry_run
 FROM workflows
 ORDER BY created_at DESC
 `
@@ -944,6 +1150,10 @@ func (q *Queries) Workflows(ctx context.Context) ([]Workflow, error) {
 			&i.Output,
 			&i.Error,
 			&i.ScheduleID,
+			&i.Paused,
+			&i.ParentWorkflowID,
+			&i.ParentTaskName,
+			&i.DryRun,
 		); err != nil {
 			return nil, err
 		}
@@ -956,7 +1166,7 @@ func (q *Queries) Workflows(ctx context.Context) ([]Workflow, error) {
 }
 
 const workflowsByName = `-- name: WorkflowsByName :many
-SELECT id, params, name, created_at, updated_at, finished, output, error, schedule_id
+SELECT id, params, name, created_at, updated_at, finished, output, error, schedule_id, paused, parent_workflow_id, parent_task_name, dry_run
 FROM workflows
 WHERE name = $1
 ORDER BY created_at DESC
@@ -981,6 +1191,10 @@ func (q *Queries) WorkflowsByName(ctx context.Context, name sql.NullString) ([]W
 			&i.Output,
 			&i.Error,
 			&i.ScheduleID,
+			&i.Paused,
+			&i.ParentWorkflowID,
+			&i.ParentTaskName,
+			&i.DryRun,
 		); err != nil {
 			return nil, err
 		}
@@ -993,7 +1207,7 @@ func (q *Queries) WorkflowsByName(ctx context.Context, name sql.NullString) ([]W
 }
 
 const workflowsByNames = `-- name: WorkflowsByNames :many
-SELECT id, params, name, created_at, updated_at, finished, output, error, schedule_id
+SELECT id, params, name, created_at, updated_at, finished, output, error, schedule_id, paused, parent_workflow_id, parent_task_name, dry_run
 FROM workflows
 WHERE name = ANY($1::text[])
 ORDER BY created_at DESC
@@ -1018,6 +1232,10 @@ func (q *Queries) WorkflowsByNames(ctx context.Context, names []string) ([]Workf
 			&i.Output,
 			&i.Error,
 			&i.ScheduleID,
+			&i.Paused,
+			&i.ParentWorkflowID,
+			&i.ParentTaskName,
+			&i.DryRun,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,50 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package relui
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"golang.org/x/build/internal/relui/db"
+	"golang.org/x/build/internal/task"
+)
+
+// DBDryRunStore implements task.DryRunStore, persisting the actions
+// of dry-run workflows in the database alongside their tasks.
+type DBDryRunStore struct {
+	DB db.PGDBTX
+}
+
+func (s *DBDryRunStore) AddDryRunAction(ctx context.Context, a task.DryRunAction) error {
+	_, err := db.New(s.DB).CreateDryRunAction(ctx, db.CreateDryRunActionParams{
+		WorkflowID: a.WorkflowID,
+		TaskName:   a.TaskName,
+		Service:    a.Service,
+		Action:     a.Action,
+		Denied:     a.Denied,
+		CreatedAt:  a.Time,
+	})
+	return err
+}
+
+func (s *DBDryRunStore) DryRunActions(ctx context.Context, workflowID uuid.UUID) ([]task.DryRunAction, error) {
+	rows, err := db.New(s.DB).DryRunActionsForWorkflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	actions := make([]task.DryRunAction, len(rows))
+	for i, r := range rows {
+		actions[i] = task.DryRunAction{
+			Time:       r.CreatedAt,
+			WorkflowID: r.WorkflowID,
+			TaskName:   r.TaskName,
+			Service:    r.Service,
+			Action:     r.Action,
+			Denied:     r.Denied,
+		}
+	}
+	return actions, nil
+}
@@ -58,6 +58,20 @@ func (l *PGListener) WorkflowStalled(workflowID uuid.UUID) error {
 	})
 }
 
+// WorkflowPaused persists whether the workflow is paused, so that
+// it stays paused when resumed.
+func (l *PGListener) WorkflowPaused(workflowID uuid.UUID, paused bool) error {
+	_, err := db.New(l.DB).UpdateWorkflowPaused(context.Background(), db.UpdateWorkflowPausedParams{
+		ID:        workflowID,
+		Paused:    paused,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Printf("WorkflowPaused(%q, %v) = %v", workflowID, paused, err)
+	}
+	return err
+}
+
 // TaskStateChanged is called whenever a task is updated by the
 // workflow. The workflow.TaskState is persisted as a db.Task,
 // creating or updating a row as necessary.
@@ -83,6 +97,15 @@ func (l *PGListener) TaskStateChanged(workflowID uuid.UUID, taskName string, sta
 			UpdatedAt:  updated,
 			RetryCount: int32(state.RetryCount),
 		})
+		if err != nil || !state.AwaitingInput {
+			return err
+		}
+		// Show the approve control for tasks waiting on input.
+		_, err = q.UpdateTaskReadyForApproval(ctx, db.UpdateTaskReadyForApprovalParams{
+			ReadyForApproval: true,
+			Name:             taskName,
+			WorkflowID:       workflowID,
+		})
 		return err
 	})
 	if err != nil {
@@ -92,7 +115,7 @@ func (l *PGListener) TaskStateChanged(workflowID uuid.UUID, taskName string, sta
 }
 
 // WorkflowStarted persists a new workflow execution in the database.
-func (l *PGListener) WorkflowStarted(ctx context.Context, workflowID uuid.UUID, name string, params map[string]any, scheduleID int) error {
+func (l *PGListener) WorkflowStarted(ctx context.Context, workflowID uuid.UUID, name string, params map[string]any, scheduleID int, opts StartOptions) error {
 	q := db.New(l.DB)
 	m, err := json.Marshal(params)
 	if err != nil {
@@ -106,6 +129,11 @@ func (l *PGListener) WorkflowStarted(ctx context.Context, workflowID uuid.UUID,
 		ScheduleID: sql.NullInt32{Int32: int32(scheduleID), Valid: scheduleID != 0},
 		CreatedAt:  updated,
 		UpdatedAt:  updated,
+		DryRun:     opts.DryRun,
+		// The parent is set in the same statement, so a child workflow
+		// is never persisted without it.
+		ParentWorkflowID: opts.ParentWorkflowID,
+		ParentTaskName:   sql.NullString{String: opts.ParentTaskName, Valid: opts.ParentWorkflowID.Valid},
 	}
 	_, err = q.CreateWorkflow(ctx, wfp)
 	return err
@@ -0,0 +1,9 @@
+-- Copyright 2026 The Go Authors. All rights reserved.
+-- Use of this source code is governed by a BSD-style
+-- license that can be found in the LICENSE file.
+
+DROP INDEX workflows_parent_idx;
+
+ALTER TABLE workflows
+    DROP COLUMN parent_task_name,
+    DROP COLUMN parent_workflow_id;
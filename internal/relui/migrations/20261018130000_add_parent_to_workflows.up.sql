@@ -0,0 +1,9 @@
+-- Copyright 2026 The Go Authors. All rights reserved.
+-- Use of this source code is governed by a BSD-style
+-- license that can be found in the LICENSE file.
+
+ALTER TABLE workflows
+    ADD COLUMN parent_workflow_id uuid REFERENCES workflows (id),
+    ADD COLUMN parent_task_name   text;
+
+CREATE UNIQUE INDEX workflows_parent_idx ON workflows (parent_workflow_id, parent_task_name);
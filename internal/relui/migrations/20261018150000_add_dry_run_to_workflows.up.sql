@@ -0,0 +1,8 @@
+-- Copyright 2026 The Go Authors. All rights reserved.
+-- Use of this source code is governed by a BSD-style
+-- license that can be found in the LICENSE file.
+
+-- Workflows started in dry-run mode run with clients that record
+-- their writes instead of making them, including after a restart.
+ALTER TABLE workflows
+    ADD COLUMN dry_run boolean NOT NULL DEFAULT FALSE;
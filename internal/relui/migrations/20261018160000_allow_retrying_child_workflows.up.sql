@@ -0,0 +1,10 @@
+-- Copyright 2026 The Go Authors. All rights reserved.
+-- Use of this source code is governed by a BSD-style
+-- license that can be found in the LICENSE file.
+
+-- A task may start a new child workflow after its previous child
+-- failed, but it has at most one unfinished child at a time.
+DROP INDEX workflows_parent_idx;
+
+CREATE INDEX workflows_parent_idx ON workflows (parent_workflow_id, parent_task_name);
+CREATE UNIQUE INDEX workflows_unfinished_child_idx ON workflows (parent_workflow_id, parent_task_name) WHERE finished = FALSE;
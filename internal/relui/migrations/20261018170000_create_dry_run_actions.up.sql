@@ -0,0 +1,17 @@
+-- Copyright 2026 The Go Authors. All rights reserved.
+-- Use of this source code is governed by a BSD-style
+-- license that can be found in the LICENSE file.
+
+-- The writes that dry-run workflows recorded instead of making,
+-- or refused to make.
+CREATE TABLE dry_run_actions (
+  id SERIAL PRIMARY KEY,
+  workflow_id uuid NOT NULL REFERENCES workflows (id),
+  task_name text NOT NULL,
+  service text NOT NULL,
+  action text NOT NULL,
+  denied boolean NOT NULL,
+  created_at timestamp with time zone NOT NULL default current_timestamp
+);
+
+CREATE INDEX dry_run_actions_workflow_idx ON dry_run_actions (workflow_id);
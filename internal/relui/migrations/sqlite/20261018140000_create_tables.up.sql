@@ -0,0 +1,66 @@
+-- Copyright 2026 The Go Authors. All rights reserved.
+-- Use of this source code is governed by a BSD-style
+-- license that can be found in the LICENSE file.
+
+-- The SQLite schema matches the Postgres one created by the migrations
+-- in the parent directory, with columns in the same order. Timestamps
+-- are stored as text in UTC, which sorts in time order.
+
+CREATE TABLE schedules
+(
+    id               INTEGER PRIMARY KEY,
+    workflow_name    text      NOT NULL,
+    workflow_params  text,
+    spec             text      NOT NULL,
+    once             timestamp,
+    interval_minutes integer   NOT NULL,
+    created_at       timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at       timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE workflows
+(
+    id                 text PRIMARY KEY,
+    params             text,
+    name               text,
+    created_at         timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at         timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    finished           boolean   NOT NULL DEFAULT FALSE,
+    output             text      NOT NULL DEFAULT '{}',
+    error              text      NOT NULL DEFAULT '',
+    schedule_id        integer REFERENCES schedules (id),
+    paused             boolean   NOT NULL DEFAULT FALSE,
+    parent_workflow_id text REFERENCES workflows (id),
+    parent_task_name   text
+);
+
+CREATE INDEX workflows_finished_ix ON workflows (finished) WHERE finished = FALSE;
+CREATE INDEX workflows_schedule_id_ix ON workflows (schedule_id) WHERE schedule_id IS NOT NULL;
+CREATE UNIQUE INDEX workflows_parent_idx ON workflows (parent_workflow_id, parent_task_name);
+
+CREATE TABLE tasks
+(
+    workflow_id        text REFERENCES workflows (id),
+    name               text,
+    finished           boolean   NOT NULL DEFAULT FALSE,
+    result             text,
+    error              text,
+    created_at         timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at         timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    approved_at        timestamp,
+    ready_for_approval boolean   NOT NULL DEFAULT FALSE,
+    started            boolean   NOT NULL DEFAULT FALSE,
+    retry_count        integer   NOT NULL DEFAULT 0,
+    PRIMARY KEY (workflow_id, name)
+);
+
+CREATE TABLE task_logs
+(
+    id          INTEGER PRIMARY KEY,
+    workflow_id text      NOT NULL,
+    task_name   text      NOT NULL,
+    body        text      NOT NULL,
+    created_at  timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at  timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    FOREIGN KEY (workflow_id, task_name) REFERENCES tasks (workflow_id, name)
+);
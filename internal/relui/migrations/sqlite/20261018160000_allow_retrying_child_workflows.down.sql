@@ -0,0 +1,8 @@
+-- Copyright 2026 The Go Authors. All rights reserved.
+-- Use of this source code is governed by a BSD-style
+-- license that can be found in the LICENSE file.
+
+DROP INDEX workflows_unfinished_child_idx;
+DROP INDEX workflows_parent_idx;
+
+CREATE UNIQUE INDEX workflows_parent_idx ON workflows (parent_workflow_id, parent_task_name);
@@ -28,6 +28,20 @@ SELECT *
 FROM workflows
 WHERE id = $1;
 
+-- name: ChildWorkflows :many
+SELECT *
+FROM workflows
+WHERE parent_workflow_id = $1
+ORDER BY created_at;
+
+-- name: ChildWorkflow :one
+SELECT *
+FROM workflows
+WHERE parent_workflow_id = $1
+  AND parent_task_name = $2
+ORDER BY created_at DESC
+LIMIT 1;
+
 -- name: WorkflowCount :one
 SELECT COUNT(*)
 FROM workflows;
@@ -39,8 +53,9 @@ GROUP BY name
 ORDER BY name;
 
 -- name: CreateWorkflow :one
-INSERT INTO workflows (id, params, name, schedule_id, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO workflows (id, params, name, schedule_id, created_at, updated_at, dry_run, parent_workflow_id,
+                       parent_task_name)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: CreateTask :one
@@ -127,6 +142,17 @@ SELECT task_logs.*
 FROM task_logs
 ORDER BY created_at;
 
+-- name: CreateDryRunAction :one
+INSERT INTO dry_run_actions (workflow_id, task_name, service, action, denied, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: DryRunActionsForWorkflow :many
+SELECT dry_run_actions.*
+FROM dry_run_actions
+WHERE workflow_id = $1
+ORDER BY created_at, id;
+
 -- name: UnfinishedWorkflows :many
 SELECT workflows.*
 FROM workflows
@@ -138,7 +164,7 @@ UPDATE tasks
     started      = TRUE,
     error        = 'task interrupted before completion',
     updated_at   = $2
-WHERE workflow_id = $1 and started and not finished;
+WHERE workflow_id = $1 and started and not finished and not ready_for_approval;
 
 -- name: WorkflowFinished :one
 UPDATE workflows
@@ -164,6 +190,13 @@ WHERE workflow_id = $1
   AND name = $2
 RETURNING *;
 
+-- name: UpdateWorkflowPaused :one
+UPDATE workflows
+SET paused     = $2,
+    updated_at = $3
+WHERE id = $1
+RETURNING *;
+
 -- name: Schedules :many
 SELECT *
 FROM schedules
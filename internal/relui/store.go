@@ -12,8 +12,10 @@ import (
 
 	"github.com/golang-migrate/migrate/v4"
 	dbpgx "github.com/golang-migrate/migrate/v4/database/pgx"
+	dbsqlite3 "github.com/golang-migrate/migrate/v4/database/sqlite3"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 	"github.com/jackc/pgx/v4"
+	"golang.org/x/build/internal/relui/db"
 )
 
 var errDBNotExist = errors.New("database does not exist")
@@ -84,6 +86,48 @@ func MigrateDB(conn string, downUp bool) error {
 	return nil
 }
 
+// MigrateSQLiteDB applies all SQLite migrations to the SQLite database
+// in the file at path, creating it if it doesn't exist. The SQLite
+// schema is maintained separately from the Postgres one, in
+// migrations/sqlite, and must be kept in sync with it.
+//
+// If downUp is true, all migrations will be run, then the down and up
+// migrations of the final migration are run.
+func MigrateSQLiteDB(path string, downUp bool) error {
+	sdb, err := sql.Open("sqlite3", db.SQLiteDSN(path))
+	if err != nil {
+		return fmt.Errorf("sql.Open(%q, _) = %v, %w", "sqlite3", sdb, err)
+	}
+	defer sdb.Close()
+	mcfg := &dbsqlite3.Config{
+		MigrationsTable: "migrations",
+	}
+	mdb, err := dbsqlite3.WithInstance(sdb, mcfg)
+	if err != nil {
+		return fmt.Errorf("dbsqlite3.WithInstance(_, %v) = %v, %w", mcfg, mdb, err)
+	}
+	mfs, err := iofs.New(migrations, "migrations/sqlite")
+	if err != nil {
+		return fmt.Errorf("iofs.New(%v, %q) = %v, %w", migrations, "migrations/sqlite", mfs, err)
+	}
+	m, err := migrate.NewWithInstance("iofs", mfs, "sqlite3", mdb)
+	if err != nil {
+		return fmt.Errorf("migrate.NewWithInstance(%q, %v, %q, %v) = %v, %w", "iofs", migrations, "sqlite3", mdb, m, err)
+	}
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("m.Up() = %w", err)
+	}
+	if downUp {
+		if err := m.Steps(-1); err != nil {
+			return fmt.Errorf("m.Steps(%d) = %w", -1, err)
+		}
+		if err := m.Up(); err != nil {
+			return fmt.Errorf("m.Up() = %w", err)
+		}
+	}
+	return nil
+}
+
 // ConnectMaintenanceDB connects to the maintenance database using the
 // credentials from cfg. If maintDB is an empty string, the database
 // with the name cfg.User will be used.
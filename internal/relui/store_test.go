@@ -5,8 +5,22 @@
 package relui
 
 import (
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/build/internal/relui/db"
 )
 
 func TestCreateDBIfNotExists(t *testing.T) {
@@ -39,3 +53,314 @@ func TestCreateDBIfNotExists(t *testing.T) {
 		t.Fatalf("p.checkIfDBExists() = %t, %v, wanted %t, nil", exists, err, true)
 	}
 }
+
+// testSQLiteDB returns a migrated SQLite database in a temporary
+// directory. Unlike testDB, it doesn't need a database server.
+func testSQLiteDB(t *testing.T) *db.SQLiteDB {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "relui.db")
+	if err := MigrateSQLiteDB(path, true); err != nil {
+		t.Fatalf("MigrateSQLiteDB(%q, true) = %v, wanted no error", path, err)
+	}
+	sdb, err := db.OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("db.OpenSQLite(%q) = %v, wanted no error", path, err)
+	}
+	t.Cleanup(sdb.Close)
+	return sdb
+}
+
+func TestSQLiteDB(t *testing.T) {
+	ctx := t.Context()
+	sdb := testSQLiteDB(t)
+	q := db.New(sdb)
+
+	// Run a workflow with the Postgres listener and worker.
+	wg := sync.WaitGroup{}
+	dh := NewDefinitionHolder()
+	w := NewWorker(dh, sdb, &testWorkflowListener{
+		Listener:   &PGListener{DB: sdb},
+		onFinished: wg.Done,
+	})
+	dh.RegisterDefinition(t.Name(), newTestEchoWorkflow())
+	wg.Add(1)
+	wfid, err := w.StartWorkflow(ctx, t.Name(), map[string]any{"greeting": "greetings", "names": []string{"alice", "bob"}}, 0)
+	if err != nil {
+		t.Fatalf("w.StartWorkflow(_, %q, _, 0) = %v, %v, wanted no error", t.Name(), wfid, err)
+	}
+	go w.Run(ctx)
+	wg.Wait()
+
+	got, err := q.Workflow(ctx, wfid)
+	if err != nil {
+		t.Fatalf("q.Workflow(_, %v) = %v, %v, wanted no error", wfid, got, err)
+	}
+	var outputs map[string]string
+	if err := json.Unmarshal([]byte(got.Output), &outputs); err != nil || !got.Finished || outputs["echo"] != "greetings alice bob" {
+		t.Errorf("q.Workflow(_, %v) = %+v, want a finished workflow with output %q", wfid, got, "greetings alice bob")
+	}
+	if _, err := q.Workflow(ctx, uuid.New()); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("q.Workflow(_, unknown ID) = _, %v, want pgx.ErrNoRows", err)
+	}
+
+	// Check the queries that are rewritten for SQLite.
+	if _, err := q.CreateTaskLog(ctx, db.CreateTaskLogParams{WorkflowID: wfid, TaskName: "echo", Body: "hello"}); err != nil {
+		t.Fatalf("q.CreateTaskLog(_, _) = %v, wanted no error", err)
+	}
+	byNames, err := q.WorkflowsByNames(ctx, []string{t.Name(), "other"})
+	if err != nil || len(byNames) != 1 || byNames[0].ID != wfid {
+		t.Errorf("q.WorkflowsByNames(_, %q) = %v, %v, want workflow %v", t.Name(), byNames, err, wfid)
+	}
+	names, err := q.WorkflowNames(ctx)
+	if diff := cmp.Diff([]string{t.Name()}, names); err != nil || diff != "" {
+		t.Errorf("q.WorkflowNames() = %v, %v, want %q", names, err, t.Name())
+	}
+	tasks, err := q.TasksForWorkflowSorted(ctx, wfid)
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("q.TasksForWorkflowSorted(_, %v) = %v, %v, want one task", wfid, tasks, err)
+	}
+	if mru := tasks[0].MostRecentUpdate; mru.Before(tasks[0].UpdatedAt) || time.Since(mru) > time.Minute {
+		t.Errorf("task %q MostRecentUpdate = %v, want a time after its UpdatedAt %v", tasks[0].Name, mru, tasks[0].UpdatedAt)
+	}
+	if all, err := q.Tasks(ctx); err != nil || len(all) != 1 {
+		t.Errorf("q.Tasks() = %v, %v, want one task", all, err)
+	}
+
+	sched, err := q.CreateSchedule(ctx, db.CreateScheduleParams{WorkflowName: t.Name(), Spec: "@daily", CreatedAt: time.Now(), UpdatedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("q.CreateSchedule(_, _) = %v, %v, wanted no error", sched, err)
+	}
+	scheduled := uuid.New()
+	for i, id := range []uuid.UUID{uuid.New(), scheduled} {
+		created := time.Now().Add(time.Duration(i) * time.Minute)
+		cwp := db.CreateWorkflowParams{ID: id, Name: nullString(t.Name()), ScheduleID: sql.NullInt32{Int32: sched.ID, Valid: true}, CreatedAt: created, UpdatedAt: created}
+		if _, err := q.CreateWorkflow(ctx, cwp); err != nil {
+			t.Fatalf("q.CreateWorkflow(_, %v) = %v, wanted no error", cwp, err)
+		}
+	}
+	lastRuns, err := q.SchedulesLastRun(ctx)
+	if err != nil || len(lastRuns) != 1 || lastRuns[0].WorkflowID != scheduled || !lastRuns[0].WorkflowCreatedAt.Valid {
+		t.Errorf("q.SchedulesLastRun() = %+v, %v, want the last run %v", lastRuns, err, scheduled)
+	}
+	cleared, err := q.ClearWorkflowSchedule(ctx, sched.ID)
+	if err != nil || len(cleared) != 2 {
+		t.Errorf("q.ClearWorkflowSchedule(_, %d) = %v, %v, want two workflows", sched.ID, cleared, err)
+	}
+}
+
+// TestSQLiteQueries runs every query in package db against SQLite, to
+// catch Postgres-only SQL that toSQLite doesn't translate. Each query
+// runs in its own transaction, which is rolled back, against the same
+// seeded rows.
+func TestSQLiteQueries(t *testing.T) {
+	ctx := t.Context()
+	sdb := testSQLiteDB(t)
+	q := db.New(sdb)
+
+	now := time.Now()
+	sched, err := q.CreateSchedule(ctx, db.CreateScheduleParams{WorkflowName: "wf", Spec: "@daily", CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatalf("q.CreateSchedule(_, _) = %v, %v, wanted no error", sched, err)
+	}
+	// Schedules can only be deleted once no workflows refer to them.
+	unused, err := q.CreateSchedule(ctx, db.CreateScheduleParams{WorkflowName: "wf", Spec: "@weekly", CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatalf("q.CreateSchedule(_, _) = %v, %v, wanted no error", unused, err)
+	}
+	wfID, childID := uuid.New(), uuid.New()
+	for _, cwp := range []db.CreateWorkflowParams{
+		{ID: wfID, Name: nullString("wf"), Params: nullString("{}"), ScheduleID: sql.NullInt32{Int32: sched.ID, Valid: true}, CreatedAt: now, UpdatedAt: now},
+		{ID: childID, Name: nullString("child"), Params: nullString("{}"), CreatedAt: now, UpdatedAt: now, ParentWorkflowID: uuid.NullUUID{UUID: wfID, Valid: true}, ParentTaskName: nullString("task")},
+	} {
+		if _, err := q.CreateWorkflow(ctx, cwp); err != nil {
+			t.Fatalf("q.CreateWorkflow(_, %v) = %v, wanted no error", cwp, err)
+		}
+	}
+	if _, err := q.CreateTask(ctx, db.CreateTaskParams{WorkflowID: wfID, Name: "task", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("q.CreateTask(_, _) = %v, wanted no error", err)
+	}
+	if _, err := q.CreateTaskLog(ctx, db.CreateTaskLogParams{WorkflowID: wfID, TaskName: "task", Body: "log"}); err != nil {
+		t.Fatalf("q.CreateTaskLog(_, _) = %v, wanted no error", err)
+	}
+	if _, err := q.CreateDryRunAction(ctx, db.CreateDryRunActionParams{WorkflowID: wfID, TaskName: "task", Service: "gerrit", Action: "tag", CreatedAt: now}); err != nil {
+		t.Fatalf("q.CreateDryRunAction(_, _) = %v, wanted no error", err)
+	}
+
+	// The arguments for each query, after the context. Queries that
+	// return a slice must return at least one of the seeded rows.
+	args := map[string][]any{
+		"ApproveTask":                {db.ApproveTaskParams{WorkflowID: wfID, Name: "task", ApprovedAt: sql.NullTime{Time: now, Valid: true}}},
+		"ChildWorkflow":              {db.ChildWorkflowParams{ParentWorkflowID: uuid.NullUUID{UUID: wfID, Valid: true}, ParentTaskName: nullString("task")}},
+		"ChildWorkflows":             {uuid.NullUUID{UUID: wfID, Valid: true}},
+		"ClearWorkflowSchedule":      {sched.ID},
+		"CreateDryRunAction":         {db.CreateDryRunActionParams{WorkflowID: wfID, TaskName: "task", Service: "mail", Action: "send", Denied: true, CreatedAt: now}},
+		"CreateSchedule":             {db.CreateScheduleParams{WorkflowName: "wf", Spec: "@hourly", CreatedAt: now, UpdatedAt: now}},
+		"CreateTask":                 {db.CreateTaskParams{WorkflowID: wfID, Name: "new task", CreatedAt: now, UpdatedAt: now}},
+		"CreateTaskLog":              {db.CreateTaskLogParams{WorkflowID: wfID, TaskName: "task", Body: "another log"}},
+		"CreateWorkflow":             {db.CreateWorkflowParams{ID: uuid.New(), Name: nullString("wf"), CreatedAt: now, UpdatedAt: now, DryRun: true}},
+		"DeleteSchedule":             {unused.ID},
+		"DryRunActionsForWorkflow":   {wfID},
+		"FailUnfinishedTasks":        {db.FailUnfinishedTasksParams{WorkflowID: wfID, UpdatedAt: now}},
+		"Schedules":                  nil,
+		"SchedulesLastRun":           nil,
+		"Task":                       {db.TaskParams{WorkflowID: wfID, Name: "task"}},
+		"TaskLogs":                   nil,
+		"TaskLogsForTask":            {db.TaskLogsForTaskParams{WorkflowID: wfID, TaskName: "task"}},
+		"TaskLogsForWorkflow":        {wfID},
+		"Tasks":                      nil,
+		"TasksForWorkflow":           {wfID},
+		"TasksForWorkflowSorted":     {wfID},
+		"UnfinishedWorkflows":        nil,
+		"UpdateTaskReadyForApproval": {db.UpdateTaskReadyForApprovalParams{WorkflowID: wfID, Name: "task", ReadyForApproval: true}},
+		"UpdateWorkflowPaused":       {db.UpdateWorkflowPausedParams{ID: wfID, Paused: true, UpdatedAt: now}},
+		"UpsertTask":                 {db.UpsertTaskParams{WorkflowID: wfID, Name: "task", Started: true, CreatedAt: now, UpdatedAt: now, RetryCount: 1}},
+		"Workflow":                   {wfID},
+		"WorkflowCount":              nil,
+		"WorkflowFinished":           {db.WorkflowFinishedParams{ID: wfID, Finished: true, Output: "{}", UpdatedAt: now}},
+		"WorkflowNames":              nil,
+		"WorkflowSidebar":            nil,
+		"Workflows":                  nil,
+		"WorkflowsByName":            {nullString("wf")},
+		"WorkflowsByNames":           {[]string{"wf", "child"}},
+	}
+	errRollback := errors.New("roll back")
+	qt := reflect.TypeOf(q)
+	for i := range qt.NumMethod() {
+		m := qt.Method(i)
+		if m.Name == "WithTx" {
+			continue
+		}
+		t.Run(m.Name, func(t *testing.T) {
+			margs, ok := args[m.Name]
+			if !ok {
+				t.Fatalf("no arguments for query %s; add them to the test", m.Name)
+			}
+			err := sdb.BeginFunc(ctx, func(tx pgx.Tx) error {
+				in := []reflect.Value{reflect.ValueOf(q.WithTx(tx)), reflect.ValueOf(ctx)}
+				for _, a := range margs {
+					in = append(in, reflect.ValueOf(a))
+				}
+				out := m.Func.Call(in)
+				if err, _ := out[len(out)-1].Interface().(error); err != nil {
+					return err
+				}
+				if len(out) == 2 && out[0].Kind() == reflect.Slice && out[0].Len() == 0 {
+					return errors.New("no rows returned")
+				}
+				return errRollback
+			})
+			if !errors.Is(err, errRollback) {
+				t.Errorf("q.%s(_, %v) = %v, wanted no error", m.Name, margs, err)
+			}
+		})
+	}
+}
+
+// sqliteSquashedMigration is the first SQLite migration, which creates
+// the schema of all Postgres migrations before it. Each later Postgres
+// migration has a SQLite counterpart with the same name.
+const sqliteSquashedMigration = "20261018140000_create_tables"
+
+func TestSQLiteMigrationsMatch(t *testing.T) {
+	list := func(dir string) []string {
+		t.Helper()
+		entries, err := fs.ReadDir(migrations, dir)
+		if err != nil {
+			t.Fatalf("fs.ReadDir(migrations, %q) = %v, wanted no error", dir, err)
+		}
+		var names []string
+		for _, e := range entries {
+			if !e.IsDir() && e.Name() > sqliteSquashedMigration+".up.sql" {
+				names = append(names, e.Name())
+			}
+		}
+		return names
+	}
+	if diff := cmp.Diff(list("migrations"), list("migrations/sqlite")); diff != "" {
+		t.Errorf("Postgres and SQLite migrations differ (-postgres +sqlite):\n%s", diff)
+	}
+}
+
+// sqliteColumns returns the column names of each table in the SQLite
+// database, sorted.
+func sqliteColumns(t *testing.T, sdb *db.SQLiteDB) map[string][]string {
+	t.Helper()
+	rows, err := sdb.Query(t.Context(), `SELECT m.name, p.name FROM sqlite_schema m, pragma_table_info(m.name) p WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%' AND m.name != 'migrations'`)
+	if err != nil {
+		t.Fatalf("listing SQLite columns: %v", err)
+	}
+	return scanColumns(t, rows)
+}
+
+func scanColumns(t *testing.T, rows pgx.Rows) map[string][]string {
+	t.Helper()
+	defer rows.Close()
+	cols := map[string][]string{}
+	for rows.Next() {
+		var table, col string
+		if err := rows.Scan(&table, &col); err != nil {
+			t.Fatalf("rows.Scan() = %v, wanted no error", err)
+		}
+		cols[table] = append(cols[table], col)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("rows.Err() = %v, wanted no error", err)
+	}
+	for _, c := range cols {
+		slices.Sort(c)
+	}
+	return cols
+}
+
+// TestSQLiteSchemaMatchesModels checks the SQLite schema against the
+// models sqlc generates from the Postgres schema, so that it runs
+// without a database server.
+func TestSQLiteSchemaMatchesModels(t *testing.T) {
+	models := map[string]any{
+		"dry_run_actions": db.DryRunAction{},
+		"schedules":       db.Schedule{},
+		"tasks":           db.Task{},
+		"task_logs":       db.TaskLog{},
+		"workflows":       db.Workflow{},
+	}
+	want := map[string][]string{}
+	for table, m := range models {
+		mt := reflect.TypeOf(m)
+		for i := range mt.NumField() {
+			want[table] = append(want[table], snakeCase(mt.Field(i).Name))
+		}
+		slices.Sort(want[table])
+	}
+	if diff := cmp.Diff(want, sqliteColumns(t, testSQLiteDB(t))); diff != "" {
+		t.Errorf("SQLite schema doesn't match the db models (-models +sqlite):\n%s", diff)
+	}
+}
+
+// snakeCase converts an sqlc field name, such as WorkflowID, back to
+// its column name, workflow_id.
+func snakeCase(s string) string {
+	s = strings.ReplaceAll(s, "ID", "Id")
+	var b strings.Builder
+	for i, r := range s {
+		if 'A' <= r && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func TestSQLiteSchemaMatchesPostgres(t *testing.T) {
+	ctx := t.Context()
+	p := testDB(ctx, t)
+	rows, err := p.Query(ctx, `SELECT table_name, column_name FROM information_schema.columns WHERE table_schema = 'public' AND table_name != 'migrations'`)
+	if err != nil {
+		t.Fatalf("listing Postgres columns: %v", err)
+	}
+	if diff := cmp.Diff(scanColumns(t, rows), sqliteColumns(t, testSQLiteDB(t))); diff != "" {
+		t.Errorf("SQLite schema doesn't match Postgres (-postgres +sqlite):\n%s", diff)
+	}
+}
@@ -94,12 +94,17 @@ func NewServer(p db.PGDBTX, w *Worker, baseURL *url.URL, header SiteHeader, ms *
 	s.homeTmpl = s.mustLookup("home.html")
 	s.newWorkflowTmpl = s.mustLookup("new_workflow.html")
 	s.m.HandleFunc("GET /workflows/{id}", s.showWorkflowHandler)
+	s.m.HandleFunc("GET /workflows/{id}/dry-run", s.dryRunActionsHandler)
 	s.m.HandleFunc("POST /workflows/{id}/stop", s.stopWorkflowHandler)
+	s.m.HandleFunc("POST /workflows/{id}/pause", s.pauseWorkflowHandler)
+	s.m.HandleFunc("POST /workflows/{id}/unpause", s.unpauseWorkflowHandler)
 	s.m.HandleFunc("POST /workflows/{id}/tasks/{name}/retry", s.retryTaskHandler)
 	s.m.HandleFunc("POST /workflows/{id}/tasks/{name}/approve", s.approveTaskHandler)
+	s.m.HandleFunc("POST /workflows/{id}/tasks/{name}/cancel", s.cancelTaskHandler)
 	s.m.HandleFunc("POST /schedules/{id}/delete", s.deleteScheduleHandler)
 	s.m.Handle("GET /metrics", ms)
 	s.m.HandleFunc("GET /new_workflow", s.newWorkflowHandler)
+	s.m.HandleFunc("GET /new_workflow/graph", s.workflowGraphHandler)
 	s.m.HandleFunc("POST /workflows", s.createWorkflowHandler)
 	s.m.ServeFiles("GET /static/", static)
 	s.m.HandleFunc("GET /{$}", s.homeHandler)
@@ -258,6 +263,56 @@ type showWorkflowResponse struct {
 	// TaskLogs is a map of all logs for a db.Task, keyed on
 	// (db.Task).Name
 	TaskLogs map[string][]db.TaskLog
+	// Tree is the tree of workflows the workflow belongs to, starting
+	// at its outermost parent. It's nil if the workflow has no parent
+	// and no children.
+	Tree *workflowTree
+}
+
+// A workflowTree is a workflow and the child workflows started by its
+// tasks, oldest first.
+type workflowTree struct {
+	Workflow db.Workflow
+	Children []*workflowTree
+	// Current is whether the tree's workflow is the one being shown.
+	Current bool
+}
+
+// maxWorkflowTreeDepth bounds how many levels of parents and children
+// buildWorkflowTree follows.
+const maxWorkflowTreeDepth = 16
+
+// buildWorkflowTree returns the tree of workflows that w belongs to,
+// starting at its outermost parent.
+func buildWorkflowTree(ctx context.Context, q *db.Queries, w db.Workflow) (*workflowTree, error) {
+	root := w
+	for i := 0; root.ParentWorkflowID.Valid && i < maxWorkflowTreeDepth; i++ {
+		parent, err := q.Workflow(ctx, root.ParentWorkflowID.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("q.Workflow(_, %v) = %w", root.ParentWorkflowID.UUID, err)
+		}
+		root = parent
+	}
+	var build func(wf db.Workflow, depth int) (*workflowTree, error)
+	build = func(wf db.Workflow, depth int) (*workflowTree, error) {
+		t := &workflowTree{Workflow: wf, Current: wf.ID == w.ID}
+		if depth >= maxWorkflowTreeDepth {
+			return t, nil
+		}
+		children, err := q.ChildWorkflows(ctx, uuid.NullUUID{UUID: wf.ID, Valid: true})
+		if err != nil {
+			return nil, fmt.Errorf("q.ChildWorkflows(_, %v) = %w", wf.ID, err)
+		}
+		for _, c := range children {
+			ct, err := build(c, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			t.Children = append(t.Children, ct)
+		}
+		return t, nil
+	}
+	return build(root, 0)
 }
 
 func (s *Server) showWorkflowHandler(w http.ResponseWriter, r *http.Request) {
@@ -299,11 +354,19 @@ func (s *Server) buildShowWorkflowResponse(ctx context.Context, id uuid.UUID) (*
 	if err != nil {
 		return nil, err
 	}
+	tree, err := buildWorkflowTree(ctx, q, w)
+	if err != nil {
+		return nil, err
+	}
+	if len(tree.Children) == 0 {
+		tree = nil
+	}
 	sr := &showWorkflowResponse{
 		SiteHeader: s.header,
 		TaskLogs:   make(map[string][]db.TaskLog),
 		Tasks:      tasks,
 		Workflow:   w,
+		Tree:       tree,
 	}
 	sr.SiteHeader.Subtitle = w.Name.String
 	sr.SiteHeader.NameParam = w.Name.String
@@ -313,6 +376,35 @@ func (s *Server) buildShowWorkflowResponse(ctx context.Context, id uuid.UUID) (*
 	return sr, nil
 }
 
+// dryRunActionsHandler serves the actions recorded by a dry-run
+// workflow as JSON.
+func (s *Server) dryRunActionsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		log.Printf("dryRunActionsHandler: uuid.Parse(%q): %v", r.PathValue("id"), err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if !s.authorizedForWorkflowID(w, r, id) {
+		// authorizedForWorkflowID writes errors to w itself.
+		return
+	}
+	actions, err := (&DBDryRunStore{DB: s.db}).DryRunActions(r.Context(), id)
+	if err != nil {
+		log.Printf("dryRunActionsHandler: DryRunActions(_, %q): %v", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	out := bytes.Buffer{}
+	if err := json.NewEncoder(&out).Encode(actions); err != nil {
+		log.Printf("dryRunActionsHandler: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	io.Copy(w, &out)
+}
+
 type newWorkflowResponse struct {
 	SiteHeader      SiteHeader
 	Definitions     map[string]*workflow.Definition
@@ -320,12 +412,24 @@ type newWorkflowResponse struct {
 	ScheduleTypes   []ScheduleType
 	Schedule        ScheduleType
 	ScheduleMinTime string
+	DryRunEnabled   bool
 }
 
 func (n *newWorkflowResponse) Selected() *workflow.Definition {
 	return n.Definitions[n.Name]
 }
 
+// GraphTasks returns the tasks of the selected workflow
+// in the order they can run.
+func (n *newWorkflowResponse) GraphTasks() []workflow.GraphTask {
+	return n.Selected().Graph().Tasks
+}
+
+// Problems returns the problems found in the selected workflow definition.
+func (n *newWorkflowResponse) Problems() []workflow.Problem {
+	return n.Selected().Validate()
+}
+
 // newWorkflowHandler presents a form for creating a new workflow.
 func (s *Server) newWorkflowHandler(w http.ResponseWriter, r *http.Request) {
 	out := bytes.Buffer{}
@@ -339,6 +443,7 @@ func (s *Server) newWorkflowHandler(w http.ResponseWriter, r *http.Request) {
 		ScheduleTypes:   ScheduleTypes,
 		Schedule:        ScheduleImmediate,
 		ScheduleMinTime: time.Now().UTC().Format(DatetimeLocalLayout),
+		DryRunEnabled:   s.w.DryRunEnabled(),
 	}
 	resp.SiteHeader.NameParam = name
 	selectedSchedule := ScheduleType(r.FormValue("workflow.schedule"))
@@ -353,6 +458,34 @@ func (s *Server) newWorkflowHandler(w http.ResponseWriter, r *http.Request) {
 	io.Copy(w, &out)
 }
 
+// workflowGraphHandler serves the graph of the workflow definition
+// named by the "workflow.name" form value, as JSON by default,
+// or as Graphviz DOT if the "format" form value is "dot".
+func (s *Server) workflowGraphHandler(w http.ResponseWriter, r *http.Request) {
+	d := s.w.dh.Definition(r.FormValue("workflow.name"))
+	if d == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	var out bytes.Buffer
+	switch r.FormValue("format") {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(&out).Encode(d.Graph()); err != nil {
+			log.Printf("workflowGraphHandler: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	case "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		d.Graph().WriteDOT(&out)
+	default:
+		http.Error(w, fmt.Sprintf("unknown graph format %q", r.FormValue("format")), http.StatusBadRequest)
+		return
+	}
+	io.Copy(w, &out)
+}
+
 // createWorkflowHandler persists a new workflow in the datastore, and
 // starts the workflow in a goroutine.
 func (s *Server) createWorkflowHandler(w http.ResponseWriter, r *http.Request) {
@@ -417,8 +550,25 @@ func (s *Server) createWorkflowHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	var dryRun bool
+	switch v := r.FormValue("workflow.dryrun"); v {
+	case "on":
+		dryRun = true
+	case "":
+	default:
+		http.Error(w, fmt.Sprintf("parameter %q has an unexpected value %q", "workflow.dryrun", v), http.StatusBadRequest)
+		return
+	}
+	if dryRun && !s.w.DryRunEnabled() {
+		http.Error(w, "dry-run mode isn't enabled", http.StatusBadRequest)
+		return
+	}
 	sched := Schedule{Type: ScheduleType(r.FormValue("workflow.schedule"))}
 	if sched.Type != ScheduleImmediate {
+		if dryRun {
+			http.Error(w, "dry-run workflows can't be scheduled", http.StatusBadRequest)
+			return
+		}
 		switch sched.Type {
 		case ScheduleOnce:
 			t, err := time.ParseInLocation(DatetimeLocalLayout, r.FormValue("workflow.schedule.datetime"), time.UTC)
@@ -441,7 +591,13 @@ func (s *Server) createWorkflowHandler(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, s.BaseLink("/"), http.StatusSeeOther)
 		return
 	}
-	id, err := s.w.StartWorkflow(r.Context(), name, params, 0)
+	var id uuid.UUID
+	var err error
+	if dryRun {
+		id, err = s.w.StartDryRunWorkflow(r.Context(), name, params)
+	} else {
+		id, err = s.w.StartWorkflow(r.Context(), name, params, 0)
+	}
 	if err != nil {
 		log.Printf("s.w.StartWorkflow(%v, %v, %v): %v", r.Context(), d, params, err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -490,7 +646,7 @@ func (s *Server) approveTaskHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	q := db.New(s.db)
-	workflow, err := q.Workflow(r.Context(), id)
+	wf, err := q.Workflow(r.Context(), id)
 	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, pgx.ErrNoRows) {
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
@@ -499,7 +655,7 @@ func (s *Server) approveTaskHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
-	d := s.w.dh.Definition(workflow.Name.String)
+	d := s.w.dh.Definition(wf.Name.String)
 	if d == nil {
 		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 		return
@@ -508,9 +664,38 @@ func (s *Server) approveTaskHandler(w http.ResponseWriter, r *http.Request) {
 		// authorizedForWorkflow writes errors to w itself.
 		return
 	}
+	// Tasks defined with workflow.Approval or workflow.Input wait for their
+	// value to be supplied, rather than polling for the approval. Supply it
+	// before recording the approval: a task marked approved no longer shows
+	// the approve control, so one that didn't get its value would be stuck.
+	name := r.PathValue("name")
+	input := r.FormValue("task.input")
+	if input == "" {
+		input = "{}"
+	}
+	if !json.Valid([]byte(input)) {
+		http.Error(w, fmt.Sprintf("task input %q is not valid JSON", input), http.StatusBadRequest)
+		return
+	}
+	switch err := s.w.SupplyInput(r.Context(), id, name, []byte(input)); {
+	case err == nil, errors.Is(err, workflow.ErrNotInput):
+	case errors.Is(err, errWorkflowNotRunning):
+		// Tasks that poll for their approval will see it once the
+		// workflow runs again, but an input's value would be lost.
+		// Inputs in If and Switch branches or added by expansions
+		// aren't in the graph, so only approve tasks known to poll.
+		if !pollsForApproval(d, name) {
+			http.Error(w, "the workflow isn't running; resume it before approving this task", http.StatusConflict)
+			return
+		}
+	default:
+		log.Printf("s.w.SupplyInput(_, %q, %q, %q): %v", id, name, input, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	t, err := q.ApproveTask(r.Context(), db.ApproveTaskParams{
 		WorkflowID: id,
-		Name:       r.PathValue("name"),
+		Name:       name,
 		ApprovedAt: sql.NullTime{Time: time.Now(), Valid: true},
 	})
 	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, pgx.ErrNoRows) {
@@ -525,6 +710,89 @@ func (s *Server) approveTaskHandler(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, s.BaseLink("/workflows", id.String()), http.StatusSeeOther)
 }
 
+// pollsForApproval reports whether the task named name is a task or
+// action in d's graph, which may poll for its approval, rather than an
+// Input or Approval, which waits for its value to be supplied. Tasks in
+// If and Switch branches or added by expansions aren't in the graph.
+func pollsForApproval(d *workflow.Definition, name string) bool {
+	for _, t := range d.Graph().Tasks {
+		if t.Name == name {
+			return t.Kind == workflow.KindTask || t.Kind == workflow.KindAction
+		}
+	}
+	return false
+}
+
+func (s *Server) cancelTaskHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		log.Printf("cancelTaskHandler: uuid.Parse(%q): %v", r.PathValue("id"), err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if !s.authorizedForWorkflowID(w, r, id) {
+		// authorizedForWorkflowID writes errors to w itself.
+		return
+	}
+	if err := s.w.CancelTask(r.Context(), id, r.PathValue("name")); err != nil {
+		log.Printf("s.w.CancelTask(_, %q, %q): %v", id, r.PathValue("name"), err)
+	}
+	http.Redirect(w, r, s.BaseLink("/workflows", id.String()), http.StatusSeeOther)
+}
+
+func (s *Server) pauseWorkflowHandler(w http.ResponseWriter, r *http.Request) {
+	s.setWorkflowPaused(w, r, true)
+}
+
+func (s *Server) unpauseWorkflowHandler(w http.ResponseWriter, r *http.Request) {
+	s.setWorkflowPaused(w, r, false)
+}
+
+func (s *Server) setWorkflowPaused(w http.ResponseWriter, r *http.Request, paused bool) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		log.Printf("setWorkflowPaused: uuid.Parse(%q): %v", r.PathValue("id"), err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if !s.authorizedForWorkflowID(w, r, id) {
+		// authorizedForWorkflowID writes errors to w itself.
+		return
+	}
+	if paused {
+		err = s.w.PauseWorkflow(r.Context(), id)
+	} else {
+		err = s.w.UnpauseWorkflow(r.Context(), id)
+	}
+	if err != nil {
+		log.Printf("setWorkflowPaused(_, %q, %v): %v", id, paused, err)
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	http.Redirect(w, r, s.BaseLink("/workflows", id.String()), http.StatusSeeOther)
+}
+
+// authorizedForWorkflowID reports whether the user is authorized to
+// interact with the workflow with the given ID. If not, or if the
+// workflow doesn't exist, it writes an error response to w.
+func (s *Server) authorizedForWorkflowID(w http.ResponseWriter, r *http.Request, id uuid.UUID) bool {
+	wf, err := db.New(s.db).Workflow(r.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, pgx.ErrNoRows) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return false
+	} else if err != nil {
+		log.Printf("authorizedForWorkflowID: Workflow(%v): %v", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return false
+	}
+	d := s.w.dh.Definition(wf.Name.String)
+	if d == nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return false
+	}
+	return s.authorizedForWorkflow(r.Context(), d, w, r)
+}
+
 func (s *Server) stopWorkflowHandler(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
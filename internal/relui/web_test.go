@@ -8,6 +8,7 @@ import (
 	"context"
 	"database/sql"
 	"embed"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
@@ -32,6 +33,7 @@ import (
 	"golang.org/x/build/internal/criadb"
 	"golang.org/x/build/internal/releasetargets"
 	"golang.org/x/build/internal/relui/db"
+	"golang.org/x/build/internal/task"
 	"golang.org/x/build/internal/workflow"
 )
 
@@ -171,6 +173,66 @@ func TestServerNewWorkflowHandler(t *testing.T) {
 	}
 }
 
+func TestServerWorkflowGraphHandler(t *testing.T) {
+	cases := []struct {
+		desc            string
+		params          url.Values
+		wantCode        int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			desc:            "json",
+			params:          url.Values{"workflow.name": []string{"echo"}},
+			wantCode:        http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        `"Name":"greeting"`,
+		},
+		{
+			desc:            "dot",
+			params:          url.Values{"workflow.name": []string{"echo"}, "format": []string{"dot"}},
+			wantCode:        http.StatusOK,
+			wantContentType: "text/vnd.graphviz; charset=utf-8",
+			wantBody:        `"parameter greeting" -> "task greeting";`,
+		},
+		{
+			desc:     "unknown format",
+			params:   url.Values{"workflow.name": []string{"echo"}, "format": []string{"svg"}},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			desc:     "unknown workflow",
+			params:   url.Values{"workflow.name": []string{"this workflow does not exist"}},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			u := url.URL{Path: "/new_workflow/graph", RawQuery: c.params.Encode()}
+			req := httptest.NewRequest(http.MethodGet, u.String(), nil)
+			w := httptest.NewRecorder()
+
+			s := &Server{w: NewWorker(NewDefinitionHolder(), nil, nil)}
+			s.workflowGraphHandler(w, req)
+			resp := w.Result()
+
+			if resp.StatusCode != c.wantCode {
+				t.Errorf("resp.StatusCode = %d, wanted %d", resp.StatusCode, c.wantCode)
+			}
+			if c.wantCode != http.StatusOK {
+				return
+			}
+			if ct := resp.Header.Get("Content-Type"); ct != c.wantContentType {
+				t.Errorf("Content-Type = %q, wanted %q", ct, c.wantContentType)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if !strings.Contains(string(body), c.wantBody) {
+				t.Errorf("body = %s, wanted it to contain %s", body, c.wantBody)
+			}
+		})
+	}
+}
+
 func TestServerCreateWorkflowHandler(t *testing.T) {
 	ctx := t.Context()
 
@@ -673,6 +735,256 @@ func TestServerApproveTaskHandler(t *testing.T) {
 	}
 }
 
+func TestServerApproveTaskHandlerInput(t *testing.T) {
+	ctx := t.Context()
+	p := testSQLiteDB(t)
+	q := db.New(p)
+
+	dh := NewDefinitionHolder()
+	wd := workflow.New(workflow.ACL{})
+	workflow.Output(wd, "number", workflow.Input[int](wd, "pick a number"))
+	dh.RegisterDefinition(t.Name(), wd)
+	finished := make(chan struct{})
+	w := NewWorker(dh, p, &testWorkflowListener{
+		Listener:   &PGListener{DB: p},
+		onFinished: func() { close(finished) },
+	})
+	s := NewServer(p, w, nil, SiteHeader{}, nil, nil)
+	wfID, err := w.StartWorkflow(ctx, t.Name(), nil, 0)
+	if err != nil {
+		t.Fatalf("w.StartWorkflow(_, %q, nil, 0) = %v, %v, wanted no error", t.Name(), wfID, err)
+	}
+	go w.Run(ctx)
+
+	taskParams := db.TaskParams{WorkflowID: wfID, Name: "pick a number"}
+	for {
+		task, err := q.Task(ctx, taskParams)
+		if err == nil && task.ReadyForApproval {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("task %q never awaited input: %v", taskParams.Name, ctx.Err())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	approve := func(input string) *http.Response {
+		form := url.Values{"task.input": {input}}
+		req := httptest.NewRequest(http.MethodPost, path.Join("/workflows/", wfID.String(), "tasks", url.PathEscape(taskParams.Name), "approve"), strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		s.m.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+	for _, input := range []string{"", "forty-two", `"42"`} {
+		if resp := approve(input); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("approving with input %q: resp.StatusCode = %d, wanted %d", input, resp.StatusCode, http.StatusBadRequest)
+		}
+		task, err := q.Task(ctx, taskParams)
+		if err != nil {
+			t.Fatalf("q.Task(_, %v) = %v, %v, wanted no error", taskParams, task, err)
+		}
+		if task.ApprovedAt.Valid || task.Finished {
+			t.Errorf("after approving with input %q: task = %+v, wanted it unapproved and unfinished", input, task)
+		}
+	}
+
+	if resp := approve("42"); resp.StatusCode != http.StatusSeeOther {
+		t.Errorf("approving with input %q: resp.StatusCode = %d, wanted %d", "42", resp.StatusCode, http.StatusSeeOther)
+	}
+	<-finished
+	task, err := q.Task(ctx, taskParams)
+	if err != nil || !task.ApprovedAt.Valid {
+		t.Errorf("q.Task(_, %v) = %+v, %v, wanted an approved task", taskParams, task, err)
+	}
+	got, err := q.Workflow(ctx, wfID)
+	if err != nil {
+		t.Fatalf("q.Workflow(_, %v) = %+v, %v, wanted no error", wfID, got, err)
+	}
+	var outputs map[string]int
+	if err := json.Unmarshal([]byte(got.Output), &outputs); err != nil || outputs["number"] != 42 {
+		t.Errorf("q.Workflow(_, %v) has output %s, wanted number 42", wfID, got.Output)
+	}
+}
+
+func TestServerShowWorkflowTree(t *testing.T) {
+	ctx := t.Context()
+	p := testSQLiteDB(t)
+	q := db.New(p)
+	s := NewServer(p, NewWorker(NewDefinitionHolder(), p, &PGListener{DB: p}), nil, SiteHeader{}, nil, nil)
+
+	create := func(name string, parent uuid.UUID, task string) uuid.UUID {
+		cwp := db.CreateWorkflowParams{
+			ID:               uuid.New(),
+			Name:             nullString(name),
+			Params:           nullString("{}"),
+			CreatedAt:        time.Now(),
+			ParentWorkflowID: uuid.NullUUID{UUID: parent, Valid: parent != uuid.Nil},
+			ParentTaskName:   sql.NullString{String: task, Valid: task != ""},
+		}
+		if _, err := q.CreateWorkflow(ctx, cwp); err != nil {
+			t.Fatalf("q.CreateWorkflow(_, %v) = %v, wanted no error", cwp, err)
+		}
+		return cwp.ID
+	}
+	root := create("root", uuid.Nil, "")
+	child := create("child", root, "release")
+	grandchild := create("grandchild", child, "build")
+	sibling := create("sibling", root, "announce")
+	lonely := create("lonely", uuid.Nil, "")
+
+	resp, err := s.buildShowWorkflowResponse(ctx, child)
+	if err != nil {
+		t.Fatalf("s.buildShowWorkflowResponse(_, %v) = %v, wanted no error", child, err)
+	}
+	type node struct {
+		ID       uuid.UUID
+		Current  bool
+		Children []node
+	}
+	var flatten func(*workflowTree) node
+	flatten = func(wt *workflowTree) node {
+		n := node{ID: wt.Workflow.ID, Current: wt.Current}
+		for _, c := range wt.Children {
+			n.Children = append(n.Children, flatten(c))
+		}
+		return n
+	}
+	want := node{ID: root, Children: []node{
+		{ID: child, Current: true, Children: []node{{ID: grandchild}}},
+		{ID: sibling},
+	}}
+	if resp.Tree == nil {
+		t.Fatalf("s.buildShowWorkflowResponse(_, %v).Tree = nil, want a tree", child)
+	}
+	if diff := cmp.Diff(want, flatten(resp.Tree)); diff != "" {
+		t.Errorf("workflow tree mismatch (-want +got):\n%s", diff)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/workflows/"+child.String(), nil)
+	rec := httptest.NewRecorder()
+	s.m.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s: rec.Code = %d, wanted %d", req.URL, rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+	for _, id := range []uuid.UUID{root, grandchild, sibling} {
+		if !strings.Contains(body, "/workflows/"+id.String()) {
+			t.Errorf("GET %s: body doesn't link to workflow %v", req.URL, id)
+		}
+	}
+
+	if resp, err := s.buildShowWorkflowResponse(ctx, lonely); err != nil || resp.Tree != nil {
+		t.Errorf("s.buildShowWorkflowResponse(_, %v) = %+v, %v, want no tree", lonely, resp, err)
+	}
+}
+
+func TestServerApproveTaskHandlerNotRunning(t *testing.T) {
+	ctx := t.Context()
+	p := testSQLiteDB(t)
+	q := db.New(p)
+
+	dh := NewDefinitionHolder()
+	wd := workflow.New(workflow.ACL{})
+	number := workflow.Input[int](wd, "pick a number")
+	workflow.Action0(wd, "wait for approval", func(context.Context) error { return nil })
+	branch := workflow.If(wd, "maybe ask", workflow.Const(true), func(d *workflow.Definition) (workflow.Value[int], error) {
+		return workflow.Input[int](d, "branch input"), nil
+	}, func(d *workflow.Definition) (workflow.Value[int], error) {
+		return number, nil
+	})
+	workflow.Output(wd, "number", branch)
+	dh.RegisterDefinition(t.Name(), wd)
+	s := NewServer(p, NewWorker(dh, p, &PGListener{DB: p}), nil, SiteHeader{}, nil, nil)
+
+	// The workflow isn't running in this worker, as after a restart
+	// before it's resumed.
+	wfID := uuid.New()
+	if _, err := q.CreateWorkflow(ctx, db.CreateWorkflowParams{ID: wfID, Name: nullString(t.Name()), Params: nullString("{}")}); err != nil {
+		t.Fatalf("q.CreateWorkflow(_, %v) = %v, wanted no error", wfID, err)
+	}
+	for _, c := range []struct {
+		task     string
+		wantCode int
+	}{
+		{"pick a number", http.StatusConflict},
+		{"branch input", http.StatusConflict},
+		{"wait for approval", http.StatusSeeOther},
+	} {
+		ctp := db.CreateTaskParams{WorkflowID: wfID, Name: c.task, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		if _, err := q.CreateTask(ctx, ctp); err != nil {
+			t.Fatalf("q.CreateTask(_, %v) = %v, wanted no error", ctp, err)
+		}
+		form := url.Values{"task.input": {"42"}}
+		req := httptest.NewRequest(http.MethodPost, path.Join("/workflows/", wfID.String(), "tasks", url.PathEscape(c.task), "approve"), strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		s.m.ServeHTTP(rec, req)
+		if rec.Code != c.wantCode {
+			t.Errorf("approving %q: rec.Code = %d, wanted %d", c.task, rec.Code, c.wantCode)
+		}
+		task, err := q.Task(ctx, db.TaskParams{WorkflowID: wfID, Name: c.task})
+		if err != nil {
+			t.Fatalf("q.Task(_, %q) = %v, %v, wanted no error", c.task, task, err)
+		}
+		if want := c.wantCode == http.StatusSeeOther; task.ApprovedAt.Valid != want {
+			t.Errorf("after approving %q: task approved = %t, wanted %t", c.task, task.ApprovedAt.Valid, want)
+		}
+	}
+}
+
+func TestServerDryRunActions(t *testing.T) {
+	ctx := t.Context()
+	p := testSQLiteDB(t)
+	q := db.New(p)
+
+	dh := NewDefinitionHolder()
+	dh.RegisterDefinition(t.Name(), newTestEchoWorkflow())
+	s := NewServer(p, NewWorker(dh, p, &PGListener{DB: p}), nil, SiteHeader{}, nil, nil)
+
+	wfID, otherID := uuid.New(), uuid.New()
+	for _, id := range []uuid.UUID{wfID, otherID} {
+		if _, err := q.CreateWorkflow(ctx, db.CreateWorkflowParams{ID: id, Name: nullString(t.Name()), Params: nullString("{}"), DryRun: true}); err != nil {
+			t.Fatalf("q.CreateWorkflow(_, %v) = %v, wanted no error", id, err)
+		}
+	}
+	trace := &task.DryRunTrace{Store: &DBDryRunStore{DB: p}}
+	gc := &task.DryRunGerritClient{Trace: trace}
+	for _, id := range []uuid.UUID{wfID, otherID} {
+		tctx := &workflow.TaskContext{Context: ctx, Logger: &testLogger{t: t}, TaskName: "tag", WorkflowID: id}
+		if err := gc.Tag(tctx, "go", "go1.99", "abc"); err != nil {
+			t.Fatalf("Tag(_, %v) = %v, wanted no error", id, err)
+		}
+	}
+
+	for _, c := range []struct {
+		id       string
+		wantCode int
+	}{
+		{"invalid", http.StatusBadRequest},
+		{uuid.New().String(), http.StatusNotFound},
+		{wfID.String(), http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, path.Join("/workflows/", c.id, "dry-run"), nil)
+		rec := httptest.NewRecorder()
+		s.m.ServeHTTP(rec, req)
+		if rec.Code != c.wantCode {
+			t.Errorf("GET dry-run actions of %q: rec.Code = %d, wanted %d", c.id, rec.Code, c.wantCode)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+		var got []task.DryRunAction
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("decoding dry-run actions: %v", err)
+		}
+		if len(got) != 1 || got[0].WorkflowID != wfID || got[0].TaskName != "tag" || got[0].Service != "gerrit" {
+			t.Errorf("served actions %v, wanted the workflow's gerrit tag", got)
+		}
+	}
+}
+
 func TestServerStopWorkflow(t *testing.T) {
 	wfID := uuid.New()
 	cases := []struct {
@@ -6,6 +6,7 @@ package relui
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,6 +18,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
 	"golang.org/x/build/internal/relui/db"
+	"golang.org/x/build/internal/task"
 	"golang.org/x/build/internal/workflow"
 	"golang.org/x/sync/errgroup"
 )
@@ -24,13 +26,26 @@ import (
 type Listener interface {
 	workflow.Listener
 
-	WorkflowStarted(ctx context.Context, workflowID uuid.UUID, name string, params map[string]any, scheduleID int) error
+	WorkflowStarted(ctx context.Context, workflowID uuid.UUID, name string, params map[string]any, scheduleID int, opts StartOptions) error
 	WorkflowFinished(ctx context.Context, workflowID uuid.UUID, outputs map[string]any, err error) error
 }
 
+// StartOptions describes how a workflow was started.
+type StartOptions struct {
+	// DryRun is whether the workflow runs in dry-run mode.
+	DryRun bool
+	// ParentWorkflowID and ParentTaskName identify the task that
+	// started the workflow, if it's a child workflow.
+	ParentWorkflowID uuid.NullUUID
+	ParentTaskName   string
+}
+
 // Worker runs workflows, and persists their state.
 type Worker struct {
 	dh *DefinitionHolder
+	// dryRun holds the definitions used by workflows started in
+	// dry-run mode. If nil, they can't be started or resumed.
+	dryRun *DefinitionHolder
 
 	db db.PGDBTX
 	l  Listener
@@ -62,6 +77,36 @@ func NewWorker(dh *DefinitionHolder, db db.PGDBTX, l Listener) *Worker {
 	}
 }
 
+// EnableDryRun lets w start workflows in dry-run mode. They, and any
+// child workflows they start, use the definitions registered in dh,
+// which must use clients that don't make writes, such as the DryRun
+// clients in the task package. It must be called before w runs.
+func (w *Worker) EnableDryRun(dh *DefinitionHolder) {
+	w.dryRun = dh
+}
+
+// DryRunEnabled reports whether w can run workflows in dry-run mode.
+func (w *Worker) DryRunEnabled() bool {
+	return w.dryRun != nil
+}
+
+// definition returns the definition of the workflow named name,
+// in dry-run mode if dryRun is set.
+func (w *Worker) definition(name string, dryRun bool) (*workflow.Definition, error) {
+	dh := w.dh
+	if dryRun {
+		if w.dryRun == nil {
+			return nil, fmt.Errorf("workflow %q is a dry run, but dry-run mode isn't enabled", name)
+		}
+		dh = w.dryRun
+	}
+	d := dh.Definition(name)
+	if d == nil {
+		return nil, fmt.Errorf("no workflow named %q", name)
+	}
+	return d, nil
+}
+
 // Run runs started workflows, waiting for new workflows to start.
 //
 // On context cancellation, Run waits for all running workflows to
@@ -141,15 +186,58 @@ func (w *Worker) workflowRunning(id uuid.UUID) bool {
 
 // StartWorkflow persists and starts running a workflow.
 func (w *Worker) StartWorkflow(ctx context.Context, name string, params map[string]any, scheduleID int) (uuid.UUID, error) {
-	d := w.dh.Definition(name)
-	if d == nil {
-		return uuid.UUID{}, fmt.Errorf("no workflow named %q", name)
+	return w.startWorkflow(ctx, name, params, scheduleID, StartOptions{})
+}
+
+// StartDryRunWorkflow persists and starts running a workflow in dry-run
+// mode. The workflow stays in dry-run mode when it's resumed.
+// Dry-run mode must be enabled with EnableDryRun.
+func (w *Worker) StartDryRunWorkflow(ctx context.Context, name string, params map[string]any) (uuid.UUID, error) {
+	return w.startWorkflow(ctx, name, params, 0, StartOptions{DryRun: true})
+}
+
+// StartChildWorkflow persists and starts running a workflow as the child
+// of the task named parentTask in the workflow with ID parentID.
+// A task can have at most one unfinished child. The child is a dry run
+// if its parent is.
+func (w *Worker) StartChildWorkflow(ctx context.Context, parentID uuid.UUID, parentTask, name string, params map[string]any) (uuid.UUID, error) {
+	parent, err := db.New(w.db).Workflow(ctx, parentID)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("q.Workflow(_, %v) = %w", parentID, err)
+	}
+	// The parameters are usually the result of an earlier task, which
+	// is decoded from JSON without the types of the child's parameters
+	// when the parent is resumed. Decode them the way the child's own
+	// parameters are on Resume.
+	d, err := w.definition(name, parent.DryRun)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	marshalled, err := json.Marshal(params)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if params, err = UnmarshalWorkflow(string(marshalled), d); err != nil {
+		return uuid.UUID{}, err
+	}
+	return w.startWorkflow(ctx, name, params, 0, StartOptions{
+		DryRun:           parent.DryRun,
+		ParentWorkflowID: uuid.NullUUID{UUID: parentID, Valid: true},
+		ParentTaskName:   parentTask,
+	})
+}
+
+// startWorkflow persists and starts running a workflow.
+func (w *Worker) startWorkflow(ctx context.Context, name string, params map[string]any, scheduleID int, opts StartOptions) (uuid.UUID, error) {
+	d, err := w.definition(name, opts.DryRun)
+	if err != nil {
+		return uuid.UUID{}, err
 	}
 	wf, err := workflow.Start(d, params)
 	if err != nil {
 		return uuid.UUID{}, err
 	}
-	if err := w.l.WorkflowStarted(ctx, wf.ID, name, params, scheduleID); err != nil {
+	if err := w.l.WorkflowStarted(ctx, wf.ID, name, params, scheduleID, opts); err != nil {
 		return wf.ID, err
 	}
 	if err := w.run(wf); err != nil {
@@ -158,6 +246,57 @@ func (w *Worker) StartWorkflow(ctx context.Context, name string, params map[stri
 	return wf.ID, err
 }
 
+// childWorkflowPollInterval is how often runChildWorkflow checks
+// whether a child workflow has finished.
+var childWorkflowPollInterval = 10 * time.Second
+
+// runChildWorkflow starts the workflow registered as name as the child of
+// the task running in ctx, unless the task already has a child that
+// hasn't failed, and waits for the child to finish. It returns the
+// child's JSON-encoded outputs.
+func (w *Worker) runChildWorkflow(ctx *workflow.TaskContext, name string, params map[string]any) (string, error) {
+	q := db.New(w.db)
+	child, err := q.ChildWorkflow(ctx, db.ChildWorkflowParams{
+		ParentWorkflowID: uuid.NullUUID{UUID: ctx.WorkflowID, Valid: true},
+		ParentTaskName:   sql.NullString{String: ctx.TaskName, Valid: true},
+	})
+	id := child.ID
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		// Start the first child below.
+	case err != nil:
+		return "", fmt.Errorf("q.ChildWorkflow(_, %v, %q) = %w", ctx.WorkflowID, ctx.TaskName, err)
+	case child.Finished && child.Error != "":
+		// Retrying the task retries the child from scratch.
+		ctx.Printf("Previous child workflow %v failed: %s", id, child.Error)
+	default:
+		ctx.Printf("Waiting for existing child workflow %v", id)
+		return w.awaitChildWorkflow(ctx, id)
+	}
+	id, err = w.StartChildWorkflow(ctx, ctx.WorkflowID, ctx.TaskName, name, params)
+	if err != nil {
+		return "", fmt.Errorf("starting child workflow %q: %w", name, err)
+	}
+	ctx.Printf("Started child workflow %v", id)
+	return w.awaitChildWorkflow(ctx, id)
+}
+
+// awaitChildWorkflow waits for the workflow with ID id to finish, and
+// returns its JSON-encoded outputs.
+func (w *Worker) awaitChildWorkflow(ctx *workflow.TaskContext, id uuid.UUID) (string, error) {
+	q := db.New(w.db)
+	return task.AwaitCondition(ctx, childWorkflowPollInterval, func() (string, bool, error) {
+		child, err := q.Workflow(ctx, id)
+		if err != nil || !child.Finished {
+			return "", false, err
+		}
+		if child.Error != "" {
+			return "", true, fmt.Errorf("child workflow %v failed: %s", id, child.Error)
+		}
+		return child.Output, true, nil
+	})
+}
+
 // ResumeAll resumes all workflows with unfinished tasks.
 func (w *Worker) ResumeAll(ctx context.Context) error {
 	q := db.New(w.db)
@@ -179,14 +318,15 @@ func (w *Worker) Resume(ctx context.Context, id uuid.UUID) error {
 	var wf db.Workflow
 	var tasks []db.Task
 	err = w.db.BeginFunc(ctx, func(tx pgx.Tx) error {
-		q := db.New(w.db)
+		q := db.New(tx)
 		wf, err = q.Workflow(ctx, id)
 		if err != nil {
 			return fmt.Errorf("q.Workflow(_, %v) = %w", id, err)
 		}
 		// The worker may have crashed, or been re-deployed. Any
 		// started but unfinished tasks are in an unknown state.
-		// Mark them as such for human review.
+		// Mark them as such for human review. Tasks that are
+		// waiting for approval can safely wait again.
 		if err := q.FailUnfinishedTasks(ctx, db.FailUnfinishedTasksParams{WorkflowID: id, UpdatedAt: time.Now()}); err != nil {
 			return fmt.Errorf("q.FailUnfinishedTasks(_, %v) = %w", id, err)
 		}
@@ -199,9 +339,8 @@ func (w *Worker) Resume(ctx context.Context, id uuid.UUID) error {
 	if err != nil {
 		return err
 	}
-	d := w.dh.Definition(wf.Name.String)
-	if d == nil {
-		err := fmt.Errorf("no workflow named %q", wf.Name.String)
+	d, err := w.definition(wf.Name.String, wf.DryRun)
+	if err != nil {
 		w.l.WorkflowFinished(ctx, wf.ID, nil, err)
 		return err
 	}
@@ -212,7 +351,7 @@ func (w *Worker) Resume(ctx context.Context, id uuid.UUID) error {
 		w.l.WorkflowFinished(ctx, wf.ID, nil, err)
 		return err
 	}
-	state := &workflow.WorkflowState{ID: wf.ID, Params: params}
+	state := &workflow.WorkflowState{ID: wf.ID, Params: params, Paused: wf.Paused}
 
 	taskStates := make(map[string]*workflow.TaskState)
 	for _, t := range tasks {
@@ -261,3 +400,56 @@ func (w *Worker) RetryTask(ctx context.Context, id uuid.UUID, name string) error
 	}
 	return rwf.w.RetryTask(ctx, name)
 }
+
+// PauseWorkflow stops a running workflow from starting new tasks.
+func (w *Worker) PauseWorkflow(ctx context.Context, id uuid.UUID) error {
+	rwf, err := w.runningWorkflow(id)
+	if err != nil {
+		return err
+	}
+	return rwf.w.Pause(ctx)
+}
+
+// UnpauseWorkflow lets a paused workflow start new tasks again.
+func (w *Worker) UnpauseWorkflow(ctx context.Context, id uuid.UUID) error {
+	rwf, err := w.runningWorkflow(id)
+	if err != nil {
+		return err
+	}
+	return rwf.w.Unpause(ctx)
+}
+
+// CancelTask stops a running task in a running workflow.
+func (w *Worker) CancelTask(ctx context.Context, id uuid.UUID, name string) error {
+	rwf, err := w.runningWorkflow(id)
+	if err != nil {
+		return err
+	}
+	return rwf.w.CancelTask(ctx, name)
+}
+
+func (w *Worker) runningWorkflow(id uuid.UUID) (runningWorkflow, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	rwf, ok := w.running[id.String()]
+	if !ok {
+		return runningWorkflow{}, fmt.Errorf("no workflow with id %q", id)
+	}
+	return rwf, nil
+}
+
+// errWorkflowNotRunning is returned by SupplyInput for a workflow
+// that isn't running.
+var errWorkflowNotRunning = errors.New("workflow is not running")
+
+// SupplyInput supplies the JSON-encoded value of an input task
+// in a running workflow.
+func (w *Worker) SupplyInput(ctx context.Context, id uuid.UUID, name string, serialized []byte) error {
+	w.mu.Lock()
+	rwf, ok := w.running[id.String()]
+	w.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no workflow with id %q: %w", id, errWorkflowNotRunning)
+	}
+	return rwf.w.SupplyInput(ctx, name, serialized)
+}
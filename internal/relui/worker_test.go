@@ -7,6 +7,7 @@ package relui
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -122,6 +123,50 @@ func TestWorkerResume(t *testing.T) {
 	}
 }
 
+func TestWorkerResumePaused(t *testing.T) {
+	ctx := t.Context()
+	dbp := testDB(ctx, t)
+	q := db.New(dbp)
+	wfDone := make(chan bool, 1)
+	dh := NewDefinitionHolder()
+	w := NewWorker(dh, dbp, &testWorkflowListener{
+		Listener:   &PGListener{DB: dbp},
+		onFinished: func() { wfDone <- true },
+	})
+
+	wd := newTestEchoWorkflow()
+	dh.RegisterDefinition(t.Name(), wd)
+	wfid := createUnfinishedEchoWorkflow(t, ctx, q)
+	if _, err := q.UpdateWorkflowPaused(ctx, db.UpdateWorkflowPausedParams{ID: wfid, Paused: true, UpdatedAt: time.Now()}); err != nil {
+		t.Fatalf("q.UpdateWorkflowPaused(_, %v) = %v, wanted no error", wfid, err)
+	}
+
+	go w.Run(ctx)
+	if err := w.Resume(ctx, wfid); err != nil {
+		t.Fatalf("w.Resume(_, %v) = %v, wanted no error", wfid, err)
+	}
+	select {
+	case <-wfDone:
+		t.Fatalf("paused workflow finished")
+	case <-time.After(100 * time.Millisecond):
+	}
+	for !w.workflowRunning(wfid) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := w.UnpauseWorkflow(ctx, wfid); err != nil {
+		t.Fatalf("w.UnpauseWorkflow(_, %v) = %v, wanted no error", wfid, err)
+	}
+	<-wfDone
+
+	wf, err := q.Workflow(ctx, wfid)
+	if err != nil {
+		t.Fatalf("q.Workflow(_, %v) = %v, %v, wanted no error", wfid, wf, err)
+	}
+	if wf.Paused || !wf.Finished || wf.Error != "" {
+		t.Errorf("workflow paused: %v, finished: %v, error: %q; want unpaused and finished without error", wf.Paused, wf.Finished, wf.Error)
+	}
+}
+
 func TestWorkerResumeMissingDefinition(t *testing.T) {
 	ctx := t.Context()
 	dbp := testDB(ctx, t)
@@ -313,3 +358,225 @@ func (t *testWorkflowListener) WorkflowStalled(workflowID uuid.UUID) error {
 	}
 	return err
 }
+
+func TestWorkerChildWorkflow(t *testing.T) {
+	defer func(old time.Duration) { childWorkflowPollInterval = old }(childWorkflowPollInterval)
+	childWorkflowPollInterval = 10 * time.Millisecond
+
+	ctx := t.Context()
+	dbp := testDB(ctx, t)
+	q := db.New(dbp)
+	wg := sync.WaitGroup{}
+	dh := NewDefinitionHolder()
+	w := NewWorker(dh, dbp, &testWorkflowListener{
+		Listener:   &PGListener{DB: dbp},
+		onFinished: wg.Done,
+	})
+
+	dh.RegisterDefinition("child", newTestEchoWorkflow())
+	parent := workflow.New(workflow.ACL{})
+	childParams := workflow.Task0(parent, "child params", func(ctx context.Context) (map[string]any, error) {
+		// Lists are decoded from JSON as []any.
+		return map[string]any{"greeting": "hi", "names": []any{"carol"}}, nil
+	})
+	type childOutputs struct{ Echo string }
+	outputs := ChildWorkflow[childOutputs](parent, w, "run child", "child", childParams)
+	workflow.Output(parent, "echo", workflow.Task1(parent, "read outputs", func(ctx context.Context, o childOutputs) (string, error) {
+		return o.Echo, nil
+	}, outputs))
+	dh.RegisterDefinition("parent", parent)
+
+	wg.Add(2) // The parent and the child.
+	parentID, err := w.StartWorkflow(ctx, "parent", nil, 0)
+	if err != nil {
+		t.Fatalf("w.StartWorkflow(_, %q, nil, 0) = %v, %v, wanted no error", "parent", parentID, err)
+	}
+	go w.Run(ctx)
+	wg.Wait()
+
+	children, err := q.ChildWorkflows(ctx, uuid.NullUUID{UUID: parentID, Valid: true})
+	if err != nil {
+		t.Fatalf("q.ChildWorkflows(_, %v) = %v, %v, wanted no error", parentID, children, err)
+	}
+	if len(children) != 1 {
+		t.Fatalf("q.ChildWorkflows(_, %v) returned %d workflows, want 1", parentID, len(children))
+	}
+	child := children[0]
+	if child.Name.String != "child" || child.ParentTaskName.String != "run child" || !child.Finished {
+		t.Errorf("child workflow = %+v, want finished workflow %q started by task %q", child, "child", "run child")
+	}
+	got, err := q.Workflow(ctx, parentID)
+	if err != nil {
+		t.Fatalf("q.Workflow(_, %v) = %v, %v, wanted no error", parentID, got, err)
+	}
+	if want := `{"echo": "hi carol"}`; got.Output != want || got.ParentWorkflowID.Valid {
+		t.Errorf("parent workflow = %+v, want output %s and no parent", got, want)
+	}
+}
+
+func TestWorkerChildWorkflowRetry(t *testing.T) {
+	defer func(old time.Duration) { childWorkflowPollInterval = old }(childWorkflowPollInterval)
+	childWorkflowPollInterval = 10 * time.Millisecond
+
+	ctx := t.Context()
+	sdb := testSQLiteDB(t)
+	q := db.New(sdb)
+	wg := sync.WaitGroup{}
+	dh := NewDefinitionHolder()
+	w := NewWorker(dh, sdb, &testWorkflowListener{
+		Listener:   &PGListener{DB: sdb},
+		onFinished: wg.Done,
+	})
+	dh.RegisterDefinition("child", newTestEchoWorkflow())
+	go w.Run(ctx)
+
+	parentID := uuid.New()
+	if _, err := q.CreateWorkflow(ctx, db.CreateWorkflowParams{ID: parentID, Name: nullString("parent")}); err != nil {
+		t.Fatalf("q.CreateWorkflow(_, %v) = %v, wanted no error", parentID, err)
+	}
+	createChild := func(taskName string) uuid.UUID {
+		cwp := db.CreateWorkflowParams{
+			ID:               uuid.New(),
+			Name:             nullString("child"),
+			CreatedAt:        time.Now(),
+			ParentWorkflowID: uuid.NullUUID{UUID: parentID, Valid: true},
+			ParentTaskName:   nullString(taskName),
+		}
+		if _, err := q.CreateWorkflow(ctx, cwp); err != nil {
+			t.Fatalf("q.CreateWorkflow(_, %v) = %v, wanted no error", cwp, err)
+		}
+		return cwp.ID
+	}
+
+	// A retried task starts a new child if its previous child failed.
+	failedID := createChild("run child")
+	wfp := db.WorkflowFinishedParams{ID: failedID, Finished: true, Output: "{}", Error: "child failed", UpdatedAt: time.Now()}
+	if _, err := q.WorkflowFinished(ctx, wfp); err != nil {
+		t.Fatalf("q.WorkflowFinished(_, %v) = %v, wanted no error", wfp, err)
+	}
+	wg.Add(1)
+	tctx := &workflow.TaskContext{Context: ctx, Logger: &testLogger{t, ""}, WorkflowID: parentID, TaskName: "run child"}
+	params := map[string]any{"greeting": "hi", "names": []string{"carol"}}
+	got, err := w.runChildWorkflow(tctx, "child", params)
+	var outputs map[string]string
+	if err == nil {
+		err = json.Unmarshal([]byte(got), &outputs)
+	}
+	if err != nil || outputs["echo"] != "hi carol" {
+		t.Errorf("w.runChildWorkflow(_, %q, %v) = %q, %v, want echo output %q", "child", params, got, err, "hi carol")
+	}
+	wg.Wait()
+	children, err := q.ChildWorkflows(ctx, uuid.NullUUID{UUID: parentID, Valid: true})
+	if err != nil || len(children) != 2 || children[0].ID != failedID || !children[1].Finished || children[1].Error != "" {
+		t.Errorf("q.ChildWorkflows(_, %v) = %+v, %v, want the failed child and a new finished one", parentID, children, err)
+	}
+
+	// A task can't have two unfinished children. The failed start
+	// mustn't leave a workflow without its parent behind.
+	createChild("stuck child")
+	before, err := q.WorkflowCount(ctx)
+	if err != nil {
+		t.Fatalf("q.WorkflowCount() = %v, wanted no error", err)
+	}
+	if id, err := w.StartChildWorkflow(ctx, parentID, "stuck child", "child", params); err == nil {
+		t.Errorf("w.StartChildWorkflow(_, %v, %q, ...) = %v, nil, want an error for a second unfinished child", parentID, "stuck child", id)
+	}
+	if after, err := q.WorkflowCount(ctx); err != nil || after != before {
+		t.Errorf("q.WorkflowCount() = %d, %v, want %d", after, err, before)
+	}
+}
+
+func TestWorkerDryRun(t *testing.T) {
+	ctx := t.Context()
+	sdb := testSQLiteDB(t)
+	q := db.New(sdb)
+	var mu sync.Mutex
+	ran := map[string]int{}
+	newWorkflow := func(mode string) *workflow.Definition {
+		wd := workflow.New(workflow.ACL{})
+		workflow.Output(wd, "mode", workflow.Task0(wd, "run", func(context.Context) (string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			ran[mode]++
+			return mode, nil
+		}))
+		return wd
+	}
+	wg := sync.WaitGroup{}
+	listener := &testWorkflowListener{Listener: &PGListener{DB: sdb}, onFinished: wg.Done}
+	dh, dryRun := NewDefinitionHolder(), NewDefinitionHolder()
+	dh.RegisterDefinition(t.Name(), newWorkflow("real"))
+	dryRun.RegisterDefinition(t.Name(), newWorkflow("dry run"))
+
+	createDryRun := func() uuid.UUID {
+		cwp := db.CreateWorkflowParams{ID: uuid.New(), Name: nullString(t.Name()), Params: nullString("{}"), DryRun: true}
+		if _, err := q.CreateWorkflow(ctx, cwp); err != nil {
+			t.Fatalf("q.CreateWorkflow(_, %v) = %v, wanted no error", cwp, err)
+		}
+		cwt := db.CreateTaskParams{WorkflowID: cwp.ID, Name: "run", Result: nullString("null"), CreatedAt: time.Now()}
+		if _, err := q.CreateTask(ctx, cwt); err != nil {
+			t.Fatalf("q.CreateTask(_, %v) = %v, wanted no error", cwt, err)
+		}
+		return cwp.ID
+	}
+
+	// Without dry-run mode, dry-run workflows can be neither started nor
+	// resumed. Resuming one fails it, like a missing definition does.
+	prodOnly := NewWorker(dh, sdb, &PGListener{DB: sdb})
+	if id, err := prodOnly.StartDryRunWorkflow(ctx, t.Name(), nil); err == nil {
+		t.Errorf("StartDryRunWorkflow without dry-run mode = %v, nil, want an error", id)
+	}
+	failedID := createDryRun()
+	if err := prodOnly.Resume(ctx, failedID); err == nil {
+		t.Errorf("Resume of a dry-run workflow without dry-run mode = nil, want an error")
+	}
+	if got, err := q.Workflow(ctx, failedID); err != nil || !got.Finished || got.Error == "" {
+		t.Errorf("q.Workflow(_, %v) = %+v, %v, want a failed workflow", failedID, got, err)
+	}
+	if ran["real"] != 0 {
+		t.Errorf("real definition ran %d times for a dry-run workflow, want 0", ran["real"])
+	}
+
+	w := NewWorker(dh, sdb, listener)
+	w.EnableDryRun(dryRun)
+	go w.Run(ctx)
+	wg.Add(3)
+	realID, err := w.StartWorkflow(ctx, t.Name(), nil, 0)
+	if err != nil {
+		t.Fatalf("w.StartWorkflow(_, %q, nil, 0) = %v, %v, wanted no error", t.Name(), realID, err)
+	}
+	dryRunID, err := w.StartDryRunWorkflow(ctx, t.Name(), nil)
+	if err != nil {
+		t.Fatalf("w.StartDryRunWorkflow(_, %q, nil) = %v, %v, wanted no error", t.Name(), dryRunID, err)
+	}
+	// Resuming restores dry-run mode from the database.
+	resumedID := createDryRun()
+	if err := w.Resume(ctx, resumedID); err != nil {
+		t.Fatalf("w.Resume(_, %v) = %v, wanted no error", resumedID, err)
+	}
+	wg.Wait()
+
+	if diff := cmp.Diff(map[string]int{"real": 1, "dry run": 2}, ran); diff != "" {
+		t.Errorf("workflow runs mismatch (-want +got):\n%s", diff)
+	}
+	for id, want := range map[uuid.UUID]bool{realID: false, dryRunID: true, resumedID: true} {
+		got, err := q.Workflow(ctx, id)
+		if err != nil || got.DryRun != want || !got.Finished || got.Error != "" {
+			t.Errorf("q.Workflow(_, %v) = %+v, %v, want a finished workflow with DryRun %v", id, got, err, want)
+		}
+	}
+
+	// Children of dry-run workflows are dry runs too.
+	wg.Add(1)
+	childID, err := w.StartChildWorkflow(ctx, dryRunID, "run", t.Name(), nil)
+	if err != nil {
+		t.Fatalf("w.StartChildWorkflow(_, %v, ...) = %v, %v, wanted no error", dryRunID, childID, err)
+	}
+	wg.Wait()
+	if child, err := q.Workflow(ctx, childID); err != nil || !child.DryRun {
+		t.Errorf("q.Workflow(_, %v) = %+v, %v, want a dry-run child", childID, child, err)
+	}
+	if ran["dry run"] != 3 {
+		t.Errorf("dry-run definition ran %d times, want 3", ran["dry run"])
+	}
+}
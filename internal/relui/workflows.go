@@ -196,6 +196,20 @@ Their first names will be included at the end of the release announcement, and C
 		Example: "heschi",
 		Check:   task.CheckCoordinators,
 	}
+	releaseToolsParameter = wf.ParamDef[bool]{
+		Name:      "Release gopls and vscode-go afterwards (optional)",
+		ParamType: wf.Bool,
+		Doc: `Release the next minor versions of gopls and then vscode-go once the Go release is published, each in a child workflow.
+
+Their release candidates must already have been prepared.`,
+	}
+)
+
+// The names that the gopls and vscode-go release workflows are
+// registered as. Final Go releases can start them as child workflows.
+const (
+	GoplsReleaseWorkflow    = "Release gopls"
+	VSCodeGoReleaseWorkflow = "Release a vscode-go stable version"
 )
 
 // newEchoWorkflow returns a runnable wf.Definition for
@@ -242,6 +256,9 @@ func checkTaskApproved(ctx *wf.TaskContext, p db.PGDBTX) (bool, error) {
 // "approve" control in the UI.
 //
 //	waitAction := wf.ActionN(wd, "Wait for Approval", ApproveActionDep(db), wf.After(someDependency))
+//
+// Approvals defined with wf.Approval don't poll the database,
+// and are preferred for new workflows.
 func ApproveActionDep(p db.PGDBTX) func(*wf.TaskContext) error {
 	return func(ctx *wf.TaskContext) error {
 		_, err := task.AwaitCondition(ctx, 5*time.Second, func() (int, bool, error) {
@@ -252,10 +269,38 @@ func ApproveActionDep(p db.PGDBTX) func(*wf.TaskContext) error {
 	}
 }
 
+// ChildWorkflow adds a task to wd that runs the workflow registered with w
+// as workflowName as a child of the workflow the task is part of, and
+// returns the child's outputs decoded from JSON into a T. T is typically
+// a struct with a field for each output of the child.
+//
+// The child has its own ID, history and retry controls. If one of its tasks
+// fails, it's retried in the child while the task keeps waiting; the task
+// only fails if the child is stopped. Retrying the task, including after
+// relui restarts, waits for the same child rather than starting another.
+//
+//	type goplsOutputs struct{ Version string }
+//	gopls := ChildWorkflow[goplsOutputs](wd, w, "Release gopls", "Release gopls", goplsParams)
+func ChildWorkflow[T any](wd *wf.Definition, w *Worker, name, workflowName string, params wf.Value[map[string]any], opts ...wf.TaskOption) wf.Value[T] {
+	return wf.Task1(wd, name, func(ctx *wf.TaskContext, params map[string]any) (T, error) {
+		var outputs T
+		marshalled, err := w.runChildWorkflow(ctx, workflowName, params)
+		if err != nil {
+			return outputs, err
+		}
+		if err := json.Unmarshal([]byte(marshalled), &outputs); err != nil {
+			return outputs, fmt.Errorf("decoding outputs of child workflow %q: %w", workflowName, err)
+		}
+		return outputs, nil
+	}, params, opts...)
+}
+
 // RegisterReleaseWorkflows registers workflows for issuing Go releases.
-func RegisterReleaseWorkflows(ctx context.Context, h *DefinitionHolder, build *BuildReleaseTasks, milestone *task.MilestoneTasks, version *task.VersionTasks, cycle task.ReleaseCycleTasks, comm task.CommunicationTasks) error {
+// Final releases run the gopls and vscode-go releases registered in h as
+// children in w, if asked to.
+func RegisterReleaseWorkflows(ctx context.Context, h *DefinitionHolder, w *Worker, build *BuildReleaseTasks, milestone *task.MilestoneTasks, version *task.VersionTasks, cycle task.ReleaseCycleTasks, comm task.CommunicationTasks) error {
 	// Register prod release workflows.
-	if err := registerProdReleaseWorkflows(ctx, h, build, milestone, version, comm); err != nil {
+	if err := registerProdReleaseWorkflows(ctx, h, w, build, milestone, version, comm); err != nil {
 		return err
 	}
 
@@ -400,7 +445,7 @@ func NewAnnounceBlogPostWorkflow(comm task.SocialMediaTasks) *wf.Definition {
 	return wd
 }
 
-func registerProdReleaseWorkflows(ctx context.Context, h *DefinitionHolder, build *BuildReleaseTasks, milestone *task.MilestoneTasks, version *task.VersionTasks, comm task.CommunicationTasks) error {
+func registerProdReleaseWorkflows(ctx context.Context, h *DefinitionHolder, w *Worker, build *BuildReleaseTasks, milestone *task.MilestoneTasks, version *task.VersionTasks, comm task.CommunicationTasks) error {
 	currentMajor, majorReleaseTime, err := version.GetCurrentMajor(ctx)
 	if err != nil {
 		return err
@@ -445,6 +490,9 @@ func registerProdReleaseWorkflows(ctx context.Context, h *DefinitionHolder, buil
 		if r.major >= currentMajor {
 			wf.Action1(wd, "update-proxy-test", version.UpdateProxyTestRepo, published)
 		}
+		if r.kind == task.KindMajor {
+			addToolsReleaseTasks(wd, w, wf.Param(wd, releaseToolsParameter), coordinators, wf.After(published))
+		}
 
 		h.RegisterDefinition(fmt.Sprintf("Go 1.%d %s", r.major, r.suffix), wd)
 	}
@@ -514,11 +562,56 @@ func createMinorReleaseWorkflow(build *BuildReleaseTasks, milestone *task.Milest
 	return wd, nil
 }
 
+// addToolsReleaseTasks adds tasks to wd that, if release is true, release
+// gopls and then vscode-go, each in a child workflow run by w.
+// The coordinators of the Go release coordinate them too.
+func addToolsReleaseTasks(wd *wf.Definition, w *Worker, release wf.Value[bool], coordinators wf.Value[[]string], opts ...wf.TaskOption) {
+	wf.If(wd, "Release gopls and vscode-go", release, func(wd *wf.Definition) (wf.Value[struct{}], error) {
+		goplsParams := wf.Task1(wd, "Choose gopls release parameters", goplsReleaseParams, coordinators)
+		gopls := ChildWorkflow[struct{}](wd, w, "Release gopls", GoplsReleaseWorkflow, goplsParams)
+		// vscode-go picks up the settings of the new gopls release.
+		vscodeGoParams := wf.Task1(wd, "Choose vscode-go release parameters", vscodeGoReleaseParams, coordinators, wf.After(gopls))
+		return ChildWorkflow[struct{}](wd, w, "Release vscode-go", VSCodeGoReleaseWorkflow, vscodeGoParams), nil
+	}, func(*wf.Definition) (wf.Value[struct{}], error) {
+		return wf.Const(struct{}{}), nil
+	}, opts...)
+}
+
+// goplsReleaseParams returns the parameters of a release of the next
+// minor version of gopls.
+func goplsReleaseParams(_ *wf.TaskContext, coordinators []string) (map[string]any, error) {
+	return map[string]any{
+		"next version":                  "next minor",
+		"explicit version (optional)":   "",
+		"Release Coordinator Usernames": jsonList(coordinators),
+	}, nil
+}
+
+// vscodeGoReleaseParams returns the parameters of a release of the next
+// minor version of vscode-go.
+func vscodeGoReleaseParams(_ *wf.TaskContext, coordinators []string) (map[string]any, error) {
+	return map[string]any{
+		"next version":                         "next minor",
+		"Reviewer Gerrit Usernames (optional)": jsonList(coordinators),
+	}, nil
+}
+
+// jsonList returns l as a []any, the type it has once decoded from JSON.
+// Task results must survive the round trip through JSON unchanged.
+// StartChildWorkflow converts it back to a []string.
+func jsonList(l []string) []any {
+	list := make([]any, len(l))
+	for i, s := range l {
+		list[i] = s
+	}
+	return list
+}
+
 func addCommTasks(
 	wd *wf.Definition, build *BuildReleaseTasks, comm task.CommunicationTasks,
 	kind task.ReleaseKind, published wf.Value[[]task.Published], securitySummary wf.Value[string], securityFixes, coordinators wf.Value[[]string],
 ) {
-	okayToAnnounce := wf.Action0(wd, "Wait to Announce", build.ApproveAction, wf.After(published))
+	okayToAnnounce := wf.Approval(wd, "Wait to Announce", wf.After(published))
 
 	// Announce that a new Go release has been published.
 	sentMail := wf.Task4(wd, "mail-announcement", comm.AnnounceRelease, wf.Const(kind), published, securityFixes, coordinators, wf.After(okayToAnnounce))
@@ -610,7 +703,7 @@ func addSingleReleaseWorkflow(
 	// Wait for planned release day,
 	// then re-check release-blocking issues
 	// and upstream PRIVATE-track security fixes, if any.
-	waitReleaseApproval := wf.Action0(wd, "Wait for Release Coordinator Approval", build.ApproveAction, wf.After(signedAndTestedArtifacts))
+	waitReleaseApproval := wf.Approval(wd, "Wait for Release Coordinator Approval", wf.After(signedAndTestedArtifacts))
 	recheckedBlockingIssues := wf.Action3(wd, "Re-check blocking issues", milestone.CheckBlockers, milestones, nextVersion, kindVal, wf.After(waitReleaseApproval))
 	upstreamedPrivateSecurityCLs := wf.Task5(wd, "Publicize PRIVATE-track security fixes (if any)", func(ctx *wf.TaskContext,
 		version, targetBranch, startingHead, securityCommit string, reviewers []string,
@@ -917,7 +1010,7 @@ type BuildReleaseTasks struct {
 	ServingURL               string // ServingURL is a gs:// or file:// URL, no trailing slash.
 	DownloadURL              string
 	ProxyPrefix              string // ProxyPrefix is the prefix at which module files are published, e.g. https://proxy.golang.org/golang.org/toolchain/@v
-	PublishFile              func(task.WebsiteFile) error
+	PublishFile              func(*wf.TaskContext, task.WebsiteFile) error
 	SignService              sign.Service
 	GoogleDockerBuildProject string
 	GoogleDockerBuildTrigger string
@@ -1973,7 +2066,7 @@ func (tasks *BuildReleaseTasks) publishArtifacts(ctx *wf.TaskContext, version st
 		}
 
 		// Publish it.
-		if err := tasks.PublishFile(f); err != nil {
+		if err := tasks.PublishFile(ctx, f); err != nil {
 			return task.Published{}, err
 		}
 		ctx.Printf("Published %q.", f.Filename)
@@ -7,12 +7,14 @@ package relui
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -287,6 +289,161 @@ func runWorkflow(t *testing.T, ctx context.Context, w *workflow.Workflow, listen
 	return w.Run(ctx, listener)
 }
 
+func TestToolsReleaseChildWorkflows(t *testing.T) {
+	defer func(old time.Duration) { childWorkflowPollInterval = old }(childWorkflowPollInterval)
+	childWorkflowPollInterval = 10 * time.Millisecond
+
+	// The parameters match those of the real child workflows, once
+	// converted the way StartChildWorkflow does.
+	for name, c := range map[string]struct {
+		d      *workflow.Definition
+		params func(*workflow.TaskContext, []string) (map[string]any, error)
+	}{
+		GoplsReleaseWorkflow:    {(&task.ReleaseGoplsTasks{}).NewReleaseDefinition(), goplsReleaseParams},
+		VSCodeGoReleaseWorkflow: {(&task.ReleaseVSCodeGoTasks{}).NewReleaseDefinition(), vscodeGoReleaseParams},
+	} {
+		params, _ := c.params(nil, []string{"heschi"})
+		marshalled, err := json.Marshal(params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		converted, err := UnmarshalWorkflow(string(marshalled), c.d)
+		if err != nil {
+			t.Errorf("UnmarshalWorkflow(%s, %q) = %v, wanted no error", marshalled, name, err)
+			continue
+		}
+		if _, err := workflow.Start(c.d, converted); err != nil {
+			t.Errorf("workflow.Start(%q, %v) = %v, wanted no error", name, converted, err)
+		}
+	}
+
+	ctx := t.Context()
+	sdb := testSQLiteDB(t)
+	q := db.New(sdb)
+	dh := NewDefinitionHolder()
+	w := NewWorker(dh, sdb, &PGListener{DB: sdb})
+
+	// Fake children with the same parameters. The vscode-go release
+	// fails until it's retried.
+	var vscodeGoAttempts atomic.Int32
+	gopls := workflow.New(workflow.ACL{})
+	workflow.Param(gopls, workflow.ParamDef[string]{Name: "next version"})
+	workflow.Param(gopls, workflow.ParamDef[string]{Name: "explicit version (optional)"})
+	goplsCoordinators := workflow.Param(gopls, workflow.ParamDef[[]string]{Name: "Release Coordinator Usernames", ParamType: workflow.SliceShort})
+	workflow.Output(gopls, "coordinators", workflow.Task1(gopls, "release", func(_ context.Context, c []string) ([]string, error) {
+		return c, nil
+	}, goplsCoordinators))
+	dh.RegisterDefinition(GoplsReleaseWorkflow, gopls)
+	vscodeGo := workflow.New(workflow.ACL{})
+	workflow.Param(vscodeGo, workflow.ParamDef[string]{Name: "next version"})
+	workflow.Param(vscodeGo, workflow.ParamDef[[]string]{Name: "Reviewer Gerrit Usernames (optional)", ParamType: workflow.SliceShort})
+	workflow.Output(vscodeGo, "released", workflow.Task0(vscodeGo, "release", func(ctx *workflow.TaskContext) (bool, error) {
+		ctx.DisableRetries()
+		if vscodeGoAttempts.Add(1) == 1 {
+			return false, errors.New("release failed")
+		}
+		return true, nil
+	}))
+	dh.RegisterDefinition(VSCodeGoReleaseWorkflow, vscodeGo)
+
+	parent := workflow.New(workflow.ACL{})
+	coordinators := workflow.Param(parent, releaseCoordinators)
+	published := workflow.Task0(parent, "publish", func(context.Context) (string, error) { return "go1.99.0", nil })
+	addToolsReleaseTasks(parent, w, workflow.Param(parent, releaseToolsParameter), coordinators, workflow.After(published))
+	workflow.Output(parent, "published", published)
+	dh.RegisterDefinition("parent", parent)
+
+	go w.Run(ctx)
+	// await polls until f reports true.
+	await := func(what string, f func() bool) {
+		t.Helper()
+		for deadline := time.Now().Add(10 * time.Second); !f(); time.Sleep(10 * time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+		}
+	}
+	child := func(parentID uuid.UUID, name string) (db.Workflow, bool) {
+		children, err := q.ChildWorkflows(ctx, uuid.NullUUID{UUID: parentID, Valid: true})
+		if err != nil {
+			t.Fatalf("q.ChildWorkflows(_, %v) = %v, wanted no error", parentID, err)
+		}
+		for _, c := range children {
+			if c.Name.String == name {
+				return c, true
+			}
+		}
+		return db.Workflow{}, false
+	}
+	finished := func(id uuid.UUID) func() bool {
+		return func() bool {
+			wf, err := q.Workflow(ctx, id)
+			return err == nil && wf.Finished
+		}
+	}
+
+	// Without release set, no children are started.
+	skipID, err := w.StartWorkflow(ctx, "parent", map[string]any{releaseCoordinators.Name: []string{"heschi"}, releaseToolsParameter.Name: false}, 0)
+	if err != nil {
+		t.Fatalf("w.StartWorkflow(_, %q, ...) = %v, wanted no error", "parent", err)
+	}
+	await("the parent that skips the tools releases to finish", finished(skipID))
+	if children, err := q.ChildWorkflows(ctx, uuid.NullUUID{UUID: skipID, Valid: true}); err != nil || len(children) != 0 {
+		t.Errorf("q.ChildWorkflows(_, %v) = %v, %v, wanted none", skipID, children, err)
+	}
+
+	parentID, err := w.StartWorkflow(ctx, "parent", map[string]any{releaseCoordinators.Name: []string{"heschi"}, releaseToolsParameter.Name: true}, 0)
+	if err != nil {
+		t.Fatalf("w.StartWorkflow(_, %q, ...) = %v, wanted no error", "parent", err)
+	}
+
+	// A failed child leaves the parent's task waiting for it.
+	var vscodeGoID uuid.UUID
+	await("the vscode-go release to fail", func() bool {
+		c, ok := child(parentID, VSCodeGoReleaseWorkflow)
+		if !ok {
+			return false
+		}
+		vscodeGoID = c.ID
+		tk, err := q.Task(ctx, db.TaskParams{WorkflowID: c.ID, Name: "release"})
+		return err == nil && tk.Error.String != ""
+	})
+	const waitingTask = "Release gopls and vscode-go: Release vscode-go"
+	if tk, err := q.Task(ctx, db.TaskParams{WorkflowID: parentID, Name: waitingTask}); err != nil || tk.Finished {
+		t.Errorf("q.Task(_, %v, %q) = %+v, %v, wanted an unfinished task", parentID, waitingTask, tk, err)
+	}
+	if p, err := q.Workflow(ctx, parentID); err != nil || p.Finished {
+		t.Errorf("q.Workflow(_, %v) = %+v, %v, wanted an unfinished workflow", parentID, p, err)
+	}
+
+	// Retrying the child's task lets both finish.
+	if err := w.RetryTask(ctx, vscodeGoID, "release"); err != nil {
+		t.Fatalf("w.RetryTask(_, %v, %q) = %v, wanted no error", vscodeGoID, "release", err)
+	}
+	await("the parent to finish", finished(parentID))
+	p, err := q.Workflow(ctx, parentID)
+	if err != nil || p.Error != "" {
+		t.Errorf("q.Workflow(_, %v) = %+v, %v, wanted a successful workflow", parentID, p, err)
+	}
+	for _, c := range []struct {
+		name, task string
+		output     map[string]any
+	}{
+		{GoplsReleaseWorkflow, "Release gopls and vscode-go: Release gopls", map[string]any{"coordinators": []any{"heschi"}}},
+		{VSCodeGoReleaseWorkflow, waitingTask, map[string]any{"released": true}},
+	} {
+		got, ok := child(parentID, c.name)
+		if !ok || !got.Finished || got.ParentTaskName.String != c.task {
+			t.Errorf("child %q = %+v, wanted a finished workflow started by %q", c.name, got, c.task)
+			continue
+		}
+		var output map[string]any
+		if err := json.Unmarshal([]byte(got.Output), &output); err != nil || !cmp.Equal(output, c.output) {
+			t.Errorf("child %q output = %s, %v, wanted %v", c.name, got.Output, err, c.output)
+		}
+	}
+}
+
 var flagRelevantBuildersMajor = flag.Int("relevant-builders-major", 0, "TestReadRelevantBuildersLive's readRelevantBuilders major version")
 
 func TestReadRelevantBuildersLive(t *testing.T) {
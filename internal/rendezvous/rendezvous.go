@@ -7,6 +7,7 @@ package rendezvous
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
@@ -15,6 +16,7 @@ import (
 
 	"golang.org/x/build/buildlet"
 	"golang.org/x/build/internal"
+	"golang.org/x/build/internal/muxconn"
 	"golang.org/x/build/revdial/v2"
 	"google.golang.org/api/idtoken"
 )
@@ -160,11 +162,13 @@ func (rdv *Rendezvous) HandleReverse(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "missing X-Go-Swarming-Auth-Token header", http.StatusBadRequest)
 		return
 	}
+	muxMode := r.Header.Get(muxconn.Header)
 	rdv.mu.Lock()
 	res, ok := rdv.m[id]
 	rdv.mu.Unlock()
 
-	if !ok {
+	// A buildlet resuming its session was already handed to its waiter.
+	if !ok && muxMode != "resume" {
 		http.Error(w, "not expecting buildlet client", http.StatusPreconditionFailed)
 		return
 	}
@@ -181,10 +185,16 @@ func (rdv *Rendezvous) HandleReverse(w http.ResponseWriter, r *http.Request) {
 	conn, _, err := hj.Hijack()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
-		res.ch <- &result{err: err}
+		if res != nil {
+			res.ch <- &result{err: err}
+		}
 		return
 	}
-	bc, err := connToClient(conn, hostname, "swarming_task")
+	if muxMode == "resume" {
+		resumeSession(conn, hostname)
+		return
+	}
+	bc, err := connToClient(conn, hostname, "swarming_task", muxMode != "")
 	if err != nil {
 		log.Printf("rendezvous: unable to create buildlet client: %s", err)
 		conn.Close()
@@ -194,15 +204,54 @@ func (rdv *Rendezvous) HandleReverse(w http.ResponseWriter, r *http.Request) {
 	res.ch <- &result{bc: bc}
 }
 
-func connToClient(conn net.Conn, hostname, hostType string) (buildlet.Client, error) {
-	if err := (&http.Response{StatusCode: http.StatusSwitchingProtocols, Proto: "HTTP/1.1"}).Write(conn); err != nil {
+// resumeSession resumes the muxconn session of a buildlet that
+// reconnected.
+func resumeSession(conn net.Conn, hostname string) {
+	res := &http.Response{StatusCode: http.StatusSwitchingProtocols, Proto: "HTTP/1.1", Header: http.Header{muxconn.Header: {"1"}}}
+	if err := res.Write(conn); err != nil {
+		log.Printf("gomote: error writing upgrade response to reverse buildlet %s at %s: %v", hostname, conn.RemoteAddr(), err)
+		conn.Close()
+		return
+	}
+	sess, resumed, err := muxconn.Server(conn, nil)
+	if err != nil {
+		log.Printf("rendezvous: unable to resume session of buildlet %s at %s: %s", hostname, conn.RemoteAddr(), err)
+		return
+	}
+	if !resumed {
+		log.Printf("rendezvous: buildlet %s at %s started a new session instead of resuming one", hostname, conn.RemoteAddr())
+		sess.Close()
+	}
+}
+
+// connToClient returns a client of the buildlet that connected over
+// conn. If mux is true, the buildlet asked to multiplex its connections
+// over a muxconn session; otherwise, they're made with revdial.
+func connToClient(conn net.Conn, hostname, hostType string, mux bool) (buildlet.Client, error) {
+	res := &http.Response{StatusCode: http.StatusSwitchingProtocols, Proto: "HTTP/1.1"}
+	if mux {
+		res.Header = http.Header{muxconn.Header: {"1"}}
+	}
+	if err := res.Write(conn); err != nil {
 		log.Printf("gomote: error writing upgrade response to reverse buildlet %s (%s) at %s: %v", hostname, hostType, conn.RemoteAddr(), err)
 		conn.Close()
 		return nil, err
 	}
-	revDialer := revdial.NewDialer(conn, "/revdial")
-	revDialerDone := revDialer.Done()
-	dialer := revDialer.Dial
+	var (
+		closer        io.Closer = conn
+		revDialerDone <-chan struct{}
+		dialer        func(context.Context) (net.Conn, error)
+	)
+	if mux {
+		sess, _, err := muxconn.Server(conn, nil)
+		if err != nil {
+			return nil, err
+		}
+		closer, revDialerDone, dialer = sess, sess.Done(), sess.Dial
+	} else {
+		revDialer := revdial.NewDialer(conn, "/revdial")
+		revDialerDone, dialer = revDialer.Done(), revDialer.Dial
+	}
 
 	client := buildlet.NewClient(conn.RemoteAddr().String(), buildlet.NoKeyPair)
 	client.SetHTTPClient(&http.Client{
@@ -223,7 +272,7 @@ func connToClient(conn net.Conn, hostname, hostType string) (buildlet.Client, er
 		isDead.Lock()
 		isDead.v = true
 		isDead.Unlock()
-		conn.Close()
+		closer.Close()
 	})
 
 	// If the reverse dialer (which is always reading from the
@@ -242,7 +291,7 @@ func connToClient(conn net.Conn, hostname, hostType string) (buildlet.Client, er
 	if err != nil {
 		log.Printf("Reverse connection %s/%s for %s did not answer status after %v: %v",
 			hostname, conn.RemoteAddr(), hostType, time.Since(tstatus), err)
-		conn.Close()
+		closer.Close()
 		return nil, err
 	}
 	log.Printf("Buildlet %s/%s: %+v for %s", hostname, conn.RemoteAddr(), status, hostType)
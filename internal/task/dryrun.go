@@ -0,0 +1,509 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/cloudbuild/apiv1/v2/cloudbuildpb"
+	"github.com/google/go-github/v74/github"
+	"github.com/google/uuid"
+	"github.com/shurcooL/githubv4"
+	pb "go.chromium.org/luci/buildbucket/proto"
+	"golang.org/x/build/gerrit"
+	"golang.org/x/build/internal/relui/sign"
+	wf "golang.org/x/build/internal/workflow"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// A DryRunTrace records the side effects that tasks would have had
+// if they weren't running in dry-run mode.
+//
+// The DryRun clients below wrap real clients, passing reads through
+// to them so that workflows see real inputs, and recording writes
+// in a DryRunTrace instead of performing them. Recorded actions are
+// written to the log of the task that attempted them, and persisted
+// in the trace's Store so that they outlive the process.
+//
+// The clients deny by default: they list every method of the interface
+// they implement rather than embedding it, and writes they don't know
+// how to fake, such as running arbitrary Cloud Build steps, are refused
+// with an error wrapping ErrDryRun. That error is recorded too.
+type DryRunTrace struct {
+	Store DryRunStore
+
+	mu     sync.Mutex
+	nextID int
+}
+
+// A DryRunStore persists the actions recorded by a DryRunTrace.
+type DryRunStore interface {
+	// AddDryRunAction persists a, which is attributed to a workflow.
+	AddDryRunAction(ctx context.Context, a DryRunAction) error
+	// DryRunActions returns the actions recorded for the workflow
+	// with the given ID, in the order they happened.
+	DryRunActions(ctx context.Context, workflowID uuid.UUID) ([]DryRunAction, error)
+}
+
+// ErrDryRun is wrapped by the errors of writes that are refused in
+// dry-run mode. Workflows can't get past the tasks that make them.
+var ErrDryRun = errors.New("not allowed in dry-run mode")
+
+// ErrDryRunPush is returned by Git pushes in dry-run mode.
+// Workflows can't get past tasks that depend on the result
+// of a push, such as the number of a change it created.
+var ErrDryRunPush = fmt.Errorf("git push: %w", ErrDryRun)
+
+// A DryRunAction is a side effect recorded by a DryRunTrace.
+type DryRunAction struct {
+	Time       time.Time
+	WorkflowID uuid.UUID // The workflow that attempted the action, if known.
+	TaskName   string    // The task that attempted the action, if known.
+	Service    string    // The service acted upon, such as "gerrit" or "mail".
+	Action     string    // A description of the action.
+	Denied     bool      // Whether the action was refused, failing the task.
+}
+
+func (a DryRunAction) String() string {
+	who := a.TaskName
+	if who == "" {
+		who = "(unknown task)"
+	}
+	action := a.Action
+	if a.Denied {
+		action = "DENIED " + action
+	}
+	return fmt.Sprintf("%s %s: %s: %s", a.Time.UTC().Format(time.RFC3339), who, a.Service, action)
+}
+
+// record records an action. If ctx is a *wf.TaskContext, the action
+// is attributed to its task and logged there.
+func (t *DryRunTrace) record(ctx context.Context, service, format string, args ...any) {
+	t.add(ctx, DryRunAction{Service: service, Action: fmt.Sprintf(format, args...)})
+}
+
+// deny records an action that is refused in dry-run mode,
+// and returns the error to fail it with.
+func (t *DryRunTrace) deny(ctx context.Context, service, format string, args ...any) error {
+	a := DryRunAction{Service: service, Action: fmt.Sprintf(format, args...), Denied: true}
+	t.add(ctx, a)
+	return fmt.Errorf("%s: %s: %w", service, a.Action, ErrDryRun)
+}
+
+// add persists a. Actions that can't be attributed to a workflow,
+// such as social media posts, whose clients don't take a context,
+// are only written to the process's log.
+func (t *DryRunTrace) add(ctx context.Context, a DryRunAction) {
+	a.Time = time.Now()
+	tctx, ok := ctx.(*wf.TaskContext)
+	if !ok || tctx.WorkflowID == uuid.Nil {
+		log.Printf("dry run: %v", a)
+		return
+	}
+	a.WorkflowID, a.TaskName = tctx.WorkflowID, tctx.TaskName
+	if a.Denied {
+		tctx.Printf("[dry run] %s: refused: %s", a.Service, a.Action)
+	} else {
+		tctx.Printf("[dry run] %s: %s", a.Service, a.Action)
+	}
+	if err := t.Store.AddDryRunAction(ctx, a); err != nil {
+		// The action is still in the task's log.
+		tctx.Printf("[dry run] failed to save the action: %v", err)
+	}
+}
+
+// fakeID returns a unique identifier for a fake object.
+func (t *DryRunTrace) fakeID() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nextID++
+	return t.nextID
+}
+
+// Actions returns the actions recorded for the workflow with the given ID,
+// in the order they happened.
+func (t *DryRunTrace) Actions(ctx context.Context, id uuid.UUID) ([]DryRunAction, error) {
+	return t.Store.DryRunActions(ctx, id)
+}
+
+// SendMail records an email instead of sending it.
+// It matches the signature of AnnounceMailTasks.SendMail.
+func (t *DryRunTrace) SendMail(ctx *wf.TaskContext, h MailHeader, m MailContent) error {
+	t.record(ctx, "mail", "send %q from %v to %v (bcc %v):\n%s", m.Subject, h.From, h.To, h.BCC, m.BodyText)
+	return nil
+}
+
+// PublishFile records a website file instead of publishing it.
+// It matches the signature of BuildReleaseTasks.PublishFile.
+func (t *DryRunTrace) PublishFile(ctx *wf.TaskContext, f WebsiteFile) error {
+	t.record(ctx, "website", "publish %s (%s, %d bytes)", f.Filename, f.ChecksumSHA256, f.Size)
+	return nil
+}
+
+// DryRunPoster is a Poster that records posts instead of making them.
+type DryRunPoster struct {
+	Service string // The name of the social media service, such as "mastodon".
+	Trace   *DryRunTrace
+}
+
+func (p *DryRunPoster) Post(text string) (string, error) {
+	p.Trace.record(context.Background(), p.Service, "post %q", text)
+	return "(dry-run)", nil
+}
+
+func (p *DryRunPoster) PostTweet(text string, imagePNG []byte, altText string) (string, error) {
+	p.Trace.record(context.Background(), p.Service, "post %q with a %d-byte image (alt text %q)", text, len(imagePNG), altText)
+	return "(dry-run)", nil
+}
+
+// dryRunChangePrefix prefixes the IDs of changes that were
+// only pretended to be created.
+const dryRunChangePrefix = "dry-run~"
+
+// DryRunGerritClient is a GerritClient that reads from a real Gerrit
+// instance, but records changes to it instead of making them.
+//
+// Changes it pretends to create are considered submitted right away,
+// at the commit their branch is at.
+type DryRunGerritClient struct {
+	GerritClient GerritClient
+	Trace        *DryRunTrace
+}
+
+var _ DestructiveGerritClient = (*DryRunGerritClient)(nil)
+
+func (c *DryRunGerritClient) GitilesURL() string { return c.GerritClient.GitilesURL() }
+
+func (c *DryRunGerritClient) GitRepoURL(project string) string {
+	return c.GerritClient.GitRepoURL(project)
+}
+
+func (c *DryRunGerritClient) GetTag(ctx context.Context, project, tag string) (gerrit.TagInfo, error) {
+	return c.GerritClient.GetTag(ctx, project, tag)
+}
+
+func (c *DryRunGerritClient) ListTags(ctx context.Context, project string) ([]string, error) {
+	return c.GerritClient.ListTags(ctx, project)
+}
+
+func (c *DryRunGerritClient) ReadBranchHead(ctx context.Context, project, branch string) (string, error) {
+	return c.GerritClient.ReadBranchHead(ctx, project, branch)
+}
+
+func (c *DryRunGerritClient) ListBranches(ctx context.Context, project string) ([]gerrit.BranchInfo, error) {
+	return c.GerritClient.ListBranches(ctx, project)
+}
+
+func (c *DryRunGerritClient) ListCommits(ctx context.Context, project, head, base string) ([]CommitInfo, error) {
+	return c.GerritClient.ListCommits(ctx, project, head, base)
+}
+
+func (c *DryRunGerritClient) ListProjects(ctx context.Context) ([]string, error) {
+	return c.GerritClient.ListProjects(ctx)
+}
+
+func (c *DryRunGerritClient) ReadFile(ctx context.Context, project, commit, file string) ([]byte, error) {
+	return c.GerritClient.ReadFile(ctx, project, commit, file)
+}
+
+func (c *DryRunGerritClient) ReadDir(ctx context.Context, project, commit, dir string) ([]struct{ Name string }, error) {
+	return c.GerritClient.ReadDir(ctx, project, commit, dir)
+}
+
+func (c *DryRunGerritClient) QueryChanges(ctx context.Context, query string) ([]*gerrit.ChangeInfo, error) {
+	return c.GerritClient.QueryChanges(ctx, query)
+}
+
+func (c *DryRunGerritClient) GetRevisionActions(ctx context.Context, changeID, revision string) (map[string]*gerrit.ActionInfo, error) {
+	return c.GerritClient.GetRevisionActions(ctx, changeID, revision)
+}
+
+func (c *DryRunGerritClient) GetCommitMessage(ctx context.Context, changeID string) (string, error) {
+	return c.GerritClient.GetCommitMessage(ctx, changeID)
+}
+
+func (c *DryRunGerritClient) GetCommitsInRefs(ctx context.Context, project string, commits, refs []string) (map[string][]string, error) {
+	return c.GerritClient.GetCommitsInRefs(ctx, project, commits, refs)
+}
+
+func (c *DryRunGerritClient) fakeChange(project, branch string) string {
+	return fmt.Sprintf("%s%s~%s~%d", dryRunChangePrefix, project, branch, c.Trace.fakeID())
+}
+
+func (c *DryRunGerritClient) CreateAutoSubmitChange(ctx *wf.TaskContext, input gerrit.ChangeInput, reviewers []string, contents map[string]string) (string, error) {
+	var files []string
+	for f := range contents {
+		files = append(files, f)
+	}
+	c.Trace.record(ctx, "gerrit", "create auto-submit change in %s on %s, reviewers %v, files %v:\n%s", input.Project, input.Branch, reviewers, files, input.Subject)
+	return c.fakeChange(input.Project, input.Branch), nil
+}
+
+func (c *DryRunGerritClient) Submitted(ctx context.Context, changeID, parentCommit string) (string, bool, error) {
+	fake, ok := strings.CutPrefix(changeID, dryRunChangePrefix)
+	if !ok {
+		return c.GerritClient.Submitted(ctx, changeID, parentCommit)
+	}
+	if parentCommit != "" {
+		return parentCommit, true, nil
+	}
+	project, rest, _ := strings.Cut(fake, "~")
+	branch, _, _ := strings.Cut(rest, "~")
+	head, err := c.GerritClient.ReadBranchHead(ctx, project, branch)
+	return head, err == nil, err
+}
+
+func (c *DryRunGerritClient) Tag(ctx context.Context, project, tag, commit string) error {
+	c.Trace.record(ctx, "gerrit", "tag %s at %s in %s", tag, commit, project)
+	return nil
+}
+
+// ForceTag records the tag, so that a DryRunGerritClient is
+// also a DestructiveGerritClient.
+func (c *DryRunGerritClient) ForceTag(ctx context.Context, project, tag, commit string) error {
+	c.Trace.record(ctx, "gerrit", "force tag %s at %s in %s", tag, commit, project)
+	return nil
+}
+
+func (c *DryRunGerritClient) CreateBranch(ctx context.Context, project, branch string, input gerrit.BranchInput) (string, error) {
+	c.Trace.record(ctx, "gerrit", "create branch %s at %s in %s", branch, input.Revision, project)
+	return input.Revision, nil
+}
+
+func (c *DryRunGerritClient) GetChange(ctx context.Context, changeID string, opts ...gerrit.QueryChangesOpt) (*gerrit.ChangeInfo, error) {
+	if strings.HasPrefix(changeID, dryRunChangePrefix) {
+		return &gerrit.ChangeInfo{ID: changeID, Status: gerrit.ChangeStatusMerged}, nil
+	}
+	return c.GerritClient.GetChange(ctx, changeID, opts...)
+}
+
+func (c *DryRunGerritClient) SubmitChange(ctx context.Context, changeID string) (gerrit.ChangeInfo, error) {
+	c.Trace.record(ctx, "gerrit", "submit change %s", changeID)
+	return gerrit.ChangeInfo{ID: changeID, Status: gerrit.ChangeStatusMerged}, nil
+}
+
+func (c *DryRunGerritClient) SetHashtags(ctx context.Context, changeID string, hashtags gerrit.HashtagsInput) error {
+	c.Trace.record(ctx, "gerrit", "set hashtags on change %s: add %v, remove %v", changeID, hashtags.Add, hashtags.Remove)
+	return nil
+}
+
+func (c *DryRunGerritClient) CreateCherryPick(ctx context.Context, changeID string, branch string, commitMessage string) (gerrit.ChangeInfo, bool, error) {
+	c.Trace.record(ctx, "gerrit", "cherry-pick change %s to %s", changeID, branch)
+	return gerrit.ChangeInfo{ID: c.fakeChange("", branch), Branch: branch, Status: gerrit.ChangeStatusNew}, false, nil
+}
+
+func (c *DryRunGerritClient) RebaseChange(ctx context.Context, changeID string, revision string) (gerrit.ChangeInfo, error) {
+	c.Trace.record(ctx, "gerrit", "rebase change %s onto %q", changeID, revision)
+	return gerrit.ChangeInfo{ID: changeID, Status: gerrit.ChangeStatusNew}, nil
+}
+
+func (c *DryRunGerritClient) MoveChange(ctx context.Context, changeID string, branch string) (gerrit.ChangeInfo, error) {
+	c.Trace.record(ctx, "gerrit", "move change %s to %s", changeID, branch)
+	return gerrit.ChangeInfo{ID: changeID, Branch: branch, Status: gerrit.ChangeStatusNew}, nil
+}
+
+// DryRunCloudBuildClient is a CloudBuildClient that records build triggers
+// and generated changes instead of running them. Scripts and custom steps
+// can do anything, including publishing, so they're refused.
+type DryRunCloudBuildClient struct {
+	CloudBuildClient CloudBuildClient
+	Trace            *DryRunTrace
+}
+
+var _ CloudBuildClient = (*DryRunCloudBuildClient)(nil)
+
+// dryRunBuildID is the ID of builds that were only pretended to be started.
+const dryRunBuildID = "dry-run"
+
+func (c *DryRunCloudBuildClient) RunBuildTrigger(ctx context.Context, project, trigger string, substitutions map[string]string) (CloudBuild, error) {
+	c.Trace.record(ctx, "cloudbuild", "run trigger %s in %s with substitutions %v", trigger, project, substitutions)
+	return CloudBuild{Project: project, ID: dryRunBuildID}, nil
+}
+
+func (c *DryRunCloudBuildClient) GenerateAutoSubmitChange(ctx *wf.TaskContext, input gerrit.ChangeInput, reviewers []string) (string, error) {
+	c.Trace.record(ctx, "gerrit", "generate auto-submit change in %s on %s, reviewers %v:\n%s", input.Project, input.Branch, reviewers, input.Subject)
+	return fmt.Sprintf("%s%s~%s~%d", dryRunChangePrefix, input.Project, input.Branch, c.Trace.fakeID()), nil
+}
+
+func (c *DryRunCloudBuildClient) RunScript(ctx context.Context, script string, gerritProject string, outputs []string) (CloudBuild, error) {
+	return CloudBuild{}, c.Trace.deny(ctx, "cloudbuild", "run script in %q with outputs %v:\n%s", gerritProject, outputs, script)
+}
+
+func (c *DryRunCloudBuildClient) RunCustomSteps(ctx context.Context, steps func(resultURL string) []*cloudbuildpb.BuildStep, opts *CloudBuildOptions) (CloudBuild, error) {
+	var names []string
+	for _, step := range steps("") {
+		names = append(names, step.GetName())
+	}
+	return CloudBuild{}, c.Trace.deny(ctx, "cloudbuild", "run custom steps %v", names)
+}
+
+func (c *DryRunCloudBuildClient) Completed(ctx context.Context, build CloudBuild) (string, bool, error) {
+	if build.ID == dryRunBuildID {
+		return "", true, nil
+	}
+	return c.CloudBuildClient.Completed(ctx, build)
+}
+
+func (c *DryRunCloudBuildClient) ResultFS(ctx context.Context, build CloudBuild) (fs.FS, error) {
+	if build.ID == dryRunBuildID {
+		return nil, fmt.Errorf("build %q has no results: %w", build.ID, ErrDryRun)
+	}
+	return c.CloudBuildClient.ResultFS(ctx, build)
+}
+
+// DryRunGitHubClient is a GitHubClientInterface that reads from GitHub,
+// but records changes to it instead of making them.
+type DryRunGitHubClient struct {
+	GitHubClientInterface GitHubClientInterface
+	Trace                 *DryRunTrace
+}
+
+var _ GitHubClientInterface = (*DryRunGitHubClient)(nil)
+
+func (c *DryRunGitHubClient) FetchMilestoneIssues(ctx context.Context, owner, repo string, milestoneID int) (map[int]map[string]bool, error) {
+	return c.GitHubClientInterface.FetchMilestoneIssues(ctx, owner, repo, milestoneID)
+}
+
+func (c *DryRunGitHubClient) GetIssue(ctx context.Context, owner, repo string, number int) (*github.Issue, *github.Response, error) {
+	return c.GitHubClientInterface.GetIssue(ctx, owner, repo, number)
+}
+
+func (c *DryRunGitHubClient) TagExists(ctx context.Context, owner, repo, tag string) (bool, error) {
+	return c.GitHubClientInterface.TagExists(ctx, owner, repo, tag)
+}
+
+func (c *DryRunGitHubClient) FetchMilestone(ctx context.Context, owner, repo, name string, create bool) (int, error) {
+	n, err := c.GitHubClientInterface.FetchMilestone(ctx, owner, repo, name, false)
+	if err != nil && create {
+		c.Trace.record(ctx, "github", "create milestone %q in %s/%s", name, owner, repo)
+		return 0, nil
+	}
+	return n, err
+}
+
+func (c *DryRunGitHubClient) CreateIssue(ctx context.Context, owner, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	c.Trace.record(ctx, "github", "create issue %q in %s/%s", issue.GetTitle(), owner, repo)
+	return &github.Issue{Title: issue.Title, Body: issue.Body}, nil, nil
+}
+
+func (c *DryRunGitHubClient) EditIssue(ctx context.Context, owner, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	c.Trace.record(ctx, "github", "edit issue %s/%s#%d", owner, repo, number)
+	return c.GitHubClientInterface.GetIssue(ctx, owner, repo, number)
+}
+
+func (c *DryRunGitHubClient) EditMilestone(ctx context.Context, owner, repo string, number int, milestone *github.Milestone) (*github.Milestone, *github.Response, error) {
+	c.Trace.record(ctx, "github", "edit milestone %d in %s/%s", number, owner, repo)
+	return milestone, nil, nil
+}
+
+func (c *DryRunGitHubClient) PostComment(ctx context.Context, id githubv4.ID, body string) error {
+	c.Trace.record(ctx, "github", "comment on %v:\n%s", id, body)
+	return nil
+}
+
+func (c *DryRunGitHubClient) CreateRelease(ctx context.Context, owner, repo string, release *github.RepositoryRelease) (*github.RepositoryRelease, error) {
+	c.Trace.record(ctx, "github", "create release %s in %s/%s", release.GetTagName(), owner, repo)
+	return release, nil
+}
+
+func (c *DryRunGitHubClient) PublishRelease(ctx context.Context, owner, repo string, release *github.RepositoryRelease) (*github.RepositoryRelease, error) {
+	c.Trace.record(ctx, "github", "publish release %s in %s/%s", release.GetTagName(), owner, repo)
+	return release, nil
+}
+
+func (c *DryRunGitHubClient) UploadReleaseAsset(ctx context.Context, owner, repo string, releaseID int64, fileName string, file fs.File) (*github.ReleaseAsset, error) {
+	c.Trace.record(ctx, "github", "upload release asset %s to release %d in %s/%s", fileName, releaseID, owner, repo)
+	return &github.ReleaseAsset{Name: github.Ptr(fileName)}, nil
+}
+
+// DryRunBuildBucketClient is a BuildBucketClient that lists and
+// inspects builds, but refuses to start them.
+type DryRunBuildBucketClient struct {
+	BuildBucketClient BuildBucketClient
+	Trace             *DryRunTrace
+}
+
+var _ BuildBucketClient = (*DryRunBuildBucketClient)(nil)
+
+func (c *DryRunBuildBucketClient) ListBuilders(ctx context.Context, bucket string) (map[string]*pb.BuilderConfig, error) {
+	return c.BuildBucketClient.ListBuilders(ctx, bucket)
+}
+
+func (c *DryRunBuildBucketClient) RunBuild(ctx context.Context, bucket, builder string, commit *pb.GitilesCommit, properties map[string]*structpb.Value) (int64, error) {
+	return 0, c.Trace.deny(ctx, "buildbucket", "run %s/%s at %s", bucket, builder, commit.GetId())
+}
+
+func (c *DryRunBuildBucketClient) Completed(ctx context.Context, id int64) (string, bool, error) {
+	return c.BuildBucketClient.Completed(ctx, id)
+}
+
+func (c *DryRunBuildBucketClient) SearchBuilds(ctx context.Context, pred *pb.BuildPredicate) ([]int64, error) {
+	return c.BuildBucketClient.SearchBuilds(ctx, pred)
+}
+
+// DryRunSwarmingClient is a SwarmingClient that refuses to run tasks.
+type DryRunSwarmingClient struct {
+	SwarmingClient SwarmingClient
+	Trace          *DryRunTrace
+}
+
+var _ SwarmingClient = (*DryRunSwarmingClient)(nil)
+
+func (c *DryRunSwarmingClient) RunTask(ctx context.Context, dims map[string]string, script string, env map[string]string) (string, error) {
+	return "", c.Trace.deny(ctx, "swarming", "run task on %v:\n%s", dims, script)
+}
+
+func (c *DryRunSwarmingClient) Completed(ctx context.Context, id string) (string, bool, error) {
+	return c.SwarmingClient.Completed(ctx, id)
+}
+
+// DryRunSignService is a sign.Service that refuses signing requests.
+type DryRunSignService struct {
+	SignService sign.Service
+	Trace       *DryRunTrace
+}
+
+var _ sign.Service = (*DryRunSignService)(nil)
+
+func (s *DryRunSignService) SignArtifact(ctx context.Context, bt sign.BuildType, objectURI []string) (string, error) {
+	return "", s.Trace.deny(ctx, "sign", "sign %v as %v", objectURI, bt)
+}
+
+func (s *DryRunSignService) ArtifactSigningStatus(ctx context.Context, jobID string) (sign.Status, string, []string, error) {
+	return s.SignService.ArtifactSigningStatus(ctx, jobID)
+}
+
+func (s *DryRunSignService) CancelSigning(ctx context.Context, jobID string) error {
+	return s.Trace.deny(ctx, "sign", "cancel signing job %s", jobID)
+}
+
+// DryRunTransport is an http.RoundTripper that makes GET and HEAD
+// requests, and refuses all others.
+type DryRunTransport struct {
+	Base  http.RoundTripper // If nil, http.DefaultTransport is used.
+	Trace *DryRunTrace
+}
+
+func (t *DryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, t.Trace.deny(req.Context(), "http", "%s %s", req.Method, req.URL.Redacted())
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
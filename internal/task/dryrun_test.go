@@ -0,0 +1,215 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/cloudbuild/apiv1/v2/cloudbuildpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"golang.org/x/build/gerrit"
+	"golang.org/x/build/internal/relui/sign"
+	wf "golang.org/x/build/internal/workflow"
+)
+
+func TestDryRunGerritClient(t *testing.T) {
+	repo := NewFakeRepo(t, "fake")
+	head := repo.Commit(map[string]string{"README": "hello"})
+	history := repo.History()
+	trace := &DryRunTrace{Store: &FakeDryRunStore{}}
+	gc := &DryRunGerritClient{GerritClient: NewFakeGerrit(t, repo), Trace: trace}
+
+	wfID := uuid.New()
+	ctx := &wf.TaskContext{Context: context.Background(), Logger: &testLogger{t, "update README"}, TaskName: "update README", WorkflowID: wfID}
+
+	// Reads pass through to the real client.
+	got, err := gc.ReadBranchHead(ctx, "fake", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != head {
+		t.Fatalf("ReadBranchHead = %q, want %q", got, head)
+	}
+
+	// Writes are recorded and not made.
+	changeID, err := gc.CreateAutoSubmitChange(ctx, gerrit.ChangeInput{Project: "fake", Branch: "master", Subject: "update README"}, nil, map[string]string{"README": "goodbye"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gc.Tag(ctx, "fake", "v1.0.0", head); err != nil {
+		t.Fatal(err)
+	}
+	if got := repo.History(); len(got) != len(history) {
+		t.Errorf("repo has %d commits after dry run, want %d", len(got), len(history))
+	}
+	if _, err := repo.dir.RunCommand(ctx, "rev-parse", "refs/tags/v1.0.0"); err == nil {
+		t.Errorf("tag v1.0.0 was created in dry run")
+	}
+
+	// Fake changes are immediately submitted at the branch head.
+	commit, submitted, err := gc.Submitted(ctx, changeID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !submitted || commit != head {
+		t.Errorf("Submitted(%q) = %q, %v, want %q, true", changeID, commit, submitted, head)
+	}
+
+	actions, err := trace.Actions(ctx, wfID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 2 {
+		t.Fatalf("recorded %d actions, want 2: %v", len(actions), actions)
+	}
+	for _, a := range actions {
+		if a.Service != "gerrit" || a.TaskName != "update README" {
+			t.Errorf("action %v: got service %q, task %q, want gerrit, update README", a, a.Service, a.TaskName)
+		}
+	}
+	if !strings.Contains(actions[1].Action, "tag v1.0.0") {
+		t.Errorf("second action = %q, want a tag", actions[1].Action)
+	}
+	if got, _ := trace.Actions(ctx, uuid.New()); len(got) != 0 {
+		t.Errorf("Actions for an unrelated workflow = %v, want none", got)
+	}
+}
+
+func TestDryRunTrace(t *testing.T) {
+	trace := &DryRunTrace{Store: &FakeDryRunStore{}}
+	wfID := uuid.New()
+	ctx := &wf.TaskContext{Context: context.Background(), Logger: &testLogger{t, "mail"}, TaskName: "mail", WorkflowID: wfID}
+	if err := trace.SendMail(ctx, MailHeader{From: mail.Address{Address: "relui@golang.org"}, To: mail.Address{Address: "golang-announce@googlegroups.com"}}, MailContent{Subject: "Go 1.99 is released", BodyText: "hooray"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := trace.PublishFile(ctx, WebsiteFile{Filename: "go1.99.src.tar.gz", Size: 1}); err != nil {
+		t.Fatal(err)
+	}
+	// Posts can't be attributed to a workflow, and are only logged.
+	poster := &DryRunPoster{Service: "mastodon", Trace: trace}
+	if _, err := poster.Post("Go 1.99 is released"); err != nil {
+		t.Fatal(err)
+	}
+
+	actions, err := trace.Actions(ctx, wfID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, a := range actions {
+		got = append(got, a.Service)
+	}
+	if diff := cmp.Diff([]string{"mail", "website"}, got); diff != "" {
+		t.Errorf("recorded services mismatch (-want +got):\n%s", diff)
+	}
+	if !strings.Contains(actions[0].Action, "Go 1.99 is released") {
+		t.Errorf("mail action = %q, want the subject", actions[0].Action)
+	}
+}
+
+func TestDryRunGitPush(t *testing.T) {
+	repo := NewFakeRepo(t, "fake")
+	trace := &DryRunTrace{Store: &FakeDryRunStore{}}
+	dir := &GitDir{&Git{DryRun: trace}, repo.dir.dir}
+	wfID := uuid.New()
+	ctx := &wf.TaskContext{Context: context.Background(), Logger: &testLogger{t, "push"}, TaskName: "push", WorkflowID: wfID}
+
+	if _, err := dir.RunGitPush(ctx, "https://go.googlesource.com/fake", "HEAD:refs/for/master"); !errors.Is(err, ErrDryRunPush) {
+		t.Errorf("RunGitPush error = %v, want ErrDryRunPush", err)
+	}
+	if _, err := dir.RunCommand(ctx, "rev-parse", "HEAD"); err != nil {
+		t.Errorf("RunCommand(rev-parse) failed in dry run: %v", err)
+	}
+	if actions, _ := trace.Actions(ctx, wfID); len(actions) != 1 || actions[0].Service != "git" {
+		t.Errorf("recorded %v, want one git push", actions)
+	}
+}
+
+func TestDryRunDenied(t *testing.T) {
+	trace := &DryRunTrace{Store: &FakeDryRunStore{}}
+	wfID := uuid.New()
+	ctx := &wf.TaskContext{Context: context.Background(), Logger: &testLogger{t, "build"}, TaskName: "build", WorkflowID: wfID}
+
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+	}))
+	defer srv.Close()
+	hc := &http.Client{Transport: &DryRunTransport{Trace: trace}}
+
+	cb := &DryRunCloudBuildClient{CloudBuildClient: NewFakeCloudBuild(t, NewFakeGerrit(t), "", nil), Trace: trace}
+	for _, c := range []struct {
+		desc string
+		do   func() error
+	}{
+		{"RunScript", func() error {
+			_, err := cb.RunScript(ctx, "gcloud storage cp x gs://golang/x", "", nil)
+			return err
+		}},
+		{"RunCustomSteps", func() error {
+			_, err := cb.RunCustomSteps(ctx, func(string) []*cloudbuildpb.BuildStep {
+				return []*cloudbuildpb.BuildStep{{Name: "bash"}}
+			}, nil)
+			return err
+		}},
+		{"RunBuild", func() error {
+			_, err := (&DryRunBuildBucketClient{Trace: trace}).RunBuild(ctx, "ci", "gotip-linux-amd64", nil, nil)
+			return err
+		}},
+		{"RunTask", func() error {
+			_, err := (&DryRunSwarmingClient{Trace: trace}).RunTask(ctx, nil, "echo", nil)
+			return err
+		}},
+		{"SignArtifact", func() error {
+			_, err := (&DryRunSignService{Trace: trace}).SignArtifact(ctx, sign.BuildGPG, []string{"gs://golang/go.tgz"})
+			return err
+		}},
+		{"POST", func() error {
+			resp, err := hc.Post(srv.URL, "text/plain", strings.NewReader("hi"))
+			if err == nil {
+				resp.Body.Close()
+			}
+			return err
+		}},
+		{"git push", func() error {
+			return (&Git{DryRun: trace}).runGitStreamed(ctx, io.Discard, io.Discard, t.TempDir(), "-c", "user.name=gopher", "push", "origin", "HEAD")
+		}},
+	} {
+		if err := c.do(); !errors.Is(err, ErrDryRun) {
+			t.Errorf("%s: got error %v, want ErrDryRun", c.desc, err)
+		}
+	}
+	resp, err := hc.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET failed in dry run: %v", err)
+	}
+	resp.Body.Close()
+	if diff := cmp.Diff([]string{"GET"}, methods); diff != "" {
+		t.Errorf("requests that reached the server mismatch (-want +got):\n%s", diff)
+	}
+
+	// The POST isn't attributed to the workflow: its context
+	// isn't a TaskContext.
+	actions, err := trace.Actions(ctx, wfID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 6 {
+		t.Errorf("recorded %d actions for the workflow, want 6: %v", len(actions), actions)
+	}
+	for _, a := range actions {
+		if !a.Denied {
+			t.Errorf("action %v isn't marked as denied", a)
+		}
+	}
+}
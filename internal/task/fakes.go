@@ -1505,3 +1505,28 @@ func (f *FakeGitHub) PostComment(_ context.Context, _ githubv4.ID, _ string) err
 	}
 	return nil
 }
+
+// FakeDryRunStore is a DryRunStore that keeps actions in memory.
+type FakeDryRunStore struct {
+	mu      sync.Mutex
+	actions []DryRunAction
+}
+
+func (s *FakeDryRunStore) AddDryRunAction(_ context.Context, a DryRunAction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actions = append(s.actions, a)
+	return nil
+}
+
+func (s *FakeDryRunStore) DryRunActions(_ context.Context, workflowID uuid.UUID) ([]DryRunAction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var actions []DryRunAction
+	for _, a := range s.actions {
+		if a.WorkflowID == workflowID {
+			actions = append(actions, a)
+		}
+	}
+	return actions, nil
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"strings"
 
 	"golang.org/x/oauth2"
 )
@@ -19,6 +20,10 @@ import (
 type Git struct {
 	ts         oauth2.TokenSource
 	cookieFile string
+
+	// DryRun, if set, makes pushes fail with ErrDryRunPush after being
+	// recorded in it, rather than being made. Other commands run normally.
+	DryRun *DryRunTrace
 }
 
 // UseOAuth2Auth configures Git authentication using ts.
@@ -81,12 +86,16 @@ func (g *Git) run(ctx context.Context, dir string, args ...string) ([]byte, erro
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 	if err := g.runGitStreamed(ctx, stdout, stderr, dir, args...); err != nil {
-		return stdout.Bytes(), fmt.Errorf("git command failed: %v, stderr %v", err, stderr.String())
+		return stdout.Bytes(), fmt.Errorf("git command failed: %w, stderr %v", err, stderr.String())
 	}
 	return stdout.Bytes(), nil
 }
 
 func (g *Git) runGitStreamed(ctx context.Context, stdout, stderr io.Writer, dir string, args ...string) error {
+	if g.DryRun != nil && gitSubcommand(args) == "push" {
+		g.DryRun.add(ctx, DryRunAction{Service: "git", Action: strings.Join(args, " "), Denied: true})
+		return ErrDryRunPush
+	}
 	if g.ts != nil {
 		tok, err := g.ts.Token()
 		if err != nil {
@@ -123,6 +132,20 @@ func (g *GitDir) RunCommand(ctx context.Context, args ...string) ([]byte, error)
 	return g.git.run(ctx, g.dir, args...)
 }
 
+// gitSubcommand returns the git subcommand that args run,
+// skipping any global options before it.
+func gitSubcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		switch a := args[i]; {
+		case a == "-c" || a == "-C":
+			i++ // Skip the option's value.
+		case !strings.HasPrefix(a, "-"):
+			return a
+		}
+	}
+	return ""
+}
+
 // RunGitPush runs a git push command with the given origin and refspec,
 // and returns the remote server's response on success.
 func (g *GitDir) RunGitPush(ctx context.Context, origin, refspec string) ([]byte, error) {
@@ -136,7 +159,7 @@ func (g *GitDir) RunGitPush(ctx context.Context, origin, refspec string) ([]byte
 	var stdout, stderr bytes.Buffer
 	err := g.git.runGitStreamed(ctx, &stdout, &stderr, g.dir, "push", origin, refspec)
 	if err != nil {
-		return nil, fmt.Errorf("git push failed: %v, stdout: %q stderr: %q", err, stdout.String(), stderr.String())
+		return nil, fmt.Errorf("git push failed: %w, stdout: %q stderr: %q", err, stdout.String(), stderr.String())
 	}
 	return stderr.Bytes(), nil
 }
@@ -201,6 +201,11 @@ func (l *govulncheckActionVerboseListener) WorkflowStalled(workflowID uuid.UUID)
 	return nil
 }
 
+func (l *govulncheckActionVerboseListener) WorkflowPaused(workflowID uuid.UUID, paused bool) error {
+	l.t.Logf("workflow %q: paused: %v", workflowID.String(), paused)
+	return nil
+}
+
 func (l *govulncheckActionVerboseListener) TaskStateChanged(_ uuid.UUID, _ string, st *workflow.TaskState) error {
 	if st.Finished && st.Error != "" {
 		l.t.Logf("task %-10v: error: %v", st.Name, st.Error)
@@ -519,6 +519,11 @@ func (l *verboseListener) WorkflowStalled(workflowID uuid.UUID) error {
 	return nil
 }
 
+func (l *verboseListener) WorkflowPaused(workflowID uuid.UUID, paused bool) error {
+	l.t.Logf("workflow %q: paused: %v", workflowID.String(), paused)
+	return nil
+}
+
 func (l *verboseListener) TaskStateChanged(_ uuid.UUID, _ string, st *wf.TaskState) error {
 	switch {
 	case !st.Finished:
@@ -0,0 +1,344 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// A Graph describes the structure of a workflow definition: its parameters,
+// its tasks and the values they consume, and its outputs. It's suitable for
+// encoding as JSON, or as Graphviz DOT with WriteDOT.
+//
+// A Graph is a static view of a definition. Tasks added by expansions,
+// including the branches of If and Switch, don't appear in it until the
+// expansion has run.
+type Graph struct {
+	Parameters []GraphParameter
+	Tasks      []GraphTask // Ordered so that tasks come after the tasks they depend on.
+	Outputs    []GraphOutput
+}
+
+// A GraphParameter is a workflow parameter in a Graph.
+type GraphParameter struct {
+	Name string
+	Type string
+	Doc  string `json:",omitempty"`
+}
+
+// A TaskKind is the kind of a task in a Graph.
+type TaskKind string
+
+const (
+	KindTask      TaskKind = "task"      // Added with TaskN.
+	KindAction    TaskKind = "action"    // Added with ActionN.
+	KindExpansion TaskKind = "expansion" // Added with ExpandN, If or Switch.
+	KindInput     TaskKind = "input"     // Added with Input or Approval.
+)
+
+// A GraphTask is a task in a Graph.
+type GraphTask struct {
+	Name   string
+	Kind   TaskKind
+	Type   string     `json:",omitempty"` // The type of the task's result. Empty for actions.
+	Inputs []GraphRef `json:",omitempty"` // The parameters and tasks the task's arguments come from.
+	After  []GraphRef `json:",omitempty"` // Other dependencies, added with After.
+}
+
+// A GraphOutput is a workflow output in a Graph.
+type GraphOutput struct {
+	Name   string
+	Type   string
+	Inputs []GraphRef // The parameters and tasks the output comes from.
+}
+
+// A GraphRef refers to a parameter or task in a Graph.
+type GraphRef struct {
+	Kind string // "parameter" or "task".
+	Name string
+}
+
+func (r GraphRef) String() string { return r.Kind + " " + r.Name }
+
+// Graph returns the structure of d.
+func (d *Definition) Graph() *Graph {
+	g := &Graph{}
+	for _, p := range d.parameters {
+		g.Parameters = append(g.Parameters, GraphParameter{Name: p.Name(), Type: p.Type().String(), Doc: p.Doc()})
+	}
+	for _, td := range d.sortedTasks() {
+		gt := GraphTask{Name: td.name, Kind: td.kind()}
+		if td.result != nil {
+			gt.Type = td.result.String()
+		}
+		gt.Inputs = graphRefs(td.args)
+		gt.After = graphRefs(td.deps[len(td.args):])
+		g.Tasks = append(g.Tasks, gt)
+	}
+	for _, name := range slices.Sorted(maps.Keys(d.outputs)) {
+		v := d.outputs[name]
+		g.Outputs = append(g.Outputs, GraphOutput{Name: name, Type: v.typ().String(), Inputs: graphRefs([]metaValue{v})})
+	}
+	return g
+}
+
+func (td *taskDefinition) kind() TaskKind {
+	switch {
+	case td.isExpansion:
+		return KindExpansion
+	case td.isInput:
+		return KindInput
+	case td.result == nil:
+		return KindAction
+	default:
+		return KindTask
+	}
+}
+
+// sortedTasks returns d's tasks in topological order, breaking ties by name.
+func (d *Definition) sortedTasks() []*taskDefinition {
+	pending := map[*taskDefinition]int{} // Number of unsorted tasks a task depends on.
+	dependents := map[*taskDefinition][]*taskDefinition{}
+	for _, td := range d.tasks {
+		pending[td] = 0
+	}
+	for _, td := range d.tasks {
+		for _, dep := range uniqueTasks(td.deps) {
+			if d.tasks[dep.name] == dep {
+				pending[td]++
+				dependents[dep] = append(dependents[dep], td)
+			}
+		}
+	}
+	byName := func(a, b *taskDefinition) int { return cmp.Compare(a.name, b.name) }
+	var ready, sorted []*taskDefinition
+	for td, n := range pending {
+		if n == 0 {
+			ready = append(ready, td)
+		}
+	}
+	for len(ready) > 0 {
+		slices.SortFunc(ready, byName)
+		td := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, td)
+		for _, dependent := range dependents[td] {
+			if pending[dependent]--; pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+	return sorted
+}
+
+// graphSources returns the parameters and tasks that the dependencies
+// deps are computed from. Constants aren't computed from anything.
+func graphSources(deps []Dependency) (params []string, tasks []*taskDefinition) {
+	for _, dep := range deps {
+		switch dep := dep.(type) {
+		case MetaParameter:
+			params = append(params, dep.Name())
+		case interface{ definition() *taskDefinition }:
+			tasks = append(tasks, dep.definition())
+		case interface{ elems() []Dependency }:
+			p, t := graphSources(dep.elems())
+			params, tasks = append(params, p...), append(tasks, t...)
+		}
+	}
+	return params, tasks
+}
+
+// uniqueTasks returns the tasks that deps are computed from, without duplicates.
+func uniqueTasks(deps []Dependency) []*taskDefinition {
+	_, tasks := graphSources(deps)
+	var unique []*taskDefinition
+	for _, td := range tasks {
+		if !slices.Contains(unique, td) {
+			unique = append(unique, td)
+		}
+	}
+	return unique
+}
+
+func graphRefs[D Dependency](deps []D) []GraphRef {
+	var ds []Dependency
+	for _, dep := range deps {
+		ds = append(ds, dep)
+	}
+	params, tasks := graphSources(ds)
+	var refs []GraphRef
+	for _, p := range params {
+		refs = append(refs, GraphRef{Kind: "parameter", Name: p})
+	}
+	for _, td := range tasks {
+		refs = append(refs, GraphRef{Kind: "task", Name: td.name})
+	}
+	// Keep the first reference to each source.
+	var unique []GraphRef
+	for _, r := range refs {
+		if !slices.Contains(unique, r) {
+			unique = append(unique, r)
+		}
+	}
+	return unique
+}
+
+func (tr *taskResult[T]) definition() *taskDefinition      { return tr.task }
+func (er *expansionResult[T]) definition() *taskDefinition { return er.td }
+func (d *dependency) definition() *taskDefinition          { return d.task }
+
+func (s *slice[T]) elems() []Dependency {
+	var deps []Dependency
+	for _, v := range s.vals {
+		deps = append(deps, v)
+	}
+	return deps
+}
+
+// WriteDOT writes g to w in the Graphviz DOT language.
+// Edges for After dependencies are dashed.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph workflow {\n")
+	node := func(r GraphRef) string { return dotQuote(r.String()) }
+	for _, p := range g.Parameters {
+		fmt.Fprintf(&b, "\t%s [label=%s, shape=parallelogram];\n", node(GraphRef{"parameter", p.Name}), dotQuote(p.Name+"\n"+p.Type))
+	}
+	attrs := map[TaskKind]string{
+		KindTask:      "shape=box",
+		KindAction:    "shape=box, style=rounded",
+		KindExpansion: "shape=box3d",
+		KindInput:     "shape=house",
+	}
+	for _, t := range g.Tasks {
+		label := t.Name
+		if t.Type != "" {
+			label += "\n" + t.Type
+		}
+		fmt.Fprintf(&b, "\t%s [label=%s, %s];\n", node(GraphRef{"task", t.Name}), dotQuote(label), attrs[t.Kind])
+		for _, in := range t.Inputs {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", node(in), node(GraphRef{"task", t.Name}))
+		}
+		for _, in := range t.After {
+			fmt.Fprintf(&b, "\t%s -> %s [style=dashed];\n", node(in), node(GraphRef{"task", t.Name}))
+		}
+	}
+	for _, o := range g.Outputs {
+		out := dotQuote("output " + o.Name)
+		fmt.Fprintf(&b, "\t%s [label=%s, shape=ellipse, style=bold];\n", out, dotQuote(o.Name+"\n"+o.Type))
+		for _, in := range o.Inputs {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", node(in), out)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote quotes s as a DOT string. Unlike strconv.Quote,
+// it leaves non-ASCII characters alone, since DOT doesn't
+// understand Go's escapes for them.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// A ProblemKind is a kind of defect found by Definition.Validate.
+type ProblemKind string
+
+const (
+	// An UnreachableTask depends, possibly indirectly, on a task that isn't
+	// part of the definition, so it can never run. This usually means that
+	// a task name was reused, or that a Value from another Definition was
+	// passed to it.
+	UnreachableTask ProblemKind = "unreachable task"
+	// An UnusedOutput is a task result that's neither passed to another task
+	// nor registered as a workflow Output.
+	UnusedOutput ProblemKind = "unused output"
+	// An UnusedParameter is a parameter that's never passed to a task or
+	// registered as a workflow Output.
+	UnusedParameter ProblemKind = "unused parameter"
+)
+
+// A Problem is a defect in a workflow definition.
+type Problem struct {
+	Kind    ProblemKind
+	Name    string // The name of the task or parameter with the problem.
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s %q: %s", p.Kind, p.Name, p.Message)
+}
+
+// Validate statically checks d for unreachable tasks, unused outputs and
+// unused parameters, and returns the problems it finds sorted by kind and
+// name. Like Graph, it can't see tasks that expansions haven't added yet.
+//
+// Results of type struct{}, such as those of Approval, and of expansions,
+// which are often used only for the tasks they add, are never reported as
+// unused.
+func (d *Definition) Validate() []Problem {
+	var problems []Problem
+	usedParams := map[string]bool{}
+	usedTasks := map[*taskDefinition]bool{}
+	use := func(deps []Dependency) {
+		params, tasks := graphSources(deps)
+		for _, p := range params {
+			usedParams[p] = true
+		}
+		for _, td := range tasks {
+			usedTasks[td] = true
+		}
+	}
+	for _, td := range d.tasks {
+		use(td.deps)
+	}
+	for _, v := range d.outputs {
+		use([]Dependency{v})
+	}
+
+	// Find tasks that can't run, in dependency order so that a missing
+	// task is reported once, at the first task that depends on it.
+	unreachable := map[*taskDefinition]bool{}
+	for _, td := range d.sortedTasks() {
+		for _, dep := range uniqueTasks(td.deps) {
+			var msg string
+			if d.tasks[dep.name] != dep {
+				msg = fmt.Sprintf("depends on a task named %q that isn't part of this definition", dep.name)
+			} else if unreachable[dep] {
+				msg = fmt.Sprintf("depends on unreachable task %q", dep.name)
+			} else {
+				continue
+			}
+			problems = append(problems, Problem{UnreachableTask, td.name, msg})
+			unreachable[td] = true
+			break
+		}
+	}
+
+	for _, td := range d.tasks {
+		if td.result == nil || td.isExpansion || td.result == reflect.TypeFor[struct{}]() {
+			continue
+		}
+		if !usedTasks[td] {
+			problems = append(problems, Problem{UnusedOutput, td.name, fmt.Sprintf("result of type %v is never used", td.result)})
+		}
+	}
+	for _, p := range d.parameters {
+		if !usedParams[p.Name()] {
+			problems = append(problems, Problem{UnusedParameter, p.Name(), "never used"})
+		}
+	}
+	slices.SortFunc(problems, func(a, b Problem) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name), cmp.Compare(a.Message, b.Message))
+	})
+	return problems
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package workflow_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	wf "golang.org/x/build/internal/workflow"
+)
+
+func TestGraph(t *testing.T) {
+	echo := func(ctx context.Context, arg string) (string, error) { return arg, nil }
+	join := func(ctx context.Context, args []string) (string, error) { return strings.Join(args, ","), nil }
+	act := func(ctx context.Context, arg string) error { return nil }
+
+	wd := wf.New(wf.ACL{})
+	greeting := wf.Param(wd, wf.ParamDef[string]{Name: "greeting", Doc: "A greeting."})
+	a := wf.Task1(wd, "a", echo, greeting)
+	b := wf.Task1(wd, "b", echo, wf.Const("b"))
+	joined := wf.Task1(wd, "join", join, wf.Slice(a, b, greeting))
+	sub := wd.Sub("sub")
+	done := wf.Action1(sub, "act", act, joined)
+	approved := wf.Approval(wd, "approve", wf.After(done))
+	wf.Output(wd, "result", wf.Task1(wd, "last", echo, joined, wf.After(approved)))
+
+	got := wd.Graph()
+	want := &wf.Graph{
+		Parameters: []wf.GraphParameter{{Name: "greeting", Type: "string", Doc: "A greeting."}},
+		Tasks: []wf.GraphTask{
+			{Name: "a", Kind: wf.KindTask, Type: "string", Inputs: []wf.GraphRef{{"parameter", "greeting"}}},
+			{Name: "b", Kind: wf.KindTask, Type: "string"},
+			{Name: "join", Kind: wf.KindTask, Type: "string", Inputs: []wf.GraphRef{{"parameter", "greeting"}, {"task", "a"}, {"task", "b"}}},
+			{Name: "sub: act", Kind: wf.KindAction, Inputs: []wf.GraphRef{{"task", "join"}}},
+			{Name: "approve", Kind: wf.KindInput, Type: "struct {}", After: []wf.GraphRef{{"task", "sub: act"}}},
+			{Name: "last", Kind: wf.KindTask, Type: "string", Inputs: []wf.GraphRef{{"task", "join"}}, After: []wf.GraphRef{{"task", "approve"}}},
+		},
+		Outputs: []wf.GraphOutput{{Name: "result", Type: "string", Inputs: []wf.GraphRef{{"task", "last"}}}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Graph mismatch (-want +got):\n%s", diff)
+	}
+
+	// The graph round-trips through JSON.
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded wf.Graph
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, &decoded); diff != "" {
+		t.Errorf("Graph JSON round trip mismatch (-want +got):\n%s", diff)
+	}
+
+	var dot strings.Builder
+	if err := got.WriteDOT(&dot); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		`"parameter greeting" -> "task a";`,
+		`"task sub: act" [label="sub: act", shape=box, style=rounded];`,
+		`"task sub: act" -> "task approve" [style=dashed];`,
+		`"task last" -> "output result";`,
+	} {
+		if !strings.Contains(dot.String(), line) {
+			t.Errorf("DOT output is missing %q:\n%s", line, dot.String())
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	echo := func(ctx context.Context, arg string) (string, error) { return arg, nil }
+
+	wd := wf.New(wf.ACL{})
+	used := wf.Param(wd, wf.ParamDef[string]{Name: "used"})
+	wf.Param(wd, wf.ParamDef[string]{Name: "unused"})
+	wf.Task1(wd, "dropped", echo, used)
+	old := wf.Task1(wd, "reused", echo, used)
+	wf.Output(wd, "reused", wf.Task1(wd, "reused", echo, used))
+	orphan := wf.Task1(wd, "orphan", echo, old)
+	wf.Output(wd, "downstream", wf.Task1(wd, "downstream", echo, orphan))
+	wf.Approval(wd, "approve")
+
+	got := wd.Validate()
+	want := []wf.Problem{
+		{Kind: wf.UnreachableTask, Name: "downstream", Message: `depends on unreachable task "orphan"`},
+		{Kind: wf.UnreachableTask, Name: "orphan", Message: `depends on a task named "reused" that isn't part of this definition`},
+		{Kind: wf.UnusedOutput, Name: "dropped", Message: "result of type string is never used"},
+		{Kind: wf.UnusedParameter, Name: "unused", Message: "never used"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Validate mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -37,6 +37,16 @@
 // modifications each time. As such, they should be pure functions of their
 // inputs. Producing different modifications is an error that will corrupt
 // the workflow's state. A workflow will run at most one expansion at a time.
+// If and Switch are conditionals built on expansions: only the branch
+// selected by their condition is ever added to the workflow.
+//
+// Inputs are tasks that don't run a function at all. Once their dependencies
+// are ready, they wait for the workflow host to supply a value with
+// SupplyInput, which makes them suitable for human approvals and decisions.
+// Approval is an Input that carries no value.
+//
+// A Definition's structure can be inspected with Graph, which can be rendered
+// as JSON or Graphviz DOT, and checked for common mistakes with Validate.
 //
 // Once a Definition is complete, call Start to set its parameters and
 // instantiate it into a Workflow. Call Run to execute the workflow until
@@ -46,6 +56,7 @@ package workflow
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"reflect"
@@ -360,6 +371,55 @@ type after struct {
 
 func (a *after) taskOption() {}
 
+// Timeout sets a hard deadline on each attempt of a task or action. When
+// the deadline passes, the task's context is canceled and the attempt fails.
+// Unlike the watchdog, the deadline applies no matter how often the task logs.
+func Timeout(d time.Duration) TaskOption {
+	return &timeout{d}
+}
+
+type timeout struct {
+	d time.Duration
+}
+
+func (t *timeout) taskOption() {}
+
+// A RetryPolicy controls automatic retries of a failed task or action.
+// Tasks without a policy are attempted up to MaxRetries times, without
+// waiting between attempts.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the task is attempted,
+	// including the first. Values less than 1 mean 1, disabling retries.
+	MaxAttempts int
+	// Backoff returns how long to wait before the given retry,
+	// numbered from 1. If nil, retries start immediately.
+	Backoff func(retry int) time.Duration
+}
+
+// Retry sets the automatic retry policy for a task or action.
+func Retry(p RetryPolicy) TaskOption {
+	return &retryPolicy{p}
+}
+
+type retryPolicy struct {
+	p RetryPolicy
+}
+
+func (r *retryPolicy) taskOption() {}
+
+// ExponentialBackoff returns a RetryPolicy Backoff function that waits
+// initial before the first retry and doubles the wait for each subsequent
+// retry, up to max.
+func ExponentialBackoff(initial, max time.Duration) func(retry int) time.Duration {
+	return func(retry int) time.Duration {
+		d := initial
+		for i := 1; i < retry && d < max; i++ {
+			d *= 2
+		}
+		return min(d, max)
+	}
+}
+
 // TaskN adds a task to the workflow definition. It takes N inputs, and returns
 // one output. name must uniquely identify the task in the workflow.
 // f must be a function that takes a context.Context or *TaskContext argument,
@@ -402,7 +462,14 @@ func addFunc(d *Definition, name string, f any, inputs []metaValue, opts []TaskO
 		td.deps = append(td.deps, input)
 	}
 	for _, opt := range opts {
-		td.deps = append(td.deps, opt.(*after).deps...)
+		switch opt := opt.(type) {
+		case *after:
+			td.deps = append(td.deps, opt.deps...)
+		case *timeout:
+			td.timeout = opt.d
+		case *retryPolicy:
+			td.retryPolicy = &opt.p
+		}
 	}
 	d.tasks[name] = td
 	return td
@@ -410,6 +477,7 @@ func addFunc(d *Definition, name string, f any, inputs []metaValue, opts []TaskO
 
 func addTask[O1 any](d *Definition, name string, f any, inputs []metaValue, opts []TaskOption) *taskResult[O1] {
 	td := addFunc(d, name, f, inputs, opts)
+	td.result = reflect.TypeFor[O1]()
 	return &taskResult[O1]{td}
 }
 
@@ -421,6 +489,7 @@ func addAction(d *Definition, name string, f any, inputs []metaValue, opts []Tas
 func addExpansion[O1 any](d *Definition, name string, f any, inputs []metaValue, opts []TaskOption) *expansionResult[O1] {
 	td := addFunc(d, name, f, inputs, opts)
 	td.isExpansion = true
+	td.result = reflect.TypeFor[O1]()
 	// Also record the workflow name prefix at the time the expansion is added.
 	// It'll be accessed later, when starting to run this expansion.
 	td.namePrefix = d.namePrefix
@@ -520,6 +589,78 @@ func Expand6[I1, I2, I3, I4, I5, I6, O1 any](d *Definition, name string, f func(
 	return addExpansion[O1](d, name, f, []metaValue{i1, i2, i3, i4, i5, i6}, opts)
 }
 
+// If adds a conditional to the workflow definition. Once cond is ready,
+// either then or els is called to add tasks to the workflow, and the Value
+// it returns becomes the result of the conditional. Tasks in the branch that
+// isn't taken are never added to the workflow. A nil branch produces the
+// zero value of T.
+//
+// Branch functions are run as expansions: they may run multiple times and
+// must produce the exact same changes to the definition each time. Tasks
+// they add are named as if they were added to d.Sub(name).
+func If[T any](d *Definition, name string, cond Value[bool], then, els func(*Definition) (Value[T], error), opts ...TaskOption) Value[T] {
+	return Expand1(d, name, func(d *Definition, cond bool) (Value[T], error) {
+		if cond {
+			return runBranch(d.Sub(name), then)
+		}
+		return runBranch(d.Sub(name), els)
+	}, cond, opts...)
+}
+
+// Switch adds a multi-way conditional to the workflow definition. Once key
+// is ready, the function in cases registered for its value is called to add
+// tasks to the workflow. If there is no such case, dflt is called instead,
+// and if dflt is nil, the switch fails. Otherwise it behaves like If.
+func Switch[K comparable, T any](d *Definition, name string, key Value[K], cases map[K]func(*Definition) (Value[T], error), dflt func(*Definition) (Value[T], error), opts ...TaskOption) Value[T] {
+	return Expand1(d, name, func(d *Definition, key K) (Value[T], error) {
+		if branch, ok := cases[key]; ok {
+			return runBranch(d.Sub(name), branch)
+		}
+		if dflt == nil {
+			return nil, fmt.Errorf("no case for %v and no default", key)
+		}
+		return runBranch(d.Sub(name), dflt)
+	}, key, opts...)
+}
+
+func runBranch[T any](d *Definition, branch func(*Definition) (Value[T], error)) (Value[T], error) {
+	if branch == nil {
+		var zero T
+		return Const(zero), nil
+	}
+	return branch(d)
+}
+
+// Input adds a task to the workflow definition that waits for a value of
+// type T to be supplied by the workflow host. Once all of its dependencies
+// are ready, the task is reported to the Listener as awaiting input, and it
+// finishes when the value is passed to Workflow.SupplyInput. Until then,
+// the workflow keeps running any other tasks that are ready.
+//
+// Inputs survive Resume: a value that was supplied before the workflow
+// stopped is restored like any other task result, and an input that was
+// still waiting is waited on again.
+func Input[T any](d *Definition, name string, opts ...TaskOption) Value[T] {
+	return &taskResult[T]{addInput[T](d, name, opts)}
+}
+
+// Approval adds an Input that carries no value to the workflow definition.
+// It's intended for gates where a human must sign off on the workflow's
+// progress before dependent tasks run. The host approves it by supplying
+// an empty JSON object ("{}") to Workflow.SupplyInput.
+func Approval(d *Definition, name string, opts ...TaskOption) Dependency {
+	return &dependency{addInput[struct{}](d, name, opts)}
+}
+
+func addInput[T any](d *Definition, name string, opts []TaskOption) *taskDefinition {
+	// Inputs never call f. It's only used to record the result type,
+	// the same way it is for other tasks.
+	td := addFunc(d, name, (func(*TaskContext) (T, error))(nil), nil, opts)
+	td.isInput = true
+	td.result = reflect.TypeFor[T]()
+	return td
+}
+
 // A TaskContext is a context.Context, plus workflow-related features.
 type TaskContext struct {
 	disableRetries bool
@@ -589,6 +730,8 @@ type Listener interface {
 	Logger(workflowID uuid.UUID, taskID string) Logger
 	// WorkflowStalled is called when there are no runnable tasks.
 	WorkflowStalled(workflowID uuid.UUID) error
+	// WorkflowPaused is called when the workflow is paused or unpaused.
+	WorkflowPaused(workflowID uuid.UUID, paused bool) error
 }
 
 // TaskState contains the state of a task in a running workflow. Once Finished
@@ -601,12 +744,14 @@ type TaskState struct {
 	SerializedResult []byte
 	Error            string
 	RetryCount       int
+	AwaitingInput    bool // The task is an Input whose value hasn't been supplied yet.
 }
 
 // WorkflowState contains the shallow state of a running workflow.
 type WorkflowState struct {
 	ID     uuid.UUID
 	Params map[string]any
+	Paused bool // Whether the workflow was paused, and should not start new tasks.
 }
 
 // A Logger is a debug logger passed to a task implementation.
@@ -617,10 +762,32 @@ type Logger interface {
 type taskDefinition struct {
 	name        string
 	isExpansion bool
+	isInput     bool   // Waits for a value from SupplyInput rather than calling f.
 	namePrefix  string // Workflow name prefix; applies only when isExpansion is true.
 	args        []metaValue
 	deps        []Dependency
 	f           any
+	result      reflect.Type // The type of the task's result; nil for actions.
+
+	// Execution policy, set by TaskOptions. They apply only to tasks and actions.
+	timeout     time.Duration
+	retryPolicy *RetryPolicy
+}
+
+// maxAttempts returns the maximum number of times the task may be attempted.
+func (td *taskDefinition) maxAttempts() int {
+	if td.retryPolicy == nil {
+		return MaxRetries
+	}
+	return max(td.retryPolicy.MaxAttempts, 1)
+}
+
+// backoff returns how long to wait before the given retry of the task.
+func (td *taskDefinition) backoff(retry int) time.Duration {
+	if td.retryPolicy == nil || td.retryPolicy.Backoff == nil {
+		return 0
+	}
+	return td.retryPolicy.Backoff(retry)
 }
 
 type taskResult[T any] struct {
@@ -643,17 +810,23 @@ func (tr *taskResult[T]) ready(w *Workflow) bool {
 
 // A Workflow is an instantiated workflow instance, ready to run.
 type Workflow struct {
-	ID            uuid.UUID
-	params        map[string]any
-	retryCommands chan retryCommand
+	ID             uuid.UUID
+	params         map[string]any
+	retryCommands  chan retryCommand
+	inputCommands  chan inputCommand
+	pauseCommands  chan pauseCommand
+	cancelCommands chan cancelCommand
 
 	// Notes on ownership and concurrency:
 	// The taskDefinitions used below are immutable. Everything else should be
 	// treated as mutable, used only in the Run goroutine, and never published
 	// to a background goroutine.
 
-	def   *Definition
-	tasks map[*taskDefinition]*taskState
+	def    *Definition
+	tasks  map[*taskDefinition]*taskState
+	paused bool
+	// cancels holds the cancellation functions of running tasks.
+	cancels map[*taskDefinition]context.CancelCauseFunc
 	// pendingStates stores states that haven't been loaded because their
 	// tasks didn't exist at Resume time.
 	pendingStates map[string]*TaskState
@@ -689,6 +862,7 @@ func (t *taskState) toExported() *TaskState {
 		SerializedResult: append([]byte(nil), t.serializedResult...),
 		Started:          t.started,
 		RetryCount:       t.retryCount,
+		AwaitingInput:    t.def.isInput && t.started && !t.finished,
 	}
 	if t.err != nil {
 		state.Error = t.err.Error()
@@ -699,11 +873,15 @@ func (t *taskState) toExported() *TaskState {
 // Start instantiates a workflow with the given parameters.
 func Start(def *Definition, params map[string]any) (*Workflow, error) {
 	w := &Workflow{
-		ID:            uuid.New(),
-		def:           def,
-		params:        params,
-		tasks:         map[*taskDefinition]*taskState{},
-		retryCommands: make(chan retryCommand, len(def.tasks)),
+		ID:             uuid.New(),
+		def:            def,
+		params:         params,
+		tasks:          map[*taskDefinition]*taskState{},
+		cancels:        map[*taskDefinition]context.CancelCauseFunc{},
+		retryCommands:  make(chan retryCommand, len(def.tasks)),
+		inputCommands:  make(chan inputCommand, len(def.tasks)),
+		pauseCommands:  make(chan pauseCommand, 1),
+		cancelCommands: make(chan cancelCommand, len(def.tasks)),
 	}
 	if err := w.validate(); err != nil {
 		return nil, err
@@ -743,12 +921,17 @@ func (w *Workflow) validate() error {
 // need to be populated.
 func Resume(def *Definition, state *WorkflowState, taskStates map[string]*TaskState) (*Workflow, error) {
 	w := &Workflow{
-		ID:            state.ID,
-		params:        state.Params,
-		retryCommands: make(chan retryCommand, len(def.tasks)),
-		def:           def,
-		tasks:         map[*taskDefinition]*taskState{},
-		pendingStates: taskStates,
+		ID:             state.ID,
+		params:         state.Params,
+		retryCommands:  make(chan retryCommand, len(def.tasks)),
+		inputCommands:  make(chan inputCommand, len(def.tasks)),
+		pauseCommands:  make(chan pauseCommand, 1),
+		cancelCommands: make(chan cancelCommand, len(def.tasks)),
+		def:            def,
+		tasks:          map[*taskDefinition]*taskState{},
+		cancels:        map[*taskDefinition]context.CancelCauseFunc{},
+		pendingStates:  taskStates,
+		paused:         state.Paused,
 	}
 	if err := w.validate(); err != nil {
 		return nil, err
@@ -807,6 +990,8 @@ func unmarshalNew(t reflect.Type, data []byte) (any, error) {
 // A workflow will either complete successfully,
 // reach a blocking state waiting on a task to be approved or retried,
 // or get stopped early via context cancellation.
+// Inputs that are waiting for a value don't prevent cancellation.
+// While the workflow is paused, Run doesn't start any new tasks.
 //
 // listener.TaskStateChanged can be used for monitoring and persistence purposes:
 // it will be called immediately, when each task starts, and when they finish.
@@ -823,6 +1008,7 @@ func (w *Workflow) Run(ctx context.Context, listener Listener) (map[string]any,
 	doneOnce := ctx.Done()
 	for {
 		running := 0
+		awaitingInput := 0
 		runningExpansion := false // Whether an expansion is running, and hasn't completed yet.
 		allDone := true
 		for _, task := range w.tasks {
@@ -831,7 +1017,11 @@ func (w *Workflow) Run(ctx context.Context, listener Listener) (map[string]any,
 				listener.TaskStateChanged(w.ID, task.def.name, task.toExported())
 			}
 			if task.started && !task.finished {
-				running++
+				if task.def.isInput {
+					awaitingInput++
+				} else {
+					running++
+				}
 			}
 			if !task.finished || task.err != nil {
 				allDone = false
@@ -841,7 +1031,7 @@ func (w *Workflow) Run(ctx context.Context, listener Listener) (map[string]any,
 			break
 		}
 
-		if ctx.Err() == nil {
+		if ctx.Err() == nil && !w.paused {
 			// Start any idle tasks whose dependencies are all done.
 			for _, task := range w.tasks {
 				if task.started {
@@ -856,6 +1046,12 @@ func (w *Workflow) Run(ctx context.Context, listener Listener) (map[string]any,
 					continue
 				}
 				task.started = true
+				if task.def.isInput {
+					// Nothing to run. The task finishes when its input is supplied.
+					awaitingInput++
+					listener.TaskStateChanged(w.ID, task.def.name, task.toExported())
+					continue
+				}
 				running++
 				listener.TaskStateChanged(w.ID, task.def.name, task.toExported())
 				taskCopy := *task
@@ -865,23 +1061,32 @@ func (w *Workflow) Run(ctx context.Context, listener Listener) (map[string]any,
 					defCopy.namePrefix = task.def.namePrefix
 					go func() { stateChan <- runExpansion(defCopy, taskCopy, args) }()
 				} else {
-					go func() { stateChan <- runTask(ctx, w.ID, listener, taskCopy, args) }()
+					taskCtx, cancel := context.WithCancelCause(ctx)
+					w.cancels[task.def] = cancel
+					go func() { stateChan <- runTask(taskCtx, w.ID, listener, taskCopy, args) }()
 				}
 			}
 		}
 
 		// Honor context cancellation only after all tasks have exited.
+		// A workflow that is paused or waiting for input isn't stalled.
 		if running == 0 {
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
 			default:
-				listener.WorkflowStalled(w.ID)
+				if awaitingInput == 0 && !w.paused {
+					listener.WorkflowStalled(w.ID)
+				}
 			}
 		}
 
 		select {
 		case state := <-stateChan:
+			if cancel, ok := w.cancels[state.def]; ok {
+				cancel(nil)
+				delete(w.cancels, state.def)
+			}
 			if state.def.isExpansion && state.finished && state.err == nil {
 				state.err = w.expand(state.expanded)
 				runningExpansion = false
@@ -902,6 +1107,65 @@ func (w *Workflow) Run(ctx context.Context, listener Listener) (map[string]any,
 			listener.Logger(w.ID, def.name).Printf("Manual retry requested")
 			stateChan <- taskState{def: def, created: true}
 			retry.reply <- nil
+		case input := <-w.inputCommands:
+			def, ok := w.def.tasks[input.name]
+			if !ok {
+				input.reply <- fmt.Errorf("unknown task %q", input.name)
+				break
+			}
+			if !def.isInput {
+				input.reply <- fmt.Errorf("task %q: %w", input.name, ErrNotInput)
+				break
+			}
+			state := w.tasks[def]
+			if !state.started || state.finished {
+				input.reply <- fmt.Errorf("task %q is not awaiting input", input.name)
+				break
+			}
+			result, err := unmarshalNew(reflect.ValueOf(def.f).Type().Out(0), input.serialized)
+			if err != nil {
+				input.reply <- fmt.Errorf("invalid input for task %q: %v", input.name, err)
+				break
+			}
+			listener.Logger(w.ID, def.name).Printf("Input supplied: %s", input.serialized)
+			stateChan <- taskState{
+				def:              def,
+				created:          true,
+				started:          true,
+				finished:         true,
+				result:           result,
+				serializedResult: input.serialized,
+				retryCount:       state.retryCount,
+			}
+			input.reply <- nil
+		case pause := <-w.pauseCommands:
+			if w.paused != pause.paused {
+				w.paused = pause.paused
+				listener.WorkflowPaused(w.ID, w.paused)
+			}
+			pause.reply <- nil
+		case c := <-w.cancelCommands:
+			def, ok := w.def.tasks[c.name]
+			if !ok {
+				c.reply <- fmt.Errorf("unknown task %q", c.name)
+				break
+			}
+			state := w.tasks[def]
+			if !state.started || state.finished {
+				c.reply <- fmt.Errorf("cannot cancel task that is not running")
+				break
+			}
+			listener.Logger(w.ID, def.name).Printf("Cancellation requested")
+			if def.isInput {
+				// There's nothing running; fail the input directly.
+				stateChan <- taskState{def: def, created: true, started: true, finished: true, err: ErrTaskCanceled, retryCount: state.retryCount}
+			} else if cancel, ok := w.cancels[def]; ok {
+				cancel(ErrTaskCanceled)
+			} else {
+				c.reply <- fmt.Errorf("task %q cannot be canceled", c.name)
+				break
+			}
+			c.reply <- nil
 		// Don't get stuck when cancellation comes in after all tasks have
 		// finished, but also don't busy wait if something's still running.
 		case <-doneOnce:
@@ -929,14 +1193,25 @@ func (w *Workflow) taskArgs(def *taskDefinition) ([]reflect.Value, bool) {
 	return args, true
 }
 
-// Maximum number of retries. This could be a workflow property.
+// Maximum number of attempts for tasks that don't have a RetryPolicy.
 var MaxRetries = 3
 
+// ErrTaskCanceled is the error of a task that was stopped by CancelTask.
+var ErrTaskCanceled = errors.New("task canceled")
+
+var errTaskTimeout = errors.New("task timed out")
+
 var WatchdogDelay = 11 * time.Minute // A little over go test -timeout's default value of 10 minutes.
 
-func runTask(ctx context.Context, workflowID uuid.UUID, listener Listener, state taskState, args []reflect.Value) taskState {
-	ctx, cancel := context.WithCancel(ctx)
+func runTask(parent context.Context, workflowID uuid.UUID, listener Listener, state taskState, args []reflect.Value) taskState {
+	// The timeout applies to a single attempt. The retry backoff below
+	// waits on parent, and the next attempt gets a new timeout.
+	ctx, cancel := context.WithCancel(parent)
 	defer cancel()
+	if state.def.timeout > 0 {
+		ctx, cancel = context.WithTimeoutCause(ctx, state.def.timeout, errTaskTimeout)
+		defer cancel()
+	}
 
 	tctx := &TaskContext{
 		Context:       ctx,
@@ -969,6 +1244,12 @@ func runTask(ctx context.Context, workflowID uuid.UUID, listener Listener, state
 		// A task that panicked always needs a human to review, and if it's deemed safe
 		// to retry, do so manually. So, always disable any remaining automatic retries.
 		tctx.disableRetries = true
+	} else if errors.Is(context.Cause(ctx), ErrTaskCanceled) {
+		state.err = ErrTaskCanceled
+		// Canceled tasks are retried only at a human's request.
+		tctx.disableRetries = true
+	} else if errors.Is(context.Cause(ctx), errTaskTimeout) {
+		state.err = fmt.Errorf("task did not complete within its timeout of %v", state.def.timeout)
 	} else if watchdogTimerAlreadyExpired {
 		state.err = fmt.Errorf("task did not log for %v, assumed hung", WatchdogDelay*time.Duration(tctx.watchdogScale))
 	} else if errIdx := len(out) - 1; !out[errIdx].IsNil() {
@@ -985,8 +1266,19 @@ func runTask(ctx context.Context, workflowID uuid.UUID, listener Listener, state
 		}
 	}
 
-	if state.err != nil && !tctx.disableRetries && state.retryCount+1 < MaxRetries {
-		tctx.Printf("task failed, will retry (%v of %v): %v", state.retryCount+1, MaxRetries, state.err)
+	if maxAttempts := state.def.maxAttempts(); state.err != nil && !tctx.disableRetries && state.retryCount+1 < maxAttempts {
+		backoff := state.def.backoff(state.retryCount + 1)
+		if backoff > 0 {
+			tctx.Printf("task failed, will retry in %v (%v of %v): %v", backoff, state.retryCount+1, maxAttempts, state.err)
+			select {
+			case <-time.After(backoff):
+			case <-parent.Done():
+				// Leave the failure for a human to retry.
+				return state
+			}
+		} else {
+			tctx.Printf("task failed, will retry (%v of %v): %v", state.retryCount+1, maxAttempts, state.err)
+		}
 		state = taskState{
 			def:        state.def,
 			created:    true,
@@ -1050,6 +1342,10 @@ func (s *defaultListener) WorkflowStalled(workflowID uuid.UUID) error {
 	return nil
 }
 
+func (s *defaultListener) WorkflowPaused(workflowID uuid.UUID, paused bool) error {
+	return nil
+}
+
 func (s *defaultListener) TaskStateChanged(_ uuid.UUID, _ string, _ *TaskState) error {
 	return nil
 }
@@ -1067,6 +1363,30 @@ type retryCommand struct {
 	reply chan error
 }
 
+type inputCommand struct {
+	name       string
+	serialized []byte
+	reply      chan error
+}
+
+// ErrNotInput is returned by SupplyInput when the named task
+// is not an Input.
+var ErrNotInput = errors.New("task is not an input")
+
+// SupplyInput supplies the value of the named Input task, which must be
+// awaiting input. serialized is the JSON encoding of a value of the
+// input's type, in the same form as TaskState.SerializedResult.
+func (w *Workflow) SupplyInput(ctx context.Context, name string, serialized []byte) error {
+	reply := make(chan error)
+	w.inputCommands <- inputCommand{name, append([]byte(nil), serialized...), reply}
+	select {
+	case err := <-reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // RetryTask retries the named task.
 func (w *Workflow) RetryTask(ctx context.Context, name string) error {
 	reply := make(chan error)
@@ -1078,3 +1398,54 @@ func (w *Workflow) RetryTask(ctx context.Context, name string) error {
 		return ctx.Err()
 	}
 }
+
+type pauseCommand struct {
+	paused bool
+	reply  chan error
+}
+
+// Pause stops the workflow from starting new tasks. Tasks that are already
+// running continue until they finish. Pausing an already paused workflow
+// has no effect.
+func (w *Workflow) Pause(ctx context.Context) error {
+	return w.setPaused(ctx, true)
+}
+
+// Unpause lets a paused workflow start new tasks again.
+func (w *Workflow) Unpause(ctx context.Context) error {
+	return w.setPaused(ctx, false)
+}
+
+func (w *Workflow) setPaused(ctx context.Context, paused bool) error {
+	reply := make(chan error)
+	select {
+	case w.pauseCommands <- pauseCommand{paused, reply}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type cancelCommand struct {
+	name  string
+	reply chan error
+}
+
+// CancelTask stops the named running task. The task finishes with
+// ErrTaskCanceled and is not retried automatically, but can be retried
+// with RetryTask. Inputs that are awaiting a value can be canceled too.
+func (w *Workflow) CancelTask(ctx context.Context, name string) error {
+	reply := make(chan error)
+	w.cancelCommands <- cancelCommand{name, reply}
+	select {
+	case err := <-reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -264,6 +264,87 @@ func TestExpansion(t *testing.T) {
 	}
 }
 
+func TestIf(t *testing.T) {
+	for _, cond := range []bool{true, false} {
+		t.Run(fmt.Sprint(cond), func(t *testing.T) {
+			var thenRan, elseRan bool
+			wd := wf.New(wf.ACL{})
+			c := wf.Param(wd, wf.ParamDef[bool]{Name: "cond (optional)", ParamType: wf.Bool})
+			result := wf.If(wd, "choose", c, func(wd *wf.Definition) (wf.Value[string], error) {
+				return wf.Task0(wd, "then", func(context.Context) (string, error) {
+					thenRan = true
+					return "then", nil
+				}), nil
+			}, func(wd *wf.Definition) (wf.Value[string], error) {
+				return wf.Task0(wd, "else", func(context.Context) (string, error) {
+					elseRan = true
+					return "else", nil
+				}), nil
+			})
+			wf.Output(wd, "result", result)
+
+			storage := &mapListener{Listener: &verboseListener{t}}
+			w := startWorkflow(t, wd, map[string]any{"cond (optional)": cond})
+			outputs := runWorkflow(t, w, storage)
+			want, taken, notTaken := "else", "choose: else", "choose: then"
+			if cond {
+				want, taken, notTaken = "then", "choose: then", "choose: else"
+			}
+			if got := outputs["result"]; got != want {
+				t.Errorf("result = %q, want %q", got, want)
+			}
+			if thenRan == elseRan {
+				t.Errorf("then ran: %v, else ran: %v; want exactly one", thenRan, elseRan)
+			}
+			if _, ok := storage.states[w.ID][taken]; !ok {
+				t.Errorf("task %q doesn't exist, have: %q", taken, slices.Sorted(maps.Keys(storage.states[w.ID])))
+			}
+			if _, ok := storage.states[w.ID][notTaken]; ok {
+				t.Errorf("task %q from the branch not taken exists", notTaken)
+			}
+		})
+	}
+}
+
+func TestSwitch(t *testing.T) {
+	branch := func(s string) func(*wf.Definition) (wf.Value[string], error) {
+		return func(wd *wf.Definition) (wf.Value[string], error) {
+			return wf.Task0(wd, s, func(context.Context) (string, error) { return s, nil }), nil
+		}
+	}
+	cases := map[string]func(*wf.Definition) (wf.Value[string], error){
+		"minor": branch("minor release"),
+		"major": branch("major release"),
+	}
+	tests := []struct {
+		kind string
+		dflt func(*wf.Definition) (wf.Value[string], error)
+		want string
+	}{
+		{"minor", nil, "minor release"},
+		{"major", nil, "major release"},
+		{"beta", branch("pre-release"), "pre-release"},
+		{"rc", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			wd := wf.New(wf.ACL{})
+			kind := wf.Param(wd, wf.ParamDef[string]{Name: "kind"})
+			wf.Output(wd, "result", wf.Switch(wd, "pick", kind, cases, tt.dflt))
+			w := startWorkflow(t, wd, map[string]any{"kind": tt.kind})
+			if tt.want == "" {
+				if got, want := runToFailure(t, w, nil, "pick"), "no case for rc"; !strings.Contains(got, want) {
+					t.Errorf("got error %q, want %q", got, want)
+				}
+				return
+			}
+			if got := runWorkflow(t, w, nil)["result"]; got != tt.want {
+				t.Errorf("result = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestExpansionPanic(t *testing.T) {
 	wd := wf.New(wf.ACL{})
 	wf.Output(wd, "output", wf.Expand0(wd, "panicking expansion", func(*wf.Definition) (wf.Value[string], error) {
@@ -475,6 +556,213 @@ func TestAutomaticRetryDisabled(t *testing.T) {
 	}
 }
 
+func TestRetryPolicy(t *testing.T) {
+	counter := 0
+	var attempts []time.Time
+	flaky := func(ctx *wf.TaskContext) (string, error) {
+		attempts = append(attempts, time.Now())
+		if counter < 4 {
+			counter++
+			return "", fmt.Errorf("counter %v too low", counter)
+		}
+		return "hi", nil
+	}
+
+	wd := wf.New(wf.ACL{})
+	wf.Output(wd, "result", wf.Task0(wd, "flaky", flaky, wf.Retry(wf.RetryPolicy{
+		MaxAttempts: 5,
+		Backoff:     wf.ExponentialBackoff(10*time.Millisecond, 20*time.Millisecond),
+	})))
+
+	w := startWorkflow(t, wd, nil)
+	outputs := runWorkflow(t, w, nil)
+	if got, want := outputs["result"], "hi"; got != want {
+		t.Errorf("result = %q, want %q", got, want)
+	}
+	if len(attempts) != 5 {
+		t.Fatalf("task ran %v times, want 5", len(attempts))
+	}
+	for i, want := range []time.Duration{10, 20, 20, 20} {
+		if got := attempts[i+1].Sub(attempts[i]); got < want*time.Millisecond {
+			t.Errorf("waited %v before retry %v, want at least %v", got, i+1, want*time.Millisecond)
+		}
+	}
+}
+
+func TestRetryPolicyNoRetries(t *testing.T) {
+	counter := 0
+	wd := wf.New(wf.ACL{})
+	wf.Output(wd, "result", wf.Task0(wd, "once", func(context.Context) (string, error) {
+		counter++
+		return "", fmt.Errorf("do not pass go")
+	}, wf.Retry(wf.RetryPolicy{MaxAttempts: 1})))
+
+	w := startWorkflow(t, wd, nil)
+	if got, want := runToFailure(t, w, nil, "once"), "do not pass go"; got != want {
+		t.Errorf("got error %q, want %q", got, want)
+	}
+	if counter != 1 {
+		t.Errorf("task ran %v times, wanted 1", counter)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := wf.ExponentialBackoff(time.Second, 5*time.Second)
+	for retry, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 10: 5 * time.Second} {
+		if got := backoff(retry); got != want {
+			t.Errorf("backoff(%v) = %v, want %v", retry, got, want)
+		}
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	wd := wf.New(wf.ACL{})
+	wf.Output(wd, "result", wf.Task0(wd, "chatty", func(ctx *wf.TaskContext) (string, error) {
+		for {
+			// Logging resets the watchdog, but not the timeout.
+			ctx.Printf("still going")
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}, wf.Timeout(100*time.Millisecond), wf.Retry(wf.RetryPolicy{MaxAttempts: 1})))
+
+	w := startWorkflow(t, wd, nil)
+	if got, want := runToFailure(t, w, nil, "chatty"), "within its timeout of 100ms"; !strings.Contains(got, want) {
+		t.Errorf("got error %q, want %q", got, want)
+	}
+
+	// Each attempt gets its own timeout, so a task that times out
+	// once can still succeed on a retry, even after a backoff.
+	var attempts atomic.Int32
+	wd = wf.New(wf.ACL{})
+	wf.Output(wd, "result", wf.Task0(wd, "slow once", func(ctx *wf.TaskContext) (string, error) {
+		if attempts.Add(1) == 1 {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}
+		return "done", nil
+	}, wf.Timeout(100*time.Millisecond), wf.Retry(wf.RetryPolicy{
+		MaxAttempts: 2,
+		Backoff:     func(int) time.Duration { return 10 * time.Millisecond },
+	})))
+
+	w = startWorkflow(t, wd, nil)
+	outputs := runWorkflow(t, w, nil)
+	if got, want := outputs["result"], "done"; got != want {
+		t.Errorf("result = %q, want %q", got, want)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("task ran %v times, want 2", got)
+	}
+}
+
+func TestPause(t *testing.T) {
+	var secondRan atomic.Bool
+	pause := make(chan bool)
+	wd := wf.New(wf.ACL{})
+	first := wf.Task0(wd, "first", func(context.Context) (string, error) {
+		pause <- true // Wait for the workflow to be paused.
+		<-pause
+		return "first", nil
+	})
+	second := wf.Task1(wd, "second", func(_ context.Context, s string) (string, error) {
+		secondRan.Store(true)
+		return s + " second", nil
+	}, first)
+	wf.Output(wd, "result", second)
+
+	w := startWorkflow(t, wd, nil)
+	listener := &pauseListener{Listener: &verboseListener{t}}
+	go func() {
+		<-pause
+		if err := w.Pause(context.Background()); err != nil {
+			t.Errorf("Pause() = %v", err)
+		}
+		pause <- true
+		// The first task finishes, but the second mustn't start.
+		time.Sleep(100 * time.Millisecond)
+		if secondRan.Load() {
+			t.Errorf("task started while the workflow was paused")
+		}
+		if err := w.Unpause(context.Background()); err != nil {
+			t.Errorf("Unpause() = %v", err)
+		}
+	}()
+	outputs := runWorkflow(t, w, listener)
+	if got, want := outputs["result"], "first second"; got != want {
+		t.Errorf("result = %q, want %q", got, want)
+	}
+	if diff := cmp.Diff([]bool{true, false}, listener.paused); diff != "" {
+		t.Errorf("WorkflowPaused calls mismatch (-want +got):\n%s", diff)
+	}
+	if listener.stalled {
+		t.Errorf("paused workflow reported as stalled")
+	}
+}
+
+func TestResumePaused(t *testing.T) {
+	wd := wf.New(wf.ACL{})
+	wf.Output(wd, "result", wf.Task0(wd, "hi", func(context.Context) (string, error) { return "hi", nil }))
+
+	w, err := wf.Resume(wd, &wf.WorkflowState{ID: uuid.New(), Paused: true}, map[string]*wf.TaskState{"hi": {Name: "hi"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener := &pauseListener{Listener: &verboseListener{t}}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		w.Unpause(context.Background())
+	}()
+	if got, want := runWorkflow(t, w, listener)["result"], "hi"; got != want {
+		t.Errorf("result = %q, want %q", got, want)
+	}
+	if diff := cmp.Diff([]bool{false}, listener.paused); diff != "" {
+		t.Errorf("WorkflowPaused calls mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCancelTask(t *testing.T) {
+	counter := 0
+	started := make(chan bool, 1)
+	wd := wf.New(wf.ACL{})
+	wf.Output(wd, "result", wf.Task0(wd, "slow", func(ctx *wf.TaskContext) (string, error) {
+		counter++
+		if counter > 1 {
+			return "done", nil
+		}
+		started <- true
+		<-ctx.Done()
+		return "", ctx.Err()
+	}))
+
+	w := startWorkflow(t, wd, nil)
+	go func() {
+		<-started
+		if err := w.CancelTask(context.Background(), "slow"); err != nil {
+			t.Errorf("CancelTask() = %v", err)
+		}
+	}()
+	listener := &errorListener{
+		taskName: "slow",
+		callback: func(msg string) {
+			if msg != wf.ErrTaskCanceled.Error() {
+				t.Errorf("canceled task error = %q, want %q", msg, wf.ErrTaskCanceled)
+			}
+			go w.RetryTask(context.Background(), "slow")
+		},
+		Listener: &verboseListener{t},
+	}
+	if got, want := runWorkflow(t, w, listener)["result"], "done"; got != want {
+		t.Errorf("result = %q, want %q", got, want)
+	}
+	if counter != 2 {
+		t.Errorf("task ran %v times, want 2 (no automatic retries after cancellation)", counter)
+	}
+}
+
 func TestWatchdog(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		testWatchdog(t, true)
@@ -627,6 +915,117 @@ func TestResume(t *testing.T) {
 	})
 }
 
+func TestInput(t *testing.T) {
+	wd := wf.New(wf.ACL{})
+	hi := wf.Task0(wd, "hi", func(context.Context) (string, error) { return "hi", nil })
+	approved := wf.Approval(wd, "approve", wf.After(hi))
+	name := wf.Input[string](wd, "name", wf.After(approved))
+	wf.Output(wd, "greeting", wf.Task2(wd, "greet", func(_ context.Context, hi, name string) (string, error) {
+		return hi + " " + name, nil
+	}, hi, name))
+
+	w := startWorkflow(t, wd, nil)
+	supply := func(name, value string) {
+		go func() {
+			if err := w.SupplyInput(context.Background(), name, []byte(value)); err != nil {
+				t.Errorf("SupplyInput(%q, %q) = %v", name, value, err)
+			}
+		}()
+	}
+	listener := &awaitingListener{
+		callback: func(task string) {
+			switch task {
+			case "approve":
+				supply("approve", "{}")
+			case "name":
+				supply("name", `"gopher"`)
+			}
+		},
+		Listener: &verboseListener{t},
+	}
+	outputs := runWorkflow(t, w, listener)
+	if got, want := outputs["greeting"], "hi gopher"; got != want {
+		t.Errorf("greeting = %q, want %q", got, want)
+	}
+}
+
+func TestInputErrors(t *testing.T) {
+	wd := wf.New(wf.ACL{})
+	hi := wf.Task0(wd, "hi", func(context.Context) (string, error) { return "hi", nil })
+	wf.Output(wd, "count", wf.Input[int](wd, "count"))
+	wf.Output(wd, "hi", hi)
+
+	w := startWorkflow(t, wd, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	listener := &awaitingListener{
+		callback: func(string) {
+			go func() {
+				if err := w.SupplyInput(ctx, "hi", []byte(`"hi"`)); !errors.Is(err, wf.ErrNotInput) {
+					t.Errorf("SupplyInput to a task = %v, want ErrNotInput", err)
+				}
+				if err := w.SupplyInput(ctx, "count", []byte(`"many"`)); err == nil {
+					t.Errorf("SupplyInput of the wrong type succeeded")
+				}
+				if err := w.SupplyInput(ctx, "count", []byte(`3`)); err != nil {
+					t.Errorf("SupplyInput(3) = %v", err)
+				}
+			}()
+		},
+		Listener: &verboseListener{t},
+	}
+	outputs, err := w.Run(ctx, listener)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := outputs["count"], 3; got != want {
+		t.Errorf("count = %v, want %v", got, want)
+	}
+}
+
+func TestResumeInput(t *testing.T) {
+	wd := wf.New(wf.ACL{})
+	wf.Output(wd, "name", wf.Input[string](wd, "name"))
+
+	// Stop the workflow while it's waiting for input. Waiting
+	// for input must not prevent cancellation.
+	ctx, cancel := context.WithCancel(context.Background())
+	storage := &mapListener{Listener: &awaitingListener{
+		callback: func(string) { cancel() },
+		Listener: &verboseListener{t},
+	}}
+	w := startWorkflow(t, wd, nil)
+	if _, err := w.Run(ctx, storage); !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled workflow returned error %v, wanted Canceled", err)
+	}
+	storage.assertState(t, w, map[string]*wf.TaskState{
+		"name": {Name: "name", Started: true, AwaitingInput: true},
+	})
+
+	resumed, err := wf.Resume(wd, &wf.WorkflowState{ID: w.ID}, storage.states[w.ID])
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage.Listener = &awaitingListener{
+		callback: func(string) {
+			go resumed.SupplyInput(context.Background(), "name", []byte(`"gopher"`))
+		},
+		Listener: &verboseListener{t},
+	}
+	if got, want := runWorkflow(t, resumed, storage)["name"], "gopher"; got != want {
+		t.Errorf("name = %q, want %q", got, want)
+	}
+
+	// A supplied input is restored like any other result.
+	resumed, err = wf.Resume(wd, &wf.WorkflowState{ID: w.ID}, storage.states[w.ID])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := runWorkflow(t, resumed, nil)["name"], "gopher"; got != want {
+		t.Errorf("name after second resume = %q, want %q", got, want)
+	}
+}
+
 type badResult struct {
 	unexported string
 }
@@ -697,6 +1096,11 @@ func (l *verboseListener) WorkflowStalled(workflowID uuid.UUID) error {
 	return nil
 }
 
+func (l *verboseListener) WorkflowPaused(workflowID uuid.UUID, paused bool) error {
+	l.t.Logf("workflow %q: paused: %v", workflowID.String(), paused)
+	return nil
+}
+
 func (l *verboseListener) TaskStateChanged(_ uuid.UUID, _ string, st *wf.TaskState) error {
 	switch {
 	case !st.Started:
@@ -761,3 +1165,34 @@ func (l *errorListener) TaskStateChanged(id uuid.UUID, taskID string, st *wf.Tas
 	l.Listener.TaskStateChanged(id, taskID, st)
 	return nil
 }
+
+// awaitingListener calls callback with the name of each task
+// that starts awaiting input.
+type awaitingListener struct {
+	callback func(string)
+	wf.Listener
+}
+
+func (l *awaitingListener) TaskStateChanged(id uuid.UUID, taskID string, st *wf.TaskState) error {
+	if st.AwaitingInput {
+		l.callback(st.Name)
+	}
+	return l.Listener.TaskStateChanged(id, taskID, st)
+}
+
+// pauseListener records calls to WorkflowPaused and WorkflowStalled.
+type pauseListener struct {
+	wf.Listener
+	paused  []bool
+	stalled bool
+}
+
+func (l *pauseListener) WorkflowPaused(id uuid.UUID, paused bool) error {
+	l.paused = append(l.paused, paused)
+	return l.Listener.WorkflowPaused(id, paused)
+}
+
+func (l *pauseListener) WorkflowStalled(id uuid.UUID) error {
+	l.stalled = true
+	return l.Listener.WorkflowStalled(id)
+}
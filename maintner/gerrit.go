@@ -13,8 +13,10 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/url"
 	"os"
@@ -29,6 +31,7 @@ import (
 
 	"golang.org/x/build/internal/envutil"
 	"golang.org/x/build/maintner/maintpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Gerrit holds information about a number of Gerrit projects.
@@ -100,6 +103,7 @@ type GerritProject struct {
 	commit          map[GitHash]*GitCommit
 	numLabelChanges int // incremented (too many times) by meta commits with "Label:" updates
 	dirtyCL         map[*GerritCL]struct{}
+	comments        map[GitHash][]*GerritComment // by the meta commit that published them
 
 	// ref are the non-change refs with keys like "HEAD",
 	// "refs/heads/master", "refs/tags/v0.8.0", etc.
@@ -355,6 +359,64 @@ type GerritMessage struct {
 	Author *GitPerson
 }
 
+// GerritComment is an inline comment on a file in a patch set of a CL,
+// or a reply to one. Unlike a GerritMessage, it's not in the text of
+// a meta commit, but in a NoteDb note in the meta commit's tree.
+type GerritComment struct {
+	// Meta is the meta commit that published the comment.
+	Meta *GitCommit
+
+	// UUID identifies the comment. ParentUUID is the UUID of the
+	// comment it replies to, or empty if it starts a thread.
+	UUID       string
+	ParentUUID string
+
+	// PatchSet is the patch set version the comment is on, and
+	// Revision is that version's commit.
+	PatchSet int32
+	Revision GitHash
+
+	// Filename is the commented-on file. Comments on the commit
+	// message are on "/COMMIT_MSG", and ones on the patch set as a
+	// whole are on "/PATCHSET_LEVEL".
+	Filename string
+
+	// Line is the line the comment is on, or 0 for the whole file.
+	Line int32
+
+	// AuthorID is the Gerrit account ID of the comment's author,
+	// on the server with UUID ServerID.
+	AuthorID int64
+	ServerID string
+
+	Written    time.Time
+	Message    string
+	Unresolved bool
+}
+
+// AuthorEmail returns the comment author's email in the form Gerrit
+// uses in meta commits, "<account ID>@<server ID>". It matches the
+// Email of GerritMessage authors.
+func (c *GerritComment) AuthorEmail() string {
+	return fmt.Sprintf("%d@%s", c.AuthorID, c.ServerID)
+}
+
+// GerritAttentionChange is a change to a CL's attention set, the
+// users whose action the CL is waiting on.
+type GerritAttentionChange struct {
+	// Meta is the meta commit that made the change.
+	Meta *GitCommit
+
+	// Person is who was added or removed, in the form
+	// "Gerrit User 13437 <13437@62eb7196-b449-3ce5-99f1-c037f21e1705>",
+	// and Email is the part inside the angle brackets.
+	Person string
+	Email  string
+
+	Operation string // "ADD" or "REMOVE"
+	Reason    string // such as "<GERRIT_ACCOUNT_13437> replied on the change"
+}
+
 // References reports whether cl includes a commit message reference
 // to the provided Github issue ref.
 func (cl *GerritCL) References(ref GitHubIssueRef) bool {
@@ -514,6 +576,116 @@ func (cl *GerritCL) CommitAtVersion(version int32) *GitCommit {
 	return cl.Project.commit[hash]
 }
 
+// ForeachComment calls fn for each inline comment on the CL, in the
+// order they were published. Iteration ends if fn returns an error,
+// with that error.
+//
+// Comments are only known for meta commits processed by a version of
+// maintner that recorded them.
+func (cl *GerritCL) ForeachComment(fn func(*GerritComment) error) error {
+	for _, m := range cl.Metas {
+		for _, c := range cl.Project.comments[m.Commit.Hash] {
+			if err := fn(c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// UnresolvedCommentCount returns the number of unresolved comment
+// threads on the CL. As in Gerrit, a thread is unresolved if its most
+// recent comment is.
+func (cl *GerritCL) UnresolvedCommentCount() int {
+	parent := make(map[string]string) // UUID => parent UUID
+	var all []*GerritComment
+	cl.ForeachComment(func(c *GerritComment) error {
+		parent[c.UUID] = c.ParentUUID
+		all = append(all, c)
+		return nil
+	})
+	root := func(uuid string) string {
+		for range len(parent) {
+			p := parent[uuid]
+			if p == "" {
+				break
+			}
+			uuid = p
+		}
+		return uuid
+	}
+	last := make(map[string]*GerritComment) // thread root UUID => latest comment
+	for _, c := range all {
+		r := root(c.UUID)
+		if l := last[r]; l == nil || !c.Written.Before(l.Written) {
+			last[r] = c
+		}
+	}
+	n := 0
+	for _, c := range last {
+		if c.Unresolved {
+			n++
+		}
+	}
+	return n
+}
+
+// AttentionChanges returns the changes to the CL's attention set,
+// from oldest to newest.
+func (cl *GerritCL) AttentionChanges() []*GerritAttentionChange {
+	var changes []*GerritAttentionChange
+	for _, m := range cl.Metas {
+		remain := m.Footer()
+		for len(remain) > 0 {
+			var v string
+			v, remain = lineValueRest(remain, "Attention: ")
+			if v == "" {
+				continue
+			}
+			var a struct {
+				PersonIdent string `json:"person_ident"`
+				Operation   string `json:"operation"`
+				Reason      string `json:"reason"`
+			}
+			if err := json.Unmarshal([]byte(v), &a); err != nil {
+				continue
+			}
+			ac := &GerritAttentionChange{
+				Meta:      m.Commit,
+				Person:    a.PersonIdent,
+				Operation: a.Operation,
+				Reason:    a.Reason,
+			}
+			if i := strings.IndexByte(a.PersonIdent, '<'); i >= 0 {
+				ac.Email = strings.TrimSuffix(a.PersonIdent[i+1:], ">")
+			}
+			changes = append(changes, ac)
+		}
+	}
+	return changes
+}
+
+// AttentionSet returns the emails, in the form "<account
+// ID>@<server ID>", of the users currently in the CL's attention
+// set, sorted.
+func (cl *GerritCL) AttentionSet() []string {
+	set := make(map[string]bool)
+	for _, ac := range cl.AttentionChanges() {
+		switch ac.Operation {
+		case "ADD":
+			set[ac.Email] = true
+		case "REMOVE":
+			delete(set, ac.Email)
+		}
+	}
+	emails := make([]string, 0, len(set))
+	for e := range set {
+		emails = append(emails, e)
+	}
+	sort.Strings(emails)
+	return emails
+}
+
 func (cl *GerritCL) updateGithubIssueRefs() {
 	gp := cl.Project
 	gerrit := gp.gerrit
@@ -764,6 +936,37 @@ func (gp *GerritProject) processMutation(gm *maintpb.GerritMutation) {
 		}
 	}
 
+	for _, cp := range gm.Comments {
+		var meta *GitCommit
+		if isGitHashHex(cp.MetaSha1) {
+			meta = c.gitCommit[c.gitHashFromHexStr(cp.MetaSha1)]
+		}
+		if meta == nil || cp.Uuid == "" {
+			gp.logf("ignoring comment %q for unknown meta commit %q", cp.Uuid, cp.MetaSha1)
+			continue
+		}
+		gc := &GerritComment{
+			Meta:       meta,
+			UUID:       cp.Uuid,
+			ParentUUID: cp.ParentUuid,
+			PatchSet:   cp.PatchSet,
+			Filename:   c.str(cp.Filename),
+			Line:       cp.Line,
+			AuthorID:   cp.AuthorId,
+			ServerID:   c.str(cp.ServerId),
+			Written:    cp.Written.AsTime().UTC(),
+			Message:    cp.Message,
+			Unresolved: cp.Unresolved,
+		}
+		if isGitHashHex(cp.RevId) {
+			gc.Revision = c.gitHashFromHexStr(cp.RevId)
+		}
+		if gp.comments == nil {
+			gp.comments = make(map[GitHash][]*GerritComment)
+		}
+		gp.comments[meta.Hash] = append(gp.comments[meta.Hash], gc)
+	}
+
 	for _, refName := range gm.DeletedRefs {
 		delete(gp.ref, refName)
 		// TODO: this doesn't delete change refs (from
@@ -805,6 +1008,7 @@ func (gp *GerritProject) processMutation(gm *maintpb.GerritMutation) {
 		clv := gerritCLVersion{int32(clNum64), version}
 		gp.remote[clv] = hash
 		cl := gp.getOrCreateCL(clv.CLNumber)
+		c.noteCLChanged(cl)
 
 		if clv.Version == 0 { // is a meta commit
 			cl.Meta = newGerritMeta(gc, cl)
@@ -1180,6 +1384,7 @@ func (gp *GerritProject) syncOnce(ctx context.Context) error {
 func (gp *GerritProject) syncCommits(ctx context.Context) (n int, err error) {
 	c := gp.gerrit.c
 	lastLog := time.Now()
+	var catFile *gitCatFile // started on the first commit to index
 	for {
 		hash := gp.commitToIndex()
 		if hash == "" {
@@ -1194,16 +1399,219 @@ func (gp *GerritProject) syncCommits(ctx context.Context) (n int, err error) {
 		if err != nil {
 			return n, err
 		}
+		if catFile == nil {
+			catFile, err = startGitCatFile(gp.gitDir())
+			if err != nil {
+				return n, err
+			}
+			defer catFile.Close()
+		}
+		comments, err := gerritCommentsFromGit(catFile, commit)
+		if err != nil {
+			return n, err
+		}
 		c.addMutation(&maintpb.Mutation{
 			Gerrit: &maintpb.GerritMutation{
-				Project: gp.proj,
-				Commits: []*maintpb.GitCommit{commit},
+				Project:  gp.proj,
+				Commits:  []*maintpb.GitCommit{commit},
+				Comments: comments,
 			},
 		})
 		n++
 	}
 }
 
+// gerritCommentsFromGit returns the inline comments published by
+// commit, if it's a Gerrit NoteDb meta commit.
+//
+// A meta commit's tree has a note for each patch set with comments,
+// named for the patch set's commit, holding all of the patch set's
+// published comments as JSON. The comments commit published are the
+// ones in the notes it changed that aren't in its parent's copies.
+func gerritCommentsFromGit(catFile *gitCatFile, commit *maintpb.GitCommit) ([]*maintpb.GerritComment, error) {
+	if !bytes.Contains(commit.Raw, []byte("\nPatch-set: ")) {
+		return nil, nil
+	}
+	var parent string
+	if i := bytes.Index(commit.Raw, []byte("\nparent ")); i >= 0 && len(commit.Raw) >= i+len("\nparent ")+40 {
+		parent = string(commit.Raw[i+len("\nparent ") : i+len("\nparent ")+40])
+	}
+	var comments []*maintpb.GerritComment
+	for _, f := range commit.GetDiffTree().GetFile() {
+		if f.Added == 0 || !isGitHashHex(strings.ReplaceAll(f.File, "/", "")) {
+			continue
+		}
+		note, err := catFile.fileAt(commit.Sha1, f.File)
+		if err != nil {
+			return nil, err
+		}
+		var old []*maintpb.GerritComment
+		if parent != "" {
+			// The note may be new in commit, so ignore errors.
+			if data, err := catFile.fileAt(parent, f.File); err == nil {
+				old = parseGerritNoteComments(data)
+			}
+		}
+		seen := make(map[string]bool)
+		for _, c := range old {
+			seen[c.Uuid] = true
+		}
+		for _, c := range parseGerritNoteComments(note) {
+			if !seen[c.Uuid] {
+				c.MetaSha1 = commit.Sha1
+				comments = append(comments, c)
+			}
+		}
+	}
+	return comments, nil
+}
+
+// gitCatFile reads files from a git repository using a single
+// long-lived "git cat-file --batch", rather than a process per file.
+type gitCatFile struct {
+	cmd *exec.Cmd
+	in  io.WriteCloser
+	out *bufio.Reader
+}
+
+// errGitFileMissing is returned by gitCatFile.fileAt for files that
+// don't exist.
+var errGitFileMissing = errors.New("no such file")
+
+// startGitCatFile starts a "git cat-file --batch" in the git
+// repository dir. The caller must Close it.
+func startGitCatFile(dir string) (*gitCatFile, error) {
+	cmd := exec.Command("git", "cat-file", "--batch")
+	envutil.SetDir(cmd, dir)
+	cmd.Stderr = os.Stderr
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("git cat-file --batch: %v", err)
+	}
+	return &gitCatFile{cmd: cmd, in: in, out: bufio.NewReader(out)}, nil
+}
+
+// fileAt returns the contents of the file at path in the given git
+// commit.
+func (g *gitCatFile) fileAt(commit, path string) ([]byte, error) {
+	name := commit + ":" + path
+	if strings.ContainsAny(name, "\r\n") {
+		return nil, fmt.Errorf("git cat-file --batch: invalid object name %q", name)
+	}
+	if _, err := io.WriteString(g.in, name+"\n"); err != nil {
+		return nil, fmt.Errorf("git cat-file --batch: %v", err)
+	}
+	// The reply is "<sha1> <type> <size>\n<contents>\n", or
+	// "<name> missing\n".
+	header, err := g.out.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file --batch: %v", err)
+	}
+	f := strings.Fields(header)
+	if len(f) == 2 && f[1] == "missing" {
+		return nil, fmt.Errorf("%s: %w", name, errGitFileMissing)
+	}
+	if len(f) != 3 {
+		return nil, fmt.Errorf("git cat-file --batch: unexpected reply %q for %s", header, name)
+	}
+	size, err := strconv.ParseInt(f[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file --batch: unexpected reply %q for %s", header, name)
+	}
+	data := make([]byte, size+1)
+	if _, err := io.ReadFull(g.out, data); err != nil {
+		return nil, fmt.Errorf("git cat-file --batch: reading %s: %v", name, err)
+	}
+	if f[1] != "blob" {
+		return nil, fmt.Errorf("%s is a %s, not a file", name, f[1])
+	}
+	return data[:size], nil
+}
+
+// Close stops the git cat-file process.
+func (g *gitCatFile) Close() error {
+	g.in.Close()
+	return g.cmd.Wait()
+}
+
+// parseGerritNoteComments parses the comments in a NoteDb note. Notes
+// in the legacy text format, and malformed comments, are ignored.
+func parseGerritNoteComments(note []byte) []*maintpb.GerritComment {
+	var data struct {
+		Comments []struct {
+			Key struct {
+				UUID       string `json:"uuid"`
+				Filename   string `json:"filename"`
+				PatchSetID int32  `json:"patchSetId"`
+			} `json:"key"`
+			LineNbr    int32              `json:"lineNbr"`
+			Author     struct{ ID int64 } `json:"author"`
+			WrittenOn  string             `json:"writtenOn"`
+			Message    string             `json:"message"`
+			ParentUUID string             `json:"parentUuid"`
+			RevID      string             `json:"revId"`
+			ServerID   string             `json:"serverId"`
+			Unresolved bool               `json:"unresolved"`
+		} `json:"comments"`
+	}
+	if err := json.Unmarshal(note, &data); err != nil {
+		return nil
+	}
+	var comments []*maintpb.GerritComment
+	for _, jc := range data.Comments {
+		written, ok := parseGerritNoteTime(jc.WrittenOn)
+		if jc.Key.UUID == "" || !ok {
+			continue
+		}
+		comments = append(comments, &maintpb.GerritComment{
+			Uuid:       jc.Key.UUID,
+			ParentUuid: jc.ParentUUID,
+			PatchSet:   jc.Key.PatchSetID,
+			RevId:      jc.RevID,
+			Filename:   jc.Key.Filename,
+			Line:       jc.LineNbr,
+			AuthorId:   jc.Author.ID,
+			ServerId:   jc.ServerID,
+			Written:    timestamppb.New(written),
+			Message:    jc.Message,
+			Unresolved: jc.Unresolved,
+		})
+	}
+	return comments
+}
+
+// parseGerritNoteTime parses a comment's writtenOn time. Gerrit
+// writes them in RFC 3339 format, but older notes have them in the
+// format of Java's DateFormat, in UTC.
+func parseGerritNoteTime(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "Jan 2, 2006 3:04:05 PM", "Jan 2, 2006, 3:04:05 PM"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// isGitHashHex reports whether s is a full hex git hash.
+func isGitHashHex(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if _, ok := fromHexChar(s[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (gp *GerritProject) commitToIndex() GitHash {
 	c := gp.gerrit.c
 
@@ -6,6 +6,11 @@ package maintner
 
 import (
 	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -559,3 +564,99 @@ func TestGerritHashtagsLen(t *testing.T) {
 		}
 	}
 }
+
+func TestParseGerritNoteComments(t *testing.T) {
+	note := []byte(`{
+  "comments": [
+    {
+      "key": {"uuid": "aa", "filename": "main.go", "patchSetId": 2},
+      "lineNbr": 7,
+      "author": {"id": 1234},
+      "writtenOn": "2017-03-04T19:47:19Z",
+      "message": "Why?",
+      "revId": "17839a9f284b473986f235ad2757a2b445d05068",
+      "serverId": "62eb7196-b449-3ce5-99f1-c037f21e1705",
+      "unresolved": true
+    },
+    {
+      "key": {"uuid": "bb", "filename": "main.go", "patchSetId": 2},
+      "lineNbr": 7,
+      "author": {"id": 5678},
+      "writtenOn": "Mar 5, 2017 1:02:03 PM",
+      "message": "Because.",
+      "parentUuid": "aa",
+      "serverId": "62eb7196-b449-3ce5-99f1-c037f21e1705"
+    },
+    {
+      "key": {"uuid": "cc"},
+      "writtenOn": "yesterday"
+    }
+  ]
+}`)
+	got := parseGerritNoteComments(note)
+	if len(got) != 2 {
+		t.Fatalf("got %d comments, want 2", len(got))
+	}
+	if c := got[0]; c.Uuid != "aa" || c.Filename != "main.go" || c.PatchSet != 2 || c.Line != 7 || c.AuthorId != 1234 || !c.Unresolved || c.Message != "Why?" {
+		t.Errorf("first comment = %v", c)
+	}
+	if c, want := got[1], time.Date(2017, 3, 5, 13, 2, 3, 0, time.UTC); c.ParentUuid != "aa" || c.Unresolved || !c.Written.AsTime().Equal(want) {
+		t.Errorf("second comment = %v, want reply to aa written at %v", c, want)
+	}
+	if got := parseGerritNoteComments([]byte("Patch-set: 1\nFile: main.go\n")); got != nil {
+		t.Errorf("legacy note parsed as %v, want nil", got)
+	}
+}
+
+func TestGitCatFile(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=a", "GIT_AUTHOR_EMAIL=a@example.com", "GIT_COMMITTER_NAME=a", "GIT_COMMITTER_EMAIL=a@example.com")
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q")
+	if err := os.MkdirAll(filepath.Join(dir, "ab"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ab", "cdef"), []byte("note\nwith lines\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "empty"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "notes")
+	commit := git("rev-parse", "HEAD")
+
+	cf, err := startGitCatFile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cf.Close()
+	// Requests are answered in turn by the same process, including
+	// after errors.
+	for i := 0; i < 2; i++ {
+		if got, err := cf.fileAt(commit, "ab/cdef"); err != nil || string(got) != "note\nwith lines\n" {
+			t.Errorf("fileAt(%s, ab/cdef) = %q, %v; want the note", commit, got, err)
+		}
+		if _, err := cf.fileAt(commit, "nope"); !errors.Is(err, errGitFileMissing) {
+			t.Errorf("fileAt(%s, nope) = %v; want errGitFileMissing", commit, err)
+		}
+		if _, err := cf.fileAt(commit, "ab"); err == nil {
+			t.Errorf("fileAt(%s, ab) succeeded for a directory", commit)
+		}
+		if got, err := cf.fileAt(commit, "empty"); err != nil || len(got) != 0 {
+			t.Errorf("fileAt(%s, empty) = %q, %v; want an empty file", commit, got, err)
+		}
+	}
+}
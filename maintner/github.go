@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-github/v74/github"
 	"github.com/gregjones/httpcache"
+	"github.com/shurcooL/githubv4"
 	"golang.org/x/build/maintner/maintpb"
 	"golang.org/x/oauth2"
 	"golang.org/x/sync/errgroup"
@@ -106,11 +107,12 @@ func (g *GitHub) getOrCreateRepo(owner, repo string) *GitHubRepo {
 }
 
 type GitHubRepo struct {
-	github     *GitHub
-	id         GitHubRepoID
-	issues     map[int32]*GitHubIssue // num -> issue
-	milestones map[int64]*GitHubMilestone
-	labels     map[int64]*GitHubLabel
+	github      *GitHub
+	id          GitHubRepoID
+	issues      map[int32]*GitHubIssue // num -> issue
+	milestones  map[int64]*GitHubMilestone
+	labels      map[int64]*GitHubLabel
+	discussions map[int32]*GitHubDiscussion // num -> discussion
 }
 
 func (gr *GitHubRepo) ID() GitHubRepoID { return gr.id }
@@ -189,6 +191,74 @@ func (pr *GitHubIssue) ForeachReview(fn func(*GitHubReview) error) error {
 	return nil
 }
 
+// HeadSHA returns the commit at the head of the pull request, as of
+// the last time its changed files were synced. It returns the empty
+// string if the head isn't known or the issue isn't a pull request.
+func (pr *GitHubIssue) HeadSHA() string {
+	if !pr.PullRequest {
+		return ""
+	}
+	return pr.headSHA
+}
+
+// ForeachFile calls fn for each file changed by the pull request, as
+// of HeadSHA.
+//
+// If the issue is not a PullRequest, then it returns early with no error.
+//
+// If fn returns an error, iteration ends and ForeachFile returns
+// with that error.
+//
+// The fn function is called serially, in order of file name.
+func (pr *GitHubIssue) ForeachFile(fn func(*GitHubPullRequestFile) error) error {
+	if !pr.PullRequest {
+		return nil
+	}
+	for _, f := range pr.files {
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FilesTruncated reports whether GitHub listed only some of the files
+// changed by the pull request, so ForeachFile doesn't visit them all.
+// GitHub lists at most 3000 files.
+func (pr *GitHubIssue) FilesTruncated() bool { return pr.filesTruncated }
+
+// ForeachCheckRun calls fn for each check run on the pull request's
+// HeadSHA.
+//
+// If the issue is not a PullRequest, then it returns early with no error.
+//
+// If fn returns an error, iteration ends and ForeachCheckRun returns
+// with that error.
+//
+// The fn function is called serially, in order of check run name,
+// then ID.
+func (pr *GitHubIssue) ForeachCheckRun(fn func(*GitHubCheckRun) error) error {
+	if !pr.PullRequest {
+		return nil
+	}
+	s := make([]*GitHubCheckRun, 0, len(pr.checkRuns))
+	for _, cr := range pr.checkRuns {
+		s = append(s, cr)
+	}
+	sort.Slice(s, func(i, j int) bool {
+		if s[i].Name != s[j].Name {
+			return s[i].Name < s[j].Name
+		}
+		return s[i].ID < s[j].ID
+	})
+	for _, cr := range s {
+		if err := fn(cr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (g *GitHubRepo) getOrCreateMilestone(id int64) *GitHubMilestone {
 	if id == 0 {
 		panic("zero id")
@@ -283,6 +353,14 @@ type GitHubIssue struct {
 	reviewsSyncedAsOf  time.Time                   // as of server's Date header
 	events             map[int64]*GitHubIssueEvent // by event.ID
 	reviews            map[int64]*GitHubReview     // by event.ID
+
+	// The following are only set for pull requests.
+	headSHA             string                    // head commit as of the last files sync
+	files               []*GitHubPullRequestFile  // sorted by Filename
+	filesTruncated      bool                      // GitHub listed only some files
+	filesSyncedAsOf     time.Time                 // as of server's Date header
+	checkRuns           map[int64]*GitHubCheckRun // by run ID; only for headSHA
+	checkRunsSyncedAsOf time.Time                 // as of server's Date header
 }
 
 // LastModified reports the most recent time that any known metadata was updated.
@@ -568,6 +646,69 @@ type GitHubComment struct {
 	Body    string
 }
 
+// GitHubPullRequestFile is a file changed by a pull request.
+type GitHubPullRequestFile struct {
+	Filename         string
+	Status           string // added, removed, modified, renamed, copied, changed, unchanged
+	Additions        int64
+	Deletions        int64
+	PreviousFilename string // only for renamed files
+}
+
+// GitHubCheckRun is a check run, such as a CI job, on a pull
+// request's head commit. For more details, see
+// https://docs.github.com/en/rest/checks/runs.
+type GitHubCheckRun struct {
+	ID         int64
+	HeadSHA    string
+	Name       string
+	App        string // slug of the GitHub App that created the run
+	Status     string // queued, in_progress, completed
+	Conclusion string // success, failure, neutral, cancelled, skipped, timed_out, action_required; empty until completed
+	Started    time.Time
+	Completed  time.Time
+	DetailsURL string
+}
+
+// Proto converts GitHubCheckRun to a protobuf.
+func (cr *GitHubCheckRun) Proto() *maintpb.GithubCheckRun {
+	p := &maintpb.GithubCheckRun{
+		Id:         cr.ID,
+		HeadSha:    cr.HeadSHA,
+		Name:       cr.Name,
+		App:        cr.App,
+		Status:     cr.Status,
+		Conclusion: cr.Conclusion,
+		DetailsUrl: cr.DetailsURL,
+	}
+	if !cr.Started.IsZero() {
+		p.Started = timestamppb.New(cr.Started)
+	}
+	if !cr.Completed.IsZero() {
+		p.Completed = timestamppb.New(cr.Completed)
+	}
+	return p
+}
+
+func newGitHubCheckRun(p *maintpb.GithubCheckRun) *GitHubCheckRun {
+	cr := &GitHubCheckRun{
+		ID:         p.Id,
+		HeadSHA:    p.HeadSha,
+		Name:       p.Name,
+		App:        p.App,
+		Status:     p.Status,
+		Conclusion: p.Conclusion,
+		DetailsURL: p.DetailsUrl,
+	}
+	if p.Started != nil {
+		cr.Started = p.Started.AsTime().UTC()
+	}
+	if p.Completed != nil {
+		cr.Completed = p.Completed.AsTime().UTC()
+	}
+	return cr
+}
+
 // GitHubDismissedReviewEvent is the contents of a dismissed review event. For more
 // details, see https://developer.github.com/v3/issues/events/.
 type GitHubDismissedReviewEvent struct {
@@ -761,6 +902,33 @@ func (gi *GitHubIssue) reviewsSynced() bool {
 	return gi.reviewsSyncedAsOf.After(gi.Updated)
 }
 
+// (requires corpus be locked for reads)
+func (gi *GitHubIssue) filesSynced() bool {
+	if gi.NotExist || !gi.PullRequest {
+		return true
+	}
+	return gi.filesSyncedAsOf.After(gi.Updated)
+}
+
+// checkRunsSynced reports whether the check runs on an open pull
+// request's head are known and finished.
+//
+// (requires corpus be locked for reads)
+func (gi *GitHubIssue) checkRunsSynced() bool {
+	if gi.NotExist || !gi.PullRequest || gi.Closed || gi.headSHA == "" {
+		return true
+	}
+	if gi.checkRunsSyncedAsOf.Before(gi.filesSyncedAsOf) {
+		return false
+	}
+	for _, cr := range gi.checkRuns {
+		if cr.Status != "completed" {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *Corpus) initGithub() {
 	if c.github != nil {
 		return
@@ -1273,6 +1441,7 @@ func (c *Corpus) processGithubIssueMutation(m *maintpb.GithubIssueMutation) {
 
 		gi.Created = m.Created.AsTime()
 	}
+	c.noteIssueChanged(gr, gi)
 	if m.NotExist != gi.NotExist {
 		gi.NotExist = m.NotExist
 	}
@@ -1406,6 +1575,50 @@ func (c *Corpus) processGithubIssueMutation(m *maintpb.GithubIssueMutation) {
 	if m.ReviewStatus != nil && m.ReviewStatus.ServerDate != nil {
 		gi.reviewsSyncedAsOf = m.ReviewStatus.ServerDate.AsTime().UTC()
 	}
+
+	if m.HeadSha != "" && m.HeadSha != gi.headSHA {
+		gi.headSHA = m.HeadSha
+		// Only the head's check runs are kept.
+		for id, cr := range gi.checkRuns {
+			if cr.HeadSHA != gi.headSHA {
+				delete(gi.checkRuns, id)
+			}
+		}
+	}
+	if m.Files != nil {
+		gi.files = make([]*GitHubPullRequestFile, 0, len(m.Files.File))
+		for _, f := range m.Files.File {
+			gi.files = append(gi.files, &GitHubPullRequestFile{
+				Filename:         f.Filename,
+				Status:           f.Status,
+				Additions:        f.Additions,
+				Deletions:        f.Deletions,
+				PreviousFilename: f.PreviousFilename,
+			})
+		}
+		sort.Slice(gi.files, func(i, j int) bool { return gi.files[i].Filename < gi.files[j].Filename })
+		gi.filesTruncated = m.Files.Truncated
+	}
+	if m.FileStatus != nil && m.FileStatus.ServerDate != nil {
+		gi.filesSyncedAsOf = m.FileStatus.ServerDate.AsTime().UTC()
+	}
+
+	for _, crmut := range m.CheckRun {
+		if crmut.Id == 0 {
+			log.Printf("Ignoring bogus check run mutation lacking Id: %v", crmut)
+			continue
+		}
+		if crmut.HeadSha != gi.headSHA {
+			continue
+		}
+		if gi.checkRuns == nil {
+			gi.checkRuns = make(map[int64]*GitHubCheckRun)
+		}
+		gi.checkRuns[crmut.Id] = newGitHubCheckRun(crmut)
+	}
+	if m.CheckRunStatus != nil && m.CheckRunStatus.ServerDate != nil {
+		gi.checkRunsSyncedAsOf = m.CheckRunStatus.ServerDate.AsTime().UTC()
+	}
 }
 
 // githubCache is an httpcache.Cache wrapper that only
@@ -1472,6 +1685,7 @@ func (gr *GitHubRepo) sync(ctx context.Context, token string, loop bool) error {
 		gr:            gr,
 		githubDirect:  github.NewClient(&http.Client{Transport: directTransport}),
 		githubCaching: github.NewClient(&http.Client{Transport: cachingTransport}),
+		githubV4:      githubv4.NewClient(&http.Client{Transport: directTransport}),
 		client:        http.DefaultClient,
 	}
 	activityCh := gr.github.c.activityChan("github:" + gr.id.String())
@@ -1532,8 +1746,39 @@ type githubRepoPoller struct {
 	token         string
 	lastUpdate    time.Time // modified by sync
 	githubCaching *github.Client
-	githubDirect  *github.Client // not caching
-	client        httpClient     // the client used to poll github
+	githubDirect  *github.Client   // not caching
+	githubV4      *githubv4.Client // for discussions; nil to not sync them
+	client        httpClient       // the client used to poll github
+
+	checkRunPolls map[string]*checkRunPoll // by head SHA; modified by syncCheckRuns
+	now           func() time.Time         // if nil, time.Now
+}
+
+// checkRunPoll tracks the polling of a pull request head's check runs
+// while they're unfinished.
+type checkRunPoll struct {
+	first time.Time     // when the runs were first seen unfinished
+	next  time.Time     // when to poll them again
+	delay time.Duration // between the last poll and next
+}
+
+const (
+	// checkRunPollMinDelay and checkRunPollMaxDelay bound how long to
+	// wait before polling a head's unfinished check runs again. The
+	// delay doubles with each poll.
+	checkRunPollMinDelay = time.Minute
+	checkRunPollMaxDelay = 30 * time.Minute
+
+	// checkRunPollDeadline is how long to keep polling a head whose
+	// check runs don't finish, such as ones stuck queued forever.
+	checkRunPollDeadline = 24 * time.Hour
+)
+
+func (p *githubRepoPoller) timeNow() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
 }
 
 func (p *githubRepoPoller) Owner() string { return p.gr.id.Owner }
@@ -1557,6 +1802,15 @@ func (p *githubRepoPoller) sync(ctx context.Context, expectChanges bool) error {
 	if err := p.syncReviews(ctx); err != nil {
 		return err
 	}
+	if err := p.syncPullRequestFiles(ctx); err != nil {
+		return err
+	}
+	if err := p.syncCheckRuns(ctx); err != nil {
+		return err
+	}
+	if err := p.syncDiscussions(ctx); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -2349,6 +2603,289 @@ func (p *githubRepoPoller) syncReviewsOnPullRequest(ctx context.Context, issueNu
 	return nil
 }
 
+func (p *githubRepoPoller) issueNumbersWithStaleFileSync() (issueNums []int32) {
+	p.c.mu.RLock()
+	defer p.c.mu.RUnlock()
+
+	for n, gi := range p.gr.issues {
+		if !gi.filesSynced() {
+			issueNums = append(issueNums, n)
+		}
+	}
+	slices.Sort(issueNums)
+	return issueNums
+}
+
+func (p *githubRepoPoller) syncPullRequestFiles(ctx context.Context) error {
+	for {
+		nums := p.issueNumbersWithStaleFileSync()
+		if len(nums) == 0 {
+			return nil
+		}
+		remain := len(nums)
+		for _, num := range nums {
+			p.logf("files sync: %d issues remaining; syncing issue %v", remain, num)
+			if err := p.syncFilesOnPullRequest(ctx, num); err != nil {
+				p.logf("files sync on issue %d: %v", num, err)
+				return err
+			}
+			remain--
+		}
+	}
+}
+
+// syncFilesOnPullRequest records the pull request's head commit and,
+// if it's changed, the files the pull request changes.
+func (p *githubRepoPoller) syncFilesOnPullRequest(ctx context.Context, issueNum int32) error {
+	p.c.mu.RLock()
+	gi := p.gr.issues[issueNum]
+	if gi == nil {
+		p.c.mu.RUnlock()
+		return fmt.Errorf("unknown issue number %v", issueNum)
+	}
+	oldHead := gi.headSHA
+	haveFiles := !gi.filesSyncedAsOf.IsZero()
+	p.c.mu.RUnlock()
+
+	owner, repo := p.Owner(), p.Repo()
+	notExist := func() error {
+		p.logf("pull request %d is gone, marking as NotExist", issueNum)
+		p.c.addMutation(&maintpb.Mutation{
+			GithubIssue: &maintpb.GithubIssueMutation{
+				Owner:    owner,
+				Repo:     repo,
+				Number:   issueNum,
+				NotExist: true,
+			},
+		})
+		return nil
+	}
+
+	var pr *github.PullRequest
+	var res *github.Response
+	var err error
+	for {
+		pr, res, err = p.githubDirect.PullRequests.Get(ctx, owner, repo, int(issueNum))
+		if !canRetry(ctx, err) {
+			break
+		}
+	}
+	if isGitHubNotFound(err) {
+		return notExist()
+	} else if err != nil {
+		return err
+	}
+	head := pr.GetHead().GetSHA()
+	if head == "" {
+		return fmt.Errorf("pull request %d has no head commit", issueNum)
+	}
+	mut := &maintpb.Mutation{
+		GithubIssue: &maintpb.GithubIssueMutation{
+			Owner:   owner,
+			Repo:    repo,
+			Number:  issueNum,
+			HeadSha: head,
+		},
+	}
+
+	if head != oldHead || !haveFiles {
+		files := &maintpb.GithubPullRequestFiles{}
+		opt := &github.ListOptions{PerPage: 100}
+		for {
+			cfs, pageRes, err := p.githubDirect.PullRequests.ListFiles(ctx, owner, repo, int(issueNum), opt)
+			if canRetry(ctx, err) {
+				continue
+			} else if isGitHubNotFound(err) {
+				return notExist()
+			} else if err != nil {
+				return err
+			}
+			res = pageRes
+			for _, cf := range cfs {
+				files.File = append(files.File, &maintpb.GithubPullRequestFile{
+					Filename:         cf.GetFilename(),
+					Status:           cf.GetStatus(),
+					Additions:        int64(cf.GetAdditions()),
+					Deletions:        int64(cf.GetDeletions()),
+					PreviousFilename: cf.GetPreviousFilename(),
+				})
+			}
+			if res.NextPage == 0 {
+				break
+			}
+			opt.Page = res.NextPage
+		}
+		files.Truncated = len(files.File) < pr.GetChangedFiles()
+		p.logf("pull request %d at %.7s changes %d files", issueNum, head, len(files.File))
+		mut.GithubIssue.Files = files
+	}
+
+	serverDate, err := http.ParseTime(res.Header.Get("Date"))
+	if err != nil {
+		return fmt.Errorf("invalid server Date response: %v", err)
+	}
+	mut.GithubIssue.FileStatus = &maintpb.GithubIssueSyncStatus{
+		ServerDate: timestamppb.New(serverDate.UTC()),
+	}
+	p.c.addMutation(mut)
+	return nil
+}
+
+// issueNumbersWithStaleCheckRunSync returns the pull requests whose
+// check runs are due to be synced. Heads with unfinished check runs
+// are backed off as recorded in p.checkRunPolls, and given up on after
+// checkRunPollDeadline.
+func (p *githubRepoPoller) issueNumbersWithStaleCheckRunSync() (issueNums []int32) {
+	p.c.mu.RLock()
+	defer p.c.mu.RUnlock()
+
+	now := p.timeNow()
+	stale := make(map[string]bool)
+	for n, gi := range p.gr.issues {
+		if gi.checkRunsSynced() {
+			continue
+		}
+		stale[gi.headSHA] = true
+		if poll := p.checkRunPolls[gi.headSHA]; poll != nil {
+			if now.Before(poll.next) || now.Sub(poll.first) > checkRunPollDeadline {
+				continue
+			}
+		}
+		issueNums = append(issueNums, n)
+	}
+	// Forget heads that were pushed over, closed, or finished.
+	for head := range p.checkRunPolls {
+		if !stale[head] {
+			delete(p.checkRunPolls, head)
+		}
+	}
+	slices.Sort(issueNums)
+	return issueNums
+}
+
+// syncCheckRuns syncs the check runs on open pull requests.
+//
+// Unlike the other stages, it makes a single pass: a pull request
+// whose runs haven't finished stays stale until they do, and is
+// synced again by a later poll once its backoff expires.
+func (p *githubRepoPoller) syncCheckRuns(ctx context.Context) error {
+	nums := p.issueNumbersWithStaleCheckRunSync()
+	remain := len(nums)
+	for _, num := range nums {
+		p.logf("check run sync: %d issues remaining; syncing issue %v", remain, num)
+		if err := p.syncCheckRunsOnPullRequest(ctx, num); err != nil {
+			p.logf("check run sync on issue %d: %v", num, err)
+			return err
+		}
+		p.backOffCheckRuns(num)
+		remain--
+	}
+	return nil
+}
+
+// backOffCheckRuns schedules the next poll of a pull request whose
+// check runs were just synced, if they haven't finished.
+func (p *githubRepoPoller) backOffCheckRuns(issueNum int32) {
+	p.c.mu.RLock()
+	gi := p.gr.issues[issueNum]
+	synced, head := gi.checkRunsSynced(), gi.headSHA
+	p.c.mu.RUnlock()
+
+	if synced {
+		delete(p.checkRunPolls, head)
+		return
+	}
+	now := p.timeNow()
+	poll := p.checkRunPolls[head]
+	if poll == nil {
+		if p.checkRunPolls == nil {
+			p.checkRunPolls = make(map[string]*checkRunPoll)
+		}
+		poll = &checkRunPoll{first: now, delay: checkRunPollMinDelay}
+		p.checkRunPolls[head] = poll
+	} else {
+		poll.delay = min(2*poll.delay, checkRunPollMaxDelay)
+	}
+	poll.next = now.Add(poll.delay)
+	if poll.next.Sub(poll.first) > checkRunPollDeadline {
+		p.logf("check runs on issue %d at %s unfinished after %v; no longer polling them", issueNum, head, checkRunPollDeadline)
+	}
+}
+
+func (p *githubRepoPoller) syncCheckRunsOnPullRequest(ctx context.Context, issueNum int32) error {
+	p.c.mu.RLock()
+	gi := p.gr.issues[issueNum]
+	if gi == nil {
+		p.c.mu.RUnlock()
+		return fmt.Errorf("unknown issue number %v", issueNum)
+	}
+	head := gi.headSHA
+	p.c.mu.RUnlock()
+
+	mut := &maintpb.Mutation{
+		GithubIssue: &maintpb.GithubIssueMutation{
+			Owner:  p.Owner(),
+			Repo:   p.Repo(),
+			Number: issueNum,
+		},
+	}
+	opt := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var res *github.Response
+	for {
+		crs, pageRes, err := p.githubDirect.Checks.ListCheckRunsForRef(ctx, p.Owner(), p.Repo(), head, opt)
+		if canRetry(ctx, err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		res = pageRes
+		p.c.mu.RLock()
+		for _, cr := range crs.CheckRuns {
+			if cr.GetID() == 0 {
+				p.logf("bogus check run: %v", cr)
+				continue
+			}
+			run := &GitHubCheckRun{
+				ID:         cr.GetID(),
+				HeadSHA:    cr.GetHeadSHA(),
+				Name:       cr.GetName(),
+				App:        cr.GetApp().GetSlug(),
+				Status:     cr.GetStatus(),
+				Conclusion: cr.GetConclusion(),
+				Started:    cr.GetStartedAt().Time.UTC(),
+				Completed:  cr.GetCompletedAt().Time.UTC(),
+				DetailsURL: cr.GetDetailsURL(),
+			}
+			if cur := gi.checkRuns[run.ID]; cur != nil && *cur == *run {
+				continue
+			}
+			mut.GithubIssue.CheckRun = append(mut.GithubIssue.CheckRun, run.Proto())
+		}
+		p.c.mu.RUnlock()
+		if res.NextPage == 0 {
+			break
+		}
+		opt.Page = res.NextPage
+	}
+
+	serverDate, err := http.ParseTime(res.Header.Get("Date"))
+	if err != nil {
+		return fmt.Errorf("invalid server Date response: %v", err)
+	}
+	mut.GithubIssue.CheckRunStatus = &maintpb.GithubIssueSyncStatus{
+		ServerDate: timestamppb.New(serverDate.UTC()),
+	}
+	p.c.addMutation(mut)
+	return nil
+}
+
+// isGitHubNotFound reports whether err is a GitHub API response saying
+// that the requested object doesn't exist or has been deleted.
+func isGitHubNotFound(err error) bool {
+	ge, ok := err.(*github.ErrorResponse)
+	return ok && (ge.Response.StatusCode == http.StatusNotFound || ge.Response.StatusCode == http.StatusGone)
+}
+
 // parseGithubReviews parses the JSON array of GitHub reviews in r.
 // It does this the very manual way (using map[string]interface{})
 // instead of using nice types because https://golang.org/issue/15314
@@ -7,10 +7,12 @@ package maintner
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -20,6 +22,7 @@ import (
 	"time"
 
 	"github.com/google/go-github/v74/github"
+	"github.com/shurcooL/githubv4"
 	"golang.org/x/build/maintner/maintpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -1089,3 +1092,260 @@ func TestParseGithubRefs(t *testing.T) {
 		}
 	}
 }
+
+func TestSyncPullRequestFilesAndCheckRuns(t *testing.T) {
+	var c Corpus
+	c.processMutationLocked(&maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{
+		Owner:       "golang",
+		Repo:        "go",
+		Number:      3,
+		Title:       "net/http: fix a bug",
+		Created:     tp1,
+		Updated:     tp1,
+		PullRequest: true,
+	}})
+	gr := c.GitHub().Repo("golang", "go")
+	requests := map[string]int{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/golang/go/pulls/3", func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		io.WriteString(w, `{"number": 3, "changed_files": 2, "head": {"sha": "abc123"}}`)
+	})
+	mux.HandleFunc("/repos/golang/go/pulls/3/files", func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		io.WriteString(w, `[
+			{"filename": "src/net/http/server.go", "status": "modified", "additions": 3, "deletions": 1},
+			{"filename": "doc/go1.26.html", "status": "added", "additions": 10}
+		]`)
+	})
+	checkStatus := "in_progress"
+	mux.HandleFunc("/repos/golang/go/commits/abc123/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		fmt.Fprintf(w, `{"total_count": 1, "check_runs": [{"id": 20, "head_sha": "abc123", "name": "linux-amd64", "status": %q, "app": {"slug": "github-actions"}}]}`, checkStatus)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ghc := github.NewClient(server.Client())
+	ghc.BaseURL, _ = url.Parse(server.URL + "/")
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	p := &githubRepoPoller{
+		c:            &c,
+		gr:           gr,
+		githubDirect: ghc,
+		now:          func() time.Time { return now },
+	}
+
+	ctx := context.Background()
+	sync := func() {
+		t.Helper()
+		if err := p.syncPullRequestFiles(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if err := p.syncCheckRuns(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sync()
+	pr := gr.Issue(3)
+	var files []string
+	pr.ForeachFile(func(f *GitHubPullRequestFile) error {
+		files = append(files, fmt.Sprintf("%s:%s:+%d-%d", f.Filename, f.Status, f.Additions, f.Deletions))
+		return nil
+	})
+	want := []string{"doc/go1.26.html:added:+10-0", "src/net/http/server.go:modified:+3-1"}
+	if pr.HeadSHA() != "abc123" || !reflect.DeepEqual(files, want) || pr.FilesTruncated() {
+		t.Errorf("after sync, head = %q, files = %v, truncated = %v; want abc123, %v, false", pr.HeadSHA(), files, pr.FilesTruncated(), want)
+	}
+	if pr.checkRunsSynced() {
+		t.Errorf("check runs synced with a run in progress")
+	}
+
+	// The unfinished check run is polled again once its backoff
+	// expires, but the files aren't.
+	sync()
+	if got := requests["/repos/golang/go/commits/abc123/check-runs"]; got != 1 {
+		t.Errorf("check runs polled %d times before the backoff expired; want 1", got)
+	}
+	now = now.Add(checkRunPollMinDelay)
+	checkStatus = "completed"
+	sync()
+	var runs []string
+	pr.ForeachCheckRun(func(cr *GitHubCheckRun) error {
+		runs = append(runs, cr.App+"/"+cr.Name+":"+cr.Status)
+		return nil
+	})
+	if want := []string{"github-actions/linux-amd64:completed"}; !reflect.DeepEqual(runs, want) {
+		t.Errorf("check runs = %v; want %v", runs, want)
+	}
+	if !pr.checkRunsSynced() {
+		t.Errorf("check runs not synced after they completed")
+	}
+	sync()
+	wantRequests := map[string]int{
+		"/repos/golang/go/pulls/3":                   1,
+		"/repos/golang/go/pulls/3/files":             1,
+		"/repos/golang/go/commits/abc123/check-runs": 2,
+	}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("requests = %v; want %v", requests, wantRequests)
+	}
+}
+
+func TestSyncCheckRunsBackoff(t *testing.T) {
+	var c Corpus
+	c.processMutationLocked(&maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{
+		Owner:       "golang",
+		Repo:        "go",
+		Number:      3,
+		Created:     tp1,
+		Updated:     tp1,
+		PullRequest: true,
+		HeadSha:     "abc123",
+		FileStatus:  &maintpb.GithubIssueSyncStatus{ServerDate: tp2},
+	}})
+	gr := c.GitHub().Repo("golang", "go")
+	var polls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		io.WriteString(w, `{"total_count": 1, "check_runs": [{"id": 20, "head_sha": "abc123", "name": "linux-amd64", "status": "queued"}]}`)
+	}))
+	defer server.Close()
+	ghc := github.NewClient(server.Client())
+	ghc.BaseURL, _ = url.Parse(server.URL + "/")
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	now := start
+	p := &githubRepoPoller{
+		c:            &c,
+		gr:           gr,
+		githubDirect: ghc,
+		now:          func() time.Time { return now },
+	}
+
+	// Sync every minute for two days. The delay between polls doubles
+	// up to checkRunPollMaxDelay, and polling stops after
+	// checkRunPollDeadline.
+	var pollTimes []time.Duration
+	for ; now.Sub(start) < 48*time.Hour; now = now.Add(time.Minute) {
+		before := polls
+		if err := p.syncCheckRuns(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if polls > before {
+			pollTimes = append(pollTimes, now.Sub(start))
+		}
+	}
+	wantPrefix := []time.Duration{0, time.Minute, 3 * time.Minute, 7 * time.Minute, 15 * time.Minute, 31 * time.Minute}
+	if len(pollTimes) < len(wantPrefix) || !reflect.DeepEqual(pollTimes[:len(wantPrefix)], wantPrefix) {
+		t.Fatalf("polled at %v; want to start with %v", pollTimes, wantPrefix)
+	}
+	for i := len(wantPrefix); i < len(pollTimes); i++ {
+		if d := pollTimes[i] - pollTimes[i-1]; d != checkRunPollMaxDelay {
+			t.Fatalf("polled at %v; want every %v after %v", pollTimes, checkRunPollMaxDelay, wantPrefix[len(wantPrefix)-1])
+		}
+	}
+	if last := pollTimes[len(pollTimes)-1]; last > checkRunPollDeadline || last+checkRunPollMaxDelay <= checkRunPollDeadline {
+		t.Errorf("last poll after %v; want the last one within %v of the %v deadline", last, checkRunPollMaxDelay, checkRunPollDeadline)
+	}
+
+	// A new head is polled right away.
+	c.mu.Lock()
+	c.processMutationLocked(&maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{
+		Owner:      "golang",
+		Repo:       "go",
+		Number:     3,
+		HeadSha:    "def456",
+		FileStatus: &maintpb.GithubIssueSyncStatus{ServerDate: timestamppb.New(time.Now().Add(time.Hour))},
+	}})
+	c.mu.Unlock()
+	before := polls
+	if err := p.syncCheckRuns(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if polls != before+1 {
+		t.Errorf("new head polled %d times; want 1", polls-before)
+	}
+	if _, ok := p.checkRunPolls["abc123"]; ok {
+		t.Errorf("backoff of the old head wasn't forgotten")
+	}
+}
+
+func TestSyncDiscussions(t *testing.T) {
+	var c Corpus
+	c.initGithub()
+	gr := c.github.getOrCreateRepo("golang", "go")
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct{ Query string }
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		switch {
+		case strings.Contains(req.Query, "discussions("):
+			queries = append(queries, "discussions")
+			io.WriteString(w, `{"data": {"repository": {"hasDiscussionsEnabled": true, "discussions": {
+				"nodes": [{
+					"databaseId": 3001, "number": 8, "title": "How do I load a snapshot?", "body": "Asking for a friend.",
+					"author": {"login": "kevinburke", "databaseId": 101},
+					"createdAt": "2016-01-02T15:04:00Z", "updatedAt": "2016-01-03T15:04:00Z",
+					"category": {"name": "Q&A"}, "closed": false, "locked": false
+				}],
+				"pageInfo": {"endCursor": "x", "hasNextPage": false}
+			}}}}`)
+		case strings.Contains(req.Query, "discussion(number"):
+			queries = append(queries, "comments")
+			io.WriteString(w, `{"data": {"repository": {"discussion": {"comments": {
+				"nodes": [{
+					"databaseId": 31, "body": "Use UseSnapshot.", "isAnswer": true, "replyTo": null,
+					"author": {"login": "gopherbot", "databaseId": 100},
+					"createdAt": "2016-01-02T16:00:00Z", "updatedAt": "2016-01-02T16:00:00Z",
+					"replies": {"nodes": [{
+						"databaseId": 32, "body": "Thanks!", "isAnswer": false, "replyTo": {"databaseId": 31},
+						"author": null,
+						"createdAt": "2016-01-03T15:04:00Z", "updatedAt": "2016-01-03T15:04:00Z"
+					}]}
+				}],
+				"pageInfo": {"endCursor": "y", "hasNextPage": false}
+			}}}}}`)
+		default:
+			t.Errorf("unexpected query %q", req.Query)
+		}
+	}))
+	defer server.Close()
+	p := &githubRepoPoller{
+		c:        &c,
+		gr:       gr,
+		githubV4: githubv4.NewEnterpriseClient(server.URL, server.Client()),
+	}
+
+	ctx := context.Background()
+	if err := p.syncDiscussions(ctx); err != nil {
+		t.Fatal(err)
+	}
+	d := gr.Discussion(8)
+	if d == nil {
+		t.Fatal("discussion 8 wasn't synced")
+	}
+	if d.ID != 3001 || d.Title != "How do I load a snapshot?" || d.Category != "Q&A" || d.User.Login != "kevinburke" {
+		t.Errorf("discussion 8 = %+v", d)
+	}
+	var comments []string
+	d.ForeachComment(func(dc *GitHubDiscussionComment) error {
+		comments = append(comments, fmt.Sprintf("%d->%d %v %q", dc.ID, dc.ReplyToID, dc.User != nil, dc.Body))
+		return nil
+	})
+	want := []string{`31->0 true "Use UseSnapshot."`, `32->31 false "Thanks!"`}
+	if !reflect.DeepEqual(comments, want) {
+		t.Errorf("comments = %q; want %q", comments, want)
+	}
+	if a := d.Answer(); a == nil || a.ID != 31 {
+		t.Errorf("answer = %+v; want comment 31", a)
+	}
+
+	// Syncing again stops at the unchanged discussion.
+	if err := p.syncDiscussions(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"discussions", "comments", "discussions"}; !reflect.DeepEqual(queries, want) {
+		t.Errorf("queries = %v; want %v", queries, want)
+	}
+}
@@ -0,0 +1,486 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"golang.org/x/build/maintner/maintpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GitHubDiscussion is a GitHub Discussion.
+//
+// Discussions share a number space with issues and pull requests,
+// but they're a separate kind of object and are kept separately.
+type GitHubDiscussion struct {
+	ID       int64
+	Number   int32
+	User     *GitHubUser
+	Created  time.Time
+	Updated  time.Time
+	Title    string
+	Body     string
+	Category string // "Q&A", "Ideas", etc
+	Closed   bool
+	Locked   bool
+
+	commentsSyncedAsOf time.Time                          // the Updated time as of which comments were synced
+	comments           map[int64]*GitHubDiscussionComment // by comment.ID
+}
+
+// GitHubDiscussionComment is a comment on a GitHub Discussion, or a
+// reply to one.
+type GitHubDiscussionComment struct {
+	ID        int64
+	ReplyToID int64 // ID of the comment replied to; zero for top-level comments
+	User      *GitHubUser
+	Created   time.Time
+	Updated   time.Time
+	Body      string
+	IsAnswer  bool // marked as the answer to a question
+}
+
+// Discussion returns the provided discussion number, or nil if it's not known.
+func (gr *GitHubRepo) Discussion(n int32) *GitHubDiscussion { return gr.discussions[n] }
+
+// ForeachDiscussion calls fn for each discussion in the repo.
+//
+// If fn returns an error, iteration ends and ForeachDiscussion returns
+// with that error.
+//
+// The fn function is called serially, with increasingly numbered
+// discussions.
+func (gr *GitHubRepo) ForeachDiscussion(fn func(*GitHubDiscussion) error) error {
+	s := make([]*GitHubDiscussion, 0, len(gr.discussions))
+	for _, d := range gr.discussions {
+		s = append(s, d)
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i].Number < s[j].Number })
+	for _, d := range s {
+		if err := fn(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForeachComment calls fn for each comment and reply on the
+// discussion.
+//
+// If fn returns an error, iteration ends and ForeachComment returns
+// with that error.
+//
+// The fn function is called serially, in order of creation time.
+func (d *GitHubDiscussion) ForeachComment(fn func(*GitHubDiscussionComment) error) error {
+	s := make([]*GitHubDiscussionComment, 0, len(d.comments))
+	for _, dc := range d.comments {
+		s = append(s, dc)
+	}
+	sort.Slice(s, func(i, j int) bool {
+		if !s[i].Created.Equal(s[j].Created) {
+			return s[i].Created.Before(s[j].Created)
+		}
+		return s[i].ID < s[j].ID
+	})
+	for _, dc := range s {
+		if err := fn(dc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Answer returns the comment marked as the answer to the discussion,
+// or nil if there isn't one.
+func (d *GitHubDiscussion) Answer() *GitHubDiscussionComment {
+	for _, dc := range d.comments {
+		if dc.IsAnswer {
+			return dc
+		}
+	}
+	return nil
+}
+
+// (requires corpus be locked for reads)
+func (d *GitHubDiscussion) commentsSynced() bool {
+	return !d.commentsSyncedAsOf.Before(d.Updated)
+}
+
+// processGithubDiscussionMutation updates the corpus with the information in m.
+func (c *Corpus) processGithubDiscussionMutation(m *maintpb.GithubDiscussionMutation) {
+	c.initGithub()
+	gr := c.github.getOrCreateRepo(m.Owner, m.Repo)
+	if gr == nil {
+		log.Printf("bogus Owner/Repo %q/%q in mutation: %v", m.Owner, m.Repo, m)
+		return
+	}
+	if m.Number == 0 {
+		log.Printf("bogus zero Number in mutation: %v", m)
+		return
+	}
+	d, ok := gr.discussions[m.Number]
+	if !ok {
+		d = &GitHubDiscussion{Number: m.Number}
+		if gr.discussions == nil {
+			gr.discussions = make(map[int32]*GitHubDiscussion)
+		}
+		gr.discussions[m.Number] = d
+	}
+	if m.Id != 0 {
+		d.ID = m.Id
+	}
+	if m.User != nil {
+		d.User = c.github.getUser(m.User)
+	}
+	if m.Created != nil {
+		d.Created = m.Created.AsTime().UTC()
+	}
+	if m.Updated != nil {
+		d.Updated = m.Updated.AsTime().UTC()
+	}
+	if m.Title != "" {
+		d.Title = m.Title
+	}
+	if m.Body != nil {
+		d.Body = m.Body.Val
+	}
+	if m.Category != "" {
+		d.Category = c.str(m.Category)
+	}
+	if b := m.Closed; b != nil {
+		d.Closed = b.Val
+	}
+	if b := m.Locked; b != nil {
+		d.Locked = b.Val
+	}
+
+	for _, cmut := range m.Comment {
+		if cmut.Id == 0 {
+			log.Printf("Ignoring bogus discussion comment mutation lacking Id: %v", cmut)
+			continue
+		}
+		dc, ok := d.comments[cmut.Id]
+		if !ok {
+			if d.comments == nil {
+				d.comments = make(map[int64]*GitHubDiscussionComment)
+			}
+			dc = &GitHubDiscussionComment{ID: cmut.Id, ReplyToID: cmut.ReplyToId}
+			d.comments[dc.ID] = dc
+		}
+		if cmut.User != nil {
+			dc.User = c.github.getUser(cmut.User)
+		}
+		if cmut.Created != nil {
+			dc.Created = cmut.Created.AsTime().UTC()
+		}
+		if cmut.Updated != nil {
+			dc.Updated = cmut.Updated.AsTime().UTC()
+		}
+		if cmut.Body != nil {
+			dc.Body = cmut.Body.Val
+		}
+		if b := cmut.IsAnswer; b != nil {
+			dc.IsAnswer = b.Val
+		}
+	}
+	if m.CommentStatus != nil && m.CommentStatus.ServerDate != nil {
+		d.commentsSyncedAsOf = m.CommentStatus.ServerDate.AsTime().UTC()
+	}
+}
+
+// githubV4Actor is the author of a discussion or comment.
+// It's empty for deleted accounts.
+type githubV4Actor struct {
+	Login string
+	User  struct{ DatabaseID int64 } `graphql:"... on User"`
+	Bot   struct{ DatabaseID int64 } `graphql:"... on Bot"`
+}
+
+func (a githubV4Actor) proto() *maintpb.GithubUser {
+	id := a.User.DatabaseID
+	if id == 0 {
+		id = a.Bot.DatabaseID
+	}
+	if id == 0 {
+		return nil
+	}
+	return &maintpb.GithubUser{Id: id, Login: a.Login}
+}
+
+type githubV4Discussion struct {
+	DatabaseID int64
+	Number     int32
+	Author     githubV4Actor
+	CreatedAt  githubv4.DateTime
+	UpdatedAt  githubv4.DateTime
+	Title      string
+	Body       string
+	Category   struct{ Name string }
+	Closed     bool
+	Locked     bool
+}
+
+type githubV4DiscussionComment struct {
+	DatabaseID int64
+	Author     githubV4Actor
+	CreatedAt  githubv4.DateTime
+	UpdatedAt  githubv4.DateTime
+	Body       string
+	IsAnswer   bool
+	ReplyTo    *struct{ DatabaseID int64 }
+}
+
+// newMutationFromDiscussion generates a GithubDiscussionMutation
+// using the discussion d as it's known to the corpus, which may be
+// nil, and its current state nd from GitHub.
+//
+// If newMutationFromDiscussion returns nil, the provided discussion is
+// up-to-date.
+//
+// (requires corpus be locked for reads)
+func (gr *GitHubRepo) newMutationFromDiscussion(d *GitHubDiscussion, nd *githubV4Discussion) *maintpb.Mutation {
+	m := &maintpb.GithubDiscussionMutation{
+		Owner:  gr.id.Owner,
+		Repo:   gr.id.Repo,
+		Number: nd.Number,
+	}
+	if d == nil {
+		d = new(GitHubDiscussion)
+		m.Id = nd.DatabaseID
+		m.User = nd.Author.proto()
+		m.Created = timestamppb.New(nd.CreatedAt.Time)
+	}
+	changed := d.ID == 0
+	if !nd.UpdatedAt.Time.Equal(d.Updated) {
+		m.Updated = timestamppb.New(nd.UpdatedAt.Time)
+		changed = true
+	}
+	if nd.Title != d.Title {
+		m.Title = nd.Title
+		changed = true
+	}
+	if nd.Body != d.Body {
+		m.Body = &maintpb.StringChange{Val: nd.Body}
+		changed = true
+	}
+	if nd.Category.Name != d.Category {
+		m.Category = nd.Category.Name
+		changed = true
+	}
+	if nd.Closed != d.Closed {
+		m.Closed = &maintpb.BoolChange{Val: nd.Closed}
+		changed = true
+	}
+	if nd.Locked != d.Locked {
+		m.Locked = &maintpb.BoolChange{Val: nd.Locked}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return &maintpb.Mutation{GithubDiscussion: m}
+}
+
+// syncDiscussions syncs the repo's discussions, most recently updated
+// first, stopping at the first one that's unchanged. Then it syncs
+// the comments on any discussions whose comments are stale.
+//
+// It does nothing if the poller has no GraphQL client or the repo
+// doesn't have discussions enabled.
+func (p *githubRepoPoller) syncDiscussions(ctx context.Context) error {
+	if p.githubV4 == nil {
+		return nil
+	}
+	variables := map[string]any{
+		"owner":  githubv4.String(p.Owner()),
+		"repo":   githubv4.String(p.Repo()),
+		"cursor": (*githubv4.String)(nil),
+	}
+PageLoop:
+	for {
+		var q struct {
+			Repository struct {
+				HasDiscussionsEnabled bool
+				Discussions           struct {
+					Nodes    []githubV4Discussion
+					PageInfo struct {
+						EndCursor   githubv4.String
+						HasNextPage bool
+					}
+				} `graphql:"discussions(first: 50, after: $cursor, orderBy: {field: UPDATED_AT, direction: DESC})"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}
+		if err := p.githubV4.Query(ctx, &q, variables); err != nil {
+			return err
+		}
+		if !q.Repository.HasDiscussionsEnabled {
+			return nil
+		}
+		for i := range q.Repository.Discussions.Nodes {
+			nd := &q.Repository.Discussions.Nodes[i]
+			p.c.mu.RLock()
+			mut := p.gr.newMutationFromDiscussion(p.gr.discussions[nd.Number], nd)
+			p.c.mu.RUnlock()
+			if mut == nil {
+				break PageLoop
+			}
+			p.logf("modified discussion %d: %s", nd.Number, nd.Title)
+			p.c.addMutation(mut)
+			p.lastUpdate = time.Now()
+		}
+		if !q.Repository.Discussions.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = githubv4.NewString(q.Repository.Discussions.PageInfo.EndCursor)
+	}
+
+	nums := p.discussionNumbersWithStaleCommentSync()
+	remain := len(nums)
+	for _, num := range nums {
+		p.logf("discussion comment sync: %d discussions remaining; syncing discussion %v", remain, num)
+		if err := p.syncCommentsOnDiscussion(ctx, num); err != nil {
+			p.logf("comment sync on discussion %d: %v", num, err)
+			return err
+		}
+		remain--
+	}
+	return nil
+}
+
+func (p *githubRepoPoller) discussionNumbersWithStaleCommentSync() (nums []int32) {
+	p.c.mu.RLock()
+	defer p.c.mu.RUnlock()
+
+	for n, d := range p.gr.discussions {
+		if !d.commentsSynced() {
+			nums = append(nums, n)
+		}
+	}
+	slices.Sort(nums)
+	return nums
+}
+
+// syncCommentsOnDiscussion syncs all the comments and replies on a
+// discussion. Only the first 100 replies to each comment are synced.
+func (p *githubRepoPoller) syncCommentsOnDiscussion(ctx context.Context, num int32) error {
+	p.c.mu.RLock()
+	d := p.gr.discussions[num]
+	if d == nil {
+		p.c.mu.RUnlock()
+		return fmt.Errorf("unknown discussion number %v", num)
+	}
+	syncedAsOf := d.Updated
+	p.c.mu.RUnlock()
+
+	mut := &maintpb.GithubDiscussionMutation{
+		Owner:  p.Owner(),
+		Repo:   p.Repo(),
+		Number: num,
+	}
+	variables := map[string]any{
+		"owner":  githubv4.String(p.Owner()),
+		"repo":   githubv4.String(p.Repo()),
+		"number": githubv4.Int(num),
+		"cursor": (*githubv4.String)(nil),
+	}
+	for {
+		var q struct {
+			Repository struct {
+				Discussion struct {
+					Comments struct {
+						Nodes []struct {
+							githubV4DiscussionComment
+							Replies struct {
+								Nodes []githubV4DiscussionComment
+							} `graphql:"replies(first: 100)"`
+						}
+						PageInfo struct {
+							EndCursor   githubv4.String
+							HasNextPage bool
+						}
+					} `graphql:"comments(first: 50, after: $cursor)"`
+				} `graphql:"discussion(number: $number)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}
+		if err := p.githubV4.Query(ctx, &q, variables); err != nil {
+			return err
+		}
+		p.c.mu.RLock()
+		for _, nc := range q.Repository.Discussion.Comments.Nodes {
+			if cmut := discussionCommentMutation(d, &nc.githubV4DiscussionComment); cmut != nil {
+				mut.Comment = append(mut.Comment, cmut)
+			}
+			for i := range nc.Replies.Nodes {
+				if cmut := discussionCommentMutation(d, &nc.Replies.Nodes[i]); cmut != nil {
+					mut.Comment = append(mut.Comment, cmut)
+				}
+			}
+		}
+		p.c.mu.RUnlock()
+		if !q.Repository.Discussion.Comments.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = githubv4.NewString(q.Repository.Discussion.Comments.PageInfo.EndCursor)
+	}
+	p.logf("discussion %d: %d new or updated comments", num, len(mut.Comment))
+	mut.CommentStatus = &maintpb.GithubIssueSyncStatus{
+		ServerDate: timestamppb.New(syncedAsOf),
+	}
+	p.c.addMutation(&maintpb.Mutation{GithubDiscussion: mut})
+	return nil
+}
+
+// discussionCommentMutation returns a mutation that brings the
+// comment nc on d up to date, or nil if it already is.
+//
+// (requires corpus be locked for reads)
+func discussionCommentMutation(d *GitHubDiscussion, nc *githubV4DiscussionComment) *maintpb.GithubDiscussionComment {
+	if nc.DatabaseID == 0 {
+		log.Printf("bogus discussion comment: %+v", nc)
+		return nil
+	}
+	cur := d.comments[nc.DatabaseID]
+	if cur == nil {
+		cmut := &maintpb.GithubDiscussionComment{
+			Id:      nc.DatabaseID,
+			User:    nc.Author.proto(),
+			Created: timestamppb.New(nc.CreatedAt.Time),
+			Updated: timestamppb.New(nc.UpdatedAt.Time),
+			Body:    &maintpb.StringChange{Val: nc.Body},
+		}
+		if nc.ReplyTo != nil {
+			cmut.ReplyToId = nc.ReplyTo.DatabaseID
+		}
+		if nc.IsAnswer {
+			cmut.IsAnswer = &maintpb.BoolChange{Val: true}
+		}
+		return cmut
+	}
+	cmut := &maintpb.GithubDiscussionComment{Id: nc.DatabaseID}
+	changed := false
+	if !cur.Updated.Equal(nc.UpdatedAt.Time) {
+		cmut.Updated = timestamppb.New(nc.UpdatedAt.Time)
+		changed = true
+	}
+	if cur.Body != nc.Body {
+		cmut.Body = &maintpb.StringChange{Val: nc.Body}
+		changed = true
+	}
+	if cur.IsAnswer != nc.IsAnswer {
+		cmut.IsAnswer = &maintpb.BoolChange{Val: nc.IsAnswer}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return cmut
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import "strings"
+
+// corpusIndex holds secondary indexes over a corpus's GitHub issues
+// and Gerrit CLs, so queries don't need to visit all of them.
+//
+// It's built in full by the first query. After that, processing a
+// mutation notes which issues and CLs it touched, and only those are
+// reindexed before the next query.
+//
+// Issues are indexed by label, milestone and assignee object rather
+// than by name, so renaming a label or milestone doesn't invalidate
+// the index.
+type corpusIndex struct {
+	dirtyIssues map[*GitHubIssue]*GitHubRepo
+	dirtyCLs    map[*GerritCL]bool
+
+	issueKeys map[*GitHubIssue]*issueIndexKeys
+	clKeys    map[*GerritCL]clIndexKeys
+
+	byLabel     map[*GitHubLabel]map[*GitHubIssue]*GitHubRepo
+	byMilestone map[*GitHubMilestone]map[*GitHubIssue]*GitHubRepo
+	byAssignee  map[*GitHubUser]map[*GitHubIssue]*GitHubRepo
+	byOwner     map[string]map[*GerritCL]bool // lowercase owner email => CLs
+	byStatus    map[string]map[*GerritCL]bool
+}
+
+// issueIndexKeys records what an issue is currently indexed under,
+// so it can be removed when it changes.
+type issueIndexKeys struct {
+	labels    []*GitHubLabel
+	milestone *GitHubMilestone
+	assignees []*GitHubUser
+}
+
+// clIndexKeys records what a CL is currently indexed under.
+type clIndexKeys struct {
+	owner  string
+	status string
+}
+
+// addIndex adds v to the set for key k in m, with value x.
+func addIndex[K, V comparable, X any](m map[K]map[V]X, k K, v V, x X) {
+	set, ok := m[k]
+	if !ok {
+		set = make(map[V]X)
+		m[k] = set
+	}
+	set[v] = x
+}
+
+// removeIndex removes v from the set for key k in m.
+func removeIndex[K, V comparable, X any](m map[K]map[V]X, k K, v V) {
+	set := m[k]
+	delete(set, v)
+	if len(set) == 0 {
+		delete(m, k)
+	}
+}
+
+// noteIssueChanged records that gi may need reindexing.
+//
+// c.mu must be held for writing.
+func (c *Corpus) noteIssueChanged(gr *GitHubRepo, gi *GitHubIssue) {
+	c.indexMu.Lock()
+	defer c.indexMu.Unlock()
+	if c.index == nil {
+		return
+	}
+	c.index.dirtyIssues[gi] = gr
+}
+
+// noteCLChanged records that cl may need reindexing.
+//
+// c.mu must be held for writing.
+func (c *Corpus) noteCLChanged(cl *GerritCL) {
+	c.indexMu.Lock()
+	defer c.indexMu.Unlock()
+	if c.index == nil {
+		return
+	}
+	c.index.dirtyCLs[cl] = true
+}
+
+// resetIndex discards the index, for when the corpus is reloaded.
+func (c *Corpus) resetIndex() {
+	c.indexMu.Lock()
+	defer c.indexMu.Unlock()
+	c.index = nil
+}
+
+// updateIndex returns c's index, building it or bringing it up to
+// date as needed.
+//
+// c.mu must be held for reading and c.indexMu must be held.
+func (c *Corpus) updateIndex() *corpusIndex {
+	idx := c.index
+	if idx == nil {
+		idx = &corpusIndex{
+			dirtyIssues: make(map[*GitHubIssue]*GitHubRepo),
+			dirtyCLs:    make(map[*GerritCL]bool),
+			issueKeys:   make(map[*GitHubIssue]*issueIndexKeys),
+			clKeys:      make(map[*GerritCL]clIndexKeys),
+			byLabel:     make(map[*GitHubLabel]map[*GitHubIssue]*GitHubRepo),
+			byMilestone: make(map[*GitHubMilestone]map[*GitHubIssue]*GitHubRepo),
+			byAssignee:  make(map[*GitHubUser]map[*GitHubIssue]*GitHubRepo),
+			byOwner:     make(map[string]map[*GerritCL]bool),
+			byStatus:    make(map[string]map[*GerritCL]bool),
+		}
+		if c.github != nil {
+			for _, gr := range c.github.repos {
+				for _, gi := range gr.issues {
+					idx.dirtyIssues[gi] = gr
+				}
+			}
+		}
+		if c.gerrit != nil {
+			for _, gp := range c.gerrit.projects {
+				for _, cl := range gp.cls {
+					idx.dirtyCLs[cl] = true
+				}
+			}
+		}
+		c.index = idx
+	}
+	for gi, gr := range idx.dirtyIssues {
+		idx.reindexIssue(gr, gi)
+	}
+	clear(idx.dirtyIssues)
+	for cl := range idx.dirtyCLs {
+		idx.reindexCL(cl)
+	}
+	clear(idx.dirtyCLs)
+	return idx
+}
+
+func (idx *corpusIndex) reindexIssue(gr *GitHubRepo, gi *GitHubIssue) {
+	if old := idx.issueKeys[gi]; old != nil {
+		for _, lb := range old.labels {
+			removeIndex(idx.byLabel, lb, gi)
+		}
+		if old.milestone != nil {
+			removeIndex(idx.byMilestone, old.milestone, gi)
+		}
+		for _, u := range old.assignees {
+			removeIndex(idx.byAssignee, u, gi)
+		}
+		delete(idx.issueKeys, gi)
+	}
+	if gi.NotExist {
+		return
+	}
+	keys := &issueIndexKeys{milestone: gi.Milestone}
+	for _, lb := range gi.Labels {
+		keys.labels = append(keys.labels, lb)
+		addIndex(idx.byLabel, lb, gi, gr)
+	}
+	if gi.Milestone != nil {
+		addIndex(idx.byMilestone, gi.Milestone, gi, gr)
+	}
+	for _, u := range gi.Assignees {
+		keys.assignees = append(keys.assignees, u)
+		addIndex(idx.byAssignee, u, gi, gr)
+	}
+	idx.issueKeys[gi] = keys
+}
+
+func (idx *corpusIndex) reindexCL(cl *GerritCL) {
+	if old, ok := idx.clKeys[cl]; ok {
+		removeIndex(idx.byOwner, old.owner, cl)
+		removeIndex(idx.byStatus, old.status, cl)
+		delete(idx.clKeys, cl)
+	}
+	if !cl.complete() {
+		return
+	}
+	var keys clIndexKeys
+	if owner := cl.Owner(); owner != nil {
+		keys.owner = strings.ToLower(owner.Email())
+	}
+	keys.status = cl.Status
+	addIndex(idx.byOwner, keys.owner, cl, true)
+	addIndex(idx.byStatus, keys.status, cl, true)
+	idx.clKeys[cl] = keys
+}
@@ -6,7 +6,9 @@ package maintner
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -28,7 +30,9 @@ type DiskMutationLogger struct {
 	directory string
 
 	mu   sync.Mutex
-	done bool // true after first GetMutations
+	done bool             // true after first GetMutations
+	skip map[string]int64 // file name => offset to start reading at, from resumeFrom
+	seen []logSegment     // files read or written so far, and how much of each
 }
 
 // NewDiskMutationLogger creates a new DiskMutationLogger, which will create
@@ -56,7 +60,70 @@ func (d *DiskMutationLogger) Log(m *maintpb.Mutation) error {
 	}
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	return reclog.AppendRecordToFile(d.filename(), data)
+	name := d.filename()
+	if err := reclog.AppendRecordToFile(name, data); err != nil {
+		return err
+	}
+	fi, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+	d.noteSeenLocked(fi.Name(), fi.Size())
+	return nil
+}
+
+// noteSeenLocked records that the first size bytes of the named file
+// have been read or written.
+// d.mu must be held.
+func (d *DiskMutationLogger) noteSeenLocked(name string, size int64) {
+	if n := len(d.seen); n > 0 && d.seen[n-1].Name == name {
+		d.seen[n-1].Size = size
+		return
+	}
+	d.seen = append(d.seen, logSegment{Name: name, Size: size})
+}
+
+func (d *DiskMutationLogger) logPosition() (*logPosition, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return &logPosition{Source: "disk", Segments: append([]logSegment(nil), d.seen...)}, nil
+}
+
+func (d *DiskMutationLogger) resumeFrom(pos *logPosition) error {
+	d.mu.Lock()
+	wasDone := d.done
+	d.mu.Unlock()
+	if wasDone {
+		return errors.New("maintner: can't resume a DiskMutationLogger that has already been read")
+	}
+	if pos == nil {
+		d.skip = nil
+		return nil
+	}
+	if pos.Source != "disk" {
+		return fmt.Errorf("maintner: can't resume a disk log from a %s log position", pos.Source)
+	}
+	// The files the position covers must be the oldest ones, and
+	// must not have shrunk.
+	var files []os.FileInfo
+	if err := d.ForeachFile(func(fullPath string, fi os.FileInfo) error {
+		files = append(files, fi)
+		return nil
+	}); err != nil {
+		return err
+	}
+	skip := make(map[string]int64)
+	for i, seg := range pos.Segments {
+		if i >= len(files) || files[i].Name() != seg.Name {
+			return fmt.Errorf("maintner: mutation log file %s is missing", seg.Name)
+		}
+		if files[i].Size() < seg.Size {
+			return fmt.Errorf("maintner: mutation log file %s is shorter than expected (%d < %d bytes)", seg.Name, files[i].Size(), seg.Size)
+		}
+		skip[seg.Name] = seg.Size
+	}
+	d.skip = skip
+	return nil
 }
 
 func (d *DiskMutationLogger) ForeachFile(fn func(fullPath string, fi os.FileInfo) error) error {
@@ -101,18 +168,34 @@ func (d *DiskMutationLogger) GetMutations(ctx context.Context) <-chan MutationSt
 
 	go func() {
 		err := d.ForeachFile(func(fullPath string, fi os.FileInfo) error {
-			return reclog.ForeachFileRecord(fullPath, func(off int64, hdr, rec []byte) error {
+			// ForeachFile holds d.mu.
+			start := d.skip[fi.Name()]
+			d.noteSeenLocked(fi.Name(), start)
+			f, err := os.Open(fullPath)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if _, err := f.Seek(start, io.SeekStart); err != nil {
+				return err
+			}
+			err = reclog.ForeachRecord(f, start, func(off int64, hdr, rec []byte) error {
 				m := new(maintpb.Mutation)
 				if err := proto.Unmarshal(rec, m); err != nil {
 					return err
 				}
 				select {
 				case ch <- MutationStreamEvent{Mutation: m}:
-					return nil
 				case <-ctx.Done():
 					return ctx.Err()
 				}
+				d.noteSeenLocked(fi.Name(), off+int64(len(hdr)+len(rec)))
+				return nil
 			})
+			if err != nil {
+				return fmt.Errorf("error in %s: %v", fullPath, err)
+			}
+			return nil
 		})
 		final := MutationStreamEvent{Err: err}
 		if err == nil {
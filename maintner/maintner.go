@@ -31,12 +31,24 @@ type Corpus struct {
 	verbose        bool
 	dataDir        string
 	sawErrSplit    bool
+	snapshotPath   string // from UseSnapshot
+
+	// snapMu is held while a mutation is processed and logged in
+	// leader mode, so that snapshots see both or neither.
+	snapMu sync.Mutex
+
+	// indexMu guards index, the secondary indexes used by queries.
+	// It's nil until the first query.
+	indexMu sync.Mutex
+	index   *corpusIndex
 
 	mu sync.RWMutex // guards all following fields
 	// corpus state:
 	didInit   bool // true after Initialize completes successfully
 	debug     bool
 	strIntern map[string]string // interned strings, including binary githashes
+	logPos    *logPosition      // position in the log of the corpus state, if known
+	replayed  int               // mutations processed by the most recent update
 
 	// pubsub:
 	activityChans map[string]chan struct{} // keyed by topic
@@ -217,8 +229,31 @@ func (c *Corpus) Initialize(ctx context.Context, src MutationSource) error {
 		panic("duplicate call to Initialize")
 	}
 	c.mutationSource = src
+	loaded := c.snapshotPath != "" && c.loadSnapshot(src)
 	log.Printf("Loading data from log %T ...", src)
-	return c.update(ctx, nil)
+	err := c.update(ctx, nil)
+	if err == ErrSplit && loaded {
+		log.Printf("Log %T has diverged from snapshot %s; reloading it from the start.", src, c.snapshotPath)
+		c.mu.Lock()
+		c.resetState()
+		c.mu.Unlock()
+		if err := src.(resumableSource).resumeFrom(nil); err != nil {
+			return err
+		}
+		loaded = false
+		err = c.update(ctx, nil)
+	}
+	if err != nil {
+		return err
+	}
+	if c.snapshotPath != "" && (!loaded || c.replayed >= snapshotRefreshMutations) {
+		if err := c.SaveSnapshot(c.snapshotPath); err != nil {
+			log.Printf("Saving corpus snapshot: %v", err)
+		} else {
+			log.Printf("Saved corpus snapshot %s.", c.snapshotPath)
+		}
+	}
+	return nil
 }
 
 // ErrSplit is returned when the client notices the leader's
@@ -283,6 +318,7 @@ func (c *Corpus) update(ctx context.Context, lk sync.Locker) error {
 	if lk == nil {
 		lk = noopLocker{}
 	}
+	c.replayed = 0
 	for {
 		select {
 		case <-done:
@@ -299,12 +335,21 @@ func (c *Corpus) update(ctx context.Context, lk sync.Locker) error {
 				lk.Lock()
 				c.finishProcessing()
 				lk.Unlock()
+				if rs, ok := src.(resumableSource); ok {
+					pos, err := rs.logPosition()
+					if err != nil {
+						log.Printf("Finding position in log %T: %v", src, err)
+					}
+					c.logPos = pos
+				}
 				log.Printf("Reloaded data from log %T.", src)
 				return nil
 			}
 			lk.Lock()
 			c.processMutationLocked(e.Mutation)
 			lk.Unlock()
+			c.logPos = nil // until the End event
+			c.replayed++
 		}
 	}
 }
@@ -314,6 +359,8 @@ func (c *Corpus) addMutation(m *maintpb.Mutation) {
 	if c.verbose {
 		log.Printf("mutation: %v", m)
 	}
+	c.snapMu.Lock()
+	defer c.snapMu.Unlock()
 	c.mu.Lock()
 	c.processMutationLocked(m)
 	c.finishProcessing()
@@ -337,6 +384,9 @@ func (c *Corpus) processMutationLocked(m *maintpb.Mutation) {
 	if gm := m.Github; gm != nil {
 		c.processGithubMutation(gm)
 	}
+	if dm := m.GithubDiscussion; dm != nil {
+		c.processGithubDiscussionMutation(dm)
+	}
 	if gm := m.Git; gm != nil {
 		c.processGitMutation(gm)
 	}
@@ -30,6 +30,7 @@ const (
 	MaintnerService_GoFindTryWork_FullMethodName  = "/apipb.MaintnerService/GoFindTryWork"
 	MaintnerService_ListGoReleases_FullMethodName = "/apipb.MaintnerService/ListGoReleases"
 	MaintnerService_GetDashboard_FullMethodName   = "/apipb.MaintnerService/GetDashboard"
+	MaintnerService_Query_FullMethodName          = "/apipb.MaintnerService/Query"
 )
 
 // MaintnerServiceClient is the client API for MaintnerService service.
@@ -59,6 +60,9 @@ type MaintnerServiceClient interface {
 	// contain any pass/fail information; it only contains information on the branches
 	// and commits themselves.
 	GetDashboard(ctx context.Context, in *DashboardRequest, opts ...grpc.CallOption) (*DashboardResponse, error)
+	// Query returns the GitHub issues, GitHub pull requests and Gerrit
+	// CLs matching a maintner query.
+	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
 }
 
 type maintnerServiceClient struct {
@@ -119,6 +123,16 @@ func (c *maintnerServiceClient) GetDashboard(ctx context.Context, in *DashboardR
 	return out, nil
 }
 
+func (c *maintnerServiceClient) Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryResponse)
+	err := c.cc.Invoke(ctx, MaintnerService_Query_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MaintnerServiceServer is the server API for MaintnerService service.
 // All implementations must embed UnimplementedMaintnerServiceServer
 // for forward compatibility.
@@ -146,6 +160,9 @@ type MaintnerServiceServer interface {
 	// contain any pass/fail information; it only contains information on the branches
 	// and commits themselves.
 	GetDashboard(context.Context, *DashboardRequest) (*DashboardResponse, error)
+	// Query returns the GitHub issues, GitHub pull requests and Gerrit
+	// CLs matching a maintner query.
+	Query(context.Context, *QueryRequest) (*QueryResponse, error)
 	mustEmbedUnimplementedMaintnerServiceServer()
 }
 
@@ -171,6 +188,9 @@ func (UnimplementedMaintnerServiceServer) ListGoReleases(context.Context, *ListG
 func (UnimplementedMaintnerServiceServer) GetDashboard(context.Context, *DashboardRequest) (*DashboardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDashboard not implemented")
 }
+func (UnimplementedMaintnerServiceServer) Query(context.Context, *QueryRequest) (*QueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Query not implemented")
+}
 func (UnimplementedMaintnerServiceServer) mustEmbedUnimplementedMaintnerServiceServer() {}
 func (UnimplementedMaintnerServiceServer) testEmbeddedByValue()                         {}
 
@@ -282,6 +302,24 @@ func _MaintnerService_GetDashboard_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MaintnerService_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaintnerServiceServer).Query(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MaintnerService_Query_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaintnerServiceServer).Query(ctx, req.(*QueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MaintnerService_ServiceDesc is the grpc.ServiceDesc for MaintnerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -309,6 +347,10 @@ var MaintnerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDashboard",
 			Handler:    _MaintnerService_GetDashboard_Handler,
 		},
+		{
+			MethodName: "Query",
+			Handler:    _MaintnerService_Query_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "maintner/maintnerd/apipb/api.proto",
@@ -891,3 +891,94 @@ func dashRepoToGerritProj(repo string) (proj string, err error) {
 	}
 	return ri.GoGerritProject, nil
 }
+
+// defaultQueryLimit is the number of results Query returns when the
+// request doesn't specify a limit.
+const defaultQueryLimit = 100
+
+// maxQueryLimit is the largest limit a Query request may ask for, which
+// bounds how long Query holds the corpus lock and how large its
+// response is.
+const maxQueryLimit = 1000
+
+var errQueryLimit = errors.New("query limit reached")
+
+func (s apiService) Query(ctx context.Context, req *apipb.QueryRequest) (*apipb.QueryResponse, error) {
+	q, err := maintner.ParseQuery(req.Query)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	limit := int(req.Limit)
+	if limit < 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "negative limit")
+	}
+	if limit > maxQueryLimit {
+		return nil, grpc.Errorf(codes.InvalidArgument, "limit %d is above the maximum of %d", limit, maxQueryLimit)
+	}
+	if limit == 0 {
+		limit = defaultQueryLimit
+	}
+
+	s.c.RLock()
+	defer s.c.RUnlock()
+
+	res := new(apipb.QueryResponse)
+	err = s.c.ForeachMatch(q, func(m maintner.QueryMatch) error {
+		if len(res.Results) == limit {
+			res.Truncated = true
+			return errQueryLimit
+		}
+		res.Results = append(res.Results, queryResult(m))
+		return nil
+	})
+	if err != nil && err != errQueryLimit {
+		return nil, err
+	}
+	return res, nil
+}
+
+func queryResult(m maintner.QueryMatch) *apipb.QueryResult {
+	if cl := m.CL; cl != nil {
+		r := &apipb.QueryResult{
+			GerritProject: cl.Project.ServerSlashProject(),
+			Number:        cl.Number,
+			Title:         cl.Subject(),
+			Status:        cl.Status,
+			Branch:        cl.Branch(),
+			CreatedSec:    cl.Created.Unix(),
+			UpdatedSec:    cl.Meta.Commit.CommitTime.Unix(),
+		}
+		if owner := cl.Owner(); owner != nil {
+			r.Author = owner.Email()
+		}
+		return r
+	}
+	gi := m.Issue
+	r := &apipb.QueryResult{
+		GithubRepo:  m.Repo.ID().String(),
+		Number:      gi.Number,
+		Title:       gi.Title,
+		Status:      "open",
+		PullRequest: gi.PullRequest,
+		CreatedSec:  gi.Created.Unix(),
+		UpdatedSec:  gi.Updated.Unix(),
+	}
+	if gi.Closed {
+		r.Status = "closed"
+	}
+	if gi.User != nil {
+		r.Author = gi.User.Login
+	}
+	for _, lb := range gi.Labels {
+		r.Labels = append(r.Labels, lb.Name)
+	}
+	sort.Strings(r.Labels)
+	if gi.Milestone != nil && !gi.Milestone.IsNone() {
+		r.Milestone = gi.Milestone.Title
+	}
+	for _, u := range gi.Assignees {
+		r.Assignees = append(r.Assignees, u.Login)
+	}
+	sort.Strings(r.Assignees)
+	return r
+}
@@ -432,6 +432,25 @@ func TestTryWorkItem(t *testing.T) {
 	}
 }
 
+func TestQueryLimit(t *testing.T) {
+	s := apiService{c: new(maintner.Corpus)}
+	tests := []struct {
+		limit    int32
+		wantCode codes.Code
+	}{
+		{0, codes.OK},
+		{maxQueryLimit, codes.OK},
+		{-1, codes.InvalidArgument},
+		{maxQueryLimit + 1, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		req := &apipb.QueryRequest{Query: "is:open", Limit: tt.limit}
+		if _, err := s.Query(context.Background(), req); grpc.Code(err) != tt.wantCode {
+			t.Errorf("Query with limit %d: got RPC code %v (err %v), want %v", tt.limit, grpc.Code(err), err, tt.wantCode)
+		}
+	}
+}
+
 func TestParseInternalBranchVersion(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -32,10 +32,11 @@ type Mutation struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	GithubIssue *GithubIssueMutation `protobuf:"bytes,1,opt,name=github_issue,json=githubIssue,proto3" json:"github_issue,omitempty"` // issue-specific changes
-	Github      *GithubMutation      `protobuf:"bytes,3,opt,name=github,proto3" json:"github,omitempty"`                              // labels, milestones (not issue-specific)
-	Git         *GitMutation         `protobuf:"bytes,2,opt,name=git,proto3" json:"git,omitempty"`
-	Gerrit      *GerritMutation      `protobuf:"bytes,4,opt,name=gerrit,proto3" json:"gerrit,omitempty"`
+	GithubIssue      *GithubIssueMutation      `protobuf:"bytes,1,opt,name=github_issue,json=githubIssue,proto3" json:"github_issue,omitempty"` // issue-specific changes
+	Github           *GithubMutation           `protobuf:"bytes,3,opt,name=github,proto3" json:"github,omitempty"`                              // labels, milestones (not issue-specific)
+	Git              *GitMutation              `protobuf:"bytes,2,opt,name=git,proto3" json:"git,omitempty"`
+	Gerrit           *GerritMutation           `protobuf:"bytes,4,opt,name=gerrit,proto3" json:"gerrit,omitempty"`
+	GithubDiscussion *GithubDiscussionMutation `protobuf:"bytes,5,opt,name=github_discussion,json=githubDiscussion,proto3" json:"github_discussion,omitempty"` // discussion-specific changes
 }
 
 func (x *Mutation) Reset() {
@@ -98,6 +99,13 @@ func (x *Mutation) GetGerrit() *GerritMutation {
 	return nil
 }
 
+func (x *Mutation) GetGithubDiscussion() *GithubDiscussionMutation {
+	if x != nil {
+		return x.GithubDiscussion
+	}
+	return nil
+}
+
 type GithubMutation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -217,6 +225,13 @@ type GithubIssueMutation struct {
 	EventStatus    *GithubIssueSyncStatus        `protobuf:"bytes,27,opt,name=event_status,json=eventStatus,proto3" json:"event_status,omitempty"`
 	Review         []*GithubReview               `protobuf:"bytes,29,rep,name=review,proto3" json:"review,omitempty"` // new reviews to add
 	ReviewStatus   *GithubIssueSyncStatus        `protobuf:"bytes,30,opt,name=review_status,json=reviewStatus,proto3" json:"review_status,omitempty"`
+	HeadSha        string                        `protobuf:"bytes,32,opt,name=head_sha,json=headSha,proto3" json:"head_sha,omitempty"` // current head commit of the pull request
+	// files, if non-nil, replaces the pull request's list of changed
+	// files. It's the list as of head_sha.
+	Files          *GithubPullRequestFiles `protobuf:"bytes,33,opt,name=files,proto3" json:"files,omitempty"`
+	FileStatus     *GithubIssueSyncStatus  `protobuf:"bytes,34,opt,name=file_status,json=fileStatus,proto3" json:"file_status,omitempty"`
+	CheckRun       []*GithubCheckRun       `protobuf:"bytes,35,rep,name=check_run,json=checkRun,proto3" json:"check_run,omitempty"` // new or updated check runs
+	CheckRunStatus *GithubIssueSyncStatus  `protobuf:"bytes,36,opt,name=check_run_status,json=checkRunStatus,proto3" json:"check_run_status,omitempty"`
 }
 
 func (x *GithubIssueMutation) Reset() {
@@ -461,6 +476,41 @@ func (x *GithubIssueMutation) GetReviewStatus() *GithubIssueSyncStatus {
 	return nil
 }
 
+func (x *GithubIssueMutation) GetHeadSha() string {
+	if x != nil {
+		return x.HeadSha
+	}
+	return ""
+}
+
+func (x *GithubIssueMutation) GetFiles() *GithubPullRequestFiles {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *GithubIssueMutation) GetFileStatus() *GithubIssueSyncStatus {
+	if x != nil {
+		return x.FileStatus
+	}
+	return nil
+}
+
+func (x *GithubIssueMutation) GetCheckRun() []*GithubCheckRun {
+	if x != nil {
+		return x.CheckRun
+	}
+	return nil
+}
+
+func (x *GithubIssueMutation) GetCheckRunStatus() *GithubIssueSyncStatus {
+	if x != nil {
+		return x.CheckRunStatus
+	}
+	return nil
+}
+
 // BoolChange represents a change to a boolean value.
 // (Notably, the wrapper type permits representing a change to false.)
 type BoolChange struct {
@@ -1112,22 +1162,21 @@ func (x *GithubReview) GetOtherJson() []byte {
 	return nil
 }
 
-// GithubIssueSyncStatus notes where syncing is at for comments
-// on an issue,
-// This mutation type is only made at/after the same top-level mutation
-// which created the corresponding comments.
-type GithubIssueSyncStatus struct {
+// GithubPullRequestFiles is the list of files changed by a pull request.
+// See https://docs.github.com/en/rest/pulls/pulls#list-pull-requests-files.
+type GithubPullRequestFiles struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// server_date is the "Date" response header from Github for the
-	// final HTTP response.
-	ServerDate *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=server_date,json=serverDate,proto3" json:"server_date,omitempty"`
+	File []*GithubPullRequestFile `protobuf:"bytes,1,rep,name=file,proto3" json:"file,omitempty"`
+	// truncated is set if GitHub only listed some of the files.
+	// It lists at most 3000.
+	Truncated bool `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
 }
 
-func (x *GithubIssueSyncStatus) Reset() {
-	*x = GithubIssueSyncStatus{}
+func (x *GithubPullRequestFiles) Reset() {
+	*x = GithubPullRequestFiles{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_maintner_maintpb_maintner_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1135,13 +1184,13 @@ func (x *GithubIssueSyncStatus) Reset() {
 	}
 }
 
-func (x *GithubIssueSyncStatus) String() string {
+func (x *GithubPullRequestFiles) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GithubIssueSyncStatus) ProtoMessage() {}
+func (*GithubPullRequestFiles) ProtoMessage() {}
 
-func (x *GithubIssueSyncStatus) ProtoReflect() protoreflect.Message {
+func (x *GithubPullRequestFiles) ProtoReflect() protoreflect.Message {
 	mi := &file_maintner_maintpb_maintner_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1153,32 +1202,39 @@ func (x *GithubIssueSyncStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GithubIssueSyncStatus.ProtoReflect.Descriptor instead.
-func (*GithubIssueSyncStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use GithubPullRequestFiles.ProtoReflect.Descriptor instead.
+func (*GithubPullRequestFiles) Descriptor() ([]byte, []int) {
 	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GithubIssueSyncStatus) GetServerDate() *timestamppb.Timestamp {
+func (x *GithubPullRequestFiles) GetFile() []*GithubPullRequestFile {
 	if x != nil {
-		return x.ServerDate
+		return x.File
 	}
 	return nil
 }
 
-type GithubIssueCommentMutation struct {
+func (x *GithubPullRequestFiles) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type GithubPullRequestFile struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id      int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	User    *GithubUser            `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`       // not present in edits later
-	Body    string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`       // may not be present in edits later (if only reactions changed? TODO: investigate)
-	Created *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"` // not present in edits later
-	Updated *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated,proto3" json:"updated,omitempty"`
+	Filename         string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Status           string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // added, removed, modified, renamed, copied, changed, unchanged
+	Additions        int64  `protobuf:"varint,3,opt,name=additions,proto3" json:"additions,omitempty"`
+	Deletions        int64  `protobuf:"varint,4,opt,name=deletions,proto3" json:"deletions,omitempty"`
+	PreviousFilename string `protobuf:"bytes,5,opt,name=previous_filename,json=previousFilename,proto3" json:"previous_filename,omitempty"` // only for renamed files
 }
 
-func (x *GithubIssueCommentMutation) Reset() {
-	*x = GithubIssueCommentMutation{}
+func (x *GithubPullRequestFile) Reset() {
+	*x = GithubPullRequestFile{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_maintner_maintpb_maintner_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1186,13 +1242,13 @@ func (x *GithubIssueCommentMutation) Reset() {
 	}
 }
 
-func (x *GithubIssueCommentMutation) String() string {
+func (x *GithubPullRequestFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GithubIssueCommentMutation) ProtoMessage() {}
+func (*GithubPullRequestFile) ProtoMessage() {}
 
-func (x *GithubIssueCommentMutation) ProtoReflect() protoreflect.Message {
+func (x *GithubPullRequestFile) ProtoReflect() protoreflect.Message {
 	mi := &file_maintner_maintpb_maintner_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1204,57 +1260,68 @@ func (x *GithubIssueCommentMutation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GithubIssueCommentMutation.ProtoReflect.Descriptor instead.
-func (*GithubIssueCommentMutation) Descriptor() ([]byte, []int) {
+// Deprecated: Use GithubPullRequestFile.ProtoReflect.Descriptor instead.
+func (*GithubPullRequestFile) Descriptor() ([]byte, []int) {
 	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GithubIssueCommentMutation) GetId() int64 {
+func (x *GithubPullRequestFile) GetFilename() string {
 	if x != nil {
-		return x.Id
+		return x.Filename
 	}
-	return 0
+	return ""
 }
 
-func (x *GithubIssueCommentMutation) GetUser() *GithubUser {
+func (x *GithubPullRequestFile) GetStatus() string {
 	if x != nil {
-		return x.User
+		return x.Status
 	}
-	return nil
+	return ""
 }
 
-func (x *GithubIssueCommentMutation) GetBody() string {
+func (x *GithubPullRequestFile) GetAdditions() int64 {
 	if x != nil {
-		return x.Body
+		return x.Additions
 	}
-	return ""
+	return 0
 }
 
-func (x *GithubIssueCommentMutation) GetCreated() *timestamppb.Timestamp {
+func (x *GithubPullRequestFile) GetDeletions() int64 {
 	if x != nil {
-		return x.Created
+		return x.Deletions
 	}
-	return nil
+	return 0
 }
 
-func (x *GithubIssueCommentMutation) GetUpdated() *timestamppb.Timestamp {
+func (x *GithubPullRequestFile) GetPreviousFilename() string {
 	if x != nil {
-		return x.Updated
+		return x.PreviousFilename
 	}
-	return nil
+	return ""
 }
 
-type GithubUser struct {
+// GithubCheckRun is the state of a check run on a pull request's
+// commit. Each mutation carries the whole run, replacing any
+// earlier state for the same id.
+// See https://docs.github.com/en/rest/checks/runs.
+type GithubCheckRun struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id    int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Login string `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // required
+	HeadSha    string                 `protobuf:"bytes,2,opt,name=head_sha,json=headSha,proto3" json:"head_sha,omitempty"`
+	Name       string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	App        string                 `protobuf:"bytes,4,opt,name=app,proto3" json:"app,omitempty"`               // slug of the GitHub App that created the run
+	Status     string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`         // queued, in_progress, completed
+	Conclusion string                 `protobuf:"bytes,6,opt,name=conclusion,proto3" json:"conclusion,omitempty"` // success, failure, neutral, cancelled, skipped, timed_out, action_required; empty until completed
+	Started    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started,proto3" json:"started,omitempty"`
+	Completed  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=completed,proto3" json:"completed,omitempty"`
+	DetailsUrl string                 `protobuf:"bytes,9,opt,name=details_url,json=detailsUrl,proto3" json:"details_url,omitempty"`
 }
 
-func (x *GithubUser) Reset() {
-	*x = GithubUser{}
+func (x *GithubCheckRun) Reset() {
+	*x = GithubCheckRun{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_maintner_maintpb_maintner_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1262,13 +1329,13 @@ func (x *GithubUser) Reset() {
 	}
 }
 
-func (x *GithubUser) String() string {
+func (x *GithubCheckRun) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GithubUser) ProtoMessage() {}
+func (*GithubCheckRun) ProtoMessage() {}
 
-func (x *GithubUser) ProtoReflect() protoreflect.Message {
+func (x *GithubCheckRun) ProtoReflect() protoreflect.Message {
 	mi := &file_maintner_maintpb_maintner_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1280,36 +1347,101 @@ func (x *GithubUser) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GithubUser.ProtoReflect.Descriptor instead.
-func (*GithubUser) Descriptor() ([]byte, []int) {
+// Deprecated: Use GithubCheckRun.ProtoReflect.Descriptor instead.
+func (*GithubCheckRun) Descriptor() ([]byte, []int) {
 	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GithubUser) GetId() int64 {
+func (x *GithubCheckRun) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *GithubUser) GetLogin() string {
+func (x *GithubCheckRun) GetHeadSha() string {
 	if x != nil {
-		return x.Login
+		return x.HeadSha
 	}
 	return ""
 }
 
-type GithubTeam struct {
+func (x *GithubCheckRun) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GithubCheckRun) GetApp() string {
+	if x != nil {
+		return x.App
+	}
+	return ""
+}
+
+func (x *GithubCheckRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GithubCheckRun) GetConclusion() string {
+	if x != nil {
+		return x.Conclusion
+	}
+	return ""
+}
+
+func (x *GithubCheckRun) GetStarted() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Started
+	}
+	return nil
+}
+
+func (x *GithubCheckRun) GetCompleted() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Completed
+	}
+	return nil
+}
+
+func (x *GithubCheckRun) GetDetailsUrl() string {
+	if x != nil {
+		return x.DetailsUrl
+	}
+	return ""
+}
+
+// GithubDiscussionMutation is a change to a GitHub Discussion.
+// Discussions are numbered alongside issues, but kept separately.
+type GithubDiscussionMutation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id   int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Slug string `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
-}
-
-func (x *GithubTeam) Reset() {
-	*x = GithubTeam{}
+	Owner    string                     `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`     // "golang"
+	Repo     string                     `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`       // "go"
+	Number   int32                      `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`  // 1, 2, 3... (not the ID)
+	Id       int64                      `protobuf:"varint,4,opt,name=id,proto3" json:"id,omitempty"`          // unique across all repos
+	User     *GithubUser                `protobuf:"bytes,5,opt,name=user,proto3" json:"user,omitempty"`       // only needed on new discussions
+	Created  *timestamppb.Timestamp     `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"` // only needed on new discussions
+	Updated  *timestamppb.Timestamp     `protobuf:"bytes,7,opt,name=updated,proto3" json:"updated,omitempty"`
+	Title    string                     `protobuf:"bytes,8,opt,name=title,proto3" json:"title,omitempty"`
+	Body     *StringChange              `protobuf:"bytes,9,opt,name=body,proto3" json:"body,omitempty"`
+	Category string                     `protobuf:"bytes,10,opt,name=category,proto3" json:"category,omitempty"` // "Q&A", "Ideas", etc
+	Closed   *BoolChange                `protobuf:"bytes,11,opt,name=closed,proto3" json:"closed,omitempty"`
+	Locked   *BoolChange                `protobuf:"bytes,12,opt,name=locked,proto3" json:"locked,omitempty"`
+	Comment  []*GithubDiscussionComment `protobuf:"bytes,13,rep,name=comment,proto3" json:"comment,omitempty"`
+	// comment_status notes that comments were synced as of the
+	// discussion's updated time.
+	CommentStatus *GithubIssueSyncStatus `protobuf:"bytes,14,opt,name=comment_status,json=commentStatus,proto3" json:"comment_status,omitempty"`
+}
+
+func (x *GithubDiscussionMutation) Reset() {
+	*x = GithubDiscussionMutation{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_maintner_maintpb_maintner_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1317,13 +1449,13 @@ func (x *GithubTeam) Reset() {
 	}
 }
 
-func (x *GithubTeam) String() string {
+func (x *GithubDiscussionMutation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GithubTeam) ProtoMessage() {}
+func (*GithubDiscussionMutation) ProtoMessage() {}
 
-func (x *GithubTeam) ProtoReflect() protoreflect.Message {
+func (x *GithubDiscussionMutation) ProtoReflect() protoreflect.Message {
 	mi := &file_maintner_maintpb_maintner_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1335,109 +1467,142 @@ func (x *GithubTeam) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GithubTeam.ProtoReflect.Descriptor instead.
-func (*GithubTeam) Descriptor() ([]byte, []int) {
+// Deprecated: Use GithubDiscussionMutation.ProtoReflect.Descriptor instead.
+func (*GithubDiscussionMutation) Descriptor() ([]byte, []int) {
 	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GithubTeam) GetId() int64 {
+func (x *GithubDiscussionMutation) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *GithubDiscussionMutation) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *GithubDiscussionMutation) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *GithubDiscussionMutation) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *GithubTeam) GetSlug() string {
+func (x *GithubDiscussionMutation) GetUser() *GithubUser {
 	if x != nil {
-		return x.Slug
+		return x.User
 	}
-	return ""
+	return nil
 }
 
-type GitMutation struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *GithubDiscussionMutation) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
 
-	Repo *GitRepo `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
-	// commit adds a commit, or adds new information to a commit if fields
-	// are added in the future.
-	Commit *GitCommit `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+func (x *GithubDiscussionMutation) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
 }
 
-func (x *GitMutation) Reset() {
-	*x = GitMutation{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_maintner_maintpb_maintner_proto_msgTypes[15]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *GithubDiscussionMutation) GetTitle() string {
+	if x != nil {
+		return x.Title
 	}
+	return ""
 }
 
-func (x *GitMutation) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GithubDiscussionMutation) GetBody() *StringChange {
+	if x != nil {
+		return x.Body
+	}
+	return nil
 }
 
-func (*GitMutation) ProtoMessage() {}
+func (x *GithubDiscussionMutation) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
 
-func (x *GitMutation) ProtoReflect() protoreflect.Message {
-	mi := &file_maintner_maintpb_maintner_proto_msgTypes[15]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *GithubDiscussionMutation) GetClosed() *BoolChange {
+	if x != nil {
+		return x.Closed
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GitMutation.ProtoReflect.Descriptor instead.
-func (*GitMutation) Descriptor() ([]byte, []int) {
-	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{15}
+func (x *GithubDiscussionMutation) GetLocked() *BoolChange {
+	if x != nil {
+		return x.Locked
+	}
+	return nil
 }
 
-func (x *GitMutation) GetRepo() *GitRepo {
+func (x *GithubDiscussionMutation) GetComment() []*GithubDiscussionComment {
 	if x != nil {
-		return x.Repo
+		return x.Comment
 	}
 	return nil
 }
 
-func (x *GitMutation) GetCommit() *GitCommit {
+func (x *GithubDiscussionMutation) GetCommentStatus() *GithubIssueSyncStatus {
 	if x != nil {
-		return x.Commit
+		return x.CommentStatus
 	}
 	return nil
 }
 
-// GitRepo identifies a git repo being mutated.
-type GitRepo struct {
+// GithubDiscussionComment is a new comment on a discussion, a reply
+// to one, or an update to either.
+type GithubDiscussionComment struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// If go_repo is set, it identifies a go.googlesource.com/<go_repo> repo.
-	GoRepo string `protobuf:"bytes,1,opt,name=go_repo,json=goRepo,proto3" json:"go_repo,omitempty"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReplyToId int64                  `protobuf:"varint,2,opt,name=reply_to_id,json=replyToId,proto3" json:"reply_to_id,omitempty"` // the comment replied to, or zero for top-level comments
+	User      *GithubUser            `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`                               // only needed on new comments
+	Created   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`                         // only needed on new comments
+	Updated   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated,proto3" json:"updated,omitempty"`
+	Body      *StringChange          `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	IsAnswer  *BoolChange            `protobuf:"bytes,7,opt,name=is_answer,json=isAnswer,proto3" json:"is_answer,omitempty"` // whether the comment is marked as the answer
 }
 
-func (x *GitRepo) Reset() {
-	*x = GitRepo{}
+func (x *GithubDiscussionComment) Reset() {
+	*x = GithubDiscussionComment{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_maintner_maintpb_maintner_proto_msgTypes[16]
+		mi := &file_maintner_maintpb_maintner_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GitRepo) String() string {
+func (x *GithubDiscussionComment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GitRepo) ProtoMessage() {}
+func (*GithubDiscussionComment) ProtoMessage() {}
 
-func (x *GitRepo) ProtoReflect() protoreflect.Message {
-	mi := &file_maintner_maintpb_maintner_proto_msgTypes[16]
+func (x *GithubDiscussionComment) ProtoReflect() protoreflect.Message {
+	mi := &file_maintner_maintpb_maintner_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1448,19 +1613,409 @@ func (x *GitRepo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GitRepo.ProtoReflect.Descriptor instead.
-func (*GitRepo) Descriptor() ([]byte, []int) {
-	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use GithubDiscussionComment.ProtoReflect.Descriptor instead.
+func (*GithubDiscussionComment) Descriptor() ([]byte, []int) {
+	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *GitRepo) GetGoRepo() string {
+func (x *GithubDiscussionComment) GetId() int64 {
 	if x != nil {
-		return x.GoRepo
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-type GitCommit struct {
+func (x *GithubDiscussionComment) GetReplyToId() int64 {
+	if x != nil {
+		return x.ReplyToId
+	}
+	return 0
+}
+
+func (x *GithubDiscussionComment) GetUser() *GithubUser {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *GithubDiscussionComment) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *GithubDiscussionComment) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+func (x *GithubDiscussionComment) GetBody() *StringChange {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *GithubDiscussionComment) GetIsAnswer() *BoolChange {
+	if x != nil {
+		return x.IsAnswer
+	}
+	return nil
+}
+
+// GithubIssueSyncStatus notes where syncing is at for comments
+// on an issue,
+// This mutation type is only made at/after the same top-level mutation
+// which created the corresponding comments.
+type GithubIssueSyncStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// server_date is the "Date" response header from Github for the
+	// final HTTP response.
+	ServerDate *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=server_date,json=serverDate,proto3" json:"server_date,omitempty"`
+}
+
+func (x *GithubIssueSyncStatus) Reset() {
+	*x = GithubIssueSyncStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_maintner_maintpb_maintner_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GithubIssueSyncStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GithubIssueSyncStatus) ProtoMessage() {}
+
+func (x *GithubIssueSyncStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_maintner_maintpb_maintner_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GithubIssueSyncStatus.ProtoReflect.Descriptor instead.
+func (*GithubIssueSyncStatus) Descriptor() ([]byte, []int) {
+	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GithubIssueSyncStatus) GetServerDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ServerDate
+	}
+	return nil
+}
+
+type GithubIssueCommentMutation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	User    *GithubUser            `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`       // not present in edits later
+	Body    string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`       // may not be present in edits later (if only reactions changed? TODO: investigate)
+	Created *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"` // not present in edits later
+	Updated *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated,proto3" json:"updated,omitempty"`
+}
+
+func (x *GithubIssueCommentMutation) Reset() {
+	*x = GithubIssueCommentMutation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_maintner_maintpb_maintner_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GithubIssueCommentMutation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GithubIssueCommentMutation) ProtoMessage() {}
+
+func (x *GithubIssueCommentMutation) ProtoReflect() protoreflect.Message {
+	mi := &file_maintner_maintpb_maintner_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GithubIssueCommentMutation.ProtoReflect.Descriptor instead.
+func (*GithubIssueCommentMutation) Descriptor() ([]byte, []int) {
+	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GithubIssueCommentMutation) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GithubIssueCommentMutation) GetUser() *GithubUser {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *GithubIssueCommentMutation) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *GithubIssueCommentMutation) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *GithubIssueCommentMutation) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+type GithubUser struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Login string `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+}
+
+func (x *GithubUser) Reset() {
+	*x = GithubUser{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_maintner_maintpb_maintner_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GithubUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GithubUser) ProtoMessage() {}
+
+func (x *GithubUser) ProtoReflect() protoreflect.Message {
+	mi := &file_maintner_maintpb_maintner_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GithubUser.ProtoReflect.Descriptor instead.
+func (*GithubUser) Descriptor() ([]byte, []int) {
+	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GithubUser) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GithubUser) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+type GithubTeam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug string `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+}
+
+func (x *GithubTeam) Reset() {
+	*x = GithubTeam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_maintner_maintpb_maintner_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GithubTeam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GithubTeam) ProtoMessage() {}
+
+func (x *GithubTeam) ProtoReflect() protoreflect.Message {
+	mi := &file_maintner_maintpb_maintner_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GithubTeam.ProtoReflect.Descriptor instead.
+func (*GithubTeam) Descriptor() ([]byte, []int) {
+	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GithubTeam) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GithubTeam) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type GitMutation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Repo *GitRepo `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	// commit adds a commit, or adds new information to a commit if fields
+	// are added in the future.
+	Commit *GitCommit `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+}
+
+func (x *GitMutation) Reset() {
+	*x = GitMutation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_maintner_maintpb_maintner_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GitMutation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GitMutation) ProtoMessage() {}
+
+func (x *GitMutation) ProtoReflect() protoreflect.Message {
+	mi := &file_maintner_maintpb_maintner_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GitMutation.ProtoReflect.Descriptor instead.
+func (*GitMutation) Descriptor() ([]byte, []int) {
+	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GitMutation) GetRepo() *GitRepo {
+	if x != nil {
+		return x.Repo
+	}
+	return nil
+}
+
+func (x *GitMutation) GetCommit() *GitCommit {
+	if x != nil {
+		return x.Commit
+	}
+	return nil
+}
+
+// GitRepo identifies a git repo being mutated.
+type GitRepo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// If go_repo is set, it identifies a go.googlesource.com/<go_repo> repo.
+	GoRepo string `protobuf:"bytes,1,opt,name=go_repo,json=goRepo,proto3" json:"go_repo,omitempty"`
+}
+
+func (x *GitRepo) Reset() {
+	*x = GitRepo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_maintner_maintpb_maintner_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GitRepo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GitRepo) ProtoMessage() {}
+
+func (x *GitRepo) ProtoReflect() protoreflect.Message {
+	mi := &file_maintner_maintpb_maintner_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GitRepo.ProtoReflect.Descriptor instead.
+func (*GitRepo) Descriptor() ([]byte, []int) {
+	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GitRepo) GetGoRepo() string {
+	if x != nil {
+		return x.GoRepo
+	}
+	return ""
+}
+
+type GitCommit struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1474,7 +2029,7 @@ type GitCommit struct {
 func (x *GitCommit) Reset() {
 	*x = GitCommit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_maintner_maintpb_maintner_proto_msgTypes[17]
+		mi := &file_maintner_maintpb_maintner_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1487,7 +2042,7 @@ func (x *GitCommit) String() string {
 func (*GitCommit) ProtoMessage() {}
 
 func (x *GitCommit) ProtoReflect() protoreflect.Message {
-	mi := &file_maintner_maintpb_maintner_proto_msgTypes[17]
+	mi := &file_maintner_maintpb_maintner_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1500,7 +2055,7 @@ func (x *GitCommit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GitCommit.ProtoReflect.Descriptor instead.
 func (*GitCommit) Descriptor() ([]byte, []int) {
-	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{17}
+	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GitCommit) GetSha1() string {
@@ -1536,7 +2091,7 @@ type GitDiffTree struct {
 func (x *GitDiffTree) Reset() {
 	*x = GitDiffTree{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_maintner_maintpb_maintner_proto_msgTypes[18]
+		mi := &file_maintner_maintpb_maintner_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1549,7 +2104,7 @@ func (x *GitDiffTree) String() string {
 func (*GitDiffTree) ProtoMessage() {}
 
 func (x *GitDiffTree) ProtoReflect() protoreflect.Message {
-	mi := &file_maintner_maintpb_maintner_proto_msgTypes[18]
+	mi := &file_maintner_maintpb_maintner_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1562,7 +2117,7 @@ func (x *GitDiffTree) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GitDiffTree.ProtoReflect.Descriptor instead.
 func (*GitDiffTree) Descriptor() ([]byte, []int) {
-	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{18}
+	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GitDiffTree) GetFile() []*GitDiffTreeFile {
@@ -1587,7 +2142,7 @@ type GitDiffTreeFile struct {
 func (x *GitDiffTreeFile) Reset() {
 	*x = GitDiffTreeFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_maintner_maintpb_maintner_proto_msgTypes[19]
+		mi := &file_maintner_maintpb_maintner_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1600,7 +2155,7 @@ func (x *GitDiffTreeFile) String() string {
 func (*GitDiffTreeFile) ProtoMessage() {}
 
 func (x *GitDiffTreeFile) ProtoReflect() protoreflect.Message {
-	mi := &file_maintner_maintpb_maintner_proto_msgTypes[19]
+	mi := &file_maintner_maintpb_maintner_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1613,7 +2168,7 @@ func (x *GitDiffTreeFile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GitDiffTreeFile.ProtoReflect.Descriptor instead.
 func (*GitDiffTreeFile) Descriptor() ([]byte, []int) {
-	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{19}
+	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GitDiffTreeFile) GetFile() string {
@@ -1658,12 +2213,16 @@ type GerritMutation struct {
 	Refs []*GitRef `protobuf:"bytes,3,rep,name=refs,proto3" json:"refs,omitempty"`
 	// deleted_refs are ref names to delete.
 	DeletedRefs []string `protobuf:"bytes,4,rep,name=deleted_refs,json=deletedRefs,proto3" json:"deleted_refs,omitempty"`
+	// Inline comments published by the commits. They're read from the
+	// notes in Gerrit NoteDb meta commits' trees, which aren't otherwise
+	// recorded.
+	Comments []*GerritComment `protobuf:"bytes,5,rep,name=comments,proto3" json:"comments,omitempty"`
 }
 
 func (x *GerritMutation) Reset() {
 	*x = GerritMutation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_maintner_maintpb_maintner_proto_msgTypes[20]
+		mi := &file_maintner_maintpb_maintner_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1676,7 +2235,7 @@ func (x *GerritMutation) String() string {
 func (*GerritMutation) ProtoMessage() {}
 
 func (x *GerritMutation) ProtoReflect() protoreflect.Message {
-	mi := &file_maintner_maintpb_maintner_proto_msgTypes[20]
+	mi := &file_maintner_maintpb_maintner_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1689,7 +2248,7 @@ func (x *GerritMutation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GerritMutation.ProtoReflect.Descriptor instead.
 func (*GerritMutation) Descriptor() ([]byte, []int) {
-	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{20}
+	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GerritMutation) GetProject() string {
@@ -1720,6 +2279,150 @@ func (x *GerritMutation) GetDeletedRefs() []string {
 	return nil
 }
 
+func (x *GerritMutation) GetComments() []*GerritComment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+// GerritComment is an inline comment on a file in a CL's patch set,
+// or a reply to one, as stored in NoteDb.
+type GerritComment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MetaSha1   string                 `protobuf:"bytes,1,opt,name=meta_sha1,json=metaSha1,proto3" json:"meta_sha1,omitempty"` // the meta commit that published the comment
+	Uuid       string                 `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	ParentUuid string                 `protobuf:"bytes,3,opt,name=parent_uuid,json=parentUuid,proto3" json:"parent_uuid,omitempty"` // the comment replied to, if any
+	PatchSet   int32                  `protobuf:"varint,4,opt,name=patch_set,json=patchSet,proto3" json:"patch_set,omitempty"`
+	RevId      string                 `protobuf:"bytes,5,opt,name=rev_id,json=revId,proto3" json:"rev_id,omitempty"`           // the patch set's commit
+	Filename   string                 `protobuf:"bytes,6,opt,name=filename,proto3" json:"filename,omitempty"`                  // "/COMMIT_MSG" and "/PATCHSET_LEVEL" are special
+	Line       int32                  `protobuf:"varint,7,opt,name=line,proto3" json:"line,omitempty"`                         // zero for comments on the whole file
+	AuthorId   int64                  `protobuf:"varint,8,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"` // Gerrit account ID
+	ServerId   string                 `protobuf:"bytes,9,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`  // "62eb7196-b449-3ce5-99f1-c037f21e1705"
+	Written    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=written,proto3" json:"written,omitempty"`
+	Message    string                 `protobuf:"bytes,11,opt,name=message,proto3" json:"message,omitempty"`
+	Unresolved bool                   `protobuf:"varint,12,opt,name=unresolved,proto3" json:"unresolved,omitempty"`
+}
+
+func (x *GerritComment) Reset() {
+	*x = GerritComment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_maintner_maintpb_maintner_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GerritComment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GerritComment) ProtoMessage() {}
+
+func (x *GerritComment) ProtoReflect() protoreflect.Message {
+	mi := &file_maintner_maintpb_maintner_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GerritComment.ProtoReflect.Descriptor instead.
+func (*GerritComment) Descriptor() ([]byte, []int) {
+	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GerritComment) GetMetaSha1() string {
+	if x != nil {
+		return x.MetaSha1
+	}
+	return ""
+}
+
+func (x *GerritComment) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *GerritComment) GetParentUuid() string {
+	if x != nil {
+		return x.ParentUuid
+	}
+	return ""
+}
+
+func (x *GerritComment) GetPatchSet() int32 {
+	if x != nil {
+		return x.PatchSet
+	}
+	return 0
+}
+
+func (x *GerritComment) GetRevId() string {
+	if x != nil {
+		return x.RevId
+	}
+	return ""
+}
+
+func (x *GerritComment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *GerritComment) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *GerritComment) GetAuthorId() int64 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *GerritComment) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *GerritComment) GetWritten() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Written
+	}
+	return nil
+}
+
+func (x *GerritComment) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GerritComment) GetUnresolved() bool {
+	if x != nil {
+		return x.Unresolved
+	}
+	return false
+}
+
 type GitRef struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1740,7 +2443,7 @@ type GitRef struct {
 func (x *GitRef) Reset() {
 	*x = GitRef{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_maintner_maintpb_maintner_proto_msgTypes[21]
+		mi := &file_maintner_maintpb_maintner_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1753,7 +2456,7 @@ func (x *GitRef) String() string {
 func (*GitRef) ProtoMessage() {}
 
 func (x *GitRef) ProtoReflect() protoreflect.Message {
-	mi := &file_maintner_maintpb_maintner_proto_msgTypes[21]
+	mi := &file_maintner_maintpb_maintner_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1766,7 +2469,7 @@ func (x *GitRef) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GitRef.ProtoReflect.Descriptor instead.
 func (*GitRef) Descriptor() ([]byte, []int) {
-	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{21}
+	return file_maintner_maintpb_maintner_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GitRef) GetRef() string {
@@ -1790,7 +2493,7 @@ var file_maintner_maintpb_maintner_proto_rawDesc = []byte{
 	0x70, 0x62, 0x2f, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x6e, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x07, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd5, 0x01, 0x0a, 0x08,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa5, 0x02, 0x0a, 0x08,
 	0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x0c, 0x67, 0x69, 0x74, 0x68,
 	0x75, 0x62, 0x5f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
 	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x49,
@@ -1804,7 +2507,12 @@ var file_maintner_maintpb_maintner_proto_rawDesc = []byte{
 	0x69, 0x74, 0x12, 0x2f, 0x0a, 0x06, 0x67, 0x65, 0x72, 0x72, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x72,
 	0x72, 0x69, 0x74, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x67, 0x65, 0x72,
-	0x72, 0x69, 0x74, 0x22, 0xa2, 0x01, 0x0a, 0x0e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x4d, 0x75,
+	0x72, 0x69, 0x74, 0x12, 0x4e, 0x0a, 0x11, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x5f, 0x64, 0x69,
+	0x73, 0x63, 0x75, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x44,
+	0x69, 0x73, 0x63, 0x75, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x10, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x44, 0x69, 0x73, 0x63, 0x75, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0xa2, 0x01, 0x0a, 0x0e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x4d, 0x75,
 	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
 	0x72, 0x65, 0x70, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f,
@@ -1814,7 +2522,7 @@ var file_maintner_maintpb_maintner_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
 	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74,
 	0x68, 0x75, 0x62, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x52, 0x0a, 0x6d, 0x69,
-	0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x73, 0x22, 0x9b, 0x0a, 0x0a, 0x13, 0x47, 0x69, 0x74,
+	0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x73, 0x22, 0xae, 0x0c, 0x0a, 0x13, 0x47, 0x69, 0x74,
 	0x68, 0x75, 0x62, 0x49, 0x73, 0x73, 0x75, 0x65, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
 	0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x02,
@@ -1896,161 +2604,297 @@ var file_maintner_maintpb_maintner_proto_rawDesc = []byte{
 	0x75, 0x73, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74,
 	0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x49, 0x73, 0x73, 0x75, 0x65, 0x53, 0x79,
 	0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0c, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x1e, 0x0a, 0x0a, 0x42, 0x6f, 0x6f, 0x6c, 0x43, 0x68,
-	0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x03, 0x76, 0x61, 0x6c, 0x22, 0x20, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x76, 0x61, 0x6c, 0x22, 0x31, 0x0a, 0x0b, 0x47, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x7c, 0x0a, 0x0f, 0x47,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14,
-	0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
-	0x69, 0x74, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x42,
-	0x6f, 0x6f, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65,
-	0x64, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x9f, 0x05, 0x0a, 0x10, 0x47, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x49, 0x73, 0x73, 0x75, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d,
-	0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a,
-	0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x5f, 0x73,
+	0x68, 0x61, 0x18, 0x20, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x53, 0x68,
+	0x61, 0x12, 0x35, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x50, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x49, 0x73,
+	0x73, 0x75, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0a, 0x66,
+	0x69, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x34, 0x0a, 0x09, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x23, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d,
+	0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x52, 0x75, 0x6e, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x75, 0x6e, 0x12,
+	0x48, 0x0a, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x72, 0x75, 0x6e, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x61, 0x69, 0x6e,
+	0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x49, 0x73, 0x73, 0x75, 0x65, 0x53,
+	0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x52, 0x75, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x1e, 0x0a, 0x0a, 0x42, 0x6f, 0x6f,
+	0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x61, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x76, 0x61, 0x6c, 0x22, 0x20, 0x0a, 0x0c, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x61, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x76, 0x61, 0x6c, 0x22, 0x31, 0x0a, 0x0b, 0x47,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x7c,
+	0x0a, 0x0f, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70,
+	0x62, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x63, 0x6c,
+	0x6f, 0x73, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x9f, 0x05, 0x0a,
+	0x10, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x49, 0x73, 0x73, 0x75, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x12, 0x2a, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x36, 0x0a,
+	0x09, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x52, 0x09, 0x6d, 0x69, 0x6c, 0x65,
+	0x73, 0x74, 0x6f, 0x6e, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70,
+	0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65,
+	0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x6e,
+	0x61, 0x6d, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x5f, 0x74, 0x6f, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x54, 0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x11, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x0d, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x72, 0x65,
+	0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d,
+	0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x54, 0x65, 0x61,
+	0x6d, 0x52, 0x0c, 0x74, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x12,
+	0x4e, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x76,
+	0x69, 0x65, 0x77, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6d, 0x61, 0x69, 0x6e,
+	0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x44, 0x69, 0x73, 0x6d, 0x69, 0x73,
+	0x73, 0x65, 0x64, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x0f,
+	0x64, 0x69, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x4a, 0x73, 0x6f, 0x6e, 0x22, 0x82,
+	0x01, 0x0a, 0x1a, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x44, 0x69, 0x73, 0x6d, 0x69, 0x73, 0x73,
+	0x65, 0x64, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x69,
+	0x73, 0x6d, 0x69, 0x73, 0x73, 0x61, 0x6c, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x64, 0x69, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x61, 0x6c,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4a, 0x04, 0x08,
+	0x02, 0x10, 0x03, 0x22, 0x55, 0x0a, 0x0c, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70,
+	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x1b, 0x0a,
+	0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x64, 0x22, 0x82, 0x02, 0x0a, 0x0c, 0x47,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x62, 0x6f, 0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x61, 0x73, 0x73,
+	0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x4a, 0x73, 0x6f, 0x6e, 0x22,
+	0x6a, 0x0a, 0x16, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x50, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x04, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70,
+	0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x50, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0xb4, 0x01, 0x0a, 0x15,
+	0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x50, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x64,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75,
+	0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0xaa, 0x02, 0x0a, 0x0e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x52, 0x75, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x5f, 0x73, 0x68,
+	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x53, 0x68, 0x61,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x61, 0x70, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x34,
+	0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x65, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x55, 0x72, 0x6c, 0x22,
+	0xbb, 0x04, 0x0a, 0x18, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x44, 0x69, 0x73, 0x63, 0x75, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27,
+	0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d,
+	0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x34, 0x0a,
+	0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x62, 0x6f, 0x64,
+	0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70,
+	0x62, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x04,
+	0x62, 0x6f, 0x64, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x12, 0x2b, 0x0a, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x2b, 0x0a,
+	0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x3a, 0x0a, 0x07, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x61,
+	0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x44, 0x69, 0x73, 0x63,
+	0x75, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x45, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x49,
+	0x73, 0x73, 0x75, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0d,
+	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xbb, 0x02,
+	0x0a, 0x17, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x44, 0x69, 0x73, 0x63, 0x75, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x72, 0x65, 0x70,
+	0x6c, 0x79, 0x5f, 0x74, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x72, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x6f, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x04, 0x75, 0x73, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70,
+	0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x55, 0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73,
+	0x65, 0x72, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x2a,
-	0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
-	0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x4c, 0x61,
-	0x62, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x36, 0x0a, 0x09, 0x6d, 0x69,
-	0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
-	0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x4d, 0x69,
-	0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x52, 0x09, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f,
-	0x6e, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x65, 0x5f, 0x69,
-	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65,
-	0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f,
-	0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e,
-	0x65, 0x72, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x09,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x66, 0x72,
-	0x6f, 0x6d, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65,
-	0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x74,
-	0x6f, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54,
-	0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x5f, 0x69, 0x64,
-	0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72,
-	0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x11, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72,
-	0x49, 0x64, 0x12, 0x38, 0x0a, 0x0d, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x65,
-	0x77, 0x65, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x61, 0x69, 0x6e,
-	0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x54, 0x65, 0x61, 0x6d, 0x52, 0x0c,
-	0x74, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x12, 0x4e, 0x0a, 0x10,
-	0x64, 0x69, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77,
-	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62,
-	0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x44, 0x69, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64,
-	0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x0f, 0x64, 0x69, 0x73,
-	0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x1d, 0x0a, 0x0a,
-	0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x09, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x4a, 0x73, 0x6f, 0x6e, 0x22, 0x82, 0x01, 0x0a, 0x1a,
-	0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x44, 0x69, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x52,
-	0x65, 0x76, 0x69, 0x65, 0x77, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65,
-	0x76, 0x69, 0x65, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72,
-	0x65, 0x76, 0x69, 0x65, 0x77, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x69, 0x73, 0x6d, 0x69,
-	0x73, 0x73, 0x61, 0x6c, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x10, 0x64, 0x69, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x61, 0x6c, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03,
-	0x22, 0x55, 0x0a, 0x0c, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
-	0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
-	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x64, 0x22, 0x82, 0x02, 0x0a, 0x0c, 0x47, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x61, 0x63, 0x74, 0x6f,
-	0x72, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x29,
+	0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d,
+	0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x30, 0x0a, 0x09, 0x69, 0x73, 0x5f,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d,
+	0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x08, 0x69, 0x73, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x22, 0x54, 0x0a, 0x15, 0x47,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x49, 0x73, 0x73, 0x75, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x64,
+	0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x61, 0x74,
+	0x65, 0x22, 0xd5, 0x01, 0x0a, 0x1a, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x49, 0x73, 0x73, 0x75,
+	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x27, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x55,
+	0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x34, 0x0a,
+	0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64,
-	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x69, 0x64,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x64,
-	0x12, 0x2b, 0x0a, 0x11, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x61, 0x63, 0x74,
-	0x6f, 0x72, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
-	0x0a, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x09, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x4a, 0x73, 0x6f, 0x6e, 0x22, 0x54, 0x0a, 0x15,
-	0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x49, 0x73, 0x73, 0x75, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f,
-	0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x61,
-	0x74, 0x65, 0x22, 0xd5, 0x01, 0x0a, 0x1a, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x49, 0x73, 0x73,
-	0x75, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x27, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x13, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x55, 0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f,
-	0x64, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x34,
-	0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x32, 0x0a, 0x0a, 0x47, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x69,
-	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x22, 0x30,
-	0x0a, 0x0a, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x54, 0x65, 0x61, 0x6d, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
-	0x73, 0x6c, 0x75, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67,
-	0x22, 0x5f, 0x0a, 0x0b, 0x47, 0x69, 0x74, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x24, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x52,
-	0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x2a, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e,
-	0x47, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x22, 0x22, 0x0a, 0x07, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x17, 0x0a, 0x07,
-	0x67, 0x6f, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67,
-	0x6f, 0x52, 0x65, 0x70, 0x6f, 0x22, 0x64, 0x0a, 0x09, 0x47, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x68, 0x61, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x73, 0x68, 0x61, 0x31, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x31, 0x0a, 0x09, 0x64, 0x69, 0x66, 0x66,
-	0x5f, 0x74, 0x72, 0x65, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x61,
-	0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x44, 0x69, 0x66, 0x66, 0x54, 0x72, 0x65,
-	0x65, 0x52, 0x08, 0x64, 0x69, 0x66, 0x66, 0x54, 0x72, 0x65, 0x65, 0x22, 0x3b, 0x0a, 0x0b, 0x47,
-	0x69, 0x74, 0x44, 0x69, 0x66, 0x66, 0x54, 0x72, 0x65, 0x65, 0x12, 0x2c, 0x0a, 0x04, 0x66, 0x69,
-	0x6c, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74,
-	0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x44, 0x69, 0x66, 0x66, 0x54, 0x72, 0x65, 0x65, 0x46, 0x69,
-	0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x6d, 0x0a, 0x0f, 0x47, 0x69, 0x74, 0x44,
-	0x69, 0x66, 0x66, 0x54, 0x72, 0x65, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66,
-	0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12,
-	0x16, 0x0a, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x22, 0xa0, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x72, 0x72,
-	0x69, 0x74, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f,
-	0x6a, 0x65, 0x63, 0x74, 0x12, 0x2c, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e,
-	0x47, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x73, 0x12, 0x23, 0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65,
-	0x66, 0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x64,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x52, 0x65, 0x66, 0x73, 0x22, 0x2e, 0x0a, 0x06, 0x47, 0x69,
-	0x74, 0x52, 0x65, 0x66, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x68, 0x61, 0x31, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x68, 0x61, 0x31, 0x42, 0x25, 0x5a, 0x23, 0x67, 0x6f,
-	0x6c, 0x61, 0x6e, 0x67, 0x2e, 0x6f, 0x72, 0x67, 0x2f, 0x78, 0x2f, 0x62, 0x75, 0x69, 0x6c, 0x64,
-	0x2f, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x6e, 0x65, 0x72, 0x2f, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70,
-	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x32, 0x0a, 0x0a, 0x47, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x22, 0x30, 0x0a,
+	0x0a, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x54, 0x65, 0x61, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x6c, 0x75, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x22,
+	0x5f, 0x0a, 0x0b, 0x47, 0x69, 0x74, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24,
+	0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d,
+	0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x04,
+	0x72, 0x65, 0x70, 0x6f, 0x12, 0x2a, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47,
+	0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x22, 0x22, 0x0a, 0x07, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x17, 0x0a, 0x07, 0x67,
+	0x6f, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x6f,
+	0x52, 0x65, 0x70, 0x6f, 0x22, 0x64, 0x0a, 0x09, 0x47, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x68, 0x61, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x73, 0x68, 0x61, 0x31, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x31, 0x0a, 0x09, 0x64, 0x69, 0x66, 0x66, 0x5f,
+	0x74, 0x72, 0x65, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x61, 0x69,
+	0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x44, 0x69, 0x66, 0x66, 0x54, 0x72, 0x65, 0x65,
+	0x52, 0x08, 0x64, 0x69, 0x66, 0x66, 0x54, 0x72, 0x65, 0x65, 0x22, 0x3b, 0x0a, 0x0b, 0x47, 0x69,
+	0x74, 0x44, 0x69, 0x66, 0x66, 0x54, 0x72, 0x65, 0x65, 0x12, 0x2c, 0x0a, 0x04, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70,
+	0x62, 0x2e, 0x47, 0x69, 0x74, 0x44, 0x69, 0x66, 0x66, 0x54, 0x72, 0x65, 0x65, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x6d, 0x0a, 0x0f, 0x47, 0x69, 0x74, 0x44, 0x69,
+	0x66, 0x66, 0x54, 0x72, 0x65, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x61,
+	0x64, 0x64, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x22, 0xd4, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x72, 0x72, 0x69,
+	0x74, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f,
+	0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x12, 0x2c, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47,
+	0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x73, 0x12, 0x23, 0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x66,
+	0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x5f, 0x72, 0x65, 0x66, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x52, 0x65, 0x66, 0x73, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x61,
+	0x69, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x72, 0x72, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xef, 0x02,
+	0x0a, 0x0d, 0x47, 0x65, 0x72, 0x72, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x74, 0x61, 0x5f, 0x73, 0x68, 0x61, 0x31, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x53, 0x68, 0x61, 0x31, 0x12, 0x12, 0x0a, 0x04,
+	0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64,
+	0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x55, 0x75, 0x69,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x12, 0x15,
+	0x0a, 0x06, 0x72, 0x65, 0x76, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x72, 0x65, 0x76, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x34, 0x0a, 0x07, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x77, 0x72,
+	0x69, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1e, 0x0a, 0x0a, 0x75, 0x6e, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0a, 0x75, 0x6e, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x22,
+	0x2e, 0x0a, 0x06, 0x47, 0x69, 0x74, 0x52, 0x65, 0x66, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x68, 0x61, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x68, 0x61, 0x31, 0x42,
+	0x25, 0x5a, 0x23, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2e, 0x6f, 0x72, 0x67, 0x2f, 0x78, 0x2f,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x6e, 0x65, 0x72, 0x2f, 0x6d,
+	0x61, 0x69, 0x6e, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2065,7 +2909,7 @@ func file_maintner_maintpb_maintner_proto_rawDescGZIP() []byte {
 	return file_maintner_maintpb_maintner_proto_rawDescData
 }
 
-var file_maintner_maintpb_maintner_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_maintner_maintpb_maintner_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_maintner_maintpb_maintner_proto_goTypes = []interface{}{
 	(*Mutation)(nil),                   // 0: maintpb.Mutation
 	(*GithubMutation)(nil),             // 1: maintpb.GithubMutation
@@ -2078,65 +2922,94 @@ var file_maintner_maintpb_maintner_proto_goTypes = []interface{}{
 	(*GithubDismissedReviewEvent)(nil), // 8: maintpb.GithubDismissedReviewEvent
 	(*GithubCommit)(nil),               // 9: maintpb.GithubCommit
 	(*GithubReview)(nil),               // 10: maintpb.GithubReview
-	(*GithubIssueSyncStatus)(nil),      // 11: maintpb.GithubIssueSyncStatus
-	(*GithubIssueCommentMutation)(nil), // 12: maintpb.GithubIssueCommentMutation
-	(*GithubUser)(nil),                 // 13: maintpb.GithubUser
-	(*GithubTeam)(nil),                 // 14: maintpb.GithubTeam
-	(*GitMutation)(nil),                // 15: maintpb.GitMutation
-	(*GitRepo)(nil),                    // 16: maintpb.GitRepo
-	(*GitCommit)(nil),                  // 17: maintpb.GitCommit
-	(*GitDiffTree)(nil),                // 18: maintpb.GitDiffTree
-	(*GitDiffTreeFile)(nil),            // 19: maintpb.GitDiffTreeFile
-	(*GerritMutation)(nil),             // 20: maintpb.GerritMutation
-	(*GitRef)(nil),                     // 21: maintpb.GitRef
-	(*timestamppb.Timestamp)(nil),      // 22: google.protobuf.Timestamp
+	(*GithubPullRequestFiles)(nil),     // 11: maintpb.GithubPullRequestFiles
+	(*GithubPullRequestFile)(nil),      // 12: maintpb.GithubPullRequestFile
+	(*GithubCheckRun)(nil),             // 13: maintpb.GithubCheckRun
+	(*GithubDiscussionMutation)(nil),   // 14: maintpb.GithubDiscussionMutation
+	(*GithubDiscussionComment)(nil),    // 15: maintpb.GithubDiscussionComment
+	(*GithubIssueSyncStatus)(nil),      // 16: maintpb.GithubIssueSyncStatus
+	(*GithubIssueCommentMutation)(nil), // 17: maintpb.GithubIssueCommentMutation
+	(*GithubUser)(nil),                 // 18: maintpb.GithubUser
+	(*GithubTeam)(nil),                 // 19: maintpb.GithubTeam
+	(*GitMutation)(nil),                // 20: maintpb.GitMutation
+	(*GitRepo)(nil),                    // 21: maintpb.GitRepo
+	(*GitCommit)(nil),                  // 22: maintpb.GitCommit
+	(*GitDiffTree)(nil),                // 23: maintpb.GitDiffTree
+	(*GitDiffTreeFile)(nil),            // 24: maintpb.GitDiffTreeFile
+	(*GerritMutation)(nil),             // 25: maintpb.GerritMutation
+	(*GerritComment)(nil),              // 26: maintpb.GerritComment
+	(*GitRef)(nil),                     // 27: maintpb.GitRef
+	(*timestamppb.Timestamp)(nil),      // 28: google.protobuf.Timestamp
 }
 var file_maintner_maintpb_maintner_proto_depIdxs = []int32{
 	2,  // 0: maintpb.Mutation.github_issue:type_name -> maintpb.GithubIssueMutation
 	1,  // 1: maintpb.Mutation.github:type_name -> maintpb.GithubMutation
-	15, // 2: maintpb.Mutation.git:type_name -> maintpb.GitMutation
-	20, // 3: maintpb.Mutation.gerrit:type_name -> maintpb.GerritMutation
-	5,  // 4: maintpb.GithubMutation.labels:type_name -> maintpb.GithubLabel
-	6,  // 5: maintpb.GithubMutation.milestones:type_name -> maintpb.GithubMilestone
-	13, // 6: maintpb.GithubIssueMutation.user:type_name -> maintpb.GithubUser
-	13, // 7: maintpb.GithubIssueMutation.assignees:type_name -> maintpb.GithubUser
-	22, // 8: maintpb.GithubIssueMutation.created:type_name -> google.protobuf.Timestamp
-	22, // 9: maintpb.GithubIssueMutation.updated:type_name -> google.protobuf.Timestamp
-	4,  // 10: maintpb.GithubIssueMutation.body_change:type_name -> maintpb.StringChange
-	3,  // 11: maintpb.GithubIssueMutation.closed:type_name -> maintpb.BoolChange
-	3,  // 12: maintpb.GithubIssueMutation.locked:type_name -> maintpb.BoolChange
-	22, // 13: maintpb.GithubIssueMutation.closed_at:type_name -> google.protobuf.Timestamp
-	13, // 14: maintpb.GithubIssueMutation.closed_by:type_name -> maintpb.GithubUser
-	5,  // 15: maintpb.GithubIssueMutation.add_label:type_name -> maintpb.GithubLabel
-	12, // 16: maintpb.GithubIssueMutation.comment:type_name -> maintpb.GithubIssueCommentMutation
-	11, // 17: maintpb.GithubIssueMutation.comment_status:type_name -> maintpb.GithubIssueSyncStatus
-	7,  // 18: maintpb.GithubIssueMutation.event:type_name -> maintpb.GithubIssueEvent
-	11, // 19: maintpb.GithubIssueMutation.event_status:type_name -> maintpb.GithubIssueSyncStatus
-	10, // 20: maintpb.GithubIssueMutation.review:type_name -> maintpb.GithubReview
-	11, // 21: maintpb.GithubIssueMutation.review_status:type_name -> maintpb.GithubIssueSyncStatus
-	3,  // 22: maintpb.GithubMilestone.closed:type_name -> maintpb.BoolChange
-	22, // 23: maintpb.GithubIssueEvent.created:type_name -> google.protobuf.Timestamp
-	5,  // 24: maintpb.GithubIssueEvent.label:type_name -> maintpb.GithubLabel
-	6,  // 25: maintpb.GithubIssueEvent.milestone:type_name -> maintpb.GithubMilestone
-	9,  // 26: maintpb.GithubIssueEvent.commit:type_name -> maintpb.GithubCommit
-	14, // 27: maintpb.GithubIssueEvent.team_reviewer:type_name -> maintpb.GithubTeam
-	8,  // 28: maintpb.GithubIssueEvent.dismissed_review:type_name -> maintpb.GithubDismissedReviewEvent
-	22, // 29: maintpb.GithubReview.created:type_name -> google.protobuf.Timestamp
-	22, // 30: maintpb.GithubIssueSyncStatus.server_date:type_name -> google.protobuf.Timestamp
-	13, // 31: maintpb.GithubIssueCommentMutation.user:type_name -> maintpb.GithubUser
-	22, // 32: maintpb.GithubIssueCommentMutation.created:type_name -> google.protobuf.Timestamp
-	22, // 33: maintpb.GithubIssueCommentMutation.updated:type_name -> google.protobuf.Timestamp
-	16, // 34: maintpb.GitMutation.repo:type_name -> maintpb.GitRepo
-	17, // 35: maintpb.GitMutation.commit:type_name -> maintpb.GitCommit
-	18, // 36: maintpb.GitCommit.diff_tree:type_name -> maintpb.GitDiffTree
-	19, // 37: maintpb.GitDiffTree.file:type_name -> maintpb.GitDiffTreeFile
-	17, // 38: maintpb.GerritMutation.commits:type_name -> maintpb.GitCommit
-	21, // 39: maintpb.GerritMutation.refs:type_name -> maintpb.GitRef
-	40, // [40:40] is the sub-list for method output_type
-	40, // [40:40] is the sub-list for method input_type
-	40, // [40:40] is the sub-list for extension type_name
-	40, // [40:40] is the sub-list for extension extendee
-	0,  // [0:40] is the sub-list for field type_name
+	20, // 2: maintpb.Mutation.git:type_name -> maintpb.GitMutation
+	25, // 3: maintpb.Mutation.gerrit:type_name -> maintpb.GerritMutation
+	14, // 4: maintpb.Mutation.github_discussion:type_name -> maintpb.GithubDiscussionMutation
+	5,  // 5: maintpb.GithubMutation.labels:type_name -> maintpb.GithubLabel
+	6,  // 6: maintpb.GithubMutation.milestones:type_name -> maintpb.GithubMilestone
+	18, // 7: maintpb.GithubIssueMutation.user:type_name -> maintpb.GithubUser
+	18, // 8: maintpb.GithubIssueMutation.assignees:type_name -> maintpb.GithubUser
+	28, // 9: maintpb.GithubIssueMutation.created:type_name -> google.protobuf.Timestamp
+	28, // 10: maintpb.GithubIssueMutation.updated:type_name -> google.protobuf.Timestamp
+	4,  // 11: maintpb.GithubIssueMutation.body_change:type_name -> maintpb.StringChange
+	3,  // 12: maintpb.GithubIssueMutation.closed:type_name -> maintpb.BoolChange
+	3,  // 13: maintpb.GithubIssueMutation.locked:type_name -> maintpb.BoolChange
+	28, // 14: maintpb.GithubIssueMutation.closed_at:type_name -> google.protobuf.Timestamp
+	18, // 15: maintpb.GithubIssueMutation.closed_by:type_name -> maintpb.GithubUser
+	5,  // 16: maintpb.GithubIssueMutation.add_label:type_name -> maintpb.GithubLabel
+	17, // 17: maintpb.GithubIssueMutation.comment:type_name -> maintpb.GithubIssueCommentMutation
+	16, // 18: maintpb.GithubIssueMutation.comment_status:type_name -> maintpb.GithubIssueSyncStatus
+	7,  // 19: maintpb.GithubIssueMutation.event:type_name -> maintpb.GithubIssueEvent
+	16, // 20: maintpb.GithubIssueMutation.event_status:type_name -> maintpb.GithubIssueSyncStatus
+	10, // 21: maintpb.GithubIssueMutation.review:type_name -> maintpb.GithubReview
+	16, // 22: maintpb.GithubIssueMutation.review_status:type_name -> maintpb.GithubIssueSyncStatus
+	11, // 23: maintpb.GithubIssueMutation.files:type_name -> maintpb.GithubPullRequestFiles
+	16, // 24: maintpb.GithubIssueMutation.file_status:type_name -> maintpb.GithubIssueSyncStatus
+	13, // 25: maintpb.GithubIssueMutation.check_run:type_name -> maintpb.GithubCheckRun
+	16, // 26: maintpb.GithubIssueMutation.check_run_status:type_name -> maintpb.GithubIssueSyncStatus
+	3,  // 27: maintpb.GithubMilestone.closed:type_name -> maintpb.BoolChange
+	28, // 28: maintpb.GithubIssueEvent.created:type_name -> google.protobuf.Timestamp
+	5,  // 29: maintpb.GithubIssueEvent.label:type_name -> maintpb.GithubLabel
+	6,  // 30: maintpb.GithubIssueEvent.milestone:type_name -> maintpb.GithubMilestone
+	9,  // 31: maintpb.GithubIssueEvent.commit:type_name -> maintpb.GithubCommit
+	19, // 32: maintpb.GithubIssueEvent.team_reviewer:type_name -> maintpb.GithubTeam
+	8,  // 33: maintpb.GithubIssueEvent.dismissed_review:type_name -> maintpb.GithubDismissedReviewEvent
+	28, // 34: maintpb.GithubReview.created:type_name -> google.protobuf.Timestamp
+	12, // 35: maintpb.GithubPullRequestFiles.file:type_name -> maintpb.GithubPullRequestFile
+	28, // 36: maintpb.GithubCheckRun.started:type_name -> google.protobuf.Timestamp
+	28, // 37: maintpb.GithubCheckRun.completed:type_name -> google.protobuf.Timestamp
+	18, // 38: maintpb.GithubDiscussionMutation.user:type_name -> maintpb.GithubUser
+	28, // 39: maintpb.GithubDiscussionMutation.created:type_name -> google.protobuf.Timestamp
+	28, // 40: maintpb.GithubDiscussionMutation.updated:type_name -> google.protobuf.Timestamp
+	4,  // 41: maintpb.GithubDiscussionMutation.body:type_name -> maintpb.StringChange
+	3,  // 42: maintpb.GithubDiscussionMutation.closed:type_name -> maintpb.BoolChange
+	3,  // 43: maintpb.GithubDiscussionMutation.locked:type_name -> maintpb.BoolChange
+	15, // 44: maintpb.GithubDiscussionMutation.comment:type_name -> maintpb.GithubDiscussionComment
+	16, // 45: maintpb.GithubDiscussionMutation.comment_status:type_name -> maintpb.GithubIssueSyncStatus
+	18, // 46: maintpb.GithubDiscussionComment.user:type_name -> maintpb.GithubUser
+	28, // 47: maintpb.GithubDiscussionComment.created:type_name -> google.protobuf.Timestamp
+	28, // 48: maintpb.GithubDiscussionComment.updated:type_name -> google.protobuf.Timestamp
+	4,  // 49: maintpb.GithubDiscussionComment.body:type_name -> maintpb.StringChange
+	3,  // 50: maintpb.GithubDiscussionComment.is_answer:type_name -> maintpb.BoolChange
+	28, // 51: maintpb.GithubIssueSyncStatus.server_date:type_name -> google.protobuf.Timestamp
+	18, // 52: maintpb.GithubIssueCommentMutation.user:type_name -> maintpb.GithubUser
+	28, // 53: maintpb.GithubIssueCommentMutation.created:type_name -> google.protobuf.Timestamp
+	28, // 54: maintpb.GithubIssueCommentMutation.updated:type_name -> google.protobuf.Timestamp
+	21, // 55: maintpb.GitMutation.repo:type_name -> maintpb.GitRepo
+	22, // 56: maintpb.GitMutation.commit:type_name -> maintpb.GitCommit
+	23, // 57: maintpb.GitCommit.diff_tree:type_name -> maintpb.GitDiffTree
+	24, // 58: maintpb.GitDiffTree.file:type_name -> maintpb.GitDiffTreeFile
+	22, // 59: maintpb.GerritMutation.commits:type_name -> maintpb.GitCommit
+	27, // 60: maintpb.GerritMutation.refs:type_name -> maintpb.GitRef
+	26, // 61: maintpb.GerritMutation.comments:type_name -> maintpb.GerritComment
+	28, // 62: maintpb.GerritComment.written:type_name -> google.protobuf.Timestamp
+	63, // [63:63] is the sub-list for method output_type
+	63, // [63:63] is the sub-list for method input_type
+	63, // [63:63] is the sub-list for extension type_name
+	63, // [63:63] is the sub-list for extension extendee
+	0,  // [0:63] is the sub-list for field type_name
 }
 
 func init() { file_maintner_maintpb_maintner_proto_init() }
@@ -2278,7 +3151,7 @@ func file_maintner_maintpb_maintner_proto_init() {
 			}
 		}
 		file_maintner_maintpb_maintner_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GithubIssueSyncStatus); i {
+			switch v := v.(*GithubPullRequestFiles); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2290,7 +3163,7 @@ func file_maintner_maintpb_maintner_proto_init() {
 			}
 		}
 		file_maintner_maintpb_maintner_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GithubIssueCommentMutation); i {
+			switch v := v.(*GithubPullRequestFile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2302,7 +3175,7 @@ func file_maintner_maintpb_maintner_proto_init() {
 			}
 		}
 		file_maintner_maintpb_maintner_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GithubUser); i {
+			switch v := v.(*GithubCheckRun); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2314,7 +3187,7 @@ func file_maintner_maintpb_maintner_proto_init() {
 			}
 		}
 		file_maintner_maintpb_maintner_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GithubTeam); i {
+			switch v := v.(*GithubDiscussionMutation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2326,7 +3199,7 @@ func file_maintner_maintpb_maintner_proto_init() {
 			}
 		}
 		file_maintner_maintpb_maintner_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GitMutation); i {
+			switch v := v.(*GithubDiscussionComment); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2338,7 +3211,7 @@ func file_maintner_maintpb_maintner_proto_init() {
 			}
 		}
 		file_maintner_maintpb_maintner_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GitRepo); i {
+			switch v := v.(*GithubIssueSyncStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2350,7 +3223,7 @@ func file_maintner_maintpb_maintner_proto_init() {
 			}
 		}
 		file_maintner_maintpb_maintner_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GitCommit); i {
+			switch v := v.(*GithubIssueCommentMutation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2362,7 +3235,7 @@ func file_maintner_maintpb_maintner_proto_init() {
 			}
 		}
 		file_maintner_maintpb_maintner_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GitDiffTree); i {
+			switch v := v.(*GithubUser); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2374,7 +3247,7 @@ func file_maintner_maintpb_maintner_proto_init() {
 			}
 		}
 		file_maintner_maintpb_maintner_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GitDiffTreeFile); i {
+			switch v := v.(*GithubTeam); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2386,7 +3259,7 @@ func file_maintner_maintpb_maintner_proto_init() {
 			}
 		}
 		file_maintner_maintpb_maintner_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GerritMutation); i {
+			switch v := v.(*GitMutation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2398,6 +3271,78 @@ func file_maintner_maintpb_maintner_proto_init() {
 			}
 		}
 		file_maintner_maintpb_maintner_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GitRepo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_maintner_maintpb_maintner_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GitCommit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_maintner_maintpb_maintner_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GitDiffTree); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_maintner_maintpb_maintner_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GitDiffTreeFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_maintner_maintpb_maintner_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GerritMutation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_maintner_maintpb_maintner_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GerritComment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_maintner_maintpb_maintner_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GitRef); i {
 			case 0:
 				return &v.state
@@ -2416,7 +3361,7 @@ func file_maintner_maintpb_maintner_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_maintner_maintpb_maintner_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   22,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -59,6 +59,7 @@ func main() {
 		"try-work":      callTryWork,
 		"list-releases": callListReleases,
 		"get-dashboard": callGetDashboard,
+		"query":         callQuery,
 	}
 	log.SetFlags(0)
 	if flag.NArg() == 0 || cmdFunc[flag.Arg(0)] == nil {
@@ -156,3 +157,41 @@ func callGetDashboard(args []string) error {
 	fmt.Print(prototext.Format(res))
 	return nil
 }
+
+func callQuery(args []string) error {
+	fs := flag.NewFlagSet("query", flag.ExitOnError)
+	limit := fs.Int("limit", 0, "maximum number of results; 0 means the server's default; at most 1000")
+	asProto := fs.Bool("proto", false, "print the full response in protobuf text format")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: maintq query [-limit=N] [-proto] <query>")
+		fmt.Fprintln(os.Stderr, "For example: maintq query repo:golang/go is:open label:NeedsFix updated:<30d")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	res, err := mc.Query(ctx, &apipb.QueryRequest{
+		Query: strings.Join(fs.Args(), " "),
+		Limit: int32(*limit),
+	})
+	if err != nil {
+		return err
+	}
+	if *asProto {
+		fmt.Print(prototext.Format(res))
+		return nil
+	}
+	for _, r := range res.Results {
+		if r.GithubRepo != "" {
+			fmt.Printf("%s#%d\t%s\t%s\n", r.GithubRepo, r.Number, r.Status, r.Title)
+		} else {
+			fmt.Printf("%s/%d\t%s\t%s\n", r.GerritProject, r.Number, r.Status, r.Title)
+		}
+	}
+	if res.Truncated {
+		fmt.Fprintf(os.Stderr, "(truncated after %d results)\n", len(res.Results))
+	}
+	return nil
+}
@@ -138,8 +138,9 @@ type netMutSource struct {
 	base     *url.URL
 	cacheDir string
 
-	last  []fileSeg
-	quiet bool // disable verbose logging
+	last    []fileSeg
+	resumed bool // last is from resumeFrom and hasn't been checked against the server yet
+	quiet   bool // disable verbose logging
 
 	// Hooks for testing. If nil, unused:
 	testHookGetServerSegments func(context.Context, int64) ([]LogSegmentJSON, error)
@@ -316,14 +317,34 @@ func (ns *netMutSource) getServerSegments(ctx context.Context, waitSizeNot int64
 // for internet connectivity to come back and keeps going when it does.
 func (ns *netMutSource) getNewSegments(ctx context.Context) ([]fileSeg, error) {
 	sumLast := sumSegSize(ns.last)
+	resumed := ns.resumed
+	ns.resumed = false
+	waitSizeNot := sumLast
+	if resumed {
+		// The log may not have grown since the position we're
+		// resuming from was recorded, so don't wait for it to.
+		waitSizeNot = 0
+	}
 
 	// First, fetch JSON metadata for the segments from the server.
 	var serverSegs []LogSegmentJSON
 	for try := 1; ; {
-		segs, err := ns.getServerSegments(ctx, sumLast)
+		segs, err := ns.getServerSegments(ctx, waitSizeNot)
 		if isNoInternetError(err) {
 			if sumLast == 0 {
-				return ns.locallyCachedSegments()
+				segs, err := ns.locallyCachedSegments()
+				if err != nil {
+					return nil, err
+				}
+				ns.last = segs
+				return segs, nil
+			}
+			if resumed {
+				cached, err := ns.locallyCachedSegments()
+				if err != nil {
+					return nil, err
+				}
+				return ns.segmentsAfterLast(cached, resumed)
 			}
 			log.Printf("No internet; blocking.")
 			select {
@@ -401,8 +422,14 @@ func (ns *netMutSource) getNewSegments(ctx context.Context) ([]fileSeg, error) {
 		}
 	}
 
-	// Verify consistency of newly fetched data,
-	// and check there is in fact something new.
+	return ns.segmentsAfterLast(fileSegs, resumed)
+}
+
+// segmentsAfterLast verifies that fileSegs, the current segments of the
+// log, extend ns.last, and returns the parts of them that are new.
+// If resumed is false, it's an error for there to be nothing new.
+func (ns *netMutSource) segmentsAfterLast(fileSegs []fileSeg, resumed bool) ([]fileSeg, error) {
+	sumLast := sumSegSize(ns.last)
 	sumCommon := ns.sumCommonPrefixSize(fileSegs, ns.last)
 	if sumCommon != sumLast {
 		if fn := ns.testHookOnSplit; fn != nil {
@@ -411,6 +438,10 @@ func (ns *netMutSource) getNewSegments(ctx context.Context) ([]fileSeg, error) {
 		// Our history diverged from the source.
 		return nil, ErrSplit
 	} else if sumCur := sumSegSize(fileSegs); sumCommon == sumCur {
+		if resumed {
+			// Nothing new since the position we resumed from.
+			return nil, nil
+		}
 		// Nothing new. This shouldn't happen since the maintnerd server is required to handle
 		// the "?waitsizenot=NNN" long polling parameter, so it's a problem if we get here.
 		return nil, fmt.Errorf("maintner.netsource: maintnerd server returned unchanged log segments")
@@ -421,6 +452,35 @@ func (ns *netMutSource) getNewSegments(ctx context.Context) ([]fileSeg, error) {
 	return newSegs, nil
 }
 
+func (ns *netMutSource) logPosition() (*logPosition, error) {
+	pos := &logPosition{Source: "network"}
+	for _, seg := range ns.last {
+		pos.Segments = append(pos.Segments, logSegment{Number: seg.seg, Size: seg.size, SHA224: seg.sha224})
+	}
+	return pos, nil
+}
+
+func (ns *netMutSource) resumeFrom(pos *logPosition) error {
+	ns.last, ns.resumed = nil, false
+	if pos == nil {
+		return nil
+	}
+	if pos.Source != "network" {
+		return fmt.Errorf("maintner: can't resume a network log from a %s log position", pos.Source)
+	}
+	for _, seg := range pos.Segments {
+		// The cache file is only read when checking for a split, if
+		// the server's copy of the segment is now shorter than ours.
+		file := filepath.Join(ns.cacheDir, fmt.Sprintf("%04d.%s.mutlog", seg.Number, seg.SHA224))
+		if _, err := os.Stat(file); err != nil {
+			file = filepath.Join(ns.cacheDir, fmt.Sprintf("%04d.growing.mutlog", seg.Number))
+		}
+		ns.last = append(ns.last, fileSeg{seg: seg.Number, file: file, sha224: seg.SHA224, size: seg.Size})
+	}
+	ns.resumed = true
+	return nil
+}
+
 func trimLeadingSegBytes(in []fileSeg, trim int64) []fileSeg {
 	// First trim off whole segments, sharing the same underlying memory.
 	for len(in) > 0 && trim >= in[0].size {
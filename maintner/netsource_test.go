@@ -149,6 +149,7 @@ func TestGetNewSegments(t *testing.T) {
 	type testCase struct {
 		name       string
 		lastSegs   []fileSeg
+		resumed    bool // lastSegs are from a snapshot
 		serverSegs [][]LogSegmentJSON
 
 		// prefixSum is the prefix sum to use if called.
@@ -243,6 +244,35 @@ func TestGetNewSegments(t *testing.T) {
 			},
 			wantUnchanged: true,
 		},
+		{
+			name: "resumed_unchanged", // resuming from a snapshot of the whole log
+			lastSegs: []fileSeg{
+				{seg: 1, size: 100, sha224: "abc", file: "/fake/0001.mutlog"},
+			},
+			resumed: true,
+			serverSegs: [][]LogSegmentJSON{
+				[]LogSegmentJSON{
+					{Number: 1, Size: 100, SHA224: "abc"},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "resumed_growseg",
+			lastSegs: []fileSeg{
+				{seg: 1, size: 100, sha224: "abc", file: "/fake/0001.mutlog"},
+			},
+			resumed:   true,
+			prefixSum: "abc",
+			serverSegs: [][]LogSegmentJSON{
+				[]LogSegmentJSON{
+					{Number: 1, Size: 150, SHA224: "abcabc"},
+				},
+			},
+			want: []fileSeg{
+				{seg: 1, size: 150, sha224: "abcabc", skip: 100, file: "/fake/0001.mutlog"},
+			},
+		},
 		{
 			name: "split_error_diff_first_seg_same_size",
 			lastSegs: []fileSeg{
@@ -317,8 +347,12 @@ func TestGetNewSegments(t *testing.T) {
 			serverSegCalls := 0
 			syncSegCalls := 0
 			ns := &netMutSource{
-				last: tt.lastSegs,
+				last:    tt.lastSegs,
+				resumed: tt.resumed,
 				testHookGetServerSegments: func(_ context.Context, waitSizeNot int64) (segs []LogSegmentJSON, err error) {
+					if tt.resumed && waitSizeNot != 0 {
+						t.Errorf("resumed getServerSegments waits for size not %d; want 0", waitSizeNot)
+					}
 					serverSegCalls++
 					if serverSegCalls%2 == 1 {
 						return nil, fetchError{PossiblyRetryable: true, Err: fmt.Errorf("fake error to simulate the internet saying 'not this time' every now and then")}
@@ -0,0 +1,626 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Query selects GitHub issues, GitHub pull requests and Gerrit CLs
+// from a Corpus. Use ParseQuery to create one and Corpus.ForeachMatch
+// to evaluate it.
+type Query struct {
+	src   string
+	terms []queryTerm
+}
+
+// queryTerm is a single, possibly negated, term of a Query.
+type queryTerm struct {
+	negate bool
+	key    string // "" for a text term
+	value  string // lowercased, except for branch:
+
+	// For created: and updated: terms.
+	op   string        // "<", "<=", ">", ">=", or "" for "on that day"
+	date time.Time     // if age is zero
+	age  time.Duration // if non-zero, compared against the current time
+}
+
+// String returns the query as it was parsed.
+func (q *Query) String() string { return q.src }
+
+// ParseQuery parses a query such as
+//
+//	repo:golang/go is:open label:NeedsFix milestone:Go1.24 updated:<30d
+//
+// A query is a sequence of space-separated terms, all of which must
+// match. A term prefixed with "-" must not match. Values containing
+// spaces may be double-quoted, as in label:"help wanted". The terms are:
+//
+//	repo:owner/name    GitHub issues and pull requests in that repo
+//	project:name       Gerrit CLs in that project ("go", or "go.googlesource.com/go")
+//	is:open            open issues and pull requests, and new CLs
+//	is:closed          closed issues and pull requests, and merged or abandoned CLs
+//	is:issue           GitHub issues that aren't pull requests
+//	is:pr              GitHub pull requests
+//	is:cl              Gerrit CLs
+//	is:merged          merged CLs, the same as status:merged
+//	is:abandoned       abandoned CLs, the same as status:abandoned
+//	label:name         GitHub issues with that label
+//	milestone:title    GitHub issues in that milestone; milestone:none for none
+//	assignee:login     GitHub issues assigned to that user; assignee:none for none
+//	author:login       GitHub issues opened by that user
+//	owner:email        Gerrit CLs owned by that email address
+//	status:status      Gerrit CLs with that status ("new", "merged", "abandoned")
+//	branch:name        Gerrit CLs for that branch ("master")
+//	created:[op]when   items created before or after a time
+//	updated:[op]when   items last updated before or after a time
+//	word               items whose title or CL subject contains word
+//
+// Matching is case-insensitive, except for branch names. Terms that
+// don't apply to a kind of item never match it, so "label:NeedsFix"
+// matches no CLs and "-label:NeedsFix" matches all of them.
+//
+// For created: and updated:, when is either a date (2006-01-02, in
+// UTC) or an age: a number followed by h, d or w, for hours, days or
+// weeks. The op is one of <, <=, > or >=, and compares times for a
+// date and ages for an age, so updated:<2024-01-01 matches items
+// last updated before 2024 and updated:<30d matches items updated
+// within the last 30 days. A date without an op matches that day.
+func ParseQuery(s string) (*Query, error) {
+	q := &Query{src: s}
+	words, err := splitQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range words {
+		t, err := parseQueryTerm(w)
+		if err != nil {
+			return nil, err
+		}
+		q.terms = append(q.terms, t)
+	}
+	return q, nil
+}
+
+// splitQuery splits s into space-separated words, removing double
+// quotes around any part of a word.
+func splitQuery(s string) ([]string, error) {
+	var words []string
+	var w strings.Builder
+	inWord, inQuote := false, false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inWord, inQuote = true, !inQuote
+		case r == ' ' && !inQuote:
+			if inWord {
+				words = append(words, w.String())
+				w.Reset()
+				inWord = false
+			}
+		default:
+			w.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inQuote {
+		return nil, errors.New("unterminated quote in query")
+	}
+	if inWord {
+		words = append(words, w.String())
+	}
+	return words, nil
+}
+
+var isValues = map[string]bool{
+	"open":      true,
+	"closed":    true,
+	"issue":     true,
+	"pr":        true,
+	"cl":        true,
+	"merged":    true,
+	"abandoned": true,
+}
+
+func parseQueryTerm(w string) (queryTerm, error) {
+	var t queryTerm
+	if strings.HasPrefix(w, "-") && len(w) > 1 {
+		t.negate = true
+		w = w[1:]
+	}
+	key, value, ok := strings.Cut(w, ":")
+	if !ok || strings.ContainsFunc(key, func(r rune) bool { return r < 'a' || r > 'z' }) {
+		// A word like "net/http:" is text, not a key.
+		t.value = strings.ToLower(w)
+		return t, nil
+	}
+	if value == "" {
+		return t, fmt.Errorf("empty value in query term %q", w)
+	}
+	t.key = key
+	switch key {
+	case "repo", "project", "label", "milestone", "assignee", "author", "owner", "status":
+		t.value = strings.ToLower(value)
+	case "branch":
+		t.value = strings.TrimPrefix(value, "refs/heads/")
+	case "is":
+		t.value = strings.ToLower(value)
+		if !isValues[t.value] {
+			return t, fmt.Errorf("unknown value in query term %q", w)
+		}
+	case "created", "updated":
+		if err := t.parseTime(value); err != nil {
+			return t, fmt.Errorf("query term %q: %v", w, err)
+		}
+	default:
+		return t, fmt.Errorf("unknown key in query term %q", w)
+	}
+	return t, nil
+}
+
+// parseTime parses the value of a created: or updated: term.
+func (t *queryTerm) parseTime(v string) error {
+	for _, op := range []string{"<=", ">=", "<", ">"} {
+		if strings.HasPrefix(v, op) {
+			t.op, v = op, v[len(op):]
+			break
+		}
+	}
+	if d, err := time.Parse(time.DateOnly, v); err == nil {
+		t.date = d
+		return nil
+	}
+	if len(v) < 2 {
+		return fmt.Errorf("invalid time %q", v)
+	}
+	n, err := strconv.Atoi(v[:len(v)-1])
+	if err != nil || n <= 0 {
+		return fmt.Errorf("invalid time %q", v)
+	}
+	switch v[len(v)-1] {
+	case 'h':
+		t.age = time.Duration(n) * time.Hour
+	case 'd':
+		t.age = time.Duration(n) * 24 * time.Hour
+	case 'w':
+		t.age = time.Duration(n) * 7 * 24 * time.Hour
+	default:
+		return fmt.Errorf("invalid time %q", v)
+	}
+	if t.op == "" {
+		return fmt.Errorf("age %q needs one of <, <=, > or >=", v)
+	}
+	return nil
+}
+
+// matchTime reports whether tm matches a created: or updated: term.
+func (t *queryTerm) matchTime(tm, now time.Time) bool {
+	if tm.IsZero() {
+		return false
+	}
+	if t.age != 0 {
+		age := now.Sub(tm)
+		switch t.op {
+		case "<":
+			return age < t.age
+		case "<=":
+			return age <= t.age
+		case ">":
+			return age > t.age
+		default: // ">="
+			return age >= t.age
+		}
+	}
+	next := t.date.AddDate(0, 0, 1)
+	switch t.op {
+	case "<":
+		return tm.Before(t.date)
+	case "<=":
+		return tm.Before(next)
+	case ">":
+		return !tm.Before(next)
+	case ">=":
+		return !tm.Before(t.date)
+	default:
+		return !tm.Before(t.date) && tm.Before(next)
+	}
+}
+
+// issueOnly and clOnly report whether the term can only match GitHub
+// issues or Gerrit CLs, respectively, when not negated.
+func (t *queryTerm) issueOnly() bool {
+	switch t.key {
+	case "repo", "label", "milestone", "assignee", "author":
+		return true
+	case "is":
+		return t.value == "issue" || t.value == "pr"
+	}
+	return false
+}
+
+func (t *queryTerm) clOnly() bool {
+	switch t.key {
+	case "project", "owner", "status", "branch":
+		return true
+	case "is":
+		return t.value == "cl" || t.value == "merged" || t.value == "abandoned"
+	}
+	return false
+}
+
+// A QueryMatch is an item matched by a Query: either a GitHub issue
+// or pull request, or a Gerrit CL.
+type QueryMatch struct {
+	Repo  *GitHubRepo  // the repo of Issue, if Issue is non-nil
+	Issue *GitHubIssue // a GitHub issue or pull request
+	CL    *GerritCL    // a Gerrit CL
+}
+
+// ForeachMatch calls fn for each GitHub issue, GitHub pull request and
+// Gerrit CL in the corpus that matches q. Issues and pull requests are
+// visited first, sorted by repo and number, followed by CLs, sorted by
+// project and number. Issues that don't exist and CLs that are private
+// or incomplete are never visited.
+//
+// If fn returns an error, iteration ends and ForeachMatch returns the
+// error.
+//
+// Queries use secondary indexes, which are built by the first call to
+// ForeachMatch and kept up to date as the corpus is updated. As with
+// the corpus's other accessors, callers that update the corpus
+// concurrently must hold its read lock; see RLock.
+func (c *Corpus) ForeachMatch(q *Query, fn func(QueryMatch) error) error {
+	issues, cls := c.queryCandidates(q)
+	now := time.Now()
+
+	var matches []QueryMatch
+	for gi, gr := range issues {
+		if q.matchIssue(gr, gi, now) {
+			matches = append(matches, QueryMatch{Repo: gr, Issue: gi})
+		}
+	}
+	for cl := range cls {
+		if q.matchCL(cl, now) {
+			matches = append(matches, QueryMatch{CL: cl})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if (a.Issue != nil) != (b.Issue != nil) {
+			return a.Issue != nil
+		}
+		if a.Issue != nil {
+			if ai, bi := a.Repo.ID().String(), b.Repo.ID().String(); ai != bi {
+				return ai < bi
+			}
+			return a.Issue.Number < b.Issue.Number
+		}
+		if ap, bp := a.CL.Project.ServerSlashProject(), b.CL.Project.ServerSlashProject(); ap != bp {
+			return ap < bp
+		}
+		return a.CL.Number < b.CL.Number
+	})
+	for _, m := range matches {
+		if err := fn(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queryCandidates returns the issues and CLs that q might match, using
+// the smallest index set selected by one of q's terms.
+func (c *Corpus) queryCandidates(q *Query) (map[*GitHubIssue]*GitHubRepo, map[*GerritCL]bool) {
+	c.indexMu.Lock()
+	defer c.indexMu.Unlock()
+	idx := c.updateIndex()
+
+	wantIssues, wantCLs := true, true
+	var repos []*GitHubRepo
+	var projects []*GerritProject
+	var issueSets []map[*GitHubIssue]*GitHubRepo
+	var clSets []map[*GerritCL]bool
+	for i := range q.terms {
+		t := &q.terms[i]
+		if t.negate {
+			continue
+		}
+		if t.issueOnly() {
+			wantCLs = false
+		}
+		if t.clOnly() {
+			wantIssues = false
+		}
+		switch t.key {
+		case "repo":
+			repos = c.queryRepos(t.value)
+		case "project":
+			projects = c.queryProjects(t.value)
+		case "label":
+			issueSets = append(issueSets, unionIndex(idx.byLabel, c.queryLabels(t.value)))
+		case "milestone":
+			if t.value != "none" {
+				issueSets = append(issueSets, unionIndex(idx.byMilestone, c.queryMilestones(t.value)))
+			}
+		case "assignee":
+			if t.value != "none" {
+				issueSets = append(issueSets, unionIndex(idx.byAssignee, c.queryUsers(t.value)))
+			}
+		case "owner":
+			clSets = append(clSets, idx.byOwner[t.value])
+		case "status":
+			clSets = append(clSets, idx.byStatus[t.value])
+		case "is":
+			switch t.value {
+			case "merged", "abandoned":
+				clSets = append(clSets, idx.byStatus[t.value])
+			case "open":
+				clSets = append(clSets, unionIndex(idx.byStatus, []string{"new", "draft"}))
+			case "closed":
+				clSets = append(clSets, unionIndex(idx.byStatus, []string{"merged", "abandoned"}))
+			}
+		}
+	}
+
+	issues := make(map[*GitHubIssue]*GitHubRepo)
+	if wantIssues {
+		if set := smallestSet(issueSets); set != nil {
+			for gi, gr := range set {
+				issues[gi] = gr
+			}
+		} else {
+			if repos == nil && c.github != nil {
+				for _, gr := range c.github.repos {
+					repos = append(repos, gr)
+				}
+			}
+			for _, gr := range repos {
+				for _, gi := range gr.issues {
+					issues[gi] = gr
+				}
+			}
+		}
+	}
+	cls := make(map[*GerritCL]bool)
+	if wantCLs {
+		if set := smallestSet(clSets); set != nil {
+			for cl := range set {
+				cls[cl] = true
+			}
+		} else {
+			if projects == nil && c.gerrit != nil {
+				for _, gp := range c.gerrit.projects {
+					projects = append(projects, gp)
+				}
+			}
+			for _, gp := range projects {
+				for _, cl := range gp.cls {
+					cls[cl] = true
+				}
+			}
+		}
+	}
+	return issues, cls
+}
+
+// unionIndex returns the union of the sets for keys in m.
+func unionIndex[K, V comparable, X any](m map[K]map[V]X, keys []K) map[V]X {
+	if len(keys) == 1 {
+		if set := m[keys[0]]; set != nil {
+			return set
+		}
+	}
+	u := make(map[V]X)
+	for _, k := range keys {
+		for v, x := range m[k] {
+			u[v] = x
+		}
+	}
+	return u
+}
+
+// smallestSet returns the smallest of sets, or nil if there are none.
+func smallestSet[M ~map[K]V, K comparable, V any](sets []M) M {
+	var smallest M
+	for i, s := range sets {
+		if i == 0 || len(s) < len(smallest) {
+			smallest = s
+			if smallest == nil {
+				smallest = M{}
+			}
+		}
+	}
+	return smallest
+}
+
+// queryRepos returns the GitHub repos named by a repo: term, which is
+// empty (but non-nil) if there are none.
+func (c *Corpus) queryRepos(name string) []*GitHubRepo {
+	repos := []*GitHubRepo{}
+	if c.github != nil {
+		for id, gr := range c.github.repos {
+			if strings.EqualFold(id.String(), name) {
+				repos = append(repos, gr)
+			}
+		}
+	}
+	return repos
+}
+
+// queryProjects returns the Gerrit projects named by a project: term,
+// which is empty (but non-nil) if there are none.
+func (c *Corpus) queryProjects(name string) []*GerritProject {
+	projects := []*GerritProject{}
+	if c.gerrit != nil {
+		for _, gp := range c.gerrit.projects {
+			if matchProject(gp, name) {
+				projects = append(projects, gp)
+			}
+		}
+	}
+	return projects
+}
+
+func matchProject(gp *GerritProject, name string) bool {
+	return strings.EqualFold(gp.Project(), name) || strings.EqualFold(gp.ServerSlashProject(), name)
+}
+
+// queryLabels returns the labels in any repo with the given name.
+func (c *Corpus) queryLabels(name string) []*GitHubLabel {
+	var labels []*GitHubLabel
+	if c.github != nil {
+		for _, gr := range c.github.repos {
+			for _, lb := range gr.labels {
+				if strings.EqualFold(lb.Name, name) {
+					labels = append(labels, lb)
+				}
+			}
+		}
+	}
+	return labels
+}
+
+// queryMilestones returns the milestones in any repo with the given title.
+func (c *Corpus) queryMilestones(title string) []*GitHubMilestone {
+	var milestones []*GitHubMilestone
+	if c.github != nil {
+		for _, gr := range c.github.repos {
+			for _, ms := range gr.milestones {
+				if strings.EqualFold(ms.Title, title) {
+					milestones = append(milestones, ms)
+				}
+			}
+		}
+	}
+	return milestones
+}
+
+// queryUsers returns the GitHub users with the given login.
+func (c *Corpus) queryUsers(login string) []*GitHubUser {
+	var users []*GitHubUser
+	if c.github != nil {
+		for _, u := range c.github.users {
+			if strings.EqualFold(u.Login, login) {
+				users = append(users, u)
+			}
+		}
+	}
+	return users
+}
+
+func (q *Query) matchIssue(gr *GitHubRepo, gi *GitHubIssue, now time.Time) bool {
+	if gi.NotExist {
+		return false
+	}
+	for i := range q.terms {
+		t := &q.terms[i]
+		if t.matchIssue(gr, gi, now) == t.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *queryTerm) matchIssue(gr *GitHubRepo, gi *GitHubIssue, now time.Time) bool {
+	switch t.key {
+	case "":
+		return strings.Contains(strings.ToLower(gi.Title), t.value)
+	case "repo":
+		return strings.EqualFold(gr.ID().String(), t.value)
+	case "is":
+		switch t.value {
+		case "open":
+			return !gi.Closed
+		case "closed":
+			return gi.Closed
+		case "issue":
+			return !gi.PullRequest
+		case "pr":
+			return gi.PullRequest
+		}
+		return false
+	case "label":
+		for _, lb := range gi.Labels {
+			if strings.EqualFold(lb.Name, t.value) {
+				return true
+			}
+		}
+		return false
+	case "milestone":
+		if t.value == "none" {
+			return gi.Milestone == nil || gi.Milestone.IsNone()
+		}
+		return gi.Milestone != nil && !gi.Milestone.IsNone() && strings.EqualFold(gi.Milestone.Title, t.value)
+	case "assignee":
+		if t.value == "none" {
+			return len(gi.Assignees) == 0
+		}
+		for _, u := range gi.Assignees {
+			if strings.EqualFold(u.Login, t.value) {
+				return true
+			}
+		}
+		return false
+	case "author":
+		return gi.User != nil && strings.EqualFold(gi.User.Login, t.value)
+	case "created":
+		return t.matchTime(gi.Created, now)
+	case "updated":
+		return t.matchTime(gi.Updated, now)
+	}
+	return false
+}
+
+func (q *Query) matchCL(cl *GerritCL, now time.Time) bool {
+	if !cl.complete() || cl.Private {
+		return false
+	}
+	for i := range q.terms {
+		t := &q.terms[i]
+		if t.matchCL(cl, now) == t.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *queryTerm) matchCL(cl *GerritCL, now time.Time) bool {
+	switch t.key {
+	case "":
+		return strings.Contains(strings.ToLower(cl.Subject()), t.value)
+	case "project":
+		return matchProject(cl.Project, t.value)
+	case "is":
+		switch t.value {
+		case "open":
+			return cl.Status == "new" || cl.Status == "draft"
+		case "closed":
+			return cl.Status == "merged" || cl.Status == "abandoned"
+		case "cl":
+			return true
+		case "merged", "abandoned":
+			return cl.Status == t.value
+		}
+		return false
+	case "owner":
+		owner := cl.Owner()
+		return owner != nil && strings.EqualFold(owner.Email(), t.value)
+	case "status":
+		return cl.Status == t.value
+	case "branch":
+		return cl.Branch() == t.value
+	case "created":
+		return t.matchTime(cl.Created, now)
+	case "updated":
+		return t.matchTime(cl.Meta.Commit.CommitTime, now)
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/build/maintner/maintpb"
+)
+
+func queryTestCorpus(t *testing.T) *Corpus {
+	head, tail := snapshotTestMutations()
+	muts := append(head, tail...)
+	muts = append(muts,
+		&maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{
+			Owner:          "golang",
+			Repo:           "go",
+			Number:         2,
+			User:           &maintpb.GithubUser{Id: 101, Login: "kevinburke"},
+			Title:          "x/build: add a query language",
+			Created:        tp1,
+			Updated:        tp2,
+			MilestoneId:    7,
+			MilestoneNum:   1,
+			MilestoneTitle: "Go1.24",
+			Assignees:      []*maintpb.GithubUser{{Id: 100, Login: "gopherbot"}},
+			AddLabel:       []*maintpb.GithubLabel{{Id: 9, Name: "NeedsFix"}, {Id: 10, Name: "help wanted"}},
+		}},
+		&maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{
+			Owner:       "golang",
+			Repo:        "go",
+			Number:      3,
+			User:        &maintpb.GithubUser{Id: 100, Login: "gopherbot"},
+			Title:       "net/http: fix a bug",
+			Created:     tp2,
+			Updated:     tp2,
+			PullRequest: true,
+			NoMilestone: true,
+		}},
+		&maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{
+			Owner:    "golang",
+			Repo:     "build",
+			Number:   4,
+			User:     &maintpb.GithubUser{Id: 100, Login: "gopherbot"},
+			Title:    "maintner: make queries faster",
+			Created:  tp2,
+			Updated:  tp2,
+			AddLabel: []*maintpb.GithubLabel{{Id: 11, Name: "NeedsFix"}},
+		}},
+		&maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{
+			Owner:    "golang",
+			Repo:     "go",
+			Number:   5,
+			NotExist: true,
+		}},
+	)
+	c := new(Corpus)
+	for _, m := range muts {
+		c.processMutationLocked(m)
+	}
+	c.finishProcessing()
+	return c
+}
+
+func queryMatches(t *testing.T, c *Corpus, query string) string {
+	t.Helper()
+	q, err := ParseQuery(query)
+	if err != nil {
+		t.Fatalf("ParseQuery(%q): %v", query, err)
+	}
+	var got []string
+	err = c.ForeachMatch(q, func(m QueryMatch) error {
+		if m.Issue != nil {
+			got = append(got, fmt.Sprintf("%v#%d", m.Repo.ID(), m.Issue.Number))
+		} else {
+			got = append(got, fmt.Sprintf("%s/%d", m.CL.Project.Project(), m.CL.Number))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Join(got, " ")
+}
+
+func TestQuery(t *testing.T) {
+	c := queryTestCorpus(t)
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "golang/build#4 golang/go#1 golang/go#2 golang/go#3 build/1"},
+		{"repo:golang/go", "golang/go#1 golang/go#2 golang/go#3"},
+		{"repo:Golang/Go is:open", "golang/go#2 golang/go#3"},
+		{"repo:golang/nope", ""},
+		{"project:build", "build/1"},
+		{"project:go.googlesource.com/build is:merged", "build/1"},
+		{"is:open", "golang/build#4 golang/go#2 golang/go#3"},
+		{"is:closed", "golang/go#1 build/1"},
+		{"is:issue", "golang/build#4 golang/go#1 golang/go#2"},
+		{"is:pr", "golang/go#3"},
+		{"is:cl", "build/1"},
+		{"is:abandoned", ""},
+		{"status:merged", "build/1"},
+		{"status:new", ""},
+		{"label:NeedsFix", "golang/build#4 golang/go#1 golang/go#2"},
+		{"label:needsfix is:open", "golang/build#4 golang/go#2"},
+		{`label:"help wanted"`, "golang/go#2"},
+		{"-label:NeedsFix", "golang/go#3 build/1"},
+		{"label:NoSuchLabel", ""},
+		{"repo:golang/go is:open label:NeedsFix milestone:Go1.24", "golang/go#2"},
+		{"milestone:none", "golang/build#4 golang/go#3"},
+		{"assignee:gopherbot", "golang/go#2"},
+		{"assignee:none repo:golang/go", "golang/go#1 golang/go#3"},
+		{"author:kevinburke", "golang/go#2"},
+		{"owner:gopher@golang.org", "build/1"},
+		{"owner:someone@golang.org", ""},
+		{"branch:master", "build/1"},
+		{"branch:refs/heads/master", "build/1"},
+		{"label:NeedsFix project:build", ""},
+		{"query", "golang/go#2"},
+		{"snapshots", "golang/go#1 build/1"},
+		{"net/http:", "golang/go#3"},
+		{"created:2016-01-02 is:issue", "golang/build#4 golang/go#1 golang/go#2"},
+		{"created:<2016-01-02", ""},
+		{"created:>=2016-01-03", "build/1"},
+		{"updated:<30d", ""},
+		{"updated:>30d is:open", "golang/build#4 golang/go#2 golang/go#3"},
+		{"created:<2017-03-04 is:cl", ""},
+		{"created:<=2017-03-04 is:cl", "build/1"},
+		{"created:2017-03-04", "build/1"},
+	}
+	for _, tt := range tests {
+		if got := queryMatches(t, c, tt.query); got != tt.want {
+			t.Errorf("query %q matched %q; want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	for _, query := range []string{
+		"nope:x",
+		"is:nope",
+		"label:",
+		`label:"help wanted`,
+		"updated:30d",
+		"updated:<30y",
+		"created:2016-13-01",
+	} {
+		if _, err := ParseQuery(query); err == nil {
+			t.Errorf("ParseQuery(%q) succeeded; want error", query)
+		}
+	}
+}
+
+func TestQueryIndexUpdates(t *testing.T) {
+	c := queryTestCorpus(t)
+	if got, want := queryMatches(t, c, "label:NeedsFix is:open"), "golang/build#4 golang/go#2"; got != want {
+		t.Fatalf("before update, matched %q; want %q", got, want)
+	}
+
+	// Relabel an issue, rename the milestone, reopen the CL and
+	// create a new issue, and check the indexes follow.
+	for _, m := range []*maintpb.Mutation{
+		{GithubIssue: &maintpb.GithubIssueMutation{
+			Owner:       "golang",
+			Repo:        "go",
+			Number:      2,
+			RemoveLabel: []int64{9},
+			Assignees:   []*maintpb.GithubUser{{Id: 101, Login: "kevinburke"}},
+		}},
+		{Github: &maintpb.GithubMutation{
+			Owner:      "golang",
+			Repo:       "go",
+			Milestones: []*maintpb.GithubMilestone{{Id: 7, Title: "Go1.25"}},
+		}},
+		{GithubIssue: &maintpb.GithubIssueMutation{
+			Owner:    "golang",
+			Repo:     "go",
+			Number:   6,
+			Title:    "new issue",
+			Created:  tp2,
+			Updated:  tp2,
+			AddLabel: []*maintpb.GithubLabel{{Id: 9, Name: "NeedsFix"}},
+		}},
+		{Gerrit: &maintpb.GerritMutation{
+			Project: "go.googlesource.com/build",
+			Commits: []*maintpb.GitCommit{
+				snapshotTestCommit("7000000000000000000000000000000000000000", "Update patch set 1\n\nChange has been restored\n\nPatch-set: 1\nStatus: new\n", "5000000000000000000000000000000000000000"),
+			},
+			Refs: []*maintpb.GitRef{{Ref: "refs/changes/01/1/meta", Sha1: "7000000000000000000000000000000000000000"}},
+		}},
+	} {
+		c.processMutationLocked(m)
+	}
+	c.finishProcessing()
+
+	for _, tt := range []struct{ query, want string }{
+		{"label:NeedsFix is:open", "golang/build#4 golang/go#6"},
+		{"milestone:Go1.24", ""},
+		{"milestone:Go1.25", "golang/go#1 golang/go#2"},
+		{"assignee:kevinburke", "golang/go#2"},
+		{"assignee:gopherbot", "golang/go#2"},
+		{"status:merged", ""},
+		{"is:open is:cl", "build/1"},
+	} {
+		if got := queryMatches(t, c, tt.query); got != tt.want {
+			t.Errorf("after update, query %q matched %q; want %q", tt.query, got, tt.want)
+		}
+	}
+
+	// The incrementally updated index must match a freshly built one.
+	updated := c.index
+	c.resetIndex()
+	c.indexMu.Lock()
+	fresh := c.updateIndex()
+	c.indexMu.Unlock()
+	for _, f := range []struct {
+		name           string
+		updated, fresh any
+	}{
+		{"byLabel", updated.byLabel, fresh.byLabel},
+		{"byMilestone", updated.byMilestone, fresh.byMilestone},
+		{"byAssignee", updated.byAssignee, fresh.byAssignee},
+		{"byOwner", updated.byOwner, fresh.byOwner},
+		{"byStatus", updated.byStatus, fresh.byStatus},
+	} {
+		if !reflect.DeepEqual(f.updated, f.fresh) {
+			t.Errorf("incrementally updated %s index differs from a rebuilt one", f.name)
+		}
+	}
+}
@@ -0,0 +1,1602 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"bufio"
+	"cmp"
+	"compress/gzip"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"golang.org/x/build/maintner/maintpb"
+)
+
+// A snapshot is the processed state of a Corpus at a position in its
+// mutation log. Initialize can load one and replay only the mutations
+// logged after it, instead of the whole log. See Corpus.UseSnapshot.
+//
+// A snapshot starts with the line "maintner-snapshot <version>",
+// followed by a gzip-compressed stream of gob values: a snapshotHeader,
+// then snapshotBatches until one with End set. Pointers between corpus
+// objects are replaced by git hashes, IDs, and indexes into tables sent
+// earlier in the stream. The encoding is deterministic, so two corpora
+// that processed the same log have identical snapshots.
+//
+// A snapshot is only valid for the version of this package that wrote
+// it. Increment snapshotVersion whenever the processed state changes,
+// such as when a field is added to GitHubIssue or a mutation is processed
+// differently, so that Initialize ignores older snapshots.
+const snapshotVersion = 3
+
+const snapshotMagic = "maintner-snapshot"
+
+// snapshotBatchSize is the number of objects in each snapshotBatch.
+// Batches bound the memory gob needs to encode and decode the stream.
+const snapshotBatchSize = 10000
+
+// snapshotRefreshMutations is the number of mutations Initialize may
+// replay after loading a snapshot before it saves a new one.
+var snapshotRefreshMutations = 10000
+
+// A logPosition is a position in a mutation log, recorded as the size
+// of each of its files or segments at the time.
+type logPosition struct {
+	Source   string // "network" or "disk"
+	Segments []logSegment
+}
+
+// A logSegment is a prefix of a mutation log file or segment.
+type logSegment struct {
+	Number int    // segment number, for network logs
+	Name   string // file name, for disk logs
+	Size   int64
+	SHA224 string // of the prefix, for network logs
+}
+
+// A resumableSource is a MutationSource that can start sending
+// mutations at a position in its log, rather than at its beginning.
+type resumableSource interface {
+	MutationSource
+
+	// logPosition returns the position in the log up to which
+	// mutations have been sent or, for a log that's also being
+	// written to, logged.
+	logPosition() (*logPosition, error)
+
+	// resumeFrom arranges for the next call to GetMutations to send
+	// only the mutations after pos, or all of them if pos is nil.
+	// It reports an error if the log doesn't extend pos.
+	resumeFrom(pos *logPosition) error
+}
+
+// UseSnapshot makes Initialize load the corpus from the snapshot file
+// at path, if it exists, and replay only the mutations logged since it
+// was written. If the snapshot can't be used, because it's missing, is
+// from a different version of this package, or doesn't match the log,
+// Initialize replays the whole log as usual.
+//
+// After loading, Initialize writes a new snapshot to path if it didn't
+// use one, or if it replayed many mutations after the one it used.
+//
+// Snapshots are supported for the network mutation source and for
+// DiskMutationLogger. UseSnapshot must be called before Initialize.
+func (c *Corpus) UseSnapshot(path string) {
+	if c.mutationSource != nil {
+		panic("UseSnapshot called after Initialize")
+	}
+	c.snapshotPath = path
+}
+
+// SaveSnapshot writes a snapshot of c to the file at path, replacing it
+// atomically. See WriteSnapshot.
+func (c *Corpus) SaveSnapshot(path string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	bw := bufio.NewWriterSize(f, 1<<20)
+	if err := c.WriteSnapshot(bw); err != nil {
+		f.Close()
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// WriteSnapshot writes a snapshot of c's current state to w, for use
+// with UseSnapshot. It waits for any in-progress Update to finish.
+//
+// The corpus must have been initialized from a network mutation source
+// or a DiskMutationLogger, and its last Update must have succeeded, so
+// that it's at a known position in the log.
+func (c *Corpus) WriteSnapshot(w io.Writer) error {
+	c.snapMu.Lock()
+	defer c.snapMu.Unlock()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	pos, err := c.snapshotPosition()
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%s %d\n", snapshotMagic, snapshotVersion); err != nil {
+		return err
+	}
+	zw, err := gzip.NewWriterLevel(w, gzip.BestSpeed)
+	if err != nil {
+		return err
+	}
+	sw := &snapshotWriter{c: c, enc: gob.NewEncoder(zw)}
+	if err := sw.write(pos); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// snapshotPosition returns the position in the log that c's state
+// corresponds to.
+//
+// c.mu must be held.
+func (c *Corpus) snapshotPosition() (*logPosition, error) {
+	if c.mutationLogger != nil {
+		// In leader mode, mutations are logged as they're processed
+		// (see addMutation), so the corpus is at the end of the log.
+		if rs, ok := c.mutationSource.(resumableSource); ok && any(rs) == any(c.mutationLogger) {
+			return rs.logPosition()
+		}
+		return nil, fmt.Errorf("maintner: can't snapshot a corpus that logs to %T", c.mutationLogger)
+	}
+	if c.logPos == nil {
+		return nil, errors.New("maintner: corpus isn't at a known position in its mutation log")
+	}
+	return c.logPos, nil
+}
+
+// loadSnapshot loads c from the snapshot at c.snapshotPath and arranges
+// for src to send only the mutations after it. It reports whether it
+// did; if not, c is unchanged, and src will send the whole log.
+func (c *Corpus) loadSnapshot(src MutationSource) bool {
+	rs, ok := src.(resumableSource)
+	if !ok {
+		log.Printf("Not using snapshot %s: can't resume log %T.", c.snapshotPath, src)
+		return false
+	}
+	f, err := os.Open(c.snapshotPath)
+	if os.IsNotExist(err) {
+		return false
+	} else if err != nil {
+		log.Printf("Not using snapshot: %v", err)
+		return false
+	}
+	defer f.Close()
+
+	start := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sr, err := newSnapshotReader(c, bufio.NewReaderSize(f, 1<<20))
+	if err == nil {
+		err = rs.resumeFrom(&sr.hdr.Position)
+	}
+	if err != nil {
+		log.Printf("Not using snapshot %s: %v", c.snapshotPath, err)
+		return false
+	}
+	if err := sr.read(); err != nil {
+		log.Printf("Not using snapshot %s: %v", c.snapshotPath, err)
+		c.resetState()
+		if err := rs.resumeFrom(nil); err != nil {
+			log.Printf("Resetting log %T: %v", src, err)
+		}
+		return false
+	}
+	c.logPos = &sr.hdr.Position
+	log.Printf("Loaded snapshot %s in %v.", c.snapshotPath, time.Since(start).Round(time.Millisecond))
+	return true
+}
+
+// resetState discards c's processed state, leaving it as if it had
+// been created and had its GitHub repos and Gerrit projects tracked,
+// but not yet initialized.
+//
+// c.mu must be held.
+func (c *Corpus) resetState() {
+	c.strIntern = nil
+	c.github = nil
+	c.gerrit = nil
+	c.gitPeople = nil
+	c.gitCommit = nil
+	c.gitCommitTodo = nil
+	c.gitOfHg = nil
+	c.zoneCache = nil
+	c.logPos = nil
+	c.resetIndex()
+	// Keep the tracked repos and projects, which are referred to by
+	// watchedGithubRepos and watchedGerritRepos.
+	for _, w := range c.watchedGithubRepos {
+		c.initGithub()
+		*w.gr = GitHubRepo{github: c.github, id: w.gr.id, issues: map[int32]*GitHubIssue{}}
+		c.github.repos[w.gr.id] = w.gr
+	}
+	for _, w := range c.watchedGerritRepos {
+		c.initGerrit()
+		gp := w.project
+		*gp = GerritProject{
+			gerrit: c.gerrit,
+			proj:   gp.proj,
+			cls:    map[int32]*GerritCL{},
+			remote: map[gerritCLVersion]GitHash{},
+			ref:    map[string]GitHash{},
+			commit: map[GitHash]*GitCommit{},
+			need:   map[GitHash]bool{},
+		}
+		c.gerrit.projects[gp.proj] = gp
+	}
+}
+
+type snapshotHeader struct {
+	Position  logPosition
+	HasGitHub bool
+	HasGerrit bool
+}
+
+// A snapshotBatch holds part of a snapshot. The fields are written
+// and read in order, so that objects are in a batch before or with the
+// objects that refer to them by index.
+type snapshotBatch struct {
+	People  []string // GitPerson.Str; referred to by index+1, 0 for nil
+	Commits []snapCommit
+	GitTodo []GitHash
+	GitOfHg []snapHgCommit
+
+	Users       []GitHubUser // referred to by index+1, 0 for nil
+	Teams       []GitHubTeam // referred to by index+1, 0 for nil
+	Repos       []snapRepo   // referred to by index
+	Issues      []snapIssue
+	Discussions []snapDiscussion
+
+	Projects []snapProject // referred to by index
+	CLs      []snapCL
+	Metas    []snapMeta // referred to by ID, which starts at 1; 0 is nil
+	IssueCLs []snapIssueCLs
+
+	End bool
+}
+
+// snapTime is a git commit time. Unlike a gob-encoded time.Time, it
+// keeps the name of the time's location.
+type snapTime struct {
+	Unix int64
+	Zone string // "+0800"; empty for the zero time
+}
+
+type snapCommit struct {
+	Hash        GitHash
+	Placeholder bool // for a parent that hasn't been processed yet
+	Tree        GitHash
+	Parents     []GitHash
+	Author      int
+	AuthorTime  snapTime
+	Committer   int
+	Reviewer    string // empty for nil
+	CommitTime  snapTime
+	Msg         string
+	Files       []snapFile
+	GerritMeta  int
+}
+
+type snapFile struct {
+	File    string
+	Added   int64
+	Deleted int64
+	Binary  bool
+}
+
+type snapHgCommit struct {
+	Hg  string
+	Git GitHash
+}
+
+type snapRepo struct {
+	Owner, Repo string
+	Milestones  []GitHubMilestone
+	Labels      []GitHubLabel
+}
+
+type snapIssue struct {
+	Repo        int
+	ID          int64
+	Number      int32
+	NotExist    bool
+	Closed      bool
+	Locked      bool
+	PullRequest bool
+	User        int
+	Assignees   []int
+	Created     time.Time
+	Updated     time.Time
+	ClosedAt    time.Time
+	ClosedBy    int
+	Title       string
+	Body        string
+	Milestone   int64 // milestone ID; 0 for nil and -1 for none
+	Labels      []int64
+
+	CommentsUpdatedTil time.Time
+	CommentsSyncedAsOf time.Time
+	Comments           []snapComment
+	EventMaxTime       time.Time
+	EventsSyncedAsOf   time.Time
+	ReviewsSyncedAsOf  time.Time
+	Events             []snapEvent
+	Reviews            []snapReview
+
+	HeadSHA             string
+	Files               []GitHubPullRequestFile
+	FilesTruncated      bool
+	FilesSyncedAsOf     time.Time
+	CheckRuns           []GitHubCheckRun
+	CheckRunsSyncedAsOf time.Time
+}
+
+type snapComment struct {
+	ID      int64
+	User    int
+	Created time.Time
+	Updated time.Time
+	Body    string
+}
+
+type snapEvent struct {
+	ID                  int64
+	Type                string
+	OtherJSON           string
+	Created             time.Time
+	Actor               int
+	Label               string
+	Assignee            int
+	Assigner            int
+	Milestone           string
+	From, To            string
+	CommitID, CommitURL string
+	Reviewer            int
+	TeamReviewer        int
+	ReviewRequester     int
+	DismissedReview     *GitHubDismissedReviewEvent
+}
+
+type snapReview struct {
+	ID               int64
+	Actor            int
+	Body             string
+	State            string
+	CommitID         string
+	ActorAssociation string
+	Created          time.Time
+	OtherJSON        string
+}
+
+type snapDiscussion struct {
+	Repo               int
+	ID                 int64
+	Number             int32
+	User               int
+	Created            time.Time
+	Updated            time.Time
+	Title              string
+	Body               string
+	Category           string
+	Closed             bool
+	Locked             bool
+	CommentsSyncedAsOf time.Time
+	Comments           []snapDiscussionComment
+}
+
+type snapDiscussionComment struct {
+	ID        int64
+	ReplyToID int64
+	User      int
+	Created   time.Time
+	Updated   time.Time
+	Body      string
+	IsAnswer  bool
+}
+
+type snapProject struct {
+	Proj            string
+	Remote          []snapRemote
+	Need            []GitHash
+	Commits         []GitHash
+	Refs            []snapRef
+	NumLabelChanges int
+	Comments        []snapGerritComment
+}
+
+type snapRemote struct {
+	CLNumber int32
+	Version  int32
+	Hash     GitHash
+}
+
+type snapRef struct {
+	Ref  string
+	Hash GitHash
+}
+
+type snapGerritComment struct {
+	Meta       GitHash
+	UUID       string
+	ParentUUID string
+	PatchSet   int32
+	Revision   GitHash
+	Filename   string
+	Line       int32
+	AuthorID   int64
+	ServerID   string
+	Written    time.Time
+	Message    string
+	Unresolved bool
+}
+
+type snapCL struct {
+	Project         int
+	Number          int32
+	Created         snapTime
+	Version         int32
+	Commit          GitHash // empty for nil
+	Branch          string
+	Meta            int
+	Metas           []int
+	Status          string
+	Private         bool
+	GitHubIssueRefs []snapIssueRef
+	Messages        []snapMessage
+}
+
+type snapIssueRef struct {
+	Owner, Repo string
+	Number      int32
+}
+
+type snapMessage struct {
+	Meta    GitHash
+	Version int32
+	Message string
+	Date    snapTime
+	Author  int
+}
+
+type snapMeta struct {
+	ID        int
+	Commit    GitHash
+	CLProject int
+	CLNumber  int32
+	Flags     gerritMetaFlags
+}
+
+type snapCLRef struct {
+	Project int
+	Number  int32
+}
+
+type snapIssueCLs struct {
+	Ref snapIssueRef
+	CLs []snapCLRef
+}
+
+func encodeSnapTime(t time.Time) snapTime {
+	if t.IsZero() {
+		return snapTime{}
+	}
+	return snapTime{Unix: t.Unix(), Zone: t.Location().String()}
+}
+
+// snapshotWriter writes a corpus snapshot.
+// Its Corpus's mu must be held.
+type snapshotWriter struct {
+	c   *Corpus
+	enc *gob.Encoder
+
+	people   map[*GitPerson]int
+	users    map[*GitHubUser]int
+	teams    map[*GitHubTeam]int
+	repos    map[*GitHubRepo]int
+	projects map[*GerritProject]int
+	metas    map[*GerritMeta]int
+	metaList []*GerritMeta
+
+	batch snapshotBatch
+	n     int // number of objects in batch
+}
+
+// added notes that n objects were added to the current batch,
+// and writes it if it's full.
+func (w *snapshotWriter) added(n int) error {
+	w.n += n
+	if w.n < snapshotBatchSize {
+		return nil
+	}
+	return w.flush()
+}
+
+func (w *snapshotWriter) flush() error {
+	if w.n == 0 && !w.batch.End {
+		return nil
+	}
+	err := w.enc.Encode(&w.batch)
+	w.batch = snapshotBatch{}
+	w.n = 0
+	return err
+}
+
+func (w *snapshotWriter) write(pos *logPosition) error {
+	c := w.c
+	hdr := snapshotHeader{
+		Position:  *pos,
+		HasGitHub: c.github != nil,
+		HasGerrit: c.gerrit != nil,
+	}
+	if err := w.enc.Encode(&hdr); err != nil {
+		return err
+	}
+	w.indexMetas()
+	for _, section := range []func() error{
+		w.writeGit,
+		w.writeGitHub,
+		w.writeGerrit,
+	} {
+		if err := section(); err != nil {
+			return err
+		}
+	}
+	w.batch.End = true
+	return w.flush()
+}
+
+// indexMetas assigns IDs to all of the Gerrit meta commits referred
+// to by git commits and CLs.
+func (w *snapshotWriter) indexMetas() {
+	w.metas = make(map[*GerritMeta]int)
+	add := func(m *GerritMeta) {
+		if m == nil {
+			return
+		}
+		if _, ok := w.metas[m]; !ok {
+			w.metaList = append(w.metaList, m)
+			w.metas[m] = len(w.metaList)
+		}
+	}
+	for _, h := range slices.Sorted(maps.Keys(w.c.gitCommit)) {
+		add(w.c.gitCommit[h].GerritMeta)
+	}
+	for _, gp := range w.sortedProjects() {
+		for _, num := range slices.Sorted(maps.Keys(gp.cls)) {
+			cl := gp.cls[num]
+			add(cl.Meta)
+			for _, m := range cl.Metas {
+				add(m)
+			}
+		}
+	}
+}
+
+func (w *snapshotWriter) sortedProjects() []*GerritProject {
+	if w.c.gerrit == nil {
+		return nil
+	}
+	var projects []*GerritProject
+	for _, name := range slices.Sorted(maps.Keys(w.c.gerrit.projects)) {
+		projects = append(projects, w.c.gerrit.projects[name])
+	}
+	return projects
+}
+
+func (w *snapshotWriter) person(p *GitPerson) (int, error) {
+	if p == nil {
+		return 0, nil
+	}
+	i, ok := w.people[p]
+	if !ok {
+		return 0, fmt.Errorf("git person %q isn't in the corpus", p.Str)
+	}
+	return i, nil
+}
+
+func (w *snapshotWriter) writeGit() error {
+	c := w.c
+	w.people = make(map[*GitPerson]int)
+	for _, s := range slices.Sorted(maps.Keys(c.gitPeople)) {
+		w.batch.People = append(w.batch.People, s)
+		w.people[c.gitPeople[s]] = len(w.people) + 1
+		if err := w.added(1); err != nil {
+			return err
+		}
+	}
+
+	for _, h := range slices.Sorted(maps.Keys(c.gitCommit)) {
+		gc := c.gitCommit[h]
+		sc := snapCommit{
+			Hash:       h,
+			Tree:       gc.Tree,
+			AuthorTime: encodeSnapTime(gc.AuthorTime),
+			CommitTime: encodeSnapTime(gc.CommitTime),
+			Msg:        gc.Msg,
+			GerritMeta: w.metas[gc.GerritMeta],
+		}
+		var err error
+		if sc.Author, err = w.person(gc.Author); err != nil {
+			return err
+		}
+		if gc.Committer == placeholderCommitter {
+			sc.Placeholder = true
+		} else if sc.Committer, err = w.person(gc.Committer); err != nil {
+			return err
+		}
+		if gc.Reviewer != nil {
+			sc.Reviewer = gc.Reviewer.Str
+		}
+		for _, p := range gc.Parents {
+			sc.Parents = append(sc.Parents, p.Hash)
+		}
+		for _, f := range gc.Files {
+			sc.Files = append(sc.Files, snapFile{File: f.File, Added: f.Added, Deleted: f.Deleted, Binary: f.Binary})
+		}
+		w.batch.Commits = append(w.batch.Commits, sc)
+		if err := w.added(1); err != nil {
+			return err
+		}
+	}
+
+	for _, h := range slices.Sorted(maps.Keys(c.gitCommitTodo)) {
+		w.batch.GitTodo = append(w.batch.GitTodo, h)
+		if err := w.added(1); err != nil {
+			return err
+		}
+	}
+	for _, hg := range slices.Sorted(maps.Keys(c.gitOfHg)) {
+		w.batch.GitOfHg = append(w.batch.GitOfHg, snapHgCommit{Hg: hg, Git: c.gitOfHg[hg]})
+		if err := w.added(1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *snapshotWriter) writeGitHub() error {
+	g := w.c.github
+	if g == nil {
+		return nil
+	}
+	w.users = make(map[*GitHubUser]int)
+	for _, id := range slices.Sorted(maps.Keys(g.users)) {
+		u := g.users[id]
+		w.batch.Users = append(w.batch.Users, *u)
+		w.users[u] = len(w.users) + 1
+		if err := w.added(1); err != nil {
+			return err
+		}
+	}
+	w.teams = make(map[*GitHubTeam]int)
+	for _, id := range slices.Sorted(maps.Keys(g.teams)) {
+		t := g.teams[id]
+		w.batch.Teams = append(w.batch.Teams, *t)
+		w.teams[t] = len(w.teams) + 1
+		if err := w.added(1); err != nil {
+			return err
+		}
+	}
+
+	w.repos = make(map[*GitHubRepo]int)
+	ids := slices.SortedFunc(maps.Keys(g.repos), func(a, b GitHubRepoID) int {
+		return cmp.Or(cmp.Compare(a.Owner, b.Owner), cmp.Compare(a.Repo, b.Repo))
+	})
+	for _, id := range ids {
+		gr := g.repos[id]
+		sr := snapRepo{Owner: id.Owner, Repo: id.Repo}
+		for _, msID := range slices.Sorted(maps.Keys(gr.milestones)) {
+			sr.Milestones = append(sr.Milestones, *gr.milestones[msID])
+		}
+		for _, lbID := range slices.Sorted(maps.Keys(gr.labels)) {
+			sr.Labels = append(sr.Labels, *gr.labels[lbID])
+		}
+		w.batch.Repos = append(w.batch.Repos, sr)
+		w.repos[gr] = len(w.repos)
+		if err := w.added(1 + len(sr.Milestones) + len(sr.Labels)); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range ids {
+		gr := g.repos[id]
+		for _, num := range slices.Sorted(maps.Keys(gr.issues)) {
+			si, err := w.issue(w.repos[gr], gr.issues[num])
+			if err != nil {
+				return fmt.Errorf("%v#%d: %v", id, num, err)
+			}
+			w.batch.Issues = append(w.batch.Issues, si)
+			if err := w.added(1 + len(si.Comments) + len(si.Events) + len(si.Reviews) + len(si.Files) + len(si.CheckRuns)); err != nil {
+				return err
+			}
+		}
+		for _, num := range slices.Sorted(maps.Keys(gr.discussions)) {
+			sd, err := w.discussion(w.repos[gr], gr.discussions[num])
+			if err != nil {
+				return fmt.Errorf("%v discussion %d: %v", id, num, err)
+			}
+			w.batch.Discussions = append(w.batch.Discussions, sd)
+			if err := w.added(1 + len(sd.Comments)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// snapshotRefs converts pointers to the indexes in a snapshotWriter's
+// tables, recording the first error.
+type snapshotRefs struct {
+	w   *snapshotWriter
+	err error
+}
+
+func (r *snapshotRefs) user(u *GitHubUser) int {
+	if u == nil {
+		return 0
+	}
+	i, ok := r.w.users[u]
+	if !ok && r.err == nil {
+		r.err = fmt.Errorf("GitHub user %d isn't in the corpus", u.ID)
+	}
+	return i
+}
+
+func (r *snapshotRefs) team(t *GitHubTeam) int {
+	if t == nil {
+		return 0
+	}
+	i, ok := r.w.teams[t]
+	if !ok && r.err == nil {
+		r.err = fmt.Errorf("GitHub team %d isn't in the corpus", t.ID)
+	}
+	return i
+}
+
+func (w *snapshotWriter) issue(repo int, gi *GitHubIssue) (snapIssue, error) {
+	r := &snapshotRefs{w: w}
+	si := snapIssue{
+		Repo:               repo,
+		ID:                 gi.ID,
+		Number:             gi.Number,
+		NotExist:           gi.NotExist,
+		Closed:             gi.Closed,
+		Locked:             gi.Locked,
+		PullRequest:        gi.PullRequest,
+		User:               r.user(gi.User),
+		Created:            gi.Created,
+		Updated:            gi.Updated,
+		ClosedAt:           gi.ClosedAt,
+		ClosedBy:           r.user(gi.ClosedBy),
+		Title:              gi.Title,
+		Body:               gi.Body,
+		Labels:             slices.Sorted(maps.Keys(gi.Labels)),
+		CommentsUpdatedTil: gi.commentsUpdatedTil,
+		CommentsSyncedAsOf: gi.commentsSyncedAsOf,
+		EventMaxTime:       gi.eventMaxTime,
+		EventsSyncedAsOf:   gi.eventsSyncedAsOf,
+		ReviewsSyncedAsOf:  gi.reviewsSyncedAsOf,
+
+		HeadSHA:             gi.headSHA,
+		FilesTruncated:      gi.filesTruncated,
+		FilesSyncedAsOf:     gi.filesSyncedAsOf,
+		CheckRunsSyncedAsOf: gi.checkRunsSyncedAsOf,
+	}
+	for _, u := range gi.Assignees {
+		si.Assignees = append(si.Assignees, r.user(u))
+	}
+	for _, f := range gi.files {
+		si.Files = append(si.Files, *f)
+	}
+	for _, id := range slices.Sorted(maps.Keys(gi.checkRuns)) {
+		si.CheckRuns = append(si.CheckRuns, *gi.checkRuns[id])
+	}
+	switch gi.Milestone {
+	case nil:
+	case noMilestone:
+		si.Milestone = -1
+	default:
+		si.Milestone = gi.Milestone.ID
+	}
+	for _, id := range slices.Sorted(maps.Keys(gi.comments)) {
+		cm := gi.comments[id]
+		si.Comments = append(si.Comments, snapComment{
+			ID:      cm.ID,
+			User:    r.user(cm.User),
+			Created: cm.Created,
+			Updated: cm.Updated,
+			Body:    cm.Body,
+		})
+	}
+	for _, id := range slices.Sorted(maps.Keys(gi.events)) {
+		e := gi.events[id]
+		si.Events = append(si.Events, snapEvent{
+			ID:              e.ID,
+			Type:            e.Type,
+			OtherJSON:       e.OtherJSON,
+			Created:         e.Created,
+			Actor:           r.user(e.Actor),
+			Label:           e.Label,
+			Assignee:        r.user(e.Assignee),
+			Assigner:        r.user(e.Assigner),
+			Milestone:       e.Milestone,
+			From:            e.From,
+			To:              e.To,
+			CommitID:        e.CommitID,
+			CommitURL:       e.CommitURL,
+			Reviewer:        r.user(e.Reviewer),
+			TeamReviewer:    r.team(e.TeamReviewer),
+			ReviewRequester: r.user(e.ReviewRequester),
+			DismissedReview: e.DismissedReview,
+		})
+	}
+	for _, id := range slices.Sorted(maps.Keys(gi.reviews)) {
+		rv := gi.reviews[id]
+		si.Reviews = append(si.Reviews, snapReview{
+			ID:               rv.ID,
+			Actor:            r.user(rv.Actor),
+			Body:             rv.Body,
+			State:            rv.State,
+			CommitID:         rv.CommitID,
+			ActorAssociation: rv.ActorAssociation,
+			Created:          rv.Created,
+			OtherJSON:        rv.OtherJSON,
+		})
+	}
+	return si, r.err
+}
+
+func (w *snapshotWriter) discussion(repo int, d *GitHubDiscussion) (snapDiscussion, error) {
+	r := &snapshotRefs{w: w}
+	sd := snapDiscussion{
+		Repo:               repo,
+		ID:                 d.ID,
+		Number:             d.Number,
+		User:               r.user(d.User),
+		Created:            d.Created,
+		Updated:            d.Updated,
+		Title:              d.Title,
+		Body:               d.Body,
+		Category:           d.Category,
+		Closed:             d.Closed,
+		Locked:             d.Locked,
+		CommentsSyncedAsOf: d.commentsSyncedAsOf,
+	}
+	for _, id := range slices.Sorted(maps.Keys(d.comments)) {
+		dc := d.comments[id]
+		sd.Comments = append(sd.Comments, snapDiscussionComment{
+			ID:        dc.ID,
+			ReplyToID: dc.ReplyToID,
+			User:      r.user(dc.User),
+			Created:   dc.Created,
+			Updated:   dc.Updated,
+			Body:      dc.Body,
+			IsAnswer:  dc.IsAnswer,
+		})
+	}
+	return sd, r.err
+}
+
+func encodeIssueRef(ref GitHubIssueRef) snapIssueRef {
+	sr := snapIssueRef{Number: ref.Number}
+	if ref.Repo != nil {
+		sr.Owner, sr.Repo = ref.Repo.id.Owner, ref.Repo.id.Repo
+	}
+	return sr
+}
+
+func (w *snapshotWriter) clRef(cl *GerritCL) (snapCLRef, error) {
+	i, ok := w.projects[cl.Project]
+	if !ok {
+		return snapCLRef{}, fmt.Errorf("Gerrit CL %d is in a project that isn't in the corpus", cl.Number)
+	}
+	return snapCLRef{Project: i, Number: cl.Number}, nil
+}
+
+func (w *snapshotWriter) writeGerrit() error {
+	if w.c.gerrit == nil {
+		return nil
+	}
+	projects := w.sortedProjects()
+	w.projects = make(map[*GerritProject]int)
+	for _, gp := range projects {
+		sp := snapProject{
+			Proj:            gp.proj,
+			Need:            slices.Sorted(maps.Keys(gp.need)),
+			Commits:         slices.Sorted(maps.Keys(gp.commit)),
+			NumLabelChanges: gp.numLabelChanges,
+		}
+		clvs := slices.SortedFunc(maps.Keys(gp.remote), func(a, b gerritCLVersion) int {
+			return cmp.Or(cmp.Compare(a.CLNumber, b.CLNumber), cmp.Compare(a.Version, b.Version))
+		})
+		for _, clv := range clvs {
+			sp.Remote = append(sp.Remote, snapRemote{CLNumber: clv.CLNumber, Version: clv.Version, Hash: gp.remote[clv]})
+		}
+		for _, ref := range slices.Sorted(maps.Keys(gp.ref)) {
+			sp.Refs = append(sp.Refs, snapRef{Ref: ref, Hash: gp.ref[ref]})
+		}
+		for _, meta := range slices.Sorted(maps.Keys(gp.comments)) {
+			for _, c := range gp.comments[meta] {
+				sp.Comments = append(sp.Comments, snapGerritComment{
+					Meta:       meta,
+					UUID:       c.UUID,
+					ParentUUID: c.ParentUUID,
+					PatchSet:   c.PatchSet,
+					Revision:   c.Revision,
+					Filename:   c.Filename,
+					Line:       c.Line,
+					AuthorID:   c.AuthorID,
+					ServerID:   c.ServerID,
+					Written:    c.Written,
+					Message:    c.Message,
+					Unresolved: c.Unresolved,
+				})
+			}
+		}
+		w.batch.Projects = append(w.batch.Projects, sp)
+		w.projects[gp] = len(w.projects)
+		if err := w.added(1 + len(sp.Remote) + len(sp.Commits) + len(sp.Comments)); err != nil {
+			return err
+		}
+	}
+
+	for pi, gp := range projects {
+		for _, num := range slices.Sorted(maps.Keys(gp.cls)) {
+			cl := gp.cls[num]
+			scl := snapCL{
+				Project: pi,
+				Number:  cl.Number,
+				Created: encodeSnapTime(cl.Created),
+				Version: cl.Version,
+				Branch:  cl.branch,
+				Meta:    w.metas[cl.Meta],
+				Status:  cl.Status,
+				Private: cl.Private,
+			}
+			if cl.Commit != nil {
+				scl.Commit = cl.Commit.Hash
+			}
+			for _, m := range cl.Metas {
+				scl.Metas = append(scl.Metas, w.metas[m])
+			}
+			for _, ref := range cl.GitHubIssueRefs {
+				scl.GitHubIssueRefs = append(scl.GitHubIssueRefs, encodeIssueRef(ref))
+			}
+			for _, msg := range cl.Messages {
+				author, err := w.person(msg.Author)
+				if err != nil {
+					return err
+				}
+				scl.Messages = append(scl.Messages, snapMessage{
+					Meta:    msg.Meta.Hash,
+					Version: msg.Version,
+					Message: msg.Message,
+					Date:    encodeSnapTime(msg.Date),
+					Author:  author,
+				})
+			}
+			w.batch.CLs = append(w.batch.CLs, scl)
+			if err := w.added(1 + len(scl.Messages)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, m := range w.metaList {
+		ref, err := w.clRef(m.CL)
+		if err != nil {
+			return err
+		}
+		w.batch.Metas = append(w.batch.Metas, snapMeta{
+			ID:        i + 1,
+			Commit:    m.Commit.Hash,
+			CLProject: ref.Project,
+			CLNumber:  ref.Number,
+			Flags:     m.flags,
+		})
+		if err := w.added(1); err != nil {
+			return err
+		}
+	}
+
+	refs := slices.SortedFunc(maps.Keys(w.c.gerrit.clsReferencingGithubIssue), func(a, b GitHubIssueRef) int {
+		ra, rb := encodeIssueRef(a), encodeIssueRef(b)
+		return cmp.Or(cmp.Compare(ra.Owner, rb.Owner), cmp.Compare(ra.Repo, rb.Repo), cmp.Compare(ra.Number, rb.Number))
+	})
+	for _, ref := range refs {
+		sic := snapIssueCLs{Ref: encodeIssueRef(ref)}
+		for _, cl := range w.c.gerrit.clsReferencingGithubIssue[ref] {
+			r, err := w.clRef(cl)
+			if err != nil {
+				return err
+			}
+			sic.CLs = append(sic.CLs, r)
+		}
+		w.batch.IssueCLs = append(w.batch.IssueCLs, sic)
+		if err := w.added(1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotReader reads a corpus snapshot into a Corpus, which must not
+// have processed any mutations. Its mu must be held.
+type snapshotReader struct {
+	c   *Corpus
+	dec *gob.Decoder
+	hdr snapshotHeader
+
+	people   []*GitPerson
+	users    []*GitHubUser
+	teams    []*GitHubTeam
+	repos    []*GitHubRepo
+	projects []*GerritProject
+	metas    []*GerritMeta
+
+	// Objects that have been referred to, but not yet read.
+	pendingCommits map[GitHash]bool
+	pendingMetas   map[int]bool
+
+	err error // first error reading a batch
+}
+
+// newSnapshotReader reads the version line and header of the
+// snapshot in r.
+func newSnapshotReader(c *Corpus, r *bufio.Reader) (*snapshotReader, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot version: %v", err)
+	}
+	var version int
+	if _, err := fmt.Sscanf(line, snapshotMagic+" %d\n", &version); err != nil {
+		return nil, errors.New("not a maintner snapshot")
+	}
+	if version != snapshotVersion {
+		return nil, fmt.Errorf("snapshot has version %d; want %d", version, snapshotVersion)
+	}
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	sr := &snapshotReader{
+		c:              c,
+		dec:            gob.NewDecoder(zr),
+		pendingCommits: make(map[GitHash]bool),
+		pendingMetas:   make(map[int]bool),
+	}
+	if err := sr.dec.Decode(&sr.hdr); err != nil {
+		return nil, fmt.Errorf("reading snapshot header: %v", err)
+	}
+	return sr, nil
+}
+
+func (r *snapshotReader) fail(format string, args ...any) {
+	if r.err == nil {
+		r.err = fmt.Errorf(format, args...)
+	}
+}
+
+// read reads the rest of the snapshot into r.c.
+func (r *snapshotReader) read() error {
+	c := r.c
+	if len(c.gitCommit) > 0 {
+		return errors.New("corpus already has data")
+	}
+	if r.hdr.HasGitHub {
+		c.initGithub()
+	}
+	if r.hdr.HasGerrit {
+		c.initGerrit()
+	}
+	for {
+		var b snapshotBatch
+		if err := r.dec.Decode(&b); err != nil {
+			return fmt.Errorf("reading snapshot: %v", err)
+		}
+		r.readBatch(&b)
+		if r.err != nil {
+			return r.err
+		}
+		if b.End {
+			break
+		}
+	}
+	if len(r.pendingCommits) > 0 {
+		return fmt.Errorf("snapshot refers to %d git commits it doesn't contain", len(r.pendingCommits))
+	}
+	if len(r.pendingMetas) > 0 {
+		return fmt.Errorf("snapshot refers to %d Gerrit meta commits it doesn't contain", len(r.pendingMetas))
+	}
+	return nil
+}
+
+func (r *snapshotReader) readBatch(b *snapshotBatch) {
+	c := r.c
+	for _, s := range b.People {
+		p := &GitPerson{Str: s}
+		if c.gitPeople == nil {
+			c.gitPeople = map[string]*GitPerson{}
+		}
+		c.gitPeople[s] = p
+		r.people = append(r.people, p)
+	}
+	for _, sc := range b.Commits {
+		r.readCommit(sc)
+	}
+	for _, h := range b.GitTodo {
+		if c.gitCommitTodo == nil {
+			c.gitCommitTodo = map[GitHash]bool{}
+		}
+		c.gitCommitTodo[r.hash(h)] = true
+	}
+	for _, hg := range b.GitOfHg {
+		if c.gitOfHg == nil {
+			c.gitOfHg = map[string]GitHash{}
+		}
+		c.gitOfHg[hg.Hg] = r.hash(hg.Git)
+	}
+
+	if (len(b.Users) > 0 || len(b.Teams) > 0 || len(b.Repos) > 0) && c.github == nil {
+		r.fail("snapshot has GitHub data, but no GitHub header")
+		return
+	}
+	for _, u := range b.Users {
+		gu := &GitHubUser{ID: u.ID, Login: u.Login}
+		if c.github.users == nil {
+			c.github.users = make(map[int64]*GitHubUser)
+		}
+		c.github.users[u.ID] = gu
+		r.users = append(r.users, gu)
+	}
+	for _, t := range b.Teams {
+		gt := &GitHubTeam{ID: t.ID, Slug: t.Slug}
+		if c.github.teams == nil {
+			c.github.teams = make(map[int64]*GitHubTeam)
+		}
+		c.github.teams[t.ID] = gt
+		r.teams = append(r.teams, gt)
+	}
+	for _, sr := range b.Repos {
+		gr := c.github.getOrCreateRepo(sr.Owner, sr.Repo)
+		if gr == nil {
+			r.fail("invalid GitHub repo %s/%s", sr.Owner, sr.Repo)
+			return
+		}
+		for _, ms := range sr.Milestones {
+			*gr.getOrCreateMilestone(ms.ID) = ms
+		}
+		for _, lb := range sr.Labels {
+			*gr.getOrCreateLabel(lb.ID) = lb
+		}
+		r.repos = append(r.repos, gr)
+	}
+	for _, si := range b.Issues {
+		r.readIssue(si)
+	}
+	for _, sd := range b.Discussions {
+		r.readDiscussion(sd)
+	}
+
+	if (len(b.Projects) > 0 || len(b.IssueCLs) > 0) && c.gerrit == nil {
+		r.fail("snapshot has Gerrit data, but no Gerrit header")
+		return
+	}
+	for _, sp := range b.Projects {
+		r.readProject(sp)
+	}
+	for _, scl := range b.CLs {
+		r.readCL(scl)
+	}
+	for _, sm := range b.Metas {
+		m := r.meta(sm.ID)
+		if m == nil {
+			r.fail("invalid Gerrit meta ID %d", sm.ID)
+			continue
+		}
+		m.Commit = r.commit(sm.Commit)
+		m.CL = r.cl(snapCLRef{Project: sm.CLProject, Number: sm.CLNumber})
+		m.flags = sm.Flags
+		delete(r.pendingMetas, sm.ID)
+	}
+	for _, sic := range b.IssueCLs {
+		ref := r.issueRef(sic.Ref)
+		for _, clr := range sic.CLs {
+			c.gerrit.clsReferencingGithubIssue[ref] = append(c.gerrit.clsReferencingGithubIssue[ref], r.cl(clr))
+		}
+	}
+}
+
+// hash returns the interned copy of h.
+func (r *snapshotReader) hash(h GitHash) GitHash {
+	return GitHash(r.c.str(string(h)))
+}
+
+// commit returns the git commit with hash h, which is filled in when
+// it's read if it hasn't been already.
+func (r *snapshotReader) commit(h GitHash) *GitCommit {
+	c := r.c
+	if gc, ok := c.gitCommit[h]; ok {
+		return gc
+	}
+	if c.gitCommit == nil {
+		c.gitCommit = map[GitHash]*GitCommit{}
+	}
+	h = r.hash(h)
+	gc := &GitCommit{Hash: h}
+	c.gitCommit[h] = gc
+	r.pendingCommits[h] = true
+	return gc
+}
+
+// meta returns the Gerrit meta commit with the given ID, which is
+// filled in when it's read if it hasn't been already.
+func (r *snapshotReader) meta(id int) *GerritMeta {
+	if id <= 0 {
+		return nil
+	}
+	if id > len(r.metas) {
+		if id-len(r.metas) > 1<<20 {
+			// Don't let a corrupt ID run us out of memory.
+			r.fail("invalid Gerrit meta ID %d", id)
+			return nil
+		}
+		r.metas = append(r.metas, make([]*GerritMeta, id-len(r.metas))...)
+	}
+	m := r.metas[id-1]
+	if m == nil {
+		m = new(GerritMeta)
+		r.metas[id-1] = m
+		r.pendingMetas[id] = true
+	}
+	return m
+}
+
+func (r *snapshotReader) person(i int) *GitPerson {
+	if i == 0 {
+		return nil
+	}
+	if i < 0 || i > len(r.people) {
+		r.fail("invalid git person index %d", i)
+		return nil
+	}
+	return r.people[i-1]
+}
+
+func (r *snapshotReader) user(i int) *GitHubUser {
+	if i == 0 {
+		return nil
+	}
+	if i < 0 || i > len(r.users) {
+		r.fail("invalid GitHub user index %d", i)
+		return nil
+	}
+	return r.users[i-1]
+}
+
+func (r *snapshotReader) team(i int) *GitHubTeam {
+	if i == 0 {
+		return nil
+	}
+	if i < 0 || i > len(r.teams) {
+		r.fail("invalid GitHub team index %d", i)
+		return nil
+	}
+	return r.teams[i-1]
+}
+
+func (r *snapshotReader) cl(ref snapCLRef) *GerritCL {
+	if ref.Project < 0 || ref.Project >= len(r.projects) {
+		r.fail("invalid Gerrit project index %d", ref.Project)
+		return nil
+	}
+	return r.projects[ref.Project].getOrCreateCL(ref.Number)
+}
+
+func (r *snapshotReader) time(t snapTime) time.Time {
+	if t.Zone == "" {
+		return time.Time{}
+	}
+	if len(t.Zone) != len("+0000") || (t.Zone[0] != '+' && t.Zone[0] != '-') {
+		r.fail("invalid git time zone %q", t.Zone)
+		return time.Time{}
+	}
+	return time.Unix(t.Unix, 0).In(r.c.gitLocation([]byte(t.Zone)))
+}
+
+func (r *snapshotReader) issueRef(sr snapIssueRef) GitHubIssueRef {
+	ref := GitHubIssueRef{Number: sr.Number}
+	if sr.Owner != "" || sr.Repo != "" {
+		r.c.initGithub()
+		ref.Repo = r.c.github.getOrCreateRepo(sr.Owner, sr.Repo)
+	}
+	return ref
+}
+
+func (r *snapshotReader) readCommit(sc snapCommit) {
+	c := r.c
+	gc := r.commit(sc.Hash)
+	if !r.pendingCommits[gc.Hash] {
+		r.fail("duplicate git commit %v", sc.Hash)
+		return
+	}
+	delete(r.pendingCommits, gc.Hash)
+	*gc = GitCommit{
+		Hash:       gc.Hash,
+		Author:     r.person(sc.Author),
+		AuthorTime: r.time(sc.AuthorTime),
+		Committer:  r.person(sc.Committer),
+		CommitTime: r.time(sc.CommitTime),
+		Msg:        c.str(sc.Msg),
+		GerritMeta: r.meta(sc.GerritMeta),
+	}
+	if sc.Tree != "" {
+		gc.Tree = r.hash(sc.Tree)
+	}
+	if sc.Placeholder {
+		gc.Committer = placeholderCommitter
+	}
+	if sc.Reviewer != "" {
+		gc.Reviewer = &GitPerson{Str: sc.Reviewer}
+	}
+	if !sc.Placeholder {
+		gc.Parents = make([]*GitCommit, 0, len(sc.Parents))
+	}
+	for _, p := range sc.Parents {
+		gc.Parents = append(gc.Parents, r.commit(p))
+	}
+	for _, f := range sc.Files {
+		gc.Files = append(gc.Files, &maintpb.GitDiffTreeFile{File: c.str(f.File), Added: f.Added, Deleted: f.Deleted, Binary: f.Binary})
+	}
+}
+
+func (r *snapshotReader) readIssue(si snapIssue) {
+	if si.Repo < 0 || si.Repo >= len(r.repos) {
+		r.fail("invalid GitHub repo index %d", si.Repo)
+		return
+	}
+	gr := r.repos[si.Repo]
+	gi := &GitHubIssue{
+		ID:                 si.ID,
+		Number:             si.Number,
+		NotExist:           si.NotExist,
+		Closed:             si.Closed,
+		Locked:             si.Locked,
+		PullRequest:        si.PullRequest,
+		User:               r.user(si.User),
+		Created:            si.Created,
+		Updated:            si.Updated,
+		ClosedAt:           si.ClosedAt,
+		ClosedBy:           r.user(si.ClosedBy),
+		Title:              si.Title,
+		Body:               si.Body,
+		commentsUpdatedTil: si.CommentsUpdatedTil,
+		commentsSyncedAsOf: si.CommentsSyncedAsOf,
+		eventMaxTime:       si.EventMaxTime,
+		eventsSyncedAsOf:   si.EventsSyncedAsOf,
+		reviewsSyncedAsOf:  si.ReviewsSyncedAsOf,
+
+		headSHA:             si.HeadSHA,
+		filesTruncated:      si.FilesTruncated,
+		filesSyncedAsOf:     si.FilesSyncedAsOf,
+		checkRunsSyncedAsOf: si.CheckRunsSyncedAsOf,
+	}
+	for _, u := range si.Assignees {
+		gi.Assignees = append(gi.Assignees, r.user(u))
+	}
+	for _, f := range si.Files {
+		gi.files = append(gi.files, &f)
+	}
+	for _, cr := range si.CheckRuns {
+		if gi.checkRuns == nil {
+			gi.checkRuns = make(map[int64]*GitHubCheckRun)
+		}
+		gi.checkRuns[cr.ID] = &cr
+	}
+	switch si.Milestone {
+	case 0:
+	case -1:
+		gi.Milestone = noMilestone
+	default:
+		gi.Milestone = gr.getOrCreateMilestone(si.Milestone)
+	}
+	for _, id := range si.Labels {
+		if gi.Labels == nil {
+			gi.Labels = make(map[int64]*GitHubLabel)
+		}
+		if id == 0 {
+			r.fail("invalid GitHub label ID 0")
+			return
+		}
+		gi.Labels[id] = gr.getOrCreateLabel(id)
+	}
+	for _, sc := range si.Comments {
+		if gi.comments == nil {
+			gi.comments = make(map[int64]*GitHubComment)
+		}
+		gi.comments[sc.ID] = &GitHubComment{
+			ID:      sc.ID,
+			User:    r.user(sc.User),
+			Created: sc.Created,
+			Updated: sc.Updated,
+			Body:    sc.Body,
+		}
+	}
+	for _, se := range si.Events {
+		if gi.events == nil {
+			gi.events = make(map[int64]*GitHubIssueEvent)
+		}
+		gi.events[se.ID] = &GitHubIssueEvent{
+			ID:              se.ID,
+			Type:            se.Type,
+			OtherJSON:       se.OtherJSON,
+			Created:         se.Created,
+			Actor:           r.user(se.Actor),
+			Label:           r.c.str(se.Label),
+			Assignee:        r.user(se.Assignee),
+			Assigner:        r.user(se.Assigner),
+			Milestone:       r.c.str(se.Milestone),
+			From:            se.From,
+			To:              se.To,
+			CommitID:        se.CommitID,
+			CommitURL:       se.CommitURL,
+			Reviewer:        r.user(se.Reviewer),
+			TeamReviewer:    r.team(se.TeamReviewer),
+			ReviewRequester: r.user(se.ReviewRequester),
+			DismissedReview: se.DismissedReview,
+		}
+	}
+	for _, sr := range si.Reviews {
+		if gi.reviews == nil {
+			gi.reviews = make(map[int64]*GitHubReview)
+		}
+		gi.reviews[sr.ID] = &GitHubReview{
+			ID:               sr.ID,
+			Actor:            r.user(sr.Actor),
+			Body:             sr.Body,
+			State:            sr.State,
+			CommitID:         sr.CommitID,
+			ActorAssociation: sr.ActorAssociation,
+			Created:          sr.Created,
+			OtherJSON:        sr.OtherJSON,
+		}
+	}
+	gr.issues[gi.Number] = gi
+}
+
+func (r *snapshotReader) readDiscussion(sd snapDiscussion) {
+	if sd.Repo < 0 || sd.Repo >= len(r.repos) {
+		r.fail("invalid GitHub repo index %d", sd.Repo)
+		return
+	}
+	gr := r.repos[sd.Repo]
+	d := &GitHubDiscussion{
+		ID:                 sd.ID,
+		Number:             sd.Number,
+		User:               r.user(sd.User),
+		Created:            sd.Created,
+		Updated:            sd.Updated,
+		Title:              sd.Title,
+		Body:               sd.Body,
+		Category:           r.c.str(sd.Category),
+		Closed:             sd.Closed,
+		Locked:             sd.Locked,
+		commentsSyncedAsOf: sd.CommentsSyncedAsOf,
+	}
+	for _, sc := range sd.Comments {
+		if d.comments == nil {
+			d.comments = make(map[int64]*GitHubDiscussionComment)
+		}
+		d.comments[sc.ID] = &GitHubDiscussionComment{
+			ID:        sc.ID,
+			ReplyToID: sc.ReplyToID,
+			User:      r.user(sc.User),
+			Created:   sc.Created,
+			Updated:   sc.Updated,
+			Body:      sc.Body,
+			IsAnswer:  sc.IsAnswer,
+		}
+	}
+	if gr.discussions == nil {
+		gr.discussions = make(map[int32]*GitHubDiscussion)
+	}
+	gr.discussions[d.Number] = d
+}
+
+func (r *snapshotReader) readProject(sp snapProject) {
+	gp := r.c.gerrit.getOrCreateProject(sp.Proj)
+	for _, rm := range sp.Remote {
+		gp.remote[gerritCLVersion{rm.CLNumber, rm.Version}] = r.hash(rm.Hash)
+	}
+	for _, h := range sp.Need {
+		gp.need[r.hash(h)] = true
+	}
+	for _, h := range sp.Commits {
+		gc := r.commit(h)
+		gp.commit[gc.Hash] = gc
+	}
+	for _, ref := range sp.Refs {
+		gp.ref[ref.Ref] = r.hash(ref.Hash)
+	}
+	gp.numLabelChanges = sp.NumLabelChanges
+	for _, sc := range sp.Comments {
+		meta := r.commit(sc.Meta)
+		if gp.comments == nil {
+			gp.comments = make(map[GitHash][]*GerritComment)
+		}
+		gc := &GerritComment{
+			Meta:       meta,
+			UUID:       sc.UUID,
+			ParentUUID: sc.ParentUUID,
+			PatchSet:   sc.PatchSet,
+			Filename:   r.c.str(sc.Filename),
+			Line:       sc.Line,
+			AuthorID:   sc.AuthorID,
+			ServerID:   r.c.str(sc.ServerID),
+			Written:    sc.Written,
+			Message:    sc.Message,
+			Unresolved: sc.Unresolved,
+		}
+		if sc.Revision != "" {
+			gc.Revision = r.hash(sc.Revision)
+		}
+		gp.comments[meta.Hash] = append(gp.comments[meta.Hash], gc)
+	}
+	r.projects = append(r.projects, gp)
+}
+
+func (r *snapshotReader) readCL(scl snapCL) {
+	cl := r.cl(snapCLRef{Project: scl.Project, Number: scl.Number})
+	if cl == nil {
+		return
+	}
+	cl.Created = r.time(scl.Created)
+	cl.Version = scl.Version
+	if scl.Commit != "" {
+		cl.Commit = r.commit(scl.Commit)
+	}
+	cl.branch = scl.Branch
+	cl.Meta = r.meta(scl.Meta)
+	for _, id := range scl.Metas {
+		cl.Metas = append(cl.Metas, r.meta(id))
+	}
+	cl.Status = scl.Status
+	cl.Private = scl.Private
+	for _, ref := range scl.GitHubIssueRefs {
+		cl.GitHubIssueRefs = append(cl.GitHubIssueRefs, r.issueRef(ref))
+	}
+	for _, sm := range scl.Messages {
+		cl.Messages = append(cl.Messages, &GerritMessage{
+			Meta:    r.commit(sm.Meta),
+			Version: sm.Version,
+			Message: sm.Message,
+			Date:    r.time(sm.Date),
+			Author:  r.person(sm.Author),
+		})
+	}
+}
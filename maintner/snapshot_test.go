@@ -0,0 +1,330 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/build/maintner/maintpb"
+)
+
+// snapshotTestCommit returns a git commit with the given hash, parents
+// and message, with its parts in the order that git writes them.
+func snapshotTestCommit(hash, msg string, parents ...string) *maintpb.GitCommit {
+	var raw bytes.Buffer
+	fmt.Fprintf(&raw, "tree %040x\n", len(msg))
+	for _, p := range parents {
+		fmt.Fprintf(&raw, "parent %s\n", p)
+	}
+	raw.WriteString("author Gopher <gopher@golang.org> 1488624439 +0900\n")
+	raw.WriteString("committer Gerrit Code Review <noreply-gerritcodereview@google.com> 1488624500 -0700\n")
+	fmt.Fprintf(&raw, "\n%s", msg)
+	return &maintpb.GitCommit{
+		Sha1: hash,
+		Raw:  raw.Bytes(),
+		DiffTree: &maintpb.GitDiffTree{File: []*maintpb.GitDiffTreeFile{
+			{File: "README", Added: 1, Deleted: 2},
+		}},
+	}
+}
+
+func snapshotTestMutations() (head, tail []*maintpb.Mutation) {
+	const (
+		base   = "1000000000000000000000000000000000000000"
+		change = "2000000000000000000000000000000000000000"
+		meta1  = "3000000000000000000000000000000000000000"
+		meta2  = "4000000000000000000000000000000000000000"
+		meta3  = "5000000000000000000000000000000000000000"
+		other  = "6000000000000000000000000000000000000000"
+	)
+	const proj = "go.googlesource.com/build"
+	gerrit := func(commits []*maintpb.GitCommit, refs ...string) *maintpb.Mutation {
+		m := &maintpb.GerritMutation{Project: proj, Commits: commits}
+		for i := 0; i < len(refs); i += 2 {
+			m.Refs = append(m.Refs, &maintpb.GitRef{Ref: refs[i], Sha1: refs[i+1]})
+		}
+		return &maintpb.Mutation{Gerrit: m}
+	}
+	issue := &maintpb.GithubIssueMutation{
+		Owner:          "golang",
+		Repo:           "go",
+		Number:         1,
+		Id:             1001,
+		User:           &maintpb.GithubUser{Id: 100, Login: "gopherbot"},
+		Title:          "x/build: snapshots",
+		Body:           "Loading the corpus is slow.",
+		Created:        tp1,
+		Updated:        tp1,
+		MilestoneId:    7,
+		MilestoneNum:   1,
+		MilestoneTitle: "Go1.24",
+		AddLabel:       []*maintpb.GithubLabel{{Id: 9, Name: "NeedsFix"}},
+		Comment: []*maintpb.GithubIssueCommentMutation{
+			{Id: 5, User: &maintpb.GithubUser{Id: 101, Login: "kevinburke"}, Body: "+1", Created: tp1, Updated: tp1},
+		},
+		Event: []*maintpb.GithubIssueEvent{
+			{Id: 6, EventType: "labeled", ActorId: 100, Created: tp1, Label: &maintpb.GithubLabel{Name: "NeedsFix"}},
+		},
+	}
+	pr := &maintpb.GithubIssueMutation{
+		Owner:       "golang",
+		Repo:        "go",
+		Number:      3,
+		User:        &maintpb.GithubUser{Id: 100, Login: "gopherbot"},
+		Title:       "net/http: fix a bug",
+		Created:     tp2,
+		Updated:     tp2,
+		PullRequest: true,
+		HeadSha:     change,
+		Files: &maintpb.GithubPullRequestFiles{File: []*maintpb.GithubPullRequestFile{
+			{Filename: "src/net/http/server.go", Status: "modified", Additions: 3, Deletions: 1},
+			{Filename: "src/net/http/serve_test.go", Status: "renamed", PreviousFilename: "src/net/http/server_test.go"},
+		}},
+		FileStatus: &maintpb.GithubIssueSyncStatus{ServerDate: tp2},
+		CheckRun: []*maintpb.GithubCheckRun{
+			{Id: 20, HeadSha: change, Name: "linux-amd64", App: "github-actions", Status: "in_progress", Started: tp2},
+		},
+		CheckRunStatus: &maintpb.GithubIssueSyncStatus{ServerDate: tp2},
+	}
+	discussion := &maintpb.GithubDiscussionMutation{
+		Owner:    "golang",
+		Repo:     "go",
+		Number:   8,
+		Id:       3001,
+		User:     &maintpb.GithubUser{Id: 101, Login: "kevinburke"},
+		Created:  tp1,
+		Updated:  tp1,
+		Title:    "How do I load a snapshot?",
+		Body:     &maintpb.StringChange{Val: "Asking for a friend."},
+		Category: "Q&A",
+		Comment: []*maintpb.GithubDiscussionComment{
+			{Id: 31, User: &maintpb.GithubUser{Id: 100, Login: "gopherbot"}, Created: tp1, Updated: tp1, Body: &maintpb.StringChange{Val: "Use UseSnapshot."}},
+		},
+		CommentStatus: &maintpb.GithubIssueSyncStatus{ServerDate: tp1},
+	}
+	head = []*maintpb.Mutation{
+		{Git: &maintpb.GitMutation{Commit: snapshotTestCommit(other, "unrelated\n", base)}},
+		{GithubIssue: issue},
+		{GithubIssue: pr},
+		{GithubDiscussion: discussion},
+		gerrit([]*maintpb.GitCommit{
+			snapshotTestCommit(base, "initial commit\n"),
+			snapshotTestCommit(change, "all: add snapshots\n\nFixes golang/go#1\n", base),
+			snapshotTestCommit(meta1, "Create change\n\nUploaded patch set 1.\n\nPatch-set: 1\nSubject: all: add snapshots\nBranch: refs/heads/master\nStatus: new\n"),
+			snapshotTestCommit(meta2, "Update patch set 1\n\nPatch Set 1: Code-Review+2\n\nLooks good.\n\nPatch-set: 1\nReviewer: Gopher <1@62eb7196-b449-3ce5-99f1-c037f21e1705>\nLabel: Code-Review=+2\nAttention: {\"person_ident\":\"Gerrit User 2 <2@62eb7196-b449-3ce5-99f1-c037f21e1705>\",\"operation\":\"ADD\",\"reason\":\"reviewer replied\"}\n", meta1),
+		}, "refs/heads/master", base, "refs/changes/01/1/1", change, "refs/changes/01/1/meta", meta2),
+	}
+	head[len(head)-1].Gerrit.Comments = []*maintpb.GerritComment{
+		{MetaSha1: meta2, Uuid: "c1", PatchSet: 1, RevId: change, Filename: "maintner/snapshot.go", Line: 10, AuthorId: 1, ServerId: "62eb7196-b449-3ce5-99f1-c037f21e1705", Written: tp1, Message: "Typo.", Unresolved: true},
+		{MetaSha1: meta2, Uuid: "c2", PatchSet: 1, RevId: change, Filename: "/COMMIT_MSG", AuthorId: 1, ServerId: "62eb7196-b449-3ce5-99f1-c037f21e1705", Written: tp1, Message: "Nice."},
+	}
+	tail = []*maintpb.Mutation{
+		{GithubIssue: &maintpb.GithubIssueMutation{
+			Owner:   "golang",
+			Repo:    "go",
+			Number:  1,
+			Updated: tp2,
+			Closed:  &maintpb.BoolChange{Val: true},
+			Review: []*maintpb.GithubReview{
+				{Id: 8, ActorId: 101, Body: "LGTM", State: "APPROVED", Created: tp2},
+			},
+		}},
+		{GithubIssue: &maintpb.GithubIssueMutation{
+			Owner:  "golang",
+			Repo:   "go",
+			Number: 3,
+			CheckRun: []*maintpb.GithubCheckRun{
+				{Id: 20, HeadSha: change, Name: "linux-amd64", App: "github-actions", Status: "completed", Conclusion: "success", Started: tp2, Completed: tp2},
+			},
+			CheckRunStatus: &maintpb.GithubIssueSyncStatus{ServerDate: tp2},
+		}},
+		{GithubDiscussion: &maintpb.GithubDiscussionMutation{
+			Owner:   "golang",
+			Repo:    "go",
+			Number:  8,
+			Updated: tp2,
+			Closed:  &maintpb.BoolChange{Val: true},
+			Comment: []*maintpb.GithubDiscussionComment{
+				{Id: 31, IsAnswer: &maintpb.BoolChange{Val: true}},
+				{Id: 32, ReplyToId: 31, User: &maintpb.GithubUser{Id: 101, Login: "kevinburke"}, Created: tp2, Updated: tp2, Body: &maintpb.StringChange{Val: "Thanks!"}},
+			},
+			CommentStatus: &maintpb.GithubIssueSyncStatus{ServerDate: tp2},
+		}},
+		gerrit([]*maintpb.GitCommit{
+			snapshotTestCommit(meta3, "Update patch set 1\n\nChange has been successfully merged\n\nPatch-set: 1\nStatus: merged\n", meta2),
+		}, "refs/changes/01/1/meta", meta3, "refs/heads/master", change),
+	}
+	return head, tail
+}
+
+func logMutations(t *testing.T, dir string, muts []*maintpb.Mutation) {
+	t.Helper()
+	logger := NewDiskMutationLogger(dir)
+	for _, m := range muts {
+		if err := logger.Log(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func initializeFromDisk(t *testing.T, dir, snapshot string) *Corpus {
+	t.Helper()
+	c := new(Corpus)
+	if snapshot != "" {
+		c.UseSnapshot(snapshot)
+	}
+	if err := c.Initialize(context.Background(), NewDiskMutationLogger(dir)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Check(); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	return c
+}
+
+func snapshotBytes(t *testing.T, c *Corpus) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := c.WriteSnapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSnapshot(t *testing.T) {
+	head, tail := snapshotTestMutations()
+	logDir := t.TempDir()
+	snapshot := filepath.Join(t.TempDir(), "corpus.snapshot")
+
+	// With no snapshot, Initialize replays the whole log and saves one.
+	logMutations(t, logDir, head)
+	c := initializeFromDisk(t, logDir, snapshot)
+	if c.replayed != len(head) {
+		t.Errorf("first Initialize replayed %d mutations, want %d", c.replayed, len(head))
+	}
+	if _, err := os.Stat(snapshot); err != nil {
+		t.Fatalf("Initialize didn't save a snapshot: %v", err)
+	}
+
+	// With one, it loads it and replays only the tail, ending up in
+	// the same state as replaying everything.
+	logMutations(t, logDir, tail)
+	resumed := initializeFromDisk(t, logDir, snapshot)
+	if resumed.replayed != len(tail) {
+		t.Errorf("resumed Initialize replayed %d mutations, want %d", resumed.replayed, len(tail))
+	}
+	full := initializeFromDisk(t, logDir, "")
+	// NumLabelChanges overcounts by an amount that depends on how the
+	// log was split into updates.
+	for _, c := range []*Corpus{resumed, full} {
+		c.Gerrit().Project("go.googlesource.com", "build").numLabelChanges = 0
+	}
+	if !bytes.Equal(snapshotBytes(t, resumed), snapshotBytes(t, full)) {
+		t.Errorf("corpus loaded from snapshot and tail differs from corpus loaded from whole log")
+	}
+
+	gi := resumed.GitHub().Repo("golang", "go").Issue(1)
+	if gi == nil || gi.Title != "x/build: snapshots" || !gi.Closed || gi.Milestone.Title != "Go1.24" || !gi.HasLabel("NeedsFix") {
+		t.Errorf("issue golang/go#1 = %+v, want the closed issue from the log", gi)
+	}
+	if !gi.HasEvent("labeled") {
+		t.Errorf("issue golang/go#1 lost its labeled event")
+	}
+	pr := resumed.GitHub().Repo("golang", "go").Issue(3)
+	var files, runs []string
+	pr.ForeachFile(func(f *GitHubPullRequestFile) error {
+		files = append(files, f.Filename)
+		return nil
+	})
+	pr.ForeachCheckRun(func(cr *GitHubCheckRun) error {
+		runs = append(runs, cr.Name+":"+cr.Conclusion)
+		return nil
+	})
+	if pr.HeadSHA() != "2000000000000000000000000000000000000000" || fmt.Sprint(files) != "[src/net/http/serve_test.go src/net/http/server.go]" || fmt.Sprint(runs) != "[linux-amd64:success]" {
+		t.Errorf("pull request golang/go#3 has head %q, files %v and check runs %v", pr.HeadSHA(), files, runs)
+	}
+	d := resumed.GitHub().Repo("golang", "go").Discussion(8)
+	if d == nil || !d.Closed || d.Category != "Q&A" || d.Answer() == nil || d.Answer().Body != "Use UseSnapshot." {
+		t.Errorf("discussion golang/go#8 = %+v, want the closed, answered discussion from the log", d)
+	}
+
+	cl := resumed.Gerrit().Project("go.googlesource.com", "build").CL(1)
+	if cl == nil {
+		t.Fatal("CL 1 is missing")
+	}
+	if cl.Status != "merged" || len(cl.Metas) != 3 || len(cl.Messages) != 1 || cl.Branch() != "master" {
+		t.Errorf("CL 1 has status %q, %d metas, %d messages and branch %q; want merged, 3, 1 and master", cl.Status, len(cl.Metas), len(cl.Messages), cl.Branch())
+	}
+	var comments []string
+	cl.ForeachComment(func(c *GerritComment) error {
+		comments = append(comments, fmt.Sprintf("%s:%d:%s", c.Filename, c.Line, c.Message))
+		return nil
+	})
+	if got, want := fmt.Sprint(comments), "[maintner/snapshot.go:10:Typo. /COMMIT_MSG:0:Nice.]"; got != want {
+		t.Errorf("CL 1 comments = %v, want %v", got, want)
+	}
+	if n := cl.UnresolvedCommentCount(); n != 1 {
+		t.Errorf("CL 1 has %d unresolved comments, want 1", n)
+	}
+	if got, want := fmt.Sprint(cl.AttentionSet()), "[2@62eb7196-b449-3ce5-99f1-c037f21e1705]"; got != want {
+		t.Errorf("CL 1 attention set = %v, want %v", got, want)
+	}
+	if got := cl.Messages[0].Message; got != "Patch Set 1: Code-Review+2\n\nLooks good." {
+		t.Errorf("CL 1 first message = %q", got)
+	}
+	if len(cl.GitHubIssueRefs) != 1 || cl.GitHubIssueRefs[0].Repo != resumed.GitHub().Repo("golang", "go") {
+		t.Errorf("CL 1 GitHubIssueRefs = %v, want golang/go#1", cl.GitHubIssueRefs)
+	}
+	if cl.Commit.Parents[0] != resumed.GitCommit("1000000000000000000000000000000000000000") {
+		t.Errorf("CL 1 commit's parent isn't the corpus's copy of the commit")
+	}
+	if got, want := cl.Commit.AuthorTime.Format("2006-01-02 15:04:05 -0700 MST"), "2017-03-04 19:47:19 +0900 +0900"; got != want {
+		t.Errorf("CL 1 commit author time = %s, want %s", got, want)
+	}
+}
+
+func TestSnapshotFallback(t *testing.T) {
+	head, tail := snapshotTestMutations()
+	all := append(head, tail...)
+	logDir := t.TempDir()
+	snapshot := filepath.Join(t.TempDir(), "corpus.snapshot")
+	logMutations(t, logDir, all)
+
+	// Snapshots that can't be used are ignored, and replaced.
+	for _, contents := range []string{
+		"garbage",
+		fmt.Sprintf("%s %d\n", snapshotMagic, snapshotVersion+1),
+		fmt.Sprintf("%s %d\ntruncated", snapshotMagic, snapshotVersion),
+	} {
+		if err := os.WriteFile(snapshot, []byte(contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+		c := initializeFromDisk(t, logDir, snapshot)
+		if c.replayed != len(all) {
+			t.Errorf("with snapshot %q, Initialize replayed %d mutations, want %d", contents, c.replayed, len(all))
+		}
+	}
+
+	// So is a snapshot of a log that has since been replaced.
+	if err := os.RemoveAll(logDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(logDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	logMutations(t, logDir, head)
+	c := initializeFromDisk(t, logDir, snapshot)
+	if c.replayed != len(head) {
+		t.Errorf("with a snapshot of a longer log, Initialize replayed %d mutations, want %d", c.replayed, len(head))
+	}
+	if cl := c.Gerrit().Project("go.googlesource.com", "build").CL(1); cl.Status != "new" {
+		t.Errorf("CL 1 has status %q from the stale snapshot, want new", cl.Status)
+	}
+}
@@ -454,28 +454,36 @@ func checkRelativeURL(s string) error {
 // requests. If the response indicates successful switch, nothing is returned.
 // If the response indicates a redirect, the new location is returned.
 func ReadProtoSwitchOrRedirect(r *bufio.Reader, req *http.Request) (location string, err error) {
+	_, location, err = ReadProtoSwitchResponse(r, req)
+	return location, err
+}
+
+// ReadProtoSwitchResponse is like ReadProtoSwitchOrRedirect, but also
+// returns the response if it indicates a successful switch, so that the
+// caller can look at its header.
+func ReadProtoSwitchResponse(r *bufio.Reader, req *http.Request) (switched *http.Response, location string, err error) {
 	resp, err := http.ReadResponse(r, req)
 	if err != nil {
-		return "", fmt.Errorf("error reading response: %v", err)
+		return nil, "", fmt.Errorf("error reading response: %v", err)
 	}
 	switch resp.StatusCode {
 	case http.StatusSwitchingProtocols:
 		// Success! Don't read body, as caller may want it.
-		return "", nil
+		return resp, "", nil
 	case http.StatusTemporaryRedirect:
 		// Redirect. Discard body.
 		msg, _ := io.ReadAll(resp.Body)
 		location := resp.Header.Get("Location")
 		if location == "" {
-			return "", fmt.Errorf("redirect missing Location header; got %+v:\n\t%s", resp, msg)
+			return nil, "", fmt.Errorf("redirect missing Location header; got %+v:\n\t%s", resp, msg)
 		}
 		if err := checkRelativeURL(location); err != nil {
-			return "", fmt.Errorf("redirect Location must be relative: %w", err)
+			return nil, "", fmt.Errorf("redirect Location must be relative: %w", err)
 		}
 		// Retry at new location.
-		return location, nil
+		return nil, location, nil
 	default:
 		msg, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("want HTTP status 101 or 307; got %v:\n\t%s", resp.Status, msg)
+		return nil, "", fmt.Errorf("want HTTP status 101 or 307; got %v:\n\t%s", resp.Status, msg)
 	}
 }